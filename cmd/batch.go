@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+// batchOperation is a single line of a batch file. Which fields are used depends on Op:
+//   - create:  input (natural language), optional type
+//   - search:  jql, optional max_results
+//   - comment: key, body
+type batchOperation struct {
+	Op         string `json:"op"`
+	Input      string `json:"input,omitempty"`
+	Type       string `json:"type,omitempty"`
+	JQL        string `json:"jql,omitempty"`
+	MaxResults int    `json:"max_results,omitempty"`
+	Key        string `json:"key,omitempty"`
+	Body       string `json:"body,omitempty"`
+}
+
+// batchResult is emitted as one JSON line per operation, in input order.
+type batchResult struct {
+	Line   int         `json:"line"`
+	Op     string      `json:"op"`
+	OK     bool        `json:"ok"`
+	Result interface{} `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// batchCreateResult is the result payload of a create operation.
+type batchCreateResult struct {
+	*mcpclient.CreateIssueResponse
+	// Replayed is set if the issue had been created earlier from the same input.
+	Replayed bool `json:"replayed,omitempty"`
+}
+
+// batchRunner executes batch operations using the same dependencies as the create command.
+type batchRunner struct {
+	create *createCmdRunner
+
+	cfgOnce sync.Once
+	cfgs    *loadedConfigs
+	cfgErr  error
+}
+
+// configs lazily loads the create configuration once, only if a create operation is present.
+func (b *batchRunner) configs() (*loadedConfigs, error) {
+	b.cfgOnce.Do(func() {
+		b.cfgs, b.cfgErr = loadAllConfigs(b.create.configProvider)
+	})
+	return b.cfgs, b.cfgErr
+}
+
+// execute runs a single operation and returns its result payload.
+func (b *batchRunner) execute(ctx context.Context, op batchOperation) (interface{}, error) {
+	mcpClient := b.create.mcpClient
+	switch op.Op {
+	case "create":
+		if strings.TrimSpace(op.Input) == "" {
+			return nil, errors.New("create requires 'input'")
+		}
+		cfgs, err := b.configs()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load configuration: %w", err)
+		}
+		var hints bytes.Buffer
+		request, err := b.create.buildIssueRequest(ctx, &hints, cfgs, op.Input, issueRequestOptions{issueType: op.Type})
+		if err != nil {
+			return nil, withHints(err, hints.String())
+		}
+		if mcpClient == nil {
+			return nil, errMCPClientNotInitialized
+		}
+		request, resp, err := b.create.submitIssue(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		b.create.recordHistory("batch", op.Input, cfgs.systemPrompt, request, resp)
+		return batchCreateResult{CreateIssueResponse: resp, Replayed: resp.Replayed}, nil
+
+	case "search":
+		if strings.TrimSpace(op.JQL) == "" {
+			return nil, errors.New("search requires 'jql'")
+		}
+		if mcpClient == nil {
+			return nil, errMCPClientNotInitialized
+		}
+		maxResults := op.MaxResults
+		if maxResults <= 0 {
+			maxResults = 20 // Same default as the search command
+		}
+		return mcpClient.SearchIssues(ctx, mcpclient.SearchIssuesRequest{JQL: op.JQL, MaxResults: maxResults})
+
+	case "comment":
+		if strings.TrimSpace(op.Key) == "" || strings.TrimSpace(op.Body) == "" {
+			return nil, errors.New("comment requires 'key' and 'body'")
+		}
+		if mcpClient == nil {
+			return nil, errMCPClientNotInitialized
+		}
+		return mcpClient.AddComment(ctx, op.Key, mcpclient.AddCommentRequest{Body: expandMentions(ctx, mcpClient, op.Body)})
+
+	case "":
+		return nil, errors.New("missing 'op'")
+	default:
+		return nil, fmt.Errorf("unsupported op %q (expected create, search or comment)", op.Op)
+	}
+}
+
+// batchRunE reads operations from in (JSON Lines, blank lines and lines starting with '#' are skipped),
+// executes them with up to parallel workers and writes one JSON result per operation to out,
+// always in input order. It returns an error if any operation failed.
+func batchRunE(ctx context.Context, runner *batchRunner, in io.Reader, out io.Writer, parallel int) error {
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	type job struct {
+		index int
+		line  int
+		op    batchOperation
+		err   error // Set if the line could not be parsed
+	}
+
+	var jobs []job
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		j := job{index: len(jobs), line: lineNo}
+		if err := json.Unmarshal([]byte(text), &j.op); err != nil {
+			j.err = fmt.Errorf("invalid JSON: %w", err)
+		}
+		jobs = append(jobs, j)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read batch input: %w", err)
+	}
+	Log.Info().Int("operations", len(jobs)).Int("parallel", parallel).Msg("Executing batch")
+
+	results := make([]*batchResult, len(jobs))
+	done := make([]chan struct{}, len(jobs))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+
+	work := make(chan job)
+	var wg sync.WaitGroup
+	for w := 0; w < parallel; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range work {
+				res := &batchResult{Line: j.line, Op: j.op.Op}
+				err := j.err
+				var payload interface{}
+				if err == nil {
+					payload, err = runner.execute(ctx, j.op)
+				}
+				if err != nil {
+					Log.Warn().Err(err).Int("line", j.line).Str("op", j.op.Op).Msg("Batch operation failed")
+					res.Error = err.Error()
+				} else {
+					res.OK = true
+					res.Result = payload
+				}
+				results[j.index] = res
+				close(done[j.index])
+			}
+		}()
+	}
+	go func() {
+		for _, j := range jobs {
+			work <- j
+		}
+		close(work)
+	}()
+
+	// Emit results in input order as soon as each one is available.
+	encoder := json.NewEncoder(out)
+	failures := 0
+	var writeErr error
+	for i := range jobs {
+		<-done[i]
+		if !results[i].OK {
+			failures++
+		}
+		if writeErr == nil {
+			writeErr = encoder.Encode(results[i])
+		}
+	}
+	wg.Wait()
+
+	if writeErr != nil {
+		return fmt.Errorf("failed to write batch results: %w", writeErr)
+	}
+	if failures > 0 {
+		return fmt.Errorf("%d of %d batch operations failed", failures, len(jobs))
+	}
+	return nil
+}
+
+// batchCmd represents the batch command
+var batchCmd = &cobra.Command{
+	Use:   "batch",
+	Short: "Execute operations from a JSON Lines file",
+	Long: `Executes a list of operations read from a JSON Lines file (one JSON object per line)
+and prints one JSON result per line, in input order. This is a stable machine
+interface intended for CI pipelines and other automation.
+
+Supported operations:
+  {"op": "create", "input": "Login page returns 500", "type": "Bug"}
+  {"op": "search", "jql": "project = BE AND status = Open", "max_results": 10}
+  {"op": "comment", "key": "BE-42", "body": "Deployed to staging"}
+
+Use '--file -' to read operations from stdin. The command exits with an error
+if any operation failed; per-operation errors are reported in the result lines.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString("file")
+		parallel, _ := cmd.Flags().GetInt("parallel")
+
+		var in io.Reader
+		if file == "-" {
+			in = cmd.InOrStdin()
+		} else {
+			f, err := os.Open(file)
+			if err != nil {
+				return fmt.Errorf("failed to open batch file: %w", err)
+			}
+			defer f.Close()
+			in = f
+		}
+
+		createRunner, err := newCreateCmdRunner()
+		if err != nil {
+			return err
+		}
+		if err := createRunner.applyLLMOverrides(cmd); err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return batchRunE(ctx, &batchRunner{create: createRunner}, in, cmd.OutOrStdout(), parallel)
+	},
+}
+
+func init() {
+	batchCmd.Flags().String("file", "", "Path to a JSON Lines file with operations ('-' for stdin)")
+	batchCmd.Flags().Int("parallel", 1, "Number of operations to execute concurrently")
+	_ = batchCmd.MarkFlagRequired("file")
+	addLLMOverrideFlags(batchCmd)
+
+	rootCmd.AddCommand(batchCmd)
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/llm"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+func decodeBatchResults(t *testing.T, out *bytes.Buffer) []batchResult {
+	t.Helper()
+	var results []batchResult
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var r batchResult
+		require.NoError(t, json.Unmarshal([]byte(line), &r), "each output line must be valid JSON")
+		results = append(results, r)
+	}
+	return results
+}
+
+func TestBatchRunE_MixedOperations(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	createRunner, mockLLM := newMCPServeTestRunner(mockMCP)
+
+	mockLLM.On("GenerateTicketDetails", mock.Anything, "login broken", "prompt", mcpServeTestContext).
+		Return(llm.LLMResponse{Summary: "Fix login", ProjectNameSuggestion: "Backend"}, nil)
+	mockMCP.On("CreateIssue", mock.Anything, mcpclient.CreateIssueRequest{ProjectKey: "BE", Summary: "Fix login", IssueType: "Bug", IdempotencyKey: idempotencyKey("login broken", "BE")}).
+		Return(&mcpclient.CreateIssueResponse{Key: "BE-1", Replayed: true}, nil)
+	mockMCP.On("SearchIssues", mock.Anything, mcpclient.SearchIssuesRequest{JQL: "project = BE", MaxResults: 20}).
+		Return(&mcpclient.SearchIssuesResponse{Total: 0}, nil)
+	mockMCP.On("AddComment", mock.Anything, "BE-1", mcpclient.AddCommentRequest{Body: "done"}).
+		Return(nil, errors.New("forbidden"))
+
+	input := strings.Join([]string{
+		`{"op":"create","input":"login broken"}`,
+		``,
+		`# comments are ignored`,
+		`{"op":"search","jql":"project = BE"}`,
+		`{"op":"comment","key":"BE-1","body":"done"}`,
+		`{"op":"explode"}`,
+		`not json`,
+	}, "\n")
+
+	var out bytes.Buffer
+	err := batchRunE(context.Background(), &batchRunner{create: createRunner}, strings.NewReader(input), &out, 3)
+	assert.EqualError(t, err, "3 of 5 batch operations failed")
+
+	results := decodeBatchResults(t, &out)
+	require.Len(t, results, 5)
+	assert.Equal(t, []int{1, 4, 5, 6, 7}, []int{results[0].Line, results[1].Line, results[2].Line, results[3].Line, results[4].Line}, "results must keep input order")
+	assert.True(t, results[0].OK)
+	assert.Equal(t, "BE-1", results[0].Result.(map[string]interface{})["key"])
+	assert.Equal(t, true, results[0].Result.(map[string]interface{})["replayed"], "issues created earlier are marked")
+	assert.True(t, results[1].OK)
+	assert.False(t, results[2].OK)
+	assert.Equal(t, "forbidden", results[2].Error)
+	assert.Contains(t, results[3].Error, `unsupported op "explode"`)
+	assert.Contains(t, results[4].Error, "invalid JSON")
+	mockMCP.AssertExpectations(t)
+}
+
+func TestBatchRunE_AllSucceed(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	createRunner, _ := newMCPServeTestRunner(mockMCP)
+	mockMCP.On("SearchIssues", mock.Anything, mock.Anything).Return(&mcpclient.SearchIssuesResponse{}, nil)
+
+	var out bytes.Buffer
+	err := batchRunE(context.Background(), &batchRunner{create: createRunner}, strings.NewReader(`{"op":"search","jql":"a"}`+"\n"+`{"op":"search","jql":"b"}`), &out, 0)
+	require.NoError(t, err)
+	assert.Len(t, decodeBatchResults(t, &out), 2)
+}
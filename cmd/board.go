@@ -0,0 +1,318 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/cache"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/output"
+	"github.com/karolswdev/ticketron/internal/sanitize"
+)
+
+const (
+	// boardCacheTTL is how long the issues of a board are shown from the cache.
+	boardCacheTTL = 5 * time.Minute
+	// boardPageSize is the number of issues requested per MCP search call.
+	boardPageSize = 100
+	// boardMinColumnWidth is the narrowest column; boards with more columns than fit
+	// side by side at this width wrap onto further rows of columns.
+	boardMinColumnWidth = 24
+	// boardDefaultWidth is used when the terminal width is unknown.
+	boardDefaultWidth = 120
+	// boardSeparator separates the columns of the board, and boardRuleSeparator the
+	// rules under the column headers.
+	boardSeparator     = " │ "
+	boardRuleSeparator = "─┼─"
+)
+
+// boardStatusRanks orders the columns of well-known statuses from new to finished work.
+// Other statuses are placed between in-progress and finished statuses.
+var boardStatusRanks = map[string]int{
+	"backlog": 0, "new": 0, "open": 0, "to do": 0, "todo": 0, "reopened": 0, "selected for development": 0,
+	"in progress": 1, "in development": 1, "blocked": 1,
+	"in review": 2, "review": 2, "code review": 2, "in testing": 2, "testing": 2, "qa": 2,
+	"done": 4, "closed": 4, "resolved": 4, "cancelled": 4, "canceled": 4, "won't do": 4,
+}
+
+// boardSnapshot is the cached result of fetching the issues of a board.
+type boardSnapshot struct {
+	FetchedAt time.Time         `json:"fetched_at"`
+	Total     int               `json:"total"`
+	Issues    []mcpclient.Issue `json:"issues"`
+}
+
+// boardColumn is a status column of the board.
+type boardColumn struct {
+	Status string            `json:"status" yaml:"status"`
+	Issues []mcpclient.Issue `json:"issues" yaml:"issues"`
+}
+
+// boardOptions holds the settings of 'tix board show'.
+type boardOptions struct {
+	projectKey   string
+	statuses     []string // Only show these statuses, in this order
+	maxResults   int
+	refresh      bool
+	width        int
+	plain        bool
+	outputFormat string
+}
+
+// boardRunE shows the issues of a project grouped by status. Issues are taken from the
+// cache when they were fetched within boardCacheTTL, unless opts.refresh is set.
+func boardRunE(ctx context.Context, mcpClient MCPClient, store *cache.Store, opts boardOptions, out io.Writer) error {
+	snapshot, cached, err := boardIssues(ctx, mcpClient, store, opts)
+	if err != nil {
+		return err
+	}
+	columns := boardColumns(snapshot.Issues, opts.statuses)
+
+	if output.IsStructured(opts.outputFormat) {
+		return output.Structured(out, opts.outputFormat, columns)
+	}
+	if len(columns) == 0 {
+		fmt.Fprintf(out, "No issues in %s.\n", opts.projectKey)
+	} else if opts.plain {
+		renderBoardPlain(out, columns)
+	} else {
+		renderBoard(out, columns, opts.width)
+	}
+	if snapshot.Total > len(snapshot.Issues) {
+		fmt.Fprintf(out, "\nShowing %d of %d issues; raise --max-results to see more.\n", len(snapshot.Issues), snapshot.Total)
+	}
+	if cached {
+		fmt.Fprintf(out, "\nFetched %s ago; use --refresh to update.\n", time.Since(snapshot.FetchedAt).Round(time.Second))
+	}
+	return nil
+}
+
+// boardIssues returns the issues of the project, from the cache if possible, and whether
+// they came from the cache.
+func boardIssues(ctx context.Context, mcpClient MCPClient, store *cache.Store, opts boardOptions) (*boardSnapshot, bool, error) {
+	cacheKey := fmt.Sprintf("board-%s-%d", strings.ToUpper(opts.projectKey), opts.maxResults)
+	if store != nil && !opts.refresh {
+		var snapshot boardSnapshot
+		found, err := store.Get(cacheKey, &snapshot)
+		if err != nil {
+			Log.Debug().Err(err).Str("project_key", opts.projectKey).Msg("Ignoring unreadable board cache entry")
+		} else if found {
+			return &snapshot, true, nil
+		}
+	}
+	if mcpClient == nil {
+		return nil, false, errMCPClientNotInitialized
+	}
+
+	jql := "project = " + jqlQuote(opts.projectKey) + " ORDER BY rank ASC"
+	snapshot := &boardSnapshot{FetchedAt: time.Now()}
+	for {
+		pageSize := boardPageSize
+		if opts.maxResults > 0 && opts.maxResults-len(snapshot.Issues) < pageSize {
+			pageSize = opts.maxResults - len(snapshot.Issues)
+		}
+		startAt := len(snapshot.Issues)
+		resp, err := mcpClient.SearchIssues(ctx, mcpclient.SearchIssuesRequest{JQL: jql, MaxResults: pageSize, StartAt: startAt})
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to search issues of %s (startAt %d): %w", opts.projectKey, startAt, err)
+		}
+		snapshot.Issues = append(snapshot.Issues, resp.Issues...)
+		snapshot.Total = resp.Total
+		if len(resp.Issues) == 0 || len(snapshot.Issues) >= resp.Total || (opts.maxResults > 0 && len(snapshot.Issues) >= opts.maxResults) {
+			break
+		}
+	}
+	if store != nil {
+		if err := store.Set(cacheKey, snapshot); err != nil {
+			Log.Debug().Err(err).Str("project_key", opts.projectKey).Msg("Failed to cache board issues")
+		}
+	}
+	return snapshot, false, nil
+}
+
+// boardColumns groups issues by status. Without statuses, columns are ordered from new to
+// finished work by boardStatusRanks, then by first appearance; with statuses, only those
+// columns are returned in the given order, including empty ones.
+func boardColumns(issues []mcpclient.Issue, statuses []string) []boardColumn {
+	var columns []boardColumn
+	index := make(map[string]int)
+	for _, status := range statuses {
+		key := strings.ToLower(strings.TrimSpace(status))
+		if _, ok := index[key]; key == "" || ok {
+			continue
+		}
+		index[key] = len(columns)
+		columns = append(columns, boardColumn{Status: strings.TrimSpace(status)})
+	}
+	for _, issue := range issues {
+		name := issue.Fields.Status.Name
+		if name == "" {
+			name = "No status"
+		}
+		key := strings.ToLower(name)
+		i, ok := index[key]
+		if !ok {
+			if len(statuses) > 0 {
+				continue
+			}
+			i = len(columns)
+			index[key] = i
+			columns = append(columns, boardColumn{Status: name})
+		}
+		columns[i].Issues = append(columns[i].Issues, issue)
+	}
+	if len(statuses) == 0 {
+		sort.SliceStable(columns, func(i, j int) bool {
+			return boardStatusRank(columns[i].Status) < boardStatusRank(columns[j].Status)
+		})
+	}
+	return columns
+}
+
+// boardStatusRank returns the position of a status in the workflow; unknown statuses rank
+// between review and finished work.
+func boardStatusRank(status string) int {
+	if rank, ok := boardStatusRanks[strings.ToLower(status)]; ok {
+		return rank
+	}
+	return 3
+}
+
+// renderBoard writes the columns side by side, as many as fit into width at
+// boardMinColumnWidth; further columns wrap onto the next rows of the board. Cards are
+// truncated to the column width.
+func renderBoard(out io.Writer, columns []boardColumn, width int) {
+	sep := sanitize.Width(boardSeparator)
+	perRow := (width + sep) / (boardMinColumnWidth + sep)
+	perRow = max(1, min(perRow, len(columns)))
+	columnWidth := max(1, (width-sep*(perRow-1))/perRow)
+
+	for start := 0; start < len(columns); start += perRow {
+		band := columns[start:min(start+perRow, len(columns))]
+		if start > 0 {
+			fmt.Fprintln(out)
+		}
+		cells := make([]string, len(band))
+		rules := make([]string, len(band))
+		height := 0
+		for i, column := range band {
+			cells[i] = fmt.Sprintf("%s (%d)", sanitize.Line(column.Status), len(column.Issues))
+			rules[i] = strings.Repeat("─", columnWidth)
+			height = max(height, len(column.Issues))
+		}
+		writeBoardRow(out, cells, columnWidth)
+		fmt.Fprintln(out, strings.Join(rules, boardRuleSeparator))
+		for row := 0; row < height; row++ {
+			for i, column := range band {
+				cells[i] = ""
+				if row < len(column.Issues) {
+					issue := column.Issues[row]
+					cells[i] = sanitize.Line(issue.Key + " " + issue.Fields.Summary)
+				}
+			}
+			writeBoardRow(out, cells, columnWidth)
+		}
+	}
+}
+
+// writeBoardRow writes one line of cells, each truncated and padded to width.
+func writeBoardRow(out io.Writer, cells []string, width int) {
+	padded := make([]string, len(cells))
+	for i, cell := range cells {
+		padded[i] = sanitize.Pad(sanitize.Truncate(cell, width), width)
+	}
+	fmt.Fprintln(out, strings.TrimRight(strings.Join(padded, boardSeparator), " "))
+}
+
+// renderBoardPlain writes the columns one after the other, for screen readers (--plain).
+func renderBoardPlain(out io.Writer, columns []boardColumn) {
+	for i, column := range columns {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		fmt.Fprintf(out, "%s: %d issue(s)\n", sanitize.Line(column.Status), len(column.Issues))
+		for _, issue := range column.Issues {
+			fmt.Fprintf(out, "  %s: %s\n", sanitize.Line(issue.Key), sanitize.Line(issue.Fields.Summary))
+		}
+	}
+}
+
+// boardWidth returns the width to render the board at: the --width flag, else the width
+// of the terminal, else $COLUMNS, else boardDefaultWidth.
+func boardWidth(flagWidth int, out io.Writer) int {
+	if flagWidth > 0 {
+		return flagWidth
+	}
+	if width := terminalWidth(out); width > 0 {
+		return width
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return boardDefaultWidth
+}
+
+// boardCmd represents the board command group
+var boardCmd = &cobra.Command{
+	Use:   "board",
+	Short: "Show project boards in the terminal",
+}
+
+// boardShowCmd represents the board show command
+var boardShowCmd = &cobra.Command{
+	Use:   "show PROJECT-KEY",
+	Short: "Show the issues of a project as a Kanban board",
+	Long: `Shows the issues of a project grouped by status in side-by-side columns, ordered
+from new to finished work. Cards are truncated to fit the terminal width; when not all
+columns fit, the board continues below. The board is read-only.
+
+The issues are cached for 5 minutes, so the board can be shown repeatedly, e.g. with
+different --status filters, without searching again; use --refresh to fetch them now.`,
+	Example: `  tix board show PROJ
+  tix board show PROJ --status "In Progress" --status "In Review"
+  tix board show PROJ -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := boardOptions{projectKey: strings.ToUpper(args[0]), plain: plainOutput(cmd)}
+		opts.statuses, _ = cmd.Flags().GetStringSlice("status")
+		opts.maxResults, _ = cmd.Flags().GetInt("max-results")
+		opts.refresh, _ = cmd.Flags().GetBool("refresh")
+		opts.outputFormat, _ = cmd.Flags().GetString("output")
+		flagWidth, _ := cmd.Flags().GetInt("width")
+		opts.width = boardWidth(flagWidth, cmd.OutOrStdout())
+
+		configDir, err := resolveConfigDir()
+		if err != nil {
+			return err
+		}
+		store := cache.New(filepath.Join(configDir, "cache"), boardCacheTTL)
+		mcpClient, err := newCommandMCPClient()
+		if err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return boardRunE(ctx, mcpClient, store, opts, cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	boardShowCmd.Flags().StringSlice("status", nil, "Only show these status columns, in this order (repeatable or comma-separated)")
+	boardShowCmd.Flags().Int("max-results", 500, "Maximum number of issues to fetch (0 for all)")
+	boardShowCmd.Flags().Bool("refresh", false, "Fetch the issues from the server instead of the cache")
+	boardShowCmd.Flags().Int("width", 0, "Board width in columns (default: terminal width)")
+
+	boardCmd.AddCommand(boardShowCmd)
+	rootCmd.AddCommand(boardCmd)
+}
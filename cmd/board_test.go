@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/cache"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+func boardTestIssue(key, status, summary string) mcpclient.Issue {
+	return mcpclient.Issue{Key: key, Fields: mcpclient.IssueFields{Summary: summary, Status: mcpclient.Status{Name: status}}}
+}
+
+func boardTestIssues() []mcpclient.Issue {
+	return []mcpclient.Issue{
+		boardTestIssue("BE-1", "Done", "Ship login"),
+		boardTestIssue("BE-2", "In Progress", "Add metrics to the payment service and the checkout flow"),
+		boardTestIssue("BE-3", "To Do", "Write docs"),
+		boardTestIssue("BE-4", "Waiting", "Ask vendor"),
+		boardTestIssue("BE-5", "To Do", "Fix flaky test"),
+	}
+}
+
+func TestBoardColumns(t *testing.T) {
+	columns := boardColumns(boardTestIssues(), nil)
+	var statuses []string
+	for _, c := range columns {
+		statuses = append(statuses, c.Status)
+	}
+	assert.Equal(t, []string{"To Do", "In Progress", "Waiting", "Done"}, statuses)
+	assert.Len(t, columns[0].Issues, 2)
+
+	columns = boardColumns(boardTestIssues(), []string{"done", "Blocked", "to do"})
+	require.Len(t, columns, 3)
+	assert.Equal(t, "done", columns[0].Status)
+	assert.Len(t, columns[0].Issues, 1)
+	assert.Empty(t, columns[1].Issues, "requested statuses are shown even when empty")
+	assert.Len(t, columns[2].Issues, 2)
+}
+
+func TestRenderBoard(t *testing.T) {
+	columns := boardColumns(boardTestIssues(), []string{"To Do", "In Progress"})
+	var out bytes.Buffer
+	renderBoard(&out, columns, 60)
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	assert.Equal(t, []string{
+		"To Do (2)                    │ In Progress (1)",
+		"─────────────────────────────┼─────────────────────────────",
+		"BE-3 Write docs              │ BE-2 Add metrics to the p...",
+		"BE-5 Fix flaky test          │",
+	}, lines)
+
+	// Columns that do not fit side by side wrap onto the next rows
+	out.Reset()
+	renderBoard(&out, boardColumns(boardTestIssues(), nil), 52)
+	assert.Contains(t, out.String(), "To Do (2)                │ In Progress (1)\n")
+	assert.Contains(t, out.String(), "\nWaiting (1)              │ Done (1)\n")
+	for _, line := range strings.Split(out.String(), "\n") {
+		assert.LessOrEqual(t, len([]rune(line)), 52, line)
+	}
+}
+
+func TestBoardRunE_Cache(t *testing.T) {
+	mockMCP := new(MockMCPClient)
+	store := cache.New(t.TempDir(), time.Minute)
+	mockMCP.On("SearchIssues", mock.Anything, mcpclient.SearchIssuesRequest{JQL: `project = "BE" ORDER BY rank ASC`, MaxResults: 3}).
+		Return(&mcpclient.SearchIssuesResponse{Total: 5, Issues: boardTestIssues()[:3]}, nil).Once()
+	opts := boardOptions{projectKey: "BE", maxResults: 3, width: 80, outputFormat: "text"}
+
+	var out bytes.Buffer
+	require.NoError(t, boardRunE(context.Background(), mockMCP, store, opts, &out))
+	assert.Contains(t, out.String(), "Showing 3 of 5 issues")
+	assert.NotContains(t, out.String(), "--refresh")
+
+	// The second run uses the cache and can filter differently
+	out.Reset()
+	opts.statuses = []string{"Done"}
+	opts.outputFormat = "json"
+	require.NoError(t, boardRunE(context.Background(), mockMCP, store, opts, &out))
+	var columns []boardColumn
+	require.NoError(t, json.Unmarshal(out.Bytes(), &columns))
+	require.Len(t, columns, 1)
+	assert.Equal(t, "BE-1", columns[0].Issues[0].Key)
+	mockMCP.AssertExpectations(t)
+}
+
+func TestBoardRunE_PaginatesAndPlain(t *testing.T) {
+	mockMCP := new(MockMCPClient)
+	issues := boardTestIssues()
+	mockMCP.On("SearchIssues", mock.Anything, mcpclient.SearchIssuesRequest{JQL: `project = "BE" ORDER BY rank ASC`, MaxResults: boardPageSize}).
+		Return(&mcpclient.SearchIssuesResponse{Total: 5, Issues: issues[:2]}, nil).Once()
+	mockMCP.On("SearchIssues", mock.Anything, mcpclient.SearchIssuesRequest{JQL: `project = "BE" ORDER BY rank ASC`, MaxResults: boardPageSize, StartAt: 2}).
+		Return(&mcpclient.SearchIssuesResponse{Total: 5, Issues: issues[2:]}, nil).Once()
+
+	var out bytes.Buffer
+	opts := boardOptions{projectKey: "BE", plain: true, refresh: true}
+	require.NoError(t, boardRunE(context.Background(), mockMCP, nil, opts, &out))
+	assert.Equal(t, `To Do: 2 issue(s)
+  BE-3: Write docs
+  BE-5: Fix flaky test
+
+In Progress: 1 issue(s)
+  BE-2: Add metrics to the payment service and the checkout flow
+
+Waiting: 1 issue(s)
+  BE-4: Ask vendor
+
+Done: 1 issue(s)
+  BE-1: Ship login
+`, out.String())
+	mockMCP.AssertExpectations(t)
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/clierr"
+	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/i18n"
+	"github.com/karolswdev/ticketron/internal/prompt"
+	"github.com/karolswdev/ticketron/internal/secrets"
+)
+
+// ciFlagUsage describes the persistent --ci flag.
+const ciFlagUsage = "Pipeline mode: never prompt, no colors or spinners, API key only from the environment or secrets.api_key, errors as JSON on stderr (default when CI=true)"
+
+// ciEnvVar is set to true by most CI systems, such as GitHub Actions, GitLab CI and CircleCI.
+const ciEnvVar = "CI"
+
+// ErrCIInteractive indicates a command that needs a person at the terminal was run in CI mode.
+var ErrCIInteractive = errors.New("not available in CI mode")
+
+// ciEnabled reports whether tix runs in CI mode. Execute sets it from the CI environment
+// variable and the root command's PersistentPreRunE from --ci, for code without access to
+// the command such as GetProvider. Commands run directly in tests are not in CI mode.
+var ciEnabled bool
+
+// ciFromEnv reports whether the CI environment variable is set to true.
+func ciFromEnv() bool {
+	ci, _ := strconv.ParseBool(os.Getenv(ciEnvVar))
+	return ci
+}
+
+// applyCIMode sets ciEnabled for cmd: --ci decides if given, and the CI environment
+// variable otherwise. In CI mode cobra's own error and usage output is silenced, as
+// Execute reports the error as JSON.
+func applyCIMode(cmd *cobra.Command) {
+	ciEnabled = ciFromEnv()
+	if flag := cmd.Flags().Lookup("ci"); flag != nil && flag.Changed {
+		ciEnabled, _ = cmd.Flags().GetBool("ci")
+	}
+	cmd.Root().SilenceErrors = ciEnabled
+	cmd.Root().SilenceUsage = ciEnabled
+}
+
+// ciError is the JSON object written to stderr when a command fails in CI mode.
+type ciError struct {
+	Command string `json:"command,omitempty"`
+	Error   string `json:"error"`
+	Code    string `json:"code,omitempty"`
+	Hint    string `json:"hint,omitempty"`
+
+	Explanation string `json:"explanation,omitempty"` // With --explain
+}
+
+// writeCIError writes err, returned by the command at path, as a single line of JSON with
+// its code, a hint on how to fix it in a pipeline and the explanation asked for with --explain,
+// if there are any.
+func writeCIError(w io.Writer, path string, err error, explanation string) {
+	hint := ciErrorHint(err)
+	if hint == "" {
+		hint = clierr.HintOf(err)
+	}
+	_ = json.NewEncoder(w).Encode(ciError{Command: path, Error: err.Error(), Code: string(clierr.CodeOf(err)), Hint: hint, Explanation: explanation})
+}
+
+// ciErrorHint returns how to fix err in a pipeline, or "" if there is no specific advice.
+func ciErrorHint(err error) string {
+	switch {
+	case errors.Is(err, prompt.ErrInputRequired):
+		return i18n.T(i18n.MsgCIInputHint)
+	case errors.Is(err, config.ErrAPIKeyNotFound):
+		return i18n.T(i18n.MsgCIAPIKeyHint, config.EnvAPIKeyName)
+	case errors.Is(err, secrets.ErrResolve):
+		return i18n.T(i18n.MsgCISecretHint)
+	default:
+		return ""
+	}
+}
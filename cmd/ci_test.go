@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/clierr"
+	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/prompt"
+	"github.com/karolswdev/ticketron/internal/secrets"
+)
+
+// newCITestCmd returns a command with the global --ci flag parsed from args, restoring
+// ciEnabled when the test ends.
+func newCITestCmd(t *testing.T, args ...string) *cobra.Command {
+	t.Cleanup(func() { ciEnabled = false })
+	cmd := &cobra.Command{Use: "tix"}
+	cmd.Flags().Bool("ci", false, ciFlagUsage)
+	cmd.Flags().Bool("yes", false, yesFlagUsage)
+	cmd.Flags().Bool("no-input", false, noInputFlagUsage)
+	require.NoError(t, cmd.ParseFlags(args))
+	return cmd
+}
+
+func TestApplyCIMode(t *testing.T) {
+	t.Setenv(ciEnvVar, "")
+	cmd := newCITestCmd(t)
+	applyCIMode(cmd)
+	assert.False(t, ciEnabled)
+	assert.False(t, cmd.SilenceErrors)
+
+	t.Setenv(ciEnvVar, "true")
+	applyCIMode(cmd)
+	assert.True(t, ciEnabled, "detected from CI")
+	assert.True(t, cmd.SilenceErrors)
+	assert.True(t, cmd.SilenceUsage)
+
+	applyCIMode(newCITestCmd(t, "--ci=false"))
+	assert.False(t, ciEnabled, "--ci=false wins over CI")
+
+	t.Setenv(ciEnvVar, "")
+	applyCIMode(newCITestCmd(t, "--ci"))
+	assert.True(t, ciEnabled)
+}
+
+func TestNewConfirmer_CIMode(t *testing.T) {
+	t.Setenv(ciEnvVar, "")
+	cmd := newCITestCmd(t, "--ci")
+	cmd.SetIn(bytes.NewBufferString("y\n"))
+	applyCIMode(cmd)
+
+	_, err := newConfirmer(cmd).Confirm(&bytes.Buffer{}, "Create?", false)
+	assert.ErrorIs(t, err, prompt.ErrInputRequired, "the answer is never read")
+
+	cmd = newCITestCmd(t, "--ci", "--yes")
+	applyCIMode(cmd)
+	ok, err := newConfirmer(cmd).Confirm(&bytes.Buffer{}, "Create?", false)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestWriteCIError(t *testing.T) {
+	var out bytes.Buffer
+	writeCIError(&out, "tix create", fmt.Errorf("failed to get API key: %w", config.ErrAPIKeyNotFound), "")
+	assert.Equal(t, 1, bytes.Count(out.Bytes(), []byte("\n")), "a single line")
+
+	var reported ciError
+	require.NoError(t, json.Unmarshal(out.Bytes(), &reported))
+	assert.Equal(t, "tix create", reported.Command)
+	assert.Contains(t, reported.Error, config.ErrAPIKeyNotFound.Error())
+	assert.Contains(t, reported.Hint, config.EnvAPIKeyName)
+
+	assert.Contains(t, ciErrorHint(prompt.ErrInputRequired), "--yes")
+	assert.Contains(t, ciErrorHint(fmt.Errorf("%w vault://x#y", secrets.ErrResolve)), "VAULT_TOKEN")
+	assert.Empty(t, ciErrorHint(fmt.Errorf("boom")))
+}
+
+func TestWriteCIError_TypedError(t *testing.T) {
+	var out bytes.Buffer
+	err := clierr.New(clierr.CodeInvalidInput, "", "Run 'tix types BE'", errInvalidIssueRequest)
+	writeCIError(&out, "tix create", fmt.Errorf("failed to build issue: %w", err), "Story is not an issue type of BE.")
+
+	var reported ciError
+	require.NoError(t, json.Unmarshal(out.Bytes(), &reported))
+	assert.Equal(t, "Story is not an issue type of BE.", reported.Explanation, "with --explain")
+	assert.Equal(t, "failed to build issue: "+errInvalidIssueRequest.Error(), reported.Error, "the text is unchanged")
+	assert.Equal(t, string(clierr.CodeInvalidInput), reported.Code)
+	assert.Equal(t, "Run 'tix types BE'", reported.Hint)
+
+	// The pipeline hint wins over the hint for the terminal
+	out.Reset()
+	writeCIError(&out, "tix create", llmError(fmt.Errorf("failed to get API key: %w", config.ErrAPIKeyNotFound)), "")
+	require.NoError(t, json.Unmarshal(out.Bytes(), &reported))
+	assert.Equal(t, string(clierr.CodeAPIKeyMissing), reported.Code)
+	assert.Equal(t, ciErrorHint(config.ErrAPIKeyNotFound), reported.Hint)
+}
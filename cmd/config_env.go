@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/output"
+	"github.com/karolswdev/ticketron/internal/sanitize"
+)
+
+// configEnvRunE lists the environment variables understood by tix with the current value
+// of each setting and whether it comes from the environment, the project-local
+// configuration, config.yaml, the team configuration or a default.
+// With plain set, each variable is printed as labeled lines instead of a table row.
+func configEnvRunE(cfgProvider ConfigProvider, outputFormat string, plain bool, out io.Writer) error {
+	configDir, err := cfgProvider.EnsureConfigDir()
+	if err != nil {
+		return fmt.Errorf("error ensuring config directory: %w", err)
+	}
+	vars, err := config.EnvVars(configDir, projectConfigOf(cfgProvider))
+	if err != nil {
+		return fmt.Errorf("error loading configuration: %w", err)
+	}
+	for i := range vars {
+		vars[i].Value = redactSetting(vars[i].Key, vars[i].Value)
+	}
+	if output.IsStructured(outputFormat) {
+		return output.Structured(out, outputFormat, vars)
+	}
+
+	table := output.NewTable("Variable", "Source", "Value")
+	for _, envVar := range vars {
+		table.Row(envVar.Name, envVar.Source, sanitize.Line(envVar.Value))
+	}
+	return table.Render(out, plain)
+}
+
+// configEnvCmd represents the config env command
+var configEnvCmd = &cobra.Command{
+	Use:   "env",
+	Short: "List the supported environment variables",
+	Long: `Lists every TICKETRON_* environment variable with the current value of the setting it
+controls and its source: env (the variable is set), file (config.yaml), default or unset.
+
+Each config.yaml key can be overridden by the variable named after its path, e.g.
+llm.openai.model_name by TICKETRON_LLM_OPENAI_MODEL_NAME. Lists are comma-separated.
+The value of TICKETRON_LLM_API_KEY is never shown, a secrets.api_key that is not a
+reference to a secret store is masked, and so are passwords in proxy URLs.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		provider, err := GetProvider()
+		if err != nil {
+			return fmt.Errorf("failed to initialize provider: %w", err)
+		}
+		outputFormat, _ := cmd.Flags().GetString("output")
+		return configEnvRunE(provider.Config, outputFormat, plainOutput(cmd), cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configEnvCmd)
+}
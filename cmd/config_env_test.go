@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/config"
+)
+
+func TestConfigEnvRunE(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TICKETRON_TIMEZONE", "UTC")
+	cfgProvider := &DefaultConfigProvider{ConfigDir: dir}
+
+	var out bytes.Buffer
+	require.NoError(t, configEnvRunE(cfgProvider, "text", false, &out))
+	assert.Regexp(t, `(?m)^VARIABLE\s+SOURCE\s+VALUE$`, out.String())
+	assert.Regexp(t, `(?m)^TICKETRON_TIMEZONE\s+env\s+UTC$`, out.String())
+	assert.Regexp(t, `(?m)^TICKETRON_LLM_PROVIDER\s+default\s+openai$`, out.String())
+
+	out.Reset()
+	require.NoError(t, configEnvRunE(cfgProvider, "json", false, &out))
+	var vars []config.EnvVar
+	require.NoError(t, json.Unmarshal(out.Bytes(), &vars))
+	assert.Contains(t, vars, config.EnvVar{Name: "TICKETRON_TIMEZONE", Key: "timezone", Source: config.SourceEnv, Value: "UTC"})
+}
+
+func TestConfigEnvRunE_MasksSecret(t *testing.T) {
+	t.Setenv("TICKETRON_SECRETS_API_KEY", "sk-plain-text-secret-key-here")
+	cfgProvider := &DefaultConfigProvider{ConfigDir: t.TempDir()}
+
+	var out bytes.Buffer
+	require.NoError(t, configEnvRunE(cfgProvider, "text", false, &out))
+	assert.Regexp(t, `(?m)^TICKETRON_SECRETS_API_KEY\s+env\s+sk-…here$`, out.String())
+	assert.NotContains(t, out.String(), "plain-text")
+
+	out.Reset()
+	require.NoError(t, configEnvRunE(cfgProvider, "json", false, &out))
+	assert.NotContains(t, out.String(), "plain-text")
+}
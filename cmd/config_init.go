@@ -1,21 +1,44 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io" // Added for io.Writer
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/rs/zerolog/log" // Import log package
 	"github.com/spf13/cobra"
-	// Removed direct import of "github.com/karolswdev/ticketron/internal/config"
+
+	"github.com/karolswdev/ticketron/internal/config"
 )
 
+// supportedInitProviders are the LLM providers accepted by 'config init --provider'.
+var supportedInitProviders = []string{"openai", "mock"}
+
 // initCmd represents the init command
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize Ticketron configuration",
 	Long: `Creates the default configuration directory and files if they don't exist.
 This command ensures that the necessary configuration structure is in place
-for Ticketron to function correctly.`,
+for Ticketron to function correctly.
+
+Settings can be given as flags so that provisioning needs no editing afterwards:
+  tix config init --mcp-url https://mcp.example.com --model gpt-4o-mini \
+    --project backend=BE --project "web app=WEB"
+
+Existing config.yaml or links.yaml files are only replaced with --force.
+
+A team can share a base configuration, published as one YAML bundle with config, links,
+system_prompt and context sections:
+  tix config init --from-url https://example.com/team-ticketron.yaml
+
+The bundle is verified against --checksum or the checksum published as URL.sha256 and
+stored in the team directory of the configuration directory. Its settings apply unless
+your own files set them. Refresh it with 'tix config sync'.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Get the provider
 		provider, err := GetProvider()
@@ -29,24 +52,107 @@ for Ticketron to function correctly.`,
 }
 
 func init() {
+	addConfigInitFlags(initCmd)
 	configCmd.AddCommand(initCmd)
 }
 
+// addConfigInitFlags registers the provisioning flags of config init on cmd.
+func addConfigInitFlags(cmd *cobra.Command) {
+	cmd.Flags().String("mcp-url", "", "MCP server URL to write to config.yaml")
+	cmd.Flags().String("provider", "", "LLM provider to write to config.yaml ("+strings.Join(supportedInitProviders, ", ")+")")
+	cmd.Flags().String("model", "", "LLM model to write to config.yaml")
+	cmd.Flags().StringArray("project", nil, "Project mapping ALIAS=KEY to write to links.yaml; repeatable")
+	cmd.Flags().Bool("force", false, "Overwrite config.yaml/links.yaml when they exist and are set by the flags above")
+	cmd.Flags().String("from-url", "", "Install the team configuration bundle at this URL")
+	cmd.Flags().String("checksum", "", "Expected SHA-256 of the --from-url bundle (default: read from URL.sha256)")
+}
+
 // configInitRunE contains the core logic for the config init command.
 // It accepts dependencies for testability.
 func configInitRunE(configProvider ConfigProvider, writer io.Writer, cmd *cobra.Command, args []string) error {
 	log.Info().Msg("Initializing configuration...")
-	// Use the injected provider to create files
-	// The configDir parameter is currently ignored by the provider implementation,
-	// but included here for potential future use or interface consistency.
-	// We can pass an empty string or determine the actual dir if needed later.
-	err := configProvider.CreateDefaultConfigFiles("")
+	opts, err := initOptionsFromFlags(cmd)
 	if err != nil {
+		return err
+	}
+
+	// The team bundle is downloaded and verified first so that a failure writes nothing.
+	fromURL, _ := cmd.Flags().GetString("from-url")
+	var teamBundle []byte
+	var teamSum string
+	if fromURL != "" {
+		checksum, _ := cmd.Flags().GetString("checksum")
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		if teamBundle, teamSum, err = fetchTeamConfig(ctx, nil, fromURL, checksum); err != nil {
+			return fmt.Errorf("failed to initialize configuration: %w", err)
+		}
+	}
+
+	if isZeroInitOptions(opts) {
+		// The configDir parameter is ignored by the provider implementation.
+		err := configProvider.CreateDefaultConfigFiles("")
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to initialize configuration files")
+			return fmt.Errorf("failed to initialize configuration: %w", err)
+		}
+		log.Info().Msg("Configuration initialization complete.")
+		// Use the injected writer for output
+		fmt.Fprintln(writer, "Configuration directory and default files ensured.")
+		return installInitTeamConfig(configProvider, writer, fromURL, teamBundle, teamSum)
+	}
+
+	if err := configProvider.InitConfigFiles(opts); err != nil {
 		log.Error().Err(err).Msg("Failed to initialize configuration files")
+		if errors.Is(err, config.ErrConfigExists) {
+			return fmt.Errorf("failed to initialize configuration: %w (use --force to overwrite it)", err)
+		}
 		return fmt.Errorf("failed to initialize configuration: %w", err)
 	}
 	log.Info().Msg("Configuration initialization complete.")
-	// Use the injected writer for output
-	fmt.Fprintln(writer, "Configuration directory and default files ensured.")
-	return nil
+	fmt.Fprintln(writer, "Configuration written.")
+	return installInitTeamConfig(configProvider, writer, fromURL, teamBundle, teamSum)
+}
+
+// installInitTeamConfig installs the team bundle downloaded from fromURL, if any.
+func installInitTeamConfig(configProvider ConfigProvider, writer io.Writer, fromURL string, data []byte, sum string) error {
+	if fromURL == "" {
+		return nil
+	}
+	configDir, err := configProvider.EnsureConfigDir()
+	if err != nil {
+		return fmt.Errorf("error ensuring config directory: %w", err)
+	}
+	return installTeamConfig(configDir, data, config.TeamSource{URL: fromURL, SHA256: sum, FetchedAt: time.Now()}, "Installed", writer)
+}
+
+// initOptionsFromFlags reads and validates the provisioning flags of config init.
+func initOptionsFromFlags(cmd *cobra.Command) (config.InitOptions, error) {
+	var opts config.InitOptions
+	opts.MCPServerURL, _ = cmd.Flags().GetString("mcp-url")
+	opts.LLMProvider, _ = cmd.Flags().GetString("provider")
+	opts.LLMModel, _ = cmd.Flags().GetString("model")
+	opts.Force, _ = cmd.Flags().GetBool("force")
+	projects, _ := cmd.Flags().GetStringArray("project")
+
+	if opts.LLMProvider != "" && !slices.Contains(supportedInitProviders, strings.ToLower(opts.LLMProvider)) {
+		return opts, fmt.Errorf("unsupported LLM provider %q (supported: %s)", opts.LLMProvider, strings.Join(supportedInitProviders, ", "))
+	}
+	opts.LLMProvider = strings.ToLower(opts.LLMProvider)
+	for _, project := range projects {
+		alias, key, found := strings.Cut(project, "=")
+		alias, key = strings.TrimSpace(alias), strings.TrimSpace(key)
+		if !found || alias == "" || key == "" {
+			return opts, fmt.Errorf("invalid --project %q: expected ALIAS=KEY", project)
+		}
+		opts.Projects = append(opts.Projects, config.ProjectLink{Name: alias, Key: strings.ToUpper(key)})
+	}
+	return opts, nil
+}
+
+// isZeroInitOptions reports whether no setting was given; --force alone changes nothing.
+func isZeroInitOptions(opts config.InitOptions) bool {
+	return opts.MCPServerURL == "" && opts.LLMProvider == "" && opts.LLMModel == "" && len(opts.Projects) == 0
 }
@@ -3,11 +3,15 @@ package cmd
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/config"
 )
 
 func TestConfigInitCmd_Success(t *testing.T) {
@@ -55,3 +59,45 @@ func TestConfigInitCmd_ProviderError(t *testing.T) {
 	mockProvider.AssertExpectations(t)
 	mockProvider.AssertCalled(t, "CreateDefaultConfigFiles", mock.AnythingOfType("string"))
 }
+
+// newConfigInitTestCmd returns a command with the config init flags set to args.
+func newConfigInitTestCmd(t *testing.T, args ...string) *cobra.Command {
+	cmd := &cobra.Command{}
+	addConfigInitFlags(cmd)
+	require.NoError(t, cmd.Flags().Parse(args))
+	return cmd
+}
+
+func TestConfigInitCmd_ProvisioningFlags(t *testing.T) {
+	mockProvider := new(MockConfigProvider)
+	want := config.InitOptions{
+		MCPServerURL: "https://mcp.example.com",
+		LLMProvider:  "openai",
+		LLMModel:     "gpt-4o-mini",
+		Projects:     []config.ProjectLink{{Name: "backend", Key: "BE"}, {Name: "web app", Key: "WEB"}},
+		Force:        true,
+	}
+	mockProvider.On("InitConfigFiles", want).Return(nil)
+
+	cmd := newConfigInitTestCmd(t, "--mcp-url", "https://mcp.example.com", "--provider", "OpenAI", "--model", "gpt-4o-mini",
+		"--project", "backend=BE", "--project", " web app = web ", "--force")
+	var out bytes.Buffer
+	require.NoError(t, configInitRunE(mockProvider, &out, cmd, nil))
+	assert.Equal(t, "Configuration written.\n", out.String())
+	mockProvider.AssertNotCalled(t, "CreateDefaultConfigFiles", mock.Anything)
+}
+
+func TestConfigInitCmd_ProvisioningErrors(t *testing.T) {
+	mockProvider := new(MockConfigProvider)
+	mockProvider.On("InitConfigFiles", mock.Anything).Return(fmt.Errorf("%w: /home/u/.ticketron/config.yaml", config.ErrConfigExists))
+
+	err := configInitRunE(mockProvider, &bytes.Buffer{}, newConfigInitTestCmd(t, "--project", "backend"), nil)
+	assert.EqualError(t, err, `invalid --project "backend": expected ALIAS=KEY`)
+
+	err = configInitRunE(mockProvider, &bytes.Buffer{}, newConfigInitTestCmd(t, "--provider", "acme"), nil)
+	assert.EqualError(t, err, `unsupported LLM provider "acme" (supported: openai, mock)`)
+
+	err = configInitRunE(mockProvider, &bytes.Buffer{}, newConfigInitTestCmd(t, "--mcp-url", "http://x"), nil)
+	assert.ErrorIs(t, err, config.ErrConfigExists)
+	assert.ErrorContains(t, err, "use --force to overwrite it")
+}
@@ -24,6 +24,9 @@ func configLocateRunE(cfgProvider ConfigProvider, out io.Writer) error {
 	fmt.Fprintf(out, "- %s\n", filepath.Join(configDir, "links.yaml"))
 	fmt.Fprintf(out, "- %s\n", filepath.Join(configDir, "system_prompt.txt"))
 	fmt.Fprintf(out, "- %s\n", filepath.Join(configDir, "context.md"))
+	if project := projectConfigOf(cfgProvider); project != nil {
+		fmt.Fprintf(out, "Project-local configuration: %s\n", project.Path)
+	}
 
 	return nil // Indicate success
 }
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/config"
+)
+
+// configMigrateRunE upgrades config.yaml in the provider's configuration directory to the
+// current schema version, or only reports the needed changes when dryRun is set.
+func configMigrateRunE(cfgProvider ConfigProvider, out io.Writer, dryRun bool) error {
+	configDir, err := cfgProvider.EnsureConfigDir()
+	if err != nil {
+		return fmt.Errorf("error ensuring config directory: %w", err)
+	}
+	result, err := config.MigrateConfigFile(configDir, !dryRun)
+	if err != nil {
+		return fmt.Errorf("failed to migrate configuration: %w", err)
+	}
+
+	if result.UpToDate() {
+		fmt.Fprintf(out, "%s is up to date (version %d).\n", result.Path, result.ToVersion)
+		return nil
+	}
+	verb := "Migrated"
+	if dryRun {
+		verb = "Would migrate"
+	}
+	fmt.Fprintf(out, "%s %s from version %d to %d.\n", verb, result.Path, result.FromVersion, result.ToVersion)
+	for _, applied := range result.Applied {
+		fmt.Fprintf(out, "- %s\n", applied)
+	}
+	if result.BackupPath != "" {
+		fmt.Fprintf(out, "The original file was saved to %s.\n", result.BackupPath)
+	}
+	return nil
+}
+
+// migrateCmd represents the config migrate command
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade config.yaml to the current schema version",
+	Long: `Rewrites config.yaml in the current layout and records its schema version.
+Older layouts are already upgraded in memory when loaded (with a warning); this command
+writes the upgraded file back. Comments are kept and the original file is saved as
+config.yaml.bak. Use --dry-run to only list the changes.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		provider, err := GetProvider()
+		if err != nil {
+			return fmt.Errorf("failed to initialize provider: %w", err)
+		}
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		return configMigrateRunE(provider.Config, cmd.OutOrStdout(), dryRun)
+	},
+}
+
+func init() {
+	migrateCmd.Flags().Bool("dry-run", false, "Show the changes without writing config.yaml")
+	configCmd.AddCommand(migrateCmd)
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/config"
+)
+
+func TestConfigMigrateRunE(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, config.DefaultConfigFileName)
+	require.NoError(t, os.WriteFile(path, []byte("llm_provider: openai\nmodel_name: gpt-4o-mini\n"), 0600))
+	cfgProvider := &DefaultConfigProvider{ConfigDir: dir}
+
+	var out bytes.Buffer
+	require.NoError(t, configMigrateRunE(cfgProvider, &out, true))
+	assert.Contains(t, out.String(), "Would migrate "+path+" from version 0 to 1.")
+	assert.NotContains(t, out.String(), ".bak")
+
+	out.Reset()
+	require.NoError(t, configMigrateRunE(cfgProvider, &out, false))
+	assert.Contains(t, out.String(), "Migrated "+path)
+	assert.Contains(t, out.String(), "- moved top-level")
+	assert.Contains(t, out.String(), path+".bak")
+
+	out.Reset()
+	require.NoError(t, configMigrateRunE(cfgProvider, &out, false))
+	assert.Equal(t, path+" is up to date (version 1).\n", out.String())
+}
+
+func TestConfigMigrateRunE_Missing(t *testing.T) {
+	var out bytes.Buffer
+	err := configMigrateRunE(&DefaultConfigProvider{ConfigDir: t.TempDir()}, &out, false)
+	assert.ErrorIs(t, err, config.ErrConfigNotFound)
+	assert.Empty(t, out.String())
+}
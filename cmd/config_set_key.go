@@ -22,7 +22,13 @@ var setKeyCmd = &cobra.Command{
 	Short: "Stores the OpenAI API key securely in the OS keychain",
 	Long: `Stores the OpenAI API key securely in the operating system's keychain or keyring.
 This is the recommended way to configure the API key for Ticketron.
-The key will be associated with the service 'ticketron' and user 'openai_api_key'.`,
+The key will be associated with the service 'ticketron' and user 'openai_api_key'.
+
+The storage location follows 'secrets.backend' in config.yaml (auto, keyring,
+wincred, file or env). With 'auto', the key is written to
+~/.ticketron/credentials.yaml (mode 0600) when the OS keyring is unavailable,
+e.g. on WSL. Nothing is stored while 'secrets.api_key' references a secret
+manager such as vault://; update the key there instead.`,
 	Args: cobra.ExactArgs(1), // Requires exactly one argument: the API key
 	// RunE will be set in init() after getting the provider
 }
@@ -49,24 +55,14 @@ func configSetKeyRun(kc KeyringClient, writer io.Writer, apiKey string) error {
 }
 
 func init() {
-	// Get the provider
-	provider, err := GetProvider()
-	if err != nil {
-		// Handle provider initialization error - log and make command return error
-		log.Error().Err(err).Msg("Failed to initialize provider for set-key command; command will be disabled.")
-		setKeyCmd.RunE = func(cmd *cobra.Command, args []string) error {
-			return fmt.Errorf("command disabled due to provider initialization error: %w", err)
-		}
-	} else {
-		// Set RunE using the provider
-		setKeyCmd.RunE = func(cmd *cobra.Command, args []string) error {
-			// Get the writer from the command
-			writer := cmd.OutOrStdout()
-			// Get the API key from args
-			apiKey := args[0]
-			// Call the actual logic function with injected dependencies
-			return configSetKeyRun(provider.Keyring, writer, apiKey)
+	// The provider is resolved when the command runs, not during init(), so that a broken
+	// configuration does not affect every other command.
+	setKeyCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		provider, err := GetProvider()
+		if err != nil {
+			return fmt.Errorf("failed to initialize provider: %w", err)
 		}
+		return configSetKeyRun(provider.Keyring, cmd.OutOrStdout(), args[0])
 	}
 
 	configCmd.AddCommand(setKeyCmd)
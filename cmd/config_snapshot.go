@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"sync"
+
+	"github.com/karolswdev/ticketron/internal/config"
+)
+
+// ConfigSnapshot is a ConfigProvider that reads each configuration file at most once and
+// returns the same result on every later call. Commands create one snapshot per invocation
+// and pass it to all of their runners, so every part of a command sees the same
+// configuration. Reload discards the loaded files, e.g. when a watcher reports a change.
+//
+// The returned *config.AppConfig and *config.LinksConfig are shared and must not be modified.
+type ConfigSnapshot struct {
+	source ConfigProvider
+
+	mu           sync.Mutex
+	appConfig    *snapshotEntry[*config.AppConfig]
+	links        *snapshotEntry[*config.LinksConfig]
+	systemPrompt *snapshotEntry[string]
+	contextData  *snapshotEntry[string]
+}
+
+// snapshotEntry is a loaded value or the error returned while loading it.
+type snapshotEntry[T any] struct {
+	value T
+	err   error
+}
+
+// NewConfigSnapshot returns a snapshot of the configuration served by source. Nothing is
+// read until the first Load call.
+func NewConfigSnapshot(source ConfigProvider) *ConfigSnapshot {
+	return &ConfigSnapshot{source: source}
+}
+
+// loadOnce returns the cached entry, loading it with load on first use. Failures are cached
+// too, so a broken file is reported consistently within one invocation.
+func loadOnce[T any](s *ConfigSnapshot, entry **snapshotEntry[T], load func() (T, error)) (T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if *entry == nil {
+		value, err := load()
+		*entry = &snapshotEntry[T]{value: value, err: err}
+	}
+	return (*entry).value, (*entry).err
+}
+
+// LoadConfig returns config.yaml as loaded by the first call.
+func (s *ConfigSnapshot) LoadConfig() (*config.AppConfig, error) {
+	return loadOnce(s, &s.appConfig, s.source.LoadConfig)
+}
+
+// LoadLinks returns links.yaml as loaded by the first call.
+func (s *ConfigSnapshot) LoadLinks() (*config.LinksConfig, error) {
+	return loadOnce(s, &s.links, s.source.LoadLinks)
+}
+
+// LoadSystemPrompt returns the system prompt as loaded by the first call.
+func (s *ConfigSnapshot) LoadSystemPrompt() (string, error) {
+	return loadOnce(s, &s.systemPrompt, s.source.LoadSystemPrompt)
+}
+
+// LoadContext returns context.md as loaded by the first call.
+func (s *ConfigSnapshot) LoadContext() (string, error) {
+	return loadOnce(s, &s.contextData, s.source.LoadContext)
+}
+
+// GetAPIKey is passed through to the source; secrets are never cached.
+func (s *ConfigSnapshot) GetAPIKey() (string, error) {
+	return s.source.GetAPIKey()
+}
+
+// CreateDefaultConfigFiles creates the default files via the source and discards the
+// snapshot so that the new files are read on the next call.
+func (s *ConfigSnapshot) CreateDefaultConfigFiles(configDir string) error {
+	defer s.Reload()
+	return s.source.CreateDefaultConfigFiles(configDir)
+}
+
+// InitConfigFiles writes the files via the source and discards the snapshot.
+func (s *ConfigSnapshot) InitConfigFiles(opts config.InitOptions) error {
+	defer s.Reload()
+	return s.source.InitConfigFiles(opts)
+}
+
+// EnsureConfigDir is passed through to the source.
+func (s *ConfigSnapshot) EnsureConfigDir() (string, error) {
+	return s.source.EnsureConfigDir()
+}
+
+// ProjectConfig returns the project-local configuration of the source, or nil.
+func (s *ConfigSnapshot) ProjectConfig() *config.ProjectConfig {
+	return projectConfigOf(s.source)
+}
+
+// Reload discards all loaded files; they are read again on their next use.
+func (s *ConfigSnapshot) Reload() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.appConfig, s.links, s.systemPrompt, s.contextData = nil, nil, nil, nil
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/config"
+)
+
+func TestConfigSnapshot_LoadsOnce(t *testing.T) {
+	source := new(MockConfigProvider)
+	cfg := &config.AppConfig{MCPServerURL: "http://mcp.example.com"}
+	source.On("LoadConfig").Return(cfg, nil).Once()
+	source.On("LoadLinks").Return(nil, config.ErrLinksParse).Once()
+	source.On("LoadContext").Return("context", nil).Once()
+	source.On("GetAPIKey").Return("key", nil).Twice()
+
+	snapshot := NewConfigSnapshot(source)
+	for i := 0; i < 2; i++ {
+		got, err := snapshot.LoadConfig()
+		require.NoError(t, err)
+		assert.Same(t, cfg, got)
+
+		_, err = snapshot.LoadLinks()
+		assert.ErrorIs(t, err, config.ErrLinksParse, "failures are cached as well")
+
+		contextData, err := snapshot.LoadContext()
+		require.NoError(t, err)
+		assert.Equal(t, "context", contextData)
+
+		key, err := snapshot.GetAPIKey()
+		require.NoError(t, err)
+		assert.Equal(t, "key", key, "secrets are not cached")
+	}
+	source.AssertExpectations(t)
+}
+
+func TestConfigSnapshot_Reload(t *testing.T) {
+	source := new(MockConfigProvider)
+	source.On("LoadSystemPrompt").Return("old", nil).Once()
+	source.On("LoadSystemPrompt").Return("new", nil).Once()
+	source.On("LoadSystemPrompt").Return("created", nil).Once()
+	source.On("CreateDefaultConfigFiles", "").Return(nil).Once()
+
+	snapshot := NewConfigSnapshot(source)
+	prompt, _ := snapshot.LoadSystemPrompt()
+	assert.Equal(t, "old", prompt)
+
+	snapshot.Reload()
+	prompt, _ = snapshot.LoadSystemPrompt()
+	assert.Equal(t, "new", prompt)
+
+	require.NoError(t, snapshot.CreateDefaultConfigFiles(""))
+	prompt, _ = snapshot.LoadSystemPrompt()
+	assert.Equal(t, "created", prompt, "creating default files discards the snapshot")
+	source.AssertExpectations(t)
+}
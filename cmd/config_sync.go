@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/config"
+)
+
+// teamFetchTimeout bounds the download of a team configuration bundle.
+const teamFetchTimeout = 30 * time.Second
+
+// configSyncRunE downloads the team configuration again from the URL it was installed from
+// and replaces the installed bundle if it changed.
+func configSyncRunE(ctx context.Context, client *http.Client, cfgProvider ConfigProvider, checksum string, now time.Time, out io.Writer) error {
+	configDir, err := cfgProvider.EnsureConfigDir()
+	if err != nil {
+		return fmt.Errorf("error ensuring config directory: %w", err)
+	}
+	source, err := config.LoadTeamSource(configDir)
+	if errors.Is(err, config.ErrTeamNotConfigured) {
+		return fmt.Errorf("%w; install one with 'tix config init --from-url URL'", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	data, sum, err := fetchTeamConfig(ctx, client, source.URL, checksum)
+	if err != nil {
+		return err
+	}
+	if sum == source.SHA256 {
+		fmt.Fprintf(out, "The team configuration from %s is up to date.\n", source.URL)
+		return nil
+	}
+	return installTeamConfig(configDir, data, config.TeamSource{URL: source.URL, SHA256: sum, FetchedAt: now}, "Updated", out)
+}
+
+// fetchTeamConfig downloads the team configuration at bundleURL and verifies it against
+// checksum, read from bundleURL.sha256 when empty. It returns the bundle, checked to parse,
+// and its checksum.
+func fetchTeamConfig(ctx context.Context, client *http.Client, bundleURL, checksum string) ([]byte, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, teamFetchTimeout)
+	defer cancel()
+	data, sum, err := config.FetchTeamBundle(ctx, client, bundleURL, checksum)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := config.ParseBundle(data); err != nil {
+		return nil, "", err
+	}
+	return data, sum, nil
+}
+
+// installTeamConfig installs the downloaded team configuration data in configDir and
+// reports what it provides, starting with verb.
+func installTeamConfig(configDir string, data []byte, source config.TeamSource, verb string, out io.Writer) error {
+	bundle, err := config.InstallTeamBundle(configDir, data, source)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%s the team configuration from %s (sha256 %s): %s.\n", verb, source.URL, source.SHA256[:12], describeTeamBundle(bundle))
+	return nil
+}
+
+// describeTeamBundle lists what a team bundle provides, e.g.
+// "settings llm, mcp_server_url; 2 projects; system prompt".
+func describeTeamBundle(bundle *config.Bundle) string {
+	var parts []string
+	if len(bundle.Config) > 0 {
+		keys := make([]string, 0, len(bundle.Config))
+		for key := range bundle.Config {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		parts = append(parts, "settings "+strings.Join(keys, ", "))
+	}
+	if n := len(bundle.Links.Projects); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d project(s)", n))
+	}
+	if bundle.SystemPrompt != "" {
+		parts = append(parts, "system prompt")
+	}
+	if bundle.Context != "" {
+		parts = append(parts, "context")
+	}
+	if len(parts) == 0 {
+		return "empty"
+	}
+	return strings.Join(parts, "; ")
+}
+
+// syncCmd represents the config sync command
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Refresh the team configuration",
+	Long: `Downloads the team configuration again from the URL given to
+'tix config init --from-url' and installs it if it changed. The download is verified
+against --checksum or, without it, the checksum published as URL.sha256.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		provider, err := GetProvider()
+		if err != nil {
+			return fmt.Errorf("failed to initialize provider: %w", err)
+		}
+		checksum, _ := cmd.Flags().GetString("checksum")
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return configSyncRunE(ctx, nil, provider.Config, checksum, time.Now(), cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	syncCmd.Flags().String("checksum", "", "Expected SHA-256 of the bundle (default: read from URL.sha256)")
+	configCmd.AddCommand(syncCmd)
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/config"
+)
+
+// teamConfigServer serves *bundle as /team.yaml with its checksum as /team.yaml.sha256.
+func teamConfigServer(t *testing.T, bundle *string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/team.yaml":
+			_, _ = w.Write([]byte(*bundle))
+		case "/team.yaml.sha256":
+			sum := sha256.Sum256([]byte(*bundle))
+			_, _ = w.Write([]byte(hex.EncodeToString(sum[:]) + "  team.yaml\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestConfigInitCmd_FromURL(t *testing.T) {
+	bundle := "config:\n  mcp_server_url: https://mcp.team.example\nlinks:\n  projects:\n    - name: backend\n      key: BE\n"
+	server := teamConfigServer(t, &bundle)
+	dir := t.TempDir()
+	cfgProvider := &DefaultConfigProvider{ConfigDir: dir}
+
+	var out bytes.Buffer
+	cmd := newConfigInitTestCmd(t, "--from-url", server.URL+"/team.yaml")
+	require.NoError(t, configInitRunE(cfgProvider, &out, cmd, nil))
+	assert.Contains(t, out.String(), "Configuration directory and default files ensured.\n")
+	assert.Contains(t, out.String(), "Installed the team configuration from "+server.URL+"/team.yaml")
+	assert.Contains(t, out.String(), "settings mcp_server_url; 1 project(s).")
+
+	cfg, err := cfgProvider.LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "https://mcp.team.example", cfg.MCPServerURL)
+	links, err := cfgProvider.LoadLinks()
+	require.NoError(t, err)
+	assert.Equal(t, []config.ProjectLink{{Name: "backend", Key: "BE"}}, links.Projects)
+}
+
+func TestConfigInitCmd_FromURLChecksumMismatch(t *testing.T) {
+	bundle := "context: team\n"
+	server := teamConfigServer(t, &bundle)
+	dir := t.TempDir()
+
+	cmd := newConfigInitTestCmd(t, "--from-url", server.URL+"/team.yaml", "--checksum", "sha256:"+hex.EncodeToString(make([]byte, 32)))
+	err := configInitRunE(&DefaultConfigProvider{ConfigDir: dir}, &bytes.Buffer{}, cmd, nil)
+	assert.ErrorIs(t, err, config.ErrTeamChecksum)
+	assert.NoFileExists(t, filepath.Join(dir, config.DefaultConfigFileName), "nothing is written")
+}
+
+func TestConfigSyncRunE(t *testing.T) {
+	bundle := "system_prompt: v1\n"
+	server := teamConfigServer(t, &bundle)
+	cfgProvider := &DefaultConfigProvider{ConfigDir: t.TempDir()}
+	now := time.Date(2025, time.April, 16, 9, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	err := configSyncRunE(ctx, nil, cfgProvider, "", now, &bytes.Buffer{})
+	assert.ErrorIs(t, err, config.ErrTeamNotConfigured)
+	assert.ErrorContains(t, err, "tix config init --from-url")
+
+	data, sum, err := fetchTeamConfig(ctx, nil, server.URL+"/team.yaml", "")
+	require.NoError(t, err)
+	require.NoError(t, installTeamConfig(cfgProvider.ConfigDir, data, config.TeamSource{URL: server.URL + "/team.yaml", SHA256: sum}, "Installed", &bytes.Buffer{}))
+
+	var out bytes.Buffer
+	require.NoError(t, configSyncRunE(ctx, nil, cfgProvider, "", now, &out))
+	assert.Equal(t, "The team configuration from "+server.URL+"/team.yaml is up to date.\n", out.String())
+
+	bundle = "system_prompt: v2\n"
+	out.Reset()
+	require.NoError(t, configSyncRunE(ctx, nil, cfgProvider, "", now, &out))
+	assert.Contains(t, out.String(), "Updated the team configuration from "+server.URL+"/team.yaml")
+	assert.Contains(t, out.String(), ": system prompt.\n")
+	prompt, err := cfgProvider.LoadSystemPrompt()
+	require.NoError(t, err)
+	assert.Equal(t, "v2", prompt)
+	source, err := config.LoadTeamSource(cfgProvider.ConfigDir)
+	require.NoError(t, err)
+	assert.Equal(t, now, source.FetchedAt)
+}
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/prompt"
+)
+
+// Usage of the global flags controlling confirmation prompts.
+const (
+	yesFlagUsage     = "Answer yes to every confirmation prompt"
+	noInputFlagUsage = "Never prompt or open an editor; fail where a confirmation would be required unless --yes is given (automatic without a terminal)"
+)
+
+// newConfirmer returns the confirmation prompt of cmd. It reads answers from the command's
+// input and honours the global --yes and --no-input flags; CI mode and running without a
+// terminal, e.g. from cron, imply --no-input (see promptsDisabled).
+func newConfirmer(cmd *cobra.Command) *prompt.Confirmer {
+	yes, _ := cmd.Flags().GetBool("yes")
+	return &prompt.Confirmer{In: cmd.InOrStdin(), AssumeYes: yes, NoInput: promptsDisabled(cmd)}
+}
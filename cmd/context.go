@@ -2,10 +2,8 @@ package cmd
 
 import (
 	"fmt"
-	"os" // Needed for os.IsNotExist, os.Getenv, os.Stdin, os.Stdout, os.Stderr, os.OpenFile, os.O_APPEND, os.O_CREATE, os.O_WRONLY
-	"os/exec"
+	"os" // Needed for os.IsNotExist, os.OpenFile, os.O_APPEND, os.O_CREATE, os.O_WRONLY
 	"path/filepath"
-	"runtime" // Needed for runtime.GOOS
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
@@ -64,6 +62,9 @@ var editCmd = &cobra.Command{
 	Short: "Edit the context file using $EDITOR",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.Debug().Msg("Executing context edit command")
+		if err := checkEditorAllowed(cmd, "tix context edit opens an editor"); err != nil {
+			return fmt.Errorf("%w; use 'tix context add' instead", err)
+		}
 
 		provider, err := GetProvider()
 		if err != nil {
@@ -80,30 +81,8 @@ var editCmd = &cobra.Command{
 		contextFilePath := filepath.Join(configDir, "context.md")
 		log.Debug().Str("path", contextFilePath).Msg("Context file path determined")
 
-		// Determine the editor
-		editor := os.Getenv("EDITOR")
-		if editor == "" {
-			log.Debug().Msg("$EDITOR not set, using default editor for OS")
-			if runtime.GOOS == "windows" {
-				editor = "notepad"
-			} else {
-				editor = "vim" // Sensible default for Linux/macOS
-			}
-		}
-		log.Debug().Str("editor", editor).Msg("Using editor")
-
-		// Prepare the command
-		editorCmd := exec.Command(editor, contextFilePath)
-		editorCmd.Stdin = os.Stdin
-		editorCmd.Stdout = os.Stdout
-		editorCmd.Stderr = os.Stderr
-
-		// Run the editor
-		log.Debug().Msg("Launching editor...")
-		err = editorCmd.Run()
-		if err != nil {
-			log.Error().Err(err).Str("editor", editor).Msg("Editor command failed")
-			return fmt.Errorf("failed to run editor '%s': %w", editor, err)
+		if err := runEditor(contextFilePath); err != nil {
+			return err
 		}
 
 		log.Info().Msg("Editor finished.")
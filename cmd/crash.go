@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/crash"
+	"github.com/karolswdev/ticketron/internal/sanitize"
+)
+
+// crashDirName is the directory below the configuration directory holding crash reports.
+const crashDirName = "crash"
+
+// issuesURL is where users are asked to report crashes.
+const issuesURL = "https://github.com/karolswdev/ticketron/issues"
+
+// crashExitCode is the exit status after a crash, distinct from ordinary command failures.
+const crashExitCode = 2
+
+// recoverCrash is deferred by Execute. On a panic it writes a crash report, tells the user
+// where to find it and exits with crashExitCode.
+func recoverCrash() {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+	reportCrash(recovered, debug.Stack(), os.Args[1:], "", os.Stderr)
+	os.Exit(crashExitCode)
+}
+
+// reportCrash writes a crash report for the panic value recovered to the crash directory
+// below the configuration directory (default or baseDir), falling back to the system's
+// temporary directory, and prints a short message to errOut. It returns the report's path,
+// or "" if no report could be written.
+func reportCrash(recovered any, stack []byte, args []string, baseDir string, errOut io.Writer) string {
+	info := currentVersionInfo()
+	report := crash.Report{
+		Time:      time.Now(),
+		Version:   info.Version,
+		Commit:    info.Commit,
+		GoVersion: info.GoVersion,
+		Platform:  info.Platform,
+		Command:   crashCommandPath(args),
+		Panic:     sanitize.Line(fmt.Sprint(recovered)),
+		Stack:     string(stack),
+	}
+	report.Config, report.ConfigError = crashConfigSummary(baseDir)
+
+	dir := filepath.Join(os.TempDir(), "ticketron-"+crashDirName)
+	if configDir, err := config.EnsureConfigDir(baseDir); err == nil {
+		dir = filepath.Join(configDir, crashDirName)
+	}
+	path, err := crash.Write(dir, report)
+	if err != nil {
+		fmt.Fprintf(errOut, "tix crashed unexpectedly: %s\nThe crash report could not be written (%v):\n\n%s", report.Panic, err, report.String())
+		return ""
+	}
+	fmt.Fprintf(errOut, "tix crashed unexpectedly: %s\nA crash report was written to %s\nPlease attach it when reporting the problem at %s\n", report.Panic, path, issuesURL)
+	return path
+}
+
+// crashCommandPath returns the path of the command args would run, e.g. "tix create".
+// Arguments are left out as they may contain issue descriptions or other user data.
+func crashCommandPath(args []string) string {
+	cmd, _, err := rootCmd.Find(args)
+	if err != nil || cmd == nil {
+		return rootCmd.Name()
+	}
+	return cmd.CommandPath()
+}
+
+// crashConfigSummary returns the settings useful for diagnosing a crash, with URLs reduced
+// to scheme and host and nothing secret, or why the configuration could not be loaded.
+// The configuration may itself be the cause of the crash, so a panic while loading it is
+// recovered too.
+func crashConfigSummary(baseDir string) (summary map[string]string, loadErr string) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			summary, loadErr = nil, sanitize.Line(fmt.Sprintf("panic while loading configuration: %v", recovered))
+		}
+	}()
+	cfg, err := config.LoadConfig(baseDir)
+	if err != nil {
+		return nil, sanitize.Line(err.Error())
+	}
+	return map[string]string{
+		"mcp_server_url":        redactURL(cfg.MCPServerURL),
+		"mcp.tls":               strconv.FormatBool(!cfg.MCP.TLS.IsZero()),
+		"mcp.chaos":             strconv.FormatBool(cfg.MCP.Chaos.Enabled),
+		"llm.provider":          cfg.LLM.Provider,
+		"llm.openai.model_name": cfg.LLM.OpenAI.ModelName,
+		"llm.openai.base_url":   redactURL(cfg.LLM.OpenAI.BaseURL),
+		"llm.fallbacks":         strconv.Itoa(len(cfg.LLM.Fallbacks)),
+		"llm.http":              strconv.FormatBool(!cfg.LLM.HTTP.IsZero()),
+		"llm.chaos":             strconv.FormatBool(cfg.LLM.Chaos.Enabled),
+		"redaction.enabled":     strconv.FormatBool(cfg.Redaction.Enabled),
+		"secrets.backend":       cfg.Secrets.Backend,
+		"timezone":              cfg.Timezone,
+		"description_format":    cfg.DescriptionFormat,
+		"strict":                strconv.FormatBool(cfg.Strict),
+		"version":               strconv.Itoa(cfg.Version),
+	}, ""
+}
+
+// redactURL reduces raw to its scheme and host, dropping credentials, paths and queries.
+func redactURL(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "(invalid URL)"
+	}
+	return u.Scheme + "://" + u.Host
+}
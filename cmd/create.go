@@ -1,10 +1,8 @@
 package cmd
 
 import (
-	"bufio" // Added for interactive confirmation
 	"context"
-	"encoding/json" // Added for JSON output
-	"errors"        // Added for errors.Is
+	"errors" // Added for errors.Is
 	"fmt"
 	"io" // Added for io.Writer
 	"os"
@@ -13,9 +11,20 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/karolswdev/ticketron/internal/cache"
+	"github.com/karolswdev/ticketron/internal/clierr"
 	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/guardrails"
+	"github.com/karolswdev/ticketron/internal/history"
+	"github.com/karolswdev/ticketron/internal/hooks"
+	"github.com/karolswdev/ticketron/internal/i18n"
 	"github.com/karolswdev/ticketron/internal/llm"
 	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/output"
+	"github.com/karolswdev/ticketron/internal/progress"
+	"github.com/karolswdev/ticketron/internal/prompt"
+	"github.com/karolswdev/ticketron/internal/prompts"
+	"github.com/karolswdev/ticketron/internal/redact"
 )
 
 // --- Concrete Implementations of Interfaces ---
@@ -26,12 +35,15 @@ import (
 
 // NOTE: defaultMCPClient and newDefaultMCPClient moved to providers.go
 
+// errDirectModeProject is returned when an issue is given with --summary but no project is known.
+var errDirectModeProject = errors.New("--summary requires --project or default_project in config.yaml")
+
 // DefaultProjectMapper implements the ProjectMapper interface. Exported for tests.
 type DefaultProjectMapper struct{}
 
 func (m *DefaultProjectMapper) MapSuggestionToKey(suggestion string, linksCfg *config.LinksConfig) (string, *config.ProjectLink, error) {
 	if linksCfg == nil || linksCfg.Projects == nil {
-		return "", nil, fmt.Errorf("links configuration is nil or empty")
+		return "", nil, fmt.Errorf("%w: links configuration is nil or empty", config.ErrProjectMappingFailed)
 	}
 	for i := range linksCfg.Projects {
 		link := &linksCfg.Projects[i] // Get pointer
@@ -43,13 +55,18 @@ func (m *DefaultProjectMapper) MapSuggestionToKey(suggestion string, linksCfg *c
 
 	homeDir, _ := os.UserHomeDir()                                     // Best effort
 	expectedPath := filepath.Join(homeDir, ".ticketron", "links.yaml") // Best effort path for logging
-	Log.Error().Str("suggestion", suggestion).Str("expected_links_path", expectedPath).Msg("Mapping failed")
+	// Debug only: callers report the failure or fall back to default_project
+	Log.Debug().Str("suggestion", suggestion).Str("expected_links_path", expectedPath).Msg("Mapping failed")
 	// Return the specific sentinel error, wrapping the suggestion for context in the calling function if needed.
 	return "", nil, config.ErrProjectMappingFailed
 }
 
-// DefaultIssueTypeResolver implements the IssueTypeResolver interface. Exported for tests.
-type DefaultIssueTypeResolver struct{}
+// DefaultIssueTypeResolver implements the IssueTypeResolver and IssueTypeChecker interfaces. Exported for tests.
+type DefaultIssueTypeResolver struct {
+	// AllowedTypes returns the issue types of a project, possibly cached unless refresh is set.
+	// If it is nil or fails, CheckType accepts any type.
+	AllowedTypes func(ctx context.Context, projectKey string, refresh bool) ([]string, error)
+}
 
 const defaultIssueType = "Task" // Hardcoded default
 
@@ -68,6 +85,30 @@ func (r *DefaultIssueTypeResolver) Resolve(flagType string, matchedProjectLink *
 	return defaultIssueType
 }
 
+// CheckType returns an error if issueType (case-insensitive) is not one of the project's issue types.
+// A type missing from a possibly cached list is checked again against a fresh one, so a type
+// added in Jira since the list was cached is not rejected.
+func (r *DefaultIssueTypeResolver) CheckType(ctx context.Context, issueType, projectKey string) error {
+	if r.AllowedTypes == nil {
+		return nil
+	}
+	var types []string
+	for _, refresh := range []bool{false, true} {
+		var err error
+		types, err = r.AllowedTypes(ctx, projectKey, refresh)
+		if err != nil {
+			Log.Debug().Err(err).Str("project_key", projectKey).Msg("Issue types unavailable, not checking --type")
+			return nil
+		}
+		for _, name := range types {
+			if strings.EqualFold(name, issueType) {
+				return nil
+			}
+		}
+	}
+	return unknownIssueTypeError(projectKey, issueType, types)
+}
+
 // --- Helper Functions for createCmdRunner.Run ---
 
 // loadedConfigs holds all necessary configuration data.
@@ -76,6 +117,8 @@ type loadedConfigs struct {
 	linksConfig  *config.LinksConfig
 	systemPrompt string
 	contextData  string
+	redactor     *redact.Redactor       // nil when redaction is disabled
+	guardrails   *guardrails.Guardrails // nil when no guardrail profiles are configured
 }
 
 // loadAllConfigs loads all required configuration files.
@@ -84,56 +127,53 @@ func loadAllConfigs(cp ConfigProvider) (*loadedConfigs, error) {
 	cfg, err := cp.LoadConfig()
 	if err != nil {
 		Log.Error().Err(err).Msg("Failed to load main configuration file (config.yaml)")
-		// Add user message based on error type using switch
+		message := i18n.T(i18n.MsgConfigUnexpected)
 		switch {
 		case errors.Is(err, config.ErrConfigRead), errors.Is(err, config.ErrConfigParse):
-			fmt.Fprintln(os.Stderr, "Error reading or parsing config.yaml. Please check its format and permissions.")
+			message = i18n.T(i18n.MsgConfigParseHint)
 		case errors.Is(err, config.ErrConfigDirCreate), errors.Is(err, config.ErrConfigDirStat), errors.Is(err, config.ErrConfigDirNotDir):
-			fmt.Fprintln(os.Stderr, "Error accessing configuration directory. Please check permissions.")
-		default:
-			fmt.Fprintln(os.Stderr, "An unexpected error occurred loading config.yaml.")
+			message = i18n.T(i18n.MsgConfigDirHint)
 		}
-		fmt.Fprintln(os.Stderr, "You might need to run 'tix config init'.")
-		return nil, err // Return original error
+		return nil, clierr.New(clierr.CodeConfig, message, i18n.T(i18n.MsgRunConfigInit), err)
 	}
 
 	linksCfg, err := cp.LoadLinks()
 	if err != nil {
 		Log.Error().Err(err).Msg("Failed to load links configuration file (links.yaml)")
-		switch {
-		case errors.Is(err, config.ErrLinksRead), errors.Is(err, config.ErrLinksParse):
-			fmt.Fprintln(os.Stderr, "Error reading or parsing links.yaml. Please check its format and permissions.")
-			fmt.Fprintln(os.Stderr, "You might need to run 'tix config init' to create a default.")
-		default:
-			fmt.Fprintln(os.Stderr, "An unexpected error occurred loading links.yaml.")
+		if errors.Is(err, config.ErrLinksRead) || errors.Is(err, config.ErrLinksParse) {
+			return nil, clierr.New(clierr.CodeConfig, i18n.T(i18n.MsgLinksParseHint), i18n.T(i18n.MsgRunConfigInitDefault), err)
 		}
-		return nil, err // Return original error
+		return nil, clierr.New(clierr.CodeConfig, i18n.T(i18n.MsgLinksUnexpected), "", err)
 	}
 
 	systemPrompt, err := cp.LoadSystemPrompt()
 	if err != nil {
 		Log.Error().Err(err).Msg("Failed to load system prompt file (system_prompt.txt)")
-		switch {
-		case errors.Is(err, config.ErrSystemPromptRead):
-			fmt.Fprintln(os.Stderr, "Error reading system_prompt.txt. Please check its permissions.")
-			fmt.Fprintln(os.Stderr, "You might need to run 'tix config init' to create a default.")
-		default:
-			fmt.Fprintln(os.Stderr, "An unexpected error occurred loading system_prompt.txt.")
+		if errors.Is(err, config.ErrSystemPromptRead) {
+			return nil, clierr.New(clierr.CodeConfig, i18n.T(i18n.MsgPromptReadHint), i18n.T(i18n.MsgRunConfigInitDefault), err)
 		}
-		return nil, err // Return original error
+		return nil, clierr.New(clierr.CodeConfig, i18n.T(i18n.MsgPromptUnexpected), "", err)
 	}
 
 	contextData, err := cp.LoadContext()
 	if err != nil {
 		Log.Error().Err(err).Msg("Failed to load context data file (context.md)")
-		switch {
-		case errors.Is(err, config.ErrContextRead):
-			fmt.Fprintln(os.Stderr, "Error reading context.md. Please check its permissions.")
-			fmt.Fprintln(os.Stderr, "You might need to run 'tix config init' to create a default.")
-		default:
-			fmt.Fprintln(os.Stderr, "An unexpected error occurred loading context.md.")
+		if errors.Is(err, config.ErrContextRead) {
+			return nil, clierr.New(clierr.CodeConfig, i18n.T(i18n.MsgContextReadHint), i18n.T(i18n.MsgRunConfigInitDefault), err)
 		}
-		return nil, err // Return original error
+		return nil, clierr.New(clierr.CodeConfig, i18n.T(i18n.MsgContextUnexpected), "", err)
+	}
+
+	redactor, err := redact.New(cfg.Redaction)
+	if err != nil {
+		Log.Error().Err(err).Msg("Invalid redaction configuration")
+		return nil, clierr.New(clierr.CodeConfig, "", i18n.T(i18n.MsgRedactionHint), err)
+	}
+
+	guards, err := loadGuardrails(cfg.Guardrails, linksCfg)
+	if err != nil {
+		Log.Error().Err(err).Msg("Invalid guardrails configuration")
+		return nil, clierr.New(clierr.CodeConfig, "", i18n.T(i18n.MsgGuardrailsHint), err)
 	}
 
 	Log.Debug().Msg("All configurations loaded successfully.")
@@ -142,38 +182,55 @@ func loadAllConfigs(cp ConfigProvider) (*loadedConfigs, error) {
 		linksConfig:  linksCfg,
 		systemPrompt: systemPrompt,
 		contextData:  contextData,
+		redactor:     redactor,
+		guardrails:   guards,
 	}, nil
 }
 
+// loadGuardrails compiles the guardrail profiles and checks that every profile selected
+// in links.yaml exists.
+func loadGuardrails(cfg config.GuardrailsConfig, linksCfg *config.LinksConfig) (*guardrails.Guardrails, error) {
+	guards, err := guardrails.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range linksCfg.Projects {
+		if link.Guardrails == "" {
+			continue
+		}
+		if _, err := guards.Profile(link.Guardrails); err != nil {
+			return nil, fmt.Errorf("links.yaml project %s: %w", link.Key, err)
+		}
+	}
+	return guards, nil
+}
+
 // confirmInteractively prompts the user for confirmation if interactive mode is enabled.
 // Returns true if the user confirms or if interactive mode is off, false if the user aborts.
-// Returns an error only if reading user input fails.
-func confirmInteractively(cmd *cobra.Command, request mcpclient.CreateIssueRequest) (proceed bool, err error) {
+// Returns an error if reading user input fails or prompting is disabled with --no-input.
+func confirmInteractively(cmd *cobra.Command, confirmer *prompt.Confirmer, request mcpclient.CreateIssueRequest) (proceed bool, err error) {
 	interactive, _ := cmd.Flags().GetBool("interactive")
-	if !interactive {
+	if !interactive || confirmer.AssumeYes {
 		return true, nil // Proceed if not interactive
 	}
 
-	fmt.Println("\n--- Issue Details ---")
-	fmt.Printf("Project Key: %s\n", request.ProjectKey)
-	fmt.Printf("Issue Type:  %s\n", request.IssueType)
-	fmt.Printf("Summary:     %s\n", request.Summary)
-	fmt.Printf("Description:\n%s\n", request.Description)
-	fmt.Println("---------------------")
-	fmt.Print("Create this issue? [y/N]: ")
+	out := cmd.OutOrStdout()
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, i18n.T(i18n.MsgIssueDetails, request.ProjectKey, request.IssueType, request.Summary, request.Description))
 
-	reader := bufio.NewReader(os.Stdin)
-	input, err := reader.ReadString('\n')
+	ok, err := confirmer.Confirm(out, i18n.T(i18n.MsgConfirmCreate), false)
+	if errors.Is(err, prompt.ErrInputRequired) {
+		return false, err
+	}
 	if err != nil {
 		Log.Error().Err(err).Msg("Failed to read user input for confirmation")
-		fmt.Println("\nError reading input:", err)
+		fmt.Fprintln(out, "\n"+i18n.T(i18n.MsgInputError, err))
 		return false, err // Return error if input reading fails
 	}
 
-	cleanedInput := strings.ToLower(strings.TrimSpace(input))
-	if cleanedInput != "y" && cleanedInput != "yes" {
+	if !ok {
 		Log.Info().Msg("User aborted issue creation.")
-		fmt.Println("Aborted.")
+		fmt.Fprintln(out, i18n.T(i18n.MsgAborted))
 		return false, nil // User aborted, no error
 	}
 
@@ -182,26 +239,21 @@ func confirmInteractively(cmd *cobra.Command, request mcpclient.CreateIssueReque
 }
 
 // formatOutput formats the successful creation response based on the output flag.
-// Updated signature to accept io.Writer
 func formatOutput(cmd *cobra.Command, resp *mcpclient.CreateIssueResponse, out io.Writer) error {
 	outputFormat, _ := cmd.Flags().GetString("output")
 	Log.Debug().Str("format", outputFormat).Msg("Processing output for created issue")
 
-	if outputFormat == "json" {
-		Log.Debug().Msg("Marshalling created issue to JSON")
-		jsonData, err := json.MarshalIndent(resp, "", "  ")
-		if err != nil {
-			Log.Error().Err(err).Msg("Failed to marshal created issue to JSON")
+	if format := output.Normalize(outputFormat); output.IsStructured(format) {
+		if err := output.Structured(out, format, resp); err != nil {
+			Log.Error().Err(err).Msg("Failed to marshal created issue")
 			// Return error, but log that the issue was created
-			return fmt.Errorf("issue created successfully (Key: %s), but failed to format result as JSON: %w", resp.Key, err)
+			return fmt.Errorf("issue created successfully (Key: %s), but %w", resp.Key, err)
 		}
-		fmt.Fprintln(out, string(jsonData)) // Use fmt.Fprintln with out
-	} else {
-		// Default to text output
-		Log.Debug().Msg("Formatting created issue as text")
-		// Use fmt.Fprintf with out
-		fmt.Fprintf(out, "Successfully created JIRA issue:\nKey: %s\nURL: %s\n", resp.Key, resp.Self)
+		return nil
 	}
+
+	// Default to text output
+	fmt.Fprintf(out, "Successfully created JIRA issue:\nKey: %s\nURL: %s\n", resp.Key, resp.Self)
 	return nil
 }
 
@@ -214,6 +266,30 @@ type createCmdRunner struct {
 	mcpClient         MCPClient  // Use the MCPClient interface directly
 	projectMapper     ProjectMapper
 	issueTypeResolver IssueTypeResolver
+
+	// llmFactory builds a replacement LLM client for --provider/--model and generation
+	// parameter overrides.
+	llmFactory func(o llmOverrides) (llm.Client, error)
+
+	// history records created issues and promptLibrary identifies the prompt version used.
+	// A nil history disables recording.
+	history       *history.Store
+	promptLibrary *prompts.Library
+
+	// spinner shows progress during the LLM and Jira calls. A nil spinner shows nothing,
+	// as for the non-CLI entry points.
+	spinner *progress.Spinner
+
+	// mappings records project corrections and serves the learned ones. A nil store
+	// disables learning.
+	mappings *history.MappingStore
+
+	// metaCache holds project create metadata between runs. A nil cache fetches it every time.
+	metaCache *cache.Store
+
+	// hooks runs the commands configured to run before and after an issue is created. A nil
+	// value runs none.
+	hooks *hooks.Hooks
 }
 
 // newCreateCmdRunner creates a new runner, fetching dependencies from the central Provider.
@@ -234,13 +310,45 @@ func newCreateCmdRunner() (*createCmdRunner, error) {
 	}
 	// LLM and MCP clients might be nil if config/API keys are missing, commands should handle this.
 
-	return &createCmdRunner{
+	var historyStore *history.Store
+	var promptLibrary *prompts.Library
+	var metaCache *cache.Store
+	var mappings *history.MappingStore
+	if configDir, err := provider.Config.EnsureConfigDir(); err != nil {
+		Log.Debug().Err(err).Msg("Config directory unavailable, history will not be recorded")
+	} else {
+		historyStore = history.NewStore(configDir)
+		promptLibrary = prompts.New(configDir)
+		metaCache = cache.New(filepath.Join(configDir, "cache"), createMetaCacheTTL)
+		mappings = history.NewMappingStore(configDir)
+	}
+
+	var createHooks *hooks.Hooks
+	if cfg, err := provider.Config.LoadConfig(); err != nil {
+		Log.Debug().Err(err).Msg("Config unavailable, no hooks will run")
+	} else if createHooks, err = hooks.New(cfg.Hooks); err != nil {
+		return nil, clierr.New(clierr.CodeConfig, "", "", err)
+	}
+
+	resolver := &DefaultIssueTypeResolver{}
+	runner := &createCmdRunner{
 		configProvider:    provider.Config,
-		llmClient:         provider.LLM,                // Get from central provider
-		mcpClient:         provider.MCP,                // Get from central provider
-		projectMapper:     &DefaultProjectMapper{},     // Use exported type
-		issueTypeResolver: &DefaultIssueTypeResolver{}, // Use exported type
-	}, nil
+		llmClient:         provider.LLM,            // Get from central provider
+		mcpClient:         provider.MCP,            // Get from central provider
+		projectMapper:     &DefaultProjectMapper{}, // Use exported type
+		issueTypeResolver: resolver,                // Use exported type
+		llmFactory: func(o llmOverrides) (llm.Client, error) {
+			return newLLMClientWithOverrides(provider.Config, o)
+		},
+		history:       historyStore,
+		promptLibrary: promptLibrary,
+		metaCache:     metaCache,
+		mappings:      mappings,
+		hooks:         createHooks,
+	}
+	// Check --type against the project's issue types, sharing the runner's metadata cache
+	resolver.AllowedTypes = runner.allowedIssueTypes
+	return runner, nil
 }
 
 // NewCreateCmdRunnerForTest creates a runner with explicitly provided dependencies for testing.
@@ -254,70 +362,433 @@ func NewCreateCmdRunnerForTest(cp ConfigProvider, llmClient llm.Client, mcpClien
 	}
 }
 
-// Run executes the logic for the create command using injected dependencies.
-func (r *createCmdRunner) Run(cmd *cobra.Command, args []string) error {
-	// Load configurations using helper
-	loadedCfgs, err := loadAllConfigs(r.configProvider)
-	if err != nil {
-		// Specific user messages added in loadAllConfigs
-		// Logged there too, just return the error for Cobra
-		return err
+// issueRequestOptions carries per-invocation overrides for buildIssueRequest.
+type issueRequestOptions struct {
+	issueType  string // Explicit issue type (e.g. from --type), overrides link defaults
+	projectKey string // Explicit project key or links.yaml name, skips mapping the LLM's project suggestion
+	priority   string
+	dueDate    string                 // Due date expression (e.g. from --due), overrides the LLM's proposal
+	fields     map[string]interface{} // Additional fields keyed by field ID or name
+
+	// summary and description are used as given by buildDirectIssueRequest (--summary).
+	summary     string
+	description string
+
+	// projectSuggestion, when non-nil, receives the LLM's project suggestion, so the caller
+	// can record projectKey as the user's correction of it once the issue is created. Only
+	// set for choices made by a person (tix create --project), not for configured projects.
+	projectSuggestion *string
+
+	// descriptionFormat overrides description_format from config.yaml (e.g. from --description-format).
+	descriptionFormat string
+
+	// conversation, when non-nil, holds the messages of a --refine session. If it is empty
+	// the ticket is generated as usual and the conversation is started; otherwise the LLM is
+	// sent the whole conversation. The LLM's reply is appended either way.
+	conversation *[]llm.Message
+}
+
+// descriptionFormatFor returns the format descriptions are sent in: override if set, otherwise
+// description_format from config.yaml. Text is returned as the empty format, which leaves the
+// request unchanged for MCP servers that do not know the field.
+func descriptionFormatFor(cfgs *loadedConfigs, override string) (mcpclient.DescriptionFormat, error) {
+	name := override
+	if name == "" && cfgs != nil && cfgs.appConfig != nil {
+		name = cfgs.appConfig.DescriptionFormat
 	}
+	format, err := mcpclient.ParseDescriptionFormat(name)
+	if err != nil || format == mcpclient.DescriptionFormatText {
+		return "", err
+	}
+	return format, nil
+}
 
-	// --- LLM Interaction ---
-	userInput := strings.Join(args, " ")
-	ctx := context.Background() // Create context for LLM and MCP calls
+// resolveProject returns the project key named by ref, a key or a links.yaml name, and its
+// links.yaml entry. Keys take precedence; a ref matching no entry is returned unchanged
+// with a nil entry.
+func resolveProject(linksCfg *config.LinksConfig, ref string) (string, *config.ProjectLink) {
+	if link := findLinkByKey(linksCfg, ref); link != nil {
+		return link.Key, link
+	}
+	if linksCfg != nil {
+		for i := range linksCfg.Projects {
+			if strings.EqualFold(linksCfg.Projects[i].Name, ref) {
+				return linksCfg.Projects[i].Key, &linksCfg.Projects[i]
+			}
+		}
+	}
+	return ref, nil
+}
 
+// projectAliases converts the links.yaml entries into the aliases offered to the LLM.
+func projectAliases(linksCfg *config.LinksConfig) []llm.ProjectAlias {
+	if linksCfg == nil {
+		return nil
+	}
+	aliases := make([]llm.ProjectAlias, 0, len(linksCfg.Projects))
+	for _, link := range linksCfg.Projects {
+		aliases = append(aliases, llm.ProjectAlias{Name: link.Name, Key: link.Key, DefaultIssueType: link.DefaultIssueType})
+	}
+	return aliases
+}
+
+// findLinkByKey returns the links.yaml entry whose key matches projectKey (case-insensitive), or nil.
+func findLinkByKey(linksCfg *config.LinksConfig, projectKey string) *config.ProjectLink {
+	if linksCfg == nil {
+		return nil
+	}
+	for i := range linksCfg.Projects {
+		if strings.EqualFold(linksCfg.Projects[i].Key, projectKey) {
+			return &linksCfg.Projects[i]
+		}
+	}
+	return nil
+}
+
+// buildIssueRequest runs the LLM-driven part of the create workflow: it generates ticket
+// details from userInput, maps the suggested project to a key and resolves the final issue type.
+// Warnings are written to errOut, failures are returned as *clierr.Error with a hint on how
+// to fix them. It is shared by the create command
+// and the non-CLI entry points (e.g. mcp-serve) so every caller gets the same behavior.
+func (r *createCmdRunner) buildIssueRequest(ctx context.Context, errOut io.Writer, loadedCfgs *loadedConfigs, userInput string, opts issueRequestOptions) (mcpclient.CreateIssueRequest, error) {
 	// Check if LLM Client was initialized
 	if r.llmClient == nil {
 		err := fmt.Errorf("LLM client not initialized. Check configuration (provider, API key)")
-		Log.Error().Err(err).Msg("LLM client is nil in createCmdRunner.Run")
-		fmt.Fprintln(cmd.ErrOrStderr(), "Error: LLM client not initialized.")
-		fmt.Fprintln(cmd.ErrOrStderr(), "Please check your LLM provider configuration and API key setup ('tix config show', 'tix config set-key').")
-		return err
+		Log.Error().Err(err).Msg("LLM client is nil in createCmdRunner.buildIssueRequest")
+		return mcpclient.CreateIssueRequest{}, clierr.New(clierr.CodeLLMNotConfigured, i18n.T(i18n.MsgLLMNotInitialized), i18n.T(i18n.MsgLLMConfigHint), err)
+	}
+
+	descriptionFormat, err := descriptionFormatFor(loadedCfgs, opts.descriptionFormat)
+	if err != nil {
+		return mcpclient.CreateIssueRequest{}, clierr.New(clierr.CodeInvalidInput, "", "", err)
+	}
+
+	dueDate, err := dueDateFor(loadedCfgs, opts.dueDate)
+	if err != nil {
+		return mcpclient.CreateIssueRequest{}, clierr.New(clierr.CodeInvalidInput, "", i18n.T(i18n.MsgDueDateHint), err)
+	}
+
+	postProcessing := loadedCfgs.appConfig.LLM.PostProcessing
+	postOpts := llm.PostProcessOptions{SummaryMaxLength: postProcessing.SummaryMaxLength, ImperativeSummary: postProcessing.ImperativeSummary}
+	if err := postOpts.Validate(); err != nil {
+		return mcpclient.CreateIssueRequest{}, clierr.New(clierr.CodeConfig, "", "", err)
+	}
+
+	var mappedProjectKey string
+	var matchedProjectLink *config.ProjectLink
+	if opts.projectKey != "" {
+		// An explicit project bypasses mapping the LLM's suggestion entirely
+		mappedProjectKey, matchedProjectLink = resolveProject(loadedCfgs.linksConfig, opts.projectKey)
+		opts.projectKey = mappedProjectKey
+		Log.Debug().Str("project_key", mappedProjectKey).Msg("Using explicitly provided project key")
+	}
+
+	// With both the project and the type known up front, reject an invalid type before calling the LLM
+	if err := r.checkIssueType(ctx, opts.issueType, opts.projectKey); err != nil {
+		return mcpclient.CreateIssueRequest{}, clierr.New(clierr.CodeInvalidInput, "", i18n.T(i18n.MsgTypesHint, opts.projectKey), err)
+	}
+
+	// Scrub sensitive data before it leaves the machine
+	llmInput, inputRedactions := loadedCfgs.redactor.Redact(userInput)
+	llmContext, contextRedactions := loadedCfgs.redactor.Redact(loadedCfgs.contextData)
+	if n := len(inputRedactions) + len(contextRedactions); n > 0 {
+		Log.Info().Int("redactions", n).Msg("Redacted sensitive data before calling the LLM")
+	}
+	if opts.projectKey == "" {
+		// Constrain the suggestion to the configured aliases so it maps cleanly
+		llmContext = llm.WithProjectAliases(llmContext, projectAliases(loadedCfgs.linksConfig))
 	}
 
 	// Call LLM Client
 	Log.Debug().Msg("Calling LLM client to generate ticket details...")
-	llmResponse, err := r.llmClient.GenerateTicketDetails(ctx, userInput, loadedCfgs.systemPrompt, loadedCfgs.contextData)
+	stopSpinner := r.spinner.Start(i18n.T(i18n.MsgProgressLLM))
+	var llmResponse llm.LLMResponse
+	if opts.conversation != nil && len(*opts.conversation) > 0 {
+		llmResponse, err = r.llmClient.GenerateFromMessages(ctx, *opts.conversation)
+	} else {
+		llmResponse, err = r.llmClient.GenerateTicketDetails(ctx, llmInput, loadedCfgs.systemPrompt, llmContext)
+		if err == nil && opts.conversation != nil {
+			*opts.conversation = llm.ConstructMessages(llmInput, loadedCfgs.systemPrompt, llmContext)
+		}
+	}
+	stopSpinner()
 	if err != nil {
 		Log.Error().Err(err).Msg("LLM client GenerateTicketDetails failed")
-		// Provide user feedback based on error type using switch
-		switch {
-		case errors.Is(err, config.ErrAPIKeyNotFound):
-			fmt.Fprintln(cmd.ErrOrStderr(), "Error: LLM API key not found.")
-			fmt.Fprintf(cmd.ErrOrStderr(), "Please store it using 'tix config set-key <your-key>' or set the %s environment variable.\n", config.EnvAPIKeyName)
-		case errors.Is(err, llm.ErrLLMCompletion):
-			fmt.Fprintf(cmd.ErrOrStderr(), "Error communicating with the LLM API: %v\n", err)
-			fmt.Fprintln(cmd.ErrOrStderr(), "Please check your network connection and API key/endpoint configuration.")
-		case errors.Is(err, llm.ErrLLMResponseParse), errors.Is(err, llm.ErrLLMResponseJSONFind), errors.Is(err, llm.ErrLLMResponseJSONUnmarshal), errors.Is(err, llm.ErrLLMResponseMissingField):
-			fmt.Fprintf(cmd.ErrOrStderr(), "Error processing the response from the LLM: %v\n", err)
-			fmt.Fprintln(cmd.ErrOrStderr(), "The LLM might have returned an unexpected format. Check logs for details.")
-		default:
-			fmt.Fprintf(cmd.ErrOrStderr(), "An unexpected error occurred during LLM processing: %v\n", err)
-		}
-		return err // Return the original error
+		return mcpclient.CreateIssueRequest{}, llmError(err)
 	}
 	Log.Info().Msg("LLM processing successful.") // Simplified log message
+	if processed := llm.PostProcess(llmResponse, postOpts); processed != llmResponse {
+		Log.Debug().Str("summary", processed.Summary).Str("llm_summary", llmResponse.Summary).Msg("Post-processed LLM response")
+		llmResponse = processed
+	}
+	if opts.conversation != nil {
+		*opts.conversation = append(*opts.conversation, llm.AssistantMessage(llmResponse))
+	}
 
 	// --- Map Project Name Suggestion ---
-	mappedProjectKey, matchedProjectLink, err := r.projectMapper.MapSuggestionToKey(llmResponse.ProjectNameSuggestion, loadedCfgs.linksConfig)
+	learnedKey, learned := "", false
+	if opts.projectKey != "" {
+		if opts.projectSuggestion != nil {
+			*opts.projectSuggestion = llmResponse.ProjectNameSuggestion
+		}
+	} else if learnedKey, learned = r.learnedProject(llmResponse.ProjectNameSuggestion); learned {
+		// Users repeatedly picked another project for this suggestion; trust them over links.yaml
+		mappedProjectKey, matchedProjectLink = resolveProject(loadedCfgs.linksConfig, learnedKey)
+		Log.Info().Str("suggestion", llmResponse.ProjectNameSuggestion).Str("project_key", mappedProjectKey).Msg("Using learned project mapping")
+	}
+	if opts.projectKey == "" && !learned {
+		mappedProjectKey, matchedProjectLink, err = r.projectMapper.MapSuggestionToKey(llmResponse.ProjectNameSuggestion, loadedCfgs.linksConfig)
+		if errors.Is(err, config.ErrProjectMappingFailed) && loadedCfgs.appConfig != nil && loadedCfgs.appConfig.DefaultProject != "" {
+			mappedProjectKey, matchedProjectLink = resolveProject(loadedCfgs.linksConfig, loadedCfgs.appConfig.DefaultProject)
+			Log.Info().Str("suggestion", llmResponse.ProjectNameSuggestion).Str("project_key", mappedProjectKey).Msg("Using default_project")
+			fmt.Fprintln(errOut, i18n.T(i18n.MsgProjectDefaultUsed, llmResponse.ProjectNameSuggestion, mappedProjectKey))
+			err = nil
+		}
+		if err != nil {
+			// Logged in MapSuggestionToKey, just return
+			if errors.Is(err, config.ErrProjectMappingFailed) {
+				return mcpclient.CreateIssueRequest{}, clierr.New(clierr.CodeProjectMapping, i18n.T(i18n.MsgProjectMapError, llmResponse.ProjectNameSuggestion), i18n.T(i18n.MsgProjectLinksHint), err)
+			}
+			return mcpclient.CreateIssueRequest{}, clierr.New(clierr.CodeProjectMapping, i18n.T(i18n.MsgProjectMapUnexpected, err), "", err)
+		}
+	}
+
+	// --- Determine Final Issue Type ---
+	// The LLM's suggestion is used like --type, but only if the project has that type
+	issueTypeChoice := opts.issueType
+	if issueTypeChoice == "" && llmResponse.IssueType != "" {
+		if err := r.checkIssueType(ctx, llmResponse.IssueType, mappedProjectKey); err != nil {
+			Log.Debug().Err(err).Str("issue_type", llmResponse.IssueType).Msg("Ignoring issue type suggested by the LLM")
+		} else {
+			Log.Debug().Str("issue_type", llmResponse.IssueType).Msg("Using issue type suggested by the LLM")
+			issueTypeChoice = llmResponse.IssueType
+		}
+	}
+	finalIssueType := r.issueTypeResolver.Resolve(issueTypeChoice, matchedProjectLink, mappedProjectKey)
+	Log.Debug().Str("final_issue_type", finalIssueType).Msg("Determined final issue type")
+	if opts.projectKey == "" {
+		if err := r.checkIssueType(ctx, opts.issueType, mappedProjectKey); err != nil {
+			return mcpclient.CreateIssueRequest{}, clierr.New(clierr.CodeInvalidInput, "", i18n.T(i18n.MsgTypesHint, mappedProjectKey), err)
+		}
+	}
+
+	if dueDate == "" && llmResponse.DueDate != "" && loadedCfgs.appConfig.LLM.ProposeDueDate {
+		if dueDate, err = dueDateFor(loadedCfgs, llmResponse.DueDate); err != nil {
+			Log.Debug().Err(err).Str("due_date", llmResponse.DueDate).Msg("Ignoring due date proposed by the LLM")
+		} else {
+			Log.Debug().Str("due_date", dueDate).Msg("Using due date proposed by the LLM")
+		}
+	}
+
+	summary, description, err := r.applyGuardrails(errOut, loadedCfgs, matchedProjectLink, llmResponse)
 	if err != nil {
-		switch {
-		case errors.Is(err, config.ErrProjectMappingFailed):
-			fmt.Fprintf(cmd.ErrOrStderr(), "Error: Could not map LLM's project suggestion '%s' to a known project key.\n", llmResponse.ProjectNameSuggestion)
-			fmt.Fprintln(cmd.ErrOrStderr(), "Please check your ~/.ticketron/links.yaml file or the LLM's output.")
-		default:
-			fmt.Fprintf(cmd.ErrOrStderr(), "An unexpected error occurred during project mapping: %v\n", err)
+		return mcpclient.CreateIssueRequest{}, err
+	}
+
+	// Prepare CreateIssue Request
+	request := mcpclient.CreateIssueRequest{
+		ProjectKey:        mappedProjectKey,
+		Summary:           summary,
+		Description:       description,
+		IssueType:         finalIssueType,
+		Priority:          opts.priority,
+		DueDate:           dueDate,
+		Fields:            opts.fields,
+		DescriptionFormat: descriptionFormat,
+	}
+	if loadedCfgs.appConfig != nil {
+		addRelatedIssues(ctx, r.mcpClient, loadedCfgs.appConfig.AutoLink, userInput, &request)
+	}
+	request.IdempotencyKey = idempotencyKey(userInput, request.ProjectKey)
+	Log.Debug().Interface("mcp_request", request).Msg("Prepared MCP request")
+	return request, nil
+}
+
+// applyGuardrails checks the generated summary and description against the guardrail
+// profile of the project, or the default profile. It returns them, redacted if the
+// profile says so, or an error if the profile blocks them.
+func (r *createCmdRunner) applyGuardrails(errOut io.Writer, loadedCfgs *loadedConfigs, projectLink *config.ProjectLink, llmResponse llm.LLMResponse) (string, string, error) {
+	profileName := ""
+	if projectLink != nil {
+		profileName = projectLink.Guardrails
+	}
+	profile, err := loadedCfgs.guardrails.Profile(profileName)
+	if err != nil {
+		return "", "", clierr.New(clierr.CodeConfig, "", i18n.T(i18n.MsgGuardrailsHint), err)
+	}
+	summary, description, findings, err := profile.Apply(llmResponse.Summary, llmResponse.Description)
+	if err != nil {
+		Log.Warn().Err(err).Int("findings", len(findings)).Msg("Generated ticket blocked by guardrails")
+		return "", "", clierr.New(clierr.CodeGuardrails, "", i18n.T(i18n.MsgGuardrailsBlockedHint), err)
+	}
+	if len(findings) > 0 {
+		Log.Warn().Str("profile", profile.Name).Int("findings", len(findings)).Msg("Redacted generated ticket content matched by guardrails")
+		fmt.Fprintln(errOut, i18n.T(i18n.MsgGuardrailsRedacted, profile.Name, len(findings), guardrails.Describe(findings)))
+	}
+	return summary, description, nil
+}
+
+// llmError wraps err, returned by the LLM client, with what went wrong and how to fix it.
+func llmError(err error) error {
+	switch {
+	case errors.Is(err, config.ErrAPIKeyNotFound):
+		return clierr.New(clierr.CodeAPIKeyMissing, i18n.T(i18n.MsgAPIKeyNotFound), i18n.T(i18n.MsgAPIKeyHint, config.EnvAPIKeyName), err)
+	case errors.Is(err, llm.ErrLLMCompletion):
+		return clierr.New(clierr.CodeLLMAPI, i18n.T(i18n.MsgLLMAPIError, err), i18n.T(i18n.MsgLLMNetworkHint), err)
+	case errors.Is(err, llm.ErrLLMResponseParse), errors.Is(err, llm.ErrLLMResponseJSONFind), errors.Is(err, llm.ErrLLMResponseJSONUnmarshal), errors.Is(err, llm.ErrLLMResponseMissingField), errors.Is(err, llm.ErrLLMResponseSchema):
+		return clierr.New(clierr.CodeLLMResponse, i18n.T(i18n.MsgLLMResponseError, err), i18n.T(i18n.MsgLLMFormatHint), err)
+	default:
+		return clierr.New(clierr.CodeLLM, i18n.T(i18n.MsgLLMUnexpected, err), "", err)
+	}
+}
+
+// mcpCreateError wraps err, returned by CreateIssue, with what went wrong and how to fix it.
+func mcpCreateError(err error) error {
+	switch {
+	case errors.Is(err, mcpclient.ErrRequestExecute):
+		return clierr.New(clierr.CodeMCPConnect, i18n.T(i18n.MsgMCPConnectError, err), i18n.T(i18n.MsgMCPConnectHint), err)
+	case errors.Is(err, mcpclient.ErrMCPServerError):
+		return clierr.New(clierr.CodeMCPServer, i18n.T(i18n.MsgMCPServerError, err), "", err) // Error includes server message
+	case errors.Is(err, mcpclient.ErrMCPServerErrorUnparseable):
+		return clierr.New(clierr.CodeMCPServer, i18n.T(i18n.MsgMCPUnparseable, err), "", err)
+	case errors.Is(err, mcpclient.ErrResponseDecode):
+		return clierr.New(clierr.CodeMCPResponse, i18n.T(i18n.MsgMCPCreateDecode, err), "", err)
+	default:
+		return clierr.New(clierr.CodeMCP, i18n.T(i18n.MsgMCPCreateUnexpected, err), "", err)
+	}
+}
+
+// refineIssueRequest runs the --refine loop: it generates the ticket like buildIssueRequest,
+// shows it on out and asks for follow-up instructions, which are sent to the LLM as further
+// turns of the conversation until the user accepts the ticket with an empty answer.
+func (r *createCmdRunner) refineIssueRequest(ctx context.Context, confirmer *prompt.Confirmer, out, errOut io.Writer, loadedCfgs *loadedConfigs, userInput string, opts issueRequestOptions) (mcpclient.CreateIssueRequest, error) {
+	var conversation []llm.Message
+	opts.conversation = &conversation
+	for {
+		request, err := r.buildIssueRequest(ctx, errOut, loadedCfgs, userInput, opts)
+		if err != nil {
+			return request, err
+		}
+
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, i18n.T(i18n.MsgIssueDetails, request.ProjectKey, request.IssueType, request.Summary, request.Description))
+		instruction, err := confirmer.Ask(out, i18n.T(i18n.MsgRefinePrompt))
+		if err != nil {
+			fmt.Fprintln(errOut, i18n.T(i18n.MsgInputError, err))
+			return mcpclient.CreateIssueRequest{}, err
 		}
-		// Logged in MapSuggestionToKey, just return
+		if instruction == "" {
+			return request, nil
+		}
+		instruction, _ = loadedCfgs.redactor.Redact(instruction)
+		Log.Debug().Int("round", len(conversation)/2).Msg("Refining ticket with follow-up instruction")
+		conversation = append(conversation, llm.Message{Role: llm.RoleUser, Content: instruction})
+	}
+}
+
+// buildDirectIssueRequest builds the request for the direct mode of the create command
+// (--summary): the summary and description are used as given and the LLM is not called,
+// so it works without an API key. The project comes from opts.projectKey or default_project,
+// the issue type from the usual resolver. Failures are returned as *clierr.Error.
+func (r *createCmdRunner) buildDirectIssueRequest(ctx context.Context, loadedCfgs *loadedConfigs, opts issueRequestOptions) (mcpclient.CreateIssueRequest, error) {
+	projectRef := opts.projectKey
+	if projectRef == "" && loadedCfgs.appConfig != nil {
+		projectRef = loadedCfgs.appConfig.DefaultProject
+	}
+	if projectRef == "" {
+		return mcpclient.CreateIssueRequest{}, clierr.New(clierr.CodeInvalidInput, "", "", errDirectModeProject)
+	}
+	projectKey, link := resolveProject(loadedCfgs.linksConfig, projectRef)
+
+	descriptionFormat, err := descriptionFormatFor(loadedCfgs, opts.descriptionFormat)
+	if err != nil {
+		return mcpclient.CreateIssueRequest{}, clierr.New(clierr.CodeInvalidInput, "", "", err)
+	}
+
+	dueDate, err := dueDateFor(loadedCfgs, opts.dueDate)
+	if err != nil {
+		return mcpclient.CreateIssueRequest{}, clierr.New(clierr.CodeInvalidInput, "", i18n.T(i18n.MsgDueDateHint), err)
+	}
+
+	issueType := r.issueTypeResolver.Resolve(opts.issueType, link, projectKey)
+	if err := r.checkIssueType(ctx, opts.issueType, projectKey); err != nil {
+		return mcpclient.CreateIssueRequest{}, clierr.New(clierr.CodeInvalidInput, "", i18n.T(i18n.MsgTypesHint, projectKey), err)
+	}
+
+	request := mcpclient.CreateIssueRequest{
+		ProjectKey:        projectKey,
+		Summary:           opts.summary,
+		Description:       opts.description,
+		IssueType:         issueType,
+		Priority:          opts.priority,
+		DueDate:           dueDate,
+		Fields:            opts.fields,
+		DescriptionFormat: descriptionFormat,
+	}
+	request.IdempotencyKey = idempotencyKey(requestInput(request), request.ProjectKey)
+	Log.Debug().Interface("mcp_request", request).Msg("Prepared MCP request without the LLM")
+	return request, nil
+}
+
+// Run executes the logic for the create command using injected dependencies.
+func (r *createCmdRunner) Run(cmd *cobra.Command, args []string) error {
+	if err := r.applyLLMOverrides(cmd); err != nil {
 		return err
 	}
 
-	// --- Determine Final Issue Type ---
+	// Load configurations using helper
+	loadedCfgs, err := loadAllConfigs(r.configProvider)
+	if err != nil {
+		// Specific user messages added in loadAllConfigs
+		// Logged there too, just return the error for Cobra
+		return err
+	}
+
+	// --- LLM Interaction ---
+	userInput := strings.Join(args, " ")
+	ctx := context.Background()                       // Create context for LLM and MCP calls
 	issueTypeFlag, _ := cmd.Flags().GetString("type") // Ignore error, default is ""
-	finalIssueType := r.issueTypeResolver.Resolve(issueTypeFlag, matchedProjectLink, mappedProjectKey)
-	Log.Debug().Str("final_issue_type", finalIssueType).Msg("Determined final issue type")
+
+	if showRedactions, _ := cmd.Flags().GetBool("show-redactions"); showRedactions {
+		return previewRedactions(cmd.OutOrStdout(), loadedCfgs, userInput)
+	}
+
+	r.spinner = newSpinner(cmd)
+	confirmer := newConfirmer(cmd) // Shared by --refine and --interactive, which read the same input
+	opts := issueRequestOptions{issueType: issueTypeFlag}
+	opts.projectKey, _ = cmd.Flags().GetString("project")
+	var projectSuggestion string
+	if opts.projectKey != "" {
+		opts.projectSuggestion = &projectSuggestion
+	}
+	opts.priority, _ = cmd.Flags().GetString("priority")
+	opts.dueDate, _ = cmd.Flags().GetString("due")
+	opts.descriptionFormat, _ = cmd.Flags().GetString("description-format")
+	fieldFlags, _ := cmd.Flags().GetStringArray("field")
+	if opts.fields, err = parseFieldFlags(fieldFlags); err != nil {
+		return err
+	}
+
+	opts.summary, _ = cmd.Flags().GetString("summary")
+	opts.summary = strings.TrimSpace(opts.summary)
+	systemPrompt := loadedCfgs.systemPrompt
+	var request mcpclient.CreateIssueRequest
+	if opts.summary != "" {
+		// Direct mode: the arguments, if any, are the description unless --description is given
+		opts.description, _ = cmd.Flags().GetString("description")
+		if opts.description == "" {
+			opts.description = userInput
+		}
+		systemPrompt = "" // No prompt version is recorded for issues written without the LLM
+		request, err = r.buildDirectIssueRequest(ctx, loadedCfgs, opts)
+	} else if refine, _ := cmd.Flags().GetBool("refine"); refine {
+		request, err = r.refineIssueRequest(ctx, confirmer, cmd.OutOrStdout(), cmd.ErrOrStderr(), loadedCfgs, userInput, opts)
+	} else {
+		request, err = r.buildIssueRequest(ctx, cmd.ErrOrStderr(), loadedCfgs, userInput, opts)
+	}
+	if err != nil {
+		return err // Hints are rendered by Execute
+	}
 
 	// --- MCP Client Interaction ---
 	Log.Debug().Msg("Preparing to call MCP server...")
@@ -326,23 +797,25 @@ func (r *createCmdRunner) Run(cmd *cobra.Command, args []string) error {
 	if r.mcpClient == nil {
 		err := fmt.Errorf("MCP client not initialized. Check MCP server URL configuration")
 		Log.Error().Err(err).Msg("MCP client is nil in createCmdRunner.Run")
-		fmt.Fprintln(cmd.ErrOrStderr(), "Error: MCP client not initialized.")
-		fmt.Fprintln(cmd.ErrOrStderr(), "Please check the 'mcp_server_url' in your configuration ('tix config show').")
-		return err
+		return clierr.New(clierr.CodeMCPNotConfigured, i18n.T(i18n.MsgMCPNotInitialized), i18n.T(i18n.MsgMCPConfigHint), err)
 	}
 	// Use the injected MCP client directly: r.mcpClient
 
-	// Prepare CreateIssue Request
-	request := mcpclient.CreateIssueRequest{
-		ProjectKey:  mappedProjectKey,
-		Summary:     llmResponse.Summary,
-		Description: llmResponse.Description,
-		IssueType:   finalIssueType,
+	// Catch invalid issue types, values and missing required fields before asking for confirmation
+	request, err = r.validateIssueRequest(ctx, request)
+	if err != nil {
+		return clierr.New(clierr.CodeInvalidInput, "", i18n.T(i18n.MsgFieldsHint, request.ProjectKey), err)
 	}
-	Log.Debug().Interface("mcp_request", request).Msg("Prepared MCP request")
+
+	if forceNew, _ := cmd.Flags().GetBool("new"); forceNew {
+		request.IdempotencyKey = "" // Nothing for the MCP server to deduplicate against
+	}
+	// Retries of this invocation are expected to be deduplicated by the MCP server; point
+	// them out before confirmation all the same
+	r.warnRecentCreate(request, cmd.ErrOrStderr())
 
 	// --- Interactive Confirmation ---
-	proceed, err := confirmInteractively(cmd, request)
+	proceed, err := confirmInteractively(cmd, confirmer, request)
 	if err != nil {
 		// Error reading input
 		return err
@@ -352,35 +825,40 @@ func (r *createCmdRunner) Run(cmd *cobra.Command, args []string) error {
 		return nil // Graceful exit
 	}
 
+	// Mentions are expanded after confirmation so the preview shows the readable handles
+	request.Description = expandMentions(ctx, r.mcpClient, request.Description)
+
+	if err := r.runPreCreateHooks(ctx, request); err != nil {
+		return err
+	}
+
 	// Call CreateIssue
 	Log.Debug().Msg("Creating JIRA issue via MCP...")
+	stopSpinner := r.spinner.Start(i18n.T(i18n.MsgProgressCreate))
 	resp, err := r.mcpClient.CreateIssue(ctx, request) // Use r.mcpClient
+	stopSpinner()
 	if err != nil {
 		Log.Error().Err(err).Msg("Failed to create JIRA issue via MCP")
-		// Provide user feedback based on MCP client errors using switch
-		switch {
-		case errors.Is(err, mcpclient.ErrRequestExecute):
-			fmt.Fprintf(cmd.ErrOrStderr(), "Error connecting to the MCP server: %v\n", err)
-			fmt.Fprintln(cmd.ErrOrStderr(), "Please ensure the MCP server is running and the URL is correct.")
-		case errors.Is(err, mcpclient.ErrMCPServerError):
-			fmt.Fprintf(cmd.ErrOrStderr(), "MCP server returned an error: %v\n", err) // Error includes server message
-		case errors.Is(err, mcpclient.ErrMCPServerErrorUnparseable):
-			fmt.Fprintf(cmd.ErrOrStderr(), "MCP server returned an error with an unparseable response body: %v\n", err)
-		case errors.Is(err, mcpclient.ErrResponseDecode):
-			fmt.Fprintf(cmd.ErrOrStderr(), "Failed to decode the success response from the MCP server: %v\n", err)
-		default:
-			fmt.Fprintf(cmd.ErrOrStderr(), "An unexpected error occurred while creating the issue via MCP: %v\n", err)
-		}
-		return err // Return original error
+		return mcpCreateError(err)
 	}
 
 	// Handle Success Response
-	Log.Info().Str("issue_key", resp.Key).Str("issue_url", resp.Self).Msg("Successfully created JIRA issue")
+	if resp.Replayed {
+		Log.Info().Str("issue_key", resp.Key).Msg("MCP server returned the issue created earlier for the same idempotency key")
+		fmt.Fprintln(cmd.ErrOrStderr(), i18n.T(i18n.MsgCreateReplayed, resp.Key))
+	} else {
+		Log.Info().Str("issue_key", resp.Key).Str("issue_url", resp.Self).Msg("Successfully created JIRA issue")
+		linkRelatedIssues(ctx, r.mcpClient, resp.Key, request)
+	}
+	r.recordHistory("create", userInput, systemPrompt, request, resp)
+	r.recordMappingCorrection(loadedCfgs.linksConfig, projectSuggestion, request.ProjectKey)
+	r.runPostCreateHooks(ctx, request, resp)
 
 	// Handle output format using helper - pass cmd's output writer
 	if err := formatOutput(cmd, resp, cmd.OutOrStdout()); err != nil {
 		return err
 	}
+	reportCreatedIssue(cmd.ErrOrStderr(), resp)
 
 	return nil // Return nil on success
 }
@@ -390,43 +868,67 @@ func (r *createCmdRunner) Run(cmd *cobra.Command, args []string) error {
 // Flags for the create command (still needed for Cobra)
 var (
 	issueType       string // Bound variable for the flag
-	issueSummary    string // Not used by core logic anymore, but kept for potential future use/consistency
-	projectKey      string // Not used by core logic anymore
-	description     string // Not used by core logic anymore
+	issueSummary    string // Summary for the direct mode, read by the runner via cmd.Flags()
+	projectKey      string // Explicit project, read by the runner via cmd.Flags()
+	description     string // Description for the direct mode
 	interactiveFlag bool   // Added for interactive confirmation
 )
 
+// createArgs requires a description unless the issue is given directly with --summary, which
+// does not call the LLM and so cannot be combined with --refine.
+func createArgs(cmd *cobra.Command, args []string) error {
+	summary, _ := cmd.Flags().GetString("summary")
+	if strings.TrimSpace(summary) != "" {
+		if refine, _ := cmd.Flags().GetBool("refine"); refine {
+			return errors.New("--refine cannot be combined with --summary")
+		}
+		return nil
+	}
+	if description, _ := cmd.Flags().GetString("description"); description != "" {
+		return errors.New("--description requires --summary")
+	}
+	return cobra.MinimumNArgs(1)(cmd, args)
+}
+
 // createCmd represents the create command
 var createCmd = &cobra.Command{
 	Use:   "create [your issue description here...]",
 	Short: "Create a new JIRA issue from a description",
 	Long: `Creates a new JIRA issue by processing the provided description
-using an LLM and interacting with the configured Jira MCP server.`,
-	Args: cobra.MinimumNArgs(1), // Require at least one argument for the description
-	// RunE will be set in init()
+using an LLM and interacting with the configured Jira MCP server.
+
+With --summary the LLM is skipped: the issue is created with the given summary and
+description (--description, or the arguments) in the --project or default_project.
+This works without an API key.`,
+	Args: createArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// The runner is built when the command runs rather than in init(), so that a broken
+		// configuration is reported as an error instead of crashing every command.
+		runner, err := newCreateCmdRunner()
+		if err != nil {
+			return err
+		}
+		return runner.Run(cmd, args)
+	},
 }
 
 func init() {
 	rootCmd.AddCommand(createCmd)
 
-	// Initialize the runner with dependencies
-	runner, err := newCreateCmdRunner()
-	if err != nil {
-		// If provider init fails, we can't set up the command.
-		// Log the error globally and exit or panic.
-		// Using panic here because this happens during init().
-		panic(fmt.Sprintf("Failed to initialize create command runner: %v", err))
-	}
-
-	// Set the RunE function for the command
-	createCmd.RunE = runner.Run // Assign the Run method of the runner instance
-
 	// Add flags for create command
 	// Note: We bind to package-level vars, but the runner reads flags directly via cmd.Flags().GetString()
 	createCmd.Flags().StringVarP(&issueType, "type", "t", "", "Specify the JIRA issue type (e.g., Task, Bug) - overrides LLM suggestion and defaults")
 	// Keep other flags for potential future direct use or help text, even if not used by core LLM flow
-	createCmd.Flags().StringVarP(&issueSummary, "summary", "s", "", "[Optional] Specify the issue summary directly (currently unused by core logic)")
-	createCmd.Flags().StringVarP(&projectKey, "project", "p", "", "[Optional] Specify the JIRA project key directly (currently unused by core logic)")
-	createCmd.Flags().StringVarP(&description, "description", "d", "", "[Optional] Specify the issue description directly (currently unused by core logic)")
+	createCmd.Flags().StringVarP(&issueSummary, "summary", "s", "", "Create the issue with this summary without calling the LLM (needs --project or default_project)")
+	createCmd.Flags().StringVarP(&projectKey, "project", "p", "", "Create the issue in this project (key or links.yaml name) instead of the one suggested by the LLM")
+	createCmd.Flags().StringVarP(&description, "description", "d", "", "Description of an issue created with --summary (default: the arguments)")
 	createCmd.Flags().BoolVarP(&interactiveFlag, "interactive", "i", false, "Prompt for confirmation before creating the issue.") // Added flag
+	createCmd.Flags().Bool("refine", false, "Show the proposed issue and send follow-up instructions to the LLM until you accept it with an empty line")
+	addLLMOverrideFlags(createCmd)
+	createCmd.Flags().String("priority", "", "Set the issue priority (e.g., High)")
+	createCmd.Flags().String("due", "", "Set the due date (e.g., 2025-05-01, tomorrow, \"next friday\", \"in 3 days\"), resolved in the configured timezone")
+	createCmd.Flags().String("description-format", "", "Send the description as text, wiki or adf (default: description_format from config.yaml, else text)")
+	createCmd.Flags().StringArray("field", nil, "Set a field as ID=VALUE or NAME=VALUE (e.g., Severity=S2); repeatable")
+	createCmd.Flags().Bool("show-redactions", false, "Preview what would be sent to the LLM after redaction, without calling it")
+	createCmd.Flags().Bool("new", false, "Create a new issue even if one was created from the same input today, by sending no idempotency key")
 }
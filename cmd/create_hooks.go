@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/karolswdev/ticketron/internal/hooks"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+// hookData returns what the arguments of the hooks are rendered with for request and, once
+// the issue is created, resp.
+func hookData(request mcpclient.CreateIssueRequest, resp *mcpclient.CreateIssueResponse) hooks.Data {
+	data := hooks.Data{
+		Project:     request.ProjectKey,
+		Type:        request.IssueType,
+		Summary:     request.Summary,
+		Priority:    request.Priority,
+		Description: request.Description,
+	}
+	if resp != nil {
+		data.Key = resp.Key
+		data.URL = browseURL(resp.Self, resp.Key)
+		if data.URL == "" {
+			data.URL = resp.Self
+		}
+	}
+	return data
+}
+
+// runPreCreateHooks runs the hooks.pre_create commands for request. An error aborts the creation.
+func (r *createCmdRunner) runPreCreateHooks(ctx context.Context, request mcpclient.CreateIssueRequest) error {
+	return r.hooks.Run(ctx, hooks.PreCreate, hookData(request, nil))
+}
+
+// runPostCreateHooks runs the hooks.post_create commands for the issue created from request.
+// The issue exists either way, so failures are logged and do not fail the creation. Issues
+// the MCP server returns for a repeated request were already announced and run no hooks.
+func (r *createCmdRunner) runPostCreateHooks(ctx context.Context, request mcpclient.CreateIssueRequest, resp *mcpclient.CreateIssueResponse) {
+	if resp == nil || resp.Replayed {
+		return
+	}
+	if err := r.hooks.Run(ctx, hooks.PostCreate, hookData(request, resp)); err != nil {
+		Log.Warn().Err(err).Str("issue_key", resp.Key).Msg("Post-create hook failed")
+	}
+}
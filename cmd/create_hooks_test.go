@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/hooks"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+func TestHookData(t *testing.T) {
+	request := mcpclient.CreateIssueRequest{ProjectKey: "BE", IssueType: "Bug", Summary: "S", Priority: "High", Description: "D"}
+	assert.Equal(t, hooks.Data{Project: "BE", Type: "Bug", Summary: "S", Priority: "High", Description: "D"}, hookData(request, nil))
+
+	data := hookData(request, &mcpclient.CreateIssueResponse{Key: "BE-2", Self: "https://jira.example.com/rest/api/2/issue/10002"})
+	assert.Equal(t, "BE-2", data.Key)
+	assert.Equal(t, "https://jira.example.com/browse/BE-2", data.URL)
+	assert.Equal(t, "https://mcp.example.com/BE-2", hookData(request, &mcpclient.CreateIssueResponse{Key: "BE-2", Self: "https://mcp.example.com/BE-2"}).URL)
+}
+
+func TestSubmitIssue_RunsHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks in the tests use sh")
+	}
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	runner, _ := newMCPServeTestRunner(mockMCP)
+	var out bytes.Buffer
+	var err error
+	runner.hooks, err = hooks.New(config.HooksConfig{
+		PreCreate:  []string{`sh -c 'echo "pre $1 $2"' sh {{.Project}} {{.Summary}}`},
+		PostCreate: []string{`sh -c 'echo "post $1"' sh {{.Key}}`, `sh -c 'exit 1'`},
+	})
+	require.NoError(t, err)
+	runner.hooks.Output = &out
+	request := mcpclient.CreateIssueRequest{ProjectKey: "BE", Summary: "Login fails", IssueType: "Bug"}
+	mockMCP.On("CreateIssue", mock.Anything, mock.Anything).Return(&mcpclient.CreateIssueResponse{Key: "BE-2"}, nil).Once()
+
+	_, resp, err := runner.submitIssue(context.Background(), request)
+	require.NoError(t, err, "a failing post-create hook does not fail the creation")
+	assert.Equal(t, "BE-2", resp.Key)
+	assert.Equal(t, "pre BE Login fails\npost BE-2\n", out.String())
+	mockMCP.AssertExpectations(t)
+}
+
+func TestSubmitIssue_PreCreateHookAborts(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks in the tests use sh")
+	}
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	runner, _ := newMCPServeTestRunner(mockMCP)
+	var err error
+	runner.hooks, err = hooks.New(config.HooksConfig{PreCreate: []string{`sh -c 'exit 1'`}})
+	require.NoError(t, err)
+
+	_, resp, err := runner.submitIssue(context.Background(), mcpclient.CreateIssueRequest{ProjectKey: "BE", Summary: "S", IssueType: "Bug"})
+	assert.ErrorIs(t, err, hooks.ErrHookFailed)
+	assert.Nil(t, resp)
+	mockMCP.AssertNotCalled(t, "CreateIssue", mock.Anything, mock.Anything)
+}
+
+func TestRunPostCreateHooks_SkipsReplayed(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks in the tests use sh")
+	}
+	marker := filepath.Join(t.TempDir(), "ran")
+	h, err := hooks.New(config.HooksConfig{PostCreate: []string{"touch " + marker}})
+	require.NoError(t, err)
+	runner := &createCmdRunner{hooks: h}
+
+	runner.runPostCreateHooks(context.Background(), mcpclient.CreateIssueRequest{}, &mcpclient.CreateIssueResponse{Key: "BE-2", Replayed: true})
+	assert.NoFileExists(t, marker)
+	runner.runPostCreateHooks(context.Background(), mcpclient.CreateIssueRequest{}, &mcpclient.CreateIssueResponse{Key: "BE-2"})
+	assert.FileExists(t, marker)
+}
@@ -2,16 +2,25 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"errors" // Keep for potential error mocking
+	"strings"
 	"testing"
 
 	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	"github.com/karolswdev/ticketron/internal/clierr"
 	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/golden"
+	"github.com/karolswdev/ticketron/internal/guardrails"
+	"github.com/karolswdev/ticketron/internal/history"
 	"github.com/karolswdev/ticketron/internal/llm"
 	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/prompt"
 )
 
 // --- Mocks (Shared mocks are now in mocks_test.go) ---
@@ -70,7 +79,11 @@ func executeCreateCmd(
 		projectMapper:     projectMapper,
 		issueTypeResolver: issueTypeResolver,
 	}
+	return executeCreateRunner(runner, args, flags)
+}
 
+// executeCreateRunner runs the create command with a prepared runner.
+func executeCreateRunner(runner *createCmdRunner, args []string, flags map[string]string) (string, error) {
 	cmd := createCmd
 
 	cmd.ResetFlags()
@@ -78,6 +91,12 @@ func executeCreateCmd(
 	createCmd.Flags().StringVarP(&issueSummary, "summary", "s", "", "[Optional] Specify the issue summary directly")
 	createCmd.Flags().StringVarP(&projectKey, "project", "p", "", "[Optional] Specify the JIRA project key directly")
 	createCmd.Flags().StringVarP(&description, "description", "d", "", "[Optional] Specify the issue description directly")
+	addLLMOverrideFlags(createCmd)
+	createCmd.Flags().Bool("show-redactions", false, "Preview redactions")
+	createCmd.Flags().String("priority", "", "Set the issue priority")
+	createCmd.Flags().String("description-format", "", "Send the description as text, wiki or adf")
+	createCmd.Flags().StringArray("field", nil, "Set a field")
+	createCmd.Flags().Bool("new", false, "Send no idempotency key")
 
 	for key, val := range flags {
 		cmd.Flags().Set(key, val)
@@ -126,7 +145,7 @@ func TestCreateCmdRunE_Success(t *testing.T) {
 		ProjectNameSuggestion: "Test Project",
 	}
 	// Removed AppConfig from mock call
-	mockLLM.On("GenerateTicketDetails", mock.AnythingOfType("context.backgroundCtx"), "Test Summary", "System prompt content", "Context content").Return(expectedLLMResponse, nil)
+	mockLLM.On("GenerateTicketDetails", mock.AnythingOfType("context.backgroundCtx"), "Test Summary", "System prompt content", llmContextWithAliases("Context content", testLinksConfig)).Return(expectedLLMResponse, nil)
 
 	matchedLinkPtr := &testLinksConfig.Projects[0]
 	mockMapper.On("MapSuggestionToKey", "Test Project", testLinksConfig).Return("TEST", matchedLinkPtr, nil)
@@ -135,11 +154,15 @@ func TestCreateCmdRunE_Success(t *testing.T) {
 	mockResolver.On("Resolve", "", matchedLinkPtr, "TEST").Return("Task") // Expect empty flag, project link, project key ("TEST")
 
 	expectedMCPRequest := mcpclient.CreateIssueRequest{
-		ProjectKey:  "TEST",
-		IssueType:   "Task",
-		Summary:     "Generated Title",
-		Description: "Generated Description",
+		ProjectKey:     "TEST",
+		IssueType:      "Task",
+		Summary:        "Generated Title",
+		Description:    "Generated Description",
+		IdempotencyKey: idempotencyKey("Test Summary", "TEST"),
 	}
+	mockMCP.On("GetCreateMeta", mock.Anything, "TEST").Return(&mcpclient.CreateMeta{ProjectKey: "TEST", IssueTypes: []mcpclient.IssueTypeMeta{
+		{Name: "Task", Fields: []mcpclient.FieldMeta{{ID: "summary", Name: "Summary", Required: true}, {ID: "labels", Name: "Labels"}}},
+	}}, nil)
 	mockMCP.On("CreateIssue", mock.AnythingOfType("context.backgroundCtx"), expectedMCPRequest).Return(&mcpclient.CreateIssueResponse{Key: "TEST-123", ID: "10001", Self: "http://jira.example.com/browse/TEST-123"}, nil)
 
 	args := []string{"Test Summary"}
@@ -321,7 +344,8 @@ func TestCreateCmdRunE_LLMGenerateError(t *testing.T) {
 	_, err := executeCreateCmd(mockProvider, mockLLM, nil, mockMapper, mockResolver, args, flags)
 
 	assert.Error(t, err)
-	assert.Equal(t, expectedError, err)
+	assert.ErrorIs(t, err, expectedError)
+	assert.Equal(t, clierr.CodeLLM, clierr.CodeOf(err))
 
 	mockProvider.AssertCalled(t, "LoadConfig")
 	mockProvider.AssertCalled(t, "LoadLinks")
@@ -354,7 +378,7 @@ func TestCreateCmdRunE_MapProjectError(t *testing.T) {
 
 	llmSuggestion := "Unknown Project"
 	// Removed AppConfig from mock call
-	mockLLM.On("GenerateTicketDetails", mock.AnythingOfType("context.backgroundCtx"), "Test Summary", "System prompt content", "Context content").Return(llm.LLMResponse{ProjectNameSuggestion: llmSuggestion}, nil)
+	mockLLM.On("GenerateTicketDetails", mock.AnythingOfType("context.backgroundCtx"), "Test Summary", "System prompt content", llmContextWithAliases("Context content", testLinksConfig)).Return(llm.LLMResponse{ProjectNameSuggestion: llmSuggestion}, nil)
 
 	expectedError := errors.New("map project error")
 	mockMapper.On("MapSuggestionToKey", llmSuggestion, testLinksConfig).Return("", nil, expectedError)
@@ -364,14 +388,15 @@ func TestCreateCmdRunE_MapProjectError(t *testing.T) {
 	_, err := executeCreateCmd(mockProvider, mockLLM, nil, mockMapper, mockResolver, args, flags)
 
 	assert.Error(t, err)
-	assert.Equal(t, expectedError, err)
+	assert.ErrorIs(t, err, expectedError)
+	assert.Equal(t, clierr.CodeProjectMapping, clierr.CodeOf(err))
 
 	mockProvider.AssertCalled(t, "LoadConfig")
 	mockProvider.AssertCalled(t, "LoadLinks")
 	mockProvider.AssertCalled(t, "LoadSystemPrompt")
 	mockProvider.AssertCalled(t, "LoadContext")
 	// Removed AppConfig from mock assertion arguments
-	mockLLM.AssertCalled(t, "GenerateTicketDetails", mock.AnythingOfType("context.backgroundCtx"), "Test Summary", "System prompt content", "Context content")
+	mockLLM.AssertCalled(t, "GenerateTicketDetails", mock.AnythingOfType("context.backgroundCtx"), "Test Summary", "System prompt content", llmContextWithAliases("Context content", testLinksConfig))
 	mockMapper.AssertCalled(t, "MapSuggestionToKey", llmSuggestion, testLinksConfig)
 	mockMapper.AssertNumberOfCalls(t, "MapSuggestionToKey", 1)
 	mockResolver.AssertNotCalled(t, "Resolve", mock.Anything, mock.Anything, mock.Anything)
@@ -406,7 +431,7 @@ func TestCreateCmdRunE_MCPCreateError(t *testing.T) {
 		ProjectNameSuggestion: "Test Project",
 	}
 	// Removed AppConfig from mock call
-	mockLLM.On("GenerateTicketDetails", mock.AnythingOfType("context.backgroundCtx"), "Test Summary", "System prompt content", "Context content").Return(expectedLLMResponse, nil)
+	mockLLM.On("GenerateTicketDetails", mock.AnythingOfType("context.backgroundCtx"), "Test Summary", "System prompt content", llmContextWithAliases("Context content", testLinksConfig)).Return(expectedLLMResponse, nil)
 
 	matchedLinkPtr := &testLinksConfig.Projects[0]
 	mockMapper.On("MapSuggestionToKey", "Test Project", testLinksConfig).Return("TEST", matchedLinkPtr, nil)
@@ -415,12 +440,14 @@ func TestCreateCmdRunE_MCPCreateError(t *testing.T) {
 	mockResolver.On("Resolve", "", matchedLinkPtr, "TEST").Return("Task")
 
 	expectedMCPRequest := mcpclient.CreateIssueRequest{
-		ProjectKey:  "TEST",
-		IssueType:   "Task",
-		Summary:     "Generated Title",
-		Description: "Generated Description",
+		ProjectKey:     "TEST",
+		IssueType:      "Task",
+		Summary:        "Generated Title",
+		Description:    "Generated Description",
+		IdempotencyKey: idempotencyKey("Test Summary", "TEST"),
 	}
 	expectedError := errors.New("mcp create error")
+	mockMCP.On("GetCreateMeta", mock.Anything, mock.Anything).Return(nil, mcpclient.ErrMCPServerError) // Metadata unavailable, validation skipped
 	mockMCP.On("CreateIssue", mock.AnythingOfType("context.backgroundCtx"), expectedMCPRequest).Return(nil, expectedError)
 
 	args := []string{"Test Summary"}
@@ -428,7 +455,8 @@ func TestCreateCmdRunE_MCPCreateError(t *testing.T) {
 	_, err := executeCreateCmd(mockProvider, mockLLM, mockMCP, mockMapper, mockResolver, args, flags)
 
 	assert.Error(t, err)
-	assert.Equal(t, expectedError, err)
+	assert.ErrorIs(t, err, expectedError)
+	assert.Equal(t, clierr.CodeMCP, clierr.CodeOf(err))
 
 	mockProvider.AssertExpectations(t)
 	mockLLM.AssertExpectations(t)
@@ -466,7 +494,7 @@ func TestCreateCmdRunE_TypeFlagOverride(t *testing.T) {
 		ProjectNameSuggestion: "Test Project",
 	}
 	// Removed AppConfig from mock call
-	mockLLM.On("GenerateTicketDetails", mock.AnythingOfType("context.backgroundCtx"), "Test Summary", "System prompt content", "Context content").Return(expectedLLMResponse, nil)
+	mockLLM.On("GenerateTicketDetails", mock.AnythingOfType("context.backgroundCtx"), "Test Summary", "System prompt content", llmContextWithAliases("Context content", testLinksConfig)).Return(expectedLLMResponse, nil)
 
 	matchedLinkPtr := &testLinksConfig.Projects[0]
 	mockMapper.On("MapSuggestionToKey", "Test Project", testLinksConfig).Return("TEST", matchedLinkPtr, nil)
@@ -478,11 +506,13 @@ func TestCreateCmdRunE_TypeFlagOverride(t *testing.T) {
 
 	// Expect MCP request to use the overridden type
 	expectedMCPRequest := mcpclient.CreateIssueRequest{
-		ProjectKey:  "TEST",
-		IssueType:   flagType, // Should be "Bug"
-		Summary:     "Generated Title",
-		Description: "Generated Description",
+		ProjectKey:     "TEST",
+		IssueType:      flagType, // Should be "Bug"
+		Summary:        "Generated Title",
+		Description:    "Generated Description",
+		IdempotencyKey: idempotencyKey("Test Summary", "TEST"),
 	}
+	mockMCP.On("GetCreateMeta", mock.Anything, mock.Anything).Return(nil, mcpclient.ErrMCPServerError) // Metadata unavailable, validation skipped
 	mockMCP.On("CreateIssue", mock.AnythingOfType("context.backgroundCtx"), expectedMCPRequest).Return(&mcpclient.CreateIssueResponse{Key: "TEST-456", ID: "10002", Self: "http://jira.example.com/browse/TEST-456"}, nil)
 
 	args := []string{"Test Summary"}
@@ -497,3 +527,376 @@ func TestCreateCmdRunE_TypeFlagOverride(t *testing.T) {
 	mockResolver.AssertExpectations(t)
 	mockMCP.AssertExpectations(t)
 }
+
+// llmContextWithAliases returns the context the LLM receives for contextData when the
+// project is left to the LLM's suggestion.
+func llmContextWithAliases(contextData string, links *config.LinksConfig) string {
+	return llm.WithProjectAliases(contextData, projectAliases(links))
+}
+
+// setupProjectSelectionTest prepares mocks for a create run in which the LLM suggests an
+// unknown project and links.yaml maps "Test Project" to TEST.
+func setupProjectSelectionTest(appConfig *config.AppConfig) (*MockConfigProvider, *MockLLMClient, *MockMCPClient, *MockProjectMapper, *MockIssueTypeResolver, *config.LinksConfig) {
+	mockProvider := new(MockConfigProvider)
+	mockLLM := new(MockLLMClient)
+	mockMCP := new(MockMCPClient)
+	mockMapper := new(MockProjectMapper)
+	mockResolver := new(MockIssueTypeResolver)
+
+	linksConfig := &config.LinksConfig{Projects: []config.ProjectLink{{Name: "Test Project", Key: "TEST", DefaultIssueType: "Story"}}}
+	mockProvider.On("LoadConfig").Return(appConfig, nil)
+	mockProvider.On("LoadLinks").Return(linksConfig, nil)
+	mockProvider.On("LoadSystemPrompt").Return("System prompt content", nil)
+	mockProvider.On("LoadContext").Return("Context content", nil)
+	mockLLM.On("GenerateTicketDetails", mock.Anything, "Test Summary", "System prompt content", mock.Anything).
+		Return(llm.LLMResponse{Summary: "Generated Title", Description: "Generated Description", ProjectNameSuggestion: "Unknown Project"}, nil)
+	mockResolver.On("Resolve", "", &linksConfig.Projects[0], "TEST").Return("Story")
+	mockMCP.On("GetCreateMeta", mock.Anything, mock.Anything).Return(nil, mcpclient.ErrMCPServerError) // Metadata unavailable, validation skipped
+	return mockProvider, mockLLM, mockMCP, mockMapper, mockResolver, linksConfig
+}
+
+func TestCreateCmdRunE_DefaultProject(t *testing.T) {
+	Log = zerolog.Nop()
+	mockProvider, mockLLM, mockMCP, mockMapper, mockResolver, linksConfig := setupProjectSelectionTest(&config.AppConfig{DefaultProject: "test project"})
+	mockMapper.On("MapSuggestionToKey", "Unknown Project", linksConfig).Return("", nil, config.ErrProjectMappingFailed)
+	mockMCP.On("CreateIssue", mock.Anything, mcpclient.CreateIssueRequest{ProjectKey: "TEST", IssueType: "Story", Summary: "Generated Title", Description: "Generated Description", IdempotencyKey: idempotencyKey("Test Summary", "TEST")}).
+		Return(&mcpclient.CreateIssueResponse{Key: "TEST-7"}, nil)
+
+	_, err := executeCreateCmd(mockProvider, mockLLM, mockMCP, mockMapper, mockResolver, []string{"Test Summary"}, map[string]string{})
+	require.NoError(t, err)
+	mockResolver.AssertExpectations(t)
+	mockMCP.AssertExpectations(t)
+}
+
+func TestCreateCmdRunE_ProjectFlag(t *testing.T) {
+	Log = zerolog.Nop()
+	mockProvider, mockLLM, mockMCP, mockMapper, mockResolver, _ := setupProjectSelectionTest(&config.AppConfig{DefaultProject: "OTHER"})
+	mockMCP.On("CreateIssue", mock.Anything, mcpclient.CreateIssueRequest{ProjectKey: "TEST", IssueType: "Story", Summary: "Generated Title", Description: "Generated Description", IdempotencyKey: idempotencyKey("Test Summary", "TEST")}).
+		Return(&mcpclient.CreateIssueResponse{Key: "TEST-7"}, nil)
+
+	_, err := executeCreateCmd(mockProvider, mockLLM, mockMCP, mockMapper, mockResolver, []string{"Test Summary"}, map[string]string{"project": "test"})
+	require.NoError(t, err)
+	mockMapper.AssertNotCalled(t, "MapSuggestionToKey", mock.Anything, mock.Anything)
+	mockMCP.AssertExpectations(t)
+}
+
+func TestCreateCmdRunE_DirectMode(t *testing.T) {
+	Log = zerolog.Nop()
+	mockProvider, _, mockMCP, mockMapper, mockResolver, _ := setupProjectSelectionTest(&config.AppConfig{})
+	mockMCP.On("CreateIssue", mock.Anything, mcpclient.CreateIssueRequest{ProjectKey: "TEST", IssueType: "Story", Summary: "Fix login", Description: "Users see a 500", IdempotencyKey: idempotencyKey("Fix login\nUsers see a 500", "TEST")}).
+		Return(&mcpclient.CreateIssueResponse{Key: "TEST-8"}, nil)
+
+	// No LLM client: direct mode must not need one
+	flags := map[string]string{"summary": "Fix login", "project": "Test Project"}
+	_, err := executeCreateCmd(mockProvider, nil, mockMCP, mockMapper, mockResolver, []string{"Users", "see", "a", "500"}, flags)
+	require.NoError(t, err)
+	mockMCP.AssertExpectations(t)
+	mockMapper.AssertNotCalled(t, "MapSuggestionToKey", mock.Anything, mock.Anything)
+}
+
+func TestCreateCmdRunE_DirectModeNeedsProject(t *testing.T) {
+	Log = zerolog.Nop()
+	mockProvider, _, mockMCP, mockMapper, mockResolver, _ := setupProjectSelectionTest(&config.AppConfig{})
+
+	_, err := executeCreateCmd(mockProvider, nil, mockMCP, mockMapper, mockResolver, nil, map[string]string{"summary": "Fix login", "description": "Details"})
+	assert.ErrorIs(t, err, errDirectModeProject)
+	mockMCP.AssertNotCalled(t, "CreateIssue", mock.Anything, mock.Anything)
+}
+
+func TestFormatOutput(t *testing.T) {
+	resp := &mcpclient.CreateIssueResponse{Key: "TEST-123", ID: "10001", Self: "http://jira.example.com/browse/TEST-123"}
+	for _, format := range []string{"text", "json", "yaml"} {
+		t.Run(format, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().String("output", format, "Output format")
+			var out bytes.Buffer
+			require.NoError(t, formatOutput(cmd, resp, &out))
+			golden.Assert(t, out.Bytes())
+		})
+	}
+}
+
+func TestConfirmInteractively(t *testing.T) {
+	Log = zerolog.Nop()
+	request := mcpclient.CreateIssueRequest{ProjectKey: "TEST", IssueType: "Bug", Summary: "Login fails", Description: "Users get a 500.\nSince the last deploy."}
+	tests := []struct {
+		name    string
+		input   string
+		proceed bool
+	}{
+		{name: "confirmed", input: "y\n", proceed: true},
+		{name: "aborted", input: "n\n", proceed: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().Bool("interactive", true, "Confirm before creating")
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+
+			proceed, err := confirmInteractively(cmd, &prompt.Confirmer{In: strings.NewReader(tt.input)}, request)
+			require.NoError(t, err)
+			assert.Equal(t, tt.proceed, proceed)
+			golden.Assert(t, out.Bytes())
+		})
+	}
+}
+
+func TestCreateArgs(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("summary", "", "")
+	cmd.Flags().String("description", "", "")
+	cmd.Flags().Bool("refine", false, "")
+
+	assert.Error(t, createArgs(cmd, nil), "a description is required without --summary")
+	assert.NoError(t, createArgs(cmd, []string{"Fix login"}))
+
+	require.NoError(t, cmd.Flags().Set("description", "Details"))
+	assert.ErrorContains(t, createArgs(cmd, []string{"Fix login"}), "--description requires --summary")
+
+	require.NoError(t, cmd.Flags().Set("summary", "Fix login"))
+	assert.NoError(t, createArgs(cmd, nil))
+
+	require.NoError(t, cmd.Flags().Set("refine", "true"))
+	assert.ErrorContains(t, createArgs(cmd, nil), "--refine cannot be combined with --summary")
+}
+
+func TestRefineIssueRequest(t *testing.T) {
+	Log = zerolog.Nop()
+	mockProvider := new(MockConfigProvider)
+	mockLLM := new(MockLLMClient)
+	links := &config.LinksConfig{Projects: []config.ProjectLink{{Name: "Backend", Key: "BE", DefaultIssueType: "Bug"}, {Name: "Frontend", Key: "FE"}}}
+	mockProvider.On("LoadConfig").Return(&config.AppConfig{}, nil)
+	mockProvider.On("LoadLinks").Return(links, nil)
+	mockProvider.On("LoadSystemPrompt").Return("prompt", nil)
+	mockProvider.On("LoadContext").Return("", nil)
+
+	mockLLM.On("GenerateTicketDetails", mock.Anything, "login broken", "prompt", mock.Anything).
+		Return(llm.LLMResponse{Summary: "Fix the error shown on the login page", ProjectNameSuggestion: "Frontend"}, nil)
+	refined := llm.LLMResponse{Summary: "Fix login", ProjectNameSuggestion: "Backend"}
+	mockLLM.On("GenerateFromMessages", mock.Anything, mock.MatchedBy(func(messages []llm.Message) bool {
+		// System prompt, project list, request, first proposal and the follow-up instruction
+		return len(messages) == 5 && messages[3].Role == llm.RoleAssistant &&
+			messages[4] == llm.Message{Role: llm.RoleUser, Content: "shorter, and target the Backend project"}
+	})).Return(refined, nil)
+
+	runner := NewCreateCmdRunnerForTest(mockProvider, mockLLM, nil, &DefaultProjectMapper{}, &DefaultIssueTypeResolver{})
+	loadedCfgs, err := loadAllConfigs(mockProvider)
+	require.NoError(t, err)
+	confirmer := &prompt.Confirmer{In: strings.NewReader("shorter, and target the Backend project\n\n")}
+
+	var out, errOut bytes.Buffer
+	request, err := runner.refineIssueRequest(context.Background(), confirmer, &out, &errOut, loadedCfgs, "login broken", issueRequestOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, mcpclient.CreateIssueRequest{ProjectKey: "BE", Summary: "Fix login", IssueType: "Bug", IdempotencyKey: idempotencyKey("login broken", "BE")}, request)
+	assert.Equal(t, 2, strings.Count(out.String(), "--- Issue Details ---"), "each proposal is shown")
+	assert.Contains(t, out.String(), "Project Key: FE")
+	mockLLM.AssertNumberOfCalls(t, "GenerateFromMessages", 1)
+}
+
+func TestCreateCmdRunE_LearnsProjectCorrections(t *testing.T) {
+	Log = zerolog.Nop()
+	mappings := history.NewMappingStore(t.TempDir())
+	newRunner := func() (*createCmdRunner, *MockMCPClient, *MockProjectMapper) {
+		mockProvider, mockLLM, mockMCP, mockMapper, mockResolver, _ := setupProjectSelectionTest(&config.AppConfig{})
+		mockMCP.On("CreateIssue", mock.Anything, mock.MatchedBy(func(req mcpclient.CreateIssueRequest) bool { return req.ProjectKey == "TEST" })).
+			Return(&mcpclient.CreateIssueResponse{Key: "TEST-9"}, nil)
+		return &createCmdRunner{configProvider: mockProvider, llmClient: mockLLM, mcpClient: mockMCP, projectMapper: mockMapper, issueTypeResolver: mockResolver, mappings: mappings}, mockMCP, mockMapper
+	}
+
+	// Nothing is learned from an issue that was not created
+	mockProvider, mockLLM, mockMCP, mockMapper, mockResolver, _ := setupProjectSelectionTest(&config.AppConfig{})
+	mockMCP.On("CreateIssue", mock.Anything, mock.Anything).Return(nil, errors.New("MCP down"))
+	failing := &createCmdRunner{configProvider: mockProvider, llmClient: mockLLM, mcpClient: mockMCP, projectMapper: mockMapper, issueTypeResolver: mockResolver, mappings: mappings}
+	_, err := executeCreateRunner(failing, []string{"Test Summary"}, map[string]string{"project": "TEST"})
+	require.Error(t, err)
+	corrections, err := mappings.List()
+	require.NoError(t, err)
+	assert.Empty(t, corrections)
+
+	// The LLM suggests "Unknown Project" twice and the user picks TEST with --project
+	for i := 0; i < learnedMappingMinCount; i++ {
+		runner, _, _ := newRunner()
+		_, err := executeCreateRunner(runner, []string{"Test Summary"}, map[string]string{"project": "TEST"})
+		require.NoError(t, err)
+	}
+	corrections, err = mappings.List()
+	require.NoError(t, err)
+	require.Len(t, corrections, learnedMappingMinCount)
+	assert.Equal(t, history.MappingCorrection{Timestamp: corrections[0].Timestamp, Suggestion: "Unknown Project", ProjectKey: "TEST"}, corrections[0])
+
+	// Now the suggestion maps to TEST without --project and without links.yaml
+	runner, mockMCP, mockMapper := newRunner()
+	_, err = executeCreateRunner(runner, []string{"Test Summary"}, map[string]string{})
+	require.NoError(t, err)
+	mockMapper.AssertNotCalled(t, "MapSuggestionToKey", mock.Anything, mock.Anything)
+	mockMCP.AssertExpectations(t)
+}
+
+func TestCreateCmdRunE_NewSendsNoIdempotencyKey(t *testing.T) {
+	Log = zerolog.Nop()
+	mockProvider, mockLLM, mockMCP, mockMapper, mockResolver, _ := setupProjectSelectionTest(&config.AppConfig{})
+	mockMCP.On("CreateIssue", mock.Anything, mock.MatchedBy(func(req mcpclient.CreateIssueRequest) bool { return req.IdempotencyKey == "" })).
+		Return(&mcpclient.CreateIssueResponse{Key: "TEST-9"}, nil).Once()
+	runner := &createCmdRunner{configProvider: mockProvider, llmClient: mockLLM, mcpClient: mockMCP, projectMapper: mockMapper, issueTypeResolver: mockResolver}
+
+	_, err := executeCreateRunner(runner, []string{"Test Summary"}, map[string]string{"project": "TEST", "new": "true"})
+	require.NoError(t, err)
+	mockMCP.AssertExpectations(t)
+}
+
+func TestResolveProject(t *testing.T) {
+	links := &config.LinksConfig{Projects: []config.ProjectLink{{Name: "Backend", Key: "BE"}, {Name: "FE", Key: "WEB"}}}
+
+	key, link := resolveProject(links, "backend")
+	assert.Equal(t, "BE", key)
+	assert.Same(t, &links.Projects[0], link)
+
+	key, link = resolveProject(links, "fe")
+	assert.Equal(t, "WEB", key, "names are matched when no key matches")
+	assert.Same(t, &links.Projects[1], link)
+
+	key, link = resolveProject(links, "OPS")
+	assert.Equal(t, "OPS", key)
+	assert.Nil(t, link)
+}
+
+func TestCreateCmdRunE_RedactsBeforeLLM(t *testing.T) {
+	Log = zerolog.Nop()
+
+	mockProvider := new(MockConfigProvider)
+	mockLLM := new(MockLLMClient)
+	mockMCP := new(MockMCPClient)
+
+	testAppConfig := &config.AppConfig{
+		MCPServerURL: "http://mcp.example.com",
+		Redaction:    config.RedactionConfig{Enabled: true, Builtins: []string{"email"}},
+	}
+	links := &config.LinksConfig{Projects: []config.ProjectLink{{Name: "Backend", Key: "BE", DefaultIssueType: "Bug"}}}
+	mockProvider.On("LoadConfig").Return(testAppConfig, nil)
+	mockProvider.On("LoadLinks").Return(links, nil)
+	mockProvider.On("LoadSystemPrompt").Return("prompt", nil)
+	mockProvider.On("LoadContext").Return("Owner: ops@example.com", nil)
+
+	mockLLM.On("GenerateTicketDetails", mock.Anything, "jane@example.com cannot log in", "prompt", mock.Anything).Maybe()
+	mockLLM.On("GenerateTicketDetails", mock.Anything, "[REDACTED:email] cannot log in", "prompt", llmContextWithAliases("Owner: [REDACTED:email]", links)).
+		Return(llm.LLMResponse{Summary: "Login fails", ProjectNameSuggestion: "Backend"}, nil)
+	mockMCP.On("GetCreateMeta", mock.Anything, mock.Anything).Return(nil, mcpclient.ErrMCPServerError) // Metadata unavailable, validation skipped
+	mockMCP.On("CreateIssue", mock.Anything, mock.Anything).Return(&mcpclient.CreateIssueResponse{Key: "BE-1"}, nil)
+
+	_, err := executeCreateCmd(mockProvider, mockLLM, mockMCP, &DefaultProjectMapper{}, &DefaultIssueTypeResolver{}, []string{"jane@example.com cannot log in"}, nil)
+	assert.NoError(t, err)
+	mockLLM.AssertCalled(t, "GenerateTicketDetails", mock.Anything, "[REDACTED:email] cannot log in", "prompt", llmContextWithAliases("Owner: [REDACTED:email]", links))
+	mockLLM.AssertNotCalled(t, "GenerateTicketDetails", mock.Anything, "jane@example.com cannot log in", "prompt", mock.Anything)
+}
+
+func TestCreateCmdRunE_ShowRedactions(t *testing.T) {
+	Log = zerolog.Nop()
+
+	mockProvider := new(MockConfigProvider)
+	mockLLM := new(MockLLMClient)
+	mockProvider.On("LoadConfig").Return(&config.AppConfig{Redaction: config.RedactionConfig{Enabled: true}}, nil)
+	mockProvider.On("LoadLinks").Return(&config.LinksConfig{}, nil)
+	mockProvider.On("LoadSystemPrompt").Return("prompt", nil)
+	mockProvider.On("LoadContext").Return("", nil)
+
+	var out bytes.Buffer
+	createCmd.SetOut(&out)
+	defer createCmd.SetOut(nil)
+	_, err := executeCreateCmd(mockProvider, mockLLM, nil, &DefaultProjectMapper{}, &DefaultIssueTypeResolver{}, []string{"key sk-abcdefghijklmnopqrstuv leaked"}, map[string]string{"show-redactions": "true"})
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "key [REDACTED:api_key] leaked")
+	assert.Contains(t, out.String(), `api_key        "sk-abcdefghijklmnopqrstuv" -> [REDACTED:api_key]`)
+	mockLLM.AssertNotCalled(t, "GenerateTicketDetails")
+}
+
+func TestDescriptionFormatFor(t *testing.T) {
+	cfgs := &loadedConfigs{appConfig: &config.AppConfig{DescriptionFormat: "wiki"}}
+
+	format, err := descriptionFormatFor(cfgs, "")
+	require.NoError(t, err)
+	assert.Equal(t, mcpclient.DescriptionFormatWiki, format, "config.yaml applies without a flag")
+
+	format, err = descriptionFormatFor(cfgs, "adf")
+	require.NoError(t, err)
+	assert.Equal(t, mcpclient.DescriptionFormatADF, format, "the flag overrides config.yaml")
+
+	format, err = descriptionFormatFor(cfgs, "text")
+	require.NoError(t, err)
+	assert.Empty(t, format, "text leaves the request unchanged")
+
+	format, err = descriptionFormatFor(&loadedConfigs{}, "")
+	require.NoError(t, err)
+	assert.Empty(t, format)
+
+	_, err = descriptionFormatFor(cfgs, "html")
+	assert.ErrorIs(t, err, mcpclient.ErrDescriptionFormatInvalid)
+}
+
+func TestBuildIssueRequest_PostProcessing(t *testing.T) {
+	Log = zerolog.Nop()
+	runner, mockLLM := newMCPServeTestRunner(nil)
+	mockLLM.On("GenerateTicketDetails", mock.Anything, "input", "prompt", mock.Anything).
+		Return(llm.LLMResponse{Summary: "Added retries to the export job.", Description: "**Context:**\nThe job fails.", ProjectNameSuggestion: "Backend"}, nil)
+	cfgs, err := loadAllConfigs(runner.configProvider)
+	require.NoError(t, err)
+
+	cfgs.appConfig.LLM.PostProcessing = config.PostProcessingConfig{SummaryMaxLength: 20, ImperativeSummary: true}
+	request, err := runner.buildIssueRequest(context.Background(), &bytes.Buffer{}, cfgs, "input", issueRequestOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "Add retries to...", request.Summary)
+	assert.Equal(t, "## Context\n\nThe job fails.", request.Description)
+
+	cfgs.appConfig.LLM.PostProcessing = config.PostProcessingConfig{SummaryMaxLength: -1}
+	_, err = runner.buildIssueRequest(context.Background(), &bytes.Buffer{}, cfgs, "input", issueRequestOptions{})
+	assert.ErrorIs(t, err, llm.ErrLLMInvalidPostProcessOptions)
+}
+
+func TestBuildIssueRequest_Guardrails(t *testing.T) {
+	Log = zerolog.Nop()
+	newRunner := func(guards config.GuardrailsConfig) (*createCmdRunner, *MockLLMClient) {
+		mockProvider := new(MockConfigProvider)
+		mockProvider.On("LoadConfig").Return(&config.AppConfig{Guardrails: guards}, nil)
+		mockProvider.On("LoadLinks").Return(&config.LinksConfig{Projects: []config.ProjectLink{
+			{Name: "Backend", Key: "BE"},
+			{Name: "Support", Key: "SUP", Guardrails: "internal"},
+		}}, nil)
+		mockProvider.On("LoadSystemPrompt").Return("prompt", nil)
+		mockProvider.On("LoadContext").Return("", nil)
+		mockLLM := new(MockLLMClient)
+		return NewCreateCmdRunnerForTest(mockProvider, mockLLM, nil, &DefaultProjectMapper{}, &DefaultIssueTypeResolver{}), mockLLM
+	}
+	guards := config.GuardrailsConfig{
+		Profile: "default",
+		Profiles: map[string]config.GuardrailProfile{
+			"default":  {Terms: []string{"Acme"}},
+			"internal": {Action: "redact", Terms: []string{"Acme"}},
+		},
+	}
+
+	runner, mockLLM := newRunner(guards)
+	mockLLM.On("GenerateTicketDetails", mock.Anything, "backend", "prompt", mock.Anything).
+		Return(llm.LLMResponse{Summary: "Fix Acme export", Description: "Details", ProjectNameSuggestion: "Backend"}, nil)
+	mockLLM.On("GenerateTicketDetails", mock.Anything, "support", "prompt", mock.Anything).
+		Return(llm.LLMResponse{Summary: "Call Acme back", Description: "Acme asked twice", ProjectNameSuggestion: "Support"}, nil)
+	cfgs, err := loadAllConfigs(runner.configProvider)
+	require.NoError(t, err)
+
+	var errOut bytes.Buffer
+	_, err = runner.buildIssueRequest(context.Background(), &errOut, cfgs, "backend", issueRequestOptions{})
+	assert.ErrorIs(t, err, guardrails.ErrBlocked, "the default profile blocks")
+	assert.Equal(t, clierr.CodeGuardrails, clierr.CodeOf(err))
+	assert.Contains(t, clierr.HintOf(err), "action: redact")
+
+	errOut.Reset()
+	request, err := runner.buildIssueRequest(context.Background(), &errOut, cfgs, "support", issueRequestOptions{})
+	require.NoError(t, err, "the profile of the project redacts")
+	assert.Equal(t, "Call [REDACTED:term] back", request.Summary)
+	assert.Equal(t, "[REDACTED:term] asked twice", request.Description)
+	assert.Contains(t, errOut.String(), "guardrail profile 'internal' redacted 2 match(es)")
+
+	guards.Profiles = map[string]config.GuardrailProfile{"default": {}}
+	runner, _ = newRunner(guards)
+	_, err = loadAllConfigs(runner.configProvider)
+	assert.ErrorIs(t, err, guardrails.ErrUnknownProfile, "links.yaml selects a missing profile")
+}
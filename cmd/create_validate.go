@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+// createMetaCacheTTL is how long a project's create metadata is reused before it is fetched again.
+const createMetaCacheTTL = 24 * time.Hour
+
+// errInvalidIssueRequest is returned when an issue request does not match the project's create metadata.
+var errInvalidIssueRequest = errors.New("invalid issue request")
+
+// providedCreateFields are the fields set by every CreateIssueRequest, plus fields Jira fills in itself.
+var providedCreateFields = map[string]bool{
+	"project":     true,
+	"summary":     true,
+	"description": true,
+	"issuetype":   true,
+	"reporter":    true,
+}
+
+// findIssueTypeMeta returns the metadata of the named issue type (case-insensitive), or nil.
+func findIssueTypeMeta(meta *mcpclient.CreateMeta, issueType string) *mcpclient.IssueTypeMeta {
+	for i := range meta.IssueTypes {
+		if strings.EqualFold(meta.IssueTypes[i].Name, issueType) {
+			return &meta.IssueTypes[i]
+		}
+	}
+	return nil
+}
+
+// issueTypeNames returns the names of the issue types in meta.
+func issueTypeNames(meta *mcpclient.CreateMeta) []string {
+	names := make([]string, 0, len(meta.IssueTypes))
+	for _, typeMeta := range meta.IssueTypes {
+		names = append(names, typeMeta.Name)
+	}
+	return names
+}
+
+// unknownIssueTypeError reports that a project has no issue type named issueType.
+func unknownIssueTypeError(projectKey, issueType string, available []string) error {
+	return fmt.Errorf("%w: project %s has no type '%s'; available: %s", errInvalidIssueRequest, projectKey, issueType, strings.Join(available, ", "))
+}
+
+// findFieldMeta returns the field of typeMeta whose ID or name matches name (case-insensitive), or nil.
+func findFieldMeta(typeMeta *mcpclient.IssueTypeMeta, name string) *mcpclient.FieldMeta {
+	for i := range typeMeta.Fields {
+		if strings.EqualFold(typeMeta.Fields[i].ID, name) || strings.EqualFold(typeMeta.Fields[i].Name, name) {
+			return &typeMeta.Fields[i]
+		}
+	}
+	return nil
+}
+
+// allowedValue returns the allowed value of field matching value (case-insensitive) and whether
+// there is one. Any value is accepted when the field has no allowed values.
+func allowedValue(field *mcpclient.FieldMeta, value string) (string, bool) {
+	if len(field.AllowedValues) == 0 {
+		return value, true
+	}
+	for _, allowed := range field.AllowedValues {
+		if strings.EqualFold(allowed, value) {
+			return allowed, true
+		}
+	}
+	return "", false
+}
+
+// validateCreateRequest checks request against the project's create metadata: the issue type
+// must exist, the priority, due date and custom fields must be on the create screen with allowed values,
+// and every required field must be provided. It returns the request with custom fields keyed
+// by field ID and values in the server's spelling, ready to be sent.
+func validateCreateRequest(meta *mcpclient.CreateMeta, request mcpclient.CreateIssueRequest) (mcpclient.CreateIssueRequest, error) {
+	typeMeta := findIssueTypeMeta(meta, request.IssueType)
+	if typeMeta == nil {
+		return request, unknownIssueTypeError(request.ProjectKey, request.IssueType, issueTypeNames(meta))
+	}
+	request.IssueType = typeMeta.Name
+	provided := make(map[string]bool, len(providedCreateFields))
+	for id := range providedCreateFields {
+		provided[id] = true
+	}
+
+	if request.Priority != "" {
+		field := findFieldMeta(typeMeta, "priority")
+		if field == nil {
+			return request, fmt.Errorf("%w: %s in project %s does not have a priority field", errInvalidIssueRequest, typeMeta.Name, request.ProjectKey)
+		}
+		value, ok := allowedValue(field, request.Priority)
+		if !ok {
+			return request, fmt.Errorf("%w: %s in project %s has no priority '%s'; available: %s", errInvalidIssueRequest, typeMeta.Name, request.ProjectKey, request.Priority, strings.Join(field.AllowedValues, ", "))
+		}
+		request.Priority = value
+		provided["priority"] = true
+	}
+
+	if request.DueDate != "" {
+		if findFieldMeta(typeMeta, "duedate") == nil {
+			return request, fmt.Errorf("%w: %s in project %s does not have a due date field", errInvalidIssueRequest, typeMeta.Name, request.ProjectKey)
+		}
+		provided["duedate"] = true
+	}
+
+	if len(request.Fields) > 0 {
+		names := make([]string, 0, len(request.Fields))
+		for name := range request.Fields {
+			names = append(names, name)
+		}
+		sort.Strings(names) // Report the same field first on every run
+		fields := make(map[string]interface{}, len(request.Fields))
+		for _, name := range names {
+			field := findFieldMeta(typeMeta, name)
+			if field == nil {
+				return request, fmt.Errorf("%w: %s in project %s has no field '%s'; run 'tix fields list %s -t %s' to see its fields", errInvalidIssueRequest, typeMeta.Name, request.ProjectKey, name, request.ProjectKey, typeMeta.Name)
+			}
+			value := request.Fields[name]
+			if s, ok := value.(string); ok {
+				allowed, ok := allowedValue(field, s)
+				if !ok {
+					return request, fmt.Errorf("%w: %s (%s) does not allow '%s'; available: %s", errInvalidIssueRequest, field.Name, field.ID, s, strings.Join(field.AllowedValues, ", "))
+				}
+				value = allowed
+			}
+			fields[field.ID] = value
+			provided[strings.ToLower(field.ID)] = true
+		}
+		request.Fields = fields
+	}
+
+	var missing []string
+	for _, field := range typeMeta.Fields {
+		if field.Required && !provided[strings.ToLower(field.ID)] {
+			missing = append(missing, fmt.Sprintf("%s (%s)", field.Name, field.ID))
+		}
+	}
+	if len(missing) > 0 {
+		return request, fmt.Errorf("%w: %s in project %s requires fields that are not set: %s", errInvalidIssueRequest, typeMeta.Name, request.ProjectKey, strings.Join(missing, ", "))
+	}
+	return request, nil
+}
+
+// createMeta returns the create metadata of a project, from the cache unless refresh is set.
+// The second result reports whether the metadata came from the cache.
+func (r *createCmdRunner) createMeta(ctx context.Context, projectKey string, refresh bool) (*mcpclient.CreateMeta, bool, error) {
+	cacheKey := "createmeta-" + strings.ToUpper(projectKey)
+	if r.metaCache != nil && !refresh {
+		var meta mcpclient.CreateMeta
+		found, err := r.metaCache.Get(cacheKey, &meta)
+		if err != nil {
+			Log.Debug().Err(err).Str("project_key", projectKey).Msg("Ignoring unreadable create metadata cache entry")
+		} else if found {
+			return &meta, true, nil
+		}
+	}
+	meta, err := r.mcpClient.GetCreateMeta(ctx, projectKey)
+	if err != nil {
+		return nil, false, err
+	}
+	if r.metaCache != nil {
+		if err := r.metaCache.Set(cacheKey, meta); err != nil {
+			Log.Debug().Err(err).Str("project_key", projectKey).Msg("Failed to cache create metadata")
+		}
+	}
+	return meta, false, nil
+}
+
+// allowedIssueTypes returns the names of the issue types that can be created in a project,
+// using the cached create metadata unless refresh is set.
+func (r *createCmdRunner) allowedIssueTypes(ctx context.Context, projectKey string, refresh bool) ([]string, error) {
+	if r.mcpClient == nil {
+		return nil, errMCPClientNotInitialized
+	}
+	meta, _, err := r.createMeta(ctx, projectKey, refresh)
+	if err != nil {
+		return nil, err
+	}
+	return issueTypeNames(meta), nil
+}
+
+// checkIssueType rejects an explicitly requested issue type that the project does not have,
+// if the resolver supports checking types.
+func (r *createCmdRunner) checkIssueType(ctx context.Context, issueType, projectKey string) error {
+	checker, ok := r.issueTypeResolver.(IssueTypeChecker)
+	if !ok || issueType == "" || projectKey == "" {
+		return nil
+	}
+	return checker.CheckType(ctx, issueType, projectKey)
+}
+
+// validateIssueRequest validates the request against the create metadata of its project and
+// returns it normalized for submission. Validation is skipped when the metadata is unavailable
+// (e.g. the MCP server does not support it), so the server remains the final authority.
+// A request rejected by cached metadata is checked again against fresh metadata, so a type or
+// field added in Jira since the cache was filled is not reported as missing.
+func (r *createCmdRunner) validateIssueRequest(ctx context.Context, request mcpclient.CreateIssueRequest) (mcpclient.CreateIssueRequest, error) {
+	meta, cached, err := r.createMeta(ctx, request.ProjectKey, false)
+	if err != nil {
+		Log.Warn().Err(err).Str("project_key", request.ProjectKey).Msg("Create metadata unavailable, skipping local validation")
+		return request, nil
+	}
+	validated, err := validateCreateRequest(meta, request)
+	if err == nil || !cached {
+		return validated, err
+	}
+	Log.Debug().Err(err).Str("project_key", request.ProjectKey).Msg("Cached create metadata rejected the request, refreshing")
+	meta, _, fetchErr := r.createMeta(ctx, request.ProjectKey, true)
+	if fetchErr != nil {
+		return validated, err
+	}
+	return validateCreateRequest(meta, request)
+}
+
+// submitIssue validates request against the project's create metadata, expands @mentions in
+// the description, creates the issue between the configured hooks and links it to the issues
+// in request.LinkTo. It returns the request as sent so callers can record it.
+func (r *createCmdRunner) submitIssue(ctx context.Context, request mcpclient.CreateIssueRequest) (mcpclient.CreateIssueRequest, *mcpclient.CreateIssueResponse, error) {
+	request, err := r.validateIssueRequest(ctx, request)
+	if err != nil {
+		return request, nil, err
+	}
+	if request.IdempotencyKey == "" {
+		request.IdempotencyKey = idempotencyKey(requestInput(request), request.ProjectKey)
+	}
+	if entry := r.recentCreate(request); entry != nil {
+		Log.Warn().Str("issue_key", entry.IssueKey).Time("created", entry.Timestamp).Msg("Issue recently created from the same input; relying on the MCP server to deduplicate")
+	}
+	request.Description = expandMentions(ctx, r.mcpClient, request.Description)
+	if err := r.runPreCreateHooks(ctx, request); err != nil {
+		return request, nil, err
+	}
+	resp, err := r.mcpClient.CreateIssue(ctx, request)
+	if err != nil || resp == nil {
+		return request, resp, err
+	}
+	if resp.Replayed {
+		Log.Warn().Str("issue_key", resp.Key).Msg("MCP server returned the issue created earlier for the same idempotency key")
+	} else {
+		linkRelatedIssues(ctx, r.mcpClient, resp.Key, request)
+	}
+	r.runPostCreateHooks(ctx, request, resp)
+	return request, resp, nil
+}
+
+// parseFieldFlags parses repeated --field ID=VALUE flags. Field names are resolved to IDs
+// during validation, once the project's metadata is known.
+func parseFieldFlags(values []string) (map[string]interface{}, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	fields := make(map[string]interface{}, len(values))
+	for _, item := range values {
+		name, value, found := strings.Cut(item, "=")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if !found || name == "" {
+			return nil, fmt.Errorf("invalid --field %q: expected FIELD=VALUE", item)
+		}
+		fields[name] = value
+	}
+	return fields, nil
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/karolswdev/ticketron/internal/cache"
+	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/llm"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+func testCreateMeta() *mcpclient.CreateMeta {
+	return &mcpclient.CreateMeta{ProjectKey: "BE", IssueTypes: []mcpclient.IssueTypeMeta{
+		{Name: "Task", Fields: []mcpclient.FieldMeta{{ID: "summary", Name: "Summary", Required: true}, {ID: "issuetype", Name: "Issue Type", Required: true}}},
+		{Name: "Bug", Fields: []mcpclient.FieldMeta{
+			{ID: "summary", Name: "Summary", Required: true},
+			{ID: "customfield_10010", Name: "Severity", Required: true},
+			{ID: "components", Name: "Component/s", Required: true},
+			{ID: "labels", Name: "Labels"},
+			{ID: "priority", Name: "Priority", AllowedValues: []string{"High", "Low"}},
+			{ID: "customfield_10020", Name: "Environment", AllowedValues: []string{"Staging", "Production"}},
+		}},
+	}}
+}
+
+func TestValidateCreateRequest(t *testing.T) {
+	meta := testCreateMeta()
+
+	request, err := validateCreateRequest(meta, mcpclient.CreateIssueRequest{ProjectKey: "BE", IssueType: "task", Summary: "x"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Task", request.IssueType, "the type is normalized to the server's spelling")
+
+	_, err = validateCreateRequest(meta, mcpclient.CreateIssueRequest{ProjectKey: "BE", IssueType: "Story"})
+	assert.ErrorIs(t, err, errInvalidIssueRequest)
+	assert.ErrorContains(t, err, "project BE has no type 'Story'; available: Task, Bug")
+
+	_, err = validateCreateRequest(meta, mcpclient.CreateIssueRequest{ProjectKey: "BE", IssueType: "Bug"})
+	assert.ErrorIs(t, err, errInvalidIssueRequest)
+	assert.ErrorContains(t, err, "Bug in project BE requires fields that are not set: Severity (customfield_10010), Component/s (components)")
+}
+
+func TestValidateCreateRequest_PriorityAndFields(t *testing.T) {
+	meta := testCreateMeta()
+	bug := func(priority string, fields map[string]interface{}) mcpclient.CreateIssueRequest {
+		return mcpclient.CreateIssueRequest{ProjectKey: "BE", IssueType: "Bug", Summary: "x", Priority: priority, Fields: fields}
+	}
+
+	request, err := validateCreateRequest(meta, bug("high", map[string]interface{}{
+		"Severity":          "S2",
+		"components":        []string{"api"},
+		"customfield_10020": "staging",
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, "High", request.Priority)
+	assert.Equal(t, map[string]interface{}{
+		"customfield_10010": "S2",
+		"components":        []string{"api"},
+		"customfield_10020": "Staging",
+	}, request.Fields, "fields are keyed by ID and values use the server's spelling")
+
+	required := map[string]interface{}{"Severity": "S2", "components": "api"}
+	_, err = validateCreateRequest(meta, bug("Urgent", required))
+	assert.ErrorIs(t, err, errInvalidIssueRequest)
+	assert.ErrorContains(t, err, "Bug in project BE has no priority 'Urgent'; available: High, Low")
+
+	_, err = validateCreateRequest(meta, bug("", map[string]interface{}{"Severity": "S2", "components": "api", "Environment": "QA"}))
+	assert.ErrorContains(t, err, "Environment (customfield_10020) does not allow 'QA'; available: Staging, Production")
+
+	_, err = validateCreateRequest(meta, bug("", map[string]interface{}{"Severity": "S2", "components": "api", "Team": "core"}))
+	assert.ErrorContains(t, err, "Bug in project BE has no field 'Team'")
+
+	_, err = validateCreateRequest(meta, mcpclient.CreateIssueRequest{ProjectKey: "BE", IssueType: "Task", Summary: "x", Priority: "High"})
+	assert.ErrorContains(t, err, "Task in project BE does not have a priority field")
+
+	_, err = validateCreateRequest(meta, mcpclient.CreateIssueRequest{ProjectKey: "BE", IssueType: "Task", Summary: "x", DueDate: "2025-05-01"})
+	assert.ErrorContains(t, err, "Task in project BE does not have a due date field")
+	meta.IssueTypes[0].Fields = append(meta.IssueTypes[0].Fields, mcpclient.FieldMeta{ID: "duedate", Name: "Due date"})
+	request, err = validateCreateRequest(meta, mcpclient.CreateIssueRequest{ProjectKey: "BE", IssueType: "Task", Summary: "x", DueDate: "2025-05-01"})
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-05-01", request.DueDate)
+}
+
+func TestParseFieldFlags(t *testing.T) {
+	fields, err := parseFieldFlags([]string{"Severity=S2", " customfield_10020 = Staging "})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"Severity": "S2", "customfield_10020": "Staging"}, fields)
+
+	fields, err = parseFieldFlags(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, fields)
+
+	_, err = parseFieldFlags([]string{"Severity"})
+	assert.ErrorContains(t, err, "expected FIELD=VALUE")
+}
+
+func TestValidateIssueRequest_Cache(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	runner := NewCreateCmdRunnerForTest(nil, nil, mockMCP, nil, nil)
+	runner.metaCache = cache.New(t.TempDir(), time.Hour)
+
+	stale := &mcpclient.CreateMeta{ProjectKey: "BE", IssueTypes: []mcpclient.IssueTypeMeta{{Name: "Task"}}}
+	mockMCP.On("GetCreateMeta", mock.Anything, "BE").Return(stale, nil).Once()
+
+	request := mcpclient.CreateIssueRequest{ProjectKey: "BE", IssueType: "Task", Summary: "x"}
+	_, err := runner.validateIssueRequest(context.Background(), request)
+	assert.NoError(t, err)
+	_, err = runner.validateIssueRequest(context.Background(), request)
+	assert.NoError(t, err)
+	mockMCP.AssertNumberOfCalls(t, "GetCreateMeta", 1) // Second run used the cache
+
+	// A type missing from the cached metadata triggers one refresh before failing
+	mockMCP.On("GetCreateMeta", mock.Anything, "BE").Return(testCreateMeta(), nil).Once()
+	request.IssueType = "Bug"
+	request.Fields = map[string]interface{}{"Severity": "S1", "components": "api"}
+	_, err = runner.validateIssueRequest(context.Background(), request)
+	assert.NoError(t, err)
+	mockMCP.AssertNumberOfCalls(t, "GetCreateMeta", 2)
+
+	// A type missing from fresh metadata as well is rejected
+	mockMCP.On("GetCreateMeta", mock.Anything, "BE").Return(testCreateMeta(), nil).Once()
+	_, err = runner.validateIssueRequest(context.Background(), mcpclient.CreateIssueRequest{ProjectKey: "BE", IssueType: "Story"})
+	assert.ErrorContains(t, err, "project BE has no type 'Story'; available: Task, Bug")
+}
+
+func TestCreateCmdRunE_RejectsInvalidIssueType(t *testing.T) {
+	Log = zerolog.Nop()
+	mockProvider := new(MockConfigProvider)
+	mockLLM := new(MockLLMClient)
+	mockMCP := new(MockMCPClient)
+
+	links := &config.LinksConfig{Projects: []config.ProjectLink{{Name: "Backend", Key: "BE"}}}
+	mockProvider.On("LoadConfig").Return(&config.AppConfig{MCPServerURL: "http://mcp.example.com"}, nil)
+	mockProvider.On("LoadLinks").Return(links, nil)
+	mockProvider.On("LoadSystemPrompt").Return("prompt", nil)
+	mockProvider.On("LoadContext").Return("", nil)
+	mockLLM.On("GenerateTicketDetails", mock.Anything, "add dark mode", "prompt", llmContextWithAliases("", links)).
+		Return(llm.LLMResponse{Summary: "Dark mode", ProjectNameSuggestion: "Backend"}, nil)
+	mockMCP.On("GetCreateMeta", mock.Anything, "BE").Return(testCreateMeta(), nil)
+
+	_, err := executeCreateCmd(mockProvider, mockLLM, mockMCP, &DefaultProjectMapper{}, &DefaultIssueTypeResolver{}, []string{"add dark mode"}, map[string]string{"type": "Story"})
+	assert.ErrorIs(t, err, errInvalidIssueRequest)
+	mockMCP.AssertNotCalled(t, "CreateIssue", mock.Anything, mock.Anything)
+}
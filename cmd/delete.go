@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/i18n"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/prompt"
+)
+
+// defaultCancelTransition is the transition used by `tix delete --cancel`.
+const defaultCancelTransition = "Cancelled"
+
+// deleteOptions holds the flags of the delete command.
+type deleteOptions struct {
+	cancel     bool   // Transition instead of deleting
+	transition string // Transition used in cancel mode
+	reason     string // Optional comment attached to the transition
+}
+
+// deleteRunE deletes (or, in cancel mode, transitions) the given issues after confirmation.
+// Each issue is processed independently; failures are reported and summarized in the returned error.
+func deleteRunE(ctx context.Context, mcpClient MCPClient, confirmer *prompt.Confirmer, out, errOut io.Writer, keys []string, opts deleteOptions) error {
+	if mcpClient == nil {
+		return errMCPClientNotInitialized
+	}
+	if opts.cancel && strings.TrimSpace(opts.transition) == "" {
+		return errors.New("--transition cannot be empty in cancel mode")
+	}
+
+	question := i18n.T(i18n.MsgConfirmDelete, strings.Join(keys, ", "))
+	if opts.cancel {
+		question = i18n.T(i18n.MsgConfirmCancel, strings.Join(keys, ", "), opts.transition)
+	}
+	ok, err := confirmer.Confirm(out, question, false)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Fprintln(out, i18n.T(i18n.MsgAborted))
+		return nil
+	}
+
+	failed := 0
+	for _, key := range keys {
+		var err error
+		if opts.cancel {
+			err = mcpClient.TransitionIssue(ctx, key, mcpclient.TransitionIssueRequest{Transition: opts.transition, Comment: opts.reason})
+		} else {
+			err = mcpClient.DeleteIssue(ctx, key)
+		}
+		if err != nil {
+			failed++
+			Log.Error().Err(err).Str("issue_key", key).Msgf("Failed to %s issue", deleteVerb(opts))
+			fmt.Fprintf(errOut, "Failed to %s %s: %v\n", deleteVerb(opts), key, err)
+			continue
+		}
+		if opts.cancel {
+			fmt.Fprintf(out, "Cancelled %s (transition %q)\n", key, opts.transition)
+		} else {
+			fmt.Fprintf(out, "Deleted %s\n", key)
+		}
+	}
+
+	if failed > 0 {
+		if !opts.cancel {
+			fmt.Fprintln(errOut, i18n.T(i18n.MsgDeleteCancelHint))
+		}
+		return fmt.Errorf("failed to %s %d of %d issue(s)", deleteVerb(opts), failed, len(keys))
+	}
+	return nil
+}
+
+func deleteVerb(opts deleteOptions) string {
+	if opts.cancel {
+		return "cancel"
+	}
+	return "delete"
+}
+
+// deleteCmd represents the delete command
+var deleteCmd = &cobra.Command{
+	Use:   "delete ISSUE-KEY...",
+	Short: "Delete JIRA issues, or cancel them with --cancel",
+	Long: `Permanently deletes one or more JIRA issues via the MCP server after asking for
+confirmation (skip it with --yes).
+
+Where deleting is forbidden by the workflow or permissions, --cancel transitions
+the issues instead (to "Cancelled" by default, see --transition), optionally
+with a --reason comment.
+
+Examples:
+  tix delete PROJ-123
+  tix delete PROJ-123 PROJ-124 --yes
+  tix delete PROJ-123 --cancel --reason "Duplicate of PROJ-99"
+  tix delete PROJ-123 --cancel --transition "Won't Do"`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var opts deleteOptions
+		opts.cancel, _ = cmd.Flags().GetBool("cancel")
+		opts.transition, _ = cmd.Flags().GetString("transition")
+		opts.reason, _ = cmd.Flags().GetString("reason")
+
+		mcpClient, err := newCommandMCPClient()
+		if err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return deleteRunE(ctx, mcpClient, newConfirmer(cmd), cmd.OutOrStdout(), cmd.ErrOrStderr(), args, opts)
+	},
+}
+
+func init() {
+	deleteCmd.Flags().Bool("cancel", false, "Transition the issues instead of deleting them")
+	deleteCmd.Flags().String("transition", defaultCancelTransition, "Transition used with --cancel")
+	deleteCmd.Flags().String("reason", "", "Comment added when cancelling with --cancel")
+
+	rootCmd.AddCommand(deleteCmd)
+}
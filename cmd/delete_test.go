@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/i18n"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/prompt"
+)
+
+func TestDeleteRunE_Confirmed(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("DeleteIssue", mock.Anything, "PROJ-1").Return(nil)
+
+	var out, errOut bytes.Buffer
+	err := deleteRunE(context.Background(), mockMCP, &prompt.Confirmer{In: strings.NewReader("y\n")}, &out, &errOut, []string{"PROJ-1"}, deleteOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "Permanently delete PROJ-1? This cannot be undone. [y/N]: ")
+	assert.Contains(t, out.String(), "Deleted PROJ-1")
+	mockMCP.AssertExpectations(t)
+}
+
+func TestDeleteRunE_Declined(t *testing.T) {
+	for name, input := range map[string]string{"no": "n\n", "empty stdin": ""} {
+		t.Run(name, func(t *testing.T) {
+			mockMCP := new(MockMCPClient)
+			var out, errOut bytes.Buffer
+			err := deleteRunE(context.Background(), mockMCP, &prompt.Confirmer{In: strings.NewReader(input)}, &out, &errOut, []string{"PROJ-1"}, deleteOptions{})
+			require.NoError(t, err)
+			assert.Contains(t, out.String(), "Aborted.")
+			mockMCP.AssertNotCalled(t, "DeleteIssue", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestDeleteRunE_Polish(t *testing.T) {
+	Log = zerolog.Nop()
+	defer i18n.SetLanguage(i18n.Language())
+	i18n.SetLanguage(i18n.Polish)
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("DeleteIssue", mock.Anything, "PROJ-1").Return(nil)
+
+	var out, errOut bytes.Buffer
+	err := deleteRunE(context.Background(), mockMCP, &prompt.Confirmer{In: strings.NewReader("tak\n")}, &out, &errOut, []string{"PROJ-1"}, deleteOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "Trwale usunąć PROJ-1? Tej operacji nie można cofnąć. [t/N]: ")
+	mockMCP.AssertExpectations(t)
+}
+
+func TestDeleteRunE_NoInput(t *testing.T) {
+	mockMCP := new(MockMCPClient)
+	var out bytes.Buffer
+	err := deleteRunE(context.Background(), mockMCP, &prompt.Confirmer{NoInput: true}, &out, &bytes.Buffer{}, []string{"PROJ-1"}, deleteOptions{})
+	assert.ErrorIs(t, err, prompt.ErrInputRequired)
+	assert.Empty(t, out.String())
+	mockMCP.AssertNotCalled(t, "DeleteIssue", mock.Anything, mock.Anything)
+}
+
+func TestDeleteRunE_PartialFailure(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("DeleteIssue", mock.Anything, "PROJ-1").Return(nil)
+	mockMCP.On("DeleteIssue", mock.Anything, "PROJ-2").Return(errors.New("forbidden"))
+
+	var out, errOut bytes.Buffer
+	err := deleteRunE(context.Background(), mockMCP, &prompt.Confirmer{AssumeYes: true}, &out, &errOut, []string{"PROJ-1", "PROJ-2"}, deleteOptions{})
+	assert.EqualError(t, err, "failed to delete 1 of 2 issue(s)")
+	assert.Contains(t, out.String(), "Deleted PROJ-1")
+	assert.NotContains(t, out.String(), "[y/N]", "--yes skips the prompt")
+	assert.Contains(t, errOut.String(), "Failed to delete PROJ-2: forbidden")
+	assert.Contains(t, errOut.String(), "use --cancel")
+}
+
+func TestDeleteRunE_Cancel(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("TransitionIssue", mock.Anything, "PROJ-3", mcpclient.TransitionIssueRequest{Transition: "Won't Do", Comment: "Duplicate"}).Return(nil)
+
+	var out, errOut bytes.Buffer
+	opts := deleteOptions{cancel: true, transition: "Won't Do", reason: "Duplicate"}
+	err := deleteRunE(context.Background(), mockMCP, &prompt.Confirmer{In: strings.NewReader("yes\n")}, &out, &errOut, []string{"PROJ-3"}, opts)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), `Transition PROJ-3 to "Won't Do"? [y/N]: `)
+	assert.Contains(t, out.String(), `Cancelled PROJ-3 (transition "Won't Do")`)
+	mockMCP.AssertNotCalled(t, "DeleteIssue", mock.Anything, mock.Anything)
+	mockMCP.AssertExpectations(t)
+}
+
+func TestDeleteRunE_NoMCPClient(t *testing.T) {
+	err := deleteRunE(context.Background(), nil, &prompt.Confirmer{AssumeYes: true}, &bytes.Buffer{}, &bytes.Buffer{}, []string{"PROJ-1"}, deleteOptions{})
+	assert.ErrorIs(t, err, errMCPClientNotInitialized)
+}
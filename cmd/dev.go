@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// devCmd represents the dev command group
+var devCmd = &cobra.Command{
+	Use:   "dev",
+	Short: "Developer and testing tools",
+	Long: `Provides tools for developing against Ticketron and testing it without real services.
+This command itself does not perform any action but serves as a parent for subcommands.`,
+	// No Run function needed for a parent command
+}
+
+func init() {
+	rootCmd.AddCommand(devCmd)
+}
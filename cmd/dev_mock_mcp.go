@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/mcpclient/mockserver"
+)
+
+// mockMCPOptions reads the mock server options from the mock-mcp flags.
+func mockMCPOptions(cmd *cobra.Command) (mockserver.Options, error) {
+	var opts mockserver.Options
+	opts.Latency, _ = cmd.Flags().GetDuration("latency")
+	opts.Jitter, _ = cmd.Flags().GetDuration("jitter")
+	opts.ErrorRate, _ = cmd.Flags().GetFloat64("error-rate")
+	opts.ErrorStatus, _ = cmd.Flags().GetInt("error-status")
+	opts.Seed, _ = cmd.Flags().GetUint64("seed")
+	opts.Projects, _ = cmd.Flags().GetStringSlice("project")
+	opts.IssueTypes, _ = cmd.Flags().GetStringSlice("issue-types")
+	if err := opts.Validate(); err != nil {
+		return mockserver.Options{}, err
+	}
+	return opts, nil
+}
+
+// devMockMCPCmd represents the dev mock-mcp command
+var devMockMCPCmd = &cobra.Command{
+	Use:   "mock-mcp",
+	Short: "Run an in-memory mock MCP server",
+	Long: `Starts an HTTP server implementing the MCP server API used by Ticketron
+(create, search, view, update, transition, delete, comments, create metadata and
+user search) on top of an in-memory issue store, so end-to-end flows can run
+without a real Jira. Issues are lost when the server stops.
+
+Point Ticketron at it with:
+  TICKETRON_MCP_SERVER_URL=http://127.0.0.1:8766 tix create "..."
+
+--latency, --jitter and --error-rate inject delays and failures to exercise
+timeouts and error handling; --seed makes the injected failures reproducible.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addr, _ := cmd.Flags().GetString("addr")
+		opts, err := mockMCPOptions(cmd)
+		if err != nil {
+			return err
+		}
+		mock, err := mockserver.New(opts)
+		if err != nil {
+			return err
+		}
+		server := &http.Server{
+			Addr:              addr,
+			Handler:           mock,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		errCh := make(chan error, 1)
+		go func() {
+			Log.Info().Str("addr", addr).Dur("latency", opts.Latency).Float64("error_rate", opts.ErrorRate).Msg("Mock MCP server listening")
+			fmt.Fprintf(cmd.ErrOrStderr(), "Mock MCP server listening on http://%s\n", addr)
+			errCh <- server.ListenAndServe()
+		}()
+
+		select {
+		case err := <-errCh:
+			if errors.Is(err, http.ErrServerClosed) {
+				return nil
+			}
+			return fmt.Errorf("server failed: %w", err)
+		case <-ctx.Done():
+			Log.Info().Int("issues", len(mock.Issues())).Msg("Shutting down mock MCP server")
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			return server.Shutdown(shutdownCtx)
+		}
+	},
+}
+
+// addMockMCPFlags registers the mock-mcp flags on cmd.
+func addMockMCPFlags(cmd *cobra.Command) {
+	cmd.Flags().String("addr", "127.0.0.1:8766", "Address to listen on")
+	cmd.Flags().Duration("latency", 0, "Delay every response by this duration (e.g. 200ms)")
+	cmd.Flags().Duration("jitter", 0, "Add a random delay of up to this duration on top of --latency")
+	cmd.Flags().Float64("error-rate", 0, "Fraction of requests (0 to 1) that fail with --error-status")
+	cmd.Flags().Int("error-status", mockserver.DefaultErrorStatus, "HTTP status code of injected failures")
+	cmd.Flags().Uint64("seed", 0, "Seed for jitter and injected failures (0 picks a random seed)")
+	cmd.Flags().StringSlice("project", nil, "Only accept these project keys (default: any)")
+	cmd.Flags().StringSlice("issue-types", nil, "Issue types of every project (default: Task, Bug, Story, Epic)")
+}
+
+func init() {
+	addMockMCPFlags(devMockMCPCmd)
+	devCmd.AddCommand(devMockMCPCmd)
+}
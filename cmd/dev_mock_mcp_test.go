@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/mcpclient/mockserver"
+)
+
+func TestMockMCPOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    mockserver.Options
+		wantErr error
+	}{
+		{
+			name: "defaults",
+			want: mockserver.Options{ErrorStatus: http.StatusInternalServerError, Projects: []string{}, IssueTypes: []string{}},
+		},
+		{
+			name: "all flags",
+			args: []string{"--latency", "200ms", "--jitter", "50ms", "--error-rate", "0.25", "--error-status", "503", "--seed", "7", "--project", "PROJ,OPS", "--issue-types", "Task,Bug"},
+			want: mockserver.Options{
+				Latency:     200 * time.Millisecond,
+				Jitter:      50 * time.Millisecond,
+				ErrorRate:   0.25,
+				ErrorStatus: http.StatusServiceUnavailable,
+				Seed:        7,
+				Projects:    []string{"PROJ", "OPS"},
+				IssueTypes:  []string{"Task", "Bug"},
+			},
+		},
+		{
+			name:    "error rate out of range",
+			args:    []string{"--error-rate", "2"},
+			wantErr: mockserver.ErrInvalidErrorRate,
+		},
+		{
+			name:    "success status",
+			args:    []string{"--error-status", "200"},
+			wantErr: mockserver.ErrInvalidErrorStatus,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "mock-mcp"}
+			addMockMCPFlags(cmd)
+			require.NoError(t, cmd.ParseFlags(tt.args))
+
+			opts, err := mockMCPOptions(cmd)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, opts)
+		})
+	}
+}
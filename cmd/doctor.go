@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/httpclient"
+	"github.com/karolswdev/ticketron/internal/output"
+)
+
+// doctorProbeTimeout bounds the MCP connectivity check.
+const doctorProbeTimeout = 5 * time.Second
+
+// errDoctorChecksFailed is returned by doctor when at least one check fails.
+var errDoctorChecksFailed = errors.New("one or more checks failed")
+
+// Statuses reported for a doctor check.
+const (
+	doctorOK   = "OK"
+	doctorWarn = "WARN"
+	doctorFail = "FAIL"
+)
+
+// doctorCheck is the outcome of a single diagnostic.
+type doctorCheck struct {
+	Name   string
+	Status string
+	Detail string
+}
+
+// doctorRunE runs all diagnostics against the configuration from cfgProvider, prints a
+// report to out and returns errDoctorChecksFailed if any check failed. With plain set,
+// each check is printed as labeled lines instead of a table row.
+func doctorRunE(ctx context.Context, cfgProvider ConfigProvider, plain bool, out io.Writer) error {
+	checks := runDoctorChecks(ctx, cfgProvider)
+
+	table := &output.Table{Labels: []string{"Status", "Check", "Detail"}}
+	failed := false
+	for _, check := range checks {
+		status := check.Status
+		if !plain {
+			status = "[" + status + "]"
+		}
+		table.Row(status, check.Name, check.Detail)
+		failed = failed || check.Status == doctorFail
+	}
+	if err := table.Render(out, plain); err != nil {
+		return err
+	}
+	if failed {
+		return errDoctorChecksFailed
+	}
+	return nil
+}
+
+// runDoctorChecks performs the diagnostics in order. Checks that depend on the main
+// configuration are skipped when it cannot be loaded.
+func runDoctorChecks(ctx context.Context, cfgProvider ConfigProvider) []doctorCheck {
+	cfg, err := cfgProvider.LoadConfig()
+	if err != nil {
+		return []doctorCheck{{Name: "Configuration", Status: doctorFail, Detail: err.Error()}}
+	}
+	checks := []doctorCheck{{Name: "Configuration", Status: doctorOK, Detail: "config.yaml loaded"}}
+
+	if links, err := cfgProvider.LoadLinks(); err != nil {
+		checks = append(checks, doctorCheck{Name: "Project links", Status: doctorFail, Detail: err.Error()})
+	} else if links == nil || len(links.Projects) == 0 {
+		checks = append(checks, doctorCheck{Name: "Project links", Status: doctorWarn, Detail: "no projects defined in links.yaml"})
+	} else {
+		checks = append(checks, doctorCheck{Name: "Project links", Status: doctorOK, Detail: fmt.Sprintf("%d project(s)", len(links.Projects))})
+	}
+
+	checks = append(checks, checkLLMAPIKey(cfg, cfgProvider), checkLLMHTTP(cfg))
+
+	urlCheck, baseURL := checkMCPServerURL(cfg)
+	checks = append(checks, urlCheck)
+	tlsCheck, tlsOK := checkMCPTLS(cfg)
+	checks = append(checks, tlsCheck)
+	if baseURL != nil && tlsOK {
+		checks = append(checks, checkMCPConnectivity(ctx, cfg, baseURL))
+	}
+	return checks
+}
+
+func checkLLMAPIKey(cfg *config.AppConfig, cfgProvider ConfigProvider) doctorCheck {
+	check := doctorCheck{Name: "LLM API key"}
+	if cfg.LLM.Provider == "mock" {
+		check.Status, check.Detail = doctorOK, "not required for the mock provider"
+		return check
+	}
+	if _, err := cfgProvider.GetAPIKey(); err != nil {
+		check.Status = doctorFail
+		if errors.Is(err, config.ErrAPIKeyNotFound) {
+			check.Detail = "not set (use 'tix config set-key')"
+		} else {
+			check.Detail = err.Error()
+		}
+		return check
+	}
+	check.Status, check.Detail = doctorOK, "set"
+	return check
+}
+
+func checkLLMHTTP(cfg *config.AppConfig) doctorCheck {
+	check := doctorCheck{Name: "LLM HTTP"}
+	if cfg.LLM.HTTP.IsZero() {
+		check.Status, check.Detail = doctorOK, "using environment proxy settings and system CAs"
+		return check
+	}
+	if _, err := httpclient.New(cfg.LLM.HTTP); err != nil {
+		check.Status, check.Detail = doctorFail, err.Error()
+		return check
+	}
+	check.Status, check.Detail = doctorOK, "llm.http settings valid"
+	return check
+}
+
+// checkMCPServerURL validates mcp_server_url and returns the parsed URL when usable.
+func checkMCPServerURL(cfg *config.AppConfig) (doctorCheck, *url.URL) {
+	check := doctorCheck{Name: "MCP server URL"}
+	if cfg.MCPServerURL == "" {
+		check.Status, check.Detail = doctorFail, "mcp_server_url is not set"
+		return check, nil
+	}
+	u, err := url.Parse(cfg.MCPServerURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		check.Status, check.Detail = doctorFail, fmt.Sprintf("invalid URL %q", cfg.MCPServerURL)
+		return check, nil
+	}
+	check.Status, check.Detail = doctorOK, cfg.MCPServerURL
+	return check, u
+}
+
+// checkMCPTLS validates mcp.tls and reports whether the settings are usable.
+func checkMCPTLS(cfg *config.AppConfig) (doctorCheck, bool) {
+	check := doctorCheck{Name: "MCP TLS"}
+	tlsSettings := cfg.MCP.TLS
+	if tlsSettings.IsZero() {
+		check.Status, check.Detail = doctorOK, "using system CAs"
+		return check, true
+	}
+	if _, err := httpclient.TLSConfig(tlsSettings); err != nil {
+		check.Status, check.Detail = doctorFail, err.Error()
+		return check, false
+	}
+	var details []string
+	if tlsSettings.CAFile != "" {
+		details = append(details, "CA bundle "+tlsSettings.CAFile)
+	}
+	if tlsSettings.CertFile != "" {
+		details = append(details, "client certificate "+tlsSettings.CertFile)
+	}
+	check.Status = doctorOK
+	if tlsSettings.InsecureSkipVerify {
+		check.Status = doctorWarn
+		details = append(details, "insecure_skip_verify is enabled, certificates are NOT verified")
+	}
+	check.Detail = strings.Join(details, "; ")
+	return check, true
+}
+
+// checkMCPConnectivity sends a GET to the MCP server using the configured TLS settings.
+// Any HTTP response counts as reachable; transport and TLS errors fail the check.
+func checkMCPConnectivity(ctx context.Context, cfg *config.AppConfig, baseURL *url.URL) doctorCheck {
+	check := doctorCheck{Name: "MCP connectivity"}
+	tlsCfg, _ := httpclient.TLSConfig(cfg.MCP.TLS) // Already validated by checkMCPTLS
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsCfg
+	client := &http.Client{Transport: transport, Timeout: doctorProbeTimeout}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL.String(), nil)
+	if err != nil {
+		check.Status, check.Detail = doctorFail, err.Error()
+		return check
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		check.Status, check.Detail = doctorFail, err.Error()
+		return check
+	}
+	resp.Body.Close()
+	check.Status, check.Detail = doctorOK, fmt.Sprintf("reachable (HTTP %d)", resp.StatusCode)
+	return check
+}
+
+// doctorCmd represents the doctor command
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the Ticketron configuration and connectivity",
+	Long: `Validates config.yaml and links.yaml, checks that an LLM API key is available,
+validates the llm.http and mcp.tls settings (CA bundles, client certificates) and
+verifies that the MCP server is reachable with them.
+
+Exits with a non-zero status if any check fails.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return doctorRunE(ctx, &DefaultConfigProvider{}, plainOutput(cmd), cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
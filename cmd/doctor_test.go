@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/config"
+)
+
+func newDoctorConfigProvider(cfg *config.AppConfig) *MockConfigProvider {
+	mockProvider := new(MockConfigProvider)
+	mockProvider.On("LoadConfig").Return(cfg, nil)
+	mockProvider.On("LoadLinks").Return(&config.LinksConfig{Projects: []config.ProjectLink{{Name: "Backend", Key: "BE"}}}, nil)
+	mockProvider.On("GetAPIKey").Return("test-key", nil)
+	return mockProvider
+}
+
+func TestDoctorRunE_PrivateCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cfg := &config.AppConfig{MCPServerURL: server.URL, LLM: config.LLMConfig{Provider: "openai"}}
+
+	// Without the CA bundle the server certificate is not trusted.
+	var out bytes.Buffer
+	err := doctorRunE(context.Background(), newDoctorConfigProvider(cfg), false, &out)
+	assert.ErrorIs(t, err, errDoctorChecksFailed)
+	assert.Regexp(t, `\[FAIL\]\s+MCP connectivity\s+.*certificate`, out.String())
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600))
+	cfg.MCP.TLS.CAFile = caFile
+
+	out.Reset()
+	err = doctorRunE(context.Background(), newDoctorConfigProvider(cfg), false, &out)
+	require.NoError(t, err)
+	assert.Regexp(t, `\[OK\]\s+MCP TLS\s+CA bundle `+regexp.QuoteMeta(caFile), out.String())
+	assert.Regexp(t, `\[OK\]\s+MCP connectivity\s+reachable \(HTTP 404\)`, out.String())
+}
+
+func TestDoctorRunE_Plain(t *testing.T) {
+	cfg := &config.AppConfig{MCPServerURL: "http://127.0.0.1:1", LLM: config.LLMConfig{Provider: "openai"}}
+
+	var out bytes.Buffer
+	_ = doctorRunE(context.Background(), newDoctorConfigProvider(cfg), true, &out)
+	assert.Contains(t, out.String(), "Status: OK\nCheck: Configuration\nDetail: config.yaml loaded\n\n")
+	assert.NotContains(t, out.String(), "[OK]")
+}
+
+func TestDoctorRunE_InvalidTLSConfig(t *testing.T) {
+	cfg := &config.AppConfig{
+		MCPServerURL: "https://mcp.internal.example",
+		LLM:          config.LLMConfig{Provider: "openai"},
+		MCP:          config.MCPConfig{TLS: config.TLSConfig{CertFile: "client.crt"}},
+	}
+
+	var out bytes.Buffer
+	err := doctorRunE(context.Background(), newDoctorConfigProvider(cfg), false, &out)
+	assert.ErrorIs(t, err, errDoctorChecksFailed)
+	assert.Regexp(t, `\[FAIL\]\s+MCP TLS\s+failed to load client certificate`, out.String())
+	assert.NotContains(t, out.String(), "MCP connectivity", "connectivity is not probed with invalid TLS settings")
+}
+
+func TestDoctorRunE_InsecureSkipVerifyWarns(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	cfg := &config.AppConfig{
+		MCPServerURL: server.URL,
+		LLM:          config.LLMConfig{Provider: "mock"},
+		MCP:          config.MCPConfig{TLS: config.TLSConfig{InsecureSkipVerify: true}},
+	}
+
+	var out bytes.Buffer
+	err := doctorRunE(context.Background(), newDoctorConfigProvider(cfg), false, &out)
+	require.NoError(t, err, "warnings do not fail doctor")
+	assert.Regexp(t, `\[WARN\]\s+MCP TLS\s+insecure_skip_verify is enabled`, out.String())
+	assert.Regexp(t, `\[OK\]\s+MCP connectivity`, out.String())
+}
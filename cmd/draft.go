@@ -0,0 +1,455 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/draft"
+	"github.com/karolswdev/ticketron/internal/i18n"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/output"
+	"github.com/karolswdev/ticketron/internal/prompt"
+	"github.com/karolswdev/ticketron/internal/sanitize"
+)
+
+// draftTimeLayout formats the times drafts were last changed in command output.
+const draftTimeLayout = "2006-01-02 15:04"
+
+// editFile opens a file in the user's editor. Tests replace it.
+var editFile = runEditor
+
+// parseDraftID parses a draft ID as shown by 'tix draft list', with or without "#".
+func parseDraftID(arg string) (int, error) {
+	id, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid draft ID %q", arg)
+	}
+	return id, nil
+}
+
+// generateDraft runs the input of d through the LLM like 'tix create', with the options
+// of d, and returns the generated ticket.
+func generateDraft(ctx context.Context, runner *createCmdRunner, cfgs *loadedConfigs, d draft.Draft) (mcpclient.CreateIssueRequest, error) {
+	opts := issueRequestOptions{
+		issueType:  d.IssueType,
+		projectKey: d.Project,
+		priority:   d.Priority,
+		dueDate:    d.Due,
+	}
+	var hints bytes.Buffer
+	request, err := runner.buildIssueRequest(ctx, &hints, cfgs, d.Input, opts)
+	if err != nil {
+		return mcpclient.CreateIssueRequest{}, withHints(err, hints.String())
+	}
+	return request, nil
+}
+
+// setDraftTicket stores request as the generated ticket of d.
+func setDraftTicket(d *draft.Draft, request mcpclient.CreateIssueRequest) {
+	d.Ticket = &request
+	d.LinkTo, d.LinkType = request.LinkTo, request.LinkType
+}
+
+// draftNewRunE saves d as a new draft. Unless offline, its ticket is generated right away
+// so it can be reviewed with 'tix draft edit'; if that fails, e.g. without a network
+// connection, the draft is saved anyway and generated on submit.
+func draftNewRunE(ctx context.Context, runner *createCmdRunner, store *draft.Store, d draft.Draft, offline bool, now time.Time, out, errOut io.Writer) error {
+	if strings.TrimSpace(d.Input) == "" {
+		return errors.New("the draft needs an input, e.g. tix draft new \"login fails with SSO\"")
+	}
+	if !offline {
+		cfgs, err := loadAllConfigs(runner.configProvider)
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+		request, err := generateDraft(ctx, runner, cfgs, d)
+		if err != nil {
+			Log.Debug().Err(err).Msg("Saving draft without a generated ticket")
+			fmt.Fprintf(errOut, "Warning: could not generate the ticket, it is generated on submit: %v\n", err)
+		} else {
+			setDraftTicket(&d, request)
+		}
+	}
+	saved, err := store.Add(d, now)
+	if err != nil {
+		return err
+	}
+	if saved.Ticket != nil {
+		fmt.Fprintf(out, "Saved draft #%d: [%s] %s\n", saved.ID, saved.Ticket.ProjectKey, sanitize.Line(saved.Ticket.Summary))
+	} else {
+		fmt.Fprintf(out, "Saved draft #%d; its ticket is generated on submit.\n", saved.ID)
+	}
+	return nil
+}
+
+// draftListRunE prints all drafts, oldest first.
+func draftListRunE(store *draft.Store, loc *time.Location, outputFormat string, plain bool, out io.Writer) error {
+	drafts, err := store.List()
+	if err != nil {
+		return err
+	}
+	if output.IsStructured(outputFormat) {
+		if drafts == nil {
+			drafts = []draft.Draft{}
+		}
+		return output.Structured(out, outputFormat, drafts)
+	}
+	if len(drafts) == 0 {
+		fmt.Fprintln(out, "No drafts. Add one with 'tix draft new \"login fails with SSO\"'.")
+		return nil
+	}
+	table := output.NewTable("ID", "Project", "Summary", "Generated", "Updated")
+	for _, d := range drafts {
+		project, generated := d.Project, "no"
+		if d.Ticket != nil {
+			project, generated = d.Ticket.ProjectKey, "yes"
+		}
+		table.Row(strconv.Itoa(d.ID), project, sanitize.Truncate(sanitize.Line(d.Title()), 60), generated, d.UpdatedAt.In(loc).Format(draftTimeLayout))
+	}
+	return table.Render(out, plain)
+}
+
+// draftEditRunE opens the draft with the given ID in the editor and saves the changes.
+// If they cannot be applied, the edited file is kept so the changes are not lost.
+func draftEditRunE(store *draft.Store, arg string, now time.Time, out io.Writer) error {
+	id, err := parseDraftID(arg)
+	if err != nil {
+		return err
+	}
+	d, err := store.Get(id)
+	if err != nil {
+		return err
+	}
+	data, err := d.Document()
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp("", fmt.Sprintf("tix-draft-%d-*.yaml", id))
+	if err != nil {
+		return fmt.Errorf("failed to create a file to edit: %w", err)
+	}
+	path := tmp.Name()
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return fmt.Errorf("failed to create a file to edit: %w", err)
+	}
+	if err := editFile(path); err != nil {
+		os.Remove(path)
+		return err
+	}
+	edited, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read the edited draft: %w", err)
+	}
+	if bytes.Equal(edited, data) {
+		os.Remove(path)
+		fmt.Fprintf(out, "Draft #%d unchanged.\n", id)
+		return nil
+	}
+	changed, err := d.ApplyDocument(edited)
+	if err == nil {
+		_, err = store.Save(changed, now)
+	}
+	if err != nil {
+		return fmt.Errorf("%w (your changes are in %s)", err, path)
+	}
+	os.Remove(path)
+	if d.Ticket != nil && changed.Ticket == nil {
+		fmt.Fprintf(out, "Saved draft #%d; the input changed, so its ticket is generated again on submit.\n", id)
+	} else {
+		fmt.Fprintf(out, "Saved draft #%d.\n", id)
+	}
+	return nil
+}
+
+// draftSubmitRunE creates the issues of the drafts with the given IDs, or of all drafts,
+// after confirmation. Drafts without a generated ticket are generated first, and saved, so
+// a failing run does not generate them again. Each submitted draft is removed; drafts that
+// fail are reported and kept.
+func draftSubmitRunE(ctx context.Context, runner *createCmdRunner, store *draft.Store, confirmer *prompt.Confirmer, args []string, now time.Time, out, errOut io.Writer) error {
+	if runner.mcpClient == nil {
+		return errMCPClientNotInitialized
+	}
+	var drafts []draft.Draft
+	if len(args) == 0 {
+		var err error
+		if drafts, err = store.List(); err != nil {
+			return err
+		}
+	}
+	for _, arg := range args {
+		id, err := parseDraftID(arg)
+		if err != nil {
+			return err
+		}
+		d, err := store.Get(id)
+		if err != nil {
+			return err
+		}
+		drafts = append(drafts, d)
+	}
+	if len(drafts) == 0 {
+		fmt.Fprintln(out, "No drafts to submit.")
+		return nil
+	}
+	cfgs, err := loadAllConfigs(runner.configProvider)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	failed := 0
+	var ready []draft.Draft
+	for _, d := range drafts {
+		if d.Ticket == nil {
+			request, err := generateDraft(ctx, runner, cfgs, d)
+			if err != nil {
+				failed++
+				fmt.Fprintf(errOut, "Draft #%d: %v\n", d.ID, err)
+				continue
+			}
+			setDraftTicket(&d, request)
+			if d, err = store.Save(d, now); err != nil {
+				return err
+			}
+		}
+		ready = append(ready, d)
+	}
+	for _, d := range ready {
+		fmt.Fprintf(out, "#%d [%s] %s: %s\n", d.ID, d.Ticket.ProjectKey, d.Ticket.IssueType, sanitize.Line(d.Ticket.Summary))
+	}
+	if len(ready) > 0 {
+		ok, err := confirmer.Confirm(out, i18n.T(i18n.MsgConfirmDrafts, len(ready)), false)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Fprintln(out, i18n.T(i18n.MsgAborted))
+			return nil
+		}
+	}
+
+	for _, d := range ready {
+		request := *d.Ticket
+		request.LinkTo, request.LinkType = d.LinkTo, d.LinkType
+		request.IdempotencyKey = idempotencyKey(d.Input, request.ProjectKey)
+		// Edited due dates may be expressions such as "tomorrow"
+		if request.DueDate, err = dueDateFor(cfgs, request.DueDate); err != nil {
+			failed++
+			fmt.Fprintf(errOut, "Draft #%d: %v\n", d.ID, err)
+			continue
+		}
+		request, resp, err := runner.submitIssue(ctx, request)
+		if err != nil {
+			failed++
+			Log.Error().Err(err).Int("draft", d.ID).Msg("Failed to create issue from draft")
+			fmt.Fprintf(errOut, "Draft #%d: %v\n", d.ID, err)
+			continue
+		}
+		runner.recordHistory("draft", d.Input, cfgs.systemPrompt, request, resp)
+		if _, err := store.Remove(d.ID); err != nil {
+			return fmt.Errorf("created %s but could not remove draft #%d, so it may be submitted again: %w", resp.Key, d.ID, err)
+		}
+		fmt.Fprintf(out, "Created %s from draft #%d: %s\n", resp.Key, d.ID, resp.Self)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d drafts failed", failed, len(drafts))
+	}
+	return nil
+}
+
+// draftCommandContext returns the draft store and the current time in the configured
+// time zone.
+func draftCommandContext(cp ConfigProvider) (*draft.Store, time.Time, error) {
+	configDir, err := cp.EnsureConfigDir()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	now, err := configuredNow(cp)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return draft.NewStore(configDir), now, nil
+}
+
+// draftCmd represents the draft command
+var draftCmd = &cobra.Command{
+	Use:   "draft",
+	Short: "Prepare tickets locally and submit them later",
+	Long: `Manages ticket drafts stored in ~/.ticketron/drafts.json. A draft holds the input for
+the LLM, as given to 'tix create', and the ticket generated from it. Write drafts over
+time or offline, review and edit them, and create all their issues in one go with
+'tix draft submit'.`,
+	Example: `  tix draft new "login fails with SSO since the upgrade" --project backend
+  tix draft new --offline "export times out for large projects"
+  tix draft list
+  tix draft edit 2
+  tix draft submit`,
+	// No Run function needed for a parent command
+}
+
+// draftNewCmd represents the draft new command
+var draftNewCmd = &cobra.Command{
+	Use:   "new [your issue description here...]",
+	Short: "Save a new ticket draft",
+	Long: `Saves the description as a new draft and generates its ticket with the LLM, so you
+can review it with 'tix draft edit'. With --offline, or if the LLM cannot be reached,
+only the description is saved and the ticket is generated on submit.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		runner, err := newCreateCmdRunner()
+		if err != nil {
+			return err
+		}
+		if err := runner.applyLLMOverrides(cmd); err != nil {
+			return err
+		}
+		store, now, err := draftCommandContext(runner.configProvider)
+		if err != nil {
+			return err
+		}
+		d := draft.Draft{Input: strings.Join(args, " ")}
+		d.Project, _ = cmd.Flags().GetString("project")
+		d.IssueType, _ = cmd.Flags().GetString("type")
+		d.Priority, _ = cmd.Flags().GetString("priority")
+		d.Due, _ = cmd.Flags().GetString("due")
+		offline, _ := cmd.Flags().GetBool("offline")
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return draftNewRunE(ctx, runner, store, d, offline, now, cmd.OutOrStdout(), cmd.ErrOrStderr())
+	},
+}
+
+// draftListCmd represents the draft list command
+var draftListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the ticket drafts",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		provider, err := GetProvider()
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+		store, now, err := draftCommandContext(provider.Config)
+		if err != nil {
+			return err
+		}
+		outputFormat, _ := cmd.Flags().GetString("output")
+		return draftListRunE(store, now.Location(), outputFormat, plainOutput(cmd), cmd.OutOrStdout())
+	},
+}
+
+// draftEditCmd represents the draft edit command
+var draftEditCmd = &cobra.Command{
+	Use:   "edit <ID>",
+	Short: "Edit a ticket draft using $EDITOR",
+	Long: `Opens the draft as YAML in $EDITOR. Before its ticket is generated you can change the
+description and the options; afterwards also the generated summary and description.
+Changing the description discards the generated ticket, which is then generated again
+on submit.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkEditorAllowed(cmd, "tix draft edit opens an editor"); err != nil {
+			return err
+		}
+		provider, err := GetProvider()
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+		store, now, err := draftCommandContext(provider.Config)
+		if err != nil {
+			return err
+		}
+		return draftEditRunE(store, args[0], now, cmd.OutOrStdout())
+	},
+}
+
+// draftSubmitCmd represents the draft submit command
+var draftSubmitCmd = &cobra.Command{
+	Use:   "submit [ID...]",
+	Short: "Create the issues of ticket drafts",
+	Long: `Creates an issue for each of the given drafts, or for all drafts, after showing them
+and asking for confirmation (skip it with --yes). Drafts whose ticket is not generated yet
+are generated first. Submitted drafts are removed; drafts that fail are kept, so you can
+fix them and submit again.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		runner, err := newCreateCmdRunner()
+		if err != nil {
+			return err
+		}
+		if err := runner.applyLLMOverrides(cmd); err != nil {
+			return err
+		}
+		store, now, err := draftCommandContext(runner.configProvider)
+		if err != nil {
+			return err
+		}
+		runner.spinner = newSpinner(cmd)
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return draftSubmitRunE(ctx, runner, store, newConfirmer(cmd), args, now, cmd.OutOrStdout(), cmd.ErrOrStderr())
+	},
+}
+
+// draftDiscardCmd represents the draft discard command
+var draftDiscardCmd = &cobra.Command{
+	Use:   "discard <ID>...",
+	Short: "Delete ticket drafts without submitting them",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		provider, err := GetProvider()
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+		store, _, err := draftCommandContext(provider.Config)
+		if err != nil {
+			return err
+		}
+		var errs []error
+		for _, arg := range args {
+			id, err := parseDraftID(arg)
+			if err == nil {
+				_, err = store.Remove(id)
+			}
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Discarded draft #%d.\n", id)
+		}
+		return errors.Join(errs...)
+	},
+}
+
+func init() {
+	draftNewCmd.Flags().StringP("project", "p", "", "Create the issue in this project (key or links.yaml name) instead of the one suggested by the LLM")
+	draftNewCmd.Flags().StringP("type", "t", "", "Specify the JIRA issue type (e.g., Task, Bug)")
+	draftNewCmd.Flags().String("priority", "", "Set the issue priority (e.g., High)")
+	draftNewCmd.Flags().String("due", "", "Set the due date (e.g., 2025-05-01, \"next friday\"), resolved when the ticket is generated")
+	draftNewCmd.Flags().Bool("offline", false, "Only save the description; generate the ticket on submit")
+	addLLMOverrideFlags(draftNewCmd)
+	addLLMOverrideFlags(draftSubmitCmd)
+
+	draftCmd.AddCommand(draftNewCmd)
+	draftCmd.AddCommand(draftListCmd)
+	draftCmd.AddCommand(draftEditCmd)
+	draftCmd.AddCommand(draftSubmitCmd)
+	draftCmd.AddCommand(draftDiscardCmd)
+	rootCmd.AddCommand(draftCmd)
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/draft"
+	"github.com/karolswdev/ticketron/internal/llm"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/prompt"
+)
+
+func TestDraftNewAndSubmit(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	runner, mockLLM := newMCPServeTestRunner(mockMCP)
+	store := draft.NewStore(t.TempDir())
+	ctx := context.Background()
+	now := time.Date(2025, time.April, 16, 14, 30, 0, 0, time.UTC)
+
+	mockLLM.On("GenerateTicketDetails", mock.Anything, "login broken", "prompt", mcpServeTestContext).
+		Return(llm.LLMResponse{Summary: "Fix login", Description: "Details", ProjectNameSuggestion: "backend"}, nil).Once()
+	var out, errOut bytes.Buffer
+	require.NoError(t, draftNewRunE(ctx, runner, store, draft.Draft{Input: "login broken"}, false, now, &out, &errOut))
+	assert.Equal(t, "Saved draft #1: [BE] Fix login\n", out.String())
+
+	// Offline, only the input is saved
+	out.Reset()
+	require.NoError(t, draftNewRunE(ctx, runner, store, draft.Draft{Input: "export slow", Project: "BE"}, true, now, &out, &errOut))
+	assert.Equal(t, "Saved draft #2; its ticket is generated on submit.\n", out.String())
+	offline, err := store.Get(2)
+	require.NoError(t, err)
+	assert.Nil(t, offline.Ticket)
+
+	out.Reset()
+	require.NoError(t, draftListRunE(store, time.UTC, "", true, &out))
+	assert.Contains(t, out.String(), "Fix login")
+	assert.Contains(t, out.String(), "export slow")
+
+	mockLLM.On("GenerateTicketDetails", mock.Anything, "export slow", "prompt", "").
+		Return(llm.LLMResponse{Summary: "Speed up export", Description: "Slow"}, nil).Once()
+	mockMCP.On("CreateIssue", mock.Anything, mcpclient.CreateIssueRequest{ProjectKey: "BE", Summary: "Fix login", Description: "Details", IssueType: "Bug", IdempotencyKey: idempotencyKey("login broken", "BE")}).
+		Return(&mcpclient.CreateIssueResponse{Key: "BE-1", Self: "https://jira.example/browse/BE-1"}, nil).Once()
+	mockMCP.On("CreateIssue", mock.Anything, mcpclient.CreateIssueRequest{ProjectKey: "BE", Summary: "Speed up export", Description: "Slow", IssueType: "Bug", IdempotencyKey: idempotencyKey("export slow", "BE")}).
+		Return(&mcpclient.CreateIssueResponse{Key: "BE-2", Self: "https://jira.example/browse/BE-2"}, nil).Once()
+	out.Reset()
+	require.NoError(t, draftSubmitRunE(ctx, runner, store, &prompt.Confirmer{In: strings.NewReader("y\n")}, nil, now, &out, &errOut))
+	assert.Contains(t, out.String(), "#1 [BE] Bug: Fix login\n#2 [BE] Bug: Speed up export\n")
+	assert.Contains(t, out.String(), "Create 2 issue(s) from drafts?")
+	assert.Contains(t, out.String(), "Created BE-1 from draft #1: https://jira.example/browse/BE-1\nCreated BE-2 from draft #2")
+	assert.Empty(t, errOut.String())
+	drafts, err := store.List()
+	require.NoError(t, err)
+	assert.Empty(t, drafts, "submitted drafts are removed")
+	mockLLM.AssertExpectations(t)
+	mockMCP.AssertExpectations(t)
+}
+
+func TestDraftSubmitRunE_Failures(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	runner, mockLLM := newMCPServeTestRunner(mockMCP)
+	store := draft.NewStore(t.TempDir())
+	ctx := context.Background()
+	now := time.Date(2025, time.April, 16, 14, 30, 0, 0, time.UTC)
+	_, err := store.Add(draft.Draft{Input: "offline idea"}, now)
+	require.NoError(t, err)
+	_, err = store.Add(draft.Draft{Input: "ready", Ticket: &mcpclient.CreateIssueRequest{ProjectKey: "BE", Summary: "Ready", IssueType: "Task"}}, now)
+	require.NoError(t, err)
+
+	mockLLM.On("GenerateTicketDetails", mock.Anything, "offline idea", "prompt", mock.Anything).
+		Return(llm.LLMResponse{}, errors.New("no network")).Once()
+	mockMCP.On("CreateIssue", mock.Anything, mock.Anything).Return(nil, mcpclient.ErrMCPServerError).Once()
+
+	var out, errOut bytes.Buffer
+	err = draftSubmitRunE(ctx, runner, store, &prompt.Confirmer{AssumeYes: true}, nil, now, &out, &errOut)
+	assert.EqualError(t, err, "2 of 2 drafts failed")
+	assert.Contains(t, errOut.String(), "Draft #1: no network")
+	assert.Contains(t, errOut.String(), "Draft #2: ")
+	drafts, err := store.List()
+	require.NoError(t, err)
+	assert.Len(t, drafts, 2, "failed drafts are kept")
+
+	// Declining submits nothing
+	out.Reset()
+	require.NoError(t, draftSubmitRunE(ctx, runner, store, &prompt.Confirmer{In: strings.NewReader("n\n")}, []string{"#2"}, now, &out, &errOut))
+	assert.Contains(t, out.String(), "Aborted.")
+	mockMCP.AssertNumberOfCalls(t, "CreateIssue", 1)
+
+	err = draftSubmitRunE(ctx, runner, store, &prompt.Confirmer{AssumeYes: true}, []string{"7"}, now, &out, &errOut)
+	assert.ErrorIs(t, err, draft.ErrNotFound)
+}
+
+func TestDraftEditRunE(t *testing.T) {
+	store := draft.NewStore(t.TempDir())
+	now := time.Date(2025, time.April, 16, 14, 30, 0, 0, time.UTC)
+	_, err := store.Add(draft.Draft{Input: "login broken", Ticket: &mcpclient.CreateIssueRequest{ProjectKey: "BE", Summary: "Fix login", IssueType: "Bug"}}, now)
+	require.NoError(t, err)
+
+	orig := editFile
+	t.Cleanup(func() { editFile = orig })
+	var edit func(string) string
+	editFile = func(path string) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(path, []byte(edit(string(data))), 0o600)
+	}
+
+	var out bytes.Buffer
+	edit = func(doc string) string { return doc }
+	require.NoError(t, draftEditRunE(store, "1", now, &out))
+	assert.Equal(t, "Draft #1 unchanged.\n", out.String())
+
+	out.Reset()
+	edit = func(doc string) string {
+		return strings.Replace(doc, "summary: Fix login", "summary: Fix SSO login", 1)
+	}
+	require.NoError(t, draftEditRunE(store, "#1", now.Add(time.Hour), &out))
+	assert.Equal(t, "Saved draft #1.\n", out.String())
+	d, err := store.Get(1)
+	require.NoError(t, err)
+	assert.Equal(t, "Fix SSO login", d.Ticket.Summary)
+	assert.Equal(t, now.Add(time.Hour), d.UpdatedAt)
+
+	// Invalid edits are not saved, and the edited file is kept
+	edit = func(doc string) string { return doc + "summary: [oops" }
+	err = draftEditRunE(store, "1", now, &out)
+	require.ErrorIs(t, err, draft.ErrInvalidDraft)
+	assert.Contains(t, err.Error(), "your changes are in ")
+	path := err.Error()[strings.LastIndex(err.Error(), " ")+1 : len(err.Error())-1]
+	assert.FileExists(t, path)
+	os.Remove(path)
+
+	out.Reset()
+	edit = func(doc string) string {
+		return strings.Replace(doc, "input: login broken", "input: login broken on mobile", 1)
+	}
+	require.NoError(t, draftEditRunE(store, "1", now, &out))
+	assert.Contains(t, out.String(), "its ticket is generated again on submit")
+	d, err = store.Get(1)
+	require.NoError(t, err)
+	assert.Nil(t, d.Ticket)
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"strings"
+	"time"
+
+	"github.com/karolswdev/ticketron/internal/reldate"
+)
+
+// dueDateFor resolves expr, a due date such as "next friday" or "2025-05-01", to the
+// YYYY-MM-DD date Jira expects. Relative dates are resolved in the time zone set by
+// timezone in config.yaml. An empty expr yields an empty date.
+func dueDateFor(cfgs *loadedConfigs, expr string) (string, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return "", nil
+	}
+	loc := time.Local
+	if cfgs != nil && cfgs.appConfig != nil {
+		var err error
+		if loc, err = cfgs.appConfig.Location(); err != nil {
+			return "", err
+		}
+	}
+	day, err := reldate.ParseDate(expr, time.Now().In(loc))
+	if err != nil {
+		return "", err
+	}
+	return day.Format(time.DateOnly), nil
+}
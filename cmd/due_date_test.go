@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/clierr"
+	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/llm"
+	"github.com/karolswdev/ticketron/internal/reldate"
+)
+
+func TestDueDateFor(t *testing.T) {
+	cfgs := &loadedConfigs{appConfig: &config.AppConfig{Timezone: "Asia/Tokyo"}}
+
+	got, err := dueDateFor(cfgs, "2025-05-01T20:00:00Z")
+	require.NoError(t, err)
+	assert.Equal(t, "2025-05-02", got, "dates are resolved in the configured time zone")
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	got, err = dueDateFor(cfgs, " tomorrow ")
+	require.NoError(t, err)
+	assert.Equal(t, time.Now().In(tokyo).AddDate(0, 0, 1).Format(time.DateOnly), got)
+
+	got, err = dueDateFor(cfgs, "")
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	_, err = dueDateFor(cfgs, "someday")
+	assert.ErrorIs(t, err, reldate.ErrUnrecognized)
+
+	_, err = dueDateFor(&loadedConfigs{appConfig: &config.AppConfig{Timezone: "Mars/Olympus"}}, "today")
+	assert.ErrorIs(t, err, config.ErrInvalidTimezone)
+}
+
+func TestBuildIssueRequest_DueDate(t *testing.T) {
+	Log = zerolog.Nop()
+	runner, mockLLM := newMCPServeTestRunner(nil)
+	mockLLM.On("GenerateTicketDetails", mock.Anything, "input", "prompt", mock.Anything).
+		Return(llm.LLMResponse{Summary: "Fix export", ProjectNameSuggestion: "Backend", DueDate: "2025-06-30"}, nil)
+	cfgs, err := loadAllConfigs(runner.configProvider)
+	require.NoError(t, err)
+
+	request, err := runner.buildIssueRequest(context.Background(), &bytes.Buffer{}, cfgs, "input", issueRequestOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, request.DueDate, "the LLM's proposal is ignored unless enabled")
+
+	cfgs.appConfig.LLM.ProposeDueDate = true
+	request, err = runner.buildIssueRequest(context.Background(), &bytes.Buffer{}, cfgs, "input", issueRequestOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "2025-06-30", request.DueDate)
+
+	request, err = runner.buildIssueRequest(context.Background(), &bytes.Buffer{}, cfgs, "input", issueRequestOptions{dueDate: "2025/07/01"})
+	require.NoError(t, err)
+	assert.Equal(t, "2025-07-01", request.DueDate, "--due overrides the proposal")
+
+	_, err = runner.buildIssueRequest(context.Background(), &bytes.Buffer{}, cfgs, "input", issueRequestOptions{dueDate: "someday"})
+	assert.ErrorIs(t, err, reldate.ErrUnrecognized)
+	assert.Contains(t, clierr.HintOf(err), "next friday")
+}
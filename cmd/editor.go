@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// runEditor opens path in $EDITOR, or in a default editor for the OS, and waits for it
+// to exit.
+func runEditor(path string) error {
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		Log.Debug().Msg("$EDITOR not set, using default editor for OS")
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		} else {
+			editor = "vim" // Sensible default for Linux/macOS
+		}
+	}
+	Log.Debug().Str("editor", editor).Str("path", path).Msg("Launching editor...")
+
+	editorCmd := exec.Command(editor, path)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+	if err := editorCmd.Run(); err != nil {
+		Log.Error().Err(err).Str("editor", editor).Msg("Editor command failed")
+		return fmt.Errorf("failed to run editor '%s': %w", editor, err)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/clierr"
+	"github.com/karolswdev/ticketron/internal/i18n"
+	"github.com/karolswdev/ticketron/internal/llm"
+	"github.com/karolswdev/ticketron/internal/redact"
+)
+
+// explainFlagUsage describes the persistent --explain flag.
+const explainFlagUsage = "When the LLM or the MCP server fails, ask the LLM to explain the (redacted) error and suggest a fix"
+
+// explainTimeout bounds the LLM call explaining an error, so a failed command still exits promptly.
+const explainTimeout = 30 * time.Second
+
+// explainSystemPrompt instructs the LLM explaining an error; %s is the language to answer in.
+const explainSystemPrompt = `You help users of tix, a command line tool that creates and searches Jira issues with an LLM and a Jira MCP server.
+Explain the error below in plain language in at most three sentences, then suggest how to fix it.
+Only mention commands, flags and settings that appear in the error or its hint. Answer in plain text in the language with the code %s.`
+
+// errExplainUnavailable indicates there is no LLM client to explain an error with.
+var errExplainUnavailable = errors.New("no LLM client is configured")
+
+// explainableCodes are the codes of the LLM and MCP failures --explain asks about. Failures
+// without an LLM to ask, such as a missing API key, are left out.
+var explainableCodes = map[clierr.Code]bool{
+	clierr.CodeLLMAPI:           true,
+	clierr.CodeLLMResponse:      true,
+	clierr.CodeLLM:              true,
+	clierr.CodeMCPNotConfigured: true,
+	clierr.CodeMCPConnect:       true,
+	clierr.CodeMCPServer:        true,
+	clierr.CodeMCPResponse:      true,
+	clierr.CodeMCP:              true,
+}
+
+// explainRequested reports whether --explain is set on cmd and err is an LLM or MCP failure.
+func explainRequested(cmd *cobra.Command, err error) bool {
+	explain, _ := cmd.Flags().GetBool("explain")
+	return explain && explainableCodes[clierr.CodeOf(err)]
+}
+
+// explainCommandError asks the configured LLM to explain cmdErr, returned by cmd.
+func explainCommandError(cmd *cobra.Command, cmdErr error) (string, error) {
+	provider, err := GetProvider()
+	if err != nil {
+		return "", err
+	}
+	if provider.LLM == nil {
+		return "", errExplainUnavailable
+	}
+	cfg, err := provider.Config.LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	redactor, err := redact.New(cfg.Redaction)
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), explainTimeout)
+	defer cancel()
+	stopSpinner := newSpinner(cmd).Start(i18n.T(i18n.MsgProgressExplain))
+	defer stopSpinner()
+	return explainError(ctx, provider.LLM, redactor, cmd.CommandPath(), cmdErr)
+}
+
+// explainError asks client to explain err, returned by the command at path, together with
+// its message and hint. Sensitive data is redacted before it is sent.
+func explainError(ctx context.Context, client llm.Client, redactor *redact.Redactor, path string, err error) (string, error) {
+	var report strings.Builder
+	fmt.Fprintf(&report, "Command: %s\nError: %s\n", path, err)
+	if e := clierr.As(err); e != nil {
+		fmt.Fprintf(&report, "Code: %s\n", e.Code)
+		if e.Message != "" {
+			fmt.Fprintf(&report, "Message: %s\n", e.Message)
+		}
+		if e.Hint != "" {
+			fmt.Fprintf(&report, "Hint: %s\n", e.Hint)
+		}
+	}
+	text, redactions := redactor.Redact(report.String())
+	Log.Debug().Int("redactions", len(redactions)).Msg("Asking the LLM to explain the error")
+	reply, err := llm.GenerateText(ctx, client, []llm.Message{
+		{Role: llm.RoleSystem, Content: fmt.Sprintf(explainSystemPrompt, i18n.Language())},
+		{Role: llm.RoleUser, Content: text},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to explain the error: %w", err)
+	}
+	return llm.StripCodeFence(reply), nil
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/llm"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/redact"
+)
+
+func TestExplainRequested(t *testing.T) {
+	cmd := &cobra.Command{Use: "tix"}
+	cmd.Flags().Bool("explain", false, explainFlagUsage)
+	connectErr := mcpCreateError(fmt.Errorf("%w: connection refused", mcpclient.ErrRequestExecute))
+	assert.False(t, explainRequested(cmd, connectErr), "without --explain")
+
+	require.NoError(t, cmd.ParseFlags([]string{"--explain"}))
+	assert.True(t, explainRequested(cmd, connectErr))
+	assert.True(t, explainRequested(cmd, llmError(fmt.Errorf("%w: 502", llm.ErrLLMCompletion))))
+	assert.False(t, explainRequested(cmd, llmError(config.ErrAPIKeyNotFound)), "there is no LLM to ask")
+	assert.False(t, explainRequested(cmd, errors.New("invalid flag")), "not an LLM or MCP failure")
+}
+
+func TestExplainError(t *testing.T) {
+	Log = zerolog.Nop()
+	redactor, err := redact.New(config.RedactionConfig{Enabled: true, Builtins: []string{"email"}})
+	require.NoError(t, err)
+	mockLLM := new(MockLLMClient)
+	mockLLM.On("GenerateText", mock.Anything, mock.MatchedBy(func(messages []llm.Message) bool {
+		return len(messages) == 2 && messages[0].Role == llm.RoleSystem && strings.Contains(messages[0].Content, "code en") &&
+			strings.Contains(messages[1].Content, "Command: tix create\n") &&
+			strings.Contains(messages[1].Content, "Code: mcp_server\n") &&
+			strings.Contains(messages[1].Content, "no user [REDACTED:email]") &&
+			!strings.Contains(messages[1].Content, "ann@example.com")
+	})).Return("```\nThe assignee does not exist in Jira.\n```", nil).Once()
+
+	cmdErr := mcpCreateError(fmt.Errorf("%w: no user ann@example.com", mcpclient.ErrMCPServerError))
+	explanation, err := explainError(context.Background(), mockLLM, redactor, "tix create", cmdErr)
+	require.NoError(t, err)
+	assert.Equal(t, "The assignee does not exist in Jira.", explanation)
+	mockLLM.AssertExpectations(t)
+
+	mockLLM.On("GenerateText", mock.Anything, mock.Anything).Return("", errors.New("rate limited")).Once()
+	_, err = explainError(context.Background(), mockLLM, nil, "tix create", cmdErr)
+	assert.ErrorContains(t, err, "failed to explain the error: rate limited")
+}
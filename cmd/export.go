@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/export"
+	"github.com/karolswdev/ticketron/internal/i18n"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/output"
+)
+
+// defaultExportFields are the columns exported when --output-fields is not given.
+const defaultExportFields = "key,fields.summary,fields.status.name,fields.issuetype.name"
+
+// defaultExportPageSize is the number of issues requested per MCP search call.
+const defaultExportPageSize = 100
+
+// exportColumn maps an issue field path to a column header.
+type exportColumn struct {
+	header string
+	path   string
+}
+
+// parseExportColumns parses a comma-separated list of field paths, each optionally
+// prefixed with a column header: "key,Summary=fields.summary".
+func parseExportColumns(spec string) ([]exportColumn, error) {
+	var columns []exportColumn
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		header, path, found := strings.Cut(item, "=")
+		if !found {
+			path = header
+		}
+		header, path = strings.TrimSpace(header), strings.TrimSpace(path)
+		if header == "" || path == "" {
+			return nil, fmt.Errorf("invalid output field %q: expected PATH or HEADER=PATH", item)
+		}
+		columns = append(columns, exportColumn{header: header, path: path})
+	}
+	if len(columns) == 0 {
+		return nil, errors.New("no output fields given")
+	}
+	return columns, nil
+}
+
+// exportOptions holds the settings of an export run.
+type exportOptions struct {
+	jql      string
+	format   string
+	columns  []exportColumn
+	pageSize int
+	limit    int // Maximum number of issues, 0 for all
+	// onPage, if set, is called after each page with the number of issues exported so far
+	// and the number that will be exported in total.
+	onPage func(exported, total int)
+}
+
+// exportRunE pages through the search results for opts.jql and streams them to out in
+// opts.format. Each page is written out before the next is requested, so memory use does
+// not grow with the number of issues. It returns the number of exported issues.
+func exportRunE(ctx context.Context, mcpClient MCPClient, opts exportOptions, out io.Writer) (int, error) {
+	if mcpClient == nil {
+		return 0, errMCPClientNotInitialized
+	}
+	buffered := bufio.NewWriter(out)
+	writer, err := export.NewWriter(opts.format, buffered)
+	if err != nil {
+		return 0, err
+	}
+	headers := make([]string, len(opts.columns))
+	paths := make([]*output.Path, len(opts.columns)) // Compiled once for every exported issue
+	for i, c := range opts.columns {
+		paths[i] = output.Compile(c.path)
+		headers[i] = c.header
+	}
+	if err := writer.WriteHeader(headers); err != nil {
+		return 0, err
+	}
+
+	pageSize := opts.pageSize
+	if pageSize <= 0 {
+		pageSize = defaultExportPageSize
+	}
+	exported := 0
+	for startAt := 0; ; {
+		if opts.limit > 0 && opts.limit-exported < pageSize {
+			pageSize = opts.limit - exported
+		}
+		resp, err := mcpClient.SearchIssues(ctx, mcpclient.SearchIssuesRequest{JQL: opts.jql, MaxResults: pageSize, StartAt: startAt})
+		if err != nil {
+			return exported, fmt.Errorf("failed to search issues (startAt %d): %w", startAt, err)
+		}
+		for _, issue := range resp.Issues {
+			row := make([]interface{}, len(opts.columns))
+			for i, path := range paths {
+				if value, found := path.Value(issue); found {
+					row[i] = value
+				}
+			}
+			if err := writer.WriteRow(row); err != nil {
+				return exported, err
+			}
+			exported++
+		}
+		if err := buffered.Flush(); err != nil {
+			return exported, fmt.Errorf("%w: %w", export.ErrWrite, err)
+		}
+		Log.Debug().Int("start_at", startAt).Int("page", len(resp.Issues)).Int("total", resp.Total).Msg("Exported page of issues")
+		if opts.onPage != nil {
+			total := resp.Total
+			if opts.limit > 0 && opts.limit < total {
+				total = opts.limit
+			}
+			opts.onPage(exported, max(total, exported))
+		}
+
+		startAt += len(resp.Issues)
+		if len(resp.Issues) == 0 || startAt >= resp.Total || (opts.limit > 0 && exported >= opts.limit) {
+			break
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return exported, err
+	}
+	if err := buffered.Flush(); err != nil {
+		return exported, fmt.Errorf("%w: %w", export.ErrWrite, err)
+	}
+	return exported, nil
+}
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export [JQL Query]",
+	Short: "Export search results to CSV, JSON or XLSX",
+	Long: `Exports all issues matching a JQL query to a CSV, JSON or Excel (XLSX) file.
+Results are fetched page by page and each page is written to the output before
+the next is fetched, so exports of any size need little memory. On a terminal, a
+progress bar shows the issues exported so far out of the total.
+
+Columns are selected with --output-fields as field paths (as in 'tix search'),
+optionally with a header: --output-fields "Key=key,Summary=fields.summary".
+The format defaults to the --out file extension, or csv.
+
+Examples:
+  tix export --jql "project = BE AND sprint in openSprints()" --out sprint.xlsx
+  tix export "assignee = currentUser()" --format json > mine.json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		jql, _ := cmd.Flags().GetString("jql")
+		if jql == "" {
+			jql = strings.Join(args, " ")
+		}
+		if strings.TrimSpace(jql) == "" {
+			return errors.New("no JQL query provided: pass it as arguments or with --jql")
+		}
+		outPath, _ := cmd.Flags().GetString("out")
+		format, _ := cmd.Flags().GetString("format")
+		if format == "" {
+			format = export.FormatFromPath(outPath)
+		}
+		if format == "" {
+			format = export.FormatCSV
+		}
+		fieldsSpec, _ := cmd.Flags().GetString("output-fields")
+		columns, err := parseExportColumns(fieldsSpec)
+		if err != nil {
+			return err
+		}
+		pageSize, _ := cmd.Flags().GetInt("page-size")
+		limit, _ := cmd.Flags().GetInt("limit")
+		opts := exportOptions{jql: jql, format: format, columns: columns, pageSize: pageSize, limit: limit}
+		if _, err := export.NewWriter(format, io.Discard); err != nil {
+			return err // Validate the format before creating the output file
+		}
+
+		mcpClient, err := newCommandMCPClient()
+		if err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		update, stopProgress := newSpinner(cmd).StartBar(i18n.T(i18n.MsgProgressExport))
+		defer stopProgress()
+		opts.onPage = update
+
+		if outPath == "" || outPath == "-" {
+			_, err = exportRunE(ctx, mcpClient, opts, cmd.OutOrStdout())
+			return err
+		}
+		f, err := os.Create(outPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		count, err := exportRunE(ctx, mcpClient, opts, f)
+		stopProgress()
+		if closeErr := f.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("failed to write output file: %w", closeErr)
+		}
+		if err != nil {
+			_ = os.Remove(outPath) // Do not leave a truncated report behind
+			return err
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "Exported %d issue(s) to %s\n", count, outPath)
+		return nil
+	},
+}
+
+func init() {
+	exportCmd.Flags().String("jql", "", "JQL query string")
+	exportCmd.Flags().String("format", "", "Output format: csv, json or xlsx (default: from --out extension, else csv)")
+	exportCmd.Flags().String("out", "", "Output file (default: stdout)")
+	exportCmd.Flags().StringP("output-fields", "f", defaultExportFields, "Comma-separated field paths, optionally as HEADER=PATH")
+	exportCmd.Flags().Int("page-size", defaultExportPageSize, "Number of issues fetched per request")
+	exportCmd.Flags().Int("limit", 0, "Maximum number of issues to export (0 for all)")
+
+	rootCmd.AddCommand(exportCmd)
+}
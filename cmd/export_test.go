@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+func TestParseExportColumns(t *testing.T) {
+	columns, err := parseExportColumns("key, Summary=fields.summary,,Status = fields.status.name")
+	require.NoError(t, err)
+	assert.Equal(t, []exportColumn{
+		{header: "key", path: "key"},
+		{header: "Summary", path: "fields.summary"},
+		{header: "Status", path: "fields.status.name"},
+	}, columns)
+
+	_, err = parseExportColumns("Summary=")
+	assert.Error(t, err)
+	_, err = parseExportColumns(" , ")
+	assert.Error(t, err)
+}
+
+func exportTestIssue(key, summary string) mcpclient.Issue {
+	return mcpclient.Issue{Key: key, Fields: mcpclient.IssueFields{Summary: summary, Status: mcpclient.Status{Name: "Open"}}}
+}
+
+func TestExportRunE_Paginates(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("SearchIssues", mock.Anything, mcpclient.SearchIssuesRequest{JQL: "project = BE", MaxResults: 2, StartAt: 0}).
+		Return(&mcpclient.SearchIssuesResponse{Total: 3, Issues: []mcpclient.Issue{exportTestIssue("BE-1", "One"), exportTestIssue("BE-2", "Two")}}, nil)
+	var out bytes.Buffer
+	var written string
+	mockMCP.On("SearchIssues", mock.Anything, mcpclient.SearchIssuesRequest{JQL: "project = BE", MaxResults: 2, StartAt: 2}).
+		Run(func(mock.Arguments) { written = out.String() }).
+		Return(&mcpclient.SearchIssuesResponse{Total: 3, StartAt: 2, Issues: []mcpclient.Issue{exportTestIssue("BE-3", "Three, with comma")}}, nil)
+
+	columns, err := parseExportColumns("Key=key,Summary=fields.summary,fields.status.name")
+	require.NoError(t, err)
+	var progress [][2]int
+	onPage := func(exported, total int) { progress = append(progress, [2]int{exported, total}) }
+	count, err := exportRunE(context.Background(), mockMCP, exportOptions{jql: "project = BE", format: "csv", columns: columns, pageSize: 2, onPage: onPage}, &out)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, [][2]int{{2, 3}, {3, 3}}, progress)
+	assert.Equal(t, "Key,Summary,fields.status.name\nBE-1,One,Open\nBE-2,Two,Open\n", written, "a page is written out before the next is fetched")
+	assert.Equal(t, "Key,Summary,fields.status.name\nBE-1,One,Open\nBE-2,Two,Open\nBE-3,\"Three, with comma\",Open\n", out.String())
+	mockMCP.AssertExpectations(t)
+}
+
+func TestExportRunE_Limit(t *testing.T) {
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("SearchIssues", mock.Anything, mcpclient.SearchIssuesRequest{JQL: "x", MaxResults: 1}).
+		Return(&mcpclient.SearchIssuesResponse{Total: 50, Issues: []mcpclient.Issue{exportTestIssue("BE-1", "One")}}, nil)
+
+	columns, _ := parseExportColumns("key")
+	var out bytes.Buffer
+	var progress [][2]int
+	onPage := func(exported, total int) { progress = append(progress, [2]int{exported, total}) }
+	count, err := exportRunE(context.Background(), mockMCP, exportOptions{jql: "x", format: "json", columns: columns, pageSize: 100, limit: 1, onPage: onPage}, &out)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, [][2]int{{1, 1}}, progress, "the total is capped by the limit")
+	assert.JSONEq(t, `[{"key": "BE-1"}]`, out.String())
+	mockMCP.AssertNumberOfCalls(t, "SearchIssues", 1)
+}
+
+func TestExportRunE_SearchError(t *testing.T) {
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("SearchIssues", mock.Anything, mock.Anything).Return(nil, errors.New("boom"))
+
+	columns, _ := parseExportColumns("key")
+	_, err := exportRunE(context.Background(), mockMCP, exportOptions{jql: "x", format: "csv", columns: columns}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "failed to search issues (startAt 0): boom")
+}
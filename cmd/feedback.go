@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/history"
+	"github.com/karolswdev/ticketron/internal/output"
+)
+
+// feedbackRunE records a rating for issueKey, copying the prompt version and generated
+// output from the history when the issue was created by Ticketron.
+func feedbackRunE(hist *history.Store, store *history.FeedbackStore, issueKey, rating, reason string, out, errOut io.Writer) error {
+	entry, err := hist.Find(issueKey)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		fmt.Fprintf(errOut, "Warning: %s was not found in the local history; the rating is recorded without prompt details.\n", issueKey)
+	}
+	if err := store.Append(history.FeedbackFor(issueKey, rating, reason, entry)); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Recorded %s feedback for %s.\n", rating, issueKey)
+	return nil
+}
+
+// feedbackExportRunE writes all feedback in the given format (json or csv), or per prompt
+// version statistics when summary is set.
+func feedbackExportRunE(store *history.FeedbackStore, format string, summary, plain bool, out io.Writer) error {
+	feedback, err := store.List()
+	if err != nil {
+		return err
+	}
+
+	if summary {
+		stats := history.StatsByPromptVersion(feedback)
+		if format == "json" {
+			return writeIndentedJSON(out, stats)
+		}
+		table := output.NewTable("Prompt version", "Good", "Bad", "Good %")
+		for _, s := range stats {
+			name := s.PromptVersion
+			if name == "" {
+				name = "(unversioned)"
+			}
+			table.Row(name, strconv.Itoa(s.Good), strconv.Itoa(s.Bad), fmt.Sprintf("%.0f%%", 100*float64(s.Good)/float64(s.Good+s.Bad)))
+		}
+		return table.Render(out, plain)
+	}
+
+	switch format {
+	case "json":
+		if feedback == nil {
+			feedback = []history.Feedback{}
+		}
+		return writeIndentedJSON(out, feedback)
+	case "csv":
+		w := csv.NewWriter(out)
+		_ = w.Write([]string{"timestamp", "issue_key", "rating", "reason", "prompt_version", "project_key", "issue_type", "summary", "input"})
+		for _, fb := range feedback {
+			_ = w.Write([]string{fb.Timestamp.Format(time.RFC3339), fb.IssueKey, fb.Rating, fb.Reason, fb.PromptVersion, fb.ProjectKey, fb.IssueType, fb.Summary, fb.Input})
+		}
+		w.Flush()
+		return w.Error()
+	default:
+		return fmt.Errorf("unsupported export format %q (expected json or csv)", format)
+	}
+}
+
+// writeIndentedJSON writes v as indented JSON followed by a newline.
+func writeIndentedJSON(out io.Writer, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format output as JSON: %w", err)
+	}
+	fmt.Fprintln(out, string(data))
+	return nil
+}
+
+// feedbackCmd represents the feedback command
+var feedbackCmd = &cobra.Command{
+	Use:   "feedback <ISSUE-KEY> --good|--bad [reason]",
+	Short: "Rate the quality of a generated ticket",
+	Long: `Records whether a ticket generated by Ticketron was good or bad, with an
+optional reason. The prompt version, input and generated summary are taken from
+the local history so ratings can be compared across prompt versions with
+'tix feedback export'.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		good, _ := cmd.Flags().GetBool("good")
+		bad, _ := cmd.Flags().GetBool("bad")
+		if good == bad {
+			return errors.New("specify exactly one of --good or --bad")
+		}
+		rating := history.RatingGood
+		if bad {
+			rating = history.RatingBad
+		}
+		var reason string
+		if len(args) > 1 {
+			reason = args[1]
+		}
+
+		configDir, err := resolveConfigDir()
+		if err != nil {
+			return err
+		}
+		return feedbackRunE(history.NewStore(configDir), history.NewFeedbackStore(configDir), args[0], rating, reason, cmd.OutOrStdout(), cmd.ErrOrStderr())
+	},
+}
+
+// feedbackExportCmd represents the feedback export command
+var feedbackExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export recorded feedback for analysis",
+	Long: `Exports all recorded feedback as JSON or CSV. With --summary, prints the number
+of good and bad ratings per prompt version instead.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		summary, _ := cmd.Flags().GetBool("summary")
+		configDir, err := resolveConfigDir()
+		if err != nil {
+			return err
+		}
+		return feedbackExportRunE(history.NewFeedbackStore(configDir), format, summary, plainOutput(cmd), cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	feedbackCmd.Flags().Bool("good", false, "Rate the generated ticket as good")
+	feedbackCmd.Flags().Bool("bad", false, "Rate the generated ticket as bad")
+	feedbackCmd.MarkFlagsMutuallyExclusive("good", "bad")
+
+	feedbackExportCmd.Flags().String("format", "json", "Export format (json|csv)")
+	feedbackExportCmd.Flags().Bool("summary", false, "Print good/bad counts per prompt version instead of all entries")
+
+	feedbackCmd.AddCommand(feedbackExportCmd)
+	rootCmd.AddCommand(feedbackCmd)
+}
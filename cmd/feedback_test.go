@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/history"
+)
+
+func TestFeedbackRunE(t *testing.T) {
+	Log = zerolog.Nop()
+	dir := t.TempDir()
+	hist := history.NewStore(dir)
+	store := history.NewFeedbackStore(dir)
+	require.NoError(t, hist.Append(history.Entry{IssueKey: "BE-1", Summary: "Fix login", PromptVersion: "v2"}))
+
+	var out, errOut bytes.Buffer
+	require.NoError(t, feedbackRunE(hist, store, "BE-1", history.RatingGood, "clear", &out, &errOut))
+	assert.Equal(t, "Recorded good feedback for BE-1.\n", out.String())
+	assert.Empty(t, errOut.String())
+
+	require.NoError(t, feedbackRunE(hist, store, "OPS-9", history.RatingBad, "", &out, &errOut))
+	assert.Contains(t, errOut.String(), "not found in the local history")
+
+	t.Run("JSON export", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, feedbackExportRunE(store, "json", false, false, &buf))
+		var feedback []history.Feedback
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &feedback))
+		require.Len(t, feedback, 2)
+		assert.Equal(t, "v2", feedback[0].PromptVersion)
+		assert.Equal(t, "Fix login", feedback[0].Summary)
+	})
+
+	t.Run("CSV export", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, feedbackExportRunE(store, "csv", false, false, &buf))
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 3)
+		assert.True(t, strings.HasPrefix(lines[0], "timestamp,issue_key,rating"))
+		assert.Contains(t, lines[1], "BE-1,good,clear,v2")
+	})
+
+	t.Run("Summary", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, feedbackExportRunE(store, "text", true, false, &buf))
+		assert.Contains(t, buf.String(), "v2")
+		assert.Contains(t, buf.String(), "(unversioned)")
+	})
+
+	t.Run("Unsupported format", func(t *testing.T) {
+		assert.Error(t, feedbackExportRunE(store, "xml", false, false, &bytes.Buffer{}))
+	})
+}
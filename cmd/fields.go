@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/output"
+	"github.com/karolswdev/ticketron/internal/sanitize"
+)
+
+// fieldsListRunE prints the issue types of a project and the fields of their create
+// screens. If issueType is set, only that type is shown.
+func fieldsListRunE(ctx context.Context, mcpClient MCPClient, projectKey, issueType, outputFormat string, plain bool, out io.Writer) error {
+	meta, err := mcpClient.GetCreateMeta(ctx, projectKey)
+	if err != nil {
+		return fmt.Errorf("failed to get create metadata for project %s: %w", projectKey, err)
+	}
+	if issueType != "" {
+		typeMeta := findIssueTypeMeta(meta, issueType)
+		if typeMeta == nil {
+			return unknownIssueTypeError(projectKey, issueType, issueTypeNames(meta))
+		}
+		meta = &mcpclient.CreateMeta{ProjectKey: meta.ProjectKey, IssueTypes: []mcpclient.IssueTypeMeta{*typeMeta}}
+	}
+
+	if strings.ToLower(outputFormat) == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(meta)
+	}
+
+	fmt.Fprintf(out, "Project %s: %d issue type(s)\n", projectKey, len(meta.IssueTypes))
+	for _, typeMeta := range meta.IssueTypes {
+		name := typeMeta.Name
+		if typeMeta.Subtask {
+			name += " (sub-task)"
+		}
+		fmt.Fprintf(out, "\n%s\n", name)
+		table := output.NewTable("Field", "Name", "Required", "Type", "Allowed values")
+		table.Indent = "  "
+		for _, field := range typeMeta.Fields {
+			required := "no"
+			if field.Required {
+				required = "yes"
+			}
+			table.Row(field.ID, sanitize.Line(field.Name), required, field.Schema, sanitize.Line(strings.Join(field.AllowedValues, ", ")))
+		}
+		if err := table.Render(out, plain); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fieldsCmd represents the fields command group
+var fieldsCmd = &cobra.Command{
+	Use:   "fields",
+	Short: "Inspect the fields of JIRA projects",
+	Long:  `Provides commands to inspect which issue types and fields are available when creating issues.`,
+}
+
+// fieldsListCmd represents the fields list command
+var fieldsListCmd = &cobra.Command{
+	Use:   "list PROJECT-KEY",
+	Short: "List the issue types and fields of a project",
+	Long: `Lists the issue types that can be created in a project and, for each of them,
+the fields of the create screen with whether they are required, their type and
+their allowed values.
+
+'tix create' uses the same metadata to check issues before submitting them.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		issueType, _ := cmd.Flags().GetString("type")
+		outputFormat, _ := cmd.Flags().GetString("output")
+
+		mcpClient, err := newCommandMCPClient()
+		if err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return fieldsListRunE(ctx, mcpClient, args[0], issueType, outputFormat, plainOutput(cmd), cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	fieldsListCmd.Flags().StringP("type", "t", "", "Only show the fields of this issue type")
+	fieldsCmd.AddCommand(fieldsListCmd)
+	rootCmd.AddCommand(fieldsCmd)
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+func TestFieldsListRunE_Text(t *testing.T) {
+	mockMCP := new(MockMCPClient)
+	meta := testCreateMeta()
+	meta.IssueTypes[1].Fields[1].AllowedValues = []string{"S1", "S2"}
+	mockMCP.On("GetCreateMeta", mock.Anything, "BE").Return(meta, nil)
+
+	var out bytes.Buffer
+	require.NoError(t, fieldsListRunE(context.Background(), mockMCP, "BE", "bug", "text", false, &out))
+	want := "Project BE: 1 issue type(s)\n" +
+		"\nBug\n" +
+		"  FIELD              NAME         REQUIRED  TYPE  ALLOWED VALUES\n" +
+		"  summary            Summary      yes             \n" +
+		"  customfield_10010  Severity     yes             S1, S2\n" +
+		"  components         Component/s  yes             \n" +
+		"  labels             Labels       no              \n" +
+		"  priority           Priority     no              High, Low\n" +
+		"  customfield_10020  Environment  no              Staging, Production\n"
+	assert.Equal(t, want, out.String())
+}
+
+func TestFieldsListRunE_Plain(t *testing.T) {
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("GetCreateMeta", mock.Anything, "BE").Return(testCreateMeta(), nil)
+
+	var out bytes.Buffer
+	require.NoError(t, fieldsListRunE(context.Background(), mockMCP, "BE", "bug", "text", true, &out))
+	assert.Contains(t, out.String(), "\nBug\n  Field: summary\n  Name: Summary\n  Required: yes\n\n  Field: customfield_10010\n")
+	assert.NotContains(t, out.String(), "FIELD")
+}
+
+func TestFieldsListRunE_JSON(t *testing.T) {
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("GetCreateMeta", mock.Anything, "BE").Return(testCreateMeta(), nil)
+
+	var out bytes.Buffer
+	require.NoError(t, fieldsListRunE(context.Background(), mockMCP, "BE", "", "json", false, &out))
+	var meta mcpclient.CreateMeta
+	require.NoError(t, json.Unmarshal(out.Bytes(), &meta))
+	assert.Equal(t, []string{"Task", "Bug"}, issueTypeNames(&meta))
+}
+
+func TestFieldsListRunE_Errors(t *testing.T) {
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("GetCreateMeta", mock.Anything, "BE").Return(testCreateMeta(), nil)
+	mockMCP.On("GetCreateMeta", mock.Anything, "XX").Return(nil, errors.New("project not found"))
+
+	err := fieldsListRunE(context.Background(), mockMCP, "BE", "Epic", "text", false, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "project BE has no type 'Epic'; available: Task, Bug")
+
+	err = fieldsListRunE(context.Background(), mockMCP, "XX", "", "text", false, &bytes.Buffer{})
+	assert.EqualError(t, err, "failed to get create metadata for project XX: project not found")
+}
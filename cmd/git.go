@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/gitinfo"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+// Actions reported by tix git link-pr for each referenced issue.
+const (
+	linkPRCommented = "commented"
+	linkPRLinked    = "already_linked" // A comment of the issue already holds the PR URL
+	linkPRNotFound  = "not_found"      // The key looks like an issue key but GetIssue failed
+	linkPRDryRun    = "dry_run"
+)
+
+// linkPROptions holds the settings of a tix git link-pr run.
+type linkPROptions struct {
+	dir          string
+	base         string // Empty for the default branch of origin, main or master
+	prURL        string // Empty to detect it from CI or the gh CLI
+	dryRun       bool
+	outputFormat string
+}
+
+// linkPRResult reports what tix git link-pr did with one referenced issue.
+type linkPRResult struct {
+	Key     string   `json:"key"`
+	Action  string   `json:"action"`
+	Commits []string `json:"commits,omitempty"` // Short hashes of the commits referencing the key
+	Error   string   `json:"error,omitempty"`
+}
+
+// linkPRReport is the JSON output of tix git link-pr.
+type linkPRReport struct {
+	Branch string         `json:"branch"`
+	Base   string         `json:"base"`
+	PRURL  string         `json:"pr_url"`
+	Issues []linkPRResult `json:"issues"`
+}
+
+// prReference collects where the current branch mentions an issue key.
+type prReference struct {
+	key      string
+	inBranch bool
+	commits  []gitinfo.Commit
+}
+
+// findPRReferences returns the issue keys named in branch or in the messages of commits,
+// in order of first appearance.
+func findPRReferences(branch string, commits []gitinfo.Commit) []*prReference {
+	var refs []*prReference
+	byKey := make(map[string]*prReference)
+	ref := func(key string) *prReference {
+		if r, ok := byKey[key]; ok {
+			return r
+		}
+		r := &prReference{key: key}
+		byKey[key] = r
+		refs = append(refs, r)
+		return r
+	}
+	for _, key := range relatedKeyRe.FindAllString(branch, -1) {
+		ref(key).inBranch = true
+	}
+	// Oldest first, so the comment lists the commits in the order they were made
+	for i := len(commits) - 1; i >= 0; i-- {
+		seen := make(map[string]bool)
+		for _, key := range relatedKeyRe.FindAllString(commits[i].Subject+"\n"+commits[i].Body, -1) {
+			if !seen[key] {
+				seen[key] = true
+				r := ref(key)
+				r.commits = append(r.commits, commits[i])
+			}
+		}
+	}
+	return refs
+}
+
+// linkPRComment returns the comment tix git link-pr adds to the issue of ref.
+func linkPRComment(prURL, branch string, ref *prReference) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Pull request: %s\n", prURL)
+	if ref.inBranch {
+		fmt.Fprintf(&b, "\nBranch: %s\n", branch)
+	}
+	if len(ref.commits) > 0 {
+		b.WriteString("\nCommits:\n")
+		for _, c := range ref.commits {
+			fmt.Fprintf(&b, "- %s %s\n", c.ShortHash(), c.Subject)
+		}
+	}
+	return b.String()
+}
+
+// hasPRComment reports whether a comment of issueKey already has the "Pull request:" line
+// linkPRComment writes for prURL. The whole line is compared, so .../pull/12 does not
+// count as a link to .../pull/1.
+func hasPRComment(ctx context.Context, mcpClient MCPClient, issueKey, prURL string) (bool, error) {
+	comments, err := fetchAllComments(ctx, mcpClient, issueKey, defaultCommentPageSize)
+	if err != nil {
+		return false, err
+	}
+	want := "Pull request: " + prURL
+	for _, c := range comments {
+		for _, line := range strings.Split(c.Body, "\n") {
+			if strings.TrimSpace(line) == want {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// gitLinkPRRunE comments the pull request URL on every issue referenced by the current
+// branch of opts.dir. Keys the MCP server reports as not found are reported and skipped,
+// so a stray "UTF-8" in a commit message does not fail the run; any other lookup error
+// and failing to comment do.
+func gitLinkPRRunE(ctx context.Context, mcpClient MCPClient, opts linkPROptions, out io.Writer) error {
+	if mcpClient == nil {
+		return errMCPClientNotInitialized
+	}
+	branch, err := gitinfo.CurrentBranch(ctx, opts.dir)
+	if err != nil {
+		return err
+	}
+	base := opts.base
+	if base == "" {
+		if base, err = gitinfo.DefaultBase(ctx, opts.dir); err != nil {
+			return err
+		}
+	}
+	commits, err := gitinfo.BranchCommits(ctx, opts.dir, base)
+	if err != nil {
+		return err
+	}
+	prURL := opts.prURL
+	if prURL == "" {
+		if prURL, err = gitinfo.PullRequestURL(ctx, opts.dir); err != nil {
+			return err
+		}
+	}
+	Log.Debug().Str("branch", branch).Str("base", base).Int("commits", len(commits)).Str("pr_url", prURL).Msg("Linking pull request")
+
+	report := linkPRReport{Branch: branch, Base: base, PRURL: prURL, Issues: []linkPRResult{}}
+	for _, ref := range findPRReferences(branch, commits) {
+		res := linkPRResult{Key: ref.key}
+		for _, c := range ref.commits {
+			res.Commits = append(res.Commits, c.ShortHash())
+		}
+		report.Issues = append(report.Issues, res)
+		last := &report.Issues[len(report.Issues)-1]
+
+		if _, err := mcpClient.GetIssue(ctx, ref.key); err != nil {
+			if !errors.Is(err, mcpclient.ErrNotFound) {
+				return fmt.Errorf("failed to get %s: %w", ref.key, err)
+			}
+			Log.Debug().Err(err).Str("key", ref.key).Msg("Skipping reference that is not an issue")
+			last.Action, last.Error = linkPRNotFound, err.Error()
+			continue
+		}
+		linked, err := hasPRComment(ctx, mcpClient, ref.key, prURL)
+		if err != nil {
+			return fmt.Errorf("failed to get the comments of %s: %w", ref.key, err)
+		}
+		switch {
+		case linked:
+			last.Action = linkPRLinked
+		case opts.dryRun:
+			last.Action = linkPRDryRun
+		default:
+			if _, err := mcpClient.AddComment(ctx, ref.key, mcpclient.AddCommentRequest{Body: linkPRComment(prURL, branch, ref)}); err != nil {
+				return fmt.Errorf("failed to comment on %s: %w", ref.key, err)
+			}
+			last.Action = linkPRCommented
+		}
+	}
+	return writeLinkPRReport(out, report, opts.outputFormat)
+}
+
+// writeLinkPRReport writes report as JSON or as one line per issue.
+func writeLinkPRReport(out io.Writer, report linkPRReport, outputFormat string) error {
+	if strings.ToLower(outputFormat) == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	if len(report.Issues) == 0 {
+		fmt.Fprintf(out, "No issue keys found in %s or its commits since %s\n", report.Branch, report.Base)
+		return nil
+	}
+	for _, res := range report.Issues {
+		switch res.Action {
+		case linkPRCommented:
+			fmt.Fprintf(out, "Commented on %s: %s\n", res.Key, report.PRURL)
+		case linkPRLinked:
+			fmt.Fprintf(out, "Skipped %s: already linked to %s\n", res.Key, report.PRURL)
+		case linkPRNotFound:
+			fmt.Fprintf(out, "Skipped %s: not an issue (%s)\n", res.Key, res.Error)
+		case linkPRDryRun:
+			fmt.Fprintf(out, "Would comment on %s: %s\n", res.Key, report.PRURL)
+		}
+	}
+	return nil
+}
+
+// gitCmd represents the git command group
+var gitCmd = &cobra.Command{
+	Use:   "git",
+	Short: "Connect git branches and commits with JIRA issues",
+	Long: `Provides commands that work on the git repository of the current directory.
+This command itself does not perform any action but serves as a parent for subcommands.`,
+	// No Run function needed for a parent command
+}
+
+// gitLinkPRCmd represents the git link-pr command
+var gitLinkPRCmd = &cobra.Command{
+	Use:   "link-pr",
+	Short: "Comment the pull request URL on the issues its commits reference",
+	Long: `Finds the issue keys, such as PROJ-123, in the name of the current branch and in the
+messages of its commits since the base branch, and adds a comment with the pull request
+URL and the referencing commits to each of those issues.
+
+Keys are verified with JIRA first; anything that is not an issue is reported and skipped.
+Issues that already have a comment with the URL are skipped, so the command can run on
+every push. The base defaults to the default branch of origin, else main or master.
+
+The pull request URL is taken from --pr-url, else from the environment of a GitHub
+Actions pull_request workflow or a GitLab merge request pipeline, else from 'gh pr view'.
+
+Use --dry-run to see which issues would get a comment, and '-o json' for a report.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var opts linkPROptions
+		opts.base, _ = cmd.Flags().GetString("base")
+		opts.prURL, _ = cmd.Flags().GetString("pr-url")
+		opts.dryRun, _ = cmd.Flags().GetBool("dry-run")
+		opts.outputFormat, _ = cmd.Flags().GetString("output")
+
+		mcpClient, err := newCommandMCPClient()
+		if err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return gitLinkPRRunE(ctx, mcpClient, opts, cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(gitCmd)
+	gitCmd.AddCommand(gitLinkPRCmd)
+	gitLinkPRCmd.Flags().String("base", "", "Branch the commits of the current branch are compared with")
+	gitLinkPRCmd.Flags().String("pr-url", "", "URL of the pull request (default: detected from CI or the gh CLI)")
+	gitLinkPRCmd.Flags().Bool("dry-run", false, "Show the issues that would get a comment without commenting")
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/gitinfo"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+// newLinkPRTestRepo returns a repository on branch PROJ-1-login with two commits since main.
+func newLinkPRTestRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	t.Setenv("GITHUB_HEAD_REF", "")
+	t.Setenv("GITHUB_BASE_REF", "")
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "--quiet", "--initial-branch=main"},
+		{"commit", "--quiet", "--allow-empty", "-m", "Initial commit"},
+		{"checkout", "--quiet", "-b", "PROJ-1-login"},
+		{"commit", "--quiet", "--allow-empty", "-m", "Add login form"},
+		{"commit", "--quiet", "--allow-empty", "-m", "Handle UTF-8 passwords\n\nFixes PROJ-2, see PROJ-1"},
+	} {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=Test", "-c", "user.email=test@example.com", "-c", "commit.gpgsign=false"}, args...)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	return dir
+}
+
+func TestFindPRReferences(t *testing.T) {
+	commits := []gitinfo.Commit{
+		{Hash: "bbbbbbbbbb", Subject: "PROJ-2 Fix it", Body: "Also PROJ-2 and OPS-9"},
+		{Hash: "aaaaaaaaaa", Subject: "OPS-9 Start"},
+	}
+	refs := findPRReferences("feature/PROJ-2", commits)
+	require.Len(t, refs, 2)
+	assert.Equal(t, "PROJ-2", refs[0].key)
+	assert.True(t, refs[0].inBranch)
+	require.Len(t, refs[0].commits, 1, "a commit is listed once per key")
+	assert.Equal(t, "OPS-9", refs[1].key)
+	assert.Equal(t, []gitinfo.Commit{commits[1], commits[0]}, refs[1].commits, "oldest first")
+
+	assert.Equal(t, "Pull request: https://pr\n\nBranch: feature/PROJ-2\n\nCommits:\n- bbbbbbb PROJ-2 Fix it\n",
+		linkPRComment("https://pr", "feature/PROJ-2", refs[0]))
+}
+
+func TestGitLinkPRRunE(t *testing.T) {
+	dir := newLinkPRTestRepo(t)
+	const prURL = "https://github.com/acme/app/pull/7"
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("GetIssue", mock.Anything, "PROJ-1").Return(&mcpclient.Issue{Key: "PROJ-1"}, nil)
+	mockMCP.On("GetIssue", mock.Anything, "PROJ-2").Return(&mcpclient.Issue{Key: "PROJ-2"}, nil)
+	mockMCP.On("GetIssue", mock.Anything, "UTF-8").
+		Return(nil, fmt.Errorf("%w: %w", mcpclient.ErrNotFound, mcpclient.ErrMCPServerError))
+	mockMCP.On("GetComments", mock.Anything, "PROJ-1", mock.Anything).
+		Return(&mcpclient.CommentsResponse{Total: 1, Comments: []mcpclient.Comment{{Body: "Pull request: " + prURL}}}, nil)
+	mockMCP.On("GetComments", mock.Anything, "PROJ-2", mock.Anything).
+		Return(&mcpclient.CommentsResponse{Total: 1, Comments: []mcpclient.Comment{{Body: "Pull request: " + prURL + "0\n"}}}, nil).Once()
+	mockMCP.On("GetComments", mock.Anything, "PROJ-2", mock.Anything).Return(&mcpclient.CommentsResponse{}, nil)
+	mockMCP.On("AddComment", mock.Anything, "PROJ-2", mock.MatchedBy(func(req mcpclient.AddCommentRequest) bool {
+		return assert.Contains(t, req.Body, "Pull request: "+prURL) && assert.Contains(t, req.Body, "Handle UTF-8 passwords") &&
+			assert.NotContains(t, req.Body, "Branch:")
+	})).Return(&mcpclient.Comment{ID: "1"}, nil).Once()
+
+	var out bytes.Buffer
+	err := gitLinkPRRunE(context.Background(), mockMCP, linkPROptions{dir: dir, prURL: prURL, outputFormat: "json"}, &out)
+	require.NoError(t, err)
+
+	var report linkPRReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.Equal(t, "PROJ-1-login", report.Branch)
+	assert.Equal(t, "main", report.Base)
+	require.Len(t, report.Issues, 3)
+	assert.Equal(t, "PROJ-1", report.Issues[0].Key)
+	assert.Equal(t, linkPRLinked, report.Issues[0].Action, "linked before, so the run is idempotent")
+	assert.Equal(t, "UTF-8", report.Issues[1].Key)
+	assert.Equal(t, linkPRNotFound, report.Issues[1].Action)
+	assert.Equal(t, "PROJ-2", report.Issues[2].Key)
+	assert.Equal(t, linkPRCommented, report.Issues[2].Action, "a comment linking .../pull/70 does not link .../pull/7")
+	assert.Len(t, report.Issues[2].Commits, 1)
+	mockMCP.AssertExpectations(t)
+
+	out.Reset()
+	err = gitLinkPRRunE(context.Background(), mockMCP, linkPROptions{dir: dir, prURL: prURL, dryRun: true}, &out)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "Skipped PROJ-1: already linked to "+prURL)
+	assert.Contains(t, out.String(), "Would comment on PROJ-2: "+prURL)
+	mockMCP.AssertNumberOfCalls(t, "AddComment", 1)
+}
+
+func TestGitLinkPRRunE_LookupError(t *testing.T) {
+	dir := newLinkPRTestRepo(t)
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("GetIssue", mock.Anything, "PROJ-1").Return(nil, fmt.Errorf("%w (status 401)", mcpclient.ErrMCPServerErrorUnparseable))
+
+	var out bytes.Buffer
+	err := gitLinkPRRunE(context.Background(), mockMCP, linkPROptions{dir: dir, prURL: "https://pr"}, &out)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, mcpclient.ErrMCPServerErrorUnparseable)
+	mockMCP.AssertNotCalled(t, "AddComment", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGitLinkPRRunE_NoKeys(t *testing.T) {
+	dir := newLinkPRTestRepo(t)
+	rename := exec.Command("git", "branch", "-m", "cleanup")
+	rename.Dir = dir
+	require.NoError(t, rename.Run())
+	mockMCP := new(MockMCPClient)
+
+	var out bytes.Buffer
+	err := gitLinkPRRunE(context.Background(), mockMCP, linkPROptions{dir: dir, base: "HEAD", prURL: "https://pr"}, &out)
+	require.NoError(t, err)
+	assert.Equal(t, "No issue keys found in cleanup or its commits since HEAD\n", out.String())
+	mockMCP.AssertNotCalled(t, "GetIssue", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/karolswdev/ticketron/internal/ghactions"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+// reportCreatedIssue tells a GitHub Actions workflow, if tix runs in one, about the issue
+// created by tix create: a ::notice annotation on errOut and the issue_key and issue_url
+// step outputs. Failing to set the outputs is only logged, as the issue exists either way.
+func reportCreatedIssue(errOut io.Writer, resp *mcpclient.CreateIssueResponse) {
+	if !ghactions.Enabled() {
+		return
+	}
+	url := browseURL(resp.Self, resp.Key)
+	if url == "" {
+		url = resp.Self
+	}
+	ghactions.Notice(errOut, "tix create", fmt.Sprintf("Created %s: %s", resp.Key, url))
+	outputs := []ghactions.Output{{Name: "issue_key", Value: resp.Key}, {Name: "issue_url", Value: url}}
+	if err := ghactions.SetOutputs(os.Getenv(ghactions.EnvOutput), outputs...); err != nil {
+		Log.Warn().Err(err).Msg("Failed to set the issue_key and issue_url step outputs")
+	}
+}
+
+// reportCommandError writes err, returned by the command at path, as an ::error annotation
+// on w if tix runs in a GitHub Actions workflow.
+func reportCommandError(w io.Writer, path string, err error) {
+	if ghactions.Enabled() {
+		ghactions.Error(w, path, err.Error())
+	}
+}
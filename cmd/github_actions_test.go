@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/ghactions"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+func TestReportCreatedIssue(t *testing.T) {
+	resp := &mcpclient.CreateIssueResponse{Key: "PROJ-7", Self: "https://jira.example.com/rest/api/2/issue/10007"}
+	outputPath := filepath.Join(t.TempDir(), "github_output")
+	t.Setenv(ghactions.EnvOutput, outputPath)
+
+	t.Setenv(ghactions.EnvActions, "")
+	var errOut bytes.Buffer
+	reportCreatedIssue(&errOut, resp)
+	assert.Empty(t, errOut.String(), "nothing is reported outside GitHub Actions")
+	assert.NoFileExists(t, outputPath)
+
+	t.Setenv(ghactions.EnvActions, "true")
+	reportCreatedIssue(&errOut, resp)
+	assert.Equal(t, "::notice title=tix create::Created PROJ-7: https://jira.example.com/browse/PROJ-7\n", errOut.String())
+	data, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.Equal(t, "issue_key=PROJ-7\nissue_url=https://jira.example.com/browse/PROJ-7\n", string(data))
+}
+
+func TestReportCommandError(t *testing.T) {
+	var errOut bytes.Buffer
+	t.Setenv(ghactions.EnvActions, "")
+	reportCommandError(&errOut, "tix create", errors.New("boom"))
+	assert.Empty(t, errOut.String())
+
+	t.Setenv(ghactions.EnvActions, "true")
+	reportCommandError(&errOut, "tix create", errors.New("MCP server returned an error:\nproject not found"))
+	assert.Equal(t, "::error title=tix create::MCP server returned an error:%0Aproject not found\n", errOut.String())
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/history"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+// recordHistory appends a created issue to the local history, tagged with the prompt version
+// that produced it (empty when the issue was not LLM-generated or the prompt is unversioned).
+// History is best effort: failures are logged and never fail the command. Issues the MCP
+// server returned for a repeated idempotency key were recorded when they were created.
+func (r *createCmdRunner) recordHistory(source, input, systemPrompt string, request mcpclient.CreateIssueRequest, resp *mcpclient.CreateIssueResponse) {
+	if r.history == nil || resp == nil || resp.Replayed {
+		return
+	}
+	var promptVersion string
+	if systemPrompt != "" && r.promptLibrary != nil {
+		version, err := r.promptLibrary.Identify(systemPrompt)
+		if err != nil {
+			Log.Debug().Err(err).Msg("Could not identify prompt version for history")
+		}
+		promptVersion = version
+	}
+	entry := history.Entry{
+		Source:        source,
+		Input:         input,
+		IssueKey:      resp.Key,
+		IssueURL:      resp.Self,
+		ProjectKey:    request.ProjectKey,
+		IssueType:     request.IssueType,
+		Summary:       request.Summary,
+		PromptVersion: promptVersion,
+
+		IdempotencyKey: request.IdempotencyKey,
+	}
+	if err := r.history.Append(entry); err != nil {
+		Log.Warn().Err(err).Msg("Failed to record issue in history")
+	}
+}
+
+// resolveConfigDir returns the configuration directory used by the default provider.
+func resolveConfigDir() (string, error) {
+	provider, err := GetProvider()
+	if err != nil {
+		return "", fmt.Errorf("failed to initialize services: %w", err)
+	}
+	return provider.Config.EnsureConfigDir()
+}
+
+// learnedMappingMinCount is how often users must have picked the same project for a
+// suggestion before it overrides links.yaml.
+const learnedMappingMinCount = 2
+
+// recordMappingCorrection remembers that the user chose projectKey although the LLM
+// suggested suggestion, unless links.yaml maps the suggestion to that project anyway.
+// Learning is best effort: failures are logged and never fail the command.
+func (r *createCmdRunner) recordMappingCorrection(links *config.LinksConfig, suggestion, projectKey string) {
+	if r.mappings == nil || strings.TrimSpace(suggestion) == "" {
+		return
+	}
+	suggestedKey, link := resolveProject(links, suggestion)
+	if link == nil {
+		suggestedKey = ""
+	}
+	if strings.EqualFold(suggestedKey, projectKey) {
+		return
+	}
+	correction := history.MappingCorrection{Suggestion: suggestion, SuggestedKey: suggestedKey, ProjectKey: projectKey}
+	if err := r.mappings.Append(correction); err != nil {
+		Log.Warn().Err(err).Msg("Failed to record project mapping correction")
+	}
+}
+
+// learnedProject returns the project users picked at least learnedMappingMinCount times
+// for suggestion, if any.
+func (r *createCmdRunner) learnedProject(suggestion string) (string, bool) {
+	if r.mappings == nil || strings.TrimSpace(suggestion) == "" {
+		return "", false
+	}
+	key, ok, err := r.mappings.Learned(suggestion, learnedMappingMinCount)
+	if err != nil {
+		Log.Warn().Err(err).Msg("Failed to read learned project mappings")
+		return "", false
+	}
+	return key, ok
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/karolswdev/ticketron/internal/history"
+	"github.com/karolswdev/ticketron/internal/i18n"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+// recentCreateWindow is how long after an issue was created tix warns about creating
+// another one from the same input in the same project, and the window idempotency keys are
+// scoped to.
+const recentCreateWindow = 24 * time.Hour
+
+// idempotencyKey identifies the creation of an issue from input in projectKey in the current
+// window (see idempotencyKeyAt).
+func idempotencyKey(input, projectKey string) string {
+	return idempotencyKeyAt(input, projectKey, time.Now())
+}
+
+// idempotencyKeyAt identifies the creation of an issue from input in projectKey in the
+// recentCreateWindow containing now, i.e. the UTC day. Retries and duplicated invocations in
+// the window produce the same key, so the MCP server can return the issue created first
+// instead of creating another, while a recurring issue created from the same input on a
+// later day is a new one. Differences in whitespace and in the case of the project key do
+// not change the key.
+func idempotencyKeyAt(input, projectKey string, now time.Time) string {
+	window := now.UTC().Truncate(recentCreateWindow).Format(time.DateOnly)
+	normalized := window + "\n" + strings.ToUpper(strings.TrimSpace(projectKey)) + "\n" + strings.Join(strings.Fields(input), " ")
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:16])
+}
+
+// requestInput is the input the idempotency key of a request built without the LLM is
+// derived from.
+func requestInput(request mcpclient.CreateIssueRequest) string {
+	return request.Summary + "\n" + request.Description
+}
+
+// recentCreate returns the issue created with the idempotency key of request within
+// recentCreateWindow, according to the history, or nil if there is none.
+func (r *createCmdRunner) recentCreate(request mcpclient.CreateIssueRequest) *history.Entry {
+	if r.history == nil || request.IdempotencyKey == "" {
+		return nil
+	}
+	entry, err := r.history.FindIdempotent(request.IdempotencyKey, time.Now().Add(-recentCreateWindow))
+	if err != nil {
+		Log.Warn().Err(err).Msg("Failed to look up recently created issues")
+		return nil
+	}
+	return entry
+}
+
+// warnRecentCreate warns on errOut when an issue was recently created from the same input
+// as request.
+func (r *createCmdRunner) warnRecentCreate(request mcpclient.CreateIssueRequest, errOut io.Writer) {
+	entry := r.recentCreate(request)
+	if entry == nil {
+		return
+	}
+	Log.Warn().Str("issue_key", entry.IssueKey).Time("created", entry.Timestamp).Msg("Issue recently created from the same input")
+	created := entry.Timestamp.Local().Format("2006-01-02 15:04")
+	fmt.Fprintln(errOut, i18n.T(i18n.MsgRecentCreate, entry.IssueKey, request.ProjectKey, created))
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/history"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+func TestIdempotencyKey(t *testing.T) {
+	key := idempotencyKey("login broken\non mobile", "BE")
+	assert.Len(t, key, 32)
+	assert.Equal(t, key, idempotencyKey("  login   broken on mobile\n", "be"), "whitespace and project case do not matter")
+	assert.NotEqual(t, key, idempotencyKey("login broken on mobile", "FE"))
+	assert.NotEqual(t, key, idempotencyKey("login broken on desktop", "BE"))
+
+	// Keys are scoped to the UTC day, so recurring issues are not replayed forever
+	morning := time.Date(2025, time.April, 9, 1, 0, 0, 0, time.UTC)
+	assert.Equal(t, idempotencyKeyAt("rotate credentials", "OPS", morning), idempotencyKeyAt("rotate credentials", "OPS", morning.Add(22*time.Hour)))
+	assert.NotEqual(t, idempotencyKeyAt("rotate credentials", "OPS", morning), idempotencyKeyAt("rotate credentials", "OPS", morning.Add(23*time.Hour)))
+}
+
+func TestSubmitIssue_IdempotencyKey(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	runner, _ := newMCPServeTestRunner(mockMCP)
+	runner.history = history.NewStore(t.TempDir())
+	ctx := context.Background()
+	request := mcpclient.CreateIssueRequest{ProjectKey: "BE", Summary: "Fix login", Description: "500 on submit", IssueType: "Bug"}
+	key := idempotencyKey("Fix login\n500 on submit", "BE")
+
+	mockMCP.On("CreateIssue", mock.Anything, mock.MatchedBy(func(req mcpclient.CreateIssueRequest) bool { return req.IdempotencyKey == key })).
+		Return(&mcpclient.CreateIssueResponse{Key: "BE-1"}, nil).Once()
+	var errOut bytes.Buffer
+	runner.warnRecentCreate(mcpclient.CreateIssueRequest{ProjectKey: "BE", IdempotencyKey: key}, &errOut)
+	assert.Empty(t, errOut.String(), "nothing was created yet")
+	sent, resp, err := runner.submitIssue(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, key, sent.IdempotencyKey, "requests without a key get one derived from their content")
+	runner.recordHistory("import", "", "", sent, resp)
+
+	runner.warnRecentCreate(sent, &errOut)
+	assert.Contains(t, errOut.String(), "Warning: BE-1 was already created from the same input in project BE at ")
+
+	// A retry the server deduplicates returns the same issue, which is not recorded again
+	mockMCP.On("CreateIssue", mock.Anything, mock.Anything).Return(&mcpclient.CreateIssueResponse{Key: "BE-1", Replayed: true}, nil).Once()
+	sent, resp, err = runner.submitIssue(ctx, request)
+	require.NoError(t, err)
+	assert.True(t, resp.Replayed)
+	runner.recordHistory("import", "", "", sent, resp)
+	entries, err := runner.history.List()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, key, entries[0].IdempotencyKey)
+	mockMCP.AssertExpectations(t)
+}
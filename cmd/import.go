@@ -0,0 +1,470 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/prompt"
+)
+
+// importTargets lists the issue fields a CSV column can be mapped to.
+var importTargets = []string{"summary", "description", "project", "type"}
+
+// importDefaultColumns are the column headers used for a target when --map does not name one.
+// They match the headers of a Jira CSV export and are compared case-insensitively.
+var importDefaultColumns = map[string][]string{
+	"summary":     {"Summary", "Title"},
+	"description": {"Description"},
+	"project":     {"Project key", "Project"},
+	"type":        {"Issue Type", "Type"},
+}
+
+// parseImportMapping parses "summary=Title,description=Details" into a target -> column map.
+func parseImportMapping(spec string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		target, column, found := strings.Cut(item, "=")
+		target, column = strings.ToLower(strings.TrimSpace(target)), strings.TrimSpace(column)
+		if !found || target == "" || column == "" {
+			return nil, fmt.Errorf("invalid mapping %q: expected FIELD=COLUMN", item)
+		}
+		if !containsString(importTargets, target) {
+			return nil, fmt.Errorf("unknown field %q in mapping (expected one of %s)", target, strings.Join(importTargets, ", "))
+		}
+		mapping[target] = column
+	}
+	return mapping, nil
+}
+
+// containsString reports whether s is one of values.
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveImportColumns finds the index of each target's column in header. Explicitly mapped
+// columns must exist; default columns are optional. Summary must always resolve.
+func resolveImportColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	indexOf := func(name string) int {
+		for i, h := range header {
+			if strings.EqualFold(strings.TrimSpace(h), name) {
+				return i
+			}
+		}
+		return -1
+	}
+
+	columns := make(map[string]int)
+	for _, target := range importTargets {
+		if name, ok := mapping[target]; ok {
+			idx := indexOf(name)
+			if idx < 0 {
+				return nil, fmt.Errorf("column %q mapped to %s not found in CSV header", name, target)
+			}
+			columns[target] = idx
+			continue
+		}
+		for _, name := range importDefaultColumns[target] {
+			if idx := indexOf(name); idx >= 0 {
+				columns[target] = idx
+				break
+			}
+		}
+	}
+	if _, ok := columns["summary"]; !ok {
+		return nil, errors.New("no summary column found; use --map summary=COLUMN")
+	}
+	return columns, nil
+}
+
+// importOptions holds the settings of an import run.
+type importOptions struct {
+	mapping           map[string]string
+	projectKey        string // Default project for rows without a project column value
+	issueType         string // Default issue type for rows without a type column value
+	enrich            bool   // Let the LLM rewrite summary and description
+	descriptionFormat string
+	dryRun            bool
+	outputFormat      string
+	onFailure         string // What to do with the created issues if other rows fail, see parseOnFailure
+	recoveryFile      string // Where the rows not in Jira are written if rows fail; empty to write none
+}
+
+// importResult reports the outcome of a single CSV row. Row is the 1-based record number
+// including the header, i.e. the row number shown by a spreadsheet.
+type importResult struct {
+	Row       int    `json:"row"`
+	OK        bool   `json:"ok"`
+	Key       string `json:"key,omitempty"`
+	Project   string `json:"project,omitempty"`
+	IssueType string `json:"issue_type,omitempty"`
+	Summary   string `json:"summary,omitempty"`
+	Error     string `json:"error,omitempty"`
+	// Replayed is set if the issue had been created from the row by an earlier import.
+	Replayed bool `json:"replayed,omitempty"`
+	// RolledBack is set if the issue was created but rolled back as other rows failed.
+	RolledBack bool `json:"rolled_back,omitempty"`
+}
+
+// importRunE creates one issue per CSV record read from in and reports the row -> key
+// mapping to out. Rows are processed in order and a failing row does not stop the import;
+// an error is returned at the end if any row failed. The created issues are then rolled
+// back according to opts.onFailure, asking with confirmer on errOut by default, and the rows
+// not in Jira are written to opts.recoveryFile, so they can be imported again.
+func importRunE(ctx context.Context, runner *createCmdRunner, confirmer *prompt.Confirmer, in io.Reader, opts importOptions, out, errOut io.Writer) error {
+	reader := csv.NewReader(in)
+	reader.FieldsPerRecord = -1 // Tolerate ragged rows, missing cells are treated as empty
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return errors.New("CSV input is empty")
+		}
+		return fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columns, err := resolveImportColumns(header, opts.mapping)
+	if err != nil {
+		return err
+	}
+	if _, err := mcpclient.ParseDescriptionFormat(opts.descriptionFormat); err != nil {
+		return err
+	}
+	if opts.onFailure, err = parseOnFailure(opts.onFailure); err != nil {
+		return err
+	}
+	if !opts.dryRun && runner.mcpClient == nil {
+		return errMCPClientNotInitialized
+	}
+
+	cfgs, err := loadAllConfigs(runner.configProvider)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	var results []importResult
+	records := make(map[int][]string)
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		res := importResult{Row: row}
+		if err != nil {
+			res.Error = fmt.Sprintf("invalid CSV: %v", err)
+		} else {
+			records[row] = record
+			res = runner.importRow(ctx, cfgs, row, record, columns, opts)
+		}
+		if !res.OK {
+			Log.Warn().Int("row", row).Str("error", res.Error).Msg("Import row failed")
+		}
+		results = append(results, res)
+	}
+
+	failures := 0
+	var created []string
+	for _, res := range results {
+		if !res.OK {
+			failures++
+		} else if res.Key != "" && !res.Replayed && !containsString(created, res.Key) {
+			// Issues created by an earlier import are not this import's to roll back
+			created = append(created, res.Key)
+		}
+	}
+	structured := strings.ToLower(opts.outputFormat) == "json"
+	if !structured {
+		// The failed rows are shown before asking whether to roll back
+		if err := writeImportResults(out, results, opts); err != nil {
+			return err
+		}
+	}
+	if failures > 0 && len(created) > 0 {
+		mode, err := resolveRollback(confirmer, opts.onFailure, len(created), failures, errOut)
+		if err != nil {
+			return err
+		}
+		if mode != onFailureKeep {
+			rolledBack := rollbackIssues(ctx, runner.mcpClient, mode, created,
+				fmt.Sprintf("Rolled back by tix import: %d of %d rows failed to import.", failures, len(results)), errOut, errOut)
+			for i := range results {
+				results[i].RolledBack = !results[i].Replayed && containsString(rolledBack, results[i].Key)
+			}
+		}
+	}
+	if structured {
+		if err := writeImportResults(out, results, opts); err != nil {
+			return err
+		}
+	}
+	if failures > 0 && !opts.dryRun && opts.recoveryFile != "" {
+		if err := writeImportRecovery(opts.recoveryFile, header, results, records, errOut); err != nil {
+			return err
+		}
+	}
+	if failures > 0 {
+		return fmt.Errorf("%d of %d rows failed to import", failures, len(results))
+	}
+	return nil
+}
+
+// writeImportRecovery writes the header and the records of the rows that are not in Jira,
+// because they failed or were rolled back, to path as CSV, so they can be fixed and
+// imported again with the same flags.
+func writeImportRecovery(path string, header []string, results []importResult, records map[int][]string, errOut io.Writer) error {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	rows := 0
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write the recovery file: %w", err)
+	}
+	for _, res := range results {
+		record, ok := records[res.Row]
+		if !ok || (res.OK && !res.RolledBack) {
+			continue
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write the recovery file: %w", err)
+		}
+		rows++
+	}
+	writer.Flush()
+	if rows == 0 {
+		return nil
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed to write the recovery file: %w", err)
+	}
+	fmt.Fprintf(errOut, "Wrote the %d row(s) not imported to %s; fix them and import that file again.\n", rows, path)
+	return nil
+}
+
+// importRow builds the create request for a single record and, unless this is a dry run, creates the issue.
+func (r *createCmdRunner) importRow(ctx context.Context, cfgs *loadedConfigs, row int, record []string, columns map[string]int, opts importOptions) importResult {
+	res := importResult{Row: row}
+	cell := func(target string) string {
+		idx, ok := columns[target]
+		if !ok || idx >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[idx])
+	}
+
+	summary, description := cell("summary"), cell("description")
+	if summary == "" {
+		res.Error = "summary is empty"
+		return res
+	}
+	projectKey := opts.projectKey
+	if value := cell("project"); value != "" {
+		projectKey = r.importProjectKey(cfgs, value)
+	}
+	issueType := opts.issueType
+	if value := cell("type"); value != "" {
+		issueType = value
+	}
+
+	var request mcpclient.CreateIssueRequest
+	input := summary
+	if description != "" {
+		input = summary + "\n\n" + description
+	}
+	if opts.enrich {
+		var hints bytes.Buffer
+		var err error
+		request, err = r.buildIssueRequest(ctx, &hints, cfgs, input, issueRequestOptions{issueType: issueType, projectKey: projectKey, descriptionFormat: opts.descriptionFormat})
+		if err != nil {
+			res.Error = withHints(err, hints.String()).Error()
+			return res
+		}
+	} else {
+		if projectKey == "" {
+			res.Error = "no project: add a project column or use --project"
+			return res
+		}
+		if err := r.checkIssueType(ctx, issueType, projectKey); err != nil {
+			res.Error = err.Error()
+			return res
+		}
+		descriptionFormat, err := descriptionFormatFor(cfgs, opts.descriptionFormat)
+		if err != nil {
+			res.Error = err.Error()
+			return res
+		}
+		link := findLinkByKey(cfgs.linksConfig, projectKey)
+		request = mcpclient.CreateIssueRequest{
+			ProjectKey:        projectKey,
+			Summary:           summary,
+			Description:       description,
+			IssueType:         r.issueTypeResolver.Resolve(issueType, link, projectKey),
+			DescriptionFormat: descriptionFormat,
+		}
+	}
+	res.Project, res.IssueType, res.Summary = request.ProjectKey, request.IssueType, request.Summary
+
+	if opts.dryRun {
+		res.OK = true
+		return res
+	}
+	// Identical rows are intentionally separate issues, so the row is part of the key
+	request.IdempotencyKey = idempotencyKey(fmt.Sprintf("import row %d\n%s", row, requestInput(request)), request.ProjectKey)
+	request, resp, err := r.submitIssue(ctx, request)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	res.OK, res.Key, res.Replayed = true, resp.Key, resp.Replayed
+	systemPrompt := "" // Only enriched rows were produced by a prompt
+	if opts.enrich {
+		systemPrompt = cfgs.systemPrompt
+	}
+	r.recordHistory("import", input, systemPrompt, request, resp)
+	return res
+}
+
+// importProjectKey maps a project column value to a key: a known key is used as is,
+// a project name from links.yaml is mapped to its key and anything else is passed through.
+func (r *createCmdRunner) importProjectKey(cfgs *loadedConfigs, value string) string {
+	if link := findLinkByKey(cfgs.linksConfig, value); link != nil {
+		return link.Key
+	}
+	if key, _, err := r.projectMapper.MapSuggestionToKey(value, cfgs.linksConfig); err == nil {
+		return key
+	}
+	return value
+}
+
+// writeImportResults prints the row -> key mapping as text or a JSON array.
+func writeImportResults(out io.Writer, results []importResult, opts importOptions) error {
+	if strings.ToLower(opts.outputFormat) == "json" {
+		if results == nil {
+			results = []importResult{}
+		}
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(results)
+	}
+
+	created := 0
+	for _, res := range results {
+		switch {
+		case !res.OK:
+			fmt.Fprintf(out, "Row %d: FAILED: %s\n", res.Row, res.Error)
+		case opts.dryRun:
+			fmt.Fprintf(out, "Row %d: would create %s in %s: %s\n", res.Row, res.IssueType, res.Project, res.Summary)
+		case res.Replayed:
+			created++
+			fmt.Fprintf(out, "Row %d -> %s (already created)\n", res.Row, res.Key)
+		default:
+			created++
+			fmt.Fprintf(out, "Row %d -> %s\n", res.Row, res.Key)
+		}
+	}
+	if !opts.dryRun {
+		fmt.Fprintf(out, "Created %d of %d issue(s).\n", created, len(results))
+	}
+	return nil
+}
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import FILE",
+	Short: "Create issues in bulk from a CSV file",
+	Long: `Creates one JIRA issue per row of a CSV file, such as a Jira CSV export, and
+reports which issue key was created for each row.
+
+Columns are matched by header name. By default the Summary (or Title), Description,
+Project key (or Project) and Issue Type (or Type) columns are used; use --map to name
+other columns:
+
+  tix import backlog.csv --map summary=Title,description=Details --project BE
+
+Rows are created as is, without the LLM. With --enrich, each row's summary and
+description are passed to the LLM to produce an improved ticket, using the same
+system prompt and context as 'tix create'. Use '-' to read the CSV from stdin.
+
+If some rows fail, you are asked whether to delete the issues created from the other rows,
+so the file can be imported again as a whole; --on-failure decides without asking. With
+--yes they are kept, unless --on-failure delete or cancel is given. The
+rows that are not in JIRA afterwards are written to a recovery file (backlog.failed.csv
+for backlog.csv, see --recovery-file) with the same columns, ready to be fixed and
+imported again.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var opts importOptions
+		mapSpec, _ := cmd.Flags().GetString("map")
+		mapping, err := parseImportMapping(mapSpec)
+		if err != nil {
+			return err
+		}
+		opts.mapping = mapping
+		opts.projectKey, _ = cmd.Flags().GetString("project")
+		opts.issueType, _ = cmd.Flags().GetString("type")
+		opts.enrich, _ = cmd.Flags().GetBool("enrich")
+		opts.dryRun, _ = cmd.Flags().GetBool("dry-run")
+		opts.descriptionFormat, _ = cmd.Flags().GetString("description-format")
+		opts.outputFormat, _ = cmd.Flags().GetString("output")
+		opts.onFailure, _ = cmd.Flags().GetString("on-failure")
+		opts.recoveryFile, _ = cmd.Flags().GetString("recovery-file")
+
+		var in io.Reader
+		if args[0] == "-" {
+			in = cmd.InOrStdin()
+		} else {
+			if !cmd.Flags().Changed("recovery-file") {
+				ext := filepath.Ext(args[0])
+				opts.recoveryFile = strings.TrimSuffix(args[0], ext) + ".failed" + ext
+			}
+			f, err := os.Open(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to open CSV file: %w", err)
+			}
+			defer f.Close()
+			in = f
+		}
+
+		runner, err := newCreateCmdRunner()
+		if err != nil {
+			return err
+		}
+		if err := runner.applyLLMOverrides(cmd); err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return importRunE(ctx, runner, newConfirmer(cmd), in, opts, cmd.OutOrStdout(), cmd.ErrOrStderr())
+	},
+}
+
+func init() {
+	importCmd.Flags().String("map", "", "Comma-separated FIELD=COLUMN mappings for summary, description, project and type")
+	importCmd.Flags().StringP("project", "p", "", "Project key for rows without a project column value")
+	importCmd.Flags().StringP("type", "t", "", "Issue type for rows without a type column value (default: project default or Task)")
+	importCmd.Flags().Bool("enrich", false, "Let the LLM improve each row's summary and description")
+	importCmd.Flags().Bool("dry-run", false, "Show the issues that would be created without creating them")
+	importCmd.Flags().String("description-format", "", "Send descriptions as text, wiki or adf (default: description_format from config.yaml, else text)")
+	importCmd.Flags().String("on-failure", onFailureAsk, "What to do with the created issues if rows fail: ask, delete, cancel (transition to \"Cancelled\") or keep")
+	importCmd.Flags().String("recovery-file", "", "Write the rows not imported to this CSV file if rows fail (default: FILE with .failed before the extension)")
+	addLLMOverrideFlags(importCmd)
+
+	rootCmd.AddCommand(importCmd)
+}
@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/llm"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/prompt"
+)
+
+func TestParseImportMapping(t *testing.T) {
+	mapping, err := parseImportMapping("summary=Title, Description = Details")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"summary": "Title", "description": "Details"}, mapping)
+
+	_, err = parseImportMapping("summary")
+	assert.ErrorContains(t, err, "expected FIELD=COLUMN")
+	_, err = parseImportMapping("assignee=Owner")
+	assert.ErrorContains(t, err, `unknown field "assignee"`)
+}
+
+func TestResolveImportColumns(t *testing.T) {
+	header := []string{"Issue Type", "Summary", "Details", "Project key"}
+
+	columns, err := resolveImportColumns(header, map[string]string{"description": "details"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"type": 0, "summary": 1, "description": 2, "project": 3}, columns)
+
+	_, err = resolveImportColumns(header, map[string]string{"summary": "Title"})
+	assert.ErrorContains(t, err, `column "Title" mapped to summary not found`)
+
+	_, err = resolveImportColumns([]string{"Details"}, nil)
+	assert.ErrorContains(t, err, "no summary column found")
+}
+
+func TestImportRunE_CreatesRowsWithoutLLM(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	runner, mockLLM := newMCPServeTestRunner(mockMCP)
+
+	mockMCP.On("CreateIssue", mock.Anything, mcpclient.CreateIssueRequest{ProjectKey: "BE", Summary: "Fix login", Description: "500 on submit", IssueType: "Bug", IdempotencyKey: idempotencyKey("import row 2\nFix login\n500 on submit", "BE")}).
+		Return(&mcpclient.CreateIssueResponse{Key: "BE-1"}, nil)
+	mockMCP.On("CreateIssue", mock.Anything, mcpclient.CreateIssueRequest{ProjectKey: "OPS", Summary: "Rotate keys", IssueType: "Chore", IdempotencyKey: idempotencyKey("import row 3\nRotate keys\n", "OPS")}).
+		Return(nil, errors.New("forbidden"))
+
+	csvInput := "Title,Details,Project,Type\n" +
+		"Fix login,500 on submit,Backend,\n" +
+		"Rotate keys,,OPS,Chore\n" +
+		",no summary,BE,\n"
+
+	var out bytes.Buffer
+	opts := importOptions{mapping: map[string]string{"summary": "Title", "description": "Details"}}
+	err := importRunE(context.Background(), runner, &prompt.Confirmer{NoInput: true}, strings.NewReader(csvInput), opts, &out, io.Discard)
+	assert.EqualError(t, err, "2 of 3 rows failed to import")
+
+	assert.Equal(t, "Row 2 -> BE-1\nRow 3: FAILED: forbidden\nRow 4: FAILED: summary is empty\nCreated 1 of 3 issue(s).\n", out.String())
+	mockLLM.AssertNotCalled(t, "GenerateTicketDetails", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockMCP.AssertExpectations(t)
+}
+
+func TestImportRunE_DefaultProjectRequired(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	runner, _ := newMCPServeTestRunner(mockMCP)
+
+	var out bytes.Buffer
+	err := importRunE(context.Background(), runner, &prompt.Confirmer{NoInput: true}, strings.NewReader("Summary\nFix login\n"), importOptions{outputFormat: "json"}, &out, io.Discard)
+	assert.EqualError(t, err, "1 of 1 rows failed to import")
+
+	var results []importResult
+	require.NoError(t, json.Unmarshal(out.Bytes(), &results))
+	require.Len(t, results, 1)
+	assert.Equal(t, "no project: add a project column or use --project", results[0].Error)
+	mockMCP.AssertNotCalled(t, "CreateIssue", mock.Anything, mock.Anything)
+}
+
+func TestImportRunE_EnrichDryRun(t *testing.T) {
+	Log = zerolog.Nop()
+	runner, mockLLM := newMCPServeTestRunner(nil)
+	mockLLM.On("GenerateTicketDetails", mock.Anything, "login broken\n\nusers see 500", "prompt", "").
+		Return(llm.LLMResponse{Summary: "Fix login error", Description: "Users get HTTP 500", ProjectNameSuggestion: "Backend"}, nil)
+
+	var out bytes.Buffer
+	opts := importOptions{projectKey: "BE", enrich: true, dryRun: true}
+	err := importRunE(context.Background(), runner, &prompt.Confirmer{NoInput: true}, strings.NewReader("Summary,Description\nlogin broken,users see 500\n"), opts, &out, io.Discard)
+	require.NoError(t, err)
+	assert.Equal(t, "Row 2: would create Bug in BE: Fix login error\n", out.String())
+	mockLLM.AssertExpectations(t)
+}
+
+func TestImportRunE_InvalidInput(t *testing.T) {
+	Log = zerolog.Nop()
+	runner, _ := newMCPServeTestRunner(new(MockMCPClient))
+
+	err := importRunE(context.Background(), runner, &prompt.Confirmer{NoInput: true}, strings.NewReader(""), importOptions{}, &bytes.Buffer{}, io.Discard)
+	assert.EqualError(t, err, "CSV input is empty")
+
+	noMCP, _ := newMCPServeTestRunner(nil)
+	err = importRunE(context.Background(), noMCP, &prompt.Confirmer{NoInput: true}, strings.NewReader("Summary\nx\n"), importOptions{projectKey: "BE"}, &bytes.Buffer{}, io.Discard)
+	assert.ErrorIs(t, err, errMCPClientNotInitialized)
+}
+
+func TestImportRunE_Rollback(t *testing.T) {
+	Log = zerolog.Nop()
+	csvInput := "Summary,Project\nFix login,BE\nAdd metrics,BE\nRotate keys,OPS\n"
+	newRunner := func() (*createCmdRunner, *MockMCPClient) {
+		mockMCP := new(MockMCPClient)
+		runner, _ := newMCPServeTestRunner(mockMCP)
+		mockMCP.On("CreateIssue", mock.Anything, mock.MatchedBy(func(req mcpclient.CreateIssueRequest) bool { return req.Summary == "Fix login" })).
+			Return(&mcpclient.CreateIssueResponse{Key: "BE-1"}, nil)
+		mockMCP.On("CreateIssue", mock.Anything, mock.MatchedBy(func(req mcpclient.CreateIssueRequest) bool { return req.Summary == "Add metrics" })).
+			Return(&mcpclient.CreateIssueResponse{Key: "BE-2"}, nil)
+		mockMCP.On("CreateIssue", mock.Anything, mock.MatchedBy(func(req mcpclient.CreateIssueRequest) bool { return req.Summary == "Rotate keys" })).
+			Return(nil, errors.New("forbidden"))
+		return runner, mockMCP
+	}
+
+	// Confirmed, the created issues are deleted and the whole file is left to retry
+	runner, mockMCP := newRunner()
+	mockMCP.On("DeleteIssue", mock.Anything, "BE-1").Return(nil).Once()
+	mockMCP.On("DeleteIssue", mock.Anything, "BE-2").Return(errors.New("no permission")).Once()
+	recovery := filepath.Join(t.TempDir(), "backlog.failed.csv")
+	var out, errOut bytes.Buffer
+	opts := importOptions{outputFormat: "json", recoveryFile: recovery}
+	err := importRunE(context.Background(), runner, &prompt.Confirmer{In: strings.NewReader("y\n")}, strings.NewReader(csvInput), opts, &out, &errOut)
+	assert.EqualError(t, err, "1 of 3 rows failed to import")
+	assert.Contains(t, errOut.String(), "1 issue(s) failed. Delete the 2 issue(s) created so far")
+	assert.Contains(t, errOut.String(), "Rolled back BE-1 (deleted)")
+	assert.Contains(t, errOut.String(), "Failed to roll back BE-2: no permission")
+	assert.Contains(t, errOut.String(), "--on-failure cancel")
+	var results []importResult
+	require.NoError(t, json.Unmarshal(out.Bytes(), &results))
+	require.Len(t, results, 3)
+	assert.True(t, results[0].RolledBack)
+	assert.False(t, results[1].RolledBack)
+	data, err := os.ReadFile(recovery)
+	require.NoError(t, err)
+	assert.Equal(t, "Summary,Project\nFix login,BE\nRotate keys,OPS\n", string(data), "rows not in Jira can be imported again")
+	assert.Contains(t, errOut.String(), "Wrote the 2 row(s) not imported to "+recovery)
+	mockMCP.AssertExpectations(t)
+
+	// --on-failure cancel transitions them without asking
+	runner, mockMCP = newRunner()
+	mockMCP.On("TransitionIssue", mock.Anything, mock.Anything, mock.MatchedBy(func(req mcpclient.TransitionIssueRequest) bool {
+		return req.Transition == defaultCancelTransition && strings.Contains(req.Comment, "1 of 3 rows failed")
+	})).Return(nil).Twice()
+	errOut.Reset()
+	opts = importOptions{onFailure: "cancel"}
+	err = importRunE(context.Background(), runner, &prompt.Confirmer{NoInput: true}, strings.NewReader(csvInput), opts, io.Discard, &errOut)
+	assert.Error(t, err)
+	assert.Contains(t, errOut.String(), `Rolled back BE-2 (transition "Cancelled")`)
+	mockMCP.AssertExpectations(t)
+
+	// Declined, the created issues are kept and only the failed row is left to retry
+	runner, mockMCP = newRunner()
+	opts = importOptions{recoveryFile: recovery}
+	out.Reset()
+	err = importRunE(context.Background(), runner, &prompt.Confirmer{In: strings.NewReader("n\n")}, strings.NewReader(csvInput), opts, &out, io.Discard)
+	assert.Error(t, err)
+	assert.Contains(t, out.String(), "Row 2 -> BE-1\nRow 3 -> BE-2\nRow 4: FAILED: forbidden\n")
+	mockMCP.AssertNotCalled(t, "DeleteIssue", mock.Anything, mock.Anything)
+	data, err = os.ReadFile(recovery)
+	require.NoError(t, err)
+	assert.Equal(t, "Summary,Project\nRotate keys,OPS\n", string(data))
+
+	// --yes does not delete the created issues, which takes --on-failure delete
+	runner, mockMCP = newRunner()
+	err = importRunE(context.Background(), runner, &prompt.Confirmer{AssumeYes: true}, strings.NewReader(csvInput), importOptions{}, io.Discard, io.Discard)
+	assert.Error(t, err)
+	mockMCP.AssertNotCalled(t, "DeleteIssue", mock.Anything, mock.Anything)
+
+	_, err = parseOnFailure("undo")
+	assert.ErrorContains(t, err, "invalid --on-failure")
+}
+
+func TestImportRunE_RepeatedAndReplayedRows(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	runner, _ := newMCPServeTestRunner(mockMCP)
+
+	// Identical rows are separate issues
+	mockMCP.On("CreateIssue", mock.Anything, mock.MatchedBy(func(req mcpclient.CreateIssueRequest) bool {
+		return req.IdempotencyKey == idempotencyKey("import row 2\nFix login\n", "BE")
+	})).Return(&mcpclient.CreateIssueResponse{Key: "BE-1", Replayed: true}, nil)
+	mockMCP.On("CreateIssue", mock.Anything, mock.MatchedBy(func(req mcpclient.CreateIssueRequest) bool {
+		return req.IdempotencyKey == idempotencyKey("import row 3\nFix login\n", "BE")
+	})).Return(&mcpclient.CreateIssueResponse{Key: "BE-2"}, nil)
+	mockMCP.On("CreateIssue", mock.Anything, mock.MatchedBy(func(req mcpclient.CreateIssueRequest) bool { return req.Summary == "Rotate keys" })).
+		Return(nil, errors.New("forbidden"))
+	// Only the issue created by this import is rolled back
+	mockMCP.On("DeleteIssue", mock.Anything, "BE-2").Return(nil).Once()
+
+	csvInput := "Summary,Project\nFix login,BE\nFix login,BE\nRotate keys,OPS\n"
+	var out bytes.Buffer
+	err := importRunE(context.Background(), runner, &prompt.Confirmer{NoInput: true}, strings.NewReader(csvInput), importOptions{onFailure: "delete"}, &out, io.Discard)
+	assert.EqualError(t, err, "1 of 3 rows failed to import")
+	assert.Contains(t, out.String(), "Row 2 -> BE-1 (already created)\nRow 3 -> BE-2\n")
+	mockMCP.AssertExpectations(t)
+}
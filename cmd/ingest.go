@@ -0,0 +1,347 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/ingest"
+	"github.com/karolswdev/ticketron/internal/llm"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/sanitize"
+)
+
+// Actions reported by tix ingest.
+const (
+	ingestCreated = "created"
+	ingestSkipped = "skipped" // An open issue with the fingerprint label exists
+	ingestDryRun  = "dry_run"
+)
+
+// ingestOptions holds the settings of an ingest run.
+type ingestOptions struct {
+	projectKey        string // Overrides the mapping's project, default_project is the fallback
+	issueType         string // Overrides the mapping's type
+	descriptionFormat string
+	dryRun            bool
+	noLLM             bool // Email only: use the subject and body as they are
+	outputFormat      string
+}
+
+// ingestResult reports what tix ingest did with an alert.
+type ingestResult struct {
+	Action      string `json:"action"`
+	Key         string `json:"key,omitempty"` // The created issue, or the open one the alert duplicates
+	Project     string `json:"project"`
+	Summary     string `json:"summary"`
+	Fingerprint string `json:"fingerprint,omitempty"` // Alerts only
+	Label       string `json:"label,omitempty"`
+}
+
+// ingestRunE maps the alert payload to an issue and creates it, unless an open issue of the
+// project already carries the alert's fingerprint label.
+func ingestRunE(ctx context.Context, runner *createCmdRunner, mapping ingest.Mapping, payload []byte, opts ingestOptions, out io.Writer) error {
+	alert, err := mapping.Apply(payload)
+	if err != nil {
+		return err
+	}
+	cfgs, err := loadAllConfigs(runner.configProvider)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	request, err := runner.buildDirectIssueRequest(ctx, cfgs, issueRequestOptions{
+		summary:           alert.Summary,
+		description:       alert.Description,
+		projectKey:        firstNonEmpty(opts.projectKey, alert.Project),
+		issueType:         firstNonEmpty(opts.issueType, alert.Type),
+		priority:          alert.Priority,
+		fields:            map[string]interface{}{"labels": alert.Labels},
+		descriptionFormat: opts.descriptionFormat,
+	})
+	if err != nil {
+		return err
+	}
+	res := ingestResult{Project: request.ProjectKey, Summary: request.Summary, Fingerprint: alert.Fingerprint, Label: alert.Labels[0]}
+
+	if runner.mcpClient == nil {
+		if !opts.dryRun {
+			return errMCPClientNotInitialized
+		}
+		// Without Jira a dry run cannot check for duplicates
+		res.Action = ingestDryRun
+		return writeIngestResult(out, res, opts.outputFormat)
+	}
+
+	existing, err := openIssueWithLabel(ctx, runner.mcpClient, request.ProjectKey, res.Label)
+	if err != nil {
+		return err
+	}
+	switch {
+	case existing != "":
+		Log.Info().Str("issue_key", existing).Str("fingerprint", alert.Fingerprint).Msg("Alert already has an open issue, skipping")
+		res.Action, res.Key = ingestSkipped, existing
+	case opts.dryRun:
+		res.Action = ingestDryRun
+	default:
+		request, resp, err := runner.submitIssue(ctx, request)
+		if err != nil {
+			return fmt.Errorf("failed to create issue: %w", err)
+		}
+		res.Action, res.Key = ingestCreated, resp.Key
+		runner.recordHistory("ingest", alert.Summary, "", request, resp)
+	}
+	return writeIngestResult(out, res, opts.outputFormat)
+}
+
+// ingestEmailRunE creates an issue from the email read from in. The LLM writes the ticket
+// from the subject, the body without quoted replies and the list of attachments; with
+// opts.noLLM the subject is the summary and the rest the description.
+func ingestEmailRunE(ctx context.Context, runner *createCmdRunner, in io.Reader, opts ingestOptions, out, errOut io.Writer) error {
+	email, err := ingest.ParseEmail(in)
+	if err != nil {
+		return err
+	}
+	if !opts.dryRun && runner.mcpClient == nil {
+		return errMCPClientNotInitialized
+	}
+	cfgs, err := loadAllConfigs(runner.configProvider)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	reqOpts := issueRequestOptions{projectKey: opts.projectKey, issueType: opts.issueType, descriptionFormat: opts.descriptionFormat}
+	var request mcpclient.CreateIssueRequest
+	if opts.noLLM {
+		if email.Subject == "" {
+			return errors.New("the email has no subject to use as the summary; drop --no-llm to let the LLM write one")
+		}
+		reqOpts.summary = sanitize.Truncate(email.Subject, llm.DefaultSummaryMaxLength)
+		reqOpts.description = email.Description()
+		request, err = runner.buildDirectIssueRequest(ctx, cfgs, reqOpts)
+	} else {
+		request, err = runner.buildIssueRequest(ctx, errOut, cfgs, email.Input(), reqOpts)
+	}
+	if err != nil {
+		return err
+	}
+	res := ingestResult{Action: ingestDryRun, Project: request.ProjectKey, Summary: request.Summary}
+	if !opts.dryRun {
+		request, resp, err := runner.submitIssue(ctx, request)
+		if err != nil {
+			return fmt.Errorf("failed to create issue: %w", err)
+		}
+		Log.Info().Str("issue_key", resp.Key).Str("message_id", email.MessageID).Msg("Created issue from email")
+		res.Action, res.Key = ingestCreated, resp.Key
+		var systemPrompt string
+		if !opts.noLLM {
+			systemPrompt = cfgs.systemPrompt
+		}
+		runner.recordHistory("email", email.Input(), systemPrompt, request, resp)
+	}
+	return writeIngestResult(out, res, opts.outputFormat)
+}
+
+// openIssueWithLabel returns the key of the most recent unresolved issue of project carrying
+// label, or "" if there is none.
+func openIssueWithLabel(ctx context.Context, client MCPClient, project, label string) (string, error) {
+	jql := fmt.Sprintf("project = %s AND labels = %s AND statusCategory != Done ORDER BY created DESC", jqlQuote(project), jqlQuote(label))
+	resp, err := client.SearchIssues(ctx, mcpclient.SearchIssuesRequest{JQL: jql, MaxResults: 1})
+	if err != nil {
+		return "", fmt.Errorf("failed to search for an open issue of the alert: %w", err)
+	}
+	if len(resp.Issues) == 0 {
+		return "", nil
+	}
+	return resp.Issues[0].Key, nil
+}
+
+// writeIngestResult prints what was done with the alert as text or JSON.
+func writeIngestResult(out io.Writer, res ingestResult, outputFormat string) error {
+	if strings.ToLower(outputFormat) == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(res)
+	}
+	switch res.Action {
+	case ingestCreated:
+		fmt.Fprintf(out, "Created %s: %s\n", res.Key, res.Summary)
+	case ingestSkipped:
+		fmt.Fprintf(out, "Skipped: %s is already open for this alert (%s)\n", res.Key, res.Label)
+	case ingestDryRun:
+		if res.Label == "" {
+			fmt.Fprintf(out, "Would create in %s: %s\n", res.Project, res.Summary)
+		} else {
+			fmt.Fprintf(out, "Would create in %s: %s (%s)\n", res.Project, res.Summary, res.Label)
+		}
+	}
+	return nil
+}
+
+// firstNonEmpty returns the first of values that is not empty.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// readIngestPayload reads the alert payload from path, or from stdin for "-".
+func readIngestPayload(cmd *cobra.Command, path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(cmd.InOrStdin())
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read alert payload: %w", err)
+	}
+	return data, nil
+}
+
+// ingestOptionsFromFlags reads the flags shared by the ingest commands.
+func ingestOptionsFromFlags(cmd *cobra.Command) ingestOptions {
+	var opts ingestOptions
+	opts.projectKey, _ = cmd.Flags().GetString("project")
+	opts.issueType, _ = cmd.Flags().GetString("type")
+	opts.descriptionFormat, _ = cmd.Flags().GetString("description-format")
+	opts.dryRun, _ = cmd.Flags().GetBool("dry-run")
+	opts.outputFormat, _ = cmd.Flags().GetString("output")
+	return opts
+}
+
+// runIngest runs tix ingest with mapping for the payload file in args.
+func runIngest(cmd *cobra.Command, mapping ingest.Mapping, args []string) error {
+	opts := ingestOptionsFromFlags(cmd)
+	payload, err := readIngestPayload(cmd, args[0])
+	if err != nil {
+		return err
+	}
+	runner, err := newCreateCmdRunner()
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return ingestRunE(ctx, runner, mapping, payload, opts, cmd.OutOrStdout())
+}
+
+// ingestCmd represents the ingest command
+var ingestCmd = &cobra.Command{
+	Use:   "ingest --mapping FILE PAYLOAD",
+	Short: "Create issues from monitoring alert payloads",
+	Long: `Turns the JSON payload of a monitoring alert into a JIRA issue, without the LLM.
+Use a subcommand for a supported source, or --mapping for any other:
+
+  tix ingest sentry payload.json
+  tix ingest --mapping grafana.yaml alert.json
+
+See 'tix ingest email --help' for creating issues from email messages.
+
+A mapping file holds templates for summary, description, fingerprint and, optionally,
+project, type and priority, plus a list of labels. Templates use the helpers of
+webhooks.yaml and {{ field "a.b" "c" }}, the first non-empty value of the given paths:
+
+  summary: '{{ field "title" }}'
+  fingerprint: 'grafana:{{ field "alerts.0.fingerprint" }}'
+  labels: [grafana]
+
+Alerts are deduplicated by fingerprint: the issue gets a tix-fp-... label derived from it,
+and no issue is created while an unresolved issue of the project has that label. Use '-'
+to read the payload from stdin.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, _ := cmd.Flags().GetString("mapping")
+		if path == "" {
+			return fmt.Errorf("--mapping is required, or use a source: tix ingest %s|email", strings.Join(ingest.Sources(), "|"))
+		}
+		mapping, err := ingest.LoadMapping(path)
+		if err != nil {
+			return err
+		}
+		return runIngest(cmd, mapping, args)
+	},
+}
+
+// newIngestSourceCmd returns the subcommand ingesting payloads of a built-in source.
+func newIngestSourceCmd(source, short string) *cobra.Command {
+	return &cobra.Command{
+		Use:   source + " PAYLOAD",
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mapping, err := ingest.Builtin(source)
+			if err != nil {
+				return err
+			}
+			return runIngest(cmd, mapping, args)
+		},
+	}
+}
+
+// ingestEmailCmd represents the ingest email command
+var ingestEmailCmd = &cobra.Command{
+	Use:   "email [FILE]",
+	Short: "Create an issue from an email message",
+	Long: `Creates a JIRA issue from an RFC 822 email message, read from FILE or stdin, e.g.
+piped from procmail or maildrop:
+
+  :0 c
+  | tix ingest email --project SUP
+
+Quoted replies, "On ... wrote:" blocks and signatures are removed, attachments are listed
+by name, type and size, and the LLM writes the ticket from what remains, using the same
+system prompt and context as 'tix create'. With --no-llm the subject becomes the summary
+and the body the description.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := ingestOptionsFromFlags(cmd)
+		opts.noLLM, _ = cmd.Flags().GetBool("no-llm")
+
+		in := cmd.InOrStdin()
+		if len(args) == 1 && args[0] != "-" {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to open email: %w", err)
+			}
+			defer f.Close()
+			in = f
+		}
+
+		runner, err := newCreateCmdRunner()
+		if err != nil {
+			return err
+		}
+		if err := runner.applyLLMOverrides(cmd); err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return ingestEmailRunE(ctx, runner, in, opts, cmd.OutOrStdout(), cmd.ErrOrStderr())
+	},
+}
+
+func init() {
+	ingestCmd.Flags().String("mapping", "", "YAML file mapping the payload to the issue")
+	ingestCmd.PersistentFlags().StringP("project", "p", "", "Project key or links.yaml name (default: the mapping's project, else default_project)")
+	ingestCmd.PersistentFlags().StringP("type", "t", "", "Issue type (default: the mapping's type, else the project default)")
+	ingestCmd.PersistentFlags().Bool("dry-run", false, "Show the issue that would be created without creating it")
+	ingestCmd.PersistentFlags().String("description-format", "", "Send the description as text, wiki or adf (default: description_format from config.yaml, else text)")
+
+	ingestEmailCmd.Flags().Bool("no-llm", false, "Use the subject as the summary and the body as the description")
+	addLLMOverrideFlags(ingestEmailCmd)
+
+	ingestCmd.AddCommand(newIngestSourceCmd("sentry", "Create issues from Sentry alert and issue webhooks"))
+	ingestCmd.AddCommand(ingestEmailCmd)
+	rootCmd.AddCommand(ingestCmd)
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/ingest"
+	"github.com/karolswdev/ticketron/internal/llm"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+const ingestTestPayload = `{"data": {"event": {"title": "DB timeout", "issue_id": "77", "level": "error"}}}`
+
+func TestIngestRunE_CreatesIssue(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	runner, _ := newMCPServeTestRunner(mockMCP)
+	mapping, err := ingest.Builtin("sentry")
+	require.NoError(t, err)
+	label := ingest.FingerprintLabel("sentry:77")
+
+	mockMCP.On("SearchIssues", mock.Anything, mcpclient.SearchIssuesRequest{
+		JQL:        `project = "BE" AND labels = "` + label + `" AND statusCategory != Done ORDER BY created DESC`,
+		MaxResults: 1,
+	}).Return(&mcpclient.SearchIssuesResponse{}, nil)
+	mockMCP.On("CreateIssue", mock.Anything, mcpclient.CreateIssueRequest{
+		ProjectKey:  "BE",
+		Summary:     "DB timeout",
+		Description: "Level: error",
+		IssueType:   "Bug",
+		Fields:      map[string]interface{}{"labels": []string{label, "sentry"}},
+
+		IdempotencyKey: idempotencyKey("DB timeout\nLevel: error", "BE"),
+	}).Return(&mcpclient.CreateIssueResponse{Key: "BE-9"}, nil)
+
+	var out bytes.Buffer
+	err = ingestRunE(context.Background(), runner, mapping, []byte(ingestTestPayload), ingestOptions{projectKey: "Backend"}, &out)
+	require.NoError(t, err)
+	assert.Equal(t, "Created BE-9: DB timeout\n", out.String())
+	mockMCP.AssertExpectations(t)
+}
+
+func TestIngestRunE_SkipsDuplicate(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	runner, _ := newMCPServeTestRunner(mockMCP)
+	mapping, err := ingest.Builtin("sentry")
+	require.NoError(t, err)
+
+	mockMCP.On("SearchIssues", mock.Anything, mock.Anything).
+		Return(&mcpclient.SearchIssuesResponse{Issues: []mcpclient.Issue{{Key: "BE-3"}}}, nil)
+
+	var out bytes.Buffer
+	err = ingestRunE(context.Background(), runner, mapping, []byte(ingestTestPayload), ingestOptions{projectKey: "BE", outputFormat: "json"}, &out)
+	require.NoError(t, err)
+
+	var res ingestResult
+	require.NoError(t, json.Unmarshal(out.Bytes(), &res))
+	assert.Equal(t, ingestResult{Action: ingestSkipped, Key: "BE-3", Project: "BE", Summary: "DB timeout", Fingerprint: "sentry:77", Label: ingest.FingerprintLabel("sentry:77")}, res)
+	mockMCP.AssertNotCalled(t, "CreateIssue", mock.Anything, mock.Anything)
+}
+
+func TestIngestRunE_DryRunWithoutJira(t *testing.T) {
+	Log = zerolog.Nop()
+	runner, _ := newMCPServeTestRunner(nil)
+	mapping, err := ingest.Builtin("sentry")
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	err = ingestRunE(context.Background(), runner, mapping, []byte(ingestTestPayload), ingestOptions{projectKey: "BE", dryRun: true}, &out)
+	require.NoError(t, err)
+	assert.Equal(t, "Would create in BE: DB timeout ("+ingest.FingerprintLabel("sentry:77")+")\n", out.String())
+
+	err = ingestRunE(context.Background(), runner, mapping, []byte(ingestTestPayload), ingestOptions{projectKey: "BE"}, &out)
+	assert.ErrorIs(t, err, errMCPClientNotInitialized)
+
+	err = ingestRunE(context.Background(), runner, mapping, []byte(`{"message": "no fingerprint"}`), ingestOptions{projectKey: "BE"}, &out)
+	assert.ErrorIs(t, err, ingest.ErrPayload)
+}
+
+const ingestTestEmail = "From: Ann <ann@example.com>\r\nSubject: Export broken\r\n\r\n" +
+	"The CSV export returns a 500.\r\n\r\nOn Mon, Support wrote:\r\n> Any news?\r\n"
+
+func TestIngestEmailRunE_LLM(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	runner, mockLLM := newMCPServeTestRunner(mockMCP)
+
+	input := "The following email reports an issue. Ignore greetings, signatures, disclaimers and quoted replies.\n\n" +
+		"From: Ann <ann@example.com>\nSubject: Export broken\n\nThe CSV export returns a 500."
+	mockLLM.On("GenerateTicketDetails", mock.Anything, input, "prompt", mcpServeTestContext).
+		Return(llm.LLMResponse{Summary: "Fix CSV export 500", Description: "Export fails", ProjectNameSuggestion: "backend"}, nil)
+	mockMCP.On("CreateIssue", mock.Anything, mcpclient.CreateIssueRequest{ProjectKey: "BE", Summary: "Fix CSV export 500", Description: "Export fails", IssueType: "Bug", IdempotencyKey: idempotencyKey(input, "BE")}).
+		Return(&mcpclient.CreateIssueResponse{Key: "BE-12"}, nil)
+
+	var out, errOut bytes.Buffer
+	err := ingestEmailRunE(context.Background(), runner, strings.NewReader(ingestTestEmail), ingestOptions{}, &out, &errOut)
+	require.NoError(t, err)
+	assert.Equal(t, "Created BE-12: Fix CSV export 500\n", out.String())
+	mockMCP.AssertExpectations(t)
+}
+
+func TestIngestEmailRunE_NoLLM(t *testing.T) {
+	Log = zerolog.Nop()
+	runner, mockLLM := newMCPServeTestRunner(nil)
+
+	var out bytes.Buffer
+	err := ingestEmailRunE(context.Background(), runner, strings.NewReader(ingestTestEmail), ingestOptions{projectKey: "BE", noLLM: true, dryRun: true, outputFormat: "json"}, &out, &bytes.Buffer{})
+	require.NoError(t, err)
+	var res ingestResult
+	require.NoError(t, json.Unmarshal(out.Bytes(), &res))
+	assert.Equal(t, ingestResult{Action: ingestDryRun, Project: "BE", Summary: "Export broken"}, res)
+	mockLLM.AssertNotCalled(t, "GenerateTicketDetails", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	err = ingestEmailRunE(context.Background(), runner, strings.NewReader("Subject: x\r\n\r\nbody"), ingestOptions{projectKey: "BE", noLLM: true}, &out, &bytes.Buffer{})
+	assert.ErrorIs(t, err, errMCPClientNotInitialized)
+	err = ingestEmailRunE(context.Background(), runner, strings.NewReader("From: a@example.com\r\n\r\nbody"), ingestOptions{projectKey: "BE", noLLM: true, dryRun: true}, &out, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "no subject")
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+// ErrNonInteractive indicates a command that needs a person at the terminal was run with
+// --no-input or without a terminal, e.g. from cron.
+var ErrNonInteractive = errors.New("not available without an interactive terminal")
+
+// stdinTerminal reports whether the process's stdin is a terminal, and whether a person can
+// answer questions on it: stdin is a terminal, or it is redirected in a session that has one,
+// as in `yes | tix delete BE-1`. Tests replace it.
+var stdinTerminal = func() (terminal, answerable bool) {
+	terminal = isTTY(os.Stdin)
+	return terminal, terminal || hasControllingTerminal()
+}
+
+// isTTY reports whether f is a terminal. Unlike isCharDevice it is false for /dev/null.
+func isTTY(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// promptsDisabled reports whether cmd must not ask questions: --no-input is given, tix runs
+// in CI mode, or nobody can answer because the process runs without a terminal.
+func promptsDisabled(cmd *cobra.Command) bool {
+	noInput, _ := cmd.Flags().GetBool("no-input")
+	if noInput || ciEnabled {
+		return true
+	}
+	if !isStdin(cmd.InOrStdin()) {
+		return false // Input set on the command, as in tests, is always answerable
+	}
+	_, answerable := stdinTerminal()
+	return !answerable
+}
+
+// isStdin reports whether in is the process's stdin.
+func isStdin(in io.Reader) bool {
+	f, ok := in.(*os.File)
+	return ok && f == os.Stdin
+}
+
+// checkEditorAllowed returns an error if cmd must not open an editor, which needs stdin to be
+// a terminal. action says what the command would do, e.g. "tix draft edit opens an editor".
+func checkEditorAllowed(cmd *cobra.Command, action string) error {
+	if ciEnabled {
+		return fmt.Errorf("%s: %w", action, ErrCIInteractive)
+	}
+	noInput, _ := cmd.Flags().GetBool("no-input")
+	if terminal, _ := stdinTerminal(); noInput || !terminal {
+		return fmt.Errorf("%s: %w", action, ErrNonInteractive)
+	}
+	return nil
+}
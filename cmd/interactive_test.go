@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/prompt"
+)
+
+// setStdinTerminal makes stdinTerminal report terminal and answerable until the test ends.
+func setStdinTerminal(t *testing.T, terminal, answerable bool) {
+	saved := stdinTerminal
+	t.Cleanup(func() { stdinTerminal = saved })
+	stdinTerminal = func() (bool, bool) { return terminal, answerable }
+}
+
+func TestPromptsDisabled(t *testing.T) {
+	t.Setenv(ciEnvVar, "")
+	cmd := newCITestCmd(t)
+	cmd.SetIn(os.Stdin)
+
+	setStdinTerminal(t, true, true)
+	assert.False(t, promptsDisabled(cmd))
+	setStdinTerminal(t, false, true)
+	assert.False(t, promptsDisabled(cmd), "answers piped in a terminal session")
+	setStdinTerminal(t, false, false)
+	assert.True(t, promptsDisabled(cmd), "no terminal, e.g. cron")
+
+	cmd.SetIn(bytes.NewBufferString("y\n"))
+	assert.False(t, promptsDisabled(cmd), "input set on the command")
+	_, err := newConfirmer(cmd).Confirm(&bytes.Buffer{}, "Delete?", false)
+	require.NoError(t, err)
+
+	cmd = newCITestCmd(t, "--no-input")
+	cmd.SetIn(bytes.NewBufferString("y\n"))
+	assert.True(t, promptsDisabled(cmd), "--no-input forces it")
+}
+
+func TestNewConfirmer_WithoutTerminal(t *testing.T) {
+	t.Setenv(ciEnvVar, "")
+	setStdinTerminal(t, false, false)
+	cmd := newCITestCmd(t)
+	cmd.SetIn(os.Stdin)
+	_, err := newConfirmer(cmd).Confirm(&bytes.Buffer{}, "Delete?", false)
+	assert.ErrorIs(t, err, prompt.ErrInputRequired, "never waits for an answer")
+}
+
+func TestCheckEditorAllowed(t *testing.T) {
+	t.Setenv(ciEnvVar, "")
+	cmd := newCITestCmd(t)
+	setStdinTerminal(t, true, true)
+	require.NoError(t, checkEditorAllowed(cmd, "tix draft edit opens an editor"))
+
+	setStdinTerminal(t, false, true)
+	err := checkEditorAllowed(cmd, "tix draft edit opens an editor")
+	assert.ErrorIs(t, err, ErrNonInteractive, "the editor needs stdin to be a terminal")
+	assert.ErrorContains(t, err, "tix draft edit opens an editor: ")
+
+	setStdinTerminal(t, true, true)
+	assert.ErrorIs(t, checkEditorAllowed(newCITestCmd(t, "--no-input"), "edit"), ErrNonInteractive)
+	applyCIMode(newCITestCmd(t, "--ci"))
+	assert.ErrorIs(t, checkEditorAllowed(cmd, "edit"), ErrCIInteractive)
+}
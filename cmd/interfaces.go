@@ -5,6 +5,7 @@ import (
 
 	"github.com/karolswdev/ticketron/internal/config"
 	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/secrets"
 )
 
 // ConfigProvider defines an interface for components that load various configuration
@@ -19,6 +20,7 @@ type ConfigProvider interface {
 	LoadContext() (string, error)
 	GetAPIKey() (string, error)
 	CreateDefaultConfigFiles(configDir string) error // Added for config init
+	InitConfigFiles(opts config.InitOptions) error   // Non-interactive config init
 	EnsureConfigDir() (string, error)                // Added for config locate
 }
 
@@ -26,10 +28,20 @@ type ConfigProvider interface {
 
 // MCPClient defines an interface for components that communicate with the
 // Jira MCP (Model Context Protocol) server. It abstracts the operations of
-// creating and searching for Jira issues via the MCP API.
+// creating, searching, editing, commenting on, transitioning, linking and deleting Jira issues via the MCP API.
 type MCPClient interface {
 	CreateIssue(ctx context.Context, req mcpclient.CreateIssueRequest) (*mcpclient.CreateIssueResponse, error)
 	SearchIssues(ctx context.Context, req mcpclient.SearchIssuesRequest) (*mcpclient.SearchIssuesResponse, error)
+	AddComment(ctx context.Context, issueKey string, req mcpclient.AddCommentRequest) (*mcpclient.Comment, error)
+	DeleteIssue(ctx context.Context, issueKey string) error
+	TransitionIssue(ctx context.Context, issueKey string, req mcpclient.TransitionIssueRequest) error
+	UpdateIssue(ctx context.Context, issueKey string, req mcpclient.UpdateIssueRequest) error
+	GetIssue(ctx context.Context, issueKey string) (*mcpclient.Issue, error)
+	GetComments(ctx context.Context, issueKey string, req mcpclient.GetCommentsRequest) (*mcpclient.CommentsResponse, error)
+	GetIssueChangelog(ctx context.Context, issueKey string, req mcpclient.GetChangelogRequest) (*mcpclient.ChangelogResponse, error)
+	GetCreateMeta(ctx context.Context, projectKey string) (*mcpclient.CreateMeta, error)
+	SearchUsers(ctx context.Context, query string, maxResults int) ([]mcpclient.User, error)
+	LinkIssues(ctx context.Context, req mcpclient.LinkIssuesRequest) error
 }
 
 // ProjectMapper defines an interface for components that can map a project name
@@ -46,11 +58,18 @@ type IssueTypeResolver interface {
 	Resolve(flagType string, projectLink *config.ProjectLink, defaultType string) string
 }
 
+// IssueTypeChecker is implemented by IssueTypeResolvers that can verify an explicitly
+// requested issue type exists in a project, so invalid types are rejected early.
+type IssueTypeChecker interface {
+	CheckType(ctx context.Context, issueType, projectKey string) error
+}
+
 // KeyringClient defines an interface for components that interact with the
-// operating system's secure credential store (keychain/keyring). It abstracts
-// the operations of setting and retrieving secrets, specifically the LLM API key.
+// configured secret store (OS keychain/keyring, credentials file or environment).
+// It abstracts the operations of setting and retrieving secrets, specifically the LLM API key.
 type KeyringClient interface {
 	Set(service, user, password string) error
 	GetAPIKey(service, user string) (string, error) // Added for config show
+	Status() secrets.Status                         // Describes the active backend for config show
 	// Add Delete later if needed by other commands
 }
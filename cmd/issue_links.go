@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/karolswdev/ticketron/internal/cache"
+	"github.com/karolswdev/ticketron/internal/sanitize"
+)
+
+// issueSummaryCacheTTL is how long the summaries of mentioned issues are cached on disk.
+const issueSummaryCacheTTL = 10 * time.Minute
+
+// issueSummaryParallel is the number of summaries of mentioned issues fetched at once.
+const issueSummaryParallel = 4
+
+// maxInlinedSummaries bounds the number of mentioned issues whose summary is looked up
+// for one rendering; further keys are only linked.
+const maxInlinedSummaries = 50
+
+// inlineSummaryWidth is the display width at which inlined summaries are truncated.
+const inlineSummaryWidth = 60
+
+// issueMentionRe matches the issue keys to link, and the Markdown links and URLs that must
+// be left alone even though they may contain keys, such as .../browse/PROJ-1.
+var issueMentionRe = regexp.MustCompile(`\[[^\]]*\]\([^)]*\)|<?https?://[^\s>)]+>?|` + relatedKeyRe.String())
+
+// issueLinker turns bare issue keys in Markdown into links to their browse pages and,
+// if it knows them, adds the summaries of the mentioned issues to the link text.
+type issueLinker struct {
+	baseURL   string            // E.g. https://acme.atlassian.net; keys link to baseURL/browse/KEY
+	summaries map[string]string // Sanitized summaries by key; keys without one are only linked
+}
+
+// mentionedIssueKeys returns the issue keys mentioned in texts outside code and links,
+// in order of first appearance.
+func mentionedIssueKeys(texts ...string) []string {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, text := range texts {
+		mapOutsideCode(text, func(segment string) string {
+			for _, match := range issueMentionRe.FindAllString(segment, -1) {
+				if relatedKeyRe.MatchString(match) && !strings.ContainsAny(match, "[/") && !seen[match] {
+					seen[match] = true
+					keys = append(keys, match)
+				}
+			}
+			return segment
+		})
+	}
+	return keys
+}
+
+// fetchIssueSummaries returns the summaries of the issues keys, fetched concurrently and
+// cached in store for issueSummaryCacheTTL if store is not nil. Issues that cannot be
+// fetched, e.g. because a key-like word such as UTF-8 is no issue, are left out.
+func fetchIssueSummaries(ctx context.Context, mcpClient MCPClient, store *cache.Store, keys []string) map[string]string {
+	if len(keys) > maxInlinedSummaries {
+		Log.Debug().Int("keys", len(keys)).Int("limit", maxInlinedSummaries).Msg("Too many mentioned issues, inlining only the first summaries")
+		keys = keys[:maxInlinedSummaries]
+	}
+	summaries := make(map[string]string, len(keys))
+	var mu sync.Mutex
+	sem := make(chan struct{}, issueSummaryParallel)
+	var wg sync.WaitGroup
+	for _, key := range keys {
+		cacheKey := "issue-summary-" + key
+		var summary string
+		if store != nil {
+			if found, err := store.Get(cacheKey, &summary); err != nil {
+				Log.Debug().Err(err).Str("issue_key", key).Msg("Failed to read cached issue summary")
+			} else if found {
+				summaries[key] = summary
+				continue
+			}
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(key, cacheKey string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			issue, err := mcpClient.GetIssue(ctx, key)
+			if err != nil {
+				Log.Debug().Err(err).Str("issue_key", key).Msg("Failed to get mentioned issue, linking it without summary")
+				return
+			}
+			summary := issue.Fields.Summary
+			if store != nil {
+				if err := store.Set(cacheKey, summary); err != nil {
+					Log.Debug().Err(err).Str("issue_key", key).Msg("Failed to cache issue summary")
+				}
+			}
+			mu.Lock()
+			summaries[key] = summary
+			mu.Unlock()
+		}(key, cacheKey)
+	}
+	wg.Wait()
+	return summaries
+}
+
+// link returns markdown with the issue keys outside code and links replaced by Markdown
+// links, e.g. [PROJ-1: Fix login](https://acme.atlassian.net/browse/PROJ-1). Without a
+// base URL, keys with a summary get it in parentheses instead.
+func (l *issueLinker) link(markdown string) string {
+	if l == nil || (l.baseURL == "" && len(l.summaries) == 0) {
+		return markdown
+	}
+	return mapOutsideCode(markdown, func(segment string) string {
+		return issueMentionRe.ReplaceAllStringFunc(segment, func(match string) string {
+			if !relatedKeyRe.MatchString(match) || strings.ContainsAny(match, "[/") {
+				return match
+			}
+			summary := l.summaries[match]
+			if summary != "" {
+				// Brackets would end the link text early
+				summary = strings.NewReplacer("[", "(", "]", ")").Replace(sanitize.Truncate(sanitize.Line(summary), inlineSummaryWidth))
+			}
+			switch {
+			case l.baseURL == "" && summary == "":
+				return match
+			case l.baseURL == "":
+				return match + " (" + summary + ")"
+			case summary != "":
+				return "[" + match + ": " + summary + "](" + l.baseURL + "/browse/" + match + ")"
+			default:
+				return "[" + match + "](" + l.baseURL + "/browse/" + match + ")"
+			}
+		})
+	})
+}
+
+// browseBaseURL returns the base of the browse URLs of the Jira instance an issue's
+// self URL points to, or "" if self is no REST API URL.
+func browseBaseURL(self string) string {
+	return strings.TrimSuffix(browseURL(self, ""), "/browse/")
+}
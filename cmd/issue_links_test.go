@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/cache"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+func TestMentionedIssueKeys(t *testing.T) {
+	keys := mentionedIssueKeys(
+		"Blocked by BE-2 and BE-3, see [BE-9](https://jira/browse/BE-9) or https://jira/browse/BE-8",
+		"```\nBE-7\n```\nAgain BE-2 and `BE-6`, also OPS-4",
+	)
+	assert.Equal(t, []string{"BE-2", "BE-3", "OPS-4"}, keys, "keys in code, links and URLs are left out")
+}
+
+func TestIssueLinker_Link(t *testing.T) {
+	links := &issueLinker{baseURL: "https://jira", summaries: map[string]string{"BE-2": "Fix [legacy] login"}}
+	assert.Equal(t, "Blocked by [BE-2: Fix (legacy) login](https://jira/browse/BE-2) and [BE-3](https://jira/browse/BE-3), see https://jira/browse/BE-2 and `BE-3`",
+		links.link("Blocked by BE-2 and BE-3, see https://jira/browse/BE-2 and `BE-3`"))
+
+	links.baseURL = ""
+	assert.Equal(t, "BE-2 (Fix (legacy) login) and BE-3", links.link("BE-2 and BE-3"), "without a browse URL only summaries are added")
+
+	var none *issueLinker
+	assert.Equal(t, "BE-2", none.link("BE-2"))
+	assert.Equal(t, "https://jira.example", browseBaseURL("https://jira.example/rest/api/2/issue/1"))
+	assert.Empty(t, browseBaseURL(""))
+}
+
+func TestFetchIssueSummaries(t *testing.T) {
+	Log = zerolog.Nop()
+	store := cache.New(t.TempDir(), time.Minute)
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("GetIssue", mock.Anything, "BE-2").Return(&mcpclient.Issue{Key: "BE-2", Fields: mcpclient.IssueFields{Summary: "Fix login"}}, nil).Once()
+	mockMCP.On("GetIssue", mock.Anything, "UTF-8").Return(nil, errors.New("issue does not exist")).Twice()
+
+	summaries := fetchIssueSummaries(context.Background(), mockMCP, store, []string{"BE-2", "UTF-8"})
+	assert.Equal(t, map[string]string{"BE-2": "Fix login"}, summaries)
+
+	summaries = fetchIssueSummaries(context.Background(), mockMCP, store, []string{"BE-2", "UTF-8"})
+	assert.Equal(t, map[string]string{"BE-2": "Fix login"}, summaries, "BE-2 comes from the cache")
+	mockMCP.AssertExpectations(t)
+}
+
+func TestViewRunE_IssueLinks(t *testing.T) {
+	Log = zerolog.Nop()
+	issue := testViewIssue()
+	issue.Self = "https://jira.example/rest/api/2/issue/10001"
+	issue.Fields.Description = "Regression of BE-2"
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("GetIssue", mock.Anything, "BE-1").Return(issue, nil)
+
+	var out bytes.Buffer
+	require.NoError(t, viewRunE(context.Background(), mockMCP, "BE-1", viewOptions{}, &out))
+	assert.Contains(t, out.String(), "Regression of BE-2 (https://jira.example/browse/BE-2)")
+
+	mockMCP.On("GetIssue", mock.Anything, "BE-2").Return(&mcpclient.Issue{Key: "BE-2", Fields: mcpclient.IssueFields{Summary: "Login fails"}}, nil).Once()
+	out.Reset()
+	opts := viewOptions{browseURL: "https://acme.atlassian.net/", inlineSummaries: true}
+	require.NoError(t, viewRunE(context.Background(), mockMCP, "BE-1", opts, &out))
+	assert.Contains(t, out.String(), "Regression of BE-2: Login fails (https://acme.atlassian.net/browse/BE-2)")
+	mockMCP.AssertExpectations(t)
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/clierr"
+	"github.com/karolswdev/ticketron/internal/i18n"
+	"github.com/karolswdev/ticketron/internal/output"
+)
+
+// jqFlagUsage describes the persistent --jq flag.
+const jqFlagUsage = "Filter the JSON output with a jq query, e.g. '.issues[].key'; implies -o json, strings are printed without quotes"
+
+// finishJQ filters the rest of the output of a command run with --jq; Execute calls it once
+// the command has run.
+var finishJQ = func() error { return nil }
+
+// startJQ sends the output of cmd through the query given with --jq, if any. Without -o the
+// command prints JSON; other formats are rejected.
+func startJQ(cmd *cobra.Command) error {
+	src, _ := cmd.Flags().GetString("jq")
+	if src == "" {
+		return nil
+	}
+	if flag := cmd.Flags().Lookup("output"); flag != nil {
+		if !flag.Changed {
+			_ = flag.Value.Set(output.FormatJSON)
+		} else if output.Normalize(flag.Value.String()) != output.FormatJSON {
+			err := fmt.Errorf("--jq cannot be combined with -o %s", flag.Value.String())
+			return clierr.New(clierr.CodeInvalidInput, "", i18n.T(i18n.MsgJQOutputHint), err)
+		}
+	}
+	query, err := output.CompileQuery(src)
+	if err != nil {
+		return clierr.New(clierr.CodeInvalidInput, "", "", err)
+	}
+	w := output.NewQueryWriter(cmd.OutOrStdout(), query)
+	cmd.SetOut(w)
+	cmd.Root().SilenceUsage = true // The usage would be filtered as JSON
+	finishJQ = func() error {
+		err := w.Close()
+		if errors.Is(err, output.ErrNotJSON) {
+			return clierr.New(clierr.CodeInvalidInput, "", i18n.T(i18n.MsgJQOutputHint), err)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/clierr"
+	"github.com/karolswdev/ticketron/internal/output"
+)
+
+// newJQTestCmd returns a command with the -o and --jq flags writing to out, and restores
+// finishJQ after the test.
+func newJQTestCmd(t *testing.T, out *bytes.Buffer, args ...string) *cobra.Command {
+	t.Helper()
+	t.Cleanup(func() { finishJQ = func() error { return nil } })
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().StringP("output", "o", "text", "")
+	cmd.Flags().String("jq", "", "")
+	cmd.SetOut(out)
+	require.NoError(t, cmd.ParseFlags(args))
+	return cmd
+}
+
+func TestStartJQ(t *testing.T) {
+	var out bytes.Buffer
+	cmd := newJQTestCmd(t, &out, "--jq", ".issues[].key")
+	require.NoError(t, startJQ(cmd))
+	format, _ := cmd.Flags().GetString("output")
+	assert.Equal(t, output.FormatJSON, format, "--jq implies -o json")
+
+	require.NoError(t, output.JSON(cmd.OutOrStdout(), map[string]interface{}{
+		"issues": []map[string]string{{"key": "BE-1"}, {"key": "BE-2"}},
+	}))
+	require.NoError(t, finishJQ())
+	assert.Equal(t, "BE-1\nBE-2\n", out.String())
+}
+
+func TestStartJQ_Errors(t *testing.T) {
+	var out bytes.Buffer
+	err := startJQ(newJQTestCmd(t, &out, "-o", "yaml", "--jq", "."))
+	assert.Equal(t, clierr.CodeInvalidInput, clierr.CodeOf(err))
+	assert.ErrorContains(t, err, "-o yaml")
+
+	err = startJQ(newJQTestCmd(t, &out, "--jq", ".issues["))
+	assert.ErrorIs(t, err, output.ErrInvalidQuery)
+
+	cmd := newJQTestCmd(t, &out, "-o", "json", "--jq", ".")
+	require.NoError(t, startJQ(cmd))
+	cmd.Println("Successfully created JIRA issue:")
+	err = finishJQ()
+	assert.ErrorIs(t, err, output.ErrNotJSON)
+	assert.NotEmpty(t, clierr.HintOf(err))
+
+	require.NoError(t, startJQ(newJQTestCmd(t, &out)), "no --jq")
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/i18n"
+)
+
+// llmOverrides holds the per-invocation LLM settings given on the command line. Empty
+// strings and nil pointers keep the configured value.
+type llmOverrides struct {
+	provider    string
+	model       string
+	temperature *float32
+	topP        *float32
+	maxTokens   *int
+	seed        *int
+}
+
+// isZero reports whether no override is set.
+func (o llmOverrides) isZero() bool {
+	return o == llmOverrides{}
+}
+
+// apply writes the overrides into cfg.
+func (o llmOverrides) apply(cfg *config.LLMConfig) {
+	if o.provider != "" {
+		cfg.Provider = o.provider
+	}
+	if o.model != "" {
+		cfg.OpenAI.ModelName = o.model
+	}
+	if o.temperature != nil {
+		cfg.OpenAI.Temperature = o.temperature
+	}
+	if o.topP != nil {
+		cfg.OpenAI.TopP = o.topP
+	}
+	if o.maxTokens != nil {
+		cfg.OpenAI.MaxTokens = *o.maxTokens
+	}
+	if o.seed != nil {
+		cfg.OpenAI.Seed = o.seed
+	}
+}
+
+// addLLMOverrideFlags registers the --provider, --model and generation parameter flags on
+// an LLM-using command.
+func addLLMOverrideFlags(cmd *cobra.Command) {
+	cmd.Flags().String("provider", "", "Override the configured LLM provider for this invocation (e.g. openai)")
+	cmd.Flags().String("model", "", "Override the configured LLM model for this invocation (e.g. gpt-4o-mini)")
+	cmd.Flags().Float32("temperature", 0, "Override the configured sampling temperature (0-2)")
+	cmd.Flags().Float32("top-p", 0, "Override the configured nucleus sampling probability (0-1)")
+	cmd.Flags().Int("max-tokens", 0, "Override the configured maximum number of generated tokens")
+	cmd.Flags().Int("seed", 0, "Override the configured seed for reproducible output")
+}
+
+// llmOverridesFromFlags reads the override flags of cmd; generation parameters count only
+// when the flag was given.
+func llmOverridesFromFlags(cmd *cobra.Command) llmOverrides {
+	var o llmOverrides
+	o.provider, _ = cmd.Flags().GetString("provider")
+	o.model, _ = cmd.Flags().GetString("model")
+	if cmd.Flags().Changed("temperature") {
+		v, _ := cmd.Flags().GetFloat32("temperature")
+		o.temperature = &v
+	}
+	if cmd.Flags().Changed("top-p") {
+		v, _ := cmd.Flags().GetFloat32("top-p")
+		o.topP = &v
+	}
+	if cmd.Flags().Changed("max-tokens") {
+		v, _ := cmd.Flags().GetInt("max-tokens")
+		o.maxTokens = &v
+	}
+	if cmd.Flags().Changed("seed") {
+		v, _ := cmd.Flags().GetInt("seed")
+		o.seed = &v
+	}
+	return o
+}
+
+// applyLLMOverrides replaces the runner's LLM client when any override flag is set.
+// Without them the configured client is kept.
+func (r *createCmdRunner) applyLLMOverrides(cmd *cobra.Command) error {
+	o := llmOverridesFromFlags(cmd)
+	if o.isZero() {
+		return nil
+	}
+	if r.llmFactory == nil {
+		return fmt.Errorf("LLM overrides are not supported by this command runner")
+	}
+
+	client, err := r.llmFactory(o)
+	if err != nil {
+		fmt.Fprintln(cmd.ErrOrStderr(), i18n.T(i18n.MsgLLMOverrideError))
+		return err
+	}
+	Log.Info().Str("provider", o.provider).Str("model", o.model).Msg("Using LLM override for this invocation")
+	r.llmClient = client
+	return nil
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/llm"
+)
+
+func newLLMOverrideTestCmd(t *testing.T, flags map[string]string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "test"}
+	addLLMOverrideFlags(cmd)
+	for k, v := range flags {
+		require.NoError(t, cmd.Flags().Set(k, v))
+	}
+	return cmd
+}
+
+func TestApplyLLMOverrides(t *testing.T) {
+	Log = zerolog.Nop()
+	configured := new(MockLLMClient)
+	override := new(MockLLMClient)
+
+	t.Run("No flags keeps configured client", func(t *testing.T) {
+		runner := &createCmdRunner{llmClient: configured, llmFactory: func(llmOverrides) (llm.Client, error) {
+			t.Fatal("factory should not be called without overrides")
+			return nil, nil
+		}}
+		require.NoError(t, runner.applyLLMOverrides(newLLMOverrideTestCmd(t, nil)))
+		assert.Same(t, configured, runner.llmClient)
+	})
+
+	t.Run("Model and provider are passed to the factory", func(t *testing.T) {
+		var got llmOverrides
+		runner := &createCmdRunner{llmClient: configured, llmFactory: func(o llmOverrides) (llm.Client, error) {
+			got = o
+			return override, nil
+		}}
+		cmd := newLLMOverrideTestCmd(t, map[string]string{"provider": "openai", "model": "gpt-4o-mini"})
+		require.NoError(t, runner.applyLLMOverrides(cmd))
+		assert.Equal(t, llmOverrides{provider: "openai", model: "gpt-4o-mini"}, got)
+		assert.Same(t, override, runner.llmClient)
+	})
+
+	t.Run("Generation parameters are passed when given", func(t *testing.T) {
+		var got llmOverrides
+		runner := &createCmdRunner{llmClient: configured, llmFactory: func(o llmOverrides) (llm.Client, error) {
+			got = o
+			return override, nil
+		}}
+		cmd := newLLMOverrideTestCmd(t, map[string]string{"temperature": "0", "seed": "7"})
+		require.NoError(t, runner.applyLLMOverrides(cmd))
+		require.NotNil(t, got.temperature, "an explicit zero temperature is an override")
+		assert.Equal(t, float32(0), *got.temperature)
+		require.NotNil(t, got.seed)
+		assert.Equal(t, 7, *got.seed)
+		assert.Nil(t, got.topP)
+		assert.Nil(t, got.maxTokens)
+		assert.Same(t, override, runner.llmClient)
+	})
+
+	t.Run("Factory error is returned", func(t *testing.T) {
+		factoryErr := errors.New("unsupported LLM provider")
+		runner := &createCmdRunner{llmClient: configured, llmFactory: func(llmOverrides) (llm.Client, error) {
+			return nil, factoryErr
+		}}
+		err := runner.applyLLMOverrides(newLLMOverrideTestCmd(t, map[string]string{"provider": "bogus"}))
+		assert.ErrorIs(t, err, factoryErr)
+		assert.Same(t, configured, runner.llmClient)
+	})
+}
+
+func TestLLMOverrides_Apply(t *testing.T) {
+	temperature, maxTokens := float32(0.3), 500
+	cfg := config.LLMConfig{Provider: "openai", OpenAI: config.OpenAIConfig{ModelName: "gpt-4o", MaxTokens: 100}}
+
+	llmOverrides{model: "gpt-4o-mini", temperature: &temperature, maxTokens: &maxTokens}.apply(&cfg)
+	assert.Equal(t, "openai", cfg.Provider)
+	assert.Equal(t, "gpt-4o-mini", cfg.OpenAI.ModelName)
+	assert.Equal(t, &temperature, cfg.OpenAI.Temperature)
+	assert.Equal(t, 500, cfg.OpenAI.MaxTokens)
+	assert.Nil(t, cfg.OpenAI.Seed)
+}
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/clierr"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/mcpserver"
+)
+
+// Input schemas for the tools exposed by mcp-serve.
+const (
+	createTicketToolSchema = `{
+  "type": "object",
+  "properties": {
+    "input": {"type": "string", "description": "Natural language description of the ticket to create"},
+    "issue_type": {"type": "string", "description": "Optional JIRA issue type overriding defaults (e.g. Bug, Task)"},
+    "dry_run": {"type": "boolean", "description": "Only generate the ticket details, do not create the issue"}
+  },
+  "required": ["input"]
+}`
+	searchIssuesToolSchema = `{
+  "type": "object",
+  "properties": {
+    "jql": {"type": "string", "description": "JQL query string"},
+    "max_results": {"type": "integer", "description": "Maximum number of results to return"}
+  },
+  "required": ["jql"]
+}`
+)
+
+// createTicketArgs are the arguments accepted by the create_ticket tool.
+type createTicketArgs struct {
+	Input     string `json:"input"`
+	IssueType string `json:"issue_type"`
+	DryRun    bool   `json:"dry_run"`
+}
+
+// searchIssuesArgs are the arguments accepted by the search_issues tool.
+type searchIssuesArgs struct {
+	JQL        string `json:"jql"`
+	MaxResults int    `json:"max_results"`
+}
+
+// newTicketronMCPServer builds an MCP server exposing ticket creation and search as tools,
+// backed by the same runner used by the create command.
+func newTicketronMCPServer(runner *createCmdRunner) (*mcpserver.Server, error) {
+	server := mcpserver.New("ticketron", version)
+
+	err := server.RegisterTool(mcpserver.Tool{
+		Name:        "create_ticket",
+		Description: "Create a JIRA issue from a natural language description. The LLM generates the summary and description and picks the project from the configured links.",
+		InputSchema: json.RawMessage(createTicketToolSchema),
+		Handler: func(ctx context.Context, raw json.RawMessage) (string, error) {
+			var args createTicketArgs
+			if err := json.Unmarshal(raw, &args); err != nil {
+				return "", fmt.Errorf("invalid arguments: %w", err)
+			}
+			return mcpCreateTicket(ctx, runner, args)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = server.RegisterTool(mcpserver.Tool{
+		Name:        "search_issues",
+		Description: "Search JIRA issues using a JQL query.",
+		InputSchema: json.RawMessage(searchIssuesToolSchema),
+		Handler: func(ctx context.Context, raw json.RawMessage) (string, error) {
+			var args searchIssuesArgs
+			if err := json.Unmarshal(raw, &args); err != nil {
+				return "", fmt.Errorf("invalid arguments: %w", err)
+			}
+			return mcpSearchIssues(ctx, runner.mcpClient, args)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return server, nil
+}
+
+// mcpCreateTicket implements the create_ticket tool. User-facing hints produced by the
+// create workflow are appended to the returned error so the calling assistant can relay them.
+func mcpCreateTicket(ctx context.Context, runner *createCmdRunner, args createTicketArgs) (string, error) {
+	if strings.TrimSpace(args.Input) == "" {
+		return "", errors.New("input is required")
+	}
+
+	loadedCfgs, err := loadAllConfigs(runner.configProvider)
+	if err != nil {
+		return "", withHints(err, "")
+	}
+
+	var hints bytes.Buffer
+	request, err := runner.buildIssueRequest(ctx, &hints, loadedCfgs, args.Input, issueRequestOptions{issueType: args.IssueType})
+	if err != nil {
+		return "", withHints(err, hints.String())
+	}
+
+	if args.DryRun {
+		Log.Info().Str("project_key", request.ProjectKey).Msg("Dry run requested via MCP, not creating issue")
+		return marshalToolResult(request)
+	}
+
+	if runner.mcpClient == nil {
+		return "", errMCPClientNotInitialized
+	}
+	request, resp, err := runner.submitIssue(ctx, request)
+	if err != nil {
+		return "", fmt.Errorf("failed to create issue: %w", err)
+	}
+	Log.Info().Str("issue_key", resp.Key).Msg("Created JIRA issue via MCP tool call")
+	runner.recordHistory("mcp", args.Input, loadedCfgs.systemPrompt, request, resp)
+	return marshalToolResult(resp)
+}
+
+// mcpSearchIssues implements the search_issues tool.
+func mcpSearchIssues(ctx context.Context, mcpClient MCPClient, args searchIssuesArgs) (string, error) {
+	if strings.TrimSpace(args.JQL) == "" {
+		return "", errors.New("jql is required")
+	}
+	if mcpClient == nil {
+		return "", errMCPClientNotInitialized
+	}
+	if args.MaxResults <= 0 {
+		args.MaxResults = 20 // Same default as the search command
+	}
+	resp, err := mcpClient.SearchIssues(ctx, mcpclient.SearchIssuesRequest{JQL: args.JQL, MaxResults: args.MaxResults})
+	if err != nil {
+		return "", fmt.Errorf("failed to search issues: %w", err)
+	}
+	return marshalToolResult(resp)
+}
+
+// withHints appends the warnings captured from buildIssueRequest and the message and hint
+// of err, for callers that report errors as text rather than through Execute.
+func withHints(err error, hints string) error {
+	var rendered strings.Builder
+	rendered.WriteString(hints)
+	clierr.Render(&rendered, err)
+	hints = strings.TrimSpace(rendered.String())
+	if hints == "" {
+		return err
+	}
+	return fmt.Errorf("%w\n%s", err, hints)
+}
+
+// marshalToolResult renders v as indented JSON for a tool result.
+func marshalToolResult(v interface{}) (string, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to format result as JSON: %w", err)
+	}
+	return string(data), nil
+}
+
+// mcpServeCmd represents the mcp-serve command
+var mcpServeCmd = &cobra.Command{
+	Use:   "mcp-serve",
+	Short: "Run Ticketron as an MCP server over stdio",
+	Long: `Exposes Ticketron's capabilities (creating tickets from natural language,
+searching issues) as Model Context Protocol tools over stdin/stdout, so AI
+assistants such as Claude Desktop can call Ticketron directly.
+
+Example Claude Desktop configuration:
+
+  "mcpServers": {
+    "ticketron": {"command": "tix", "args": ["mcp-serve"]}
+  }
+
+Logs are written to stderr; stdout is reserved for the protocol.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		runner, err := newCreateCmdRunner()
+		if err != nil {
+			return err
+		}
+		if err := runner.applyLLMOverrides(cmd); err != nil {
+			return err
+		}
+		server, err := newTicketronMCPServer(runner)
+		if err != nil {
+			return fmt.Errorf("failed to set up MCP server: %w", err)
+		}
+		Log.Info().Msg("Starting Ticketron MCP server on stdio")
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return server.Serve(ctx, cmd.InOrStdin(), cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	addLLMOverrideFlags(mcpServeCmd)
+
+	rootCmd.AddCommand(mcpServeCmd)
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/llm"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+// mcpServeTestContext is the LLM context of newMCPServeTestRunner: the empty context.md plus
+// the links.yaml aliases.
+var mcpServeTestContext = llm.WithProjectAliases("", []llm.ProjectAlias{{Name: "Backend", Key: "BE", DefaultIssueType: "Bug"}})
+
+// newMCPServeTestRunner builds a create runner with mocked configuration and LLM output.
+func newMCPServeTestRunner(mockMCP *MockMCPClient) (*createCmdRunner, *MockLLMClient) {
+	mockProvider := new(MockConfigProvider)
+	mockLLM := new(MockLLMClient)
+	links := &config.LinksConfig{Projects: []config.ProjectLink{{Name: "Backend", Key: "BE", DefaultIssueType: "Bug"}}}
+
+	mockProvider.On("LoadConfig").Return(&config.AppConfig{MCPServerURL: "http://mcp.example.com"}, nil)
+	mockProvider.On("LoadLinks").Return(links, nil)
+	mockProvider.On("LoadSystemPrompt").Return("prompt", nil)
+	mockProvider.On("LoadContext").Return("", nil)
+
+	var mcp MCPClient
+	if mockMCP != nil {
+		// Without create metadata, requests are sent without local validation
+		mockMCP.On("GetCreateMeta", mock.Anything, mock.Anything).Return(nil, mcpclient.ErrMCPServerError).Maybe()
+		mcp = mockMCP
+	}
+	runner := NewCreateCmdRunnerForTest(mockProvider, mockLLM, mcp, &DefaultProjectMapper{}, &DefaultIssueTypeResolver{})
+	return runner, mockLLM
+}
+
+func TestMCPCreateTicket_DryRun(t *testing.T) {
+	Log = zerolog.Nop()
+	runner, mockLLM := newMCPServeTestRunner(nil)
+	mockLLM.On("GenerateTicketDetails", mock.Anything, "login broken", "prompt", mcpServeTestContext).
+		Return(llm.LLMResponse{Summary: "Fix login", Description: "Details", ProjectNameSuggestion: "backend"}, nil)
+
+	out, err := mcpCreateTicket(context.Background(), runner, createTicketArgs{Input: "login broken", DryRun: true})
+	require.NoError(t, err)
+
+	var req mcpclient.CreateIssueRequest
+	require.NoError(t, json.Unmarshal([]byte(out), &req))
+	assert.Equal(t, mcpclient.CreateIssueRequest{ProjectKey: "BE", Summary: "Fix login", Description: "Details", IssueType: "Bug"}, req)
+}
+
+func TestMCPCreateTicket_Create(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	runner, mockLLM := newMCPServeTestRunner(mockMCP)
+	mockLLM.On("GenerateTicketDetails", mock.Anything, "add metrics", "prompt", mcpServeTestContext).
+		Return(llm.LLMResponse{Summary: "Add metrics", ProjectNameSuggestion: "Backend"}, nil)
+	mockMCP.On("CreateIssue", mock.Anything, mcpclient.CreateIssueRequest{ProjectKey: "BE", Summary: "Add metrics", IssueType: "Task", IdempotencyKey: idempotencyKey("add metrics", "BE")}).
+		Return(&mcpclient.CreateIssueResponse{Key: "BE-7"}, nil)
+
+	out, err := mcpCreateTicket(context.Background(), runner, createTicketArgs{Input: "add metrics", IssueType: "Task"})
+	require.NoError(t, err)
+	assert.Contains(t, out, `"key": "BE-7"`)
+	mockMCP.AssertExpectations(t)
+}
+
+func TestMCPCreateTicket_MappingFailureIncludesHints(t *testing.T) {
+	Log = zerolog.Nop()
+	runner, mockLLM := newMCPServeTestRunner(nil)
+	mockLLM.On("GenerateTicketDetails", mock.Anything, "x", "prompt", mcpServeTestContext).
+		Return(llm.LLMResponse{Summary: "X", ProjectNameSuggestion: "unknown"}, nil)
+
+	_, err := mcpCreateTicket(context.Background(), runner, createTicketArgs{Input: "x"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, config.ErrProjectMappingFailed)
+	assert.Contains(t, err.Error(), "Could not map LLM's project suggestion 'unknown'")
+}
+
+func TestMCPSearchIssues(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("SearchIssues", mock.Anything, mcpclient.SearchIssuesRequest{JQL: "project = BE", MaxResults: 20}).
+		Return(&mcpclient.SearchIssuesResponse{Total: 1, Issues: []mcpclient.Issue{{Key: "BE-1"}}}, nil)
+
+	out, err := mcpSearchIssues(context.Background(), mockMCP, searchIssuesArgs{JQL: "project = BE"})
+	require.NoError(t, err)
+	assert.Contains(t, out, `"key": "BE-1"`)
+
+	_, err = mcpSearchIssues(context.Background(), mockMCP, searchIssuesArgs{})
+	assert.EqualError(t, err, "jql is required")
+
+	failing := new(MockMCPClient)
+	failing.On("SearchIssues", mock.Anything, mock.Anything).Return(nil, errors.New("boom"))
+	_, err = mcpSearchIssues(context.Background(), failing, searchIssuesArgs{JQL: "x"})
+	assert.ErrorContains(t, err, "boom")
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+// mentionRe matches "@handle" mentions. The character before the @ must not be part of a
+// word, so email addresses are left alone, and trailing punctuation is not part of the handle.
+var mentionRe = regexp.MustCompile(`(^|[^\w@.~\[])@([A-Za-z0-9](?:[A-Za-z0-9._-]*[A-Za-z0-9])?)`)
+
+// mentionSearchLimit is the number of users fetched to resolve one mention.
+const mentionSearchLimit = 10
+
+// completionTimeout bounds the server calls made for shell completion.
+const completionTimeout = 3 * time.Second
+
+// jiraMention returns the Jira wiki markup referencing an account.
+func jiraMention(accountID string) string {
+	return "[~accountid:" + accountID + "]"
+}
+
+// normalizeHandle lowercases s and drops everything but letters and digits, so that
+// "alice.smith" and "Alice Smith" compare equal.
+func normalizeHandle(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// matchMentionUser picks the user a handle refers to: the only user whose email name or
+// display name matches the handle. It returns nil if the handle is ambiguous or matches
+// no user exactly, even if the search found a single, fuzzy match.
+func matchMentionUser(handle string, users []mcpclient.User) *mcpclient.User {
+	want := normalizeHandle(handle)
+	var exact []*mcpclient.User
+	for i := range users {
+		user := &users[i]
+		if user.AccountID == "" {
+			continue
+		}
+		emailName, _, _ := strings.Cut(user.EmailAddress, "@")
+		if (emailName != "" && normalizeHandle(emailName) == want) || normalizeHandle(user.DisplayName) == want {
+			exact = append(exact, user)
+		}
+	}
+	if len(exact) == 1 {
+		return exact[0]
+	}
+	return nil
+}
+
+// expandMentions replaces "@handle" mentions in text with Jira account references, looking
+// each handle up once with SearchUsers. Mentions inside code blocks and code spans, and
+// handles that are unknown or ambiguous, are left unchanged.
+func expandMentions(ctx context.Context, mcpClient MCPClient, text string) string {
+	if mcpClient == nil || !strings.Contains(text, "@") {
+		return text
+	}
+	resolved := make(map[string]string)
+	resolve := func(handle string) string {
+		key := strings.ToLower(handle)
+		if ref, ok := resolved[key]; ok {
+			return ref
+		}
+		ref := ""
+		users, err := mcpClient.SearchUsers(ctx, handle, mentionSearchLimit)
+		if err != nil {
+			Log.Debug().Err(err).Str("handle", handle).Msg("User search failed, leaving mention unchanged")
+		} else if user := matchMentionUser(handle, users); user != nil {
+			ref = jiraMention(user.AccountID)
+		} else {
+			Log.Debug().Str("handle", handle).Int("matches", len(users)).Msg("Mention is unknown or ambiguous, leaving it unchanged")
+		}
+		resolved[key] = ref
+		return ref
+	}
+
+	return mapOutsideCode(text, func(segment string) string {
+		return mentionRe.ReplaceAllStringFunc(segment, func(match string) string {
+			m := mentionRe.FindStringSubmatch(match)
+			if ref := resolve(m[2]); ref != "" {
+				return m[1] + ref
+			}
+			return match
+		})
+	})
+}
+
+// mapOutsideCode returns Markdown text with fn applied to every part of each line that is
+// not inside a fenced code block or a code span.
+func mapOutsideCode(text string, fn func(segment string) string) string {
+	lines := strings.Split(text, "\n")
+	inFence := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		// Odd segments between backticks are code spans
+		segments := strings.Split(line, "`")
+		for j := 0; j < len(segments); j += 2 {
+			segments[j] = fn(segments[j])
+		}
+		lines[i] = strings.Join(segments, "`")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// completeAssignees completes --assignee values with 'me', 'none' and the account IDs of
+// matching users. Values are comma-separated, so only the last one is completed.
+func completeAssignees(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	prefix, current := "", toComplete
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		prefix, current = toComplete[:i+1], toComplete[i+1:]
+	}
+
+	var completions []string
+	for _, keyword := range []string{"me\tYourself", "none\tUnassigned issues"} {
+		if strings.HasPrefix(keyword, strings.ToLower(current)) {
+			completions = append(completions, prefix+keyword)
+		}
+	}
+	if current != "" {
+		if mcpClient, err := newCommandMCPClient(); err == nil {
+			ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+			defer cancel()
+			users, err := mcpClient.SearchUsers(ctx, current, mentionSearchLimit)
+			if err != nil {
+				Log.Debug().Err(err).Msg("User search failed during completion")
+			}
+			completions = append(completions, userCompletions(prefix, users)...)
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// userCompletions formats users as "accountId<TAB>Display Name <email>" completions.
+func userCompletions(prefix string, users []mcpclient.User) []string {
+	var completions []string
+	for _, user := range users {
+		if user.AccountID == "" {
+			continue
+		}
+		description := user.DisplayName
+		if user.EmailAddress != "" {
+			description += " <" + user.EmailAddress + ">"
+		}
+		completions = append(completions, prefix+user.AccountID+"\t"+strings.TrimSpace(description))
+	}
+	return completions
+}
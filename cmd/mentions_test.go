@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+func TestMatchMentionUser(t *testing.T) {
+	alice := mcpclient.User{AccountID: "a1", DisplayName: "Alice Smith", EmailAddress: "alice@example.com"}
+	alicia := mcpclient.User{AccountID: "a2", DisplayName: "Alicia Keys", EmailAddress: "akeys@example.com"}
+
+	assert.Equal(t, &alice, matchMentionUser("alice", []mcpclient.User{alice, alicia}), "email name matches exactly")
+	assert.Equal(t, "a1", matchMentionUser("alice.smith", []mcpclient.User{alicia, alice}).AccountID, "display name matches exactly")
+	assert.Equal(t, "a2", matchMentionUser("akeys", []mcpclient.User{alicia}).AccountID)
+	assert.Nil(t, matchMentionUser("keys", []mcpclient.User{alicia}), "a single fuzzy result is not used")
+	assert.Nil(t, matchMentionUser("ali", []mcpclient.User{alice, alicia}), "ambiguous handles are not expanded")
+	assert.Nil(t, matchMentionUser("bob", nil))
+	assert.Nil(t, matchMentionUser("bot", []mcpclient.User{{DisplayName: "Bot"}}), "users without an account ID are skipped")
+}
+
+func TestExpandMentions(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("SearchUsers", mock.Anything, "alice", mentionSearchLimit).
+		Return([]mcpclient.User{{AccountID: "a1", DisplayName: "Alice Smith", EmailAddress: "alice@example.com"}}, nil).Once()
+	mockMCP.On("SearchUsers", mock.Anything, "nobody", mentionSearchLimit).Return([]mcpclient.User{}, nil).Once()
+	mockMCP.On("SearchUsers", mock.Anything, "bob", mentionSearchLimit).Return(nil, mcpclient.ErrMCPServerError).Once()
+
+	text := "Assigned to @alice, cc @Alice and @nobody.\n" +
+		"Mail alice@example.com or ask @bob.\n" +
+		"Use `@alice` in code.\n" +
+		"```\n@Override\n```"
+	want := "Assigned to [~accountid:a1], cc [~accountid:a1] and @nobody.\n" +
+		"Mail alice@example.com or ask @bob.\n" +
+		"Use `@alice` in code.\n" +
+		"```\n@Override\n```"
+	assert.Equal(t, want, expandMentions(context.Background(), mockMCP, text))
+	mockMCP.AssertExpectations(t) // Each handle is looked up once
+
+	assert.Equal(t, "no mentions", expandMentions(context.Background(), nil, "no mentions"))
+}
+
+func TestUserCompletions(t *testing.T) {
+	users := []mcpclient.User{
+		{AccountID: "a1", DisplayName: "Alice Smith", EmailAddress: "alice@example.com"},
+		{AccountID: "a2", DisplayName: "Alicia Keys"},
+		{DisplayName: "No Account"},
+	}
+	assert.Equal(t, []string{"me,a1\tAlice Smith <alice@example.com>", "me,a2\tAlicia Keys"}, userCompletions("me,", users))
+}
@@ -31,4 +31,21 @@ func (m *MockLLMClient) GenerateTicketDetails(ctx context.Context, userInput, sy
 	return resp, args.Error(1) // Return potentially zero struct and error
 }
 
+// GenerateFromMessages matches llm.Client interface
+func (m *MockLLMClient) GenerateFromMessages(ctx context.Context, messages []llm.Message) (llm.LLMResponse, error) {
+	args := m.Called(ctx, messages)
+	respArg := args.Get(0)
+	var resp llm.LLMResponse
+	if respArg != nil {
+		resp = respArg.(llm.LLMResponse)
+	}
+	return resp, args.Error(1)
+}
+
+// GenerateText matches llm.TextGenerator interface
+func (m *MockLLMClient) GenerateText(ctx context.Context, messages []llm.Message) (string, error) {
+	args := m.Called(ctx, messages)
+	return args.String(0), args.Error(1)
+}
+
 // Add other shared mocks here if needed later.
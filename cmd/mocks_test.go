@@ -9,6 +9,7 @@ import (
 	"github.com/karolswdev/ticketron/internal/config" // Correct path
 	// Added llm import
 	"github.com/karolswdev/ticketron/internal/mcpclient" // Correct path
+	"github.com/karolswdev/ticketron/internal/secrets"
 )
 
 // --- Mock ConfigLoader ---
@@ -61,6 +62,12 @@ func (m *MockConfigProvider) CreateDefaultConfigFiles(configDir string) error {
 	return args.Error(0)
 }
 
+// InitConfigFiles matches ConfigProvider interface
+func (m *MockConfigProvider) InitConfigFiles(opts config.InitOptions) error {
+	args := m.Called(opts)
+	return args.Error(0)
+}
+
 // EnsureConfigDir matches ConfigProvider interface
 func (m *MockConfigProvider) EnsureConfigDir() (string, error) {
 	args := m.Called()
@@ -87,6 +94,72 @@ func (m *MockMCPClient) SearchIssues(ctx context.Context, req mcpclient.SearchIs
 	return resp, args.Error(1)
 }
 
+// AddComment matches MCPClient interface
+func (m *MockMCPClient) AddComment(ctx context.Context, issueKey string, req mcpclient.AddCommentRequest) (*mcpclient.Comment, error) {
+	args := m.Called(ctx, issueKey, req)
+	resp, _ := args.Get(0).(*mcpclient.Comment)
+	return resp, args.Error(1)
+}
+
+// DeleteIssue matches MCPClient interface
+func (m *MockMCPClient) DeleteIssue(ctx context.Context, issueKey string) error {
+	args := m.Called(ctx, issueKey)
+	return args.Error(0)
+}
+
+// TransitionIssue matches MCPClient interface
+func (m *MockMCPClient) TransitionIssue(ctx context.Context, issueKey string, req mcpclient.TransitionIssueRequest) error {
+	args := m.Called(ctx, issueKey, req)
+	return args.Error(0)
+}
+
+// UpdateIssue matches MCPClient interface
+func (m *MockMCPClient) UpdateIssue(ctx context.Context, issueKey string, req mcpclient.UpdateIssueRequest) error {
+	args := m.Called(ctx, issueKey, req)
+	return args.Error(0)
+}
+
+// GetIssue matches MCPClient interface
+func (m *MockMCPClient) GetIssue(ctx context.Context, issueKey string) (*mcpclient.Issue, error) {
+	args := m.Called(ctx, issueKey)
+	resp, _ := args.Get(0).(*mcpclient.Issue)
+	return resp, args.Error(1)
+}
+
+// GetComments matches MCPClient interface
+func (m *MockMCPClient) GetComments(ctx context.Context, issueKey string, req mcpclient.GetCommentsRequest) (*mcpclient.CommentsResponse, error) {
+	args := m.Called(ctx, issueKey, req)
+	resp, _ := args.Get(0).(*mcpclient.CommentsResponse)
+	return resp, args.Error(1)
+}
+
+// GetIssueChangelog matches MCPClient interface
+func (m *MockMCPClient) GetIssueChangelog(ctx context.Context, issueKey string, req mcpclient.GetChangelogRequest) (*mcpclient.ChangelogResponse, error) {
+	args := m.Called(ctx, issueKey, req)
+	resp, _ := args.Get(0).(*mcpclient.ChangelogResponse)
+	return resp, args.Error(1)
+}
+
+// GetCreateMeta matches MCPClient interface
+func (m *MockMCPClient) GetCreateMeta(ctx context.Context, projectKey string) (*mcpclient.CreateMeta, error) {
+	args := m.Called(ctx, projectKey)
+	resp, _ := args.Get(0).(*mcpclient.CreateMeta)
+	return resp, args.Error(1)
+}
+
+// SearchUsers matches MCPClient interface
+func (m *MockMCPClient) SearchUsers(ctx context.Context, query string, maxResults int) ([]mcpclient.User, error) {
+	args := m.Called(ctx, query, maxResults)
+	users, _ := args.Get(0).([]mcpclient.User)
+	return users, args.Error(1)
+}
+
+// LinkIssues matches MCPClient interface
+func (m *MockMCPClient) LinkIssues(ctx context.Context, req mcpclient.LinkIssuesRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
 // MockLLMClient moved to mocks.go
 
 // --- Mock KeyringClient ---
@@ -107,4 +180,10 @@ func (m *MockKeyringClient) GetAPIKey(service, user string) (string, error) {
 	return args.String(0), args.Error(1)
 }
 
+// Status matches KeyringClient interface
+func (m *MockKeyringClient) Status() secrets.Status {
+	args := m.Called()
+	return args.Get(0).(secrets.Status)
+}
+
 // Removed Delete as it's not in the current interface definition
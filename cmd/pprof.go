@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"path/filepath"
+	"runtime"
+	runtimepprof "runtime/pprof"
+	"strconv"
+	"time"
+)
+
+// pprofFlagUsage describes the hidden persistent --pprof flag.
+const pprofFlagUsage = "Profile the command: serve net/http/pprof on ADDR (e.g. localhost:6060; an empty host means localhost), or write cpu.pprof and heap.pprof to DIR"
+
+// Names of the profiles written by --pprof DIR.
+const (
+	cpuProfileName  = "cpu.pprof"
+	heapProfileName = "heap.pprof"
+)
+
+// stopProfiling ends the profiling started by --pprof; Execute calls it once the command
+// has run.
+var stopProfiling = func() {}
+
+// startProfiling starts profiling for the --pprof target: an address such as localhost:6060
+// serves the net/http/pprof endpoints there while the command runs, anything else is a
+// directory the CPU profile of the command and a heap profile at its end are written to.
+func startProfiling(target string) (stop func(), err error) {
+	if isListenAddress(target) {
+		return serveProfiles(profileAddress(target))
+	}
+	return writeProfiles(target)
+}
+
+// isListenAddress reports whether target is a host:port address rather than a directory.
+func isListenAddress(target string) bool {
+	_, port, err := net.SplitHostPort(target)
+	if err != nil {
+		return false
+	}
+	_, err = strconv.ParseUint(port, 10, 16)
+	return err == nil
+}
+
+// profileAddress returns addr with an empty host replaced by localhost, so --pprof :6060
+// does not expose the unauthenticated endpoints on every interface.
+func profileAddress(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil || host != "" {
+		return addr
+	}
+	return net.JoinHostPort("localhost", port)
+}
+
+// serveProfiles serves the net/http/pprof endpoints on addr until stop is called.
+func serveProfiles(addr string) (stop func(), err error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serve profiles: %w", err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			Log.Warn().Err(err).Msg("Profiling server failed")
+		}
+	}()
+	Log.Info().Str("url", "http://"+listener.Addr().String()+"/debug/pprof/").Msg("Serving profiles")
+	return func() { _ = server.Close() }, nil
+}
+
+// writeProfiles starts the CPU profile in dir; stop ends it and writes the heap profile.
+func writeProfiles(dir string) (stop func(), err error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create profile directory: %w", err)
+	}
+	cpuPath := filepath.Join(dir, cpuProfileName)
+	cpuFile, err := os.Create(cpuPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CPU profile: %w", err)
+	}
+	if err := runtimepprof.StartCPUProfile(cpuFile); err != nil {
+		cpuFile.Close()
+		return nil, fmt.Errorf("failed to start CPU profile: %w", err)
+	}
+	return func() {
+		runtimepprof.StopCPUProfile()
+		if err := cpuFile.Close(); err != nil {
+			Log.Warn().Err(err).Str("path", cpuPath).Msg("Failed to write CPU profile")
+		}
+		heapPath := filepath.Join(dir, heapProfileName)
+		if err := writeHeapProfile(heapPath); err != nil {
+			Log.Warn().Err(err).Str("path", heapPath).Msg("Failed to write heap profile")
+		}
+		Log.Info().Str("cpu", cpuPath).Str("heap", heapPath).Msg("Profiles written")
+	}, nil
+}
+
+// writeHeapProfile writes a heap profile of the live objects to path.
+func writeHeapProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	runtime.GC() // Up-to-date statistics of the live objects
+	if err := runtimepprof.WriteHeapProfile(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsListenAddress(t *testing.T) {
+	assert.True(t, isListenAddress("localhost:6060"))
+	assert.True(t, isListenAddress(":6060"))
+	assert.True(t, isListenAddress("[::1]:6060"))
+	assert.False(t, isListenAddress("profiles"))
+	assert.False(t, isListenAddress("/tmp/tix-profiles"))
+	assert.False(t, isListenAddress(`C:\profiles`))
+	assert.False(t, isListenAddress("host:http"))
+}
+
+func TestProfileAddress(t *testing.T) {
+	assert.Equal(t, "localhost:6060", profileAddress(":6060"))
+	assert.Equal(t, "127.0.0.1:6060", profileAddress("127.0.0.1:6060"))
+	assert.Equal(t, "[::1]:6060", profileAddress("[::1]:6060"))
+	assert.Equal(t, "0.0.0.0:6060", profileAddress("0.0.0.0:6060"), "an explicit host is kept")
+}
+
+func TestStartProfiling_Directory(t *testing.T) {
+	Log = zerolog.Nop()
+	dir := filepath.Join(t.TempDir(), "profiles")
+	stop, err := startProfiling(dir)
+	require.NoError(t, err)
+	stop()
+
+	for _, name := range []string{cpuProfileName, heapProfileName} {
+		info, err := os.Stat(filepath.Join(dir, name))
+		require.NoError(t, err, name)
+		assert.Positive(t, info.Size(), name)
+	}
+}
+
+func TestStartProfiling_Address(t *testing.T) {
+	Log = zerolog.Nop()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	stop, err := startProfiling(addr)
+	require.NoError(t, err)
+	defer stop()
+	resp, err := http.Get("http://" + addr + "/debug/pprof/cmdline")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.NotEmpty(t, body)
+
+	_, err = startProfiling(addr)
+	assert.ErrorContains(t, err, "failed to serve profiles", "the address is in use")
+}
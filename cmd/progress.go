@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/output"
+	"github.com/karolswdev/ticketron/internal/progress"
+)
+
+// newSpinner returns the progress indicator for long operations of cmd. It is drawn on
+// stderr only when that is a terminal, and never with --plain, in CI mode, with -o other
+// than text or with debug logging, whose lines would be interleaved with it. The returned
+// spinner may be nil, which shows nothing.
+func newSpinner(cmd *cobra.Command) *progress.Spinner {
+	outputFormat, _ := cmd.Flags().GetString("output")
+	enabled := !plainOutput(cmd) && !ciEnabled &&
+		output.Normalize(outputFormat) == output.FormatText &&
+		zerolog.GlobalLevel() > zerolog.DebugLevel &&
+		isCharDevice(cmd.ErrOrStderr())
+	return progress.New(cmd.ErrOrStderr(), enabled)
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSpinner_Disabled(t *testing.T) {
+	for name, args := range map[string][]string{
+		"not a terminal": nil,
+		"plain":          {"--plain"},
+		"json":           {"-o", "json"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().Bool("plain", false, "")
+			cmd.Flags().StringP("output", "o", "text", "")
+			cmd.SetErr(&bytes.Buffer{})
+			assert.NoError(t, cmd.ParseFlags(args))
+
+			assert.Nil(t, newSpinner(cmd))
+		})
+	}
+}
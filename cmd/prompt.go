@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// promptCmd represents the prompt command group
+var promptCmd = &cobra.Command{
+	Use:   "prompt",
+	Short: "Work with LLM system prompts",
+	Long: `Provides commands for iterating on the system prompt used to generate tickets.
+This command itself does not perform any action but serves as a parent for subcommands.`,
+	// No Run function needed for a parent command
+}
+
+func init() {
+	rootCmd.AddCommand(promptCmd)
+}
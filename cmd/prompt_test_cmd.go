@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/llm"
+	"github.com/karolswdev/ticketron/internal/sanitize"
+)
+
+// promptVariantResult is the outcome of running the input through one prompt variant.
+type promptVariantResult struct {
+	Variant     string `json:"variant"`
+	Summary     string `json:"summary,omitempty"`
+	Description string `json:"description,omitempty"`
+	Project     string `json:"project_name_suggestion,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// promptTestRunE runs userInput through each system prompt variant with the same context and
+// writes the parsed results side by side. No issues are created. A failing variant is reported
+// in its column rather than aborting the comparison.
+func promptTestRunE(ctx context.Context, llmClient llm.Client, contextData, userInput string, variants []string, out io.Writer, outputFormat string) error {
+	if llmClient == nil {
+		return fmt.Errorf("LLM client not initialized. Check configuration (provider, API key)")
+	}
+
+	results := make([]promptVariantResult, 0, len(variants))
+	for _, path := range variants {
+		res := promptVariantResult{Variant: filepath.Base(path)}
+		systemPrompt, err := os.ReadFile(path)
+		if err != nil {
+			res.Error = fmt.Sprintf("failed to read prompt: %v", err)
+			results = append(results, res)
+			continue
+		}
+		Log.Debug().Str("variant", path).Msg("Running prompt variant")
+		resp, err := llmClient.GenerateTicketDetails(ctx, userInput, string(systemPrompt), contextData)
+		if err != nil {
+			res.Error = err.Error()
+		} else {
+			res.Summary, res.Description, res.Project = resp.Summary, resp.Description, resp.ProjectNameSuggestion
+		}
+		results = append(results, res)
+	}
+
+	if outputFormat == "json" {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format results as JSON: %w", err)
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+	return writePromptComparison(out, results)
+}
+
+// writePromptComparison prints one column per variant and one row per field.
+// Multi-line descriptions are flattened so the columns stay aligned.
+func writePromptComparison(out io.Writer, results []promptVariantResult) error {
+	tw := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	row := func(label string, value func(promptVariantResult) string) {
+		cells := []string{label}
+		for _, r := range results {
+			cells = append(cells, value(r))
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	row("", func(r promptVariantResult) string { return r.Variant })
+	row("PROJECT", func(r promptVariantResult) string { return r.Project })
+	row("SUMMARY", func(r promptVariantResult) string { return r.Summary })
+	row("DESCRIPTION", func(r promptVariantResult) string { return truncateCell(r.Description, 60) })
+	row("ERROR", func(r promptVariantResult) string { return truncateCell(r.Error, 60) })
+	return tw.Flush()
+}
+
+// truncateCell flattens s onto a single line and shortens it to at most n columns.
+func truncateCell(s string, n int) string {
+	return sanitize.Truncate(strings.Join(strings.Fields(sanitize.Line(s)), " "), n)
+}
+
+// promptTestCmd represents the prompt test command
+var promptTestCmd = &cobra.Command{
+	Use:   "test \"input text\" --variants promptA.txt,promptB.txt",
+	Short: "Compare system prompt variants on the same input (dry-run)",
+	Long: `Runs the same input through multiple system prompts and prints the parsed
+results side by side. The configured context.md and the links.yaml projects are used for every variant and
+the configured redaction rules are applied. Nothing is sent to the MCP server, so no issues are created.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		variants, _ := cmd.Flags().GetStringSlice("variants")
+		if len(variants) < 1 {
+			return fmt.Errorf("at least one prompt variant is required (--variants a.txt,b.txt)")
+		}
+		outputFormat, _ := cmd.Flags().GetString("output")
+
+		runner, err := newCreateCmdRunner()
+		if err != nil {
+			return err
+		}
+		if err := runner.applyLLMOverrides(cmd); err != nil {
+			return err
+		}
+		loadedCfgs, err := loadAllConfigs(runner.configProvider)
+		if err != nil {
+			return err
+		}
+		userInput, _ := loadedCfgs.redactor.Redact(strings.Join(args, " "))
+		contextData, _ := loadedCfgs.redactor.Redact(loadedCfgs.contextData)
+		// Same context as tix create, so suggestions are comparable
+		contextData = llm.WithProjectAliases(contextData, projectAliases(loadedCfgs.linksConfig))
+
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return promptTestRunE(ctx, runner.llmClient, contextData, userInput, variants, cmd.OutOrStdout(), outputFormat)
+	},
+}
+
+func init() {
+	promptTestCmd.Flags().StringSlice("variants", nil, "Comma-separated list of system prompt files to compare")
+	_ = promptTestCmd.MarkFlagRequired("variants")
+	addLLMOverrideFlags(promptTestCmd)
+
+	promptCmd.AddCommand(promptTestCmd)
+}
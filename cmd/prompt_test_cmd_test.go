@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/llm"
+)
+
+func TestPromptTestRunE(t *testing.T) {
+	Log = zerolog.Nop()
+	dir := t.TempDir()
+	promptA := filepath.Join(dir, "terse.txt")
+	promptB := filepath.Join(dir, "verbose.txt")
+	require.NoError(t, os.WriteFile(promptA, []byte("Be terse."), 0600))
+	require.NoError(t, os.WriteFile(promptB, []byte("Be verbose."), 0600))
+
+	newMock := func() *MockLLMClient {
+		m := new(MockLLMClient)
+		m.On("GenerateTicketDetails", mock.Anything, "login broken", "Be terse.", "ctx").
+			Return(llm.LLMResponse{Summary: "Fix login", ProjectNameSuggestion: "Backend"}, nil)
+		m.On("GenerateTicketDetails", mock.Anything, "login broken", "Be verbose.", "ctx").
+			Return(llm.LLMResponse{}, errors.New("rate limited"))
+		return m
+	}
+
+	t.Run("JSON output", func(t *testing.T) {
+		var out bytes.Buffer
+		variants := []string{promptA, promptB, filepath.Join(dir, "missing.txt")}
+		require.NoError(t, promptTestRunE(context.Background(), newMock(), "ctx", "login broken", variants, &out, "json"))
+
+		var results []promptVariantResult
+		require.NoError(t, json.Unmarshal(out.Bytes(), &results))
+		require.Len(t, results, 3)
+		assert.Equal(t, promptVariantResult{Variant: "terse.txt", Summary: "Fix login", Project: "Backend"}, results[0])
+		assert.Equal(t, "rate limited", results[1].Error)
+		assert.Contains(t, results[2].Error, "failed to read prompt")
+	})
+
+	t.Run("Text output", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, promptTestRunE(context.Background(), newMock(), "ctx", "login broken", []string{promptA, promptB}, &out, "text"))
+		assert.Contains(t, out.String(), "terse.txt")
+		assert.Contains(t, out.String(), "verbose.txt")
+		assert.Contains(t, out.String(), "Fix login")
+	})
+
+	t.Run("Nil LLM client", func(t *testing.T) {
+		err := promptTestRunE(context.Background(), nil, "", "x", []string{promptA}, &bytes.Buffer{}, "text")
+		assert.Error(t, err)
+	})
+}
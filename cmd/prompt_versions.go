@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/i18n"
+	"github.com/karolswdev/ticketron/internal/prompts"
+	"github.com/karolswdev/ticketron/internal/textdiff"
+)
+
+// currentPromptName labels system_prompt.txt when diffing against it.
+const currentPromptName = "system_prompt.txt"
+
+// newPromptLibrary returns the prompt library of the configured config directory.
+func newPromptLibrary() (*prompts.Library, string, error) {
+	configDir, err := resolveConfigDir()
+	if err != nil {
+		return nil, "", err
+	}
+	return prompts.New(configDir), configDir, nil
+}
+
+// promptListRunE prints all stored prompt versions, marking the active one.
+func promptListRunE(lib *prompts.Library, out io.Writer) error {
+	versions, err := lib.List()
+	if err != nil {
+		return err
+	}
+	if len(versions) == 0 {
+		fmt.Fprintln(out, "No prompt versions stored. Save the current prompt with 'tix prompt save <name>'.")
+		return nil
+	}
+	for _, v := range versions {
+		marker := " "
+		if v.Active {
+			marker = "*"
+		}
+		fmt.Fprintf(out, "%s %s\n", marker, v.Name)
+	}
+	return nil
+}
+
+// promptDiffRunE prints a unified diff between two versions, or between a version and the
+// current system_prompt.txt when only one name is given.
+func promptDiffRunE(lib *prompts.Library, configDir string, args []string, out io.Writer) error {
+	from, err := lib.Load(args[0])
+	if err != nil {
+		return err
+	}
+	toName := currentPromptName
+	var to string
+	if len(args) > 1 {
+		toName = args[1]
+		if to, err = lib.Load(toName); err != nil {
+			return err
+		}
+	} else {
+		data, err := os.ReadFile(filepath.Join(configDir, config.DefaultPromptFileName))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%w: %w", config.ErrSystemPromptRead, err)
+		}
+		to = string(data)
+	}
+
+	diff := textdiff.Unified(from, to, args[0], toName, 3)
+	if diff == "" {
+		fmt.Fprintf(out, "No differences between %s and %s.\n", args[0], toName)
+		return nil
+	}
+	fmt.Fprint(out, diff)
+	return nil
+}
+
+// promptListCmd represents the prompt list command
+var promptListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List stored prompt versions",
+	Long: `Lists the system prompt versions stored in ~/.ticketron/prompts/.
+The active version (the one system_prompt.txt currently matches) is marked with '*'.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lib, _, err := newPromptLibrary()
+		if err != nil {
+			return err
+		}
+		return promptListRunE(lib, cmd.OutOrStdout())
+	},
+}
+
+// promptSaveCmd represents the prompt save command
+var promptSaveCmd = &cobra.Command{
+	Use:   "save <name>",
+	Short: "Save the current system prompt as a named version",
+	Long: `Stores the current system_prompt.txt (or the file given with --file) as
+~/.ticketron/prompts/<name>.txt, overwriting an existing version of that name.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lib, configDir, err := newPromptLibrary()
+		if err != nil {
+			return err
+		}
+		file, _ := cmd.Flags().GetString("file")
+		if file == "" {
+			file = filepath.Join(configDir, config.DefaultPromptFileName)
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read prompt file: %w", err)
+		}
+		if err := lib.Save(args[0], string(content)); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Saved prompt version %q.\n", args[0])
+		return nil
+	},
+}
+
+// promptUseCmd represents the prompt use command
+var promptUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Activate a stored prompt version",
+	Long: `Copies the named version to system_prompt.txt so it is used by all commands.
+Issues created afterwards are recorded in the history with this version name.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lib, _, err := newPromptLibrary()
+		if err != nil {
+			return err
+		}
+		if err := lib.Use(args[0]); err != nil {
+			if errors.Is(err, prompts.ErrVersionNotFound) {
+				fmt.Fprintln(cmd.ErrOrStderr(), i18n.T(i18n.MsgPromptListHint))
+			}
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Now using prompt version %q.\n", args[0])
+		return nil
+	},
+}
+
+// promptDiffCmd represents the prompt diff command
+var promptDiffCmd = &cobra.Command{
+	Use:   "diff <version> [other-version]",
+	Short: "Show differences between prompt versions",
+	Long: `Shows a unified diff between two stored prompt versions, or between a version
+and the current system_prompt.txt when only one version is given.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lib, configDir, err := newPromptLibrary()
+		if err != nil {
+			return err
+		}
+		return promptDiffRunE(lib, configDir, args, cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	promptSaveCmd.Flags().String("file", "", "Prompt file to save instead of the current system_prompt.txt")
+
+	promptCmd.AddCommand(promptListCmd)
+	promptCmd.AddCommand(promptSaveCmd)
+	promptCmd.AddCommand(promptUseCmd)
+	promptCmd.AddCommand(promptDiffCmd)
+}
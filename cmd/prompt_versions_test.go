@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/history"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/prompts"
+)
+
+func TestPromptListAndDiff(t *testing.T) {
+	Log = zerolog.Nop()
+	dir := t.TempDir()
+	lib := prompts.New(dir)
+
+	var out bytes.Buffer
+	require.NoError(t, promptListRunE(lib, &out))
+	assert.Contains(t, out.String(), "No prompt versions stored")
+
+	require.NoError(t, lib.Save("v1", "Line one\nLine two\n"))
+	require.NoError(t, lib.Save("v2", "Line one\nLine 2\n"))
+	require.NoError(t, lib.Use("v1"))
+
+	out.Reset()
+	require.NoError(t, promptListRunE(lib, &out))
+	assert.Equal(t, "* v1\n  v2\n", out.String())
+
+	out.Reset()
+	require.NoError(t, promptDiffRunE(lib, dir, []string{"v1", "v2"}, &out))
+	assert.Contains(t, out.String(), "-Line two\n+Line 2\n")
+
+	out.Reset()
+	require.NoError(t, promptDiffRunE(lib, dir, []string{"v1"}, &out))
+	assert.Contains(t, out.String(), "No differences between v1 and system_prompt.txt")
+
+	assert.ErrorIs(t, promptDiffRunE(lib, dir, []string{"missing"}, &out), prompts.ErrVersionNotFound)
+}
+
+func TestRecordHistory_PromptVersion(t *testing.T) {
+	Log = zerolog.Nop()
+	dir := t.TempDir()
+	lib := prompts.New(dir)
+	require.NoError(t, lib.Save("concise", "Be concise."))
+	require.NoError(t, lib.Use("concise"))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, config.DefaultPromptFileName), []byte("Be concise."), 0600))
+
+	store := history.NewStore(dir)
+	runner := &createCmdRunner{history: store, promptLibrary: lib}
+	request := mcpclient.CreateIssueRequest{ProjectKey: "BE", IssueType: "Bug", Summary: "Fix login"}
+	runner.recordHistory("create", "login broken", "Be concise.", request, &mcpclient.CreateIssueResponse{Key: "BE-7"})
+	runner.recordHistory("webhook", "", "", request, &mcpclient.CreateIssueResponse{Key: "BE-8"})
+
+	entries, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "BE-7", entries[0].IssueKey)
+	assert.Equal(t, "concise", entries[0].PromptVersion)
+	assert.Equal(t, "login broken", entries[0].Input)
+	assert.Empty(t, entries[1].PromptVersion)
+}
@@ -2,68 +2,173 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
+	"sync"
 
 	openai "github.com/sashabaranov/go-openai" // Added openai import
-	keyring "github.com/zalando/go-keyring"
 
+	"github.com/karolswdev/ticketron/internal/breaker"
+	"github.com/karolswdev/ticketron/internal/chaos"
 	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/httpclient"
+	"github.com/karolswdev/ticketron/internal/i18n"
 	"github.com/karolswdev/ticketron/internal/llm" // Added llm import
 	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/ratelimit"
+	"github.com/karolswdev/ticketron/internal/secrets"
 )
 
 // --- Concrete Implementations of Shared Interfaces ---
 
 // defaultConfigProvider implements the ConfigProvider interface using the actual config package functions.
 // Exported for potential use in tests directly.
-type DefaultConfigProvider struct{}
+type DefaultConfigProvider struct {
+	// ConfigDir overrides the configuration directory. When empty, the config package's
+	// default resolution applies (TICKETRON_CONFIG_DIR, then ~/.ticketron).
+	ConfigDir string
+	// Project is the project-local configuration layered over the global one, if any.
+	Project *config.ProjectConfig
+	// EnvOnlySecrets replaces secrets.backend with the env backend, so that the API key is
+	// read from the environment or a secrets.api_key reference but never from the OS
+	// keyring or the credentials file (CI mode).
+	EnvOnlySecrets bool
+}
+
+// projectConfigProvider is implemented by ConfigProviders that layer a project-local
+// configuration over the global one.
+type projectConfigProvider interface {
+	ProjectConfig() *config.ProjectConfig
+}
+
+// ProjectConfig returns the project-local configuration layered over the global one, or nil.
+func (p *DefaultConfigProvider) ProjectConfig() *config.ProjectConfig {
+	return p.Project
+}
+
+// projectConfigOf returns the project-local configuration of cfgProvider, or nil.
+func projectConfigOf(cfgProvider ConfigProvider) *config.ProjectConfig {
+	if layered, ok := cfgProvider.(projectConfigProvider); ok {
+		return layered.ProjectConfig()
+	}
+	return nil
+}
 
+// LoadConfig loads config.yaml and applies its ui.language to the CLI messages; until a
+// command loads the configuration, messages follow the locale (LANG).
 func (p *DefaultConfigProvider) LoadConfig() (*config.AppConfig, error) {
-	return config.LoadConfig("") // Pass empty string for default behavior
+	cfg, err := config.LoadLayeredConfig(p.ConfigDir, p.Project)
+	if err != nil {
+		return nil, err
+	}
+	if p.EnvOnlySecrets {
+		cfg.Secrets.Backend = secrets.BackendEnv
+	}
+	if cfg.UI.Language != "" {
+		lang, ok := i18n.Lookup(cfg.UI.Language)
+		if !ok {
+			Log.Warn().Str("language", cfg.UI.Language).Strs("supported", i18n.Supported()).Msg("Unsupported ui.language, using English")
+		}
+		i18n.SetLanguage(lang) // Unsupported languages select English
+	}
+	return cfg, nil
 }
 
 func (p *DefaultConfigProvider) LoadLinks() (*config.LinksConfig, error) {
 	// LoadLinks returns LinksConfig, not *LinksConfig. Adjusting interface might be better,
 	// but for now, we return a pointer to the loaded struct.
-	links, err := config.LoadLinks("") // Pass empty string for default behavior
+	links, err := config.LoadLinks(p.ConfigDir)
 	if err != nil {
 		return nil, err
 	}
+	links = p.Project.ApplyLinks(links)
 	return &links, nil // Corrected: Use & instead of &amp;
 }
 
 func (p *DefaultConfigProvider) LoadSystemPrompt() (string, error) {
-	return config.LoadSystemPrompt("") // Pass empty string for default behavior
+	prompt, err := config.LoadSystemPrompt(p.ConfigDir)
+	if err != nil {
+		return "", err
+	}
+	return p.Project.ApplySystemPrompt(prompt), nil
 }
 
 func (p *DefaultConfigProvider) LoadContext() (string, error) {
-	return config.LoadContext("") // Pass empty string for default behavior
+	contextData, err := config.LoadContext(p.ConfigDir)
+	if err != nil {
+		return "", err
+	}
+	return p.Project.ApplyContext(contextData), nil
 }
 
+// GetAPIKey reads the API key from the secrets backend selected in config.yaml, or the
+// secret manager referenced by secrets.api_key, falling back to the TICKETRON_LLM_API_KEY
+// environment variable if the backend does not hold it.
 func (p *DefaultConfigProvider) GetAPIKey() (string, error) {
-	return config.GetAPIKey()
+	backend, err := secretsBackendFor(p)
+	if err != nil {
+		return "", err
+	}
+	return lookupAPIKey(backend)
+}
+
+// secretsBackendFor returns the secrets backend configured for cfgProvider's config directory.
+func secretsBackendFor(cfgProvider ConfigProvider) (secrets.Backend, error) {
+	cfg, err := cfgProvider.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	configDir, err := cfgProvider.EnsureConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return secrets.New(cfg.Secrets, configDir)
+}
+
+// lookupAPIKey reads the LLM API key from backend and then from the environment.
+func lookupAPIKey(backend secrets.Backend) (string, error) {
+	key, err := backend.Get(keyringServiceName, keyringUserName)
+	if err == nil {
+		return key, nil
+	}
+	if !errors.Is(err, secrets.ErrNotFound) {
+		return "", fmt.Errorf("%w: %w", config.ErrKeyringGet, err)
+	}
+	if key := os.Getenv(config.EnvAPIKeyName); key != "" {
+		return key, nil
+	}
+	return "", config.ErrAPIKeyNotFound
 }
 
 // CreateDefaultConfigFiles calls the underlying config function to create default files.
-// It ignores the configDir parameter as the underlying function determines the path.
+// It ignores the configDir parameter; the provider's ConfigDir (or the default location) is used.
 func (p *DefaultConfigProvider) CreateDefaultConfigFiles(configDir string) error {
-	// Ignore configDir, call the function that handles directory creation internally
-	return config.CreateDefaultConfigFiles("") // Pass empty string for default behavior
+	return config.CreateDefaultConfigFiles(p.ConfigDir)
+}
+
+// InitConfigFiles writes the configuration files with the settings in opts applied.
+func (p *DefaultConfigProvider) InitConfigFiles(opts config.InitOptions) error {
+	return config.InitConfigFiles(p.ConfigDir, opts)
 }
 
 // EnsureConfigDir calls the underlying config function to ensure the config directory exists.
 func (p *DefaultConfigProvider) EnsureConfigDir() (string, error) {
-	return config.EnsureConfigDir("") // Pass empty string for default behavior
+	return config.EnsureConfigDir(p.ConfigDir)
 }
 
+// errMCPClientNotInitialized is returned by non-interactive entry points (mcp-serve, batch)
+// when no MCP client is configured.
+var errMCPClientNotInitialized = errors.New("MCP client not initialized. Check the 'mcp_server_url' in your configuration")
+
 // defaultMCPClient implements the MCPClient interface.
 type defaultMCPClient struct {
 	client *mcpclient.Client // Store the actual MCP client instance (pointer)
 }
 
-func newDefaultMCPClient(cfg *config.AppConfig) (MCPClient, error) {
+func newDefaultMCPClient(cfg *config.AppConfig, opts ...mcpclient.Option) (MCPClient, error) {
 	// Check for MCP Server URL before creating client
 	if cfg.MCPServerURL == "" {
 		homeDir, _ := os.UserHomeDir() // Best effort
@@ -76,7 +181,7 @@ func newDefaultMCPClient(cfg *config.AppConfig) (MCPClient, error) {
 		return nil, fmt.Errorf("%w: Ensure 'mcp_server_url' is set in %s or via TICKETRON_MCP_SERVER_URL env var", err, expectedPath)
 	}
 
-	c, err := mcpclient.New(cfg)
+	c, err := mcpclient.New(cfg, opts...)
 	if err != nil {
 		// Error is returned, logging should happen in the caller (e.g., RunE)
 		// Log.Error().Err(err).Msg("Failed to initialize MCP client")
@@ -95,6 +200,56 @@ func (m *defaultMCPClient) SearchIssues(ctx context.Context, req mcpclient.Searc
 	return m.client.SearchIssues(ctx, req)
 }
 
+// AddComment calls the underlying client's AddComment method.
+func (m *defaultMCPClient) AddComment(ctx context.Context, issueKey string, req mcpclient.AddCommentRequest) (*mcpclient.Comment, error) {
+	return m.client.AddComment(ctx, issueKey, req)
+}
+
+// DeleteIssue calls the underlying client's DeleteIssue method.
+func (m *defaultMCPClient) DeleteIssue(ctx context.Context, issueKey string) error {
+	return m.client.DeleteIssue(ctx, issueKey)
+}
+
+// TransitionIssue calls the underlying client's TransitionIssue method.
+func (m *defaultMCPClient) TransitionIssue(ctx context.Context, issueKey string, req mcpclient.TransitionIssueRequest) error {
+	return m.client.TransitionIssue(ctx, issueKey, req)
+}
+
+// UpdateIssue calls the underlying client's UpdateIssue method.
+func (m *defaultMCPClient) UpdateIssue(ctx context.Context, issueKey string, req mcpclient.UpdateIssueRequest) error {
+	return m.client.UpdateIssue(ctx, issueKey, req)
+}
+
+// GetIssue calls the underlying client's GetIssue method.
+func (m *defaultMCPClient) GetIssue(ctx context.Context, issueKey string) (*mcpclient.Issue, error) {
+	return m.client.GetIssue(ctx, issueKey)
+}
+
+// GetComments calls the underlying client's GetComments method.
+func (m *defaultMCPClient) GetComments(ctx context.Context, issueKey string, req mcpclient.GetCommentsRequest) (*mcpclient.CommentsResponse, error) {
+	return m.client.GetComments(ctx, issueKey, req)
+}
+
+// GetIssueChangelog calls the underlying client's GetIssueChangelog method.
+func (m *defaultMCPClient) GetIssueChangelog(ctx context.Context, issueKey string, req mcpclient.GetChangelogRequest) (*mcpclient.ChangelogResponse, error) {
+	return m.client.GetIssueChangelog(ctx, issueKey, req)
+}
+
+// GetCreateMeta calls the underlying client's GetCreateMeta method.
+func (m *defaultMCPClient) GetCreateMeta(ctx context.Context, projectKey string) (*mcpclient.CreateMeta, error) {
+	return m.client.GetCreateMeta(ctx, projectKey)
+}
+
+// SearchUsers calls the underlying client's SearchUsers method.
+func (m *defaultMCPClient) SearchUsers(ctx context.Context, query string, maxResults int) ([]mcpclient.User, error) {
+	return m.client.SearchUsers(ctx, query, maxResults)
+}
+
+// LinkIssues calls the underlying client's LinkIssues method.
+func (m *defaultMCPClient) LinkIssues(ctx context.Context, req mcpclient.LinkIssuesRequest) error {
+	return m.client.LinkIssues(ctx, req)
+}
+
 // DefaultMCPClientWrapper wraps the concrete mcpclient.Client to satisfy the MCPClient interface for testing.
 // Exported for use in tests.
 type DefaultMCPClientWrapper struct {
@@ -115,30 +270,120 @@ func (w *DefaultMCPClientWrapper) SearchIssues(ctx context.Context, req mcpclien
 	return w.Client.SearchIssues(ctx, req)
 }
 
+func (w *DefaultMCPClientWrapper) AddComment(ctx context.Context, issueKey string, req mcpclient.AddCommentRequest) (*mcpclient.Comment, error) {
+	if w.Client == nil {
+		return nil, fmt.Errorf("wrapped mcpclient.Client is nil")
+	}
+	return w.Client.AddComment(ctx, issueKey, req)
+}
+
+func (w *DefaultMCPClientWrapper) DeleteIssue(ctx context.Context, issueKey string) error {
+	if w.Client == nil {
+		return fmt.Errorf("wrapped mcpclient.Client is nil")
+	}
+	return w.Client.DeleteIssue(ctx, issueKey)
+}
+
+func (w *DefaultMCPClientWrapper) TransitionIssue(ctx context.Context, issueKey string, req mcpclient.TransitionIssueRequest) error {
+	if w.Client == nil {
+		return fmt.Errorf("wrapped mcpclient.Client is nil")
+	}
+	return w.Client.TransitionIssue(ctx, issueKey, req)
+}
+
+func (w *DefaultMCPClientWrapper) UpdateIssue(ctx context.Context, issueKey string, req mcpclient.UpdateIssueRequest) error {
+	if w.Client == nil {
+		return fmt.Errorf("wrapped mcpclient.Client is nil")
+	}
+	return w.Client.UpdateIssue(ctx, issueKey, req)
+}
+
+func (w *DefaultMCPClientWrapper) GetIssue(ctx context.Context, issueKey string) (*mcpclient.Issue, error) {
+	if w.Client == nil {
+		return nil, fmt.Errorf("wrapped mcpclient.Client is nil")
+	}
+	return w.Client.GetIssue(ctx, issueKey)
+}
+
+func (w *DefaultMCPClientWrapper) GetComments(ctx context.Context, issueKey string, req mcpclient.GetCommentsRequest) (*mcpclient.CommentsResponse, error) {
+	if w.Client == nil {
+		return nil, fmt.Errorf("wrapped mcpclient.Client is nil")
+	}
+	return w.Client.GetComments(ctx, issueKey, req)
+}
+
+func (w *DefaultMCPClientWrapper) GetIssueChangelog(ctx context.Context, issueKey string, req mcpclient.GetChangelogRequest) (*mcpclient.ChangelogResponse, error) {
+	if w.Client == nil {
+		return nil, fmt.Errorf("wrapped mcpclient.Client is nil")
+	}
+	return w.Client.GetIssueChangelog(ctx, issueKey, req)
+}
+
+func (w *DefaultMCPClientWrapper) GetCreateMeta(ctx context.Context, projectKey string) (*mcpclient.CreateMeta, error) {
+	if w.Client == nil {
+		return nil, fmt.Errorf("wrapped mcpclient.Client is nil")
+	}
+	return w.Client.GetCreateMeta(ctx, projectKey)
+}
+
+func (w *DefaultMCPClientWrapper) SearchUsers(ctx context.Context, query string, maxResults int) ([]mcpclient.User, error) {
+	if w.Client == nil {
+		return nil, fmt.Errorf("wrapped mcpclient.Client is nil")
+	}
+	return w.Client.SearchUsers(ctx, query, maxResults)
+}
+
+func (w *DefaultMCPClientWrapper) LinkIssues(ctx context.Context, req mcpclient.LinkIssuesRequest) error {
+	if w.Client == nil {
+		return fmt.Errorf("wrapped mcpclient.Client is nil")
+	}
+	return w.Client.LinkIssues(ctx, req)
+}
+
 // --- Keyring Client Implementation ---
 
-// defaultKeyringClient implements the KeyringClient interface using the actual keyring package.
+// defaultKeyringClient implements the KeyringClient interface using the secrets backend
+// selected by secrets.backend in config.yaml.
 type defaultKeyringClient struct {
-	// No fields needed for the basic Set operation using the package directly.
+	config ConfigProvider
 }
 
-// Set calls the underlying keyring package's Set function.
+// Set stores the secret in the configured backend.
 func (k *defaultKeyringClient) Set(service, user, password string) error {
-	// Note: Consider adding configuration for the keyring backend if needed.
-	// For now, it uses the default backend determined by the library.
-	return keyring.Set(service, user, password)
+	backend, err := secretsBackendFor(k.config)
+	if err != nil {
+		return err
+	}
+	return backend.Set(service, user, password)
 }
 
-// GetAPIKey calls the underlying config function to retrieve the API key.
-// Note: The service and user parameters are currently unused by the underlying
-// config.GetAPIKey function but are kept for interface compatibility.
+// GetAPIKey retrieves the API key via the config provider.
+// Note: The service and user parameters are currently unused but are kept for interface compatibility.
 func (k *defaultKeyringClient) GetAPIKey(service, user string) (string, error) {
-	// TODO: Consider using service/user if the underlying implementation changes.
-	return config.GetAPIKey()
+	return k.config.GetAPIKey()
+}
+
+// Status describes the configured secrets backend.
+func (k *defaultKeyringClient) Status() secrets.Status {
+	backend, err := secretsBackendFor(k.config)
+	if err != nil {
+		return secrets.Status{Configured: "unknown", Detail: err.Error()}
+	}
+	return backend.Status()
 }
 
 // --- Central Provider ---
 
+// Errors returned (or recorded in Provider.InitErrors) by NewProvider.
+var (
+	// ErrProviderConfig indicates the application configuration could not be loaded.
+	ErrProviderConfig = errors.New("failed to load application config")
+	// ErrProviderMCPClient indicates the MCP client could not be initialized.
+	ErrProviderMCPClient = errors.New("failed to initialize MCP client")
+	// ErrProviderLLMClient indicates the LLM client could not be initialized.
+	ErrProviderLLMClient = errors.New("failed to initialize LLM client")
+)
+
 // Provider serves as a central dependency injection container, aggregating the various
 // service interfaces (like ConfigProvider, MCPClient, KeyringClient) required by
 // the application's commands. This structure simplifies passing dependencies down
@@ -148,92 +393,429 @@ type Provider struct {
 	MCP     MCPClient
 	Keyring KeyringClient
 	LLM     llm.Client // Added LLM client interface
+
+	// Transport is the pooled transport of the MCP client. It is shared by every MCP client
+	// built in this process with the same mcp.tls settings, so repeated and concurrent
+	// requests reuse connections. It is nil when WithMCPClient or WithHTTPClient is given.
+	Transport *http.Transport
+
+	// InitErrors records non-fatal initialization failures (wrapping ErrProviderMCPClient or
+	// ErrProviderLLMClient). The corresponding client is nil; commands needing it fail later.
+	InitErrors []error
 }
 
-// GetProvider is the factory function responsible for initializing and returning a
-// fully configured Provider instance. It sets up the concrete implementations for
-// each required service interface (e.g., defaultConfigProvider, defaultMCPClient,
-// defaultKeyringClient). It handles loading the initial application configuration
-// and attempts to initialize the MCP client if configured. Errors during critical
-// initialization steps (like loading AppConfig) are returned. Warnings may be logged
-// for non-critical failures (like MCP client init if URL is missing).
-func GetProvider() (*Provider, error) {
-	// Initialize Config Provider
-	cfgProvider := &DefaultConfigProvider{} // Corrected: Use & instead of &amp;
+// providerOptions collects the settings applied by ProviderOption functions.
+type providerOptions struct {
+	configDir      string
+	project        *config.ProjectConfig
+	envOnlySecrets bool
+	configProvider ConfigProvider
+	mcpClient      MCPClient
+	llmClient      llm.Client
+	keyringClient  KeyringClient
+	httpClient     *http.Client
+}
+
+// ProviderOption customizes how NewProvider builds a Provider.
+type ProviderOption func(*providerOptions)
+
+// WithConfigDir makes the default ConfigProvider read from dir instead of the default location.
+// It has no effect when WithConfigProvider is also given.
+func WithConfigDir(dir string) ProviderOption {
+	return func(o *providerOptions) { o.configDir = dir }
+}
+
+// WithProjectConfig layers project over the configuration read by the default
+// ConfigProvider. It has no effect when WithConfigProvider is also given.
+func WithProjectConfig(project *config.ProjectConfig) ProviderOption {
+	return func(o *providerOptions) { o.project = project }
+}
+
+// WithEnvOnlySecrets makes the default ConfigProvider read the API key only from the
+// environment or a secrets.api_key reference (see DefaultConfigProvider.EnvOnlySecrets).
+// It has no effect when WithConfigProvider is also given.
+func WithEnvOnlySecrets() ProviderOption {
+	return func(o *providerOptions) { o.envOnlySecrets = true }
+}
+
+// WithConfigProvider uses cp instead of the default file-based ConfigProvider.
+func WithConfigProvider(cp ConfigProvider) ProviderOption {
+	return func(o *providerOptions) { o.configProvider = cp }
+}
+
+// WithMCPClient uses client instead of building one from the configured MCP server URL.
+func WithMCPClient(client MCPClient) ProviderOption {
+	return func(o *providerOptions) { o.mcpClient = client }
+}
+
+// WithLLMClient uses client instead of building one from the configured LLM provider.
+func WithLLMClient(client llm.Client) ProviderOption {
+	return func(o *providerOptions) { o.llmClient = client }
+}
+
+// WithKeyringClient uses client instead of the OS keyring.
+func WithKeyringClient(client KeyringClient) ProviderOption {
+	return func(o *providerOptions) { o.keyringClient = client }
+}
+
+// WithHTTPClient sets the HTTP client used by the MCP and OpenAI clients built by NewProvider.
+func WithHTTPClient(client *http.Client) ProviderOption {
+	return func(o *providerOptions) { o.httpClient = client }
+}
+
+// NewProvider builds a Provider. Only a failure to load the application config is fatal
+// (wrapping ErrProviderConfig); failures to build the MCP or LLM client are recorded in
+// InitErrors and leave that client nil. NewProvider does not log; see GetProvider.
+//
+// The default ConfigProvider is a ConfigSnapshot, so the configuration files are read
+// once per Provider however many runners use them.
+func NewProvider(opts ...ProviderOption) (*Provider, error) {
+	o := &providerOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	cfgProvider := o.configProvider
+	if cfgProvider == nil {
+		cfgProvider = NewConfigSnapshot(&DefaultConfigProvider{ConfigDir: o.configDir, Project: o.project, EnvOnlySecrets: o.envOnlySecrets})
+	}
 	appCfg, err := cfgProvider.LoadConfig()
 	if err != nil {
-		// Error is returned, logging should happen in the caller (e.g., RunE)
-		// Log.Error().Err(err).Msg("Failed to load application config during provider initialization")
-		return nil, fmt.Errorf("failed to load application config: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrProviderConfig, err)
+	}
+
+	provider := &Provider{
+		Config:  cfgProvider,
+		MCP:     o.mcpClient,
+		Keyring: o.keyringClient,
+		LLM:     o.llmClient,
+	}
+	if provider.Keyring == nil {
+		provider.Keyring = &defaultKeyringClient{config: cfgProvider}
+	}
+	if provider.MCP == nil {
+		provider.MCP, err = buildMCPClient(appCfg, o.httpClient)
+		if err == nil && provider.MCP != nil && o.httpClient == nil {
+			provider.Transport, err = sharedMCPTransport(appCfg.MCP.TLS)
+		}
+		if err != nil {
+			provider.InitErrors = append(provider.InitErrors, fmt.Errorf("%w: %w", ErrProviderMCPClient, err))
+		}
+	}
+	if provider.LLM == nil {
+		provider.LLM, err = buildLLMClient(appCfg, cfgProvider, o.httpClient)
+		if err != nil {
+			provider.InitErrors = append(provider.InitErrors, fmt.Errorf("%w: %w", ErrProviderLLMClient, err))
+		}
+	}
+	return provider, nil
+}
+
+// buildMCPClient creates the MCP client if a server URL is configured. A missing URL is not
+// an error: commands that need MCP report it when they run. Unless httpClient is given
+// explicitly, the client uses the shared transport for the TLS settings from mcp.tls,
+// wrapped by the recorder of TICKETRON_RECORD or TICKETRON_REPLAY if set, by the fault
+// injection of mcp.chaos if enabled, by the rate limit of mcp.rate_limit and by the
+// circuit breaker of mcp.circuit_breaker, which all clients of the process for the same
+// server share.
+func buildMCPClient(appCfg *config.AppConfig, httpClient *http.Client) (MCPClient, error) {
+	if appCfg.MCPServerURL == "" {
+		return nil, nil
+	}
+	if httpClient != nil {
+		return newDefaultMCPClient(appCfg, mcpclient.WithHTTPClient(httpClient))
+	}
+	transport, err := sharedMCPTransport(appCfg.MCP.TLS)
+	if err != nil {
+		return nil, err
+	}
+	roundTripper, err := recordingTransport(transport)
+	if err != nil {
+		return nil, err
+	}
+	roundTripper, err = chaos.Wrap(roundTripper, appCfg.MCP.Chaos, "mcp")
+	if err != nil {
+		return nil, fmt.Errorf("invalid mcp.chaos configuration: %w", err)
+	}
+	limiter, err := ratelimit.Shared("mcp "+appCfg.MCPServerURL, appCfg.MCP.RateLimit)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mcp.rate_limit configuration: %w", err)
+	}
+	roundTripper = ratelimit.Wrap(roundTripper, limiter, "mcp")
+	circuit, err := breaker.Shared("mcp "+appCfg.MCPServerURL, appCfg.MCP.CircuitBreaker)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mcp.circuit_breaker configuration: %w", err)
 	}
+	roundTripper = breaker.Wrap(roundTripper, circuit, "mcp")
+	return newDefaultMCPClient(appCfg, mcpclient.WithTransport(roundTripper))
+}
+
+// mcpTransport is a cached transport and the stamp of the certificate files it was built from.
+type mcpTransport struct {
+	transport *http.Transport
+	stamp     string
+}
+
+// mcpTransports caches one pooled transport per mcp.tls configuration.
+var (
+	mcpTransportsMu sync.Mutex
+	mcpTransports   = make(map[config.TLSConfig]mcpTransport)
+)
 
-	// Initialize MCP Client (conditionally based on config)
-	var mcpClient MCPClient
-	// Only initialize MCP if URL is present, otherwise commands needing it will fail later if they try to use a nil client.
-	// Commands that don't need MCP should still function.
-	if appCfg.MCPServerURL != "" {
-		mcpClient, err = newDefaultMCPClient(appCfg)
+// sharedMCPTransport returns the process-wide transport for MCP connections with the given
+// TLS settings, creating it on first use. Certificate files are read at that point and again
+// once they change on disk, e.g. when rotated, so clients built afterwards (as on a config
+// reload of tix serve) use the new certificates.
+func sharedMCPTransport(tlsSettings config.TLSConfig) (*http.Transport, error) {
+	mcpTransportsMu.Lock()
+	defer mcpTransportsMu.Unlock()
+	stamp := httpclient.TLSFilesStamp(tlsSettings)
+	cached, ok := mcpTransports[tlsSettings]
+	if ok && cached.stamp == stamp {
+		return cached.transport, nil
+	}
+	tlsCfg, err := httpclient.TLSConfig(tlsSettings)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mcp.tls configuration: %w", err)
+	}
+	if ok {
+		Log.Debug().Msg("MCP TLS files changed, creating a new transport")
+		cached.transport.CloseIdleConnections() // Clients still holding it keep working
+	}
+	transport := httpclient.NewTransport(tlsCfg)
+	mcpTransports[tlsSettings] = mcpTransport{transport: transport, stamp: stamp}
+	return transport, nil
+}
+
+// newCommandMCPClient builds the MCP client for commands that only talk to the MCP
+// server, without initializing (and warning about) the LLM client.
+func newCommandMCPClient() (MCPClient, error) {
+	cfg, err := (&DefaultConfigProvider{}).LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrProviderConfig, err)
+	}
+	client, err := buildMCPClient(cfg, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrProviderMCPClient, err)
+	}
+	if client == nil {
+		return nil, errMCPClientNotInitialized
+	}
+	return client, nil
+}
+
+// buildLLMClient creates the LLM client for the configured provider. The mock provider
+// yields an llm.MockClient, which needs neither an API key nor the network. When llm.fallbacks is configured, the primary and
+// fallback clients are combined into an llm.FallbackClient; members that cannot be built
+// are skipped and reported in the returned error alongside the (possibly non-nil) client.
+//
+// Unless httpClient is given explicitly, provider clients use the transport configured
+// under llm.http (proxy, CA bundle), wrapped by the recorder of TICKETRON_RECORD or
+// TICKETRON_REPLAY if set and by the fault injection of llm.chaos if enabled, and each
+// provider's requests are limited by its entry in llm.rate_limits and guarded by its own
+// circuit breaker from llm.circuit_breaker, so that an open circuit moves on to the next
+// fallback at once. The generation parameters under llm.openai apply to
+// every client in the chain, as do llm.lenient_parsing, llm.repair_attempts and llm.response_schema.
+func buildLLMClient(appCfg *config.AppConfig, cfgProvider ConfigProvider, httpClient *http.Client) (llm.Client, error) {
+	params := generationParams(appCfg.LLM.OpenAI)
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	parseOpts := llm.ParseOptions{Lenient: appCfg.LLM.LenientParsing, RepairAttempts: appCfg.LLM.RepairAttempts}
+	if err := parseOpts.Validate(); err != nil {
+		return nil, err
+	}
+	if appCfg.LLM.ResponseSchema != "" {
+		schema, err := llm.LoadSchema(appCfg.LLM.ResponseSchema)
 		if err != nil {
-			Log.Warn().Err(err).Msg("Failed to initialize MCP client during provider setup. MCP operations might fail.") // Uncommented and kept as Warn
-			// Consider returning the error if MCP is essential for most operations:
-			// return nil, fmt.Errorf("failed to initialize MCP client: %w", err)
+			return nil, fmt.Errorf("invalid llm.response_schema: %w", err)
 		}
+		parseOpts.Schema = schema
+	}
+	rateLimits, circuitBreaker := appCfg.LLM.RateLimits, appCfg.LLM.CircuitBreaker
+	if httpClient != nil {
+		rateLimits, circuitBreaker = nil, config.CircuitBreakerConfig{} // Explicit clients are used as given
 	} else {
-		Log.Debug().Msg("MCP Server URL not configured. MCP client not initialized.") // Uncommented and kept as Debug
+		configured, err := httpclient.New(appCfg.LLM.HTTP)
+		if err != nil {
+			return nil, fmt.Errorf("invalid llm.http configuration: %w", err)
+		}
+		var base http.RoundTripper
+		if configured != nil {
+			base = configured.Transport
+		}
+		transport, err := recordingTransport(base)
+		if err != nil {
+			return nil, err
+		}
+		transport, err = chaos.Wrap(transport, appCfg.LLM.Chaos, "llm")
+		if err != nil {
+			return nil, fmt.Errorf("invalid llm.chaos configuration: %w", err)
+		}
+		if transport != base {
+			if configured == nil {
+				configured = &http.Client{}
+			}
+			configured.Transport = transport
+		}
+		httpClient = configured
+	}
+	for name, limit := range rateLimits {
+		if err := ratelimit.Validate(limit); err != nil {
+			return nil, fmt.Errorf("invalid llm.rate_limits.%s configuration: %w", name, err)
+		}
+	}
+	if err := breaker.Validate(circuitBreaker); err != nil {
+		return nil, fmt.Errorf("invalid llm.circuit_breaker configuration: %w", err)
+	}
+	providerClient := func(providerName string) *http.Client {
+		return providerHTTPClient(httpClient, "llm "+providerName, rateLimits[providerName], circuitBreaker)
+	}
+	primary, err := buildSingleLLMClient(appCfg.LLM.Provider, appCfg.LLM.OpenAI.ModelName, appCfg.LLM.OpenAI.BaseURL, params, parseOpts, cfgProvider, providerClient(appCfg.LLM.Provider))
+	if len(appCfg.LLM.Fallbacks) == 0 {
+		return primary, err
 	}
 
-	// Initialize Keyring Client
-	keyringClient := &defaultKeyringClient{} // Corrected: Use & instead of &amp;
+	var chain []llm.NamedClient
+	var errs []error
+	if err != nil {
+		errs = append(errs, err)
+	} else if primary != nil {
+		chain = append(chain, llm.NamedClient{Name: appCfg.LLM.Provider + "/" + appCfg.LLM.OpenAI.ModelName, Client: primary})
+	}
+	for _, fb := range appCfg.LLM.Fallbacks {
+		client, err := buildSingleLLMClient(fb.Provider, fb.Model, fb.BaseURL, params, parseOpts, cfgProvider, providerClient(fb.Provider))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("fallback %s/%s: %w", fb.Provider, fb.Model, err))
+			continue
+		}
+		if client != nil {
+			chain = append(chain, llm.NamedClient{Name: fb.Provider + "/" + fb.Model, Client: client})
+		}
+	}
+	if len(chain) == 0 {
+		return nil, errors.Join(errs...)
+	}
+	fallbackClient, err := llm.NewFallbackClient(chain, appCfg.LLM.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	return fallbackClient, errors.Join(errs...)
+}
 
-	// Initialize LLM Client based on config
-	var llmClient llm.Client
-	apiKey, keyErr := cfgProvider.GetAPIKey()           // Get API key once
-	if keyErr != nil && appCfg.LLM.Provider != "mock" { // Allow mock provider without key
-		// Log warning but don't fail provider init; commands needing LLM will fail later
-		Log.Warn().Err(keyErr).Msg("Failed to get LLM API key during provider setup. LLM operations might fail.")
+// providerHTTPClient returns a copy of httpClient whose requests are limited by the shared
+// limiter of name for limit and guarded by the shared breaker of name for circuitBreaker, or
+// httpClient itself if neither is configured. Both configurations must be valid.
+func providerHTTPClient(httpClient *http.Client, name string, limit config.RateLimitConfig, circuitBreaker config.CircuitBreakerConfig) *http.Client {
+	limiter, _ := ratelimit.Shared(name, limit)
+	circuit, _ := breaker.Shared(name, circuitBreaker)
+	if limiter == nil && circuit == nil {
+		return httpClient
+	}
+	wrapped := &http.Client{}
+	if httpClient != nil {
+		*wrapped = *httpClient
 	}
+	wrapped.Transport = breaker.Wrap(ratelimit.Wrap(wrapped.Transport, limiter, name), circuit, name)
+	return wrapped
+}
 
-	switch appCfg.LLM.Provider {
+// buildSingleLLMClient creates a client for one provider/model combination.
+func buildSingleLLMClient(providerName, model, baseURL string, params llm.GenerationParams, parseOpts llm.ParseOptions, cfgProvider ConfigProvider, httpClient *http.Client) (llm.Client, error) {
+	switch providerName {
 	case "openai":
-		if keyErr == nil { // Only proceed if key was retrieved
-			Log.Debug().Str("provider", "openai").Msg("Initializing OpenAI LLM client")
-			// Log the key being used (MASK SENSITIVE PARTS IN REAL LOGS if necessary, but ok for test dummy key)
-			Log.Debug().Str("apiKeyUsed", apiKey).Msg("API Key retrieved for OpenAI client")
-			openAIConfig := openai.DefaultConfig(apiKey)
-			if appCfg.LLM.OpenAI.BaseURL != "" {
-				openAIConfig.BaseURL = appCfg.LLM.OpenAI.BaseURL
-				Log.Debug().Str("baseURLUsed", openAIConfig.BaseURL).Msg("Using custom OpenAI BaseURL")
-			} else {
-				Log.Debug().Msg("Using default OpenAI BaseURL")
-			}
-			openaiSdkClient := openai.NewClientWithConfig(openAIConfig)
-			llmClient, err = llm.NewOpenAIClient(openaiSdkClient, appCfg.LLM.OpenAI.ModelName)
-			if err != nil {
-				Log.Warn().Err(err).Msg("Failed to initialize OpenAI client. LLM operations might fail.")
-				// Don't return error here, let commands fail if they need LLM
-			}
-		} else {
-			Log.Warn().Msg("OpenAI provider selected but API key retrieval failed. LLM client not initialized.")
+		apiKey, err := cfgProvider.GetAPIKey()
+		if err != nil {
+			return nil, err
+		}
+		openAIConfig := openai.DefaultConfig(apiKey)
+		if baseURL != "" {
+			openAIConfig.BaseURL = baseURL
 		}
+		if httpClient != nil {
+			openAIConfig.HTTPClient = httpClient
+		}
+		client, err := llm.NewOpenAIClient(openai.NewClientWithConfig(openAIConfig), model)
+		if err != nil {
+			return nil, err // Avoid returning a typed nil inside the interface
+		}
+		client.SetGenerationParams(params)
+		client.SetParseOptions(parseOpts)
+		return client, nil
 	// case "anthropic": // Placeholder
-	// 	Log.Warn().Msg("Anthropic provider not yet implemented.")
 	// case "ollama": // Placeholder
-	// 	Log.Warn().Msg("Ollama provider not yet implemented.")
-	case "mock": // Allow a mock provider for testing potentially
-		Log.Info().Msg("Using mock LLM provider (if implemented).")
-		// llmClient = NewMockLLMClient() // Example
+	case "mock": // Deterministic in-process client for tests and demos
+		return llm.NewMockClient(params), nil
 	default:
-		Log.Warn().Str("provider", appCfg.LLM.Provider).Msg("Unsupported LLM provider specified in config. LLM client not initialized.")
+		return nil, fmt.Errorf("unsupported LLM provider %q", providerName)
 	}
+}
 
-	// Construct and return the Provider
-	provider := &Provider{ // Corrected: Use & instead of &amp;
-		Config:  cfgProvider,
-		MCP:     mcpClient, // This might be nil if URL wasn't set or init failed
-		Keyring: keyringClient,
-		LLM:     llmClient, // Assign the initialized LLM client (might be nil)
+// generationParams returns the generation parameters configured under llm.openai.
+func generationParams(cfg config.OpenAIConfig) llm.GenerationParams {
+	return llm.GenerationParams{Temperature: cfg.Temperature, TopP: cfg.TopP, MaxTokens: cfg.MaxTokens, Seed: cfg.Seed}
+}
+
+// newLLMClientWithOverrides builds an LLM client from the current configuration with the
+// provider, model and/or generation parameters replaced for a single invocation. Unset
+// overrides keep the configured setting.
+func newLLMClientWithOverrides(cfgProvider ConfigProvider, o llmOverrides) (llm.Client, error) {
+	appCfg, err := cfgProvider.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrProviderConfig, err)
+	}
+	overridden := *appCfg
+	o.apply(&overridden.LLM)
+	client, err := buildLLMClient(&overridden, cfgProvider, nil)
+	if err != nil {
+		if client == nil {
+			return nil, fmt.Errorf("%w: %w", ErrProviderLLMClient, err)
+		}
+		Log.Warn().Err(err).Msg("Some LLM fallbacks could not be initialized")
 	}
+	return client, nil
+}
 
-	Log.Debug().Msg("Service Provider initialized successfully.") // Uncommented and kept as Debug
+// GetProvider is the factory function used by commands. It builds a Provider with the
+// default implementations via NewProvider and logs any non-fatal initialization failures
+// as warnings, so commands that don't need the failed client still work.
+func GetProvider() (*Provider, error) {
+	var opts []ProviderOption
+	project, err := discoverProjectConfig()
+	if err != nil {
+		Log.Warn().Err(err).Msg("Ignoring the project-local configuration")
+	} else if project != nil {
+		Log.Debug().Str("path", project.Path).Msg("Using the project-local configuration")
+		opts = append(opts, WithProjectConfig(project))
+	}
+	if ciEnabled {
+		opts = append(opts, WithEnvOnlySecrets())
+	}
+	provider, err := NewProvider(opts...)
+	if err != nil {
+		return nil, err
+	}
+	for _, initErr := range provider.InitErrors {
+		Log.Warn().Err(initErr).Msg("Dependency initialization failed. Operations using it will fail.")
+	}
+	Log.Debug().Msg("Service Provider initialized successfully.")
 	return provider, nil
 }
+
+// discoverProjectConfig loads the project-local configuration nearest to the working
+// directory (see config.FindProjectConfig), or returns nil if there is none.
+func discoverProjectConfig() (*config.ProjectConfig, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	globalDir, err := config.EnsureConfigDir("")
+	if err != nil {
+		return nil, err
+	}
+	path, err := config.FindProjectConfig(wd, globalDir)
+	if err != nil || path == "" {
+		return nil, err
+	}
+	return config.LoadProjectConfig(path)
+}
@@ -0,0 +1,396 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/breaker"
+	"github.com/karolswdev/ticketron/internal/chaos"
+	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/httpclient"
+	"github.com/karolswdev/ticketron/internal/i18n"
+	"github.com/karolswdev/ticketron/internal/llm"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/ratelimit"
+	"github.com/karolswdev/ticketron/internal/secrets"
+)
+
+func TestNewProvider_WithInjectedClients(t *testing.T) {
+	mockConfig := new(MockConfigProvider)
+	mockConfig.On("LoadConfig").Return(&config.AppConfig{LLM: config.LLMConfig{Provider: "openai"}}, nil)
+	mockMCP := new(MockMCPClient)
+	mockLLM := new(MockLLMClient)
+	mockKeyring := new(MockKeyringClient)
+
+	provider, err := NewProvider(
+		WithConfigProvider(mockConfig),
+		WithMCPClient(mockMCP),
+		WithLLMClient(mockLLM),
+		WithKeyringClient(mockKeyring),
+	)
+	require.NoError(t, err)
+	assert.Same(t, mockConfig, provider.Config)
+	assert.Same(t, mockMCP, provider.MCP)
+	assert.Same(t, mockLLM, provider.LLM)
+	assert.Same(t, mockKeyring, provider.Keyring)
+	assert.Empty(t, provider.InitErrors)
+	// Injected clients are used as-is; the API key is never looked up.
+	mockConfig.AssertNotCalled(t, "GetAPIKey")
+}
+
+func TestNewProvider_ConfigError(t *testing.T) {
+	mockConfig := new(MockConfigProvider)
+	mockConfig.On("LoadConfig").Return(nil, config.ErrConfigParse)
+
+	_, err := NewProvider(WithConfigProvider(mockConfig))
+	assert.ErrorIs(t, err, ErrProviderConfig)
+	assert.ErrorIs(t, err, config.ErrConfigParse)
+}
+
+func TestNewProvider_RecordsInitErrors(t *testing.T) {
+	mockConfig := new(MockConfigProvider)
+	mockConfig.On("LoadConfig").Return(&config.AppConfig{
+		MCPServerURL: "http://[::1]:namedport", // Unparseable URL
+		LLM:          config.LLMConfig{Provider: "openai"},
+	}, nil)
+	mockConfig.On("GetAPIKey").Return("", errors.New("no key"))
+
+	provider, err := NewProvider(WithConfigProvider(mockConfig), WithHTTPClient(&http.Client{}))
+	require.NoError(t, err, "client init failures are not fatal")
+	assert.Nil(t, provider.MCP)
+	assert.Nil(t, provider.LLM)
+	require.Len(t, provider.InitErrors, 2)
+	assert.ErrorIs(t, provider.InitErrors[0], ErrProviderMCPClient)
+	assert.ErrorIs(t, provider.InitErrors[1], ErrProviderLLMClient)
+}
+
+func TestNewProvider_WithConfigDir(t *testing.T) {
+	dir := t.TempDir()
+	content := "mcp_server_url: http://mcp.example.com\nllm:\n  provider: mock\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, config.DefaultConfigFileName), []byte(content), 0600))
+
+	provider, err := NewProvider(WithConfigDir(dir))
+	require.NoError(t, err)
+	require.NotNil(t, provider.MCP)
+	assert.IsType(t, &llm.MockClient{}, provider.LLM, "the mock provider needs no API key")
+	assert.Empty(t, provider.InitErrors)
+
+	gotDir, err := provider.Config.EnsureConfigDir()
+	require.NoError(t, err)
+	assert.Equal(t, dir, gotDir)
+}
+
+func TestNewProvider_WithProjectConfig(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, config.DefaultConfigFileName), []byte("default_project: GLOBAL\nllm:\n  provider: mock\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, config.DefaultLinksFileName), []byte("projects:\n  - name: ops\n    key: OPS\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, config.DefaultContextFileName), []byte("Global context"), 0600))
+	project := &config.ProjectConfig{Path: "/repo/.ticketron.yaml", Bundle: config.Bundle{
+		Config:  map[string]any{"default_project": "API"},
+		Links:   config.LinksConfig{Projects: []config.ProjectLink{{Name: "api", Key: "API"}}},
+		Context: "Repo context",
+	}}
+
+	provider, err := NewProvider(WithConfigDir(dir), WithProjectConfig(project))
+	require.NoError(t, err)
+	cfg, err := provider.Config.LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "API", cfg.DefaultProject)
+	links, err := provider.Config.LoadLinks()
+	require.NoError(t, err)
+	assert.Equal(t, []config.ProjectLink{{Name: "api", Key: "API"}, {Name: "ops", Key: "OPS"}}, links.Projects)
+	contextData, err := provider.Config.LoadContext()
+	require.NoError(t, err)
+	assert.Equal(t, "Global context\n\nRepo context", contextData)
+
+	var out bytes.Buffer
+	require.NoError(t, configLocateRunE(provider.Config, &out))
+	assert.Contains(t, out.String(), "Project-local configuration: /repo/.ticketron.yaml\n")
+}
+
+func TestNewProvider_WithEnvOnlySecrets(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, config.DefaultConfigFileName), []byte("llm:\n  provider: mock\nsecrets:\n  backend: keyring\n"), 0600))
+	t.Setenv(config.EnvAPIKeyName, "sk-ci")
+
+	provider, err := NewProvider(WithConfigDir(dir), WithEnvOnlySecrets())
+	require.NoError(t, err)
+
+	cfg, err := provider.Config.LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, secrets.BackendEnv, cfg.Secrets.Backend, "the keyring and credentials file are not used")
+	assert.Equal(t, secrets.BackendEnv, provider.Keyring.Status().Active)
+	key, err := provider.Config.GetAPIKey()
+	require.NoError(t, err)
+	assert.Equal(t, "sk-ci", key)
+}
+
+func TestNewProvider_SharesMCPTransport(t *testing.T) {
+	mockConfig := new(MockConfigProvider)
+	mockConfig.On("LoadConfig").Return(&config.AppConfig{MCPServerURL: "http://mcp.example.com", LLM: config.LLMConfig{Provider: "mock"}}, nil)
+
+	first, err := NewProvider(WithConfigProvider(mockConfig))
+	require.NoError(t, err)
+	second, err := NewProvider(WithConfigProvider(mockConfig))
+	require.NoError(t, err)
+	require.NotNil(t, first.Transport)
+	assert.Same(t, first.Transport, second.Transport, "providers share one connection pool")
+	assert.Same(t, first.Transport, first.MCP.(*defaultMCPClient).client.HTTPClient.Transport)
+
+	withClient, err := NewProvider(WithConfigProvider(mockConfig), WithHTTPClient(&http.Client{}))
+	require.NoError(t, err)
+	assert.Nil(t, withClient.Transport, "an explicit HTTP client keeps its own transport")
+
+	other, err := sharedMCPTransport(config.TLSConfig{InsecureSkipVerify: true})
+	require.NoError(t, err)
+	assert.NotSame(t, first.Transport, other, "different mcp.tls settings get their own transport")
+}
+
+func TestSharedMCPTransport_RotatedCertificates(t *testing.T) {
+	first := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer first.Close()
+	second := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer second.Close()
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: first.Certificate().Raw}), 0600))
+	settings := config.TLSConfig{CAFile: caFile}
+
+	transport, err := sharedMCPTransport(settings)
+	require.NoError(t, err)
+	unchanged, err := sharedMCPTransport(settings)
+	require.NoError(t, err)
+	assert.Same(t, transport, unchanged)
+
+	// A rotated bundle is read again, e.g. when tix serve reloads its configuration
+	rotated := append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: first.Certificate().Raw}),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: second.Certificate().Raw})...)
+	require.NoError(t, os.WriteFile(caFile, rotated, 0600))
+	reloaded, err := sharedMCPTransport(settings)
+	require.NoError(t, err)
+	assert.NotSame(t, transport, reloaded)
+}
+
+func TestNewProvider_LLMFallbacks(t *testing.T) {
+	mockConfig := new(MockConfigProvider)
+	mockConfig.On("LoadConfig").Return(&config.AppConfig{
+		LLM: config.LLMConfig{
+			Provider:  "openai",
+			OpenAI:    config.OpenAIConfig{ModelName: "gpt-4o"},
+			Fallbacks: []config.LLMFallbackConfig{{Provider: "openai", Model: "gpt-4o-mini"}, {Provider: "bogus", Model: "x"}},
+		},
+	}, nil)
+	mockConfig.On("GetAPIKey").Return("test-key", nil)
+
+	provider, err := NewProvider(WithConfigProvider(mockConfig))
+	require.NoError(t, err)
+	assert.IsType(t, &llm.FallbackClient{}, provider.LLM)
+	// The unsupported fallback is skipped and reported, the rest of the chain still works.
+	require.Len(t, provider.InitErrors, 1)
+	assert.ErrorIs(t, provider.InitErrors[0], ErrProviderLLMClient)
+}
+
+func TestNewProvider_InvalidLLMHTTPConfig(t *testing.T) {
+	mockConfig := new(MockConfigProvider)
+	mockConfig.On("LoadConfig").Return(&config.AppConfig{
+		LLM: config.LLMConfig{
+			Provider: "openai",
+			HTTP:     config.HTTPConfig{HTTPSProxy: "not a url"},
+		},
+	}, nil)
+
+	provider, err := NewProvider(WithConfigProvider(mockConfig))
+	require.NoError(t, err)
+	assert.Nil(t, provider.LLM)
+	require.Len(t, provider.InitErrors, 1)
+	assert.ErrorIs(t, provider.InitErrors[0], ErrProviderLLMClient)
+	assert.ErrorIs(t, provider.InitErrors[0], httpclient.ErrInvalidProxyURL)
+}
+
+func TestNewProvider_InvalidGenerationParams(t *testing.T) {
+	temperature := float32(3)
+	mockConfig := new(MockConfigProvider)
+	mockConfig.On("LoadConfig").Return(&config.AppConfig{
+		LLM: config.LLMConfig{Provider: "openai", OpenAI: config.OpenAIConfig{Temperature: &temperature}},
+	}, nil)
+
+	provider, err := NewProvider(WithConfigProvider(mockConfig))
+	require.NoError(t, err)
+	assert.Nil(t, provider.LLM)
+	require.Len(t, provider.InitErrors, 1)
+	assert.ErrorIs(t, provider.InitErrors[0], llm.ErrLLMInvalidParams)
+}
+
+func TestNewProvider_InvalidRepairAttempts(t *testing.T) {
+	mockConfig := new(MockConfigProvider)
+	mockConfig.On("LoadConfig").Return(&config.AppConfig{
+		LLM: config.LLMConfig{Provider: "mock", RepairAttempts: -1},
+	}, nil)
+
+	provider, err := NewProvider(WithConfigProvider(mockConfig))
+	require.NoError(t, err)
+	assert.Nil(t, provider.LLM)
+	require.Len(t, provider.InitErrors, 1)
+	assert.ErrorIs(t, provider.InitErrors[0], llm.ErrLLMInvalidParseOptions)
+}
+
+func TestNewProvider_InvalidResponseSchema(t *testing.T) {
+	mockConfig := new(MockConfigProvider)
+	mockConfig.On("LoadConfig").Return(&config.AppConfig{
+		LLM: config.LLMConfig{Provider: "mock", ResponseSchema: filepath.Join(t.TempDir(), "missing.json")},
+	}, nil)
+
+	provider, err := NewProvider(WithConfigProvider(mockConfig))
+	require.NoError(t, err)
+	assert.Nil(t, provider.LLM)
+	require.Len(t, provider.InitErrors, 1)
+	assert.ErrorIs(t, provider.InitErrors[0], llm.ErrLLMInvalidSchema)
+}
+
+func TestNewProvider_Chaos(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("injected failures must not reach the server")
+	}))
+	defer server.Close()
+	mockConfig := new(MockConfigProvider)
+	mockConfig.On("LoadConfig").Return(&config.AppConfig{
+		MCPServerURL: server.URL,
+		MCP:          config.MCPConfig{Chaos: config.ChaosConfig{Enabled: true, ErrorRate: 1}},
+		LLM: config.LLMConfig{
+			Provider: "openai",
+			Chaos:    config.ChaosConfig{Enabled: true, TimeoutRate: 2},
+		},
+	}, nil)
+
+	provider, err := NewProvider(WithConfigProvider(mockConfig))
+	require.NoError(t, err)
+	require.NotNil(t, provider.MCP)
+	_, err = provider.MCP.GetIssue(context.Background(), "PROJ-1")
+	assert.ErrorIs(t, err, mcpclient.ErrMCPServerError)
+	assert.ErrorContains(t, err, "chaos: injected")
+
+	assert.Nil(t, provider.LLM)
+	require.Len(t, provider.InitErrors, 1)
+	assert.ErrorIs(t, provider.InitErrors[0], chaos.ErrInvalidConfig)
+	assert.ErrorContains(t, provider.InitErrors[0], "llm.chaos")
+}
+
+func TestNewProvider_RateLimit(t *testing.T) {
+	var hits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"key": "PROJ-1"}`))
+	}))
+	defer server.Close()
+	cfg := &config.AppConfig{
+		MCPServerURL: server.URL,
+		MCP:          config.MCPConfig{RateLimit: config.RateLimitConfig{RequestsPerSecond: 0.01, Burst: 1}},
+		LLM: config.LLMConfig{
+			Provider:   "openai",
+			RateLimits: map[string]config.RateLimitConfig{"openai": {RequestsPerSecond: -1}},
+		},
+	}
+	mockConfig := new(MockConfigProvider)
+	mockConfig.On("LoadConfig").Return(cfg, nil)
+
+	provider, err := NewProvider(WithConfigProvider(mockConfig))
+	require.NoError(t, err)
+	require.NotNil(t, provider.MCP)
+	_, err = provider.MCP.GetIssue(context.Background(), "PROJ-1")
+	require.NoError(t, err)
+
+	// A second client for the same server draws from the same bucket
+	other, err := buildMCPClient(cfg, nil)
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = other.GetIssue(ctx, "PROJ-1")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, hits)
+
+	assert.Nil(t, provider.LLM)
+	require.Len(t, provider.InitErrors, 1)
+	assert.ErrorIs(t, provider.InitErrors[0], ratelimit.ErrInvalidConfig)
+	assert.ErrorContains(t, provider.InitErrors[0], "llm.rate_limits.openai")
+}
+
+func TestNewProvider_CircuitBreaker(t *testing.T) {
+	var hits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	cfg := &config.AppConfig{
+		MCPServerURL: server.URL,
+		MCP:          config.MCPConfig{CircuitBreaker: config.CircuitBreakerConfig{FailureThreshold: 2, Cooldown: time.Hour}},
+		LLM:          config.LLMConfig{Provider: "openai", CircuitBreaker: config.CircuitBreakerConfig{Cooldown: -time.Second}},
+	}
+	mockConfig := new(MockConfigProvider)
+	mockConfig.On("LoadConfig").Return(cfg, nil)
+
+	provider, err := NewProvider(WithConfigProvider(mockConfig))
+	require.NoError(t, err)
+	require.NotNil(t, provider.MCP)
+	for range 2 {
+		_, err = provider.MCP.GetIssue(context.Background(), "PROJ-1")
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, breaker.ErrOpen)
+	}
+
+	// The circuit is open for every client of the same server
+	other, err := buildMCPClient(cfg, nil)
+	require.NoError(t, err)
+	_, err = other.GetIssue(context.Background(), "PROJ-1")
+	assert.ErrorIs(t, err, breaker.ErrOpen)
+	assert.Equal(t, 2, hits)
+
+	assert.Nil(t, provider.LLM)
+	require.Len(t, provider.InitErrors, 1)
+	assert.ErrorIs(t, provider.InitErrors[0], breaker.ErrInvalidConfig)
+	assert.ErrorContains(t, provider.InitErrors[0], "llm.circuit_breaker")
+}
+
+func TestDefaultConfigProvider_GetAPIKey_SecretsBackend(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.yaml"), []byte("secrets:\n  backend: file\n"), 0600))
+	cp := &DefaultConfigProvider{ConfigDir: dir}
+
+	t.Setenv(config.EnvAPIKeyName, "")
+	_, err := cp.GetAPIKey()
+	assert.ErrorIs(t, err, config.ErrAPIKeyNotFound)
+
+	t.Setenv(config.EnvAPIKeyName, "sk-env")
+	key, err := cp.GetAPIKey()
+	require.NoError(t, err)
+	assert.Equal(t, "sk-env", key, "the environment variable is used when the backend has no key")
+
+	kc := &defaultKeyringClient{config: cp}
+	require.NoError(t, kc.Set(keyringServiceName, keyringUserName, "sk-file"))
+	key, err = cp.GetAPIKey()
+	require.NoError(t, err)
+	assert.Equal(t, "sk-file", key)
+	assert.Equal(t, "file", kc.Status().Active)
+}
+
+func TestDefaultConfigProvider_LoadConfig_Language(t *testing.T) {
+	defer i18n.SetLanguage(i18n.Language())
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.yaml"), []byte("ui:\n  language: pl_PL\n"), 0600))
+
+	cfg, err := (&DefaultConfigProvider{ConfigDir: dir}).LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "pl_PL", cfg.UI.Language)
+	assert.Equal(t, i18n.Polish, i18n.Language())
+}
@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/karolswdev/ticketron/internal/vcr"
+)
+
+// envRecorder returns the process-wide recorder selected by TICKETRON_RECORD or
+// TICKETRON_REPLAY, or nil. It is created once so that every client built in the process
+// shares one cassette. A variable so tests can replace it.
+var envRecorder = sync.OnceValues(vcr.FromEnv)
+
+// recordingTransport wraps next with the environment's recorder, if any. A nil next
+// stands for http.DefaultTransport.
+func recordingTransport(next http.RoundTripper) (http.RoundTripper, error) {
+	recorder, err := envRecorder()
+	if err != nil || recorder == nil {
+		return next, err
+	}
+	Log.Debug().Int("mode", int(recorder.Mode())).Msg("Recording or replaying HTTP interactions")
+	return recorder.Wrap(next), nil
+}
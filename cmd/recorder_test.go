@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/vcr"
+)
+
+// useRecorder makes the providers built by the test use recorder.
+func useRecorder(t *testing.T, recorder *vcr.Recorder) {
+	t.Helper()
+	previous := envRecorder
+	envRecorder = func() (*vcr.Recorder, error) { return recorder, nil }
+	t.Cleanup(func() { envRecorder = previous })
+}
+
+func TestNewProvider_RecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/chat/completions":
+			fmt.Fprintln(w, `{"choices": [{"index": 0, "message": {"role": "assistant", "content": "{\"summary\": \"Fix login\", \"project_name_suggestion\": \"Backend\"}"}}]}`)
+		case "/create_jira_issue":
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintln(w, `{"key": "BE-1", "self": "https://jira.example.com/browse/BE-1"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	cfg := &config.AppConfig{
+		MCPServerURL: server.URL,
+		LLM:          config.LLMConfig{Provider: "openai", OpenAI: config.OpenAIConfig{ModelName: "gpt-4o", BaseURL: server.URL + "/v1"}},
+	}
+	cassette := filepath.Join(t.TempDir(), "cassette.yaml")
+
+	run := func(mode vcr.Mode) (string, string) {
+		recorder, err := vcr.New(mode, cassette)
+		require.NoError(t, err)
+		useRecorder(t, recorder)
+		provider, err := NewProvider(WithConfigProvider(newDoctorConfigProvider(cfg)))
+		require.NoError(t, err)
+		require.Empty(t, provider.InitErrors)
+
+		resp, err := provider.LLM.GenerateTicketDetails(context.Background(), "login broken", "prompt", "")
+		require.NoError(t, err)
+		created, err := provider.MCP.CreateIssue(context.Background(), mcpclient.CreateIssueRequest{ProjectKey: "BE", Summary: resp.Summary, IssueType: "Bug"})
+		require.NoError(t, err)
+		return resp.Summary, created.Key
+	}
+
+	summary, key := run(vcr.ModeRecord)
+	assert.Equal(t, "Fix login", summary)
+	assert.Equal(t, "BE-1", key)
+	server.Close()
+
+	summary, key = run(vcr.ModeReplay)
+	assert.Equal(t, "Fix login", summary, "the LLM response is replayed")
+	assert.Equal(t, "BE-1", key, "the MCP response is replayed")
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/karolswdev/ticketron/internal/redact"
+)
+
+// previewRedactions prints the input exactly as it would be sent to the LLM and lists every
+// redaction applied to the input and context.md. It never calls the LLM.
+func previewRedactions(out io.Writer, loadedCfgs *loadedConfigs, userInput string) error {
+	if loadedCfgs.redactor == nil {
+		fmt.Fprintln(out, "Redaction is disabled. Enable it with 'redaction.enabled: true' in config.yaml.")
+		return nil
+	}
+	redactedInput, inputRedactions := loadedCfgs.redactor.Redact(userInput)
+	_, contextRedactions := loadedCfgs.redactor.Redact(loadedCfgs.contextData)
+
+	fmt.Fprintf(out, "Input sent to the LLM:\n%s\n\n", redactedInput)
+	printRedactions(out, "input", inputRedactions)
+	printRedactions(out, "context.md", contextRedactions)
+	return nil
+}
+
+func printRedactions(out io.Writer, source string, redactions []redact.Redaction) {
+	if len(redactions) == 0 {
+		fmt.Fprintf(out, "No redactions in %s.\n", source)
+		return
+	}
+	fmt.Fprintf(out, "Redactions in %s (%d):\n", source, len(redactions))
+	for _, r := range redactions {
+		fmt.Fprintf(out, "  %-14s %q -> %s\n", r.Rule, r.Original, r.Replacement)
+	}
+}
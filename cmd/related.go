@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+// relatedKeyRe matches Jira issue keys such as PROJ-88: an upper-case project key of at
+// least two characters, a hyphen and an issue number.
+var relatedKeyRe = regexp.MustCompile(`\b[A-Z][A-Z0-9_]+-[1-9][0-9]*\b`)
+
+// maxRelatedIssues bounds the number of keys looked up for one request.
+const maxRelatedIssues = 10
+
+// defaultLinkType is the Jira link type of auto_link.issue_links when link_type is unset.
+const defaultLinkType = "Relates"
+
+// relatedIssuesHeading introduces the list of related issues in descriptions.
+const relatedIssuesHeading = "## Related issues"
+
+// findIssueKeys returns the distinct issue keys in text in order of appearance, at most
+// maxRelatedIssues of them.
+func findIssueKeys(text string) []string {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, key := range relatedKeyRe.FindAllString(text, -1) {
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		keys = append(keys, key)
+		if len(keys) == maxRelatedIssues {
+			break
+		}
+	}
+	return keys
+}
+
+// resolveRelatedIssues looks up the issue keys mentioned in text and returns the issues
+// that exist. Keys that cannot be found, e.g. version numbers like UTF-8, are skipped.
+func resolveRelatedIssues(ctx context.Context, mcpClient MCPClient, text string) []mcpclient.Issue {
+	if mcpClient == nil {
+		return nil
+	}
+	var issues []mcpclient.Issue
+	for _, key := range findIssueKeys(text) {
+		issue, err := mcpClient.GetIssue(ctx, key)
+		if err != nil || issue == nil {
+			Log.Debug().Err(err).Str("issue_key", key).Msg("Mentioned issue key not found, ignoring it")
+			continue
+		}
+		if issue.Key == "" {
+			issue.Key = key
+		}
+		issues = append(issues, *issue)
+	}
+	return issues
+}
+
+// addRelatedIssues applies auto_link to request: the issues whose keys userInput mentions
+// are listed in the description and/or recorded in request.LinkTo, to be linked once the
+// issue is created.
+func addRelatedIssues(ctx context.Context, mcpClient MCPClient, cfg config.AutoLinkConfig, userInput string, request *mcpclient.CreateIssueRequest) {
+	if !cfg.Description && !cfg.IssueLinks {
+		return
+	}
+	issues := resolveRelatedIssues(ctx, mcpClient, userInput)
+	if len(issues) == 0 {
+		return
+	}
+	if cfg.Description {
+		request.Description = appendRelatedIssues(request.Description, issues)
+	}
+	if cfg.IssueLinks {
+		for _, issue := range issues {
+			request.LinkTo = append(request.LinkTo, issue.Key)
+		}
+		request.LinkType = cfg.LinkType
+		if request.LinkType == "" {
+			request.LinkType = defaultLinkType
+		}
+	}
+	Log.Debug().Int("related_issues", len(issues)).Msg("Added issues mentioned in the request")
+}
+
+// appendRelatedIssues appends a "Related issues" section listing issues, with links to
+// Jira where their URL is known, to description.
+func appendRelatedIssues(description string, issues []mcpclient.Issue) string {
+	var b strings.Builder
+	b.WriteString(strings.TrimRight(description, "\n"))
+	if b.Len() > 0 {
+		b.WriteString("\n\n")
+	}
+	b.WriteString(relatedIssuesHeading + "\n")
+	for _, issue := range issues {
+		b.WriteString("\n- ")
+		if url := browseURL(issue.Self, issue.Key); url != "" {
+			b.WriteString("[" + issue.Key + "](" + url + ")")
+		} else {
+			b.WriteString(issue.Key)
+		}
+		if summary := strings.TrimSpace(issue.Fields.Summary); summary != "" {
+			b.WriteString(": " + summary)
+		}
+	}
+	return b.String()
+}
+
+// browseURL derives the web URL of an issue from the URL of its Jira REST resource
+// (https://jira.example.com/rest/api/2/issue/10001). It returns "" for other URLs.
+func browseURL(self, key string) string {
+	base, _, found := strings.Cut(self, "/rest/api/")
+	if !found || base == "" {
+		return ""
+	}
+	return base + "/browse/" + key
+}
+
+// linkRelatedIssues links the issues in request.LinkTo to the newly created issue.
+// Failures are logged and do not fail the creation. It returns the number of links created.
+func linkRelatedIssues(ctx context.Context, mcpClient MCPClient, issueKey string, request mcpclient.CreateIssueRequest) int {
+	linkType := request.LinkType
+	if linkType == "" {
+		linkType = defaultLinkType
+	}
+	linked := 0
+	for _, key := range request.LinkTo {
+		if key == issueKey {
+			continue
+		}
+		err := mcpClient.LinkIssues(ctx, mcpclient.LinkIssuesRequest{Type: linkType, InwardIssue: key, OutwardIssue: issueKey})
+		if err != nil {
+			Log.Warn().Err(err).Str("issue_key", issueKey).Str("related_key", key).Msg("Failed to link related issue")
+			continue
+		}
+		linked++
+	}
+	if linked > 0 {
+		Log.Info().Str("issue_key", issueKey).Int("links", linked).Str("link_type", linkType).Msg("Linked related issues")
+	}
+	return linked
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+func TestFindIssueKeys(t *testing.T) {
+	assert.Equal(t, []string{"PROJ-88", "BE_2-7"}, findIssueKeys("Related to PROJ-88 and BE_2-7, see PROJ-88 again"))
+	assert.Empty(t, findIssueKeys("Version 1-2, key A-1, proj-5 and PROJ-0 are not keys"))
+	assert.Empty(t, findIssueKeys("XPROJ-88Y"))
+}
+
+func TestAddRelatedIssues(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("GetIssue", mock.Anything, "PROJ-88").Return(&mcpclient.Issue{
+		Key: "PROJ-88", Self: "https://jira.example.com/rest/api/2/issue/10088",
+		Fields: mcpclient.IssueFields{Summary: "Login times out"},
+	}, nil).Once()
+	mockMCP.On("GetIssue", mock.Anything, "UTF-8").Return(nil, mcpclient.ErrMCPServerError).Once()
+	mockMCP.On("GetIssue", mock.Anything, "OPS-3").Return(&mcpclient.Issue{Key: "OPS-3"}, nil).Once()
+
+	request := mcpclient.CreateIssueRequest{Description: "The login page hangs.\n"}
+	cfg := config.AutoLinkConfig{Description: true, IssueLinks: true}
+	addRelatedIssues(context.Background(), mockMCP, cfg, "Login hangs, related to PROJ-88 and OPS-3 (UTF-8 input)", &request)
+
+	assert.Equal(t, "The login page hangs.\n\n## Related issues\n\n"+
+		"- [PROJ-88](https://jira.example.com/browse/PROJ-88): Login times out\n"+
+		"- OPS-3", request.Description)
+	assert.Equal(t, []string{"PROJ-88", "OPS-3"}, request.LinkTo)
+	assert.Equal(t, defaultLinkType, request.LinkType)
+	mockMCP.AssertExpectations(t)
+
+	unchanged := mcpclient.CreateIssueRequest{Description: "Text"}
+	addRelatedIssues(context.Background(), mockMCP, config.AutoLinkConfig{}, "PROJ-88", &unchanged)
+	assert.Equal(t, mcpclient.CreateIssueRequest{Description: "Text"}, unchanged, "auto_link is disabled by default")
+}
+
+func TestLinkRelatedIssues(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("LinkIssues", mock.Anything, mcpclient.LinkIssuesRequest{Type: "Blocks", InwardIssue: "PROJ-88", OutwardIssue: "PROJ-100"}).Return(nil).Once()
+	mockMCP.On("LinkIssues", mock.Anything, mcpclient.LinkIssuesRequest{Type: "Blocks", InwardIssue: "OPS-3", OutwardIssue: "PROJ-100"}).Return(mcpclient.ErrMCPServerError).Once()
+
+	request := mcpclient.CreateIssueRequest{LinkTo: []string{"PROJ-88", "PROJ-100", "OPS-3"}, LinkType: "Blocks"}
+	assert.Equal(t, 1, linkRelatedIssues(context.Background(), mockMCP, "PROJ-100", request), "failures and self-links are skipped")
+	mockMCP.AssertExpectations(t)
+}
+
+func TestSubmitIssue_LinksRelatedIssues(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	runner, _ := newMCPServeTestRunner(mockMCP)
+	request := mcpclient.CreateIssueRequest{ProjectKey: "BE", Summary: "S", IssueType: "Bug", LinkTo: []string{"BE-1"}}
+	mockMCP.On("CreateIssue", mock.Anything, mock.Anything).Return(&mcpclient.CreateIssueResponse{Key: "BE-2"}, nil).Once()
+	mockMCP.On("LinkIssues", mock.Anything, mcpclient.LinkIssuesRequest{Type: defaultLinkType, InwardIssue: "BE-1", OutwardIssue: "BE-2"}).Return(nil).Once()
+
+	_, resp, err := runner.submitIssue(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, "BE-2", resp.Key)
+	mockMCP.AssertExpectations(t)
+}
@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/output"
+	"github.com/karolswdev/ticketron/internal/reldate"
+	"github.com/karolswdev/ticketron/internal/remind"
+	"github.com/karolswdev/ticketron/internal/sanitize"
+)
+
+// reminderTimeLayout formats due times in command output.
+const reminderTimeLayout = "2006-01-02 15:04 MST"
+
+// desktopNotifier shows a desktop notification. Tests replace it.
+var desktopNotifier = notifyDesktop
+
+// parseReminderArgs splits the arguments following the issue key into the time expression
+// and the note. The time is the longest leading run of arguments that parses, so both
+// `in 3d "note"` and `"in 3d" note` work.
+func parseReminderArgs(args []string, now time.Time) (time.Time, string, error) {
+	for n := len(args); n > 0; n-- {
+		if due, err := reldate.ParseTime(strings.Join(args[:n], " "), now); err == nil {
+			return due, strings.Join(args[n:], " "), nil
+		}
+	}
+	return time.Time{}, "", fmt.Errorf("could not understand when to remind you in %q; use e.g. \"in 3d\", \"in 2 hours\", tomorrow, \"next friday\" or 2025-05-01", strings.Join(args, " "))
+}
+
+// remindAddRunE records a reminder for issueKey.
+func remindAddRunE(store *remind.Store, issueKey string, args []string, now time.Time, out io.Writer) error {
+	if !issueKeyRe.MatchString(issueKey) {
+		return fmt.Errorf("invalid issue key %q (expected e.g. PROJ-123)", issueKey)
+	}
+	due, note, err := parseReminderArgs(args, now)
+	if err != nil {
+		return err
+	}
+	if !due.After(now) {
+		return fmt.Errorf("the reminder time %s is not in the future", due.Format(reminderTimeLayout))
+	}
+	reminder, err := store.Add(strings.ToUpper(issueKey), note, due, now)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Reminder #%d for %s set for %s.\n", reminder.ID, reminder.IssueKey, due.Format(reminderTimeLayout))
+	return nil
+}
+
+// remindListRunE prints reminders: only the overdue ones with dueOnly, else all pending.
+// With notify, a desktop notification is shown for every overdue reminder not notified
+// before, so running it regularly notifies each reminder once.
+func remindListRunE(ctx context.Context, store *remind.Store, now time.Time, dueOnly, notify bool, outputFormat string, plain bool, out, errOut io.Writer) error {
+	var reminders []remind.Reminder
+	var err error
+	if dueOnly {
+		reminders, err = store.Due(now)
+	} else {
+		reminders, err = store.List()
+	}
+	if err != nil {
+		return err
+	}
+
+	if notify {
+		var notified []int
+		for _, r := range reminders {
+			if !r.Overdue(now) || r.NotifiedAt != nil {
+				continue
+			}
+			if err := desktopNotifier(ctx, "Ticketron: "+r.IssueKey, reminderText(r)); err != nil {
+				fmt.Fprintf(errOut, "Warning: could not show a desktop notification: %v\n", err)
+				break
+			}
+			notified = append(notified, r.ID)
+		}
+		if len(notified) > 0 {
+			if err := store.MarkNotified(notified, now); err != nil {
+				return err
+			}
+		}
+	}
+
+	if output.IsStructured(outputFormat) {
+		if reminders == nil {
+			reminders = []remind.Reminder{}
+		}
+		return output.Structured(out, outputFormat, reminders)
+	}
+	if len(reminders) == 0 {
+		if dueOnly {
+			fmt.Fprintln(out, "No reminders are due.")
+		} else {
+			fmt.Fprintln(out, "No reminders. Add one with 'tix remind PROJ-123 in 3d \"note\"'.")
+		}
+		return nil
+	}
+	table := output.NewTable("ID", "Issue", "Due", "Note")
+	for _, r := range reminders {
+		due := r.Due.In(now.Location()).Format(reminderTimeLayout)
+		if !dueOnly && r.Overdue(now) {
+			due += " (overdue)"
+		}
+		table.Row(strconv.Itoa(r.ID), r.IssueKey, due, sanitize.Line(r.Note))
+	}
+	return table.Render(out, plain)
+}
+
+// remindDoneRunE removes the reminders with the given IDs.
+func remindDoneRunE(store *remind.Store, ids []string, out io.Writer) error {
+	var errs []error
+	for _, arg := range ids {
+		id, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid reminder ID %q", arg))
+			continue
+		}
+		reminder, err := store.Done(id)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		fmt.Fprintf(out, "Completed reminder #%d for %s.\n", reminder.ID, reminder.IssueKey)
+	}
+	return errors.Join(errs...)
+}
+
+// reminderText is the body of a reminder notification.
+func reminderText(r remind.Reminder) string {
+	if r.Note == "" {
+		return "Follow up on " + r.IssueKey
+	}
+	return r.Note
+}
+
+// notifyDesktop shows a desktop notification with notify-send on Linux and osascript on
+// macOS.
+func notifyDesktop(ctx context.Context, title, message string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "linux", "freebsd", "openbsd":
+		cmd = exec.CommandContext(ctx, "notify-send", "--app-name=Ticketron", title, message)
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", strconv.Quote(message), strconv.Quote(title))
+		cmd = exec.CommandContext(ctx, "osascript", "-e", script)
+	default:
+		return fmt.Errorf("desktop notifications are not supported on %s", runtime.GOOS)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s failed: %w: %s", cmd.Path, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// remindCommandContext returns the reminder store and the current time in the configured
+// time zone.
+func remindCommandContext() (*remind.Store, time.Time, error) {
+	provider, err := GetProvider()
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to initialize services: %w", err)
+	}
+	configDir, err := provider.Config.EnsureConfigDir()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	now, err := configuredNow(provider.Config)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return remind.NewStore(configDir), now, nil
+}
+
+// remindCmd represents the remind command
+var remindCmd = &cobra.Command{
+	Use:   "remind <ISSUE-KEY> <when> [note]",
+	Short: "Remind yourself to follow up on an issue",
+	Long: `Stores a reminder to follow up on an issue in ~/.ticketron/reminders.json. The time
+is relative ("in 3d", "in 2 hours", tomorrow, "next friday") or absolute
+("2025-05-01 09:00"), in the time zone set by timezone in config.yaml.
+
+'tix remind due' lists the reminders that are due; run it from your shell profile or,
+with --notify, from cron to get a desktop notification.`,
+	Example: `  tix remind PROJ-123 in 3d "check if fix shipped"
+  tix remind due
+  tix remind done 4`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, now, err := remindCommandContext()
+		if err != nil {
+			return err
+		}
+		return remindAddRunE(store, args[0], args[1:], now, cmd.OutOrStdout())
+	},
+}
+
+// remindDueCmd represents the remind due command
+var remindDueCmd = &cobra.Command{
+	Use:   "due",
+	Short: "List the reminders that are due",
+	Long: `Lists the reminders whose time has come. With --notify, also shows a desktop
+notification (notify-send on Linux, osascript on macOS) for each of them once.`,
+	Example: `  # crontab -e
+  */15 * * * * DISPLAY=:0 tix remind due --notify >/dev/null`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, now, err := remindCommandContext()
+		if err != nil {
+			return err
+		}
+		notify, _ := cmd.Flags().GetBool("notify")
+		outputFormat, _ := cmd.Flags().GetString("output")
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return remindListRunE(ctx, store, now, true, notify, outputFormat, plainOutput(cmd), cmd.OutOrStdout(), cmd.ErrOrStderr())
+	},
+}
+
+// remindListCmd represents the remind list command
+var remindListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all pending reminders",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, now, err := remindCommandContext()
+		if err != nil {
+			return err
+		}
+		outputFormat, _ := cmd.Flags().GetString("output")
+		return remindListRunE(context.Background(), store, now, false, false, outputFormat, plainOutput(cmd), cmd.OutOrStdout(), cmd.ErrOrStderr())
+	},
+}
+
+// remindDoneCmd represents the remind done command
+var remindDoneCmd = &cobra.Command{
+	Use:   "done <ID>...",
+	Short: "Complete reminders",
+	Long:  `Removes the reminders with the given IDs, as shown by 'tix remind list'.`,
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, _, err := remindCommandContext()
+		if err != nil {
+			return err
+		}
+		return remindDoneRunE(store, args, cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	remindDueCmd.Flags().Bool("notify", false, "Show a desktop notification for each due reminder (once per reminder)")
+
+	remindCmd.AddCommand(remindDueCmd)
+	remindCmd.AddCommand(remindListCmd)
+	remindCmd.AddCommand(remindDoneCmd)
+	rootCmd.AddCommand(remindCmd)
+}
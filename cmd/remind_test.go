@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/remind"
+)
+
+func TestParseReminderArgs(t *testing.T) {
+	now := time.Date(2025, time.April, 16, 14, 30, 0, 0, time.UTC)
+	tests := []struct {
+		args []string
+		due  time.Time
+		note string
+	}{
+		{[]string{"in", "3d", "check if fix shipped"}, now.AddDate(0, 0, 3), "check if fix shipped"},
+		{[]string{"in 2 hours", "ping", "QA"}, now.Add(2 * time.Hour), "ping QA"},
+		{[]string{"tomorrow"}, time.Date(2025, time.April, 17, 0, 0, 0, 0, time.UTC), ""},
+		{[]string{"2025-05-01", "09:00", "release"}, time.Date(2025, time.May, 1, 9, 0, 0, 0, time.UTC), "release"},
+	}
+	for _, tt := range tests {
+		due, note, err := parseReminderArgs(tt.args, now)
+		require.NoError(t, err, tt.args)
+		assert.Equal(t, tt.due, due, tt.args)
+		assert.Equal(t, tt.note, note, tt.args)
+	}
+
+	_, _, err := parseReminderArgs([]string{"someday", "maybe"}, now)
+	assert.Error(t, err)
+}
+
+func TestRemindAddRunE(t *testing.T) {
+	store := remind.NewStore(t.TempDir())
+	now := time.Date(2025, time.April, 16, 14, 30, 0, 0, time.UTC)
+	var out bytes.Buffer
+
+	require.NoError(t, remindAddRunE(store, "proj-123", []string{"in", "3d", "check if fix shipped"}, now, &out))
+	assert.Equal(t, "Reminder #1 for PROJ-123 set for 2025-04-19 14:30 UTC.\n", out.String())
+
+	assert.ErrorContains(t, remindAddRunE(store, "not a key", []string{"in 3d"}, now, &out), "invalid issue key")
+	assert.ErrorContains(t, remindAddRunE(store, "PROJ-1", []string{"2025-01-01"}, now, &out), "not in the future")
+}
+
+func TestRemindListRunE_DueAndNotify(t *testing.T) {
+	store := remind.NewStore(t.TempDir())
+	now := time.Date(2025, time.April, 16, 14, 30, 0, 0, time.UTC)
+	_, err := store.Add("BE-1", "check if fix shipped", now.Add(-time.Hour), now.Add(-48*time.Hour))
+	require.NoError(t, err)
+	_, err = store.Add("BE-2", "", now.Add(time.Hour), now)
+	require.NoError(t, err)
+
+	var notifications []string
+	original := desktopNotifier
+	t.Cleanup(func() { desktopNotifier = original })
+	desktopNotifier = func(_ context.Context, title, message string) error {
+		notifications = append(notifications, title+": "+message)
+		return nil
+	}
+
+	var out, errOut bytes.Buffer
+	require.NoError(t, remindListRunE(context.Background(), store, now, true, true, "text", false, &out, &errOut))
+	assert.Contains(t, out.String(), "BE-1")
+	assert.NotContains(t, out.String(), "BE-2")
+	assert.Equal(t, []string{"Ticketron: BE-1: check if fix shipped"}, notifications)
+
+	// Each reminder is notified once
+	require.NoError(t, remindListRunE(context.Background(), store, now, true, true, "text", false, &out, &errOut))
+	assert.Len(t, notifications, 1)
+
+	// A failing notifier only warns
+	desktopNotifier = func(context.Context, string, string) error { return errors.New("notify-send not found") }
+	require.NoError(t, remindListRunE(context.Background(), store, now.Add(2*time.Hour), true, true, "text", false, &out, &errOut))
+	assert.Contains(t, errOut.String(), "notify-send not found")
+
+	out.Reset()
+	require.NoError(t, remindListRunE(context.Background(), store, now, false, false, "json", false, &out, &errOut))
+	var reminders []remind.Reminder
+	require.NoError(t, json.Unmarshal(out.Bytes(), &reminders))
+	assert.Len(t, reminders, 2)
+
+	out.Reset()
+	require.NoError(t, remindListRunE(context.Background(), store, now, false, false, "text", false, &out, &errOut))
+	assert.Contains(t, out.String(), "2025-04-16 13:30 UTC (overdue)")
+}
+
+func TestRemindDoneRunE(t *testing.T) {
+	store := remind.NewStore(t.TempDir())
+	now := time.Date(2025, time.April, 16, 14, 30, 0, 0, time.UTC)
+	_, err := store.Add("BE-1", "", now, now)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	err = remindDoneRunE(store, []string{"#1", "7", "x"}, &out)
+	assert.Equal(t, "Completed reminder #1 for BE-1.\n", out.String())
+	assert.ErrorIs(t, err, remind.ErrNotFound)
+	assert.ErrorContains(t, err, `invalid reminder ID "x"`)
+}
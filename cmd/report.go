@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/llm"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/output"
+	"github.com/karolswdev/ticketron/internal/redact"
+	"github.com/karolswdev/ticketron/internal/relnotes"
+)
+
+// releaseNotesTemplateFile is the prompt template in the configuration directory that
+// replaces the built-in one.
+const releaseNotesTemplateFile = "release_notes.tmpl"
+
+// reportPageSize is the number of issues requested per MCP search call.
+const reportPageSize = 100
+
+// releaseNotesOptions holds the settings of 'tix report release'.
+type releaseNotesOptions struct {
+	jql          string
+	version      string // Version named in the notes; detected from the query when empty
+	template     string // Prompt template text
+	maxResults   int
+	noLLM        bool
+	redactor     *redact.Redactor // Applied to the prompt; nil when redaction is disabled
+	outputFormat string
+}
+
+// releaseNotesResult is the structured output of 'tix report release'.
+type releaseNotesResult struct {
+	Version string           `json:"version,omitempty" yaml:"version,omitempty"`
+	JQL     string           `json:"jql" yaml:"jql"`
+	Count   int              `json:"count" yaml:"count"`
+	Groups  []relnotes.Group `json:"groups" yaml:"groups"`
+	Notes   string           `json:"notes" yaml:"notes"`
+}
+
+// reportReleaseRunE fetches the issues matching opts.jql and prints release notes for
+// them, written by the LLM or, with opts.noLLM, listed per section.
+func reportReleaseRunE(ctx context.Context, mcpClient MCPClient, llmClient llm.Client, opts releaseNotesOptions, out, errOut io.Writer) error {
+	if mcpClient == nil {
+		return errMCPClientNotInitialized
+	}
+	if !opts.noLLM && llmClient == nil {
+		return fmt.Errorf("LLM client not initialized. Check configuration (provider, API key) or use --no-llm")
+	}
+
+	issues, err := searchReportIssues(ctx, mcpClient, opts.jql, opts.maxResults, errOut)
+	if err != nil {
+		return err
+	}
+	if len(issues) == 0 {
+		return fmt.Errorf("no issues match %q", opts.jql)
+	}
+
+	version := opts.version
+	if version == "" {
+		version = relnotes.Version(opts.jql)
+	}
+	data := relnotes.Build(version, opts.jql, issues)
+
+	var notes string
+	if opts.noLLM {
+		notes = relnotes.Markdown(data)
+	} else {
+		prompt, err := relnotes.RenderPrompt(opts.template, data)
+		if err != nil {
+			return err
+		}
+		prompt, redactions := opts.redactor.Redact(prompt)
+		Log.Debug().Int("issues", len(issues)).Int("redactions", len(redactions)).Msg("Asking the LLM for release notes")
+		reply, err := llm.GenerateText(ctx, llmClient, []llm.Message{
+			{Role: llm.RoleSystem, Content: relnotes.SystemPrompt},
+			{Role: llm.RoleUser, Content: prompt},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to generate release notes: %w", err)
+		}
+		notes = llm.StripCodeFence(reply)
+	}
+
+	if output.IsStructured(opts.outputFormat) {
+		return output.Structured(out, opts.outputFormat, releaseNotesResult{
+			Version: version,
+			JQL:     opts.jql,
+			Count:   len(issues),
+			Groups:  data.Groups,
+			Notes:   notes,
+		})
+	}
+	fmt.Fprintln(out, notes)
+	return nil
+}
+
+// searchReportIssues returns up to maxResults (0 for all) issues matching jql, fetched page
+// by page. A warning is written to errOut if more issues match.
+func searchReportIssues(ctx context.Context, mcpClient MCPClient, jql string, maxResults int, errOut io.Writer) ([]mcpclient.Issue, error) {
+	var issues []mcpclient.Issue
+	total := 0
+	for {
+		pageSize := reportPageSize
+		if maxResults > 0 && maxResults-len(issues) < pageSize {
+			pageSize = maxResults - len(issues)
+		}
+		resp, err := mcpClient.SearchIssues(ctx, mcpclient.SearchIssuesRequest{JQL: jql, MaxResults: pageSize, StartAt: len(issues)})
+		if err != nil {
+			return nil, fmt.Errorf("failed to search issues (startAt %d): %w", len(issues), err)
+		}
+		issues = append(issues, resp.Issues...)
+		total = resp.Total
+		if len(resp.Issues) == 0 || len(issues) >= resp.Total || (maxResults > 0 && len(issues) >= maxResults) {
+			break
+		}
+	}
+	if total > len(issues) {
+		fmt.Fprintf(errOut, "Warning: only the first %d of %d issues are included; raise --max-results to include all.\n", len(issues), total)
+	}
+	return issues, nil
+}
+
+// loadReportTemplate returns the prompt template at path if given, else the file name in
+// configDir if it exists, else builtin.
+func loadReportTemplate(path, configDir, name, builtin string) (string, error) {
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read template: %w", err)
+		}
+		return string(data), nil
+	}
+	data, err := os.ReadFile(filepath.Join(configDir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return builtin, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read template: %w", err)
+	}
+	return string(data), nil
+}
+
+// reportCmd represents the report command group
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate reports from JIRA issues",
+}
+
+// reportReleaseCmd represents the report release command
+var reportReleaseCmd = &cobra.Command{
+	Use:   "release --jql QUERY",
+	Short: "Write release notes for the issues of a release",
+	Long: `Fetches the issues matching a JQL query and has the LLM write release notes in
+Markdown, grouped into Features, Fixes and Chores. Issues are suggested a section by
+their type: stories, features and improvements are features, bugs are fixes and
+everything else is a chore.
+
+The prompt is a Go template. Use --template or place release_notes.tmpl in the
+configuration directory to replace the built-in one; 'tix report release --show-template'
+prints it as a starting point. The configured redaction rules are applied to the prompt.
+
+With --no-llm the issues are listed per section without the LLM.`,
+	Example: `  tix report release --jql 'fixVersion = 1.2.0'
+  tix report release --jql 'project = BE AND resolved >= -14d' --version 2025.04 > NOTES.md
+  tix report release --jql 'fixVersion = 1.2.0' --no-llm`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if show, _ := cmd.Flags().GetBool("show-template"); show {
+			fmt.Fprint(cmd.OutOrStdout(), relnotes.DefaultTemplate)
+			return nil
+		}
+		var opts releaseNotesOptions
+		opts.jql, _ = cmd.Flags().GetString("jql")
+		if opts.jql == "" {
+			return fmt.Errorf("--jql is required")
+		}
+		opts.version, _ = cmd.Flags().GetString("version")
+		opts.maxResults, _ = cmd.Flags().GetInt("max-results")
+		opts.noLLM, _ = cmd.Flags().GetBool("no-llm")
+		opts.outputFormat, _ = cmd.Flags().GetString("output")
+
+		runner, err := newCreateCmdRunner()
+		if err != nil {
+			return err
+		}
+		if !opts.noLLM {
+			if err := runner.applyLLMOverrides(cmd); err != nil {
+				return err
+			}
+			loadedCfgs, err := loadAllConfigs(runner.configProvider)
+			if err != nil {
+				return err
+			}
+			opts.redactor = loadedCfgs.redactor
+			configDir, err := runner.configProvider.EnsureConfigDir()
+			if err != nil {
+				return err
+			}
+			templatePath, _ := cmd.Flags().GetString("template")
+			if opts.template, err = loadReportTemplate(templatePath, configDir, releaseNotesTemplateFile, relnotes.DefaultTemplate); err != nil {
+				return err
+			}
+		}
+
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return reportReleaseRunE(ctx, runner.mcpClient, runner.llmClient, opts, cmd.OutOrStdout(), cmd.ErrOrStderr())
+	},
+}
+
+func init() {
+	reportReleaseCmd.Flags().String("jql", "", "JQL query selecting the issues of the release")
+	reportReleaseCmd.Flags().String("version", "", "Version named in the notes (default: the fixVersion of the query)")
+	reportReleaseCmd.Flags().String("template", "", "Prompt template file (default: release_notes.tmpl in the configuration directory, else built in)")
+	reportReleaseCmd.Flags().Bool("show-template", false, "Print the built-in prompt template and exit")
+	reportReleaseCmd.Flags().Int("max-results", 1000, "Maximum number of issues to include (0 for all)")
+	reportReleaseCmd.Flags().Bool("no-llm", false, "List the issues per section without the LLM")
+	addLLMOverrideFlags(reportReleaseCmd)
+
+	reportCmd.AddCommand(reportReleaseCmd)
+	rootCmd.AddCommand(reportCmd)
+}
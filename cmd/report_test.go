@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/llm"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/redact"
+	"github.com/karolswdev/ticketron/internal/relnotes"
+)
+
+func releaseTestIssues() []mcpclient.Issue {
+	issue := func(key, issueType, summary string) mcpclient.Issue {
+		return mcpclient.Issue{Key: key, Fields: mcpclient.IssueFields{
+			Summary: summary, IssueType: mcpclient.IssueType{Name: issueType}, Status: mcpclient.Status{Name: "Done"},
+		}}
+	}
+	return []mcpclient.Issue{
+		issue("BE-1", "Bug", "Fix login for alice@example.com"),
+		issue("BE-2", "Story", "Dark mode"),
+		issue("BE-3", "Task", "Bump Go"),
+	}
+}
+
+func TestReportReleaseRunE_LLM(t *testing.T) {
+	const jql = "fixVersion = 1.2.0"
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("SearchIssues", mock.Anything, mcpclient.SearchIssuesRequest{JQL: jql, MaxResults: reportPageSize}).
+		Return(&mcpclient.SearchIssuesResponse{Total: 3, Issues: releaseTestIssues()}, nil)
+	mockLLM := new(MockLLMClient)
+	mockLLM.On("GenerateText", mock.Anything, mock.MatchedBy(func(messages []llm.Message) bool {
+		return len(messages) == 2 && messages[0].Role == llm.RoleSystem &&
+			messages[1].Content == "v1.2.0: Features BE-2 Fixes BE-1 Fix login for [REDACTED:email] Chores BE-3"
+	})).Return("```markdown\n## Features\n- Dark mode (BE-2)\n```", nil)
+	redactor, err := redact.New(config.RedactionConfig{Enabled: true, Builtins: []string{"email"}})
+	require.NoError(t, err)
+
+	var out, errOut bytes.Buffer
+	opts := releaseNotesOptions{
+		jql:      jql,
+		template: "v{{.Version}}:{{range .Groups}} {{.Category}}{{range .Items}} {{.Key}}{{if eq .Type \"Bug\"}} {{.Summary}}{{end}}{{end}}{{end}}",
+		redactor: redactor,
+	}
+	require.NoError(t, reportReleaseRunE(context.Background(), mockMCP, mockLLM, opts, &out, &errOut))
+	assert.Equal(t, "## Features\n- Dark mode (BE-2)\n", out.String(), "the code fence is removed")
+	assert.Empty(t, errOut.String())
+	mockLLM.AssertExpectations(t)
+}
+
+func TestReportReleaseRunE_NoLLM(t *testing.T) {
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("SearchIssues", mock.Anything, mcpclient.SearchIssuesRequest{JQL: "project = BE", MaxResults: 2}).
+		Return(&mcpclient.SearchIssuesResponse{Total: 5, Issues: releaseTestIssues()[:2]}, nil)
+
+	var out, errOut bytes.Buffer
+	opts := releaseNotesOptions{jql: "project = BE", version: "2025.04", maxResults: 2, noLLM: true}
+	require.NoError(t, reportReleaseRunE(context.Background(), mockMCP, nil, opts, &out, &errOut))
+	assert.Equal(t, "# Release 2025.04\n\n## Features\n\n- Dark mode (BE-2)\n\n## Fixes\n\n- Fix login for alice@example.com (BE-1)\n", out.String())
+	assert.Contains(t, errOut.String(), "only the first 2 of 5 issues")
+}
+
+func TestReportReleaseRunE_Structured(t *testing.T) {
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("SearchIssues", mock.Anything, mock.Anything).
+		Return(&mcpclient.SearchIssuesResponse{Total: 1, Issues: releaseTestIssues()[2:]}, nil)
+
+	var out bytes.Buffer
+	opts := releaseNotesOptions{jql: `fixVersion = "1.3"`, noLLM: true, outputFormat: "json"}
+	require.NoError(t, reportReleaseRunE(context.Background(), mockMCP, nil, opts, &out, &bytes.Buffer{}))
+	assert.JSONEq(t, `{
+		"version": "1.3",
+		"jql": "fixVersion = \"1.3\"",
+		"count": 1,
+		"groups": [{"category": "Chores", "items": [{"key": "BE-3", "summary": "Bump Go", "type": "Task", "status": "Done"}]}],
+		"notes": "# Release 1.3\n\n## Chores\n\n- Bump Go (BE-3)"
+	}`, out.String())
+}
+
+func TestReportReleaseRunE_Errors(t *testing.T) {
+	err := reportReleaseRunE(context.Background(), nil, nil, releaseNotesOptions{noLLM: true}, &bytes.Buffer{}, &bytes.Buffer{})
+	assert.ErrorIs(t, err, errMCPClientNotInitialized)
+
+	mockMCP := new(MockMCPClient)
+	err = reportReleaseRunE(context.Background(), mockMCP, nil, releaseNotesOptions{jql: "x"}, &bytes.Buffer{}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "LLM client not initialized")
+
+	mockMCP.On("SearchIssues", mock.Anything, mcpclient.SearchIssuesRequest{JQL: "empty", MaxResults: reportPageSize}).
+		Return(&mcpclient.SearchIssuesResponse{}, nil)
+	err = reportReleaseRunE(context.Background(), mockMCP, nil, releaseNotesOptions{jql: "empty", noLLM: true}, &bytes.Buffer{}, &bytes.Buffer{})
+	assert.EqualError(t, err, `no issues match "empty"`)
+
+	mockMCP.On("SearchIssues", mock.Anything, mcpclient.SearchIssuesRequest{JQL: "project = BE", MaxResults: reportPageSize}).
+		Return(&mcpclient.SearchIssuesResponse{Total: 3, Issues: releaseTestIssues()}, nil)
+	mockLLM := new(MockLLMClient)
+	opts := releaseNotesOptions{jql: "project = BE", template: "{{.Nope}}"}
+	err = reportReleaseRunE(context.Background(), mockMCP, mockLLM, opts, &bytes.Buffer{}, &bytes.Buffer{})
+	assert.ErrorIs(t, err, relnotes.ErrTemplate)
+
+	mockLLM.On("GenerateText", mock.Anything, mock.Anything).Return("", errors.New("rate limited"))
+	opts.template = relnotes.DefaultTemplate
+	err = reportReleaseRunE(context.Background(), mockMCP, mockLLM, opts, &bytes.Buffer{}, &bytes.Buffer{})
+	assert.EqualError(t, err, "failed to generate release notes: rate limited")
+}
+
+func TestLoadReportTemplate(t *testing.T) {
+	dir := t.TempDir()
+	text, err := loadReportTemplate("", dir, releaseNotesTemplateFile, relnotes.DefaultTemplate)
+	require.NoError(t, err)
+	assert.Equal(t, relnotes.DefaultTemplate, text)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, releaseNotesTemplateFile), []byte("configured"), 0o600))
+	text, err = loadReportTemplate("", dir, releaseNotesTemplateFile, relnotes.DefaultTemplate)
+	require.NoError(t, err)
+	assert.Equal(t, "configured", text)
+
+	explicit := filepath.Join(t.TempDir(), "notes.tmpl")
+	require.NoError(t, os.WriteFile(explicit, []byte("explicit"), 0o600))
+	text, err = loadReportTemplate(explicit, dir, releaseNotesTemplateFile, relnotes.DefaultTemplate)
+	require.NoError(t, err)
+	assert.Equal(t, "explicit", text)
+
+	_, err = loadReportTemplate(filepath.Join(dir, "missing.tmpl"), dir, releaseNotesTemplateFile, relnotes.DefaultTemplate)
+	assert.ErrorContains(t, err, "failed to read template")
+}
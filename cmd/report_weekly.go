@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/llm"
+	"github.com/karolswdev/ticketron/internal/output"
+	"github.com/karolswdev/ticketron/internal/redact"
+	"github.com/karolswdev/ticketron/internal/reldate"
+	"github.com/karolswdev/ticketron/internal/weekly"
+)
+
+// weeklyReportTemplateFile is the prompt template in the configuration directory that
+// replaces the built-in one.
+const weeklyReportTemplateFile = "weekly_report.tmpl"
+
+// weeklyReportOptions holds the settings of 'tix report weekly'.
+type weeklyReportOptions struct {
+	since        string
+	assignees    []string // Users whose issues are covered; yourself when empty
+	projects     []string
+	team         bool   // Cover all issues of the projects instead of the assignees' ones
+	template     string // Prompt template text
+	maxResults   int    // Per list of issues
+	noLLM        bool
+	redactor     *redact.Redactor // Applied to the prompt; nil when redaction is disabled
+	outPath      string           // Write the report to this file instead of out
+	outputFormat string
+}
+
+// weeklyReportResult is the structured output of 'tix report weekly'.
+type weeklyReportResult struct {
+	Scope   string        `json:"scope" yaml:"scope"`
+	Since   time.Time     `json:"since" yaml:"since"`
+	Until   time.Time     `json:"until" yaml:"until"`
+	Closed  []weekly.Item `json:"closed" yaml:"closed"`
+	Created []weekly.Item `json:"created" yaml:"created"`
+	Report  string        `json:"report" yaml:"report"`
+}
+
+// scope returns the JQL clause selecting whose issues are covered and its description.
+func (o weeklyReportOptions) scope() (string, string, error) {
+	filters := jqlFilters{projects: o.projects}
+	var description string
+	switch {
+	case o.team:
+		if len(nonEmpty(o.projects)) == 0 {
+			return "", "", fmt.Errorf("--team requires --project")
+		}
+		description = "the team of " + strings.Join(nonEmpty(o.projects), ", ")
+	case len(nonEmpty(o.assignees)) == 0:
+		filters.assignees = []string{"me"}
+		description = "you"
+	default:
+		filters.assignees = o.assignees
+		description = strings.Join(nonEmpty(o.assignees), ", ")
+	}
+	jql, err := buildJQL("", filters)
+	return jql, description, err
+}
+
+// reportWeeklyRunE fetches the issues created and resolved since opts.since and writes an
+// activity report on them, summarized by the LLM or, with opts.noLLM, listed.
+func reportWeeklyRunE(ctx context.Context, mcpClient MCPClient, llmClient llm.Client, opts weeklyReportOptions, now time.Time, out, errOut io.Writer) error {
+	if mcpClient == nil {
+		return errMCPClientNotInitialized
+	}
+	if !opts.noLLM && llmClient == nil {
+		return fmt.Errorf("LLM client not initialized. Check configuration (provider, API key) or use --no-llm")
+	}
+	since, err := reldate.Parse(opts.since, now)
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	scopeJQL, scope, err := opts.scope()
+	if err != nil {
+		return err
+	}
+	sinceDate := jqlQuote(since.In(now.Location()).Format(time.DateOnly))
+	createdJQL := fmt.Sprintf("%s AND created >= %s ORDER BY created ASC", scopeJQL, sinceDate)
+	closedJQL := fmt.Sprintf("%s AND resolutiondate >= %s ORDER BY resolutiondate ASC", scopeJQL, sinceDate)
+	Log.Debug().Str("created_jql", createdJQL).Str("closed_jql", closedJQL).Msg("Built weekly report queries")
+
+	created, err := searchReportIssues(ctx, mcpClient, createdJQL, opts.maxResults, errOut)
+	if err != nil {
+		return err
+	}
+	closed, err := searchReportIssues(ctx, mcpClient, closedJQL, opts.maxResults, errOut)
+	if err != nil {
+		return err
+	}
+	data := weekly.Build(scope, since, now, created, closed)
+
+	var report string
+	if opts.noLLM || len(created)+len(closed) == 0 {
+		report = weekly.Markdown(data)
+	} else {
+		prompt, err := weekly.RenderPrompt(opts.template, data)
+		if err != nil {
+			return err
+		}
+		prompt, redactions := opts.redactor.Redact(prompt)
+		Log.Debug().Int("created", len(created)).Int("closed", len(closed)).Int("redactions", len(redactions)).Msg("Asking the LLM for the weekly report")
+		reply, err := llm.GenerateText(ctx, llmClient, []llm.Message{
+			{Role: llm.RoleSystem, Content: weekly.SystemPrompt},
+			{Role: llm.RoleUser, Content: prompt},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to generate the report: %w", err)
+		}
+		report = llm.StripCodeFence(reply)
+	}
+
+	var buf bytes.Buffer
+	if output.IsStructured(opts.outputFormat) {
+		err = output.Structured(&buf, opts.outputFormat, weeklyReportResult{
+			Scope:   scope,
+			Since:   data.Since,
+			Until:   data.Until,
+			Closed:  data.Closed,
+			Created: data.Created,
+			Report:  report,
+		})
+		if err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintln(&buf, report)
+	}
+	if opts.outPath == "" {
+		_, err = out.Write(buf.Bytes())
+		return err
+	}
+	if err := os.WriteFile(opts.outPath, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write the report: %w", err)
+	}
+	fmt.Fprintf(errOut, "Wrote the report to %s\n", opts.outPath)
+	return nil
+}
+
+// reportWeeklyCmd represents the report weekly command
+var reportWeeklyCmd = &cobra.Command{
+	Use:   "weekly",
+	Short: "Summarize the issues created and closed in the last week",
+	Long: `Fetches the issues created and the issues resolved in a period, a week by default,
+and has the LLM summarize them into a Markdown report to share with the team.
+
+By default your own issues are covered: those assigned to you. Use --assignee to report
+on other users and --team with --project to report on all issues of a project.
+
+The prompt is a Go template. Use --template or place weekly_report.tmpl in the
+configuration directory to replace the built-in one; --show-template prints it as a
+starting point. The configured redaction rules are applied to the prompt.
+
+With --no-llm the issues are listed without the LLM.`,
+	Example: `  tix report weekly
+  tix report weekly --team --project BE --out weekly.md
+  tix report weekly --assignee alice,bob --since 2w --no-llm`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if show, _ := cmd.Flags().GetBool("show-template"); show {
+			fmt.Fprint(cmd.OutOrStdout(), weekly.DefaultTemplate)
+			return nil
+		}
+		var opts weeklyReportOptions
+		opts.since, _ = cmd.Flags().GetString("since")
+		opts.assignees, _ = cmd.Flags().GetStringSlice("assignee")
+		opts.projects, _ = cmd.Flags().GetStringSlice("project")
+		opts.team, _ = cmd.Flags().GetBool("team")
+		opts.maxResults, _ = cmd.Flags().GetInt("max-results")
+		opts.noLLM, _ = cmd.Flags().GetBool("no-llm")
+		opts.outPath, _ = cmd.Flags().GetString("out")
+		opts.outputFormat, _ = cmd.Flags().GetString("output")
+
+		runner, err := newCreateCmdRunner()
+		if err != nil {
+			return err
+		}
+		now, err := configuredNow(runner.configProvider)
+		if err != nil {
+			return err
+		}
+		if !opts.noLLM {
+			if err := runner.applyLLMOverrides(cmd); err != nil {
+				return err
+			}
+			loadedCfgs, err := loadAllConfigs(runner.configProvider)
+			if err != nil {
+				return err
+			}
+			opts.redactor = loadedCfgs.redactor
+			configDir, err := runner.configProvider.EnsureConfigDir()
+			if err != nil {
+				return err
+			}
+			templatePath, _ := cmd.Flags().GetString("template")
+			if opts.template, err = loadReportTemplate(templatePath, configDir, weeklyReportTemplateFile, weekly.DefaultTemplate); err != nil {
+				return err
+			}
+		}
+
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return reportWeeklyRunE(ctx, runner.mcpClient, runner.llmClient, opts, now, cmd.OutOrStdout(), cmd.ErrOrStderr())
+	},
+}
+
+func init() {
+	reportWeeklyCmd.Flags().String("since", "7d", "Start of the period, e.g. 7d, 2w, \"last monday\" or 2025-04-01")
+	reportWeeklyCmd.Flags().StringSlice("assignee", nil, "Report on the issues of these users (default: yourself)")
+	_ = reportWeeklyCmd.RegisterFlagCompletionFunc("assignee", completeAssignees)
+	reportWeeklyCmd.Flags().StringSlice("project", nil, "Only issues in these projects")
+	reportWeeklyCmd.Flags().Bool("team", false, "Report on all issues of --project instead of your own")
+	reportWeeklyCmd.Flags().String("out", "", "Write the report to this file instead of standard output")
+	reportWeeklyCmd.Flags().String("template", "", "Prompt template file (default: weekly_report.tmpl in the configuration directory, else built in)")
+	reportWeeklyCmd.Flags().Bool("show-template", false, "Print the built-in prompt template and exit")
+	reportWeeklyCmd.Flags().Int("max-results", 500, "Maximum number of created and of closed issues to include (0 for all)")
+	reportWeeklyCmd.Flags().Bool("no-llm", false, "List the issues without the LLM")
+	addLLMOverrideFlags(reportWeeklyCmd)
+
+	reportCmd.AddCommand(reportWeeklyCmd)
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/llm"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/weekly"
+)
+
+var weeklyTestNow = time.Date(2025, time.April, 16, 14, 30, 0, 0, time.UTC)
+
+func weeklyTestMCP(scope string) *MockMCPClient {
+	issue := func(key, status, summary string) mcpclient.Issue {
+		return mcpclient.Issue{Key: key, Fields: mcpclient.IssueFields{
+			Summary: summary, Status: mcpclient.Status{Name: status}, IssueType: mcpclient.IssueType{Name: "Task"},
+		}}
+	}
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("SearchIssues", mock.Anything, mcpclient.SearchIssuesRequest{
+		JQL:        scope + ` AND created >= "2025-04-09" ORDER BY created ASC`,
+		MaxResults: reportPageSize,
+	}).Return(&mcpclient.SearchIssuesResponse{Total: 1, Issues: []mcpclient.Issue{issue("BE-3", "To Do", "Bump Go")}}, nil)
+	mockMCP.On("SearchIssues", mock.Anything, mcpclient.SearchIssuesRequest{
+		JQL:        scope + ` AND resolutiondate >= "2025-04-09" ORDER BY resolutiondate ASC`,
+		MaxResults: reportPageSize,
+	}).Return(&mcpclient.SearchIssuesResponse{Total: 1, Issues: []mcpclient.Issue{issue("BE-1", "Done", "Fix login")}}, nil)
+	return mockMCP
+}
+
+func TestReportWeeklyRunE_LLM(t *testing.T) {
+	mockMCP := weeklyTestMCP("assignee = currentUser()")
+	mockLLM := new(MockLLMClient)
+	mockLLM.On("GenerateText", mock.Anything, []llm.Message{
+		{Role: llm.RoleSystem, Content: weekly.SystemPrompt},
+		{Role: llm.RoleUser, Content: "you 2025-04-09 to 2025-04-16: BE-1 / BE-3"},
+	}).Return("# Week 16\n\nLogin fixed (BE-1).", nil)
+
+	var out, errOut bytes.Buffer
+	opts := weeklyReportOptions{
+		since:    "7d",
+		template: "{{.Scope}} {{.Period}}:{{range .Closed}} {{.Key}}{{end}} /{{range .Created}} {{.Key}}{{end}}",
+	}
+	require.NoError(t, reportWeeklyRunE(context.Background(), mockMCP, mockLLM, opts, weeklyTestNow, &out, &errOut))
+	assert.Equal(t, "# Week 16\n\nLogin fixed (BE-1).\n", out.String())
+	assert.Empty(t, errOut.String())
+	mockMCP.AssertExpectations(t)
+	mockLLM.AssertExpectations(t)
+}
+
+func TestReportWeeklyRunE_TeamToFile(t *testing.T) {
+	mockMCP := weeklyTestMCP(`project in ("BE", "OPS")`)
+	path := filepath.Join(t.TempDir(), "weekly.md")
+
+	var out, errOut bytes.Buffer
+	opts := weeklyReportOptions{since: "7d", projects: []string{"BE", "OPS"}, team: true, noLLM: true, outPath: path}
+	require.NoError(t, reportWeeklyRunE(context.Background(), mockMCP, nil, opts, weeklyTestNow, &out, &errOut))
+	assert.Empty(t, out.String())
+	assert.Equal(t, "Wrote the report to "+path+"\n", errOut.String())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `# Activity report: 2025-04-09 to 2025-04-16
+
+1 issue(s) closed and 1 created for the team of BE, OPS.
+
+## Done
+
+- Fix login (BE-1)
+
+## New
+
+- Bump Go (BE-3, To Do)
+`, string(data))
+}
+
+func TestReportWeeklyRunE_Structured(t *testing.T) {
+	mockMCP := weeklyTestMCP(`assignee in ("alice", "bob")`)
+
+	var out bytes.Buffer
+	opts := weeklyReportOptions{since: "7d", assignees: []string{"alice", "bob"}, noLLM: true, outputFormat: "json"}
+	require.NoError(t, reportWeeklyRunE(context.Background(), mockMCP, nil, opts, weeklyTestNow, &out, &bytes.Buffer{}))
+	var result weeklyReportResult
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+	assert.Equal(t, "alice, bob", result.Scope)
+	assert.Equal(t, weeklyTestNow, result.Until)
+	require.Len(t, result.Closed, 1)
+	assert.Equal(t, "BE-1", result.Closed[0].Key)
+	assert.Contains(t, result.Report, "for alice, bob.")
+}
+
+func TestReportWeeklyRunE_NothingToSummarize(t *testing.T) {
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("SearchIssues", mock.Anything, mock.Anything).Return(&mcpclient.SearchIssuesResponse{}, nil)
+	mockLLM := new(MockLLMClient)
+
+	var out bytes.Buffer
+	opts := weeklyReportOptions{since: "7d", template: weekly.DefaultTemplate}
+	require.NoError(t, reportWeeklyRunE(context.Background(), mockMCP, mockLLM, opts, weeklyTestNow, &out, &bytes.Buffer{}))
+	assert.Contains(t, out.String(), "0 issue(s) closed and 0 created for you.")
+	mockLLM.AssertNotCalled(t, "GenerateText", mock.Anything, mock.Anything)
+}
+
+func TestReportWeeklyRunE_Errors(t *testing.T) {
+	mockMCP := new(MockMCPClient)
+	run := func(opts weeklyReportOptions) error {
+		return reportWeeklyRunE(context.Background(), mockMCP, nil, opts, weeklyTestNow, &bytes.Buffer{}, &bytes.Buffer{})
+	}
+	assert.ErrorIs(t, reportWeeklyRunE(context.Background(), nil, nil, weeklyReportOptions{noLLM: true}, weeklyTestNow, &bytes.Buffer{}, &bytes.Buffer{}), errMCPClientNotInitialized)
+	assert.ErrorContains(t, run(weeklyReportOptions{since: "7d"}), "LLM client not initialized")
+	assert.ErrorContains(t, run(weeklyReportOptions{since: "soon", noLLM: true}), "invalid --since")
+	assert.EqualError(t, run(weeklyReportOptions{since: "7d", team: true, noLLM: true}), "--team requires --project")
+}
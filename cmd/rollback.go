@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/karolswdev/ticketron/internal/i18n"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/prompt"
+)
+
+// What commands creating a set of issues do with the created issues when part of the set
+// fails (--on-failure).
+const (
+	onFailureAsk    = "ask"    // Ask whether to delete them
+	onFailureDelete = "delete" // Delete them
+	onFailureCancel = "cancel" // Transition them to defaultCancelTransition
+	onFailureKeep   = "keep"   // Keep them
+)
+
+// parseOnFailure validates an --on-failure value; empty means onFailureAsk.
+func parseOnFailure(value string) (string, error) {
+	switch mode := strings.ToLower(strings.TrimSpace(value)); mode {
+	case "":
+		return onFailureAsk, nil
+	case onFailureAsk, onFailureDelete, onFailureCancel, onFailureKeep:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid --on-failure %q: use ask, delete, cancel or keep", value)
+	}
+}
+
+// resolveRollback turns onFailureAsk into onFailureDelete or onFailureKeep by asking on out
+// whether to delete the created issues. Without a terminal to ask on, and with --yes, they
+// are kept: deleting issues unasked takes an explicit --on-failure delete.
+func resolveRollback(confirmer *prompt.Confirmer, mode string, created, failed int, out io.Writer) (string, error) {
+	if mode != onFailureAsk {
+		return mode, nil
+	}
+	if confirmer.NoInput || confirmer.AssumeYes {
+		return onFailureKeep, nil
+	}
+	ok, err := confirmer.Confirm(out, i18n.T(i18n.MsgConfirmRollback, failed, created), false)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return onFailureKeep, nil
+	}
+	return onFailureDelete, nil
+}
+
+// rollbackIssues deletes the issues keys, or transitions them to defaultCancelTransition
+// with onFailureCancel, and returns the keys it rolled back. Failures are reported on
+// errOut; the issues concerned are left as they are.
+func rollbackIssues(ctx context.Context, mcpClient MCPClient, mode string, keys []string, reason string, out, errOut io.Writer) []string {
+	var rolledBack []string
+	failed := false
+	for _, key := range keys {
+		var err error
+		if mode == onFailureCancel {
+			err = mcpClient.TransitionIssue(ctx, key, mcpclient.TransitionIssueRequest{Transition: defaultCancelTransition, Comment: reason})
+		} else {
+			err = mcpClient.DeleteIssue(ctx, key)
+		}
+		if err != nil {
+			failed = true
+			Log.Error().Err(err).Str("issue_key", key).Str("mode", mode).Msg("Failed to roll back issue")
+			fmt.Fprintf(errOut, "Failed to roll back %s: %v\n", key, err)
+			continue
+		}
+		rolledBack = append(rolledBack, key)
+		if mode == onFailureCancel {
+			fmt.Fprintf(out, "Rolled back %s (transition %q)\n", key, defaultCancelTransition)
+		} else {
+			fmt.Fprintf(out, "Rolled back %s (deleted)\n", key)
+		}
+	}
+	if failed && mode != onFailureCancel {
+		fmt.Fprintln(errOut, i18n.T(i18n.MsgRollbackHint))
+	}
+	return rolledBack
+}
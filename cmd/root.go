@@ -9,12 +9,18 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/clierr"
+	"github.com/karolswdev/ticketron/internal/i18n"
 )
 
 // version is set during build time (e.g., via ldflags)
-// Default is "dev" for local development.
+// Default is "dev" for local development. See version.go for the other build metadata.
 var version = "dev"
 
+// versionTemplate is printed by --version; 'tix version' shows the full build information.
+const versionTemplate = "{{.Version}}\n"
+
 var (
 	logLevel string
 	// Log is the globally configured zerolog logger instance used throughout the cmd package.
@@ -22,11 +28,12 @@ var (
 	Log zerolog.Logger
 )
 
-// configureLogger sets up the global zerolog logger based on the logLevel flag.
+// configureLogger sets up the global zerolog logger based on the logLevel flag; noColor
+// disables colored log levels (--plain).
 // This is extracted to be reusable by both the package-level rootCmd and NewRootCmd.
-func configureLogger(levelStr string) error {
+func configureLogger(levelStr string, noColor bool) error {
 	// Configure logger
-	output := zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.RFC3339}
+	output := zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.RFC3339, NoColor: noColor}
 	log.Logger = log.Output(output) // Use zerolog's global logger temporarily for setup
 
 	// Set global log level
@@ -46,14 +53,29 @@ func configureLogger(levelStr string) error {
 
 // persistentPreRunLogic contains the logic for PersistentPreRunE, reusable by NewRootCmd.
 func persistentPreRunLogic(cmd *cobra.Command, args []string) error {
-	// Handle --version flag
-	showVersion, _ := cmd.Flags().GetBool("version") // Error ignored, default is false
-	if showVersion {
-		fmt.Println(version)
-		os.Exit(0) // Exit after showing version, as Cobra does not handle this automatically in PersistentPreRunE
-	}
+	applyCIMode(cmd)
 	// Configure logger using the bound logLevel variable
-	return configureLogger(logLevel)
+	if err := configureLogger(logLevel, plainOutput(cmd) || ciEnabled); err != nil {
+		return err
+	}
+	if target, _ := cmd.Flags().GetString("pprof"); target != "" {
+		stop, err := startProfiling(target)
+		if err != nil {
+			return err
+		}
+		stopProfiling = stop
+	}
+	return startJQ(cmd)
+}
+
+// plainFlagUsage describes the persistent --plain flag.
+const plainFlagUsage = "Screen-reader friendly output: no colors, styles, box drawing or tables"
+
+// plainOutput reports whether --plain was given. Commands then print labeled lines instead
+// of tables (see output.Table) and never use ANSI styles.
+func plainOutput(cmd *cobra.Command) bool {
+	plain, _ := cmd.Flags().GetBool("plain")
+	return plain
 }
 
 // rootCmd represents the base command when called without any subcommands
@@ -63,18 +85,47 @@ var rootCmd = &cobra.Command{
 	Long: `Ticketron (tix) is a CLI tool designed to quickly create JIRA issues
 based on brief user input, leveraging LLMs for detail generation and
 an MCP server for JIRA interaction.`,
+	Version:           version,               // --version is handled by Cobra
 	PersistentPreRunE: persistentPreRunLogic, // Use the extracted logic
 }
 
 // Execute is the main entry point for the Cobra CLI application.
 // It parses command-line arguments, executes the appropriate command (rootCmd or one of its subcommands),
 // handles flag parsing, and manages error reporting. This function is typically called directly from main.main().
+// A panic is turned into a crash report (see recoverCrash) instead of a raw stack trace.
+// In CI mode the error is written to stderr as JSON (see writeCIError), and in a GitHub
+// Actions workflow also as an ::error annotation.
 func Execute() {
-	err := rootCmd.Execute()
+	defer recoverCrash()
+	ciEnabled = ciFromEnv() // Until --ci is parsed, e.g. for invalid flags
+	rootCmd.SilenceErrors = ciEnabled
+	rootCmd.SilenceUsage = ciEnabled
+	cmd, err := rootCmd.ExecuteC()
+	if jqErr := finishJQ(); err == nil {
+		err = jqErr // A failed command's output is incomplete anyway
+	}
+	stopProfiling()
 	if err != nil {
+		reportCommandError(os.Stderr, cmd.CommandPath(), err)
+		explanation := ""
+		if explainRequested(cmd, err) {
+			var explainErr error
+			if explanation, explainErr = explainCommandError(cmd, err); explainErr != nil {
+				Log.Warn().Err(explainErr).Msg("Could not explain the error")
+			}
+		}
+		if ciEnabled {
+			writeCIError(os.Stderr, cmd.CommandPath(), err, explanation)
+			os.Exit(1)
+		}
+		clierr.Render(os.Stderr, err) // After cobra's "Error:" line
+		if explanation != "" {
+			fmt.Fprintln(os.Stderr, "\n"+i18n.T(i18n.MsgExplainHeading))
+			fmt.Fprintln(os.Stderr, explanation)
+		}
 		// Ensure logger is initialized even if PersistentPreRunE failed early
 		if Log.GetLevel() == zerolog.Disabled {
-			_ = configureLogger("info") // Use default level if logger wasn't set up
+			_ = configureLogger("info", false) // Use default level if logger wasn't set up
 		}
 		Log.Error().Err(err).Msg("Command execution failed") // Use logger for errors
 		os.Exit(1)
@@ -97,14 +148,9 @@ an MCP server for JIRA interaction.`,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			// Get flags directly from this command instance
 			lvl, _ := cmd.Flags().GetString("log-level")
-			showVersion, _ := cmd.Flags().GetBool("version")
-
-			if showVersion {
-				fmt.Println(version)
-				os.Exit(0)
-			}
+			applyCIMode(cmd)
 			// Configure logger using the flag value from *this* command
-			return configureLogger(lvl)
+			return configureLogger(lvl, plainOutput(cmd) || ciEnabled)
 		},
 	}
 
@@ -112,8 +158,14 @@ an MCP server for JIRA interaction.`,
 	// Use local variables to avoid conflicts with package-level flag bindings
 	var instanceLogLevel string
 	newCmd.PersistentFlags().StringVar(&instanceLogLevel, "log-level", "info", "Set log level (debug, info, warn, error, fatal, panic)")
-	newCmd.PersistentFlags().Bool("version", false, "Show application version")
+	newCmd.Version = version
+	newCmd.SetVersionTemplate(versionTemplate)
 	newCmd.PersistentFlags().StringP("output", "o", "text", "Output format (text|json)")
+	newCmd.PersistentFlags().Bool("plain", false, plainFlagUsage)
+	newCmd.PersistentFlags().BoolP("yes", "y", false, yesFlagUsage)
+	newCmd.PersistentFlags().Bool("no-input", false, noInputFlagUsage)
+	newCmd.PersistentFlags().Bool("ci", false, ciFlagUsage)
+	newCmd.PersistentFlags().Bool("explain", false, explainFlagUsage)
 
 	// Add subcommands (ensure subcommands are also initialized correctly if needed)
 	// We need to add the *initialized* subcommand variables from their respective files.
@@ -121,6 +173,7 @@ an MCP server for JIRA interaction.`,
 	newCmd.AddCommand(createCmd) // Assuming createCmd is initialized in create.go's init()
 	newCmd.AddCommand(searchCmd) // Assuming searchCmd is initialized in search.go's init()
 	newCmd.AddCommand(completionCmd)
+	newCmd.AddCommand(versionCmd)
 
 	return newCmd
 }
@@ -187,8 +240,16 @@ PowerShell:
 func init() {
 	// Define flags for the package-level rootCmd
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "Set log level (debug, info, warn, error, fatal, panic)")
-	rootCmd.PersistentFlags().Bool("version", false, "Show application version")
+	rootCmd.SetVersionTemplate(versionTemplate)
 	rootCmd.PersistentFlags().StringP("output", "o", "text", "Output format (text|json)")
+	rootCmd.PersistentFlags().Bool("plain", false, plainFlagUsage)
+	rootCmd.PersistentFlags().BoolP("yes", "y", false, yesFlagUsage)
+	rootCmd.PersistentFlags().Bool("no-input", false, noInputFlagUsage)
+	rootCmd.PersistentFlags().Bool("ci", false, ciFlagUsage)
+	rootCmd.PersistentFlags().Bool("explain", false, explainFlagUsage)
+	rootCmd.PersistentFlags().String("pprof", "", pprofFlagUsage)
+	_ = rootCmd.PersistentFlags().MarkHidden("pprof") // For diagnosing slow commands, not everyday use
+	rootCmd.PersistentFlags().String("jq", "", jqFlagUsage)
 
 	// Add child commands to the package-level rootCmd
 	// Subcommands like createCmd, searchCmd, configCmd are added via their own init() functions.
@@ -0,0 +1,296 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/i18n"
+	"github.com/karolswdev/ticketron/internal/llm"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/prompt"
+	"github.com/karolswdev/ticketron/internal/sanitize"
+	"github.com/karolswdev/ticketron/internal/todoscan"
+)
+
+// scanOptions holds the settings of a scan run.
+type scanOptions struct {
+	scan              todoscan.Options
+	projectKey        string
+	issueType         string
+	descriptionFormat string
+	noLLM             bool // Use the comment as the summary
+	create            bool // Create the proposed issues
+	rewrite           bool // Add the created keys to the comments
+	limit             int  // Maximum number of untracked comments to propose tickets for; 0 for all
+	outputFormat      string
+}
+
+// scanResult reports what was proposed or done for an untracked comment.
+type scanResult struct {
+	File      string `json:"file"`
+	Line      int    `json:"line"`
+	Tag       string `json:"tag"`
+	Text      string `json:"text"`
+	Project   string `json:"project,omitempty"`
+	IssueType string `json:"issue_type,omitempty"`
+	Summary   string `json:"summary,omitempty"`
+	Key       string `json:"key,omitempty"`
+	Rewritten bool   `json:"rewritten,omitempty"`
+	Error     string `json:"error,omitempty"`
+
+	request mcpclient.CreateIssueRequest
+	input   string
+}
+
+// scanRunE scans root for TODO-style comments and proposes an issue for each untracked
+// one. With opts.create the issues are created after confirmation and, with opts.rewrite,
+// their keys are added to the comments. A failing comment does not stop the others; an
+// error is returned at the end if any failed.
+func scanRunE(ctx context.Context, runner *createCmdRunner, confirmer *prompt.Confirmer, root string, opts scanOptions, out, errOut io.Writer) error {
+	if opts.rewrite && !opts.create {
+		return errors.New("--rewrite needs --create")
+	}
+	if opts.create && runner.mcpClient == nil {
+		return errMCPClientNotInitialized
+	}
+	todos, err := todoscan.Scan(root, opts.scan)
+	if err != nil {
+		return err
+	}
+	var untracked []todoscan.Todo
+	for _, todo := range todos {
+		if !todo.Tracked() {
+			untracked = append(untracked, todo)
+		}
+	}
+	Log.Info().Int("comments", len(todos)).Int("untracked", len(untracked)).Msg("Scanned for TODO comments")
+	if opts.limit > 0 && len(untracked) > opts.limit {
+		untracked = untracked[:opts.limit]
+	}
+
+	cfgs, err := loadAllConfigs(runner.configProvider)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	results := make([]scanResult, 0, len(untracked))
+	for _, todo := range untracked {
+		results = append(results, runner.proposeScanIssue(ctx, cfgs, todo, opts))
+	}
+
+	if opts.create {
+		proposed := 0
+		for _, res := range results {
+			if res.Error == "" {
+				proposed++
+			}
+		}
+		if proposed > 0 {
+			if err := writeScanResults(out, results, len(todos), opts, false); err != nil {
+				return err
+			}
+			ok, err := confirmer.Confirm(out, i18n.T(i18n.MsgConfirmScan, proposed), false)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				fmt.Fprintln(out, i18n.T(i18n.MsgAborted))
+				return nil
+			}
+			for i := range results {
+				runner.createScanIssue(ctx, cfgs, root, untracked[i], &results[i], opts, errOut)
+			}
+		}
+	}
+
+	if err := writeScanResults(out, results, len(todos), opts, opts.create); err != nil {
+		return err
+	}
+	failures := 0
+	for _, res := range results {
+		if res.Error != "" {
+			failures++
+		}
+	}
+	if failures > 0 {
+		return fmt.Errorf("%d of %d comments failed", failures, len(results))
+	}
+	return nil
+}
+
+// proposeScanIssue builds the create request for an untracked comment: the LLM writes it
+// from the comment and the code following it, or with opts.noLLM the comment is the summary.
+// The description ends with the location of the comment either way.
+func (r *createCmdRunner) proposeScanIssue(ctx context.Context, cfgs *loadedConfigs, todo todoscan.Todo, opts scanOptions) scanResult {
+	res := scanResult{File: todo.File, Line: todo.Line, Tag: todo.Tag, Text: todo.Text, input: scanInput(todo)}
+	reqOpts := issueRequestOptions{projectKey: opts.projectKey, issueType: opts.issueType, descriptionFormat: opts.descriptionFormat}
+	var err error
+	if opts.noLLM {
+		if todo.Text == "" {
+			res.Error = "the comment has no text to use as the summary"
+			return res
+		}
+		reqOpts.summary = sanitize.Truncate(sanitize.Line(todo.Text), llm.DefaultSummaryMaxLength)
+		reqOpts.description = res.input
+		res.request, err = r.buildDirectIssueRequest(ctx, cfgs, reqOpts)
+	} else {
+		var hints bytes.Buffer
+		if res.request, err = r.buildIssueRequest(ctx, &hints, cfgs, res.input, reqOpts); err != nil {
+			err = withHints(err, hints.String())
+		} else {
+			res.request.Description = strings.TrimSpace(res.request.Description) + fmt.Sprintf("\n\nFound in %s:%d.", todo.File, todo.Line)
+		}
+	}
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	res.Project, res.IssueType, res.Summary = res.request.ProjectKey, res.request.IssueType, res.request.Summary
+	return res
+}
+
+// createScanIssue creates the proposed issue of res and, with opts.rewrite, adds its key
+// to the comment.
+func (r *createCmdRunner) createScanIssue(ctx context.Context, cfgs *loadedConfigs, root string, todo todoscan.Todo, res *scanResult, opts scanOptions, errOut io.Writer) {
+	if res.Error != "" {
+		return
+	}
+	request, resp, err := r.submitIssue(ctx, res.request)
+	if err != nil {
+		res.Error = err.Error()
+		return
+	}
+	res.Key = resp.Key
+	var systemPrompt string
+	if !opts.noLLM {
+		systemPrompt = cfgs.systemPrompt
+	}
+	r.recordHistory("scan", res.input, systemPrompt, request, resp)
+	if !opts.rewrite {
+		return
+	}
+	if err := todoscan.Rewrite(root, todo, resp.Key); err != nil {
+		Log.Warn().Err(err).Str("issue_key", resp.Key).Msg("Failed to add the issue key to the comment")
+		fmt.Fprintf(errOut, "Created %s, but could not add it to %s:%d: %v\n", resp.Key, todo.File, todo.Line, err)
+		return
+	}
+	res.Rewritten = true
+}
+
+// scanInput describes a comment and the code following it, for the LLM and as the
+// description without it.
+func scanInput(todo todoscan.Todo) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s comment in %s:%d: %s", todo.Tag, todo.File, todo.Line, todo.Text)
+	if code := strings.Trim(strings.Join(todo.Context, "\n"), "\n"); strings.TrimSpace(code) != "" {
+		fmt.Fprintf(&b, "\n\nCode following the comment:\n\n```\n%s\n```", code)
+	}
+	return b.String()
+}
+
+// writeScanResults prints the proposals, or with done what was created, as text or JSON.
+// JSON is only written once, after the issues were created if they were.
+func writeScanResults(out io.Writer, results []scanResult, scanned int, opts scanOptions, done bool) error {
+	if strings.ToLower(opts.outputFormat) == "json" {
+		if opts.create && !done {
+			return nil
+		}
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(results)
+	}
+
+	for _, res := range results {
+		location := fmt.Sprintf("%s:%d %s: %s", res.File, res.Line, res.Tag, res.Text)
+		switch {
+		case res.Error != "":
+			fmt.Fprintf(out, "%s\n  FAILED: %s\n", location, res.Error)
+		case res.Key != "" && res.Rewritten:
+			fmt.Fprintf(out, "%s\n  -> %s (comment updated)\n", location, res.Key)
+		case res.Key != "":
+			fmt.Fprintf(out, "%s\n  -> %s\n", location, res.Key)
+		case !done:
+			fmt.Fprintf(out, "%s\n  [%s] %s: %s\n", location, res.Project, res.IssueType, res.Summary)
+		}
+	}
+	if !done {
+		fmt.Fprintf(out, "%d untracked of %d comment(s).\n", len(results), scanned)
+		return nil
+	}
+	created := 0
+	for _, res := range results {
+		if res.Key != "" {
+			created++
+		}
+	}
+	fmt.Fprintf(out, "Created %d of %d issue(s).\n", created, len(results))
+	return nil
+}
+
+// scanCmd represents the scan command
+var scanCmd = &cobra.Command{
+	Use:   "scan [PATH]",
+	Short: "Propose issues for untracked TODO and FIXME comments",
+	Long: `Scans a codebase (default: the current directory) for TODO and FIXME comments and
+proposes an issue for each one that does not refer to an issue key yet, written by the
+LLM from the comment, its file and line and the code following it.
+
+  tix scan                                  # Preview the proposed issues
+  tix scan --create --rewrite --project BE  # Create them and add the keys to the comments
+
+With --rewrite, "// TODO: retry on 429" becomes "// TODO(BE-123): retry on 429", so the
+comment is tracked on the next scan. Comments such as TODO(BE-12) or mentioning a key
+are tracked already. Hidden directories, vendor, node_modules and binary files are skipped.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root := "."
+		if len(args) == 1 {
+			root = args[0]
+		}
+		var opts scanOptions
+		opts.scan.Tags, _ = cmd.Flags().GetStringSlice("tags")
+		opts.scan.Exclude, _ = cmd.Flags().GetStringSlice("exclude")
+		opts.projectKey, _ = cmd.Flags().GetString("project")
+		opts.issueType, _ = cmd.Flags().GetString("type")
+		opts.descriptionFormat, _ = cmd.Flags().GetString("description-format")
+		opts.noLLM, _ = cmd.Flags().GetBool("no-llm")
+		opts.create, _ = cmd.Flags().GetBool("create")
+		opts.rewrite, _ = cmd.Flags().GetBool("rewrite")
+		opts.limit, _ = cmd.Flags().GetInt("limit")
+		opts.outputFormat, _ = cmd.Flags().GetString("output")
+
+		runner, err := newCreateCmdRunner()
+		if err != nil {
+			return err
+		}
+		if err := runner.applyLLMOverrides(cmd); err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return scanRunE(ctx, runner, newConfirmer(cmd), root, opts, cmd.OutOrStdout(), cmd.ErrOrStderr())
+	},
+}
+
+func init() {
+	scanCmd.Flags().StringSlice("tags", todoscan.DefaultTags, "Comment tags to look for (repeatable or comma-separated)")
+	scanCmd.Flags().StringSlice("exclude", nil, "Skip files and directories matching these glob patterns, e.g. '*.pb.go' (repeatable)")
+	scanCmd.Flags().StringP("project", "p", "", "Project key or links.yaml name of the issues (default: the LLM's suggestion, else default_project)")
+	scanCmd.Flags().StringP("type", "t", "", "Issue type (default: project default or Task)")
+	scanCmd.Flags().Bool("no-llm", false, "Use the comment as the summary instead of letting the LLM write the issue")
+	scanCmd.Flags().Bool("create", false, "Create the proposed issues after confirmation (skip it with --yes)")
+	scanCmd.Flags().Bool("rewrite", false, "With --create, add the created keys to the comments, as in TODO(PROJ-123)")
+	scanCmd.Flags().Int("limit", 0, "Propose issues for at most this many untracked comments (default: all)")
+	scanCmd.Flags().String("description-format", "", "Send descriptions as text, wiki or adf (default: description_format from config.yaml, else text)")
+	addLLMOverrideFlags(scanCmd)
+
+	rootCmd.AddCommand(scanCmd)
+}
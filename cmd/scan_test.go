@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/llm"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/prompt"
+)
+
+const scanTestSource = "package api\n\n// TODO: retry on 429\nfunc call() {}\n\n// FIXME(BE-4): tracked\n"
+
+func TestScanRunE_Preview(t *testing.T) {
+	Log = zerolog.Nop()
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "api.go"), []byte(scanTestSource), 0o644))
+	runner, mockLLM := newMCPServeTestRunner(nil)
+
+	input := "TODO comment in api.go:3: retry on 429\n\nCode following the comment:\n\n```\nfunc call() {}\n\n// FIXME(BE-4): tracked\n```"
+	mockLLM.On("GenerateTicketDetails", mock.Anything, input, "prompt", mcpServeTestContext).
+		Return(llm.LLMResponse{Summary: "Retry rate-limited API calls", Description: "Calls fail on 429.", ProjectNameSuggestion: "backend"}, nil)
+
+	var out bytes.Buffer
+	err := scanRunE(context.Background(), runner, &prompt.Confirmer{NoInput: true}, root, scanOptions{}, &out, &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.Equal(t, "api.go:3 TODO: retry on 429\n  [BE] Bug: Retry rate-limited API calls\n1 untracked of 2 comment(s).\n", out.String())
+
+	err = scanRunE(context.Background(), runner, &prompt.Confirmer{NoInput: true}, root, scanOptions{rewrite: true}, &out, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "--rewrite needs --create")
+}
+
+func TestScanRunE_CreateAndRewrite(t *testing.T) {
+	Log = zerolog.Nop()
+	root := t.TempDir()
+	path := filepath.Join(root, "api.go")
+	require.NoError(t, os.WriteFile(path, []byte(scanTestSource+"# TODO:\n"), 0o644))
+	mockMCP := new(MockMCPClient)
+	runner, mockLLM := newMCPServeTestRunner(mockMCP)
+
+	mockMCP.On("CreateIssue", mock.Anything, mcpclient.CreateIssueRequest{
+		ProjectKey:  "BE",
+		Summary:     "retry on 429",
+		Description: "TODO comment in api.go:3: retry on 429\n\nCode following the comment:\n\n```\nfunc call() {}\n\n// FIXME(BE-4): tracked\n# TODO:\n```",
+		IssueType:   "Bug",
+
+		IdempotencyKey: idempotencyKey("retry on 429\nTODO comment in api.go:3: retry on 429\n\nCode following the comment:\n\n```\nfunc call() {}\n\n// FIXME(BE-4): tracked\n# TODO:\n```", "BE"),
+	}).Return(&mcpclient.CreateIssueResponse{Key: "BE-31"}, nil)
+
+	var out bytes.Buffer
+	opts := scanOptions{projectKey: "BE", noLLM: true, create: true, rewrite: true, outputFormat: "json"}
+	err := scanRunE(context.Background(), runner, &prompt.Confirmer{AssumeYes: true}, root, opts, &out, &bytes.Buffer{})
+	assert.EqualError(t, err, "1 of 2 comments failed")
+
+	jsonOut := out.String()[strings.Index(out.String(), "["):]
+	var results []scanResult
+	require.NoError(t, json.Unmarshal([]byte(jsonOut), &results))
+	require.Len(t, results, 2)
+	assert.Equal(t, "BE-31", results[0].Key)
+	assert.True(t, results[0].Rewritten)
+	assert.Equal(t, "the comment has no text to use as the summary", results[1].Error)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "// TODO(BE-31): retry on 429\n")
+	mockLLM.AssertNotCalled(t, "GenerateTicketDetails", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockMCP.AssertExpectations(t)
+}
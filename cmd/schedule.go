@@ -0,0 +1,428 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/output"
+	"github.com/karolswdev/ticketron/internal/schedule"
+)
+
+// scheduleTimeLayout formats occurrence times in command output.
+const scheduleTimeLayout = "2006-01-02 15:04 MST"
+
+// scheduleFiles returns the path of schedules.yaml and the state store of the config directory.
+func scheduleFiles(cp ConfigProvider) (string, *schedule.StateStore, error) {
+	configDir, err := cp.EnsureConfigDir()
+	if err != nil {
+		return "", nil, err
+	}
+	return filepath.Join(configDir, config.DefaultSchedulesFileName), schedule.NewStateStore(configDir), nil
+}
+
+// scheduleRunE creates the tickets of all schedules with an occurrence due since their last
+// run, at most one per schedule: after downtime only the latest missed occurrence is
+// created. Schedules seen for the first time and disabled schedules only record now, so
+// they never fire for past occurrences. The state is saved after every created ticket, so
+// an occurrence never produces a second ticket, even if the run fails later on. With
+// dryRun the tickets are generated and printed but neither created nor recorded.
+func scheduleRunE(ctx context.Context, runner *createCmdRunner, schedules *schedule.Config, store *schedule.StateStore, now time.Time, dryRun bool, out, errOut io.Writer) error {
+	if runner.mcpClient == nil && !dryRun {
+		return errMCPClientNotInitialized
+	}
+	unlock, err := store.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	state, err := store.Load()
+	if err != nil {
+		return err
+	}
+	cfgs, err := loadAllConfigs(runner.configProvider)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	changed := false
+	due, failed := 0, 0
+	for i := range schedules.Schedules {
+		def := &schedules.Schedules[i]
+		run, seen := state.Schedules[def.Name]
+		if !seen || def.Disabled {
+			if !seen {
+				Log.Info().Str("schedule", def.Name).Msg("New schedule, only occurrences from now on create tickets")
+			}
+			state.Schedules[def.Name] = schedule.Run{Last: now, IssueKey: run.IssueKey, CreatedAt: run.CreatedAt}
+			changed = true
+			continue
+		}
+		cron, err := def.Schedule()
+		if err != nil {
+			return err // Validated when the schedules were loaded
+		}
+		occurrence, ok := cron.Latest(run.Last.In(now.Location()), now)
+		if !ok {
+			continue
+		}
+		due++
+		rendered, err := def.Render(occurrence)
+		var request mcpclient.CreateIssueRequest
+		if err == nil {
+			request, err = scheduledIssueRequest(ctx, runner, cfgs, def, occurrence, rendered)
+		}
+		if err != nil {
+			failed++
+			fmt.Fprintf(errOut, "Schedule %q (%s): %v\n", def.Name, occurrence.Format(scheduleTimeLayout), err)
+			continue
+		}
+		if dryRun {
+			fmt.Fprintf(out, "Would create from schedule %q (%s): [%s] %s\n", def.Name, occurrence.Format(scheduleTimeLayout), request.ProjectKey, request.Summary)
+			continue
+		}
+		request, resp, err := runner.submitIssue(ctx, request)
+		if err != nil {
+			failed++
+			Log.Error().Err(err).Str("schedule", def.Name).Msg("Failed to create scheduled issue")
+			fmt.Fprintf(errOut, "Schedule %q (%s): %v\n", def.Name, occurrence.Format(scheduleTimeLayout), err)
+			continue
+		}
+		state.Schedules[def.Name] = schedule.Run{Last: occurrence, IssueKey: resp.Key, CreatedAt: time.Now().UTC()}
+		if err := store.Save(state); err != nil {
+			return fmt.Errorf("created %s but could not record it, so it may be created again: %w", resp.Key, err)
+		}
+		changed = false // Saved along with this ticket
+		if resp.Replayed {
+			fmt.Fprintf(out, "Already created %s from schedule %q (%s)\n", resp.Key, def.Name, occurrence.Format(scheduleTimeLayout))
+		} else {
+			fmt.Fprintf(out, "Created %s from schedule %q (%s)\n", resp.Key, def.Name, occurrence.Format(scheduleTimeLayout))
+		}
+		var systemPrompt string
+		if def.UsesLLM() {
+			systemPrompt = cfgs.systemPrompt
+		}
+		runner.recordHistory("schedule", rendered.Input, systemPrompt, request, resp)
+	}
+
+	if changed && !dryRun {
+		if err := store.Save(state); err != nil {
+			return err
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d due schedules failed", failed, due)
+	}
+	if due == 0 {
+		Log.Debug().Msg("No schedules due")
+	}
+	return nil
+}
+
+// scheduledIssueRequest builds the create request for an occurrence of def, either directly
+// from its rendered templates or by running its rendered input through the LLM. Its
+// idempotency key identifies the occurrence rather than the input, so a schedule rendering
+// the same summary every time still creates one issue per occurrence.
+func scheduledIssueRequest(ctx context.Context, runner *createCmdRunner, cfgs *loadedConfigs, def *schedule.Definition, occurrence time.Time, rendered schedule.Rendered) (mcpclient.CreateIssueRequest, error) {
+	opts := issueRequestOptions{
+		issueType:  def.IssueType,
+		projectKey: def.Project,
+		priority:   def.Priority,
+		dueDate:    def.Due,
+	}
+	var hints bytes.Buffer
+	var request mcpclient.CreateIssueRequest
+	var err error
+	if def.UsesLLM() {
+		request, err = runner.buildIssueRequest(ctx, &hints, cfgs, rendered.Input, opts)
+	} else {
+		opts.summary, opts.description = rendered.Summary, rendered.Description
+		request, err = runner.buildDirectIssueRequest(ctx, cfgs, opts)
+	}
+	if err != nil {
+		return mcpclient.CreateIssueRequest{}, withHints(err, hints.String())
+	}
+	request.IdempotencyKey = idempotencyKey("schedule "+def.Name+"\n"+occurrence.UTC().Format(time.RFC3339), request.ProjectKey)
+	return request, nil
+}
+
+// scheduleInfo is the JSON representation of a schedule listed by 'tix schedule list'.
+type scheduleInfo struct {
+	Name      string     `json:"name"`
+	Cron      string     `json:"cron"`
+	LLM       bool       `json:"llm"`
+	Disabled  bool       `json:"disabled,omitempty"`
+	Next      *time.Time `json:"next,omitempty"`
+	LastIssue string     `json:"last_issue,omitempty"`
+	LastAt    *time.Time `json:"last_created_at,omitempty"`
+}
+
+// scheduleListRunE prints the schedules with their next occurrence and the issue created last.
+func scheduleListRunE(schedules *schedule.Config, store *schedule.StateStore, now time.Time, outputFormat string, plain bool, out io.Writer) error {
+	state, err := store.Load()
+	if err != nil {
+		return err
+	}
+	infos := make([]scheduleInfo, 0, len(schedules.Schedules))
+	for i := range schedules.Schedules {
+		def := &schedules.Schedules[i]
+		info := scheduleInfo{Name: def.Name, Cron: def.Cron, LLM: def.UsesLLM(), Disabled: def.Disabled}
+		run, seen := state.Schedules[def.Name]
+		if !def.Disabled {
+			from := now
+			if seen && run.Last.Before(now) {
+				from = run.Last.In(now.Location())
+			}
+			if cron, err := def.Schedule(); err == nil {
+				if next := cron.Next(from); !next.IsZero() {
+					info.Next = &next
+				}
+			}
+		}
+		if run.IssueKey != "" {
+			info.LastIssue = run.IssueKey
+			createdAt := run.CreatedAt.In(now.Location())
+			info.LastAt = &createdAt
+		}
+		infos = append(infos, info)
+	}
+
+	if output.IsStructured(outputFormat) {
+		return output.Structured(out, outputFormat, infos)
+	}
+	if len(infos) == 0 {
+		fmt.Fprintln(out, "No schedules defined. Add one with 'tix schedule add'.")
+		return nil
+	}
+	table := output.NewTable("Name", "Cron", "Next", "Last issue")
+	for _, info := range infos {
+		next := "-"
+		switch {
+		case info.Disabled:
+			next = "disabled"
+		case info.Next != nil:
+			next = info.Next.Format(scheduleTimeLayout)
+		}
+		last := "-"
+		if info.LastIssue != "" {
+			last = fmt.Sprintf("%s (%s)", info.LastIssue, info.LastAt.Format(scheduleTimeLayout))
+		}
+		table.Row(info.Name, info.Cron, next, last)
+	}
+	return table.Render(out, plain)
+}
+
+// scheduleAddRunE adds def to schedules.yaml and records now as its last run, so only
+// occurrences from now on create tickets.
+func scheduleAddRunE(path string, store *schedule.StateStore, def schedule.Definition, now time.Time, out io.Writer) error {
+	schedules, err := schedule.LoadConfig(path)
+	if err != nil {
+		return err
+	}
+	if err := schedules.Add(def); err != nil {
+		return err
+	}
+	if err := schedules.Save(path); err != nil {
+		return err
+	}
+	state, err := store.Load()
+	if err == nil {
+		state.Schedules[def.Name] = schedule.Run{Last: now}
+		err = store.Save(state)
+	}
+	if err != nil {
+		Log.Warn().Err(err).Str("schedule", def.Name).Msg("Failed to record the new schedule, its first run will do it")
+	}
+	cron, _ := def.Schedule() // Validated by Add
+	fmt.Fprintf(out, "Added schedule %q. Next occurrence: %s.\n", def.Name, cron.Next(now).Format(scheduleTimeLayout))
+	return nil
+}
+
+// scheduleRemoveRunE removes the named schedule from schedules.yaml and forgets its state.
+func scheduleRemoveRunE(path string, store *schedule.StateStore, name string, out io.Writer) error {
+	schedules, err := schedule.LoadConfig(path)
+	if err != nil {
+		return err
+	}
+	if err := schedules.Remove(name); err != nil {
+		return err
+	}
+	if err := schedules.Save(path); err != nil {
+		return err
+	}
+	if state, err := store.Load(); err == nil {
+		delete(state.Schedules, name)
+		if err := store.Save(state); err != nil {
+			Log.Warn().Err(err).Str("schedule", name).Msg("Failed to remove the schedule's state")
+		}
+	}
+	fmt.Fprintf(out, "Removed schedule %q.\n", name)
+	return nil
+}
+
+// scheduleCommandContext returns the config provider, the schedules file path and state
+// store, and the current time in the configured time zone.
+func scheduleCommandContext() (ConfigProvider, string, *schedule.StateStore, time.Time, error) {
+	provider, err := GetProvider()
+	if err != nil {
+		return nil, "", nil, time.Time{}, fmt.Errorf("failed to initialize services: %w", err)
+	}
+	path, store, err := scheduleFiles(provider.Config)
+	if err != nil {
+		return nil, "", nil, time.Time{}, err
+	}
+	now, err := configuredNow(provider.Config)
+	if err != nil {
+		return nil, "", nil, time.Time{}, err
+	}
+	return provider.Config, path, store, now, nil
+}
+
+// scheduleCmd represents the schedule command group
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Create tickets on a recurring schedule",
+	Long: `Manages recurring ticket definitions stored in ~/.ticketron/schedules.yaml.
+Each schedule has a cron expression and either an input for the LLM or summary and
+description templates. 'tix schedule run', run regularly from cron or a systemd timer,
+creates the tickets that are due.`,
+}
+
+// scheduleAddCmd represents the schedule add command
+var scheduleAddCmd = &cobra.Command{
+	Use:   "add NAME [input...]",
+	Short: "Add a recurring ticket",
+	Long: `Adds a schedule to schedules.yaml. The arguments are the input processed by the LLM
+on every occurrence; with --summary the ticket is created from the --summary and
+--description templates without the LLM. Templates can use {{.Date}}, {{.Week}},
+{{.Name}} and {{.Time}} of the occurrence, e.g. "Weekly report {{.Date}}".
+
+The cron expression has five fields (minute, hour, day of month, month, day of week)
+and is evaluated in the time zone set by timezone in config.yaml.`,
+	Example: `  tix schedule add weekly-report --cron "0 9 * * MON" --project OPS "Prepare the weekly ops report for week {{.Week}}"
+  tix schedule add patch-day --cron "@monthly" --project SEC --summary "Apply security patches ({{.Date}})" --due "in 3 days"`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, path, store, now, err := scheduleCommandContext()
+		if err != nil {
+			return err
+		}
+		def := schedule.Definition{Name: args[0], Input: strings.Join(args[1:], " ")}
+		def.Cron, _ = cmd.Flags().GetString("cron")
+		def.Summary, _ = cmd.Flags().GetString("summary")
+		def.Description, _ = cmd.Flags().GetString("description")
+		def.Project, _ = cmd.Flags().GetString("project")
+		def.IssueType, _ = cmd.Flags().GetString("type")
+		def.Priority, _ = cmd.Flags().GetString("priority")
+		def.Due, _ = cmd.Flags().GetString("due")
+		if def.Summary != "" && def.Input != "" {
+			return fmt.Errorf("either give an input for the LLM or --summary, not both")
+		}
+		return scheduleAddRunE(path, store, def, now, cmd.OutOrStdout())
+	},
+}
+
+// scheduleListCmd represents the schedule list command
+var scheduleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List recurring tickets",
+	Long:  `Lists the schedules with their next occurrence and the issue created last.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, path, store, now, err := scheduleCommandContext()
+		if err != nil {
+			return err
+		}
+		schedules, err := schedule.LoadConfig(path)
+		if err != nil {
+			return err
+		}
+		outputFormat, _ := cmd.Flags().GetString("output")
+		return scheduleListRunE(schedules, store, now, outputFormat, plainOutput(cmd), cmd.OutOrStdout())
+	},
+}
+
+// scheduleRemoveCmd represents the schedule remove command
+var scheduleRemoveCmd = &cobra.Command{
+	Use:   "remove NAME",
+	Short: "Remove a recurring ticket",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, path, store, _, err := scheduleCommandContext()
+		if err != nil {
+			return err
+		}
+		return scheduleRemoveRunE(path, store, args[0], cmd.OutOrStdout())
+	},
+}
+
+// scheduleRunCmd represents the schedule run command
+var scheduleRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Create the recurring tickets that are due",
+	Long: `Creates a ticket for every schedule with an occurrence due since the last run. Run it
+regularly, e.g. every 5 minutes from cron or a systemd timer; each occurrence creates at
+most one ticket, and after downtime only the latest missed occurrence is created.
+Concurrent runs are prevented with a lock file.
+
+The command exits with an error if any due ticket could not be created; it is retried
+on the next run.`,
+	Example: `  # crontab -e
+  */5 * * * * tix schedule run --no-input`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		runner, err := newCreateCmdRunner()
+		if err != nil {
+			return err
+		}
+		if err := runner.applyLLMOverrides(cmd); err != nil {
+			return err
+		}
+		path, store, err := scheduleFiles(runner.configProvider)
+		if err != nil {
+			return err
+		}
+		schedules, err := schedule.LoadConfig(path)
+		if err != nil {
+			return err
+		}
+		now, err := configuredNow(runner.configProvider)
+		if err != nil {
+			return err
+		}
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return scheduleRunE(ctx, runner, schedules, store, now, dryRun, cmd.OutOrStdout(), cmd.ErrOrStderr())
+	},
+}
+
+func init() {
+	scheduleAddCmd.Flags().String("cron", "", "Cron expression, e.g. \"0 9 * * MON\" or @weekly")
+	scheduleAddCmd.Flags().StringP("summary", "s", "", "Summary template; creates the tickets without the LLM")
+	scheduleAddCmd.Flags().StringP("description", "d", "", "Description template for --summary")
+	scheduleAddCmd.Flags().StringP("project", "p", "", "Project key or links.yaml name")
+	scheduleAddCmd.Flags().StringP("type", "t", "", "Issue type")
+	scheduleAddCmd.Flags().String("priority", "", "Issue priority")
+	scheduleAddCmd.Flags().String("due", "", "Due date of the tickets, resolved when each is created, e.g. \"in 3 days\" or \"end of week\"")
+	_ = scheduleAddCmd.MarkFlagRequired("cron")
+
+	scheduleRunCmd.Flags().Bool("dry-run", false, "Show the tickets that are due without creating them")
+	addLLMOverrideFlags(scheduleRunCmd)
+
+	scheduleCmd.AddCommand(scheduleAddCmd)
+	scheduleCmd.AddCommand(scheduleListCmd)
+	scheduleCmd.AddCommand(scheduleRemoveCmd)
+	scheduleCmd.AddCommand(scheduleRunCmd)
+	rootCmd.AddCommand(scheduleCmd)
+}
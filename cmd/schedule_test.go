@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/llm"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/schedule"
+)
+
+func TestScheduleRunE(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	runner, _ := newMCPServeTestRunner(mockMCP)
+	store := schedule.NewStateStore(t.TempDir())
+	schedules := &schedule.Config{Schedules: []schedule.Definition{
+		{Name: "weekly-report", Cron: "0 9 * * MON", Project: "BE", Summary: "Weekly report {{.Date}}", Description: "Week {{.Week}}"},
+	}}
+	ctx := context.Background()
+	// Wednesday
+	now := time.Date(2025, time.April, 9, 12, 0, 0, 0, time.UTC)
+
+	// A new schedule only records its baseline
+	var out, errOut bytes.Buffer
+	require.NoError(t, scheduleRunE(ctx, runner, schedules, store, now, false, &out, &errOut))
+	assert.Empty(t, out.String())
+	state, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, now, state.Schedules["weekly-report"].Last.UTC())
+
+	// After two missed Mondays, only the latest creates a ticket
+	now = time.Date(2025, time.April, 22, 8, 0, 0, 0, time.UTC)
+	mockMCP.On("CreateIssue", mock.Anything, mcpclient.CreateIssueRequest{ProjectKey: "BE", Summary: "Weekly report 2025-04-21", Description: "Week 17", IssueType: "Bug", IdempotencyKey: idempotencyKey("schedule weekly-report\n2025-04-21T09:00:00Z", "BE")}).
+		Return(&mcpclient.CreateIssueResponse{Key: "BE-7"}, nil).Once()
+	require.NoError(t, scheduleRunE(ctx, runner, schedules, store, now, false, &out, &errOut))
+	assert.Contains(t, out.String(), `Created BE-7 from schedule "weekly-report"`)
+	state, err = store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "BE-7", state.Schedules["weekly-report"].IssueKey)
+
+	// Running again for the same occurrence does not create a duplicate
+	out.Reset()
+	require.NoError(t, scheduleRunE(ctx, runner, schedules, store, now.Add(time.Hour), false, &out, &errOut))
+	assert.Empty(t, out.String())
+	mockMCP.AssertNumberOfCalls(t, "CreateIssue", 1)
+
+	// A dry run shows the next due ticket without creating or recording it
+	next := time.Date(2025, time.April, 28, 9, 30, 0, 0, time.UTC)
+	require.NoError(t, scheduleRunE(ctx, runner, schedules, store, next, true, &out, &errOut))
+	assert.Contains(t, out.String(), `Would create from schedule "weekly-report" (2025-04-28 09:00 UTC): [BE] Weekly report 2025-04-28`)
+	state, err = store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "BE-7", state.Schedules["weekly-report"].IssueKey)
+	mockMCP.AssertNumberOfCalls(t, "CreateIssue", 1)
+	assert.Empty(t, errOut.String())
+}
+
+func TestScheduleRunE_LLMAndFailure(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	runner, mockLLM := newMCPServeTestRunner(mockMCP)
+	store := schedule.NewStateStore(t.TempDir())
+	schedules := &schedule.Config{Schedules: []schedule.Definition{
+		{Name: "standup", Cron: "@daily", Input: "standup notes for {{.Date}}"},
+	}}
+	last := time.Date(2025, time.April, 9, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, store.Save(&schedule.State{Schedules: map[string]schedule.Run{"standup": {Last: last}}}))
+	now := last.Add(24 * time.Hour)
+
+	mockLLM.On("GenerateTicketDetails", mock.Anything, "standup notes for 2025-04-10", "prompt", mock.Anything).
+		Return(llm.LLMResponse{Summary: "Standup notes", ProjectNameSuggestion: "Backend"}, nil)
+	mockMCP.On("CreateIssue", mock.Anything, mock.Anything).Return(nil, mcpclient.ErrMCPServerError).Once()
+
+	var out, errOut bytes.Buffer
+	err := scheduleRunE(context.Background(), runner, schedules, store, now, false, &out, &errOut)
+	assert.EqualError(t, err, "1 of 1 due schedules failed")
+	assert.Contains(t, errOut.String(), `Schedule "standup" (2025-04-10 00:00 UTC)`)
+
+	// The failed occurrence is retried on the next run
+	mockMCP.On("CreateIssue", mock.Anything, mcpclient.CreateIssueRequest{ProjectKey: "BE", Summary: "Standup notes", IssueType: "Bug", IdempotencyKey: idempotencyKey("schedule standup\n2025-04-10T00:00:00Z", "BE")}).
+		Return(&mcpclient.CreateIssueResponse{Key: "BE-8"}, nil).Once()
+	require.NoError(t, scheduleRunE(context.Background(), runner, schedules, store, now, false, &out, &errOut))
+	assert.Contains(t, out.String(), "Created BE-8")
+}
+
+func TestScheduleRunE_StaticSummaryAndReplay(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	runner, _ := newMCPServeTestRunner(mockMCP)
+	store := schedule.NewStateStore(t.TempDir())
+	schedules := &schedule.Config{Schedules: []schedule.Definition{
+		{Name: "rotate", Cron: "@daily", Project: "OPS", Summary: "Rotate keys"},
+	}}
+	last := time.Date(2025, time.April, 9, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, store.Save(&schedule.State{Schedules: map[string]schedule.Run{"rotate": {Last: last}}}))
+
+	// Each occurrence of a schedule with a static summary has its own key
+	var keys []string
+	mockMCP.On("CreateIssue", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		keys = append(keys, args.Get(1).(mcpclient.CreateIssueRequest).IdempotencyKey)
+	}).Return(&mcpclient.CreateIssueResponse{Key: "OPS-1"}, nil).Once()
+	mockMCP.On("CreateIssue", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		keys = append(keys, args.Get(1).(mcpclient.CreateIssueRequest).IdempotencyKey)
+	}).Return(&mcpclient.CreateIssueResponse{Key: "OPS-1", Replayed: true}, nil).Once()
+
+	var out bytes.Buffer
+	require.NoError(t, scheduleRunE(context.Background(), runner, schedules, store, last.Add(24*time.Hour), false, &out, io.Discard))
+	assert.Contains(t, out.String(), `Created OPS-1 from schedule "rotate" (2025-04-10 00:00 UTC)`)
+	require.NoError(t, scheduleRunE(context.Background(), runner, schedules, store, last.Add(48*time.Hour), false, &out, io.Discard))
+	require.Len(t, keys, 2)
+	assert.NotEqual(t, keys[0], keys[1])
+
+	// A replayed create is reported as such
+	assert.Contains(t, out.String(), `Already created OPS-1 from schedule "rotate" (2025-04-11 00:00 UTC)`)
+	mockMCP.AssertExpectations(t)
+}
+
+func TestScheduleRunE_Locked(t *testing.T) {
+	Log = zerolog.Nop()
+	runner, _ := newMCPServeTestRunner(new(MockMCPClient))
+	store := schedule.NewStateStore(t.TempDir())
+	unlock, err := store.Lock()
+	require.NoError(t, err)
+	defer unlock()
+
+	err = scheduleRunE(context.Background(), runner, &schedule.Config{}, store, time.Now(), false, &bytes.Buffer{}, &bytes.Buffer{})
+	assert.ErrorIs(t, err, schedule.ErrLocked)
+}
+
+func TestScheduleAddListRemove(t *testing.T) {
+	Log = zerolog.Nop()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "schedules.yaml")
+	store := schedule.NewStateStore(dir)
+	now := time.Date(2025, time.April, 9, 12, 0, 0, 0, time.UTC)
+
+	var out bytes.Buffer
+	def := schedule.Definition{Name: "weekly-report", Cron: "0 9 * * MON", Input: "weekly report"}
+	require.NoError(t, scheduleAddRunE(path, store, def, now, &out))
+	assert.Contains(t, out.String(), "Next occurrence: 2025-04-14 09:00 UTC")
+	assert.ErrorIs(t, scheduleAddRunE(path, store, def, now, &out), schedule.ErrInvalidSchedule)
+
+	schedules, err := schedule.LoadConfig(path)
+	require.NoError(t, err)
+	out.Reset()
+	require.NoError(t, scheduleListRunE(schedules, store, now, "json", false, &out))
+	var infos []scheduleInfo
+	require.NoError(t, json.Unmarshal(out.Bytes(), &infos))
+	require.Len(t, infos, 1)
+	assert.True(t, infos[0].LLM)
+	require.NotNil(t, infos[0].Next)
+	assert.Equal(t, time.Date(2025, time.April, 14, 9, 0, 0, 0, time.UTC), infos[0].Next.UTC())
+
+	out.Reset()
+	require.NoError(t, scheduleListRunE(schedules, store, now, "text", false, &out))
+	assert.Contains(t, out.String(), "weekly-report  0 9 * * MON  2025-04-14 09:00 UTC  -")
+
+	require.NoError(t, scheduleRemoveRunE(path, store, "weekly-report", &out))
+	assert.ErrorIs(t, scheduleRemoveRunE(path, store, "weekly-report", &out), schedule.ErrScheduleNotFound)
+	state, err := store.Load()
+	require.NoError(t, err)
+	assert.Empty(t, state.Schedules)
+}
@@ -1,19 +1,20 @@
 package cmd
 
 import (
-	"encoding/json" // Added for JSON output
 	"errors"
 	"fmt"
 	"io" // Added for io.Writer
-	"reflect"
 	"strings"
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3" // Added for YAML output
 
+	"github.com/karolswdev/ticketron/internal/clierr"
 	"github.com/karolswdev/ticketron/internal/config" // Added for config errors
+	"github.com/karolswdev/ticketron/internal/i18n"
 	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/output"
+	"github.com/karolswdev/ticketron/internal/sanitize"
 )
 
 // searchRunE holds the logic for the search command, accepting dependencies.
@@ -23,21 +24,44 @@ func searchRunE(cfgProvider ConfigProvider, mcpClient MCPClient, out io.Writer,
 	maxResults, _ := cmd.Flags().GetInt("max-results")
 	outputFormat, _ := cmd.Flags().GetString("output")
 	outputFieldsStr, _ := cmd.Flags().GetString("output-fields") // Get raw flag string
+	applyExprs, _ := cmd.Flags().GetStringArray("apply")
+	sortSpec, _ := cmd.Flags().GetString("sort")
+	groupBy, _ := cmd.Flags().GetString("group-by")
 
-	// Determine JQL query
-	var jqlQuery string
-	switch {
-	case jqlFlag != "":
-		jqlQuery = jqlFlag
-	case len(args) > 0:
-		jqlQuery = strings.Join(args, " ")
-	default:
+	// Validate bulk actions before searching so typos fail fast.
+	actions, err := parseBulkActions(applyExprs)
+	if err != nil {
+		return clierr.New(clierr.CodeInvalidInput, "", "", err)
+	}
+
+	sortKeys, err := parseSortSpec(sortSpec)
+	if err != nil {
+		return clierr.New(clierr.CodeInvalidInput, "", "", err)
+	}
+
+	// Determine JQL query, combining any raw JQL with the filter flags
+	rawJQL := jqlFlag
+	if rawJQL == "" {
+		rawJQL = strings.Join(args, " ")
+	}
+	filters := jqlFiltersFromFlags(cmd)
+	if filters.needsTimezone() {
+		now, err := configuredNow(cfgProvider)
+		if err != nil {
+			return clierr.New(clierr.CodeConfig, "", i18n.T(i18n.MsgTimezoneHint), err)
+		}
+		filters.now = now
+	}
+	jqlQuery, err := buildJQL(rawJQL, filters)
+	if err != nil {
+		return clierr.New(clierr.CodeInvalidInput, "", "", err)
+	}
+	if jqlQuery == "" {
 		err := errors.New("no JQL query provided")
 		log.Error().Err(err).Msg("JQL query missing")
-		fmt.Fprintln(cmd.ErrOrStderr(), "Error: No JQL query provided.")
-		fmt.Fprintln(cmd.ErrOrStderr(), "Please provide the query as arguments or use the --jql flag.")
-		return err
+		return clierr.New(clierr.CodeInvalidInput, i18n.T(i18n.MsgNoJQL), i18n.T(i18n.MsgNoJQLHint), err)
 	}
+	log.Debug().Str("jql", jqlQuery).Msg("Built JQL query")
 
 	// Prepare request
 	request := mcpclient.SearchIssuesRequest{
@@ -47,131 +71,69 @@ func searchRunE(cfgProvider ConfigProvider, mcpClient MCPClient, out io.Writer,
 
 	// Call MCP server
 	ctx := cmd.Context()
+	stopSpinner := newSpinner(cmd).Start(i18n.T(i18n.MsgProgressSearch))
 	resp, err := mcpClient.SearchIssues(ctx, request)
+	stopSpinner()
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to search issues via MCP")
-		// User feedback based on error type using switch
-		switch {
-		case errors.Is(err, mcpclient.ErrRequestExecute):
-			fmt.Fprintf(cmd.ErrOrStderr(), "Error connecting to the MCP server: %v\n", err)
-			fmt.Fprintln(cmd.ErrOrStderr(), "Please ensure the MCP server is running and the URL is correct.")
-		case errors.Is(err, mcpclient.ErrMCPServerError):
-			fmt.Fprintf(cmd.ErrOrStderr(), "MCP server returned an error during search: %v\n", err)
-		case errors.Is(err, mcpclient.ErrMCPServerErrorUnparseable):
-			fmt.Fprintf(cmd.ErrOrStderr(), "MCP server returned an error with an unparseable response body during search: %v\n", err)
-		case errors.Is(err, mcpclient.ErrResponseDecode):
-			fmt.Fprintf(cmd.ErrOrStderr(), "Failed to decode the search results from the MCP server: %v\n", err)
-		default:
-			fmt.Fprintf(cmd.ErrOrStderr(), "An unexpected error occurred while searching issues via MCP: %v\n", err)
-		}
-		return err
+		return mcpSearchError(err)
 	}
 
-	// Parse fields only if the flag string is not empty
-	var fields []string
-	if outputFieldsStr != "" {
-		rawFields := strings.Split(outputFieldsStr, ",")
-		validFields := make([]string, 0, len(rawFields))
-		for _, field := range rawFields {
-			trimmedField := strings.TrimSpace(field)
-			if trimmedField != "" {
-				validFields = append(validFields, trimmedField)
-			}
-		}
-		// Only assign if parsing resulted in non-empty fields
-		if len(validFields) > 0 {
-			fields = validFields
-		}
+	if len(actions) > 0 {
+		parallel, _ := cmd.Flags().GetInt("parallel")
+		opts := bulkApplyOptions{parallel: parallel, outputFormat: outputFormat}
+		return bulkApplyRunE(ctx, mcpClient, resp, actions, opts, newConfirmer(cmd), out, cmd.ErrOrStderr())
 	}
 
-	switch outputFormat {
-	case "json":
-		var outputData interface{}
-		// Use parsed fields if available (implies flag was set and valid)
-		if len(fields) > 0 {
-			filteredIssues := make([]map[string]interface{}, 0, len(resp.Issues))
-			for _, issue := range resp.Issues {
-				filteredIssues = append(filteredIssues, extractFields(issue, fields))
-			}
-			outputData = filteredIssues
-		} else {
-			// Default: output full response object
-			outputData = resp
-		}
-		jsonData, err := json.MarshalIndent(outputData, "", "  ")
-		if err != nil {
-			log.Error().Err(err).Msg("Failed to marshal search results to JSON")
-			fmt.Fprintf(cmd.ErrOrStderr(), "Error formatting search results as JSON: %v\n", err)
+	fields := output.ParseFields(outputFieldsStr)
+
+	// Sorting and grouping happen client-side so they work the same for every format
+	if len(sortKeys) > 0 {
+		sortIssues(resp.Issues, sortKeys)
+	}
+	if groupBy != "" {
+		groupPath := resolveIssueFieldPath(groupBy)
+		if err := writeGroupedSearchResults(out, resp.Issues, groupPath, outputFormat, fields, searchTSVFields(outputFieldsStr, fields)); err != nil {
 			return err
 		}
-		fmt.Fprintln(out, string(jsonData))
+		return nil
+	}
 
-	case "yaml":
+	switch format := output.Normalize(outputFormat); format {
+	case output.FormatJSON, output.FormatYAML:
 		var outputData interface{}
-		// Use parsed fields if available (implies flag was set and valid)
-		if len(fields) > 0 {
+		switch {
+		case len(fields) > 0:
+			paths := output.CompileAll(fields)
 			filteredIssues := make([]map[string]interface{}, 0, len(resp.Issues))
 			for _, issue := range resp.Issues {
-				filteredIssues = append(filteredIssues, extractFields(issue, fields))
+				filteredIssues = append(filteredIssues, output.Extract(issue, paths))
 			}
-			outputData = filteredIssues // Assign filtered data
-		} else {
-			// Default: output only the issues list
+			outputData = filteredIssues
+		case format == output.FormatJSON:
+			outputData = resp // Full response, including the total
+		default:
 			outputData = resp.Issues
 		}
-		yamlData, err := yaml.Marshal(outputData)
-		if err != nil {
-			log.Error().Err(err).Msg("Failed to marshal search results to YAML")
-			fmt.Fprintf(cmd.ErrOrStderr(), "Error formatting search results as YAML: %v\n", err)
+		if err := output.Structured(out, format, outputData); err != nil {
+			log.Error().Err(err).Str("format", format).Msg("Failed to marshal search results")
+			fmt.Fprintln(cmd.ErrOrStderr(), i18n.T(i18n.MsgFormatResultsError, err))
 			return err
 		}
-		fmt.Fprintln(out, string(yamlData))
 
-	case "tsv":
+	case output.FormatTSV:
 		if len(resp.Issues) == 0 {
 			log.Info().Msg("No issues found matching the query.")
 			fmt.Fprintln(out, "No issues found.")
 			return nil
 		}
-		var tsvFields []string
-		// Revert to original default field names (lowercase JSON style)
-		defaultTsvFields := []string{"key", "fields.summary", "fields.status.name", "fields.issuetype.name"}
-
-		// Determine fields based *only* on whether the flag string was provided.
-		if outputFieldsStr != "" {
-			// Flag string was provided. Use parsed 'fields' if valid, otherwise default.
-			if len(fields) > 0 {
-				tsvFields = fields
-			} else {
-				// Flag string was provided but invalid (e.g., "-f ,,,"), use default
-				log.Warn().Str("flag_value", outputFieldsStr).Msg("Invalid value for --output-fields, using default TSV fields.")
-				tsvFields = defaultTsvFields
-			}
-		} else {
-			// Flag string was empty, use default
-			tsvFields = defaultTsvFields
-		}
-
-		fmt.Fprintln(out, strings.Join(tsvFields, "\t")) // Print header
-
+		tsvFields := searchTSVFields(outputFieldsStr, fields)
+		paths := output.CompileAll(tsvFields)
+		rows := make([][]string, 0, len(resp.Issues))
 		for _, issue := range resp.Issues {
-			values := make([]string, 0, len(tsvFields)) // Re-initialize slice for each issue
-			for _, fieldPath := range tsvFields {
-				value, found := getValueByPath(issue, fieldPath)
-				var formattedValue string
-				if found && value != nil {
-					formattedValue = fmt.Sprintf("%v", value)
-					// Restore sanitization
-					formattedValue = strings.ReplaceAll(formattedValue, "\t", " ")
-					formattedValue = strings.ReplaceAll(formattedValue, "\n", " ")
-					formattedValue = strings.ReplaceAll(formattedValue, "\r", " ")
-				} else {
-					formattedValue = ""
-				}
-				values = append(values, formattedValue)
-			}
-			fmt.Fprintln(out, strings.Join(values, "\t"))
+			rows = append(rows, tsvRow(issue, paths))
 		}
+		return output.TSV(out, tsvFields, rows)
 
 	default: // Includes "text" or any unspecified format
 		if len(resp.Issues) == 0 {
@@ -180,10 +142,16 @@ func searchRunE(cfgProvider ConfigProvider, mcpClient MCPClient, out io.Writer,
 		} else {
 			log.Info().Int("count", len(resp.Issues)).Msg("Found issues")
 			fmt.Fprintf(out, "Found %d issues:\n", len(resp.Issues))
+			if plainOutput(cmd) {
+				fmt.Fprintln(out)
+				table := output.NewTable("Key", "Status", "Summary")
+				for _, issue := range resp.Issues {
+					table.Row(sanitize.Line(issue.Key), sanitize.Line(issue.Fields.Status.Name), sanitize.Line(issue.Fields.Summary))
+				}
+				return table.Render(out, true)
+			}
 			for _, issue := range resp.Issues {
-				status := issue.Fields.Status.Name
-				summary := issue.Fields.Summary
-				fmt.Fprintf(out, "- %s - %s - %s\n", issue.Key, status, summary)
+				fmt.Fprintln(out, issueLine(issue))
 			}
 		}
 	}
@@ -191,120 +159,51 @@ func searchRunE(cfgProvider ConfigProvider, mcpClient MCPClient, out io.Writer,
 	return nil
 }
 
-// getValueByPath initiates the recursive traversal to find a value by path.
-func getValueByPath(data interface{}, path string) (interface{}, bool) {
-	parts := strings.Split(path, ".")
-	val, found := getValueRecursive(reflect.ValueOf(data), parts)
-	if !found || !val.IsValid() || !val.CanInterface() {
-		return nil, false
-	}
-	return val.Interface(), true
-}
+// defaultTSVFields are the columns printed by -o tsv when --output-fields is not given.
+var defaultTSVFields = []string{"key", "fields.summary", "fields.status.name", "fields.issuetype.name"}
 
-// getValueRecursive performs the actual recursive traversal.
-func getValueRecursive(current reflect.Value, pathParts []string) (reflect.Value, bool) {
-	if len(pathParts) == 0 {
-		return current, true
+// searchTSVFields returns the TSV columns: the parsed --output-fields, or the defaults
+// when the flag is empty or contains no valid field.
+func searchTSVFields(outputFieldsStr string, fields []string) []string {
+	if outputFieldsStr == "" {
+		return defaultTSVFields
 	}
-	part := pathParts[0]
-	remainingParts := pathParts[1:]
-
-	// Dereference pointers and interfaces
-	for current.Kind() == reflect.Ptr || current.Kind() == reflect.Interface {
-		if current.IsNil() {
-			return reflect.Value{}, false // Cannot traverse nil
-		}
-		current = current.Elem()
-		if !current.IsValid() {
-			return reflect.Value{}, false // Invalid element after dereferencing
-		}
+	if len(fields) == 0 {
+		// Flag string was provided but invalid (e.g., "-f ,,,"), use default
+		log.Warn().Str("flag_value", outputFieldsStr).Msg("Invalid value for --output-fields, using default TSV fields.")
+		return defaultTSVFields
 	}
+	return fields
+}
 
-	switch current.Kind() {
-	case reflect.Struct:
-		var nextVal reflect.Value
-		found := false
-
-		// 1. Try direct field name match (case-insensitive)
-		fieldVal := current.FieldByNameFunc(func(name string) bool {
-			return strings.EqualFold(name, part)
-		})
-		if fieldVal.IsValid() {
-			if fieldVal.CanInterface() {
-				structField, _ := current.Type().FieldByNameFunc(func(name string) bool { return strings.EqualFold(name, part) })
-				jsonTag := structField.Tag.Get("json")
-				if jsonTag != "-" {
-					nextVal = fieldVal
-					found = true
-				}
-			}
-		}
-
-		// 2. If not found by name, try JSON tag match
-		if !found {
-			fmt.Printf("[DEBUG JSON Tag Lookup] Part: '%s', Current Type: %s\n", part, current.Type().String()) // Log entry to JSON tag loop
-			for i := 0; i < current.NumField(); i++ {
-				structField := current.Type().Field(i)
-				jsonTag := structField.Tag.Get("json")
-				tagName := strings.Split(jsonTag, ",")[0]
-				fmt.Printf("[DEBUG JSON Tag Lookup] Checking Field: '%s', TagName: '%s'\n", structField.Name, tagName) // Log field being checked
-
-				if tagName == part && jsonTag != "-" {
-					fieldValByIndex := current.Field(i)
-					fmt.Printf("[DEBUG JSON Tag Lookup] Match found! Field: '%s', IsValid: %v, CanInterface: %v\n", structField.Name, fieldValByIndex.IsValid(), fieldValByIndex.CanInterface()) // Log match details
-					if fieldValByIndex.IsValid() && fieldValByIndex.CanInterface() {
-						nextVal = fieldValByIndex
-						found = true
-						break
-					}
-				}
-			}
-		}
-
-		if !found {
-			fmt.Printf("[DEBUG getValueRecursive Struct] Field '%s' not found by name or JSON tag on type %s.\n", part, current.Type().String()) // Log failure
-			return reflect.Value{}, false
-		}
-		return getValueRecursive(nextVal, remainingParts)
-
-	case reflect.Map:
-		var mapValue reflect.Value
-		keyKind := current.Type().Key().Kind()
-		if keyKind == reflect.String {
-			mapValue = current.MapIndex(reflect.ValueOf(part))
-			if !mapValue.IsValid() {
-				iter := current.MapRange()
-				for iter.Next() {
-					k := iter.Key()
-					if k.Kind() == reflect.String && strings.EqualFold(k.String(), part) {
-						mapValue = iter.Value()
-						break
-					}
-				}
-			}
-		}
-
-		if !mapValue.IsValid() {
-			return reflect.Value{}, false
-		}
-		return getValueRecursive(mapValue, remainingParts)
-
-	default:
-		return reflect.Value{}, false
+// tsvRow returns the values at paths for a single issue.
+func tsvRow(issue mcpclient.Issue, paths []*output.Path) []string {
+	values := make([]string, 0, len(paths))
+	for _, path := range paths {
+		values = append(values, path.Text(issue))
 	}
+	return values
 }
 
-// extractFields uses getValueByPath to build the result map for a single issue.
-func extractFields(issue mcpclient.Issue, fields []string) map[string]interface{} {
-	result := make(map[string]interface{})
-	for _, fieldPath := range fields {
-		if value, found := getValueByPath(issue, fieldPath); found {
-			result[fieldPath] = value
-		} else {
-			result[fieldPath] = nil // Field not found or inaccessible, include as null
-		}
+// issueLine formats an issue as a single line of text output.
+func issueLine(issue mcpclient.Issue) string {
+	return fmt.Sprintf("- %s - %s - %s", sanitize.Line(issue.Key), sanitize.Line(issue.Fields.Status.Name), sanitize.Line(issue.Fields.Summary))
+}
+
+// mcpSearchError wraps err, returned by SearchIssues, with what went wrong and how to fix it.
+func mcpSearchError(err error) error {
+	switch {
+	case errors.Is(err, mcpclient.ErrRequestExecute):
+		return clierr.New(clierr.CodeMCPConnect, i18n.T(i18n.MsgMCPConnectError, err), i18n.T(i18n.MsgMCPConnectHint), err)
+	case errors.Is(err, mcpclient.ErrMCPServerError):
+		return clierr.New(clierr.CodeMCPServer, i18n.T(i18n.MsgMCPSearchError, err), "", err)
+	case errors.Is(err, mcpclient.ErrMCPServerErrorUnparseable):
+		return clierr.New(clierr.CodeMCPServer, i18n.T(i18n.MsgMCPSearchUnparseable, err), "", err)
+	case errors.Is(err, mcpclient.ErrResponseDecode):
+		return clierr.New(clierr.CodeMCPResponse, i18n.T(i18n.MsgMCPSearchDecode, err), "", err)
+	default:
+		return clierr.New(clierr.CodeMCP, i18n.T(i18n.MsgMCPSearchUnexpected, err), "", err)
 	}
-	return result
 }
 
 // searchCmd represents the search command
@@ -312,36 +211,52 @@ var searchCmd = &cobra.Command{
 	Use:   "search [JQL Query]",
 	Short: "Search for JIRA issues using JQL",
 	Long: `Searches for JIRA issues using a JQL query via the MCP server.
-You can provide the JQL query directly as arguments or use the --jql flag.`,
+You can provide the JQL query directly as arguments or use the --jql flag.
+
+Common filters can be given as flags instead of JQL and are combined with any
+query using AND:
+  tix search --project BE --assignee me --status "In Progress" --updated-since 3d
+
+Results can be sorted and grouped client-side with --sort and --group-by:
+  tix search --project BE --sort key:desc --group-by status
+
+With --apply, an action is performed on every result after a preview and
+confirmation (skip it with --yes). --apply can be repeated:
+  transition=NAME   Transition the issues (e.g. transition="Done")
+  label+=LABEL      Add a label
+  label-=LABEL      Remove a label
+  priority=NAME     Set the priority
+  summary=TEXT      Set the summary
+
+Example:
+  tix search "project = BE AND status = 'In Review'" --apply transition=Done --apply label+=released`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfgProvider := &DefaultConfigProvider{}
+		// One snapshot serves the whole invocation, so config.yaml is read only once
+		cfgProvider := NewConfigSnapshot(&DefaultConfigProvider{})
 		cfg, err := cfgProvider.LoadConfig()
 		if err != nil {
 			log.Error().Err(err).Msg("Failed to load configuration for search command setup")
+			message := i18n.T(i18n.MsgConfigUnexpectedErr, err)
 			if errors.Is(err, config.ErrConfigRead) || errors.Is(err, config.ErrConfigParse) {
-				fmt.Fprintln(cmd.ErrOrStderr(), "Error reading or parsing config.yaml. Please check its format and permissions.")
+				message = i18n.T(i18n.MsgConfigParseHint)
 			} else if errors.Is(err, config.ErrConfigDirCreate) || errors.Is(err, config.ErrConfigDirStat) || errors.Is(err, config.ErrConfigDirNotDir) {
-				fmt.Fprintln(cmd.ErrOrStderr(), "Error accessing configuration directory. Please check permissions.")
-			} else {
-				fmt.Fprintf(cmd.ErrOrStderr(), "An unexpected error occurred loading config.yaml: %v\n", err)
+				message = i18n.T(i18n.MsgConfigDirHint)
 			}
-			fmt.Fprintln(cmd.ErrOrStderr(), "You might need to run 'tix config init'.")
-			return err
+			return clierr.New(clierr.CodeConfig, message, i18n.T(i18n.MsgRunConfigInit), err)
 		}
 
-		mcpClient, err := newDefaultMCPClient(cfg)
+		mcpClient, err := buildMCPClient(cfg, nil)
+		if err == nil && mcpClient == nil {
+			err = mcpclient.ErrMCPServerURLMissing
+		}
 		if err != nil {
 			log.Error().Err(err).Msg("Failed to create MCP client for search command setup")
 			if errors.Is(err, mcpclient.ErrMCPServerURLMissing) {
-				fmt.Fprintln(cmd.ErrOrStderr(), "Error: MCP Server URL is not configured.")
-				fmt.Fprintln(cmd.ErrOrStderr(), "Please set 'mcp_server_url' in ~/.ticketron/config.yaml or use the TICKETRON_MCP_SERVER_URL environment variable.")
+				return clierr.New(clierr.CodeMCPNotConfigured, i18n.T(i18n.MsgMCPURLMissing), i18n.T(i18n.MsgMCPURLHint), err)
 			} else if errors.Is(err, mcpclient.ErrMCPServerURLParse) {
-				fmt.Fprintf(cmd.ErrOrStderr(), "Error parsing MCP Server URL: %v\n", err)
-				fmt.Fprintln(cmd.ErrOrStderr(), "Please check the URL format in your configuration.")
-			} else {
-				fmt.Fprintf(cmd.ErrOrStderr(), "Failed to initialize MCP client: %v\n", err)
+				return clierr.New(clierr.CodeMCPNotConfigured, i18n.T(i18n.MsgMCPURLParse, err), i18n.T(i18n.MsgMCPURLFormatHint), err)
 			}
-			return err
+			return clierr.New(clierr.CodeMCPNotConfigured, i18n.T(i18n.MsgMCPInitError, err), "", err)
 		}
 
 		out := cmd.OutOrStdout()
@@ -352,6 +267,11 @@ You can provide the JQL query directly as arguments or use the --jql flag.`,
 func init() {
 	searchCmd.Flags().String("jql", "", "JQL query string")
 	searchCmd.Flags().Int("max-results", 20, "Maximum number of results to return")
+	addJQLFilterFlags(searchCmd)
+	searchCmd.Flags().String("sort", "", "Sort results by fields, e.g. status,key:desc (key, summary, status, type or a field path)")
+	searchCmd.Flags().String("group-by", "", "Group results by a field (key, summary, status, type or a field path)")
+	searchCmd.Flags().StringArray("apply", nil, "Action to apply to every result (transition=NAME, label+=X, label-=X, priority=NAME, summary=TEXT); repeatable")
+	searchCmd.Flags().Int("parallel", defaultBulkParallel, "Maximum number of issues modified concurrently by --apply")
 	searchCmd.Flags().StringP("output-fields", "f", "", "Comma-separated fields to include in JSON/YAML/TSV output (e.g., key,fields.summary,fields.status.name)") // Updated help text
 
 	rootCmd.AddCommand(searchCmd)
@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/karolswdev/ticketron/internal/i18n"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/prompt"
+)
+
+// defaultBulkParallel is the default number of issues modified concurrently by search --apply.
+const defaultBulkParallel = 4
+
+// bulkAction is a single --apply expression of the search command.
+type bulkAction struct {
+	kind  string // bulkTransition, bulkLabelAdd, bulkLabelRemove or bulkSetField
+	field string // Field name for bulkSetField
+	value string
+}
+
+// Kinds of bulk actions.
+const (
+	bulkTransition  = "transition"
+	bulkLabelAdd    = "label_add"
+	bulkLabelRemove = "label_remove"
+	bulkSetField    = "set"
+)
+
+// bulkSettableFields maps the fields accepted as <field>=<value> to a function building
+// the Jira field value.
+var bulkSettableFields = map[string]func(string) interface{}{
+	"priority": func(v string) interface{} { return map[string]string{"name": v} },
+	"summary":  func(v string) interface{} { return v },
+}
+
+// parseBulkAction parses an --apply expression: transition=NAME, label+=LABEL,
+// label-=LABEL, priority=NAME or summary=TEXT. The operator is the first one after the
+// field name, so the value may contain "=", "+=" or "-=", e.g. summary=a-=b.
+func parseBulkAction(expr string) (bulkAction, error) {
+	i := strings.Index(expr, "=")
+	if i < 0 {
+		return bulkAction{}, fmt.Errorf("invalid --apply %q: expected FIELD=VALUE, label+=VALUE or label-=VALUE", expr)
+	}
+	field, op, value := expr[:i], "=", expr[i+1:]
+	if i > 0 && (expr[i-1] == '+' || expr[i-1] == '-') {
+		field, op = expr[:i-1], expr[i-1:i+1]
+	}
+	field = strings.ToLower(strings.TrimSpace(field))
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return bulkAction{}, fmt.Errorf("invalid --apply %q: value is empty", expr)
+	}
+
+	switch {
+	case field == "transition" && op == "=":
+		return bulkAction{kind: bulkTransition, value: value}, nil
+	case (field == "label" || field == "labels") && op == "+=":
+		return bulkAction{kind: bulkLabelAdd, field: "labels", value: value}, nil
+	case (field == "label" || field == "labels") && op == "-=":
+		return bulkAction{kind: bulkLabelRemove, field: "labels", value: value}, nil
+	case op == "=" && bulkSettableFields[field] != nil:
+		return bulkAction{kind: bulkSetField, field: field, value: value}, nil
+	default:
+		return bulkAction{}, fmt.Errorf("unsupported --apply %q (expected transition=, label+=, label-=, priority= or summary=)", expr)
+	}
+}
+
+// parseBulkActions parses all --apply expressions; at most one transition is allowed.
+func parseBulkActions(exprs []string) ([]bulkAction, error) {
+	actions := make([]bulkAction, 0, len(exprs))
+	transitions := 0
+	for _, expr := range exprs {
+		action, err := parseBulkAction(expr)
+		if err != nil {
+			return nil, err
+		}
+		if action.kind == bulkTransition {
+			transitions++
+		}
+		actions = append(actions, action)
+	}
+	if transitions > 1 {
+		return nil, errors.New("only one transition can be applied at a time")
+	}
+	return actions, nil
+}
+
+// String describes the action for the preview.
+func (a bulkAction) String() string {
+	switch a.kind {
+	case bulkTransition:
+		return fmt.Sprintf("transition to %q", a.value)
+	case bulkLabelAdd:
+		return fmt.Sprintf("add label %q", a.value)
+	case bulkLabelRemove:
+		return fmt.Sprintf("remove label %q", a.value)
+	default:
+		return fmt.Sprintf("set %s to %q", a.field, a.value)
+	}
+}
+
+// bulkPlan groups actions into a single field update plus an optional transition.
+type bulkPlan struct {
+	update     *mcpclient.UpdateIssueRequest
+	transition string
+}
+
+func newBulkPlan(actions []bulkAction) bulkPlan {
+	var plan bulkPlan
+	for _, a := range actions {
+		if a.kind == bulkTransition {
+			plan.transition = a.value
+			continue
+		}
+		if plan.update == nil {
+			plan.update = &mcpclient.UpdateIssueRequest{}
+		}
+		switch a.kind {
+		case bulkSetField:
+			if plan.update.Fields == nil {
+				plan.update.Fields = map[string]interface{}{}
+			}
+			plan.update.Fields[a.field] = bulkSettableFields[a.field](a.value)
+		case bulkLabelAdd, bulkLabelRemove:
+			if plan.update.Update == nil {
+				plan.update.Update = map[string][]mcpclient.FieldOperation{}
+			}
+			op := mcpclient.FieldOperation{Add: a.value}
+			if a.kind == bulkLabelRemove {
+				op = mcpclient.FieldOperation{Remove: a.value}
+			}
+			plan.update.Update[a.field] = append(plan.update.Update[a.field], op)
+		}
+	}
+	return plan
+}
+
+// apply edits one issue. Field updates run before the transition, since the target
+// status may not allow editing.
+func (p bulkPlan) apply(ctx context.Context, mcpClient MCPClient, key string) error {
+	if p.update != nil {
+		if err := mcpClient.UpdateIssue(ctx, key, *p.update); err != nil {
+			return err
+		}
+	}
+	if p.transition != "" {
+		return mcpClient.TransitionIssue(ctx, key, mcpclient.TransitionIssueRequest{Transition: p.transition})
+	}
+	return nil
+}
+
+// bulkResult reports the outcome for one issue.
+type bulkResult struct {
+	Key   string `json:"key"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// bulkApplyOptions holds the flags controlling search --apply.
+type bulkApplyOptions struct {
+	parallel     int
+	outputFormat string
+}
+
+// bulkApplyRunE previews the actions and affected issues, asks for confirmation and applies
+// the actions to every issue with at most opts.parallel concurrent requests. It prints a
+// per-issue report and returns an error if any issue failed. With JSON output the preview
+// goes to errOut so that out only carries the report.
+func bulkApplyRunE(ctx context.Context, mcpClient MCPClient, resp *mcpclient.SearchIssuesResponse, actions []bulkAction, opts bulkApplyOptions, confirmer *prompt.Confirmer, out, errOut io.Writer) error {
+	if len(resp.Issues) == 0 {
+		fmt.Fprintln(out, "No issues found.")
+		return nil
+	}
+	previewOut := out
+	if opts.outputFormat == "json" {
+		previewOut = errOut
+	}
+
+	fmt.Fprintf(previewOut, "The following changes will be applied to %d issue(s):\n", len(resp.Issues))
+	for _, a := range actions {
+		fmt.Fprintf(previewOut, "  - %s\n", a)
+	}
+	fmt.Fprintln(previewOut, "Issues:")
+	for _, issue := range resp.Issues {
+		fmt.Fprintf(previewOut, "  %s - %s - %s\n", issue.Key, issue.Fields.Status.Name, issue.Fields.Summary)
+	}
+	if resp.Total > len(resp.Issues) {
+		fmt.Fprintf(previewOut, "Note: only %d of %d matching issues are included; raise --max-results to include more.\n", len(resp.Issues), resp.Total)
+	}
+
+	ok, err := confirmer.Confirm(previewOut, i18n.T(i18n.MsgConfirmApply, len(resp.Issues)), false)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Fprintln(previewOut, i18n.T(i18n.MsgAborted))
+		return nil
+	}
+
+	plan := newBulkPlan(actions)
+	parallel := opts.parallel
+	if parallel < 1 {
+		parallel = 1
+	}
+	results := make([]bulkResult, len(resp.Issues))
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i, issue := range resp.Issues {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, key string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = bulkResult{Key: key, OK: true}
+			if err := plan.apply(ctx, mcpClient, key); err != nil {
+				Log.Warn().Err(err).Str("issue_key", key).Msg("Bulk action failed")
+				results[i] = bulkResult{Key: key, Error: err.Error()}
+			}
+		}(i, issue.Key)
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, r := range results {
+		if !r.OK {
+			failed++
+		}
+	}
+
+	if opts.outputFormat == "json" {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format results as JSON: %w", err)
+		}
+		fmt.Fprintln(out, string(data))
+	} else {
+		for _, r := range results {
+			if r.OK {
+				fmt.Fprintf(out, "[OK]   %s\n", r.Key)
+			} else {
+				fmt.Fprintf(out, "[FAIL] %s: %s\n", r.Key, r.Error)
+			}
+		}
+		fmt.Fprintf(out, "Applied to %d of %d issue(s).\n", len(results)-failed, len(results))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("bulk action failed for %d of %d issue(s)", failed, len(results))
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/prompt"
+)
+
+func TestParseBulkAction(t *testing.T) {
+	tests := []struct {
+		expr string
+		want bulkAction
+	}{
+		{"transition=Done", bulkAction{kind: bulkTransition, value: "Done"}},
+		{"transition = In Review", bulkAction{kind: bulkTransition, value: "In Review"}},
+		{"label+=triaged", bulkAction{kind: bulkLabelAdd, field: "labels", value: "triaged"}},
+		{"labels-=stale", bulkAction{kind: bulkLabelRemove, field: "labels", value: "stale"}},
+		{"Priority=High", bulkAction{kind: bulkSetField, field: "priority", value: "High"}},
+		{"summary=a-=b", bulkAction{kind: bulkSetField, field: "summary", value: "a-=b"}},
+		{"summary=x+=y", bulkAction{kind: bulkSetField, field: "summary", value: "x+=y"}},
+		{"label+=a=b", bulkAction{kind: bulkLabelAdd, field: "labels", value: "a=b"}},
+	}
+	for _, tt := range tests {
+		got, err := parseBulkAction(tt.expr)
+		require.NoError(t, err, tt.expr)
+		assert.Equal(t, tt.want, got, tt.expr)
+	}
+
+	for _, expr := range []string{"transition", "transition=", "status=Done", "transition+=Done", "priority-=High"} {
+		_, err := parseBulkAction(expr)
+		assert.Error(t, err, expr)
+	}
+
+	_, err := parseBulkActions([]string{"transition=Done", "transition=Closed"})
+	assert.EqualError(t, err, "only one transition can be applied at a time")
+}
+
+func bulkTestResponse() *mcpclient.SearchIssuesResponse {
+	return &mcpclient.SearchIssuesResponse{
+		Total: 3,
+		Issues: []mcpclient.Issue{
+			{Key: "BE-1", Fields: mcpclient.IssueFields{Summary: "First", Status: mcpclient.Status{Name: "In Review"}}},
+			{Key: "BE-2", Fields: mcpclient.IssueFields{Summary: "Second", Status: mcpclient.Status{Name: "In Review"}}},
+		},
+	}
+}
+
+func TestBulkApplyRunE(t *testing.T) {
+	Log = zerolog.Nop()
+	actions, err := parseBulkActions([]string{"transition=Done", "label+=released", "priority=Low"})
+	require.NoError(t, err)
+
+	wantUpdate := mcpclient.UpdateIssueRequest{
+		Fields: map[string]interface{}{"priority": map[string]string{"name": "Low"}},
+		Update: map[string][]mcpclient.FieldOperation{"labels": {{Add: "released"}}},
+	}
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("UpdateIssue", mock.Anything, "BE-1", wantUpdate).Return(nil)
+	mockMCP.On("UpdateIssue", mock.Anything, "BE-2", wantUpdate).Return(nil)
+	mockMCP.On("TransitionIssue", mock.Anything, "BE-1", mcpclient.TransitionIssueRequest{Transition: "Done"}).Return(nil)
+	mockMCP.On("TransitionIssue", mock.Anything, "BE-2", mcpclient.TransitionIssueRequest{Transition: "Done"}).Return(errors.New("transition not available"))
+
+	var out, errOut bytes.Buffer
+	err = bulkApplyRunE(context.Background(), mockMCP, bulkTestResponse(), actions, bulkApplyOptions{parallel: 2}, &prompt.Confirmer{In: strings.NewReader("y\n")}, &out, &errOut)
+	assert.EqualError(t, err, "bulk action failed for 1 of 2 issue(s)")
+
+	output := out.String()
+	assert.Contains(t, output, "The following changes will be applied to 2 issue(s):")
+	assert.Contains(t, output, `  - transition to "Done"`)
+	assert.Contains(t, output, `  - add label "released"`)
+	assert.Contains(t, output, "  BE-1 - In Review - First")
+	assert.Contains(t, output, "only 2 of 3 matching issues are included")
+	assert.Contains(t, output, "Apply to 2 issue(s)? [y/N]: ")
+	assert.Contains(t, output, "[OK]   BE-1\n[FAIL] BE-2: transition not available\nApplied to 1 of 2 issue(s).")
+	mockMCP.AssertExpectations(t)
+}
+
+func TestBulkApplyRunE_Declined(t *testing.T) {
+	actions, err := parseBulkActions([]string{"label-=stale"})
+	require.NoError(t, err)
+	mockMCP := new(MockMCPClient)
+
+	var out bytes.Buffer
+	err = bulkApplyRunE(context.Background(), mockMCP, bulkTestResponse(), actions, bulkApplyOptions{}, &prompt.Confirmer{In: strings.NewReader("n\n")}, &out, &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "Aborted.")
+	mockMCP.AssertNotCalled(t, "UpdateIssue", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBulkApplyRunE_JSON(t *testing.T) {
+	actions, err := parseBulkActions([]string{"label-=stale"})
+	require.NoError(t, err)
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("UpdateIssue", mock.Anything, mock.Anything, mcpclient.UpdateIssueRequest{
+		Update: map[string][]mcpclient.FieldOperation{"labels": {{Remove: "stale"}}},
+	}).Return(nil)
+
+	var out, errOut bytes.Buffer
+	err = bulkApplyRunE(context.Background(), mockMCP, bulkTestResponse(), actions, bulkApplyOptions{outputFormat: "json"}, &prompt.Confirmer{AssumeYes: true}, &out, &errOut)
+	require.NoError(t, err)
+	assert.Contains(t, errOut.String(), `remove label "stale"`, "the preview goes to stderr with JSON output")
+
+	var results []bulkResult
+	require.NoError(t, json.Unmarshal(out.Bytes(), &results))
+	assert.Equal(t, []bulkResult{{Key: "BE-1", OK: true}, {Key: "BE-2", OK: true}}, results)
+}
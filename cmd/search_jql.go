@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/reldate"
+)
+
+// jqlFilters are the common search filters that can be given as flags instead of JQL.
+type jqlFilters struct {
+	assignees    []string
+	statuses     []string
+	projects     []string
+	createdSince string
+	updatedSince string
+
+	// now is the reference time for relative dates, in the configured time zone.
+	// The zero value means time.Now() in the system zone.
+	now time.Time
+}
+
+// orderByRe finds the ORDER BY clause of a JQL query, which must stay at the end.
+var orderByRe = regexp.MustCompile(`(?i)\border\s+by\b`)
+
+// jqlRelativeRe matches Jira relative date offsets such as "3d", "-2w" or "12h",
+// which are passed to Jira as is.
+var jqlRelativeRe = regexp.MustCompile(`^-?\d+[wdhm]$`)
+
+// addJQLFilterFlags registers the JQL builder flags on cmd.
+func addJQLFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("assignee", nil, "Only issues assigned to these users ('me' for yourself, 'none' for unassigned)")
+	_ = cmd.RegisterFlagCompletionFunc("assignee", completeAssignees)
+	cmd.Flags().StringSlice("status", nil, "Only issues in these statuses")
+	cmd.Flags().StringSlice("project", nil, "Only issues in these projects")
+	cmd.Flags().String("created-since", "", "Only issues created since a date (2025-04-01, \"last monday\", yesterday) or offset (3d, 2w)")
+	cmd.Flags().String("updated-since", "", "Only issues updated since a date (2025-04-01, \"last monday\", yesterday) or offset (3d, 2w)")
+}
+
+// jqlFiltersFromFlags reads the JQL builder flags of cmd. Flags that are not registered are ignored.
+func jqlFiltersFromFlags(cmd *cobra.Command) jqlFilters {
+	var f jqlFilters
+	f.assignees, _ = cmd.Flags().GetStringSlice("assignee")
+	f.statuses, _ = cmd.Flags().GetStringSlice("status")
+	f.projects, _ = cmd.Flags().GetStringSlice("project")
+	f.createdSince, _ = cmd.Flags().GetString("created-since")
+	f.updatedSince, _ = cmd.Flags().GetString("updated-since")
+	return f
+}
+
+// needsTimezone reports whether a date filter must be resolved locally, which requires
+// the configured time zone. Jira offsets such as "3d" are resolved by Jira itself.
+func (f jqlFilters) needsTimezone() bool {
+	for _, value := range []string{f.createdSince, f.updatedSince} {
+		value = strings.TrimSpace(value)
+		if value != "" && !jqlRelativeRe.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// configuredNow returns the current time in the time zone set by timezone in config.yaml.
+func configuredNow(cfgProvider ConfigProvider) (time.Time, error) {
+	cfg, err := cfgProvider.LoadConfig()
+	if err != nil {
+		return time.Time{}, err
+	}
+	loc, err := cfg.Location()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Now().In(loc), nil
+}
+
+// buildJQL combines a raw JQL query with the filters using AND. The raw query is parenthesized
+// so its own OR clauses keep their meaning, and its ORDER BY clause is moved to the end.
+// It returns an empty string if there is neither a query nor a filter.
+func buildJQL(raw string, f jqlFilters) (string, error) {
+	var clauses []string
+	query, orderBy := splitOrderBy(strings.TrimSpace(raw))
+
+	if clause := jqlAssigneeClause(f.assignees); clause != "" {
+		clauses = append(clauses, clause)
+	}
+	if clause := jqlInClause("status", f.statuses); clause != "" {
+		clauses = append(clauses, clause)
+	}
+	if clause := jqlInClause("project", f.projects); clause != "" {
+		clauses = append(clauses, clause)
+	}
+	now := f.now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	for _, date := range []struct{ field, flag, value string }{
+		{"created", "created-since", f.createdSince},
+		{"updated", "updated-since", f.updatedSince},
+	} {
+		since := strings.TrimSpace(date.value)
+		if since == "" {
+			continue
+		}
+		value, err := jqlDateValue(since, now)
+		if err != nil {
+			return "", fmt.Errorf("invalid --%s: %w", date.flag, err)
+		}
+		clauses = append(clauses, date.field+" >= "+value)
+	}
+
+	jql := query
+	if len(clauses) > 0 {
+		if query != "" {
+			clauses = append([]string{"(" + query + ")"}, clauses...)
+		}
+		jql = strings.Join(clauses, " AND ")
+	}
+	if orderBy != "" {
+		jql = strings.TrimSpace(jql + " " + orderBy)
+	}
+	return jql, nil
+}
+
+// splitOrderBy separates a trailing ORDER BY clause from a JQL query. "order by" inside a
+// quoted string, e.g. summary ~ "sort order by date", is not a clause.
+func splitOrderBy(jql string) (query, orderBy string) {
+	for _, loc := range orderByRe.FindAllStringIndex(jql, -1) {
+		if !jqlQuoted(jql, loc[0]) {
+			return strings.TrimSpace(jql[:loc[0]]), strings.TrimSpace(jql[loc[0]:])
+		}
+	}
+	return jql, ""
+}
+
+// jqlQuoted reports whether the byte at pos of jql is inside a single- or double-quoted
+// string. A backslash escapes the next character within a string.
+func jqlQuoted(jql string, pos int) bool {
+	var quote byte
+	for i := 0; i < pos; i++ {
+		switch c := jql[i]; {
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote != 0 && c == '\\':
+			i++
+		case c == quote:
+			quote = 0
+		}
+	}
+	return quote != 0
+}
+
+// jqlAssigneeClause builds the assignee filter, mapping "me" to currentUser() and
+// "none"/"unassigned" to EMPTY.
+func jqlAssigneeClause(assignees []string) string {
+	var users []string
+	unassigned := false
+	for _, a := range nonEmpty(assignees) {
+		switch strings.ToLower(a) {
+		case "me", "currentuser()":
+			users = append(users, "currentUser()")
+		case "none", "unassigned":
+			unassigned = true
+		default:
+			users = append(users, jqlQuote(a))
+		}
+	}
+	var parts []string
+	switch len(users) {
+	case 0:
+	case 1:
+		parts = append(parts, "assignee = "+users[0])
+	default:
+		parts = append(parts, "assignee in ("+strings.Join(users, ", ")+")")
+	}
+	if unassigned {
+		parts = append(parts, "assignee is EMPTY")
+	}
+	if len(parts) > 1 {
+		return "(" + strings.Join(parts, " OR ") + ")"
+	}
+	return strings.Join(parts, "")
+}
+
+// jqlInClause builds "field = value" or "field in (values...)" with quoted values.
+func jqlInClause(field string, values []string) string {
+	values = nonEmpty(values)
+	switch len(values) {
+	case 0:
+		return ""
+	case 1:
+		return field + " = " + jqlQuote(values[0])
+	}
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = jqlQuote(v)
+	}
+	return field + " in (" + strings.Join(quoted, ", ") + ")"
+}
+
+// jqlDateValue converts a date flag to a JQL date value. Jira offsets are passed through
+// with a leading minus; other expressions are resolved against now and quoted, as a date
+// when they fall on midnight and as a date and time otherwise.
+func jqlDateValue(s string, now time.Time) (string, error) {
+	if jqlRelativeRe.MatchString(s) {
+		return "-" + strings.TrimPrefix(s, "-"), nil
+	}
+	t, err := reldate.Parse(s, now)
+	if err != nil {
+		return "", err
+	}
+	t = t.In(now.Location())
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+		return jqlQuote(t.Format("2006-01-02")), nil
+	}
+	return jqlQuote(t.Format("2006-01-02 15:04")), nil
+}
+
+// jqlQuote returns s as a double-quoted JQL string literal.
+func jqlQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// nonEmpty returns the trimmed, non-empty values.
+func nonEmpty(values []string) []string {
+	var result []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/clierr"
+	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+func TestBuildJQL(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		filters jqlFilters
+		want    string
+		wantErr string
+	}{
+		{name: "raw only", raw: "project = BE", want: "project = BE"},
+		{name: "nothing", want: ""},
+		{
+			name:    "filters only",
+			filters: jqlFilters{assignees: []string{"me"}, statuses: []string{"In Progress"}, projects: []string{"BE"}},
+			want:    `assignee = currentUser() AND status = "In Progress" AND project = "BE"`,
+		},
+		{
+			name:    "raw combined and order kept last",
+			raw:     "labels = ops OR labels = infra order by updated DESC",
+			filters: jqlFilters{projects: []string{"BE", "API"}},
+			want:    `(labels = ops OR labels = infra) AND project in ("BE", "API") order by updated DESC`,
+		},
+		{
+			name:    "order by only",
+			raw:     "ORDER BY created",
+			filters: jqlFilters{statuses: []string{"Open"}},
+			want:    `status = "Open" ORDER BY created`,
+		},
+		{
+			name:    "order by inside quotes is not a clause",
+			raw:     `summary ~ "sort order by date" OR summary ~ 'it\'s order by' ORDER BY key`,
+			filters: jqlFilters{projects: []string{"BE"}},
+			want:    `(summary ~ "sort order by date" OR summary ~ 'it\'s order by') AND project = "BE" ORDER BY key`,
+		},
+		{
+			name:    "quoted order by only",
+			raw:     `text ~ "order by"`,
+			filters: jqlFilters{projects: []string{"BE"}},
+			want:    `(text ~ "order by") AND project = "BE"`,
+		},
+		{
+			name:    "assignees with unassigned",
+			filters: jqlFilters{assignees: []string{"alice", " ", "none", `bob "b"`}},
+			want:    `(assignee in ("alice", "bob \"b\"") OR assignee is EMPTY)`,
+		},
+		{name: "relative updated", filters: jqlFilters{updatedSince: "3d"}, want: "updated >= -3d"},
+		{name: "absolute updated", filters: jqlFilters{updatedSince: "2025-04-01"}, want: `updated >= "2025-04-01"`},
+		{name: "invalid updated", filters: jqlFilters{updatedSince: "yesterday-ish"}, wantErr: "invalid --updated-since"},
+		{
+			name:    "human dates",
+			filters: jqlFilters{createdSince: "last monday", updatedSince: "2 hours ago", now: time.Date(2025, time.April, 16, 9, 30, 0, 0, time.UTC)},
+			want:    `created >= "2025-04-14" AND updated >= "2025-04-16 07:30"`,
+		},
+		{name: "invalid created", filters: jqlFilters{createdSince: "soon"}, wantErr: "invalid --created-since"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildJQL(tt.raw, tt.filters)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSearchCmd_FilterFlags(t *testing.T) {
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("SearchIssues", mock.Anything, mcpclient.SearchIssuesRequest{JQL: `assignee = currentUser() AND project = "BE"`, MaxResults: 20}).
+		Return(&mcpclient.SearchIssuesResponse{}, nil)
+
+	cmd := &cobra.Command{}
+	setupSearchCmdFlags(cmd, "text", "")
+	addJQLFilterFlags(cmd)
+	require.NoError(t, cmd.Flags().Set("assignee", "me"))
+	require.NoError(t, cmd.Flags().Set("project", "BE"))
+
+	var out bytes.Buffer
+	require.NoError(t, searchRunE(new(MockConfigProvider), mockMCP, &out, cmd, nil))
+	mockMCP.AssertExpectations(t)
+}
+
+func TestSearchCmd_RelativeDateUsesConfiguredTimezone(t *testing.T) {
+	mockProvider := new(MockConfigProvider)
+	mockProvider.On("LoadConfig").Return(&config.AppConfig{Timezone: "Pacific/Kiritimati"}, nil)
+	mockMCP := new(MockMCPClient)
+	kiritimati, err := time.LoadLocation("Pacific/Kiritimati")
+	require.NoError(t, err)
+	today := time.Now().In(kiritimati).Format("2006-01-02")
+	mockMCP.On("SearchIssues", mock.Anything, mcpclient.SearchIssuesRequest{JQL: `created >= "` + today + `"`, MaxResults: 20}).
+		Return(&mcpclient.SearchIssuesResponse{}, nil)
+
+	cmd := &cobra.Command{}
+	setupSearchCmdFlags(cmd, "text", "")
+	addJQLFilterFlags(cmd)
+	require.NoError(t, cmd.Flags().Set("created-since", "today"))
+
+	var out bytes.Buffer
+	require.NoError(t, searchRunE(mockProvider, mockMCP, &out, cmd, nil))
+	mockMCP.AssertExpectations(t)
+}
+
+func TestSearchCmd_InvalidTimezone(t *testing.T) {
+	mockProvider := new(MockConfigProvider)
+	mockProvider.On("LoadConfig").Return(&config.AppConfig{Timezone: "Nowhere/Land"}, nil)
+
+	cmd := &cobra.Command{}
+	setupSearchCmdFlags(cmd, "text", "")
+	addJQLFilterFlags(cmd)
+	require.NoError(t, cmd.Flags().Set("updated-since", "yesterday"))
+
+	err := searchRunE(mockProvider, new(MockMCPClient), &bytes.Buffer{}, cmd, nil)
+	assert.ErrorIs(t, err, config.ErrInvalidTimezone)
+	assert.Contains(t, clierr.HintOf(err), "'timezone' setting")
+}
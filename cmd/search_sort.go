@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/output"
+	"github.com/karolswdev/ticketron/internal/sanitize"
+)
+
+// issueFieldAliases maps short field names accepted by --sort and --group-by to issue paths.
+var issueFieldAliases = map[string]string{
+	"key":         "key",
+	"summary":     "fields.summary",
+	"status":      "fields.status.name",
+	"type":        "fields.issuetype.name",
+	"issuetype":   "fields.issuetype.name",
+	"description": "fields.description",
+}
+
+// issueKeyRe matches Jira issue keys, which sort by project and then numerically.
+var issueKeyRe = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_]*)-(\d+)$`)
+
+// noGroupName labels issues without a value for the --group-by field.
+const noGroupName = "(none)"
+
+// issueSortKey is a single --sort criterion.
+type issueSortKey struct {
+	path string
+	desc bool
+}
+
+// issueGroup is a set of issues sharing the same --group-by value.
+type issueGroup struct {
+	Name   string        `json:"group" yaml:"group"`
+	Count  int           `json:"count" yaml:"count"`
+	Issues []interface{} `json:"issues" yaml:"issues"`
+}
+
+// resolveIssueFieldPath maps an alias such as "status" to its issue path; paths are returned unchanged.
+func resolveIssueFieldPath(name string) string {
+	name = strings.TrimSpace(name)
+	if path, ok := issueFieldAliases[strings.ToLower(name)]; ok {
+		return path
+	}
+	return name
+}
+
+// parseSortSpec parses "status,key:desc" into sort keys. The direction defaults to ascending.
+func parseSortSpec(spec string) ([]issueSortKey, error) {
+	var keys []issueSortKey
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		field, direction, _ := strings.Cut(item, ":")
+		key := issueSortKey{path: resolveIssueFieldPath(field)}
+		switch strings.ToLower(strings.TrimSpace(direction)) {
+		case "", "asc":
+		case "desc":
+			key.desc = true
+		default:
+			return nil, fmt.Errorf("invalid sort direction %q in %q (expected asc or desc)", direction, item)
+		}
+		if key.path == "" {
+			return nil, fmt.Errorf("invalid sort field in %q", item)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// sortIssues sorts issues in place by keys. The sort is stable so the server order
+// is kept for equal values. Sort values are extracted once per issue before sorting.
+func sortIssues(issues []mcpclient.Issue, keys []issueSortKey) {
+	paths := make([]*output.Path, len(keys))
+	for i, key := range keys {
+		paths[i] = output.Compile(key.path)
+	}
+	type sortEntry struct {
+		issue  mcpclient.Issue
+		values []string
+	}
+	entries := make([]sortEntry, len(issues))
+	for i, issue := range issues {
+		entries[i] = sortEntry{issue: issue, values: make([]string, len(paths))}
+		for k, path := range paths {
+			entries[i].values[k] = path.Text(issue)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		for k, key := range keys {
+			c := compareFieldValues(entries[i].values[k], entries[j].values[k])
+			if c == 0 {
+				continue
+			}
+			if key.desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+	for i := range entries {
+		issues[i] = entries[i].issue
+	}
+}
+
+// compareFieldValues orders issue keys by project and number, numbers numerically and
+// everything else case-insensitively. Empty values always sort last.
+func compareFieldValues(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+	if ma, mb := issueKeyRe.FindStringSubmatch(a), issueKeyRe.FindStringSubmatch(b); ma != nil && mb != nil {
+		if c := strings.Compare(strings.ToUpper(ma[1]), strings.ToUpper(mb[1])); c != 0 {
+			return c
+		}
+		na, _ := strconv.Atoi(ma[2])
+		nb, _ := strconv.Atoi(mb[2])
+		return compareInts(na, nb)
+	}
+	if fa, errA := strconv.ParseFloat(a, 64); errA == nil {
+		if fb, errB := strconv.ParseFloat(b, 64); errB == nil {
+			switch {
+			case fa < fb:
+				return -1
+			case fa > fb:
+				return 1
+			}
+			return 0
+		}
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// groupIssues splits issues by the value at path. Group names are ordered with issues
+// lacking a value last; issues keep their order within a group.
+func groupIssues(issues []mcpclient.Issue, path string) ([]string, map[string][]mcpclient.Issue) {
+	accessor := output.Compile(path)
+	var names []string
+	members := make(map[string][]mcpclient.Issue)
+	for _, issue := range issues {
+		name := accessor.Text(issue)
+		if _, seen := members[name]; !seen {
+			names = append(names, name)
+		}
+		members[name] = append(members[name], issue)
+	}
+	sort.SliceStable(names, func(i, j int) bool { return compareFieldValues(names[i], names[j]) < 0 })
+	return names, members
+}
+
+// writeGroupedSearchResults renders search results grouped by the value at groupPath.
+// Structured formats emit an ordered list of {group, count, issues}; TSV adds the group
+// as the first column and text prints a heading per group.
+func writeGroupedSearchResults(out io.Writer, issues []mcpclient.Issue, groupPath, outputFormat string, fields, tsvFields []string) error {
+	format := output.Normalize(outputFormat)
+	if len(issues) == 0 && !output.IsStructured(format) {
+		fmt.Fprintln(out, "No issues found.")
+		return nil
+	}
+
+	names, members := groupIssues(issues, groupPath)
+	groups := make([]issueGroup, 0, len(names))
+	fieldPaths := output.CompileAll(fields)
+	tsvPaths := output.CompileAll(tsvFields)
+
+	switch format {
+	case output.FormatJSON, output.FormatYAML:
+		for _, name := range names {
+			group := issueGroup{Name: name, Count: len(members[name])}
+			if name == "" {
+				group.Name = noGroupName
+			}
+			for _, issue := range members[name] {
+				if len(fields) > 0 {
+					group.Issues = append(group.Issues, output.Extract(issue, fieldPaths))
+				} else {
+					group.Issues = append(group.Issues, issue)
+				}
+			}
+			groups = append(groups, group)
+		}
+		if err := output.Structured(out, format, groups); err != nil {
+			return fmt.Errorf("failed to format search results: %w", err)
+		}
+
+	case output.FormatTSV:
+		var rows [][]string
+		for _, name := range names {
+			for _, issue := range members[name] {
+				rows = append(rows, append([]string{name}, tsvRow(issue, tsvPaths)...))
+			}
+		}
+		return output.TSV(out, append([]string{groupPath}, tsvFields...), rows)
+
+	default:
+		fmt.Fprintf(out, "Found %d issues:\n", len(issues))
+		for _, name := range names {
+			label := sanitize.Line(name)
+			if label == "" {
+				label = noGroupName
+			}
+			fmt.Fprintf(out, "\n%s (%d):\n", label, len(members[name]))
+			for _, issue := range members[name] {
+				fmt.Fprintln(out, issueLine(issue))
+			}
+		}
+	}
+	return nil
+}
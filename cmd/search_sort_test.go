@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+func sortTestIssues() []mcpclient.Issue {
+	issue := func(key, status, summary string) mcpclient.Issue {
+		return mcpclient.Issue{Key: key, Fields: mcpclient.IssueFields{Summary: summary, Status: mcpclient.Status{Name: status}}}
+	}
+	return []mcpclient.Issue{
+		issue("BE-10", "Open", "beta"),
+		issue("BE-9", "Done", "Alpha"),
+		issue("API-2", "", "gamma"),
+		issue("BE-100", "Open", "alpha"),
+	}
+}
+
+func issueKeys(issues []mcpclient.Issue) []string {
+	keys := make([]string, len(issues))
+	for i, issue := range issues {
+		keys[i] = issue.Key
+	}
+	return keys
+}
+
+func TestParseSortSpec(t *testing.T) {
+	keys, err := parseSortSpec("status, key:DESC,fields.custom")
+	require.NoError(t, err)
+	assert.Equal(t, []issueSortKey{{path: "fields.status.name"}, {path: "key", desc: true}, {path: "fields.custom"}}, keys)
+
+	_, err = parseSortSpec("key:up")
+	assert.ErrorContains(t, err, `invalid sort direction "up"`)
+	_, err = parseSortSpec(":desc")
+	assert.ErrorContains(t, err, "invalid sort field")
+}
+
+func TestSortIssues(t *testing.T) {
+	issues := sortTestIssues()
+	sortIssues(issues, []issueSortKey{{path: "key"}})
+	assert.Equal(t, []string{"API-2", "BE-9", "BE-10", "BE-100"}, issueKeys(issues), "keys sort by project, then numerically")
+
+	issues = sortTestIssues()
+	sortIssues(issues, []issueSortKey{{path: "fields.status.name"}, {path: "fields.summary", desc: true}})
+	assert.Equal(t, []string{"BE-9", "BE-10", "BE-100", "API-2"}, issueKeys(issues), "empty values sort last")
+}
+
+func TestCompareFieldValues(t *testing.T) {
+	assert.Equal(t, -1, compareFieldValues("2", "10"))
+	assert.Equal(t, 1, compareFieldValues("b", "A"))
+	assert.Equal(t, 0, compareFieldValues("x", "x"))
+	assert.Equal(t, -1, compareFieldValues("x", ""))
+}
+
+func runGroupedSearch(t *testing.T, outputFormat, outputFields string) string {
+	t.Helper()
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("SearchIssues", mock.Anything, mock.Anything).Return(&mcpclient.SearchIssuesResponse{Issues: sortTestIssues()}, nil)
+
+	cmd := &cobra.Command{}
+	setupSearchCmdFlags(cmd, outputFormat, outputFields)
+	cmd.Flags().String("sort", "key", "")
+	cmd.Flags().String("group-by", "status", "")
+
+	var out bytes.Buffer
+	require.NoError(t, searchRunE(new(MockConfigProvider), mockMCP, &out, cmd, []string{"project = BE"}))
+	return out.String()
+}
+
+func TestSearchCmd_GroupBy_Text(t *testing.T) {
+	want := "Found 4 issues:\n" +
+		"\nDone (1):\n- BE-9 - Done - Alpha\n" +
+		"\nOpen (2):\n- BE-10 - Open - beta\n- BE-100 - Open - alpha\n" +
+		"\n(none) (1):\n- API-2 -  - gamma\n"
+	assert.Equal(t, want, runGroupedSearch(t, "text", ""))
+}
+
+func TestSearchCmd_GroupBy_TSV(t *testing.T) {
+	want := "fields.status.name\tkey\tfields.summary\n" +
+		"Done\tBE-9\tAlpha\n" +
+		"Open\tBE-10\tbeta\n" +
+		"Open\tBE-100\talpha\n" +
+		"\tAPI-2\tgamma\n"
+	assert.Equal(t, want, runGroupedSearch(t, "tsv", "key,fields.summary"))
+}
+
+func TestSearchCmd_GroupBy_JSON(t *testing.T) {
+	var groups []struct {
+		Group  string                   `json:"group"`
+		Count  int                      `json:"count"`
+		Issues []map[string]interface{} `json:"issues"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(runGroupedSearch(t, "json", "key")), &groups))
+	require.Len(t, groups, 3)
+	assert.Equal(t, "Open", groups[1].Group)
+	assert.Equal(t, 2, groups[1].Count)
+	assert.Equal(t, []map[string]interface{}{{"key": "BE-10"}, {"key": "BE-100"}}, groups[1].Issues)
+	assert.Equal(t, "(none)", groups[2].Group)
+}
@@ -0,0 +1,340 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/webhook"
+)
+
+// maxWebhookBodyBytes bounds the size of inbound webhook payloads.
+const maxWebhookBodyBytes = 1 << 20 // 1 MiB
+
+// webhookTokenHeader is the header carrying a mapping's shared secret.
+const webhookTokenHeader = "X-Ticketron-Token"
+
+// watchedConfigFiles are the files in the config directory that trigger a reload in serve mode.
+var watchedConfigFiles = []string{
+	config.DefaultConfigFileName,
+	config.DefaultLinksFileName,
+	config.DefaultPromptFileName,
+	config.DefaultContextFileName,
+	config.DefaultWebhooksFileName,
+}
+
+// serveState is an immutable snapshot of everything the daemon derives from the config directory.
+// It is replaced as a whole on reload so in-flight requests keep a consistent view.
+type serveState struct {
+	runner   *createCmdRunner
+	configs  *loadedConfigs
+	webhooks *webhook.Config
+}
+
+// loadServeState builds a fully validated serveState, using newRunner to construct the clients.
+// Identical searches the daemon sends at the same time are merged into one request.
+func loadServeState(newRunner func() (*createCmdRunner, error)) (*serveState, error) {
+	runner, err := newRunner()
+	if err != nil {
+		return nil, err
+	}
+	runner.mcpClient = newCoalescingMCPClient(runner.mcpClient)
+	configs, err := loadAllConfigs(runner.configProvider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	webhooks, err := loadWebhooks(runner.configProvider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load webhook mappings: %w", err)
+	}
+	return &serveState{runner: runner, configs: configs, webhooks: webhooks}, nil
+}
+
+// serveHandler serves the HTTP endpoints of `tix serve`.
+type serveHandler struct {
+	state atomic.Pointer[serveState]
+}
+
+// newServeHandler creates a handler serving the given initial state.
+func newServeHandler(state *serveState) *serveHandler {
+	h := &serveHandler{}
+	h.state.Store(state)
+	return h
+}
+
+// reload loads a new state and atomically swaps it in. If loading fails the current
+// state is kept, so a bad edit to a config file does not take the daemon down.
+func (h *serveHandler) reload(newRunner func() (*createCmdRunner, error)) error {
+	state, err := loadServeState(newRunner)
+	if err != nil {
+		Log.Error().Err(err).Msg("Configuration reload failed, keeping previous configuration")
+		return err
+	}
+	h.state.Store(state)
+	Log.Info().Int("webhooks", len(state.webhooks.Webhooks)).Msg("Configuration reloaded")
+	return nil
+}
+
+// serveErrorResponse is the JSON body returned for failed requests.
+type serveErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// routes registers all serve endpoints.
+func (h *serveHandler) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", h.handleHealth)
+	mux.HandleFunc("POST /webhooks/{name}", h.handleWebhook)
+	mux.HandleFunc("OPTIONS /quick-create", h.handleQuickCreatePreflight)
+	mux.HandleFunc("POST /quick-create", h.handleQuickCreate)
+	return mux
+}
+
+func (h *serveHandler) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// handleWebhook converts an inbound payload into a ticket using the named mapping.
+func (h *serveHandler) handleWebhook(w http.ResponseWriter, r *http.Request) {
+	state := h.state.Load()
+	name := r.PathValue("name")
+	mapping, ok := state.webhooks.Find(name)
+	if !ok {
+		writeJSON(w, http.StatusNotFound, serveErrorResponse{Error: fmt.Sprintf("unknown webhook %q", name)})
+		return
+	}
+	token := r.Header.Get(webhookTokenHeader)
+	if token == "" {
+		token = r.URL.Query().Get("token")
+	}
+	if !mapping.Authorize(token) {
+		Log.Warn().Str("webhook", name).Str("remote", r.RemoteAddr).Msg("Rejected webhook with invalid token")
+		writeJSON(w, http.StatusUnauthorized, serveErrorResponse{Error: "invalid or missing token"})
+		return
+	}
+
+	var payload interface{}
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxWebhookBodyBytes)).Decode(&payload); err != nil {
+		writeJSON(w, http.StatusBadRequest, serveErrorResponse{Error: fmt.Sprintf("invalid JSON payload: %v", err)})
+		return
+	}
+
+	rendered, err := mapping.Render(payload)
+	if err != nil {
+		writeJSON(w, http.StatusUnprocessableEntity, serveErrorResponse{Error: err.Error()})
+		return
+	}
+
+	request, err := state.issueRequestFor(r.Context(), mapping, rendered)
+	if err != nil {
+		writeJSON(w, http.StatusUnprocessableEntity, serveErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if state.runner.mcpClient == nil {
+		writeJSON(w, http.StatusServiceUnavailable, serveErrorResponse{Error: errMCPClientNotInitialized.Error()})
+		return
+	}
+	request, resp, err := state.runner.submitIssue(r.Context(), request)
+	if errors.Is(err, errInvalidIssueRequest) {
+		writeJSON(w, http.StatusUnprocessableEntity, serveErrorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		Log.Error().Err(err).Str("webhook", name).Msg("Failed to create issue from webhook")
+		writeJSON(w, http.StatusBadGateway, serveErrorResponse{Error: err.Error()})
+		return
+	}
+	Log.Info().Str("webhook", name).Str("issue_key", resp.Key).Msg("Created issue from webhook")
+	var systemPrompt string
+	if mapping.UseLLM {
+		systemPrompt = state.configs.systemPrompt
+	}
+	state.runner.recordHistory("webhook", rendered.Input, systemPrompt, request, resp)
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+// issueRequestFor builds the create request for a rendered webhook, either directly
+// from the templates or by running the rendered input through the LLM.
+func (s *serveState) issueRequestFor(ctx context.Context, mapping *webhook.Mapping, rendered webhook.Rendered) (mcpclient.CreateIssueRequest, error) {
+	if !mapping.UseLLM {
+		if rendered.Summary == "" {
+			return mcpclient.CreateIssueRequest{}, errors.New("summary template rendered an empty summary")
+		}
+		issueType := mapping.IssueType
+		if issueType == "" {
+			issueType = defaultIssueType
+		}
+		descriptionFormat, err := descriptionFormatFor(s.configs, "")
+		if err != nil {
+			return mcpclient.CreateIssueRequest{}, err
+		}
+		return mcpclient.CreateIssueRequest{
+			ProjectKey:        mapping.Project,
+			Summary:           rendered.Summary,
+			Description:       rendered.Description,
+			IssueType:         issueType,
+			DescriptionFormat: descriptionFormat,
+		}, nil
+	}
+
+	var hints bytes.Buffer
+	request, err := s.runner.buildIssueRequest(ctx, &hints, s.configs, rendered.Input, issueRequestOptions{
+		issueType:  mapping.IssueType,
+		projectKey: mapping.Project,
+	})
+	if err != nil {
+		return mcpclient.CreateIssueRequest{}, withHints(err, hints.String())
+	}
+	return request, nil
+}
+
+// writeJSON writes v as a JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		Log.Warn().Err(err).Msg("Failed to write JSON response")
+	}
+}
+
+// loadWebhooks loads webhook mappings from the configuration directory.
+func loadWebhooks(cp ConfigProvider) (*webhook.Config, error) {
+	configDir, err := cp.EnsureConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return webhook.LoadConfig(filepath.Join(configDir, config.DefaultWebhooksFileName))
+}
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run Ticketron as a long-running HTTP daemon",
+	Long: `Starts an HTTP server that accepts inbound webhooks (e.g. from Alertmanager or
+Sentry) and converts them into JIRA issues using the mappings defined in
+~/.ticketron/webhooks.yaml, optionally processing them through the LLM.
+
+Endpoints:
+  POST /webhooks/{name}   Create an issue from a payload using mapping {name}
+  POST /quick-create      Create an issue from text selected in the browser
+  GET  /healthz           Liveness probe
+
+Mappings with a 'token' require it in the X-Ticketron-Token header (or ?token=).
+/quick-create is enabled by the quick_create section of webhooks.yaml and accepts
+cross-origin requests with its token, for bookmarklets and browser extensions.
+
+With --socket PATH, editor plugins can call the JSON-RPC 2.0 methods ticket/create and
+ping on a unix socket, one JSON object per line. Only HTTP is served as well if --addr
+is given.
+
+Edits to config.yaml, links.yaml, system_prompt.txt, context.md and webhooks.yaml
+are picked up without a restart. An invalid edit is logged and the previous
+configuration stays active.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addr, _ := cmd.Flags().GetString("addr")
+		socket, _ := cmd.Flags().GetString("socket")
+
+		// Overrides are re-applied on every reload so they survive config edits.
+		newRunner := func() (*createCmdRunner, error) {
+			runner, err := newCreateCmdRunner()
+			if err != nil {
+				return nil, err
+			}
+			if err := runner.applyLLMOverrides(cmd); err != nil {
+				return nil, err
+			}
+			return runner, nil
+		}
+		state, err := loadServeState(newRunner)
+		if err != nil {
+			return err
+		}
+		configDir, err := state.runner.configProvider.EnsureConfigDir()
+		if err != nil {
+			return err
+		}
+
+		handler := newServeHandler(state)
+		server := &http.Server{
+			Addr:              addr,
+			Handler:           handler.routes(),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		// With --socket, HTTP is only served if --addr is given as well.
+		serveHTTP := socket == "" || cmd.Flags().Changed("addr")
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		go func() {
+			err := config.Watch(ctx, configDir, watchedConfigFiles, config.DefaultWatchDebounce, func() {
+				_ = handler.reload(newRunner)
+			})
+			if err != nil {
+				Log.Warn().Err(err).Msg("Configuration hot-reload disabled")
+			}
+		}()
+
+		errCh := make(chan error, 2)
+		if socket != "" {
+			rpcServer, err := newEditorRPCServer(handler)
+			if err != nil {
+				return err
+			}
+			listener, err := listenEditorSocket(socket)
+			if err != nil {
+				return err
+			}
+			go func() {
+				Log.Info().Str("socket", socket).Msg("Ticketron editor socket listening")
+				errCh <- rpcServer.Serve(ctx, listener)
+			}()
+		}
+		if serveHTTP {
+			go func() {
+				Log.Info().Str("addr", addr).Int("webhooks", len(state.webhooks.Webhooks)).Msg("Ticketron daemon listening")
+				errCh <- server.ListenAndServe()
+			}()
+		}
+
+		select {
+		case err := <-errCh:
+			if err == nil || errors.Is(err, http.ErrServerClosed) {
+				return nil
+			}
+			return fmt.Errorf("server failed: %w", err)
+		case <-ctx.Done():
+			Log.Info().Msg("Shutting down Ticketron daemon")
+			if !serveHTTP {
+				return nil // The socket is closed with ctx
+			}
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			return server.Shutdown(shutdownCtx)
+		}
+	},
+}
+
+func init() {
+	serveCmd.Flags().String("addr", "127.0.0.1:8765", "Address to listen on")
+	serveCmd.Flags().String("socket", "", "Serve JSON-RPC for editor plugins on this unix socket (instead of HTTP unless --addr is given)")
+	addLLMOverrideFlags(serveCmd)
+
+	rootCmd.AddCommand(serveCmd)
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/karolswdev/ticketron/internal/llm"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/sanitize"
+	"github.com/karolswdev/ticketron/internal/webhook"
+)
+
+// quickCreateRequest is the JSON body a bookmarklet or browser extension posts to /quick-create.
+type quickCreateRequest struct {
+	Text    string `json:"text"`              // Text selected on the page
+	URL     string `json:"url"`               // URL of the page
+	Title   string `json:"title"`             // Title of the page
+	Project string `json:"project,omitempty"` // Overrides quick_create.project
+	Type    string `json:"type,omitempty"`    // Overrides quick_create.issue_type
+}
+
+// quickCreateResponse is returned for an issue created through /quick-create.
+type quickCreateResponse struct {
+	Key string `json:"key"`
+	URL string `json:"url,omitempty"` // Web URL of the issue, to open or show in the browser
+}
+
+// setQuickCreateCORS adds the CORS headers allowing the page making r to read the response.
+// Requests without an Origin header, such as those of curl, need none.
+func setQuickCreateCORS(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return
+	}
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+	w.Header().Add("Vary", "Origin")
+	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, "+webhookTokenHeader)
+	w.Header().Set("Access-Control-Max-Age", "600")
+	// Chrome asks before public pages may call a daemon on localhost or the private network
+	if r.Header.Get("Access-Control-Request-Private-Network") == "true" {
+		w.Header().Set("Access-Control-Allow-Private-Network", "true")
+	}
+}
+
+// quickCreateConfig returns the quick_create settings of state if the endpoint may serve r,
+// or writes the error response and returns nil.
+func quickCreateConfig(w http.ResponseWriter, r *http.Request, state *serveState) *webhook.QuickCreate {
+	q := state.webhooks.QuickCreate
+	if q == nil {
+		writeJSON(w, http.StatusNotFound, serveErrorResponse{Error: "quick-create is not configured; add quick_create to webhooks.yaml"})
+		return nil
+	}
+	if origin := r.Header.Get("Origin"); origin != "" && !q.AllowsOrigin(origin) {
+		Log.Warn().Str("origin", origin).Str("remote", r.RemoteAddr).Msg("Rejected quick-create from a disallowed origin")
+		writeJSON(w, http.StatusForbidden, serveErrorResponse{Error: fmt.Sprintf("origin %s is not in quick_create.allowed_origins", origin)})
+		return nil
+	}
+	setQuickCreateCORS(w, r)
+	return q
+}
+
+// handleQuickCreatePreflight answers the CORS preflight of browsers before a quick-create.
+func (h *serveHandler) handleQuickCreatePreflight(w http.ResponseWriter, r *http.Request) {
+	if quickCreateConfig(w, r, h.state.Load()) == nil {
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleQuickCreate creates an issue from the text selected on a page and its URL.
+func (h *serveHandler) handleQuickCreate(w http.ResponseWriter, r *http.Request) {
+	state := h.state.Load()
+	q := quickCreateConfig(w, r, state)
+	if q == nil {
+		return
+	}
+	token := r.Header.Get(webhookTokenHeader)
+	if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		token = bearer
+	}
+	if !q.Authorize(token) {
+		Log.Warn().Str("remote", r.RemoteAddr).Msg("Rejected quick-create with invalid token")
+		writeJSON(w, http.StatusUnauthorized, serveErrorResponse{Error: "invalid or missing token"})
+		return
+	}
+
+	var body quickCreateRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxWebhookBodyBytes)).Decode(&body); err != nil {
+		writeJSON(w, http.StatusBadRequest, serveErrorResponse{Error: fmt.Sprintf("invalid JSON body: %v", err)})
+		return
+	}
+	request, err := state.quickCreateRequestFor(r.Context(), q, body)
+	if err != nil {
+		writeJSON(w, http.StatusUnprocessableEntity, serveErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if state.runner.mcpClient == nil {
+		writeJSON(w, http.StatusServiceUnavailable, serveErrorResponse{Error: errMCPClientNotInitialized.Error()})
+		return
+	}
+	request, resp, err := state.runner.submitIssue(r.Context(), request)
+	if errors.Is(err, errInvalidIssueRequest) {
+		writeJSON(w, http.StatusUnprocessableEntity, serveErrorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		Log.Error().Err(err).Str("url", body.URL).Msg("Failed to create issue from quick-create")
+		writeJSON(w, http.StatusBadGateway, serveErrorResponse{Error: err.Error()})
+		return
+	}
+	Log.Info().Str("issue_key", resp.Key).Str("url", body.URL).Msg("Created issue from quick-create")
+	var systemPrompt string
+	if q.UseLLM {
+		systemPrompt = state.configs.systemPrompt
+	}
+	state.runner.recordHistory("quick-create", quickCreateInput(body), systemPrompt, request, resp)
+	writeJSON(w, http.StatusCreated, quickCreateResponse{Key: resp.Key, URL: browseURL(resp.Self, resp.Key)})
+}
+
+// quickCreateInput returns the selection followed by the page it was made on.
+func quickCreateInput(body quickCreateRequest) string {
+	source := strings.TrimSpace(body.URL)
+	if title := strings.TrimSpace(body.Title); title != "" && source != "" {
+		source = title + " (" + source + ")"
+	} else if title != "" {
+		source = title
+	}
+	text := strings.TrimSpace(body.Text)
+	if source == "" {
+		return text
+	}
+	return strings.TrimSpace(text + "\n\nSource: " + source)
+}
+
+// quickCreateRequestFor builds the create request for a quick-create. With use_llm the LLM
+// writes the ticket from the selection and page; otherwise the first line of the selection,
+// or the page title, is the summary and the selection with its source the description.
+func (s *serveState) quickCreateRequestFor(ctx context.Context, q *webhook.QuickCreate, body quickCreateRequest) (mcpclient.CreateIssueRequest, error) {
+	if strings.TrimSpace(body.Text) == "" && strings.TrimSpace(body.Title) == "" {
+		return mcpclient.CreateIssueRequest{}, errors.New("text or title is required")
+	}
+	opts := issueRequestOptions{
+		projectKey: firstNonEmpty(body.Project, q.Project),
+		issueType:  firstNonEmpty(body.Type, q.IssueType),
+	}
+	var hints bytes.Buffer
+	if q.UseLLM {
+		request, err := s.runner.buildIssueRequest(ctx, &hints, s.configs, quickCreateInput(body), opts)
+		if err != nil {
+			return mcpclient.CreateIssueRequest{}, withHints(err, hints.String())
+		}
+		return request, nil
+	}
+
+	summary, _, _ := strings.Cut(strings.TrimSpace(body.Text), "\n")
+	if summary == "" {
+		summary = body.Title
+	}
+	opts.summary = sanitize.Truncate(strings.TrimSpace(sanitize.Line(summary)), llm.DefaultSummaryMaxLength)
+	opts.description = sanitize.Text(quickCreateInput(body))
+	return s.runner.buildDirectIssueRequest(ctx, s.configs, opts)
+}
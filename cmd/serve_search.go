@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/karolswdev/ticketron/internal/coalesce"
+	"github.com/karolswdev/ticketron/internal/jsonrpc"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+// editorSearchParams are the params of the issues/search method.
+type editorSearchParams struct {
+	JQL        string `json:"jql"`
+	MaxResults int    `json:"max_results,omitempty"`
+	StartAt    int    `json:"start_at,omitempty"`
+}
+
+// editorSearchIssues runs the search of an issues/search call.
+func editorSearchIssues(ctx context.Context, state *serveState, raw json.RawMessage) (*mcpclient.SearchIssuesResponse, error) {
+	var params editorSearchParams
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, jsonrpc.InvalidParams("invalid params: %v", err)
+	}
+	if strings.TrimSpace(params.JQL) == "" {
+		return nil, jsonrpc.InvalidParams("jql is required")
+	}
+	if params.MaxResults < 0 || params.StartAt < 0 {
+		return nil, jsonrpc.InvalidParams("max_results and start_at must not be negative")
+	}
+	if state.runner.mcpClient == nil {
+		return nil, errMCPClientNotInitialized
+	}
+	if params.MaxResults == 0 {
+		params.MaxResults = 20 // Same default as the search command
+	}
+	resp, err := state.runner.mcpClient.SearchIssues(ctx, mcpclient.SearchIssuesRequest{JQL: params.JQL, MaxResults: params.MaxResults, StartAt: params.StartAt})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+	return resp, nil
+}
+
+// coalescingMCPClient merges identical searches that are in flight at the same time into
+// one request, so that consumers of tix serve polling the same JQL do not multiply the load
+// on the MCP server. Other methods are passed through.
+type coalescingMCPClient struct {
+	MCPClient
+	searches coalesce.Group[*mcpclient.SearchIssuesResponse]
+}
+
+// newCoalescingMCPClient wraps client, which may be nil.
+func newCoalescingMCPClient(client MCPClient) MCPClient {
+	if client == nil {
+		return nil
+	}
+	return &coalescingMCPClient{MCPClient: client}
+}
+
+// SearchIssues searches for req, or waits for the same search already in flight. The
+// response is shared by the callers of the search and must not be modified.
+func (c *coalescingMCPClient) SearchIssues(ctx context.Context, req mcpclient.SearchIssuesRequest) (*mcpclient.SearchIssuesResponse, error) {
+	key := fmt.Sprintf("%d\x00%d\x00%s", req.MaxResults, req.StartAt, req.JQL)
+	resp, shared, err := c.searches.Do(ctx, key, func(ctx context.Context) (*mcpclient.SearchIssuesResponse, error) {
+		return c.MCPClient.SearchIssues(ctx, req)
+	})
+	if shared {
+		Log.Debug().Str("jql", req.JQL).Msg("Search coalesced with an identical search in flight")
+	}
+	return resp, err
+}
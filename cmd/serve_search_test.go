@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+func TestCoalescingMCPClient_SearchIssues(t *testing.T) {
+	Log = zerolog.Nop()
+	assert.Nil(t, newCoalescingMCPClient(nil))
+	mockMCP := new(MockMCPClient)
+	client := newCoalescingMCPClient(mockMCP)
+
+	started, release := make(chan struct{}), make(chan struct{})
+	request := mcpclient.SearchIssuesRequest{JQL: "project = BE AND status = Open", MaxResults: 20}
+	mockMCP.On("SearchIssues", mock.Anything, request).
+		Return(&mcpclient.SearchIssuesResponse{Total: 1, Issues: []mcpclient.Issue{{Key: "BE-1"}}}, nil).
+		Run(func(mock.Arguments) { close(started); <-release }).Once()
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.SearchIssues(context.Background(), request)
+			assert.NoError(t, err)
+			assert.Equal(t, 1, resp.Total)
+		}()
+	}
+	<-started
+	time.Sleep(50 * time.Millisecond) // Let the other callers join the search in flight
+	close(release)
+	wg.Wait()
+	mockMCP.AssertExpectations(t)
+
+	// Other pages are different searches, and nothing is cached
+	page := mcpclient.SearchIssuesRequest{JQL: request.JQL, MaxResults: 20, StartAt: 20}
+	mockMCP.On("SearchIssues", mock.Anything, page).Return(&mcpclient.SearchIssuesResponse{Total: 21}, nil).Once()
+	mockMCP.On("SearchIssues", mock.Anything, request).Return(&mcpclient.SearchIssuesResponse{Total: 2}, nil).Once()
+	resp, err := client.SearchIssues(context.Background(), page)
+	require.NoError(t, err)
+	assert.Equal(t, 21, resp.Total)
+	resp, err = client.SearchIssues(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, 2, resp.Total)
+	mockMCP.AssertExpectations(t)
+}
+
+func TestEditorRPC_SearchIssues(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	h, _ := newTestServeHandler(t, mockMCP)
+	rpcServer, err := newEditorRPCServer(h)
+	require.NoError(t, err)
+	mockMCP.On("SearchIssues", mock.Anything, mcpclient.SearchIssuesRequest{JQL: "assignee = currentUser()", MaxResults: 20}).
+		Return(&mcpclient.SearchIssuesResponse{Total: 1, Issues: []mcpclient.Issue{{Key: "BE-3"}}}, nil).Once()
+
+	server, conn := net.Pipe()
+	defer conn.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = rpcServer.ServeConn(ctx, server) }()
+	reader := bufio.NewReader(conn)
+	call := func(line string) string {
+		t.Helper()
+		_, err := conn.Write([]byte(line + "\n"))
+		require.NoError(t, err)
+		resp, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := call(`{"jsonrpc":"2.0","id":1,"method":"issues/search","params":{"jql":"assignee = currentUser()"}}`)
+	assert.Contains(t, resp, `"total":1`)
+	assert.Contains(t, resp, `"key":"BE-3"`)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":2,"error":{"code":-32602,"message":"jql is required"}}`,
+		call(`{"jsonrpc":"2.0","id":2,"method":"issues/search","params":{"jql":" "}}`))
+	mockMCP.AssertExpectations(t)
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/karolswdev/ticketron/internal/jsonrpc"
+	"github.com/karolswdev/ticketron/internal/llm"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/sanitize"
+)
+
+// editorCreateParams are the params of the ticket/create method: the code or comment
+// selected in the editor and where it comes from.
+type editorCreateParams struct {
+	Text         string `json:"text"`
+	File         string `json:"file,omitempty"`         // Path of the file, relative to the workspace if possible
+	StartLine    int    `json:"start_line,omitempty"`   // 1-based
+	EndLine      int    `json:"end_line,omitempty"`     // 1-based, inclusive
+	Language     string `json:"language,omitempty"`     // Language ID of the editor, e.g. go
+	Instructions string `json:"instructions,omitempty"` // What the ticket is about, as typed by the user
+	Project      string `json:"project,omitempty"`
+	IssueType    string `json:"issue_type,omitempty"`
+	NoLLM        bool   `json:"no_llm,omitempty"` // Use the instructions or the comment as the summary
+	DryRun       bool   `json:"dry_run,omitempty"`
+}
+
+// editorCreateResult is the result of ticket/create. Key is empty for a dry run.
+type editorCreateResult struct {
+	Key       string `json:"key,omitempty"`
+	URL       string `json:"url,omitempty"`
+	Project   string `json:"project"`
+	IssueType string `json:"issue_type"`
+	Summary   string `json:"summary"`
+}
+
+// newEditorRPCServer builds the JSON-RPC server of tix serve --socket. Its methods use
+// the current state of h, so configuration reloads apply to editor requests too.
+func newEditorRPCServer(h *serveHandler) (*jsonrpc.Server, error) {
+	server := jsonrpc.New()
+	if err := server.Handle("ping", func(ctx context.Context, params json.RawMessage) (any, error) {
+		return map[string]string{"version": version}, nil
+	}); err != nil {
+		return nil, err
+	}
+	err := server.Handle("ticket/create", func(ctx context.Context, raw json.RawMessage) (any, error) {
+		var params editorCreateParams
+		if err := json.Unmarshal(raw, &params); err != nil {
+			return nil, jsonrpc.InvalidParams("invalid params: %v", err)
+		}
+		if strings.TrimSpace(params.Text) == "" && strings.TrimSpace(params.Instructions) == "" {
+			return nil, jsonrpc.InvalidParams("text or instructions is required")
+		}
+		return editorCreateTicket(ctx, h.state.Load(), params)
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = server.Handle("issues/search", func(ctx context.Context, raw json.RawMessage) (any, error) {
+		return editorSearchIssues(ctx, h.state.Load(), raw)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return server, nil
+}
+
+// editorCreateTicket creates the issue for a ticket/create call.
+func editorCreateTicket(ctx context.Context, state *serveState, params editorCreateParams) (*editorCreateResult, error) {
+	opts := issueRequestOptions{projectKey: params.Project, issueType: params.IssueType}
+	var request mcpclient.CreateIssueRequest
+	var err error
+	if params.NoLLM {
+		opts.summary = sanitize.Truncate(editorSummary(params), llm.DefaultSummaryMaxLength)
+		opts.description = sanitize.Text(editorInput(params))
+		if opts.summary == "" {
+			return nil, jsonrpc.InvalidParams("no summary: give instructions or select a comment")
+		}
+		request, err = state.runner.buildDirectIssueRequest(ctx, state.configs, opts)
+	} else {
+		var hints bytes.Buffer
+		if request, err = state.runner.buildIssueRequest(ctx, &hints, state.configs, editorInput(params), opts); err != nil {
+			err = withHints(err, hints.String())
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := &editorCreateResult{Project: request.ProjectKey, IssueType: request.IssueType, Summary: request.Summary}
+	if params.DryRun {
+		return result, nil
+	}
+
+	if state.runner.mcpClient == nil {
+		return nil, errMCPClientNotInitialized
+	}
+	request, resp, err := state.runner.submitIssue(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create issue: %w", err)
+	}
+	Log.Info().Str("issue_key", resp.Key).Str("file", params.File).Msg("Created issue from editor")
+	var systemPrompt string
+	if !params.NoLLM {
+		systemPrompt = state.configs.systemPrompt
+	}
+	state.runner.recordHistory("editor", editorInput(params), systemPrompt, request, resp)
+	result.Key, result.URL = resp.Key, browseURL(resp.Self, resp.Key)
+	return result, nil
+}
+
+// editorInput returns the instructions followed by the selection as a fenced code block
+// headed by its location.
+func editorInput(params editorCreateParams) string {
+	var b strings.Builder
+	if instructions := strings.TrimSpace(params.Instructions); instructions != "" {
+		b.WriteString(instructions + "\n\n")
+	}
+	if strings.TrimSpace(params.Text) == "" {
+		return strings.TrimSpace(b.String())
+	}
+	if params.File != "" {
+		location := params.File
+		switch {
+		case params.StartLine > 0 && params.EndLine > params.StartLine:
+			location += fmt.Sprintf(":%d-%d", params.StartLine, params.EndLine)
+		case params.StartLine > 0:
+			location += fmt.Sprintf(":%d", params.StartLine)
+		}
+		fmt.Fprintf(&b, "Selected code in %s:\n\n", location)
+	}
+	fmt.Fprintf(&b, "```%s\n%s\n```", params.Language, strings.Trim(params.Text, "\n"))
+	return b.String()
+}
+
+// commentMarkerPattern matches the comment syntax and TODO-style tags starting a line.
+var commentMarkerPattern = regexp.MustCompile(`^\s*(//+|#+|--+|;+|/\*+|\*+|<!--)?\s*((TODO|FIXME|XXX|HACK|BUG)\b(\([^)]*\))?:?)?\s*`)
+
+// editorSummary returns the summary of a ticket/create call without the LLM: the first
+// line of the instructions, or of the selected comment without its comment markers.
+func editorSummary(params editorCreateParams) string {
+	for _, text := range []string{params.Instructions, params.Text} {
+		for _, line := range strings.Split(text, "\n") {
+			line = commentMarkerPattern.ReplaceAllString(line, "")
+			line = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(line), "*/"))
+			if line != "" {
+				return sanitize.Line(line)
+			}
+		}
+	}
+	return ""
+}
+
+// listenEditorSocket listens on the unix socket at path, readable only by the user from the
+// moment it is created, as it accepts requests without a token. A socket left behind by a tix serve that did not exit
+// cleanly is replaced.
+func listenEditorSocket(path string) (net.Listener, error) {
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("%s is in use by another tix serve", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+		}
+	}
+	listener, err := listenPrivateSocket(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on socket: %w", err)
+	}
+	if err := os.Chmod(path, 0o600); err != nil { // Where the umask is not applied
+		listener.Close()
+		return nil, fmt.Errorf("failed to restrict socket permissions: %w", err)
+	}
+	return listener, nil
+}
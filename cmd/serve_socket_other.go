@@ -0,0 +1,11 @@
+//go:build !unix
+
+package cmd
+
+import "net"
+
+// listenPrivateSocket listens on the unix socket at path. Platforms without a umask rely on
+// the permissions set by listenEditorSocket afterwards.
+func listenPrivateSocket(path string) (net.Listener, error) {
+	return net.Listen("unix", path)
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/llm"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+func TestEditorInput(t *testing.T) {
+	params := editorCreateParams{
+		Text:         "// TODO: retry on 429\nresp, err := client.Do(req)\n",
+		File:         "internal/api/client.go",
+		StartLine:    41,
+		EndLine:      42,
+		Language:     "go",
+		Instructions: "Rate limits break the sync",
+	}
+	assert.Equal(t, "Rate limits break the sync\n\nSelected code in internal/api/client.go:41-42:\n\n```go\n// TODO: retry on 429\nresp, err := client.Do(req)\n```", editorInput(params))
+	assert.Equal(t, "Rate limits break the sync", editorSummary(params))
+
+	params.Instructions = ""
+	assert.Equal(t, "retry on 429", editorSummary(params))
+	for text, want := range map[string]string{
+		"# FIXME(ann): flaky on CI":   "flaky on CI",
+		"/* Handle empty carts */":    "Handle empty carts",
+		"\n   -- remove after v2\n":   "remove after v2",
+		"<!-- TODO drop this banner":  "drop this banner",
+		" * Parse the header lazily.": "Parse the header lazily.",
+	} {
+		assert.Equal(t, want, editorSummary(editorCreateParams{Text: text}), text)
+	}
+}
+
+func TestEditorRPC_CreateTicket(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	h, mockLLM := newTestServeHandler(t, mockMCP)
+	rpcServer, err := newEditorRPCServer(h)
+	require.NoError(t, err)
+
+	input := "Selected code in main.go:7:\n\n```go\n// TODO: handle SIGHUP\n```"
+	mockLLM.On("GenerateTicketDetails", mock.Anything, input, "prompt", mcpServeTestContext).
+		Return(llm.LLMResponse{Summary: "Reload config on SIGHUP", Description: "Details", ProjectNameSuggestion: "backend"}, nil)
+	mockMCP.On("CreateIssue", mock.Anything, mcpclient.CreateIssueRequest{ProjectKey: "BE", Summary: "Reload config on SIGHUP", Description: "Details", IssueType: "Bug", IdempotencyKey: idempotencyKey(input, "BE")}).
+		Return(&mcpclient.CreateIssueResponse{Key: "BE-5", Self: "https://jira.example.com/rest/api/2/issue/10005"}, nil)
+
+	path := filepath.Join(t.TempDir(), "tix.sock")
+	listener, err := listenEditorSocket(path)
+	require.NoError(t, err)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = rpcServer.Serve(ctx, listener) }()
+	_, err = listenEditorSocket(path)
+	assert.ErrorContains(t, err, "in use", "a running server is not replaced")
+
+	conn, err := net.Dial("unix", path)
+	require.NoError(t, err)
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	call := func(line string) string {
+		t.Helper()
+		_, err := conn.Write([]byte(line + "\n"))
+		require.NoError(t, err)
+		resp, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		return resp
+	}
+
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":1,"result":{"key":"BE-5","url":"https://jira.example.com/browse/BE-5","project":"BE","issue_type":"Bug","summary":"Reload config on SIGHUP"}}`,
+		call(`{"jsonrpc":"2.0","id":1,"method":"ticket/create","params":{"text":"// TODO: handle SIGHUP","file":"main.go","start_line":7,"language":"go"}}`))
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":2,"result":{"project":"OPS","issue_type":"Task","summary":"handle SIGHUP"}}`,
+		call(`{"jsonrpc":"2.0","id":2,"method":"ticket/create","params":{"text":"// TODO: handle SIGHUP","project":"OPS","no_llm":true,"dry_run":true}}`))
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":3,"error":{"code":-32602,"message":"text or instructions is required"}}`,
+		call(`{"jsonrpc":"2.0","id":3,"method":"ticket/create","params":{}}`))
+	mockMCP.AssertExpectations(t)
+}
+
+func TestListenPrivateSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("no umask on Windows")
+	}
+	path := filepath.Join(t.TempDir(), "tix.sock")
+	listener, err := listenPrivateSocket(path)
+	require.NoError(t, err)
+	defer listener.Close()
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm(), "private without a chmod")
+}
+
+func TestListenEditorSocket_Stale(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tix.sock")
+	stale, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false) // As if the server had crashed
+	require.NoError(t, stale.Close())
+	require.FileExists(t, path)
+
+	listener, err := listenEditorSocket(path)
+	require.NoError(t, err, "a socket left behind is replaced")
+	require.NoError(t, listener.Close())
+	assert.NoFileExists(t, path, "the socket is removed on close")
+}
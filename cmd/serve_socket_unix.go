@@ -0,0 +1,18 @@
+//go:build unix
+
+package cmd
+
+import (
+	"net"
+
+	"golang.org/x/sys/unix"
+)
+
+// listenPrivateSocket listens on the unix socket at path with a umask of 0177, so the socket
+// is created with mode 0600 rather than being open to other users until it is chmodded. The
+// umask is process-wide and restored as soon as the socket exists.
+func listenPrivateSocket(path string) (net.Listener, error) {
+	old := unix.Umask(0o177)
+	defer unix.Umask(old)
+	return net.Listen("unix", path)
+}
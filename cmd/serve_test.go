@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/llm"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/webhook"
+)
+
+func newTestServeHandler(t *testing.T, mockMCP *MockMCPClient) (*serveHandler, *MockLLMClient) {
+	t.Helper()
+	runner, mockLLM := newMCPServeTestRunner(mockMCP)
+	hooks := &webhook.Config{Webhooks: []webhook.Mapping{
+		{Name: "alerts", Token: "secret", Project: "OPS", IssueType: "Bug", Summary: "{{ .alert }} firing", Description: "{{ .details }}"},
+		{Name: "sentry", UseLLM: true, Project: "BE", Input: "Sentry error: {{ .message }}"},
+	}}
+	require.NoError(t, hooks.Validate())
+	cfgs, err := loadAllConfigs(runner.configProvider)
+	require.NoError(t, err)
+	return newServeHandler(&serveState{runner: runner, configs: cfgs, webhooks: hooks}), mockLLM
+}
+
+func postWebhook(h http.Handler, path, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set(webhookTokenHeader, token)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServeWebhook_DirectMapping(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	h, _ := newTestServeHandler(t, mockMCP)
+	mockMCP.On("CreateIssue", mock.Anything, mcpclient.CreateIssueRequest{ProjectKey: "OPS", Summary: "DiskFull firing", Description: "sda1 at 99%", IssueType: "Bug", IdempotencyKey: idempotencyKey("DiskFull firing\nsda1 at 99%", "OPS")}).
+		Return(&mcpclient.CreateIssueResponse{Key: "OPS-9"}, nil)
+
+	rec := postWebhook(h.routes(), "/webhooks/alerts", "secret", `{"alert":"DiskFull","details":"sda1 at 99%"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	var resp mcpclient.CreateIssueResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "OPS-9", resp.Key)
+	mockMCP.AssertExpectations(t)
+}
+
+func TestServeWebhook_LLMMapping(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	h, mockLLM := newTestServeHandler(t, mockMCP)
+	// The mapping pins the project, so the LLM's suggestion is not mapped.
+	mockLLM.On("GenerateTicketDetails", mock.Anything, "Sentry error: nil pointer", "prompt", "").
+		Return(llm.LLMResponse{Summary: "Fix nil pointer", Description: "Trace", ProjectNameSuggestion: "whatever"}, nil)
+	mockMCP.On("CreateIssue", mock.Anything, mcpclient.CreateIssueRequest{ProjectKey: "BE", Summary: "Fix nil pointer", Description: "Trace", IssueType: "Bug", IdempotencyKey: idempotencyKey("Sentry error: nil pointer", "BE")}).
+		Return(&mcpclient.CreateIssueResponse{Key: "BE-3"}, nil)
+
+	rec := postWebhook(h.routes(), "/webhooks/sentry", "", `{"message":"nil pointer"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	mockMCP.AssertExpectations(t)
+}
+
+func TestServeWebhook_Errors(t *testing.T) {
+	Log = zerolog.Nop()
+	h, _ := newTestServeHandler(t, new(MockMCPClient))
+	routes := h.routes()
+
+	assert.Equal(t, http.StatusNotFound, postWebhook(routes, "/webhooks/unknown", "", `{}`).Code)
+	assert.Equal(t, http.StatusUnauthorized, postWebhook(routes, "/webhooks/alerts", "wrong", `{}`).Code)
+	assert.Equal(t, http.StatusBadRequest, postWebhook(routes, "/webhooks/alerts", "secret", `not json`).Code)
+
+	rec := httptest.NewRecorder()
+	routes.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestServeHandler_Reload(t *testing.T) {
+	Log = zerolog.Nop()
+	h, _ := newTestServeHandler(t, new(MockMCPClient))
+	dir := t.TempDir()
+	webhooksPath := filepath.Join(dir, config.DefaultWebhooksFileName)
+
+	newRunner := func() (*createCmdRunner, error) {
+		runner, _ := newMCPServeTestRunner(new(MockMCPClient))
+		runner.configProvider.(*MockConfigProvider).On("EnsureConfigDir").Return(dir, nil)
+		return runner, nil
+	}
+
+	t.Run("Valid edit is swapped in", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(webhooksPath, []byte("webhooks:\n  - name: deploys\n    project: OPS\n    summary: \"{{ .service }}\"\n"), 0600))
+		require.NoError(t, h.reload(newRunner))
+
+		_, ok := h.state.Load().webhooks.Find("deploys")
+		assert.True(t, ok)
+		_, ok = h.state.Load().webhooks.Find("alerts")
+		assert.False(t, ok)
+	})
+
+	t.Run("Invalid edit keeps previous state", func(t *testing.T) {
+		before := h.state.Load()
+		require.NoError(t, os.WriteFile(webhooksPath, []byte("webhooks:\n  - name: broken\n"), 0600))
+		assert.Error(t, h.reload(newRunner))
+		assert.Same(t, before, h.state.Load())
+	})
+}
+
+func TestServeQuickCreate(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	h, _ := newTestServeHandler(t, mockMCP)
+	routes := h.routes()
+
+	rec := postWebhook(routes, "/quick-create", "s3cret", `{"text":"x"}`)
+	assert.Equal(t, http.StatusNotFound, rec.Code, "disabled without quick_create")
+
+	state := *h.state.Load()
+	state.webhooks = &webhook.Config{QuickCreate: &webhook.QuickCreate{Token: "s3cret", AllowedOrigins: []string{"https://wiki.example.com"}, Project: "OPS"}}
+	h.state.Store(&state)
+
+	preflight := httptest.NewRequest(http.MethodOptions, "/quick-create", nil)
+	preflight.Header.Set("Origin", "https://wiki.example.com")
+	preflight.Header.Set("Access-Control-Request-Method", "POST")
+	preflight.Header.Set("Access-Control-Request-Private-Network", "true")
+	rec = httptest.NewRecorder()
+	routes.ServeHTTP(rec, preflight)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "https://wiki.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Contains(t, rec.Header().Get("Access-Control-Allow-Headers"), webhookTokenHeader)
+	assert.Equal(t, "true", rec.Header().Get("Access-Control-Allow-Private-Network"))
+
+	preflight.Header.Set("Origin", "https://evil.example")
+	rec = httptest.NewRecorder()
+	routes.ServeHTTP(rec, preflight)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+
+	rec = postWebhook(routes, "/quick-create", "wrong", `{"text":"x"}`)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	rec = postWebhook(routes, "/quick-create", "s3cret", `{"url":"https://wiki.example.com/page"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code, "nothing to create an issue from")
+
+	mockMCP.On("CreateIssue", mock.Anything, mcpclient.CreateIssueRequest{
+		ProjectKey:  "BE",
+		Summary:     "Checkout button does nothing",
+		Description: "Checkout button does nothing\non Safari 17\n\nSource: Release notes (https://wiki.example.com/page)",
+		IssueType:   "Bug",
+
+		IdempotencyKey: idempotencyKey("Checkout button does nothing\nCheckout button does nothing\non Safari 17\n\nSource: Release notes (https://wiki.example.com/page)", "BE"),
+	}).Return(&mcpclient.CreateIssueResponse{Key: "BE-21", Self: "https://jira.example.com/rest/api/2/issue/10021"}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/quick-create", strings.NewReader(
+		`{"text":"Checkout button does nothing\non Safari 17","url":"https://wiki.example.com/page","title":"Release notes","project":"Backend"}`))
+	req.Header.Set("Origin", "https://wiki.example.com")
+	req.Header.Set("Authorization", "Bearer s3cret")
+	rec = httptest.NewRecorder()
+	routes.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	assert.Equal(t, "https://wiki.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+
+	var resp quickCreateResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, quickCreateResponse{Key: "BE-21", URL: "https://jira.example.com/browse/BE-21"}, resp)
+	mockMCP.AssertExpectations(t)
+}
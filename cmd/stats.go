@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/output"
+	"github.com/karolswdev/ticketron/internal/reldate"
+	"github.com/karolswdev/ticketron/internal/sanitize"
+	"github.com/karolswdev/ticketron/internal/stats"
+)
+
+// statsPageSize is the number of issues requested per MCP search call.
+const statsPageSize = 100
+
+// statsOptions holds the settings of 'tix stats'.
+type statsOptions struct {
+	projectKey   string
+	since        string
+	maxResults   int
+	plain        bool
+	outputFormat string
+}
+
+// statsReport is the output of 'tix stats'.
+type statsReport struct {
+	Project      string `json:"project" yaml:"project"`
+	stats.Report `yaml:",inline"`
+}
+
+// statsRunE fetches the issues of a project that were open at some point since opts.since
+// and prints their statistics.
+func statsRunE(ctx context.Context, mcpClient MCPClient, opts statsOptions, now time.Time, out, errOut io.Writer) error {
+	if mcpClient == nil {
+		return errMCPClientNotInitialized
+	}
+	since, err := reldate.Parse(opts.since, now)
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	sinceDate := jqlQuote(since.In(now.Location()).Format(time.DateOnly))
+	jql := fmt.Sprintf("project = %s AND (created >= %s OR resolutiondate >= %s OR resolution IS EMPTY)", jqlQuote(opts.projectKey), sinceDate, sinceDate)
+	Log.Debug().Str("jql", jql).Msg("Built stats query")
+
+	var issues []mcpclient.Issue
+	total := 0
+	for {
+		pageSize := statsPageSize
+		if opts.maxResults > 0 && opts.maxResults-len(issues) < pageSize {
+			pageSize = opts.maxResults - len(issues)
+		}
+		resp, err := mcpClient.SearchIssues(ctx, mcpclient.SearchIssuesRequest{JQL: jql, MaxResults: pageSize, StartAt: len(issues)})
+		if err != nil {
+			return fmt.Errorf("failed to search issues of %s (startAt %d): %w", opts.projectKey, len(issues), err)
+		}
+		issues = append(issues, resp.Issues...)
+		total = resp.Total
+		if len(resp.Issues) == 0 || len(issues) >= resp.Total || (opts.maxResults > 0 && len(issues) >= opts.maxResults) {
+			break
+		}
+	}
+	if total > len(issues) {
+		fmt.Fprintf(errOut, "Warning: only the first %d of %d issues are included; raise --max-results to include all.\n", len(issues), total)
+	}
+
+	report := statsReport{Project: opts.projectKey, Report: stats.Compute(issues, since, now)}
+	if output.IsStructured(opts.outputFormat) {
+		return output.Structured(out, opts.outputFormat, report)
+	}
+	return writeStatsText(out, report, opts.plain)
+}
+
+// writeStatsText prints the trends as sparklines and the counts as tables. In plain mode
+// the trends are printed as numbers, which screen readers can read.
+func writeStatsText(out io.Writer, report statsReport, plain bool) error {
+	fmt.Fprintf(out, "%s since %s: %d issues\n\n", report.Project, report.Since.Format(time.DateOnly), report.Total)
+
+	t := report.Trend
+	open := 0
+	if len(t.Open) > 0 {
+		open = t.Open[len(t.Open)-1]
+	}
+	lines := []struct {
+		label  string
+		values []int
+		note   string
+	}{
+		{"Created", t.Created, fmt.Sprintf("%d total", stats.Sum(t.Created))},
+		{"Resolved", t.Resolved, fmt.Sprintf("%d total", stats.Sum(t.Resolved))},
+		{"Open", t.Open, fmt.Sprintf("%d now", open)},
+	}
+	if plain {
+		for _, line := range lines {
+			values := make([]string, len(line.values))
+			for i, v := range line.values {
+				values[i] = strconv.Itoa(v)
+			}
+			fmt.Fprintf(out, "%s per %s: %s (%s)\n", line.label, t.Bucket, strings.Join(values, ", "), line.note)
+		}
+	} else {
+		fmt.Fprintf(out, "Per %s:\n", t.Bucket)
+		table := output.NewTable("", "", "")
+		table.Header = false
+		table.Indent = "  "
+		for _, line := range lines {
+			table.Row(line.label, stats.Sparkline(line.values), line.note)
+		}
+		if err := table.Render(out, false); err != nil {
+			return err
+		}
+	}
+
+	for _, dimension := range []struct {
+		label  string
+		counts []stats.Count
+	}{
+		{"Status", report.ByStatus},
+		{"Type", report.ByType},
+		{"Assignee", report.ByAssignee},
+	} {
+		if len(dimension.counts) == 0 {
+			continue
+		}
+		fmt.Fprintln(out)
+		table := output.NewTable(dimension.label, "Issues", "Share")
+		for _, c := range dimension.counts {
+			table.Row(sanitize.Line(c.Name), strconv.Itoa(c.Count), fmt.Sprintf("%.0f%%", 100*float64(c.Count)/float64(report.Total)))
+		}
+		if err := table.Render(out, plain); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// statsCmd represents the stats command
+var statsCmd = &cobra.Command{
+	Use:   "stats --project PROJECT-KEY",
+	Short: "Show quick statistics of a project",
+	Long: `Shows how many issues of a project were created and resolved over a period and how
+many were open, as sparklines per day (per week for periods over a month), and the
+issues by status, type and assignee.
+
+The statistics cover the issues that were open at some point since --since: those
+created or resolved in the period and those still unresolved. They are computed from
+search results on your machine, so they work with any MCP server.`,
+	Example: `  tix stats --project PROJ --since 14d
+  tix stats --project PROJ --since 2025-01-01 -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := statsOptions{plain: plainOutput(cmd)}
+		opts.projectKey, _ = cmd.Flags().GetString("project")
+		opts.projectKey = strings.ToUpper(strings.TrimSpace(opts.projectKey))
+		opts.since, _ = cmd.Flags().GetString("since")
+		opts.maxResults, _ = cmd.Flags().GetInt("max-results")
+		opts.outputFormat, _ = cmd.Flags().GetString("output")
+
+		provider, err := GetProvider()
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+		now, err := configuredNow(provider.Config)
+		if err != nil {
+			return err
+		}
+		mcpClient, err := newCommandMCPClient()
+		if err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return statsRunE(ctx, mcpClient, opts, now, cmd.OutOrStdout(), cmd.ErrOrStderr())
+	},
+}
+
+func init() {
+	statsCmd.Flags().StringP("project", "p", "", "Project key (required)")
+	statsCmd.Flags().String("since", "14d", "Start of the period, e.g. 14d, 2w, \"last month\" or 2025-04-01")
+	statsCmd.Flags().Int("max-results", 1000, "Maximum number of issues to include (0 for all)")
+	_ = statsCmd.MarkFlagRequired("project")
+
+	rootCmd.AddCommand(statsCmd)
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+func statsTestResponse() *mcpclient.SearchIssuesResponse {
+	issue := func(key, status, issueType, created, resolved string) mcpclient.Issue {
+		return mcpclient.Issue{Key: key, Fields: mcpclient.IssueFields{
+			Status: mcpclient.Status{Name: status}, IssueType: mcpclient.IssueType{Name: issueType},
+			Created: created, ResolutionDate: resolved,
+		}}
+	}
+	return &mcpclient.SearchIssuesResponse{Total: 3, Issues: []mcpclient.Issue{
+		issue("BE-1", "Done", "Bug", "2025-04-14T10:00:00.000+0000", "2025-04-15T10:00:00.000+0000"),
+		issue("BE-2", "To Do", "Task", "2025-04-15T10:00:00.000+0000", ""),
+		issue("BE-3", "To Do", "Bug", "2025-04-16T10:00:00.000+0000", ""),
+	}}
+}
+
+func TestStatsRunE(t *testing.T) {
+	now := time.Date(2025, time.April, 16, 14, 30, 0, 0, time.UTC)
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("SearchIssues", mock.Anything, mcpclient.SearchIssuesRequest{
+		JQL:        `project = "BE" AND (created >= "2025-04-14" OR resolutiondate >= "2025-04-14" OR resolution IS EMPTY)`,
+		MaxResults: statsPageSize,
+	}).Return(statsTestResponse(), nil)
+
+	var out, errOut bytes.Buffer
+	opts := statsOptions{projectKey: "BE", since: "2d", outputFormat: "text"}
+	require.NoError(t, statsRunE(context.Background(), mockMCP, opts, now, &out, &errOut))
+	assert.Equal(t, `BE since 2025-04-14: 3 issues
+
+Per day:
+  Created   ███  3 total
+  Resolved  ▁█▁  1 total
+  Open      ▅▅█  2 now
+
+STATUS  ISSUES  SHARE
+To Do   2       67%
+Done    1       33%
+
+TYPE  ISSUES  SHARE
+Bug   2       67%
+Task  1       33%
+
+ASSIGNEE    ISSUES  SHARE
+Unassigned  3       100%
+`, out.String())
+	assert.Empty(t, errOut.String())
+
+	out.Reset()
+	opts.plain = true
+	require.NoError(t, statsRunE(context.Background(), mockMCP, opts, now, &out, &errOut))
+	assert.Contains(t, out.String(), "Created per day: 1, 1, 1 (3 total)\nResolved per day: 0, 1, 0 (1 total)\nOpen per day: 1, 1, 2 (2 now)\n")
+
+	out.Reset()
+	opts.outputFormat = "json"
+	require.NoError(t, statsRunE(context.Background(), mockMCP, opts, now, &out, &errOut))
+	var report map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.Equal(t, "BE", report["project"])
+	assert.EqualValues(t, 3, report["total"])
+	assert.Contains(t, report, "trend")
+}
+
+func TestStatsRunE_Truncated(t *testing.T) {
+	now := time.Date(2025, time.April, 16, 14, 30, 0, 0, time.UTC)
+	mockMCP := new(MockMCPClient)
+	resp := statsTestResponse()
+	resp.Total = 10
+	mockMCP.On("SearchIssues", mock.Anything, mock.Anything).Return(resp, nil).Once()
+
+	var out, errOut bytes.Buffer
+	opts := statsOptions{projectKey: "BE", since: "2d", maxResults: 3}
+	require.NoError(t, statsRunE(context.Background(), mockMCP, opts, now, &out, &errOut))
+	assert.Contains(t, errOut.String(), "only the first 3 of 10 issues")
+
+	assert.ErrorContains(t, statsRunE(context.Background(), mockMCP, statsOptions{projectKey: "BE", since: "whenever"}, now, &out, &errOut), "invalid --since")
+}
@@ -0,0 +1,17 @@
+//go:build !unix
+
+package cmd
+
+import "io"
+
+// terminalWidth returns 0 on platforms where the terminal size is not queried; callers
+// fall back to $COLUMNS or a default width.
+func terminalWidth(io.Writer) int {
+	return 0
+}
+
+// hasControllingTerminal reports false on platforms without a controlling terminal device;
+// only a terminal on stdin counts as interactive there.
+func hasControllingTerminal() bool {
+	return false
+}
@@ -0,0 +1,39 @@
+//go:build unix
+
+package cmd
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// controllingTerminal is the device of the process's controlling terminal.
+const controllingTerminal = "/dev/tty"
+
+// terminalWidth returns the number of columns of the terminal w writes to, or 0 if w is
+// not a terminal.
+func terminalWidth(w io.Writer) int {
+	f, ok := w.(*os.File)
+	if !ok || !isCharDevice(w) {
+		return 0
+	}
+	size, err := unix.IoctlGetWinsize(int(f.Fd()), unix.TIOCGWINSZ)
+	if err != nil {
+		return 0
+	}
+	return int(size.Col)
+}
+
+// hasControllingTerminal reports whether the process has a controlling terminal, as it does
+// when started from an interactive shell even with stdin redirected, and does not when run
+// by cron, systemd or another daemon.
+func hasControllingTerminal() bool {
+	f, err := os.OpenFile(controllingTerminal, os.O_RDWR, 0)
+	if err != nil {
+		return false
+	}
+	f.Close()
+	return true
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// issueTypeInfo is the JSON representation of an issue type listed by 'tix types'.
+type issueTypeInfo struct {
+	ID      string `json:"id,omitempty"`
+	Name    string `json:"name"`
+	Subtask bool   `json:"subtask,omitempty"`
+	Default bool   `json:"default,omitempty"`
+}
+
+// typesRunE lists the issue types that can be created in a project. The create metadata is
+// taken from the cache unless refresh is set. The project's default_issue_type from
+// links.yaml, if any, is marked.
+func typesRunE(ctx context.Context, runner *createCmdRunner, projectKey string, refresh bool, outputFormat string, out io.Writer) error {
+	if runner.mcpClient == nil {
+		return errMCPClientNotInitialized
+	}
+	meta, _, err := runner.createMeta(ctx, projectKey, refresh)
+	if err != nil {
+		return fmt.Errorf("failed to get issue types for project %s: %w", projectKey, err)
+	}
+
+	var defaultType string
+	if links, err := runner.configProvider.LoadLinks(); err != nil {
+		Log.Debug().Err(err).Msg("Links unavailable, not marking the default issue type")
+	} else if link := findLinkByKey(links, projectKey); link != nil {
+		defaultType = link.DefaultIssueType
+	}
+
+	types := make([]issueTypeInfo, 0, len(meta.IssueTypes))
+	for _, typeMeta := range meta.IssueTypes {
+		types = append(types, issueTypeInfo{
+			ID:      typeMeta.ID,
+			Name:    typeMeta.Name,
+			Subtask: typeMeta.Subtask,
+			Default: defaultType != "" && strings.EqualFold(typeMeta.Name, defaultType),
+		})
+	}
+
+	if strings.ToLower(outputFormat) == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(types)
+	}
+
+	fmt.Fprintf(out, "Issue types in %s:\n", projectKey)
+	for _, t := range types {
+		var notes []string
+		if t.Subtask {
+			notes = append(notes, "sub-task")
+		}
+		if t.Default {
+			notes = append(notes, "default")
+		}
+		if len(notes) > 0 {
+			fmt.Fprintf(out, "  %s (%s)\n", t.Name, strings.Join(notes, ", "))
+		} else {
+			fmt.Fprintf(out, "  %s\n", t.Name)
+		}
+	}
+	return nil
+}
+
+// typesCmd represents the types command
+var typesCmd = &cobra.Command{
+	Use:   "types PROJECT-KEY",
+	Short: "List the issue types of a project",
+	Long: `Lists the issue types that can be created in a JIRA project, marking sub-task types
+and the project's default_issue_type from links.yaml.
+
+The list comes from the project's create metadata, which is cached for 24 hours;
+use --refresh to fetch it again. 'tix create --type' and the other create commands
+use the same list to reject unknown issue types before calling the LLM.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		refresh, _ := cmd.Flags().GetBool("refresh")
+		outputFormat, _ := cmd.Flags().GetString("output")
+
+		runner, err := newCreateCmdRunner()
+		if err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return typesRunE(ctx, runner, args[0], refresh, outputFormat, cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	typesCmd.Flags().Bool("refresh", false, "Fetch the issue types from the server instead of the cache")
+
+	rootCmd.AddCommand(typesCmd)
+}
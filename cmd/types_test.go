@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/cache"
+	"github.com/karolswdev/ticketron/internal/clierr"
+	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/llm"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+func TestTypesRunE(t *testing.T) {
+	Log = zerolog.Nop()
+	mockProvider := new(MockConfigProvider)
+	mockMCP := new(MockMCPClient)
+	mockProvider.On("LoadLinks").Return(&config.LinksConfig{Projects: []config.ProjectLink{{Name: "Backend", Key: "BE", DefaultIssueType: "bug"}}}, nil)
+	meta := testCreateMeta()
+	meta.IssueTypes = append(meta.IssueTypes, mcpclient.IssueTypeMeta{ID: "10003", Name: "Sub-task", Subtask: true})
+	mockMCP.On("GetCreateMeta", mock.Anything, "BE").Return(meta, nil).Once()
+
+	runner := NewCreateCmdRunnerForTest(mockProvider, nil, mockMCP, nil, nil)
+	runner.metaCache = cache.New(t.TempDir(), time.Hour)
+
+	var out bytes.Buffer
+	require.NoError(t, typesRunE(context.Background(), runner, "BE", false, "text", &out))
+	assert.Equal(t, "Issue types in BE:\n  Task\n  Bug (default)\n  Sub-task (sub-task)\n", out.String())
+
+	// The second run is served from the cache
+	out.Reset()
+	require.NoError(t, typesRunE(context.Background(), runner, "BE", false, "json", &out))
+	var types []issueTypeInfo
+	require.NoError(t, json.Unmarshal(out.Bytes(), &types))
+	assert.Equal(t, []issueTypeInfo{{Name: "Task"}, {Name: "Bug", Default: true}, {ID: "10003", Name: "Sub-task", Subtask: true}}, types)
+	mockMCP.AssertNumberOfCalls(t, "GetCreateMeta", 1)
+
+	// --refresh bypasses the cache
+	mockMCP.On("GetCreateMeta", mock.Anything, "BE").Return(nil, mcpclient.ErrMCPServerError).Once()
+	err := typesRunE(context.Background(), runner, "BE", true, "text", &out)
+	assert.ErrorIs(t, err, mcpclient.ErrMCPServerError)
+}
+
+func TestDefaultIssueTypeResolver_CheckType(t *testing.T) {
+	Log = zerolog.Nop()
+	ctx := context.Background()
+	resolver := &DefaultIssueTypeResolver{}
+	assert.NoError(t, resolver.CheckType(ctx, "Anything", "BE"), "types are not checked without a source")
+
+	var refreshed int
+	resolver.AllowedTypes = func(_ context.Context, _ string, refresh bool) ([]string, error) {
+		if refresh {
+			refreshed++
+		}
+		return []string{"Task", "Bug"}, nil
+	}
+	assert.NoError(t, resolver.CheckType(ctx, "bug", "BE"))
+	assert.Zero(t, refreshed, "a cached type needs no refresh")
+	err := resolver.CheckType(ctx, "Story", "BE")
+	assert.ErrorIs(t, err, errInvalidIssueRequest)
+	assert.EqualError(t, err, "invalid issue request: project BE has no type 'Story'; available: Task, Bug")
+	assert.Equal(t, 1, refreshed)
+
+	// A type added since the list was cached is accepted
+	resolver.AllowedTypes = func(_ context.Context, _ string, refresh bool) ([]string, error) {
+		if refresh {
+			return []string{"Task", "Bug", "Story"}, nil
+		}
+		return []string{"Task", "Bug"}, nil
+	}
+	assert.NoError(t, resolver.CheckType(ctx, "Story", "BE"))
+
+	resolver.AllowedTypes = func(context.Context, string, bool) ([]string, error) { return nil, errors.New("unavailable") }
+	assert.NoError(t, resolver.CheckType(ctx, "Story", "BE"), "types are accepted when the list is unavailable")
+}
+
+func TestBuildIssueRequest_RejectsTypeBeforeLLM(t *testing.T) {
+	Log = zerolog.Nop()
+	runner, mockLLM := newMCPServeTestRunner(nil)
+	runner.issueTypeResolver = &DefaultIssueTypeResolver{
+		AllowedTypes: func(context.Context, string, bool) ([]string, error) { return []string{"Task", "Bug"}, nil },
+	}
+	cfgs, err := loadAllConfigs(runner.configProvider)
+	require.NoError(t, err)
+
+	_, err = runner.buildIssueRequest(context.Background(), &bytes.Buffer{}, cfgs, "input", issueRequestOptions{issueType: "Story", projectKey: "BE"})
+	assert.ErrorIs(t, err, errInvalidIssueRequest)
+	assert.Contains(t, clierr.HintOf(err), "tix types BE")
+	mockLLM.AssertNotCalled(t, "GenerateTicketDetails", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBuildIssueRequest_LLMIssueType(t *testing.T) {
+	Log = zerolog.Nop()
+	tests := []struct {
+		name      string
+		suggested string
+		flagType  string
+		want      string
+	}{
+		{name: "suggestion of a project type", suggested: "Task", want: "Task"},
+		{name: "suggestion the project lacks", suggested: "Story", want: "Bug"},
+		{name: "no suggestion", want: "Bug"},
+		{name: "flag wins", suggested: "Task", flagType: "bug", want: "bug"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner, mockLLM := newMCPServeTestRunner(nil)
+			runner.issueTypeResolver = &DefaultIssueTypeResolver{
+				AllowedTypes: func(context.Context, string, bool) ([]string, error) { return []string{"Task", "Bug"}, nil },
+			}
+			mockLLM.On("GenerateTicketDetails", mock.Anything, "input", "prompt", mock.Anything).
+				Return(llm.LLMResponse{Summary: "S", ProjectNameSuggestion: "Backend", IssueType: tt.suggested}, nil)
+			cfgs, err := loadAllConfigs(runner.configProvider)
+			require.NoError(t, err)
+
+			request, err := runner.buildIssueRequest(context.Background(), io.Discard, cfgs, "input", issueRequestOptions{issueType: tt.flagType})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, request.IssueType, "the links.yaml default of BE is Bug")
+		})
+	}
+}
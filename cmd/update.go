@@ -0,0 +1,271 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/i18n"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/prompt"
+	"github.com/karolswdev/ticketron/internal/sanitize"
+	"github.com/karolswdev/ticketron/internal/textdiff"
+)
+
+// updateDiffContext is the number of unchanged lines shown around changes of a field.
+const updateDiffContext = 3
+
+// updateOptions holds the flags of the update command. Fields left nil are not changed.
+type updateOptions struct {
+	summary           *string
+	description       *string
+	descriptionFormat mcpclient.DescriptionFormat // Empty sends the description unchanged
+	color             bool                        // Color the diff
+}
+
+// fieldChange is a proposed new value of an issue field.
+type fieldChange struct {
+	field    string // Jira field ID, e.g. "summary"
+	current  string
+	proposed string
+}
+
+// maxUpdateAttempts bounds how often updateRunE re-fetches an issue that keeps changing
+// while it waits for confirmation.
+const maxUpdateAttempts = 3
+
+// updateRunE shows a unified diff of the current and proposed values of the fields in
+// opts, fetched with GetIssue, and updates the fields that change after confirmation, so a
+// field edited in the meantime is not overwritten unnoticed. The update is conditional on
+// the issue's updated timestamp: if someone else changed the issue after it was fetched,
+// the user is asked to review the diff against the new values instead. The timestamp is
+// checked twice, by fetching the issue again right before the update and by the MCP
+// server if it supports expectedUpdated, so servers without that support still catch
+// most concurrent edits.
+func updateRunE(ctx context.Context, mcpClient MCPClient, confirmer *prompt.Confirmer, out io.Writer, key string, opts updateOptions) error {
+	if mcpClient == nil {
+		return errMCPClientNotInitialized
+	}
+	if opts.summary == nil && opts.description == nil {
+		return errors.New("nothing to update: use --summary, --description or --description-file")
+	}
+	if opts.summary != nil && strings.TrimSpace(*opts.summary) == "" {
+		return errors.New("--summary cannot be empty")
+	}
+	for attempt := 1; ; attempt++ {
+		issue, err := mcpClient.GetIssue(ctx, key)
+		if err != nil {
+			return fmt.Errorf("failed to get issue %s: %w", key, err)
+		}
+		if issue.Fields.Updated == "" {
+			Log.Warn().Str("issue_key", key).Msg("The MCP server did not report when the issue was last updated; changes made by someone else in the meantime cannot be detected")
+		}
+		changes := proposedChanges(issue, opts)
+		if len(changes) == 0 {
+			fmt.Fprintf(out, "No changes: %s already has these values.\n", key)
+			return nil
+		}
+
+		for _, change := range changes {
+			// The diff is sanitized before coloring, so text from Jira cannot alter the terminal
+			diff := sanitize.Text(textdiff.Unified(change.current, change.proposed,
+				key+" "+change.field+" (current)", key+" "+change.field+" (proposed)", updateDiffContext))
+			if opts.color {
+				diff = textdiff.Colorize(diff)
+			}
+			fmt.Fprint(out, diff)
+		}
+		ok, err := confirmer.Confirm(out, i18n.T(i18n.MsgConfirmUpdate, key), false)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Fprintln(out, i18n.T(i18n.MsgAborted))
+			return nil
+		}
+
+		fields, err := updateFields(changes, opts.descriptionFormat)
+		if err != nil {
+			return err
+		}
+		err = checkUnchanged(ctx, mcpClient, key, issue)
+		if err == nil {
+			err = mcpClient.UpdateIssue(ctx, key, mcpclient.UpdateIssueRequest{Fields: fields, ExpectedUpdated: issue.Fields.Updated})
+		}
+		if errors.Is(err, mcpclient.ErrConflict) && attempt < maxUpdateAttempts && !confirmer.AssumeYes && !confirmer.NoInput {
+			Log.Debug().Err(err).Str("issue_key", key).Int("attempt", attempt).Msg("Issue changed since it was fetched")
+			refetch, err := confirmer.Confirm(out, i18n.T(i18n.MsgConfirmRefetch, key), true)
+			if err != nil {
+				return err
+			}
+			if !refetch {
+				fmt.Fprintln(out, i18n.T(i18n.MsgAborted))
+				return nil
+			}
+			continue
+		}
+		if errors.Is(err, mcpclient.ErrConflict) {
+			return fmt.Errorf("failed to update %s: %w; run the command again to review the changes", key, err)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update %s: %w", key, err)
+		}
+		if url := browseURL(issue.Self, key); url != "" {
+			fmt.Fprintf(out, "Updated %s: %s\n", key, url)
+		} else {
+			fmt.Fprintf(out, "Updated %s\n", key)
+		}
+		return nil
+	}
+}
+
+// checkUnchanged fetches issue key again and returns an error wrapping mcpclient.ErrConflict if
+// its updated timestamp changed since issue was fetched. It does nothing if the MCP server
+// does not report the timestamp.
+func checkUnchanged(ctx context.Context, mcpClient MCPClient, key string, issue *mcpclient.Issue) error {
+	if issue.Fields.Updated == "" {
+		return nil
+	}
+	latest, err := mcpClient.GetIssue(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to get issue %s: %w", key, err)
+	}
+	if latest.Fields.Updated != issue.Fields.Updated {
+		return fmt.Errorf("%w: updated at %s, fetched at %s", mcpclient.ErrConflict, latest.Fields.Updated, issue.Fields.Updated)
+	}
+	return nil
+}
+
+// proposedChanges returns the fields of issue whose values opts changes.
+func proposedChanges(issue *mcpclient.Issue, opts updateOptions) []fieldChange {
+	var changes []fieldChange
+	if opts.summary != nil && *opts.summary != issue.Fields.Summary {
+		changes = append(changes, fieldChange{field: "summary", current: issue.Fields.Summary, proposed: *opts.summary})
+	}
+	if opts.description != nil && strings.TrimSpace(*opts.description) != strings.TrimSpace(issue.Fields.Description) {
+		changes = append(changes, fieldChange{field: "description", current: issue.Fields.Description, proposed: *opts.description})
+	}
+	return changes
+}
+
+// updateFields returns the fields of an UpdateIssueRequest applying changes, with the
+// description converted to format.
+func updateFields(changes []fieldChange, format mcpclient.DescriptionFormat) (map[string]interface{}, error) {
+	fields := make(map[string]interface{}, len(changes))
+	for _, change := range changes {
+		if change.field != "description" {
+			fields[change.field] = change.proposed
+			continue
+		}
+		description, err := mcpclient.ConvertDescription(change.proposed, format)
+		if err != nil {
+			return nil, err
+		}
+		if format == mcpclient.DescriptionFormatADF && strings.TrimSpace(description) != "" {
+			fields["description"] = json.RawMessage(description) // A document, not a string
+		} else {
+			fields["description"] = description
+		}
+	}
+	return fields, nil
+}
+
+// readUpdateDescription returns the description given by --description or
+// --description-file, which is read from in if it is "-", or nil if neither is set.
+func readUpdateDescription(cmd *cobra.Command, in io.Reader) (*string, error) {
+	if cmd.Flags().Changed("description") {
+		description, _ := cmd.Flags().GetString("description")
+		return &description, nil
+	}
+	path, _ := cmd.Flags().GetString("description-file")
+	if path == "" {
+		return nil, nil
+	}
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(in)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the description: %w", err)
+	}
+	description := strings.TrimRight(string(data), "\n")
+	return &description, nil
+}
+
+// updateCmd represents the update command
+var updateCmd = &cobra.Command{
+	Use:   "update ISSUE-KEY",
+	Short: "Change the summary or description of a JIRA issue",
+	Long: `Changes the summary or description of a JIRA issue. The current values are fetched
+first and a unified diff of the current and proposed values is shown, so you can check
+you are not overwriting changes made by someone else; the issue is only updated after
+confirmation (skip it with --yes). Fields whose value does not change are left alone.
+
+If someone else changes the issue between fetching and updating it, the update is not
+sent: the issue is fetched again right before updating it to compare its updated time, and
+MCP servers that support expectedUpdated also reject a conditional update. You are asked to
+fetch the issue again and review the diff against the new values. With --yes or --no-input
+the command fails instead.
+
+The description is Markdown and is converted like that of new issues, according to
+description_format in config.yaml or --description-format.
+
+Examples:
+  tix update PROJ-123 --summary "Retry API requests rate limited with 429"
+  tix update PROJ-123 --description-file notes.md
+  git log -1 --format=%b | tix update PROJ-123 --description-file - --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if cmd.Flags().Changed("description") && cmd.Flags().Changed("description-file") {
+			return errors.New("use either --description or --description-file")
+		}
+		var opts updateOptions
+		if cmd.Flags().Changed("summary") {
+			summary, _ := cmd.Flags().GetString("summary")
+			opts.summary = &summary
+		}
+		var err error
+		if opts.description, err = readUpdateDescription(cmd, cmd.InOrStdin()); err != nil {
+			return err
+		}
+		opts.color = !plainOutput(cmd) && !ciEnabled && isTerminal(cmd.OutOrStdout())
+
+		cfgProvider := &DefaultConfigProvider{}
+		cfg, err := cfgProvider.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrProviderConfig, err)
+		}
+		override, _ := cmd.Flags().GetString("description-format")
+		if opts.descriptionFormat, err = descriptionFormatFor(&loadedConfigs{appConfig: cfg}, override); err != nil {
+			return err
+		}
+
+		mcpClient, err := newCommandMCPClient()
+		if err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return updateRunE(ctx, mcpClient, newConfirmer(cmd), cmd.OutOrStdout(), args[0], opts)
+	},
+}
+
+func init() {
+	updateCmd.Flags().String("summary", "", "New summary")
+	updateCmd.Flags().String("description", "", "New description (Markdown)")
+	updateCmd.Flags().String("description-file", "", "Read the new description from a file, or '-' for stdin (then pass --yes)")
+	updateCmd.Flags().String("description-format", "", "Override description_format from config.yaml: text, wiki or adf")
+
+	rootCmd.AddCommand(updateCmd)
+}
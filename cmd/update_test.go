@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/prompt"
+)
+
+func testUpdateIssue() *mcpclient.Issue {
+	return &mcpclient.Issue{Key: "BE-1", Self: "https://jira.example/rest/api/2/issue/10001", Fields: mcpclient.IssueFields{
+		Summary:     "Fix login",
+		Description: "Users see 500\nSteps:\n1. Log in",
+		Updated:     "2025-04-01T10:00:00.000+0000",
+	}}
+}
+
+func ptr(s string) *string {
+	return &s
+}
+
+func TestUpdateRunE(t *testing.T) {
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("GetIssue", mock.Anything, "BE-1").Return(testUpdateIssue(), nil)
+	mockMCP.On("UpdateIssue", mock.Anything, "BE-1", mcpclient.UpdateIssueRequest{
+		Fields:          map[string]interface{}{"description": "Users see 502\nSteps:\n1. Log in"},
+		ExpectedUpdated: "2025-04-01T10:00:00.000+0000",
+	}).Return(nil).Once()
+
+	var out bytes.Buffer
+	opts := updateOptions{summary: ptr("Fix login"), description: ptr("Users see 502\nSteps:\n1. Log in")}
+	require.NoError(t, updateRunE(context.Background(), mockMCP, &prompt.Confirmer{In: strings.NewReader("y\n")}, &out, "BE-1", opts))
+	assert.Contains(t, out.String(), "--- BE-1 description (current)\n+++ BE-1 description (proposed)\n@@ -1,3 +1,3 @@\n-Users see 500\n+Users see 502\n Steps:\n 1. Log in\n")
+	assert.NotContains(t, out.String(), "summary (current)", "unchanged fields are not shown")
+	assert.NotContains(t, out.String(), "\x1b[")
+	assert.Contains(t, out.String(), "Updated BE-1: https://jira.example/browse/BE-1")
+	mockMCP.AssertExpectations(t)
+}
+
+func TestUpdateRunE_Declined(t *testing.T) {
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("GetIssue", mock.Anything, "BE-1").Return(testUpdateIssue(), nil)
+
+	var out bytes.Buffer
+	opts := updateOptions{summary: ptr("Fix SSO login"), color: true}
+	require.NoError(t, updateRunE(context.Background(), mockMCP, &prompt.Confirmer{In: strings.NewReader("n\n")}, &out, "BE-1", opts))
+	assert.Contains(t, out.String(), "\x1b[31m-Fix login\x1b[0m\n\x1b[32m+Fix SSO login\x1b[0m\n")
+	assert.Contains(t, out.String(), "Aborted.")
+	mockMCP.AssertNotCalled(t, "UpdateIssue", mock.Anything, mock.Anything, mock.Anything)
+
+	// Without a terminal the update needs --yes
+	out.Reset()
+	err := updateRunE(context.Background(), mockMCP, &prompt.Confirmer{In: strings.NewReader(""), NoInput: true}, &out, "BE-1", opts)
+	assert.ErrorIs(t, err, prompt.ErrInputRequired)
+	mockMCP.AssertNotCalled(t, "UpdateIssue", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateRunE_NoChanges(t *testing.T) {
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("GetIssue", mock.Anything, "BE-1").Return(testUpdateIssue(), nil)
+
+	var out bytes.Buffer
+	opts := updateOptions{summary: ptr("Fix login"), description: ptr("Users see 500\nSteps:\n1. Log in\n")}
+	require.NoError(t, updateRunE(context.Background(), mockMCP, &prompt.Confirmer{AssumeYes: true}, &out, "BE-1", opts))
+	assert.Equal(t, "No changes: BE-1 already has these values.\n", out.String())
+	mockMCP.AssertNotCalled(t, "UpdateIssue", mock.Anything, mock.Anything, mock.Anything)
+
+	err := updateRunE(context.Background(), mockMCP, &prompt.Confirmer{AssumeYes: true}, &out, "BE-1", updateOptions{})
+	assert.ErrorContains(t, err, "nothing to update")
+	err = updateRunE(context.Background(), mockMCP, &prompt.Confirmer{AssumeYes: true}, &out, "BE-1", updateOptions{summary: ptr(" ")})
+	assert.ErrorContains(t, err, "--summary cannot be empty")
+}
+
+func TestUpdateRunE_ADF(t *testing.T) {
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("GetIssue", mock.Anything, "BE-1").Return(testUpdateIssue(), nil)
+	mockMCP.On("UpdateIssue", mock.Anything, "BE-1", mock.MatchedBy(func(req mcpclient.UpdateIssueRequest) bool {
+		data, err := json.Marshal(req)
+		return err == nil && assert.Contains(t, string(data), `"description":{"type":"doc"`)
+	})).Return(nil).Once()
+
+	var out bytes.Buffer
+	opts := updateOptions{description: ptr("**New** text"), descriptionFormat: mcpclient.DescriptionFormatADF}
+	require.NoError(t, updateRunE(context.Background(), mockMCP, &prompt.Confirmer{AssumeYes: true}, &out, "BE-1", opts))
+	mockMCP.AssertExpectations(t)
+}
+
+func TestUpdateRunE_Conflict(t *testing.T) {
+	changed := testUpdateIssue()
+	changed.Fields.Summary = "Fix login on mobile"
+	changed.Fields.Updated = "2025-04-01T10:05:00.000+0000"
+	conflict := fmt.Errorf("%w: %w", mcpclient.ErrConflict, mcpclient.ErrMCPServerError)
+
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("GetIssue", mock.Anything, "BE-1").Return(testUpdateIssue(), nil).Twice()
+	mockMCP.On("GetIssue", mock.Anything, "BE-1").Return(changed, nil).Twice()
+	mockMCP.On("UpdateIssue", mock.Anything, "BE-1", mcpclient.UpdateIssueRequest{
+		Fields: map[string]interface{}{"summary": "Fix SSO login"}, ExpectedUpdated: "2025-04-01T10:00:00.000+0000",
+	}).Return(conflict).Once()
+	mockMCP.On("UpdateIssue", mock.Anything, "BE-1", mcpclient.UpdateIssueRequest{
+		Fields: map[string]interface{}{"summary": "Fix SSO login"}, ExpectedUpdated: "2025-04-01T10:05:00.000+0000",
+	}).Return(nil).Once()
+
+	var out bytes.Buffer
+	opts := updateOptions{summary: ptr("Fix SSO login")}
+	require.NoError(t, updateRunE(context.Background(), mockMCP, &prompt.Confirmer{In: strings.NewReader("y\n\ny\n")}, &out, "BE-1", opts))
+	assert.Contains(t, out.String(), "BE-1 was changed by someone else since it was fetched.")
+	assert.Contains(t, out.String(), "-Fix login on mobile\n+Fix SSO login\n", "the diff is shown again against the new values")
+	assert.Contains(t, out.String(), "Updated BE-1")
+	mockMCP.AssertExpectations(t)
+
+	// With --yes nobody reviews the new values, so the update fails
+	mockMCP = new(MockMCPClient)
+	mockMCP.On("GetIssue", mock.Anything, "BE-1").Return(testUpdateIssue(), nil).Twice()
+	mockMCP.On("UpdateIssue", mock.Anything, "BE-1", mock.Anything).Return(conflict).Once()
+	err := updateRunE(context.Background(), mockMCP, &prompt.Confirmer{AssumeYes: true}, &out, "BE-1", opts)
+	assert.ErrorIs(t, err, mcpclient.ErrConflict)
+	assert.ErrorContains(t, err, "run the command again")
+	mockMCP.AssertExpectations(t)
+}
+
+func TestUpdateRunE_ChangedBeforeUpdate(t *testing.T) {
+	changed := testUpdateIssue()
+	changed.Fields.Updated = "2025-04-01T10:05:00.000+0000"
+
+	// The MCP server ignores expectedUpdated, so the change is caught by fetching the issue again
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("GetIssue", mock.Anything, "BE-1").Return(testUpdateIssue(), nil).Once()
+	mockMCP.On("GetIssue", mock.Anything, "BE-1").Return(changed, nil).Once()
+
+	var out bytes.Buffer
+	opts := updateOptions{summary: ptr("Fix SSO login")}
+	err := updateRunE(context.Background(), mockMCP, &prompt.Confirmer{AssumeYes: true}, &out, "BE-1", opts)
+	assert.ErrorIs(t, err, mcpclient.ErrConflict)
+	mockMCP.AssertNotCalled(t, "UpdateIssue", mock.Anything, mock.Anything, mock.Anything)
+	mockMCP.AssertExpectations(t)
+}
+
+func TestUpdateRunE_NoUpdatedTimestamp(t *testing.T) {
+	var logs bytes.Buffer
+	Log = zerolog.New(&logs)
+	defer func() { Log = zerolog.Nop() }()
+
+	issue := testUpdateIssue()
+	issue.Fields.Updated = ""
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("GetIssue", mock.Anything, "BE-1").Return(issue, nil).Once()
+	mockMCP.On("UpdateIssue", mock.Anything, "BE-1", mock.Anything).Return(nil).Once()
+
+	var out bytes.Buffer
+	opts := updateOptions{summary: ptr("Fix SSO login")}
+	require.NoError(t, updateRunE(context.Background(), mockMCP, &prompt.Confirmer{AssumeYes: true}, &out, "BE-1", opts))
+	assert.Contains(t, logs.String(), "cannot be detected")
+	mockMCP.AssertExpectations(t)
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/output"
+	"github.com/karolswdev/ticketron/internal/update"
+)
+
+// Build metadata set via ldflags (see .goreleaser.yml and the Makefile); version is in root.go.
+var (
+	commit  = ""
+	date    = ""
+	builtBy = ""
+)
+
+// updateCheckTimeout bounds the release lookup of 'tix version --check'.
+const updateCheckTimeout = 5 * time.Second
+
+// versionInfo is the output of 'tix version'.
+type versionInfo struct {
+	Version   string         `json:"version" yaml:"version"`
+	Commit    string         `json:"commit,omitempty" yaml:"commit,omitempty"`
+	Date      string         `json:"date,omitempty" yaml:"date,omitempty"`
+	BuiltBy   string         `json:"built_by,omitempty" yaml:"built_by,omitempty"`
+	GoVersion string         `json:"go_version" yaml:"go_version"`
+	Platform  string         `json:"platform" yaml:"platform"`
+	Update    *update.Result `json:"update,omitempty" yaml:"update,omitempty"`
+	// UpdateError explains why the update check failed; the check never fails the command.
+	UpdateError string `json:"update_error,omitempty" yaml:"update_error,omitempty"`
+}
+
+// currentVersionInfo returns the build metadata of the running binary. Builds without
+// ldflags, such as 'go install', fall back to the VCS information recorded by the Go toolchain.
+func currentVersionInfo() versionInfo {
+	info := versionInfo{
+		Version:   version,
+		Commit:    commit,
+		Date:      date,
+		BuiltBy:   builtBy,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	if info.Version == "dev" && buildInfo.Main.Version != "" && buildInfo.Main.Version != "(devel)" {
+		info.Version = buildInfo.Main.Version
+	}
+	for _, setting := range buildInfo.Settings {
+		switch {
+		case setting.Key == "vcs.revision" && info.Commit == "":
+			info.Commit = setting.Value
+		case setting.Key == "vcs.time" && info.Date == "":
+			info.Date = setting.Value
+		}
+	}
+	return info
+}
+
+// versionRunE prints the build metadata and, with check set, whether releaseURL
+// describes a newer release.
+func versionRunE(ctx context.Context, client *http.Client, releaseURL string, check bool, outputFormat string, out io.Writer) error {
+	info := currentVersionInfo()
+	if check {
+		ctx, cancel := context.WithTimeout(ctx, updateCheckTimeout)
+		defer cancel()
+		result, err := update.Check(ctx, client, releaseURL, info.Version)
+		if err != nil {
+			Log.Debug().Err(err).Msg("Update check failed")
+			info.UpdateError = err.Error()
+		}
+		info.Update = result
+	}
+	if output.IsStructured(outputFormat) {
+		return output.Structured(out, outputFormat, info)
+	}
+
+	fmt.Fprintf(out, "tix %s\n", info.Version)
+	if info.Commit != "" {
+		fmt.Fprintf(out, "  commit:     %s\n", info.Commit)
+	}
+	if info.Date != "" {
+		fmt.Fprintf(out, "  built:      %s\n", info.Date)
+	}
+	if info.BuiltBy != "" {
+		fmt.Fprintf(out, "  built by:   %s\n", info.BuiltBy)
+	}
+	fmt.Fprintf(out, "  go version: %s\n", info.GoVersion)
+	fmt.Fprintf(out, "  platform:   %s\n", info.Platform)
+	switch {
+	case info.UpdateError != "":
+		fmt.Fprintf(out, "Could not check for updates: %s\n", info.UpdateError)
+	case info.Update != nil && info.Update.UpdateAvailable:
+		fmt.Fprintf(out, "A newer release is available: %s (%s)\n", info.Update.Latest, info.Update.URL)
+	case info.Update != nil:
+		fmt.Fprintf(out, "You are running the latest release (%s).\n", info.Update.Latest)
+	}
+	return nil
+}
+
+// versionCmd represents the version command
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Show version and build information",
+	Long: `Prints the version, commit, build date, Go version and platform of tix.
+With --check, also queries GitHub for the latest release and reports whether it is
+newer. Failing to reach GitHub is reported but does not fail the command.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		check, _ := cmd.Flags().GetBool("check")
+		outputFormat, _ := cmd.Flags().GetString("output")
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return versionRunE(ctx, nil, update.DefaultReleaseURL, check, outputFormat, cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	versionCmd.Flags().Bool("check", false, "Check whether a newer release is available")
+	rootCmd.AddCommand(versionCmd)
+}
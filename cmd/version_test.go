@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionRunE(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, versionRunE(context.Background(), nil, "", false, "text", &out))
+	assert.Contains(t, out.String(), "tix ")
+	assert.Contains(t, out.String(), "go version: "+runtime.Version())
+	assert.Contains(t, out.String(), "platform:   "+runtime.GOOS+"/"+runtime.GOARCH)
+	assert.NotContains(t, out.String(), "release")
+}
+
+func TestVersionRunE_Check(t *testing.T) {
+	origVersion := version
+	version = "v0.1.0"
+	defer func() { version = origVersion }()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"tag_name": "v0.2.0", "html_url": "https://example.com/v0.2.0"}`))
+	}))
+	defer server.Close()
+
+	var out bytes.Buffer
+	require.NoError(t, versionRunE(context.Background(), server.Client(), server.URL, true, "text", &out))
+	assert.Contains(t, out.String(), "tix v0.1.0\n")
+	assert.Contains(t, out.String(), "A newer release is available: v0.2.0 (https://example.com/v0.2.0)")
+
+	out.Reset()
+	require.NoError(t, versionRunE(context.Background(), server.Client(), server.URL, true, "json", &out))
+	var info versionInfo
+	require.NoError(t, json.Unmarshal(out.Bytes(), &info))
+	assert.Equal(t, "v0.1.0", info.Version)
+	require.NotNil(t, info.Update)
+	assert.True(t, info.Update.UpdateAvailable)
+	assert.Equal(t, "v0.2.0", info.Update.Latest)
+
+	out.Reset()
+	require.NoError(t, versionRunE(context.Background(), server.Client(), server.URL, true, "yaml", &out))
+	assert.Contains(t, out.String(), "update_available: true")
+	assert.Contains(t, out.String(), "latest: v0.2.0")
+}
+
+func TestVersionRunE_CheckFailureIsNotFatal(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	var out bytes.Buffer
+	require.NoError(t, versionRunE(context.Background(), server.Client(), server.URL, true, "text", &out))
+	assert.Contains(t, out.String(), "Could not check for updates:")
+	assert.Contains(t, out.String(), "403")
+}
+
+func TestRootVersionFlag(t *testing.T) {
+	root := NewRootCmd()
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetArgs([]string{"--version"})
+	require.NoError(t, root.Execute())
+	assert.Equal(t, version+"\n", out.String())
+}
@@ -0,0 +1,353 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/karolswdev/ticketron/internal/cache"
+	"github.com/karolswdev/ticketron/internal/markdown"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/output"
+	"github.com/karolswdev/ticketron/internal/sanitize"
+)
+
+// defaultCommentPageSize is the number of comments requested per MCP call.
+const defaultCommentPageSize = 50
+
+// historyValueWidth is the display width at which changed field values are truncated.
+const historyValueWidth = 60
+
+// jiraTimeLayout is the timestamp format used by the Jira REST API.
+const jiraTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// viewOptions holds the settings of a view run.
+type viewOptions struct {
+	comments     bool
+	history      bool
+	pageSize     int
+	limit        int // Show only the most recent comments, 0 for all
+	outputFormat string
+	styled       bool   // Render markdown with ANSI styles
+	plain        bool   // Render markdown for screen readers (--plain), see markdown.RenderLinear
+	raw          bool   // Print descriptions and comments as their Markdown source
+	browseURL    string // Base of issue links, e.g. https://acme.atlassian.net; derived from the issue if empty
+	// inlineSummaries adds the summaries of the issues mentioned in the description and
+	// comments to their links, fetched through summaryCache if it is not nil.
+	inlineSummaries bool
+	summaryCache    *cache.Store
+	links           *issueLinker // Set by viewRunE
+}
+
+// markdown renders src as configured by the options.
+func (o viewOptions) markdown(src string) string {
+	if o.plain {
+		return markdown.RenderLinear(src)
+	}
+	return markdown.Render(src, o.styled)
+}
+
+// body renders the Markdown of a description or comment, sanitized by the caller, with
+// its issue keys linked, or returns it as it is with --raw.
+func (o viewOptions) body(src string) string {
+	if o.raw {
+		return src
+	}
+	return o.markdown(o.links.link(src))
+}
+
+// viewResult is the JSON representation of a viewed issue.
+type viewResult struct {
+	Issue    *mcpclient.Issue          `json:"issue"`
+	Comments []mcpclient.Comment       `json:"comments,omitempty"`
+	History  []mcpclient.ChangeHistory `json:"history,omitempty" yaml:"history,omitempty"`
+}
+
+// viewRunE fetches an issue and, if requested, its full comment thread and change history
+// and renders them to out.
+func viewRunE(ctx context.Context, mcpClient MCPClient, issueKey string, opts viewOptions, out io.Writer) error {
+	issue, err := mcpClient.GetIssue(ctx, issueKey)
+	if err != nil {
+		return fmt.Errorf("failed to get issue %s: %w", issueKey, err)
+	}
+
+	var comments []mcpclient.Comment
+	if opts.comments {
+		comments, err = fetchAllComments(ctx, mcpClient, issueKey, opts.pageSize)
+		if err != nil {
+			return fmt.Errorf("failed to get comments for %s: %w", issueKey, err)
+		}
+		if opts.limit > 0 && len(comments) > opts.limit {
+			comments = comments[len(comments)-opts.limit:]
+		}
+	}
+
+	var history []mcpclient.ChangeHistory
+	if opts.history {
+		history, err = fetchAllChangelog(ctx, mcpClient, issueKey, opts.pageSize)
+		if err != nil {
+			return fmt.Errorf("failed to get history for %s: %w", issueKey, err)
+		}
+	}
+
+	if format := output.Normalize(opts.outputFormat); output.IsStructured(format) {
+		return output.Structured(out, format, viewResult{Issue: issue, Comments: comments, History: history})
+	}
+
+	if !opts.raw {
+		opts.links = newViewIssueLinker(ctx, mcpClient, issue, comments, opts)
+	}
+	renderIssue(out, issue, opts)
+	if opts.comments {
+		renderComments(out, comments, opts)
+	}
+	if opts.history {
+		renderHistory(out, history, opts)
+	}
+	return nil
+}
+
+// newViewIssueLinker returns the linker for the issue keys mentioned in the description
+// and comments of issue.
+func newViewIssueLinker(ctx context.Context, mcpClient MCPClient, issue *mcpclient.Issue, comments []mcpclient.Comment, opts viewOptions) *issueLinker {
+	links := &issueLinker{baseURL: strings.TrimRight(opts.browseURL, "/")}
+	if links.baseURL == "" {
+		links.baseURL = browseBaseURL(issue.Self)
+	}
+	if !opts.inlineSummaries {
+		return links
+	}
+	texts := []string{issue.Fields.Description}
+	for _, comment := range comments {
+		texts = append(texts, comment.Body)
+	}
+	var keys []string
+	for _, key := range mentionedIssueKeys(texts...) {
+		if key != issue.Key {
+			keys = append(keys, key)
+		}
+	}
+	links.summaries = fetchIssueSummaries(ctx, mcpClient, opts.summaryCache, keys)
+	links.summaries[issue.Key] = issue.Fields.Summary
+	return links
+}
+
+// fetchAllComments pages through the comments of an issue until all of them are retrieved.
+func fetchAllComments(ctx context.Context, mcpClient MCPClient, issueKey string, pageSize int) ([]mcpclient.Comment, error) {
+	if pageSize <= 0 {
+		pageSize = defaultCommentPageSize
+	}
+	var comments []mcpclient.Comment
+	for {
+		page, err := mcpClient.GetComments(ctx, issueKey, mcpclient.GetCommentsRequest{StartAt: len(comments), MaxResults: pageSize})
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, page.Comments...)
+		Log.Debug().Int("fetched", len(comments)).Int("total", page.Total).Msg("Fetched comment page")
+		// Stop on an empty page as well, so a server reporting a wrong total cannot loop forever
+		if len(page.Comments) == 0 || len(comments) >= page.Total {
+			return comments, nil
+		}
+	}
+}
+
+// fetchAllChangelog pages through the change history of an issue until all of it is retrieved.
+func fetchAllChangelog(ctx context.Context, mcpClient MCPClient, issueKey string, pageSize int) ([]mcpclient.ChangeHistory, error) {
+	if pageSize <= 0 {
+		pageSize = defaultCommentPageSize
+	}
+	var history []mcpclient.ChangeHistory
+	for {
+		page, err := mcpClient.GetIssueChangelog(ctx, issueKey, mcpclient.GetChangelogRequest{StartAt: len(history), MaxResults: pageSize})
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, page.Histories...)
+		Log.Debug().Int("fetched", len(history)).Int("total", page.Total).Msg("Fetched changelog page")
+		if len(page.Histories) == 0 || len(history) >= page.Total {
+			return history, nil
+		}
+	}
+}
+
+// renderIssue prints the header and description of an issue. Text from Jira is sanitized
+// so control characters cannot alter the terminal.
+func renderIssue(out io.Writer, issue *mcpclient.Issue, opts viewOptions) {
+	fmt.Fprintln(out, opts.markdown(fmt.Sprintf("# %s  %s", sanitize.Line(issue.Key), sanitize.Line(issue.Fields.Summary))))
+	separator := "   "
+	if opts.plain {
+		separator = "\n"
+	}
+	header := "Type: " + sanitize.Line(issue.Fields.IssueType.Name) + separator + "Status: " + sanitize.Line(issue.Fields.Status.Name)
+	if issue.Fields.DueDate != "" {
+		header += separator + "Due: " + sanitize.Line(issue.Fields.DueDate)
+	}
+	fmt.Fprintln(out, header)
+	if description := strings.TrimSpace(sanitize.Text(issue.Fields.Description)); description != "" {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, opts.body(description))
+	}
+}
+
+// renderComments prints a comment thread with an author and time header per comment.
+func renderComments(out io.Writer, comments []mcpclient.Comment, opts viewOptions) {
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, opts.markdown(fmt.Sprintf("## Comments (%d)", len(comments))))
+	for _, comment := range comments {
+		author := "Unknown"
+		if comment.Author != nil && comment.Author.DisplayName != "" {
+			author = sanitize.Line(comment.Author.DisplayName)
+		}
+		header := "**" + author + "**"
+		if comment.Created != "" {
+			separator := " · "
+			if opts.plain {
+				separator = ", "
+			}
+			header += separator + formatJiraTime(comment.Created)
+		}
+		if comment.Updated != "" && comment.Updated != comment.Created {
+			header += " (edited)"
+		}
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, opts.markdown(header))
+		for _, line := range strings.Split(opts.body(strings.TrimSpace(sanitize.Text(comment.Body))), "\n") {
+			fmt.Fprintln(out, "  "+line)
+		}
+	}
+}
+
+// renderHistory prints the change history of an issue, oldest first, with an author and
+// time header per change and one "field: from → to" line per changed field.
+func renderHistory(out io.Writer, history []mcpclient.ChangeHistory, opts viewOptions) {
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, opts.markdown(fmt.Sprintf("## History (%d)", len(history))))
+	arrow := " → "
+	if opts.plain {
+		arrow = " to "
+	}
+	for _, change := range history {
+		author := "Unknown"
+		if change.Author != nil && change.Author.DisplayName != "" {
+			author = sanitize.Line(change.Author.DisplayName)
+		}
+		header := "**" + author + "**"
+		if change.Created != "" {
+			separator := " · "
+			if opts.plain {
+				separator = ", "
+			}
+			header += separator + formatJiraTime(change.Created)
+		}
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, opts.markdown(header))
+		for _, item := range change.Items {
+			fmt.Fprintf(out, "  %s: %s%s%s\n", sanitize.Line(item.Field), historyValue(item.FromString), arrow, historyValue(item.ToString))
+		}
+	}
+}
+
+// historyValue returns a changed field value for display on a single line.
+func historyValue(s string) string {
+	s = sanitize.Line(strings.Join(strings.Fields(s), " "))
+	if s == "" {
+		return "(none)"
+	}
+	return sanitize.Truncate(s, historyValueWidth)
+}
+
+// formatJiraTime renders a Jira timestamp in local time, or returns it unchanged if it cannot be parsed.
+func formatJiraTime(ts string) string {
+	t, err := time.Parse(jiraTimeLayout, ts)
+	if err != nil {
+		return ts
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+// isTerminal reports whether w is a terminal that should receive ANSI styles.
+// Styles are disabled when the NO_COLOR environment variable is set.
+func isTerminal(w io.Writer) bool {
+	return os.Getenv("NO_COLOR") == "" && isCharDevice(w)
+}
+
+// isCharDevice reports whether w is a terminal, regardless of NO_COLOR.
+func isCharDevice(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// viewCmd represents the view command
+var viewCmd = &cobra.Command{
+	Use:   "view ISSUE-KEY",
+	Short: "Show a JIRA issue and its comments",
+	Long: `Shows the summary, type, status and description of a JIRA issue. With --comments,
+the full comment thread is fetched page by page and shown oldest first with authors
+and timestamps. With --history, the change history is shown the same way, one line per
+changed field, which makes status churn easy to audit. Markdown in descriptions and
+comments is rendered for the terminal, with syntax highlighting of code blocks, and the
+issue keys they mention become links to the issues. With --inline-summaries, the links
+also show the summaries of the issues. Use --raw for the Markdown source instead.
+
+Use '-o json' to print the issue, comments and history as JSON.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var opts viewOptions
+		opts.comments, _ = cmd.Flags().GetBool("comments")
+		opts.history, _ = cmd.Flags().GetBool("history")
+		opts.pageSize, _ = cmd.Flags().GetInt("page-size")
+		opts.limit, _ = cmd.Flags().GetInt("limit")
+		opts.outputFormat, _ = cmd.Flags().GetString("output")
+		opts.plain = plainOutput(cmd)
+		opts.raw, _ = cmd.Flags().GetBool("raw")
+		opts.styled = !opts.plain && !ciEnabled && isTerminal(cmd.OutOrStdout())
+
+		cfgProvider := &DefaultConfigProvider{}
+		cfg, err := cfgProvider.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrProviderConfig, err)
+		}
+		opts.browseURL = cfg.UI.BrowseURL
+		opts.inlineSummaries = cfg.UI.InlineIssueSummaries
+		if cmd.Flags().Changed("inline-summaries") {
+			opts.inlineSummaries, _ = cmd.Flags().GetBool("inline-summaries")
+		}
+		if opts.inlineSummaries {
+			if configDir, err := cfgProvider.EnsureConfigDir(); err == nil {
+				opts.summaryCache = cache.New(filepath.Join(configDir, "cache"), issueSummaryCacheTTL)
+			}
+		}
+
+		mcpClient, err := newCommandMCPClient()
+		if err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return viewRunE(ctx, mcpClient, args[0], opts, cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	viewCmd.Flags().Bool("comments", false, "Show the comment thread")
+	viewCmd.Flags().Bool("history", false, "Show the change history")
+	viewCmd.Flags().Int("page-size", defaultCommentPageSize, "Comments or history entries fetched per request")
+	viewCmd.Flags().Int("limit", 0, "Show only the N most recent comments (0 for all)")
+	viewCmd.Flags().Bool("raw", false, "Print descriptions and comments as their Markdown source, without rendering or links")
+	viewCmd.Flags().Bool("inline-summaries", false, "Show the summaries of the issues mentioned in the description and comments (default: ui.inline_issue_summaries)")
+
+	rootCmd.AddCommand(viewCmd)
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/golden"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+func testViewIssue() *mcpclient.Issue {
+	return &mcpclient.Issue{Key: "BE-1", Fields: mcpclient.IssueFields{
+		Summary:     "Fix login",
+		Status:      mcpclient.Status{Name: "Open"},
+		IssueType:   mcpclient.IssueType{Name: "Bug"},
+		Description: "Users see **500**",
+	}}
+}
+
+func TestViewRunE_IssueOnly(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("GetIssue", mock.Anything, "BE-1").Return(testViewIssue(), nil)
+
+	var out bytes.Buffer
+	require.NoError(t, viewRunE(context.Background(), mockMCP, "BE-1", viewOptions{}, &out))
+	golden.Assert(t, out.Bytes())
+	mockMCP.AssertNotCalled(t, "GetComments", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestViewRunE_CommentsPaginated(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("GetIssue", mock.Anything, "BE-1").Return(testViewIssue(), nil)
+	mockMCP.On("GetComments", mock.Anything, "BE-1", mcpclient.GetCommentsRequest{StartAt: 0, MaxResults: 2}).
+		Return(&mcpclient.CommentsResponse{Total: 3, Comments: []mcpclient.Comment{
+			{ID: "1", Author: &mcpclient.User{DisplayName: "Ada"}, Body: "First", Created: "bad-time"},
+			{ID: "2", Body: "Second"},
+		}}, nil)
+	mockMCP.On("GetComments", mock.Anything, "BE-1", mcpclient.GetCommentsRequest{StartAt: 2, MaxResults: 2}).
+		Return(&mcpclient.CommentsResponse{Total: 3, Comments: []mcpclient.Comment{
+			{ID: "3", Author: &mcpclient.User{DisplayName: "Linus"}, Body: "- `fixed`\n- deployed", Created: "t1", Updated: "t2"},
+		}}, nil)
+
+	var out bytes.Buffer
+	opts := viewOptions{comments: true, pageSize: 2, limit: 2}
+	require.NoError(t, viewRunE(context.Background(), mockMCP, "BE-1", opts, &out))
+	golden.Assert(t, out.Bytes())
+	assert.NotContains(t, out.String(), "First", "--limit keeps the most recent comments")
+	mockMCP.AssertExpectations(t)
+}
+
+func TestViewRunE_Plain(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("GetIssue", mock.Anything, "BE-1").Return(testViewIssue(), nil)
+	mockMCP.On("GetComments", mock.Anything, "BE-1", mock.Anything).
+		Return(&mcpclient.CommentsResponse{Total: 1, Comments: []mcpclient.Comment{
+			{ID: "1", Author: &mcpclient.User{DisplayName: "Ada"}, Body: "> quoted\n- item", Created: "t1"},
+		}}, nil)
+
+	var out bytes.Buffer
+	require.NoError(t, viewRunE(context.Background(), mockMCP, "BE-1", viewOptions{comments: true, plain: true, styled: true}, &out))
+	golden.Assert(t, out.Bytes())
+}
+
+func TestViewRunE_Structured(t *testing.T) {
+	for _, format := range []string{"json", "yaml"} {
+		t.Run(format, func(t *testing.T) {
+			Log = zerolog.Nop()
+			mockMCP := new(MockMCPClient)
+			mockMCP.On("GetIssue", mock.Anything, "BE-1").Return(testViewIssue(), nil)
+			mockMCP.On("GetComments", mock.Anything, "BE-1", mock.Anything).
+				Return(&mcpclient.CommentsResponse{Total: 5, Comments: []mcpclient.Comment{{ID: "1", Body: "Only"}}}, nil).Once()
+			mockMCP.On("GetComments", mock.Anything, "BE-1", mock.Anything).
+				Return(&mcpclient.CommentsResponse{Total: 5}, nil).Once()
+
+			var out bytes.Buffer
+			require.NoError(t, viewRunE(context.Background(), mockMCP, "BE-1", viewOptions{comments: true, outputFormat: format}, &out))
+			golden.Assert(t, out.Bytes()) // An empty page ends pagination, so only one comment is listed
+			mockMCP.AssertExpectations(t)
+		})
+	}
+}
+
+func TestViewRunE_History(t *testing.T) {
+	history := []mcpclient.ChangeHistory{
+		{ID: "1", Author: &mcpclient.User{DisplayName: "Ada"}, Created: "t1", Items: []mcpclient.ChangeItem{
+			{Field: "status", FromString: "Open", ToString: "In Progress"},
+			{Field: "assignee", ToString: "Ada"},
+		}},
+		{ID: "2", Items: []mcpclient.ChangeItem{
+			{Field: "description", FromString: "Users see a 500 error when they log in with an expired password token", ToString: "Fixed\nin 1.2"},
+		}},
+	}
+	for _, tt := range []struct {
+		name string
+		opts viewOptions
+	}{
+		{name: "text", opts: viewOptions{history: true, pageSize: 1}},
+		{name: "plain", opts: viewOptions{history: true, pageSize: 1, plain: true}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			Log = zerolog.Nop()
+			mockMCP := new(MockMCPClient)
+			mockMCP.On("GetIssue", mock.Anything, "BE-1").Return(testViewIssue(), nil)
+			mockMCP.On("GetIssueChangelog", mock.Anything, "BE-1", mcpclient.GetChangelogRequest{StartAt: 0, MaxResults: 1}).
+				Return(&mcpclient.ChangelogResponse{Total: 2, Histories: history[:1]}, nil)
+			mockMCP.On("GetIssueChangelog", mock.Anything, "BE-1", mcpclient.GetChangelogRequest{StartAt: 1, MaxResults: 1}).
+				Return(&mcpclient.ChangelogResponse{Total: 2, Histories: history[1:]}, nil)
+
+			var out bytes.Buffer
+			require.NoError(t, viewRunE(context.Background(), mockMCP, "BE-1", tt.opts, &out))
+			golden.Assert(t, out.Bytes())
+			mockMCP.AssertExpectations(t)
+			mockMCP.AssertNotCalled(t, "GetComments", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestViewRunE_Errors(t *testing.T) {
+	Log = zerolog.Nop()
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("GetIssue", mock.Anything, "BE-404").Return(nil, errors.New("not found"))
+	err := viewRunE(context.Background(), mockMCP, "BE-404", viewOptions{}, &bytes.Buffer{})
+	assert.EqualError(t, err, "failed to get issue BE-404: not found")
+
+	mockMCP.On("GetIssue", mock.Anything, "BE-1").Return(testViewIssue(), nil)
+	mockMCP.On("GetComments", mock.Anything, "BE-1", mock.Anything).Return(nil, errors.New("forbidden"))
+	err = viewRunE(context.Background(), mockMCP, "BE-1", viewOptions{comments: true}, &bytes.Buffer{})
+	assert.EqualError(t, err, "failed to get comments for BE-1: forbidden")
+
+	mockMCP.On("GetIssueChangelog", mock.Anything, "BE-1", mock.Anything).Return(nil, errors.New("forbidden"))
+	err = viewRunE(context.Background(), mockMCP, "BE-1", viewOptions{history: true}, &bytes.Buffer{})
+	assert.EqualError(t, err, "failed to get history for BE-1: forbidden")
+}
+
+func TestFormatJiraTime(t *testing.T) {
+	assert.Equal(t, "not a time", formatJiraTime("not a time"))
+	assert.Regexp(t, `^2025-04-0[12] \d\d:\d\d$`, formatJiraTime("2025-04-01T10:00:00.000+0000"))
+}
+
+func TestViewRunE_Raw(t *testing.T) {
+	Log = zerolog.Nop()
+	issue := testViewIssue()
+	issue.Self = "https://jira.example/rest/api/2/issue/10001"
+	issue.Fields.Description = "Users see **500**, see BE-2\n```go\nreturn nil\n```"
+	mockMCP := new(MockMCPClient)
+	mockMCP.On("GetIssue", mock.Anything, "BE-1").Return(issue, nil)
+
+	var out bytes.Buffer
+	require.NoError(t, viewRunE(context.Background(), mockMCP, "BE-1", viewOptions{raw: true, inlineSummaries: true}, &out))
+	assert.Contains(t, out.String(), "\n\nUsers see **500**, see BE-2\n```go\nreturn nil\n```\n", "the description is printed as it is")
+	mockMCP.AssertNumberOfCalls(t, "GetIssue", 1)
+}
@@ -0,0 +1,223 @@
+// Package breaker stops sending requests to a server that keeps failing (mcp.circuit_breaker
+// and llm.circuit_breaker in config.yaml). After a number of consecutive failures the circuit
+// opens and requests fail at once; after a cooldown a single probe request is let through,
+// and its success closes the circuit again. Breakers are shared process-wide by name, so
+// the workers of bulk operations and the requests of tix serve see the same state.
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/karolswdev/ticketron/internal/config"
+)
+
+// DefaultCooldown is how long the circuit stays open when CircuitBreakerConfig.Cooldown is unset.
+const DefaultCooldown = 30 * time.Second
+
+// State is the state of a circuit.
+type State int
+
+const (
+	Closed   State = iota // Requests are sent
+	Open                  // Requests fail without being sent
+	HalfOpen              // The cooldown is over; one probe request is sent at a time
+)
+
+// outcome classifies a finished request.
+type outcome int
+
+const (
+	outcomeSuccess outcome = iota
+	outcomeFailure
+	outcomeIgnored // Cancelled by the caller; says nothing about the server
+)
+
+// Validate checks that the threshold and cooldown of cfg are not negative.
+func Validate(cfg config.CircuitBreakerConfig) error {
+	if cfg.FailureThreshold < 0 {
+		return fmt.Errorf("%w: failure_threshold must not be negative, got %d", ErrInvalidConfig, cfg.FailureThreshold)
+	}
+	if cfg.Cooldown < 0 {
+		return fmt.Errorf("%w: cooldown must not be negative, got %s", ErrInvalidConfig, cfg.Cooldown)
+	}
+	return nil
+}
+
+// Breaker tracks the consecutive failures of the requests to one server. It is safe for
+// concurrent use.
+type Breaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    State
+	failures int       // Consecutive failures while closed
+	openedAt time.Time // When the circuit last opened
+	probing  bool      // A probe request is in flight while half-open
+}
+
+// New returns a breaker for cfg, or nil if cfg disables it.
+func New(cfg config.CircuitBreakerConfig) (*Breaker, error) {
+	if err := Validate(cfg); err != nil {
+		return nil, err
+	}
+	if cfg.FailureThreshold == 0 {
+		return nil, nil
+	}
+	cooldown := cfg.Cooldown
+	if cooldown == 0 {
+		cooldown = DefaultCooldown
+	}
+	return &Breaker{threshold: cfg.FailureThreshold, cooldown: cooldown, now: time.Now}, nil
+}
+
+// allow reports whether a request may be sent and whether it is the probe of a half-open
+// circuit. Requests that may not be sent get an error wrapping ErrOpen.
+func (b *Breaker) allow() (probe bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case Closed:
+		return false, nil
+	case Open:
+		remaining := b.cooldown - b.now().Sub(b.openedAt)
+		if remaining > 0 {
+			return false, fmt.Errorf("%w after %d consecutive failures; retrying in %s", ErrOpen, b.threshold, remaining.Round(time.Second))
+		}
+		b.state = HalfOpen
+	}
+	if b.probing {
+		return false, fmt.Errorf("%w; waiting for a probe request to succeed", ErrOpen)
+	}
+	b.probing = true
+	return true, nil
+}
+
+// record updates the circuit with the outcome of a request admitted by allow and returns
+// the state it changed to, if any.
+func (b *Breaker) record(probe bool, result outcome) (State, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if probe {
+		b.probing = false
+		switch result {
+		case outcomeSuccess:
+			b.state, b.failures = Closed, 0
+			return Closed, true
+		case outcomeFailure:
+			b.state, b.openedAt = Open, b.now()
+			return Open, true
+		}
+		return b.state, false
+	}
+	if b.state != Closed || result == outcomeIgnored {
+		return b.state, false // Late results of requests sent before the circuit opened
+	}
+	if result == outcomeSuccess {
+		b.failures = 0
+		return Closed, false
+	}
+	b.failures++
+	if b.failures < b.threshold {
+		return Closed, false
+	}
+	b.state, b.openedAt, b.failures = Open, b.now(), 0
+	return Open, true
+}
+
+// shared holds the breakers of Shared by name and configuration.
+var (
+	sharedMu sync.Mutex
+	shared   = make(map[sharedKey]*Breaker)
+)
+
+type sharedKey struct {
+	name string
+	cfg  config.CircuitBreakerConfig
+}
+
+// Shared returns the process-wide breaker for name and cfg, creating it on first use, or
+// nil if cfg disables it. Clients built again with the same configuration, e.g. when tix
+// serve reloads it, keep the state of the circuit.
+func Shared(name string, cfg config.CircuitBreakerConfig) (*Breaker, error) {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	key := sharedKey{name: name, cfg: cfg}
+	if b, ok := shared[key]; ok {
+		return b, nil
+	}
+	b, err := New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if b != nil {
+		shared[key] = b
+	}
+	return b, nil
+}
+
+// Transport is an http.RoundTripper that passes requests on to the wrapped transport while
+// its circuit is closed. Transport errors and 429 and 5xx responses count as failures.
+type Transport struct {
+	next    http.RoundTripper
+	breaker *Breaker
+	name    string
+}
+
+// Wrap returns next with requests guarded by b, or next unchanged if b is nil. name
+// identifies the client in errors and log messages ("mcp", "llm openai"). A nil next
+// stands for http.DefaultTransport.
+func Wrap(next http.RoundTripper, b *Breaker, name string) http.RoundTripper {
+	if b == nil {
+		return next
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Transport{next: next, breaker: b, name: name}
+}
+
+// RoundTrip sends the request unless the circuit is open, and records its outcome.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	probe, err := t.breaker.allow()
+	if err != nil {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, fmt.Errorf("%s: %w", t.name, err)
+	}
+	if probe {
+		log.Info().Str("client", t.name).Str("url", req.URL.Redacted()).Msg("Circuit breaker half-open, sending probe request")
+	}
+	resp, err := t.next.RoundTrip(req)
+	if state, changed := t.breaker.record(probe, classify(resp, err)); changed {
+		if state == Open {
+			log.Warn().Str("client", t.name).Dur("cooldown", t.breaker.cooldown).Msg("Circuit breaker opened, failing requests until the cooldown is over")
+		} else {
+			log.Info().Str("client", t.name).Msg("Circuit breaker closed")
+		}
+	}
+	return resp, err
+}
+
+// classify returns the outcome of a request from its response or error.
+func classify(resp *http.Response, err error) outcome {
+	switch {
+	case err != nil && errors.Is(err, context.Canceled):
+		return outcomeIgnored
+	case err != nil:
+		return outcomeFailure
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return outcomeFailure
+	default:
+		return outcomeSuccess
+	}
+}
@@ -0,0 +1,159 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/config"
+)
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate(config.CircuitBreakerConfig{}))
+	assert.NoError(t, Validate(config.CircuitBreakerConfig{FailureThreshold: 5, Cooldown: time.Minute}))
+	assert.ErrorIs(t, Validate(config.CircuitBreakerConfig{FailureThreshold: -1}), ErrInvalidConfig)
+	assert.ErrorIs(t, Validate(config.CircuitBreakerConfig{FailureThreshold: 1, Cooldown: -time.Second}), ErrInvalidConfig)
+}
+
+func TestNew(t *testing.T) {
+	b, err := New(config.CircuitBreakerConfig{Cooldown: time.Minute})
+	require.NoError(t, err)
+	assert.Nil(t, b, "a zero threshold disables the breaker")
+
+	b, err = New(config.CircuitBreakerConfig{FailureThreshold: 3})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultCooldown, b.cooldown)
+}
+
+func TestBreaker_States(t *testing.T) {
+	b, err := New(config.CircuitBreakerConfig{FailureThreshold: 2, Cooldown: 10 * time.Second})
+	require.NoError(t, err)
+	now := time.Date(2025, time.April, 16, 12, 0, 0, 0, time.UTC)
+	b.now = func() time.Time { return now }
+
+	send := func(result outcome) (bool, error) {
+		probe, err := b.allow()
+		if err == nil {
+			b.record(probe, result)
+		}
+		return probe, err
+	}
+
+	_, err = send(outcomeFailure)
+	require.NoError(t, err)
+	_, err = send(outcomeSuccess)
+	require.NoError(t, err)
+	_, err = send(outcomeFailure)
+	require.NoError(t, err)
+	assert.Equal(t, Closed, b.state, "a success resets the count of consecutive failures")
+	_, err = send(outcomeFailure)
+	require.NoError(t, err)
+	assert.Equal(t, Open, b.state)
+
+	now = now.Add(4 * time.Second)
+	_, err = b.allow()
+	require.ErrorIs(t, err, ErrOpen)
+	assert.Contains(t, err.Error(), "after 2 consecutive failures; retrying in 6s")
+
+	// After the cooldown a single probe is sent; a failed probe opens the circuit again
+	now = now.Add(6 * time.Second)
+	probe, err := b.allow()
+	require.NoError(t, err)
+	assert.True(t, probe)
+	_, err = b.allow()
+	require.ErrorIs(t, err, ErrOpen, "only one probe is in flight at a time")
+	state, changed := b.record(true, outcomeFailure)
+	assert.True(t, changed)
+	assert.Equal(t, Open, state)
+	_, err = b.allow()
+	require.ErrorIs(t, err, ErrOpen)
+
+	// A cancelled probe lets the next request probe
+	now = now.Add(10 * time.Second)
+	probe, err = send(outcomeIgnored)
+	require.NoError(t, err)
+	assert.True(t, probe)
+	assert.Equal(t, HalfOpen, b.state)
+
+	probe, err = send(outcomeSuccess)
+	require.NoError(t, err)
+	assert.True(t, probe)
+	assert.Equal(t, Closed, b.state)
+	assert.Zero(t, b.failures)
+}
+
+func TestShared(t *testing.T) {
+	cfg := config.CircuitBreakerConfig{FailureThreshold: 7}
+	first, err := Shared("test", cfg)
+	require.NoError(t, err)
+	again, err := Shared("test", cfg)
+	require.NoError(t, err)
+	assert.Same(t, first, again)
+	other, err := Shared("other", cfg)
+	require.NoError(t, err)
+	assert.NotSame(t, first, other)
+
+	disabled, err := Shared("test", config.CircuitBreakerConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, disabled)
+	_, err = Shared("test", config.CircuitBreakerConfig{FailureThreshold: -1})
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
+
+func TestTransport(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusServiceUnavailable)
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer server.Close()
+
+	b, err := New(config.CircuitBreakerConfig{FailureThreshold: 2, Cooldown: time.Hour})
+	require.NoError(t, err)
+	now := time.Now()
+	b.now = func() time.Time { return now }
+	client := &http.Client{Transport: Wrap(nil, b, "mcp")}
+
+	for range 2 {
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	}
+	_, err = client.Get(server.URL)
+	require.ErrorIs(t, err, ErrOpen)
+	assert.Contains(t, err.Error(), "mcp: circuit breaker open")
+	assert.Equal(t, int32(2), hits.Load(), "requests are not sent while the circuit is open")
+
+	status.Store(http.StatusNotFound)
+	now = now.Add(time.Hour)
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, Closed, b.state, "client errors do not count as failures")
+	assert.Equal(t, int32(3), hits.Load())
+}
+
+func TestWrap_Disabled(t *testing.T) {
+	next := http.DefaultTransport
+	assert.Equal(t, next, Wrap(next, nil, "mcp"))
+}
+
+func TestClassify(t *testing.T) {
+	assert.Equal(t, outcomeIgnored, classify(nil, context.Canceled))
+	assert.Equal(t, outcomeFailure, classify(nil, context.DeadlineExceeded))
+	assert.Equal(t, outcomeFailure, classify(nil, errors.New("connection refused")))
+	assert.Equal(t, outcomeFailure, classify(&http.Response{StatusCode: http.StatusTooManyRequests}, nil))
+	assert.Equal(t, outcomeFailure, classify(&http.Response{StatusCode: http.StatusBadGateway}, nil))
+	assert.Equal(t, outcomeSuccess, classify(&http.Response{StatusCode: http.StatusBadRequest}, nil))
+	assert.Equal(t, outcomeSuccess, classify(&http.Response{StatusCode: http.StatusOK}, nil))
+}
@@ -0,0 +1,11 @@
+package breaker
+
+import "errors"
+
+// Sentinel errors for circuit breaking.
+
+// ErrInvalidConfig indicates a circuit breaker configuration with a negative threshold or cooldown.
+var ErrInvalidConfig = errors.New("invalid circuit breaker configuration")
+
+// ErrOpen is returned for requests that were not sent because the circuit is open.
+var ErrOpen = errors.New("circuit breaker open")
@@ -0,0 +1,103 @@
+// Package cache stores JSON values on disk with a time-to-live. It is used for data
+// fetched from the MCP server that rarely changes, such as project metadata.
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// unsafeKeyChars matches characters that are not kept in cache file names.
+var unsafeKeyChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// entry is the on-disk representation of a cached value.
+type entry struct {
+	StoredAt time.Time       `json:"stored_at"`
+	Value    json.RawMessage `json:"value"`
+}
+
+// Store is a directory of cached JSON values, one file per key.
+type Store struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// New returns a Store keeping entries in dir for ttl. A zero ttl keeps entries forever.
+// The directory is created on the first write.
+func New(dir string, ttl time.Duration) *Store {
+	return &Store{dir: dir, ttl: ttl, now: time.Now}
+}
+
+// path returns the file holding key.
+func (s *Store) path(key string) string {
+	return filepath.Join(s.dir, unsafeKeyChars.ReplaceAllString(key, "_")+".json")
+}
+
+// Get decodes the value cached under key into v. It reports false, without error,
+// when there is no entry or the entry has expired.
+func (s *Store) Get(key string, v interface{}) (bool, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", ErrCacheRead, err)
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return false, fmt.Errorf("%w: %w", ErrCacheRead, err)
+	}
+	if s.ttl > 0 && s.now().Sub(e.StoredAt) > s.ttl {
+		return false, nil
+	}
+	if err := json.Unmarshal(e.Value, v); err != nil {
+		return false, fmt.Errorf("%w: %w", ErrCacheRead, err)
+	}
+	return true, nil
+}
+
+// Set stores v under key, replacing any previous entry.
+func (s *Store) Set(key string, v interface{}) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCacheWrite, err)
+	}
+	data, err := json.Marshal(entry{StoredAt: s.now().UTC(), Value: value})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCacheWrite, err)
+	}
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("%w: %w", ErrCacheWrite, err)
+	}
+	// Write to a temporary file first so concurrent readers never see a partial entry
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCacheWrite, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("%w: %w", ErrCacheWrite, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("%w: %w", ErrCacheWrite, err)
+	}
+	if err := os.Rename(tmp.Name(), s.path(key)); err != nil {
+		return fmt.Errorf("%w: %w", ErrCacheWrite, err)
+	}
+	return nil
+}
+
+// Delete removes the entry for key. Deleting a missing entry is not an error.
+func (s *Store) Delete(key string) error {
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: %w", ErrCacheWrite, err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_SetGet(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+	store := New(dir, time.Hour)
+
+	var got []string
+	found, err := store.Get("createmeta/BE", &got)
+	require.NoError(t, err)
+	assert.False(t, found, "missing entries are not an error")
+
+	require.NoError(t, store.Set("createmeta/BE", []string{"Task", "Bug"}))
+	found, err = store.Get("createmeta/BE", &got)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []string{"Task", "Bug"}, got)
+	assert.FileExists(t, filepath.Join(dir, "createmeta_BE.json"))
+
+	require.NoError(t, store.Delete("createmeta/BE"))
+	require.NoError(t, store.Delete("createmeta/BE"))
+	found, err = store.Get("createmeta/BE", &got)
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestStore_Expiry(t *testing.T) {
+	store := New(t.TempDir(), time.Hour)
+	now := time.Date(2025, time.April, 1, 12, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	require.NoError(t, store.Set("k", 42))
+
+	var v int
+	now = now.Add(59 * time.Minute)
+	found, err := store.Get("k", &v)
+	require.NoError(t, err)
+	assert.True(t, found)
+
+	now = now.Add(2 * time.Minute)
+	found, err = store.Get("k", &v)
+	require.NoError(t, err)
+	assert.False(t, found, "expired entries are reported as missing")
+}
+
+func TestStore_CorruptEntry(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "k.json"), []byte("{not json"), 0o600))
+
+	var v int
+	_, err := New(dir, 0).Get("k", &v)
+	assert.ErrorIs(t, err, ErrCacheRead)
+}
@@ -0,0 +1,11 @@
+package cache
+
+import "errors"
+
+// Sentinel errors for the on-disk cache.
+
+// ErrCacheRead indicates a cache entry exists but could not be read or decoded.
+var ErrCacheRead = errors.New("failed to read cache entry")
+
+// ErrCacheWrite indicates a cache entry could not be written.
+var ErrCacheWrite = errors.New("failed to write cache entry")
@@ -0,0 +1,178 @@
+// Package chaos injects latency, timeouts and server errors into outbound HTTP requests,
+// so fallback and error handling can be exercised against slow or failing services
+// (mcp.chaos and llm.chaos in config.yaml). It is meant for development and testing only.
+package chaos
+
+import (
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/karolswdev/ticketron/internal/config"
+)
+
+// DefaultTimeout is how long an injected timeout hangs when ChaosConfig.Timeout is unset
+// and the request has no earlier deadline.
+const DefaultTimeout = 30 * time.Second
+
+// errorStatuses are the status codes of injected server errors.
+var errorStatuses = []int{
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// fault is the outcome drawn for one request.
+type fault int
+
+const (
+	faultNone fault = iota
+	faultError
+	faultTimeout
+)
+
+// Validate checks the rates and durations of cfg.
+func Validate(cfg config.ChaosConfig) error {
+	for _, rate := range []struct {
+		name  string
+		value float64
+	}{{"error_rate", cfg.ErrorRate}, {"timeout_rate", cfg.TimeoutRate}} {
+		if rate.value < 0 || rate.value > 1 {
+			return fmt.Errorf("%w: %s must be between 0 and 1, got %v", ErrInvalidConfig, rate.name, rate.value)
+		}
+	}
+	if cfg.ErrorRate+cfg.TimeoutRate > 1 {
+		return fmt.Errorf("%w: error_rate and timeout_rate must not add up to more than 1", ErrInvalidConfig)
+	}
+	if cfg.Latency < 0 || cfg.Jitter < 0 || cfg.Timeout < 0 {
+		return fmt.Errorf("%w: latency, jitter and timeout must not be negative", ErrInvalidConfig)
+	}
+	return nil
+}
+
+// Transport is an http.RoundTripper injecting faults before delegating to the wrapped transport.
+type Transport struct {
+	next    http.RoundTripper
+	cfg     config.ChaosConfig
+	timeout time.Duration
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// Wrap returns next wrapped with the faults configured by cfg, or next unchanged when cfg
+// is disabled. name identifies the client in log messages ("mcp", "llm"). A nil next stands
+// for http.DefaultTransport.
+func Wrap(next http.RoundTripper, cfg config.ChaosConfig, name string) (http.RoundTripper, error) {
+	if !cfg.Enabled {
+		return next, nil
+	}
+	if err := Validate(cfg); err != nil {
+		return nil, err
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	log.Warn().Str("client", name).Dur("latency", cfg.Latency).Dur("jitter", cfg.Jitter).
+		Float64("error_rate", cfg.ErrorRate).Float64("timeout_rate", cfg.TimeoutRate).
+		Msg("Chaos mode enabled; requests will be delayed and fail on purpose")
+	return &Transport{
+		next:    next,
+		cfg:     cfg,
+		timeout: timeout,
+		rng:     rand.New(rand.NewPCG(seed, seed)),
+	}, nil
+}
+
+// RoundTrip delays the request, then either fails it or passes it on.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	delay, outcome, status := t.roll()
+	ctx := req.Context()
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			closeBody(req)
+			return nil, ctx.Err()
+		}
+	}
+
+	switch outcome {
+	case faultError:
+		closeBody(req)
+		log.Debug().Str("url", req.URL.String()).Int("status", status).Msg("Chaos: injecting server error")
+		return errorResponse(req, status), nil
+	case faultTimeout:
+		closeBody(req)
+		log.Debug().Str("url", req.URL.String()).Msg("Chaos: injecting timeout")
+		timer := time.NewTimer(t.timeout)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			return nil, fmt.Errorf("%w: %s %s", ErrInjectedTimeout, req.Method, req.URL.Redacted())
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	default:
+		return t.next.RoundTrip(req)
+	}
+}
+
+// roll draws the delay, the fault and the status of an injected error for one request.
+func (t *Transport) roll() (time.Duration, fault, int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delay := t.cfg.Latency
+	if t.cfg.Jitter > 0 {
+		delay += time.Duration(t.rng.Int64N(int64(t.cfg.Jitter) + 1))
+	}
+	draw := t.rng.Float64()
+	switch {
+	case draw < t.cfg.ErrorRate:
+		return delay, faultError, errorStatuses[t.rng.IntN(len(errorStatuses))]
+	case draw < t.cfg.ErrorRate+t.cfg.TimeoutRate:
+		return delay, faultTimeout, 0
+	default:
+		return delay, faultNone, 0
+	}
+}
+
+// errorResponse builds a synthetic server error in the {"error": "..."} shape of the MCP server.
+func errorResponse(req *http.Request, status int) *http.Response {
+	body := fmt.Sprintf(`{"error":"chaos: injected %d %s"}`, status, http.StatusText(status))
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// closeBody closes the request body of a request that is not passed on, as RoundTrip must.
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+}
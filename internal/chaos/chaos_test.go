@@ -0,0 +1,139 @@
+package chaos
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/config"
+)
+
+// countingServer answers 200 OK and counts the requests that reached it.
+func countingServer(t *testing.T) (*httptest.Server, *int) {
+	t.Helper()
+	var hits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, &hits
+}
+
+func get(t *testing.T, client *http.Client, ctx context.Context, url string) (*http.Response, error) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	if resp != nil {
+		t.Cleanup(func() { resp.Body.Close() })
+	}
+	return resp, err
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.ChaosConfig
+		wantErr bool
+	}{
+		{name: "zero value", cfg: config.ChaosConfig{}},
+		{name: "full", cfg: config.ChaosConfig{Enabled: true, Latency: time.Second, Jitter: time.Second, ErrorRate: 0.5, TimeoutRate: 0.5, Timeout: time.Second}},
+		{name: "error rate above one", cfg: config.ChaosConfig{ErrorRate: 1.1}, wantErr: true},
+		{name: "negative timeout rate", cfg: config.ChaosConfig{TimeoutRate: -0.1}, wantErr: true},
+		{name: "rates above one together", cfg: config.ChaosConfig{ErrorRate: 0.6, TimeoutRate: 0.6}, wantErr: true},
+		{name: "negative latency", cfg: config.ChaosConfig{Latency: -time.Second}, wantErr: true},
+		{name: "negative timeout", cfg: config.ChaosConfig{Timeout: -time.Second}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.cfg)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidConfig)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestWrap_Disabled(t *testing.T) {
+	next := &http.Transport{}
+	wrapped, err := Wrap(next, config.ChaosConfig{ErrorRate: 1}, "mcp")
+	require.NoError(t, err)
+	assert.Same(t, next, wrapped, "a disabled configuration leaves the transport unchanged")
+
+	_, err = Wrap(next, config.ChaosConfig{Enabled: true, ErrorRate: 2}, "mcp")
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
+
+func TestTransport_ServerError(t *testing.T) {
+	server, hits := countingServer(t)
+	transport, err := Wrap(nil, config.ChaosConfig{Enabled: true, ErrorRate: 1}, "mcp")
+	require.NoError(t, err)
+
+	resp, err := get(t, &http.Client{Transport: transport}, context.Background(), server.URL)
+	require.NoError(t, err)
+	assert.Contains(t, errorStatuses, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"error":"chaos: injected`)
+	assert.Zero(t, *hits, "injected errors never reach the server")
+}
+
+func TestTransport_Timeout(t *testing.T) {
+	server, hits := countingServer(t)
+	transport, err := Wrap(nil, config.ChaosConfig{Enabled: true, TimeoutRate: 1, Timeout: 10 * time.Millisecond}, "llm")
+	require.NoError(t, err)
+	client := &http.Client{Transport: transport}
+
+	_, err = get(t, client, context.Background(), server.URL)
+	assert.ErrorIs(t, err, ErrInjectedTimeout)
+
+	// A request deadline shorter than the injected hang ends the request first.
+	transport, err = Wrap(nil, config.ChaosConfig{Enabled: true, TimeoutRate: 1}, "llm")
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = get(t, &http.Client{Transport: transport}, ctx, server.URL)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Zero(t, *hits)
+}
+
+func TestTransport_Latency(t *testing.T) {
+	server, hits := countingServer(t)
+	transport, err := Wrap(nil, config.ChaosConfig{Enabled: true, Latency: 30 * time.Millisecond}, "mcp")
+	require.NoError(t, err)
+
+	start := time.Now()
+	resp, err := get(t, &http.Client{Transport: transport}, context.Background(), server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+	assert.Equal(t, 1, *hits)
+}
+
+func TestTransport_SeedIsReproducible(t *testing.T) {
+	server, _ := countingServer(t)
+	statuses := func() []int {
+		transport, err := Wrap(nil, config.ChaosConfig{Enabled: true, ErrorRate: 0.5, Seed: 7}, "mcp")
+		require.NoError(t, err)
+		client := &http.Client{Transport: transport}
+		var codes []int
+		for i := 0; i < 20; i++ {
+			resp, err := get(t, client, context.Background(), server.URL)
+			require.NoError(t, err)
+			codes = append(codes, resp.StatusCode)
+		}
+		return codes
+	}
+	first := statuses()
+	assert.Equal(t, first, statuses())
+	assert.Contains(t, first, http.StatusOK)
+}
@@ -0,0 +1,11 @@
+package chaos
+
+import "errors"
+
+// Sentinel errors for fault injection.
+
+// ErrInvalidConfig indicates a chaos configuration with out-of-range rates or negative durations.
+var ErrInvalidConfig = errors.New("invalid chaos configuration")
+
+// ErrInjectedTimeout is returned for a request that was made to time out on purpose.
+var ErrInjectedTimeout = errors.New("chaos: injected timeout")
@@ -0,0 +1,101 @@
+// Package clierr defines the error type commands return for failures the user can act on.
+// Besides the underlying error it carries a stable code, a message explaining the failure
+// in the user's language and a hint on how to fix it, which the root command renders in
+// one place instead of every command printing them on its own.
+package clierr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Code identifies a kind of failure. Codes are stable across releases and languages, so
+// scripts can rely on them (tix --ci reports them in its JSON errors).
+type Code string
+
+// Codes of the failures reported by the commands.
+const (
+	CodeConfig           Code = "config"             // A configuration file could not be loaded or is invalid
+	CodeInvalidInput     Code = "invalid_input"      // A flag, argument or setting has an invalid value
+	CodeLLMNotConfigured Code = "llm_not_configured" // No LLM client could be set up
+	CodeAPIKeyMissing    Code = "api_key_missing"    // No LLM API key is stored or set
+	CodeLLMAPI           Code = "llm_api"            // The LLM API could not be reached or failed
+	CodeLLMResponse      Code = "llm_response"       // The response of the LLM could not be used
+	CodeLLM              Code = "llm"                // Any other failure of the LLM step
+	CodeProjectMapping   Code = "project_mapping"    // The suggested project matches no project key
+	CodeGuardrails       Code = "guardrails_blocked" // A guardrail profile blocked the generated ticket
+	CodeMCPNotConfigured Code = "mcp_not_configured" // No MCP client could be set up
+	CodeMCPConnect       Code = "mcp_connect"        // The MCP server could not be reached
+	CodeMCPServer        Code = "mcp_server"         // The MCP server returned an error
+	CodeMCPResponse      Code = "mcp_response"       // The response of the MCP server could not be decoded
+	CodeMCP              Code = "mcp"                // Any other failure talking to the MCP server
+)
+
+// Error is a failure with the message and hint to show the user. Its Error method returns
+// the text of the wrapped error, so wrapping an error in an *Error changes neither its text
+// nor what errors.Is matches; Message and Hint are only shown by Render.
+type Error struct {
+	Code    Code
+	Message string // What went wrong, in words for the user; empty if the wrapped error says it all
+	Hint    string // How to fix it; may be empty
+	Wrapped error
+}
+
+// New returns an *Error wrapping err.
+func New(code Code, message, hint string, err error) *Error {
+	return &Error{Code: code, Message: message, Hint: hint, Wrapped: err}
+}
+
+// Error returns the text of the wrapped error, or the message if there is none.
+func (e *Error) Error() string {
+	if e.Wrapped == nil {
+		return e.Message
+	}
+	return e.Wrapped.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *Error) Unwrap() error {
+	return e.Wrapped
+}
+
+// As returns the first *Error in the chain of err, or nil if there is none.
+func As(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return nil
+}
+
+// CodeOf returns the code of the first *Error in the chain of err, or "" if there is none.
+func CodeOf(err error) Code {
+	if e := As(err); e != nil {
+		return e.Code
+	}
+	return ""
+}
+
+// HintOf returns the hint of the first *Error in the chain of err, or "".
+func HintOf(err error) string {
+	if e := As(err); e != nil {
+		return e.Hint
+	}
+	return ""
+}
+
+// Render writes the message and the hint of the first *Error in the chain of err to w, one
+// per line. It writes nothing for other errors.
+func Render(w io.Writer, err error) {
+	e := As(err)
+	if e == nil {
+		return
+	}
+	if e.Message != "" {
+		fmt.Fprintln(w, e.Message)
+	}
+	if e.Hint != "" {
+		fmt.Fprintln(w, e.Hint)
+	}
+}
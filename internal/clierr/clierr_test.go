@@ -0,0 +1,39 @@
+package clierr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errTest = errors.New("no JQL query provided")
+
+func TestError(t *testing.T) {
+	err := fmt.Errorf("search failed: %w", New(CodeInvalidInput, "Error: No JQL query provided.", "Pass --jql.", errTest))
+	assert.EqualError(t, err, "search failed: no JQL query provided", "the text of the wrapped error")
+	assert.ErrorIs(t, err, errTest)
+	assert.Equal(t, CodeInvalidInput, CodeOf(err))
+	assert.Equal(t, "Pass --jql.", HintOf(err))
+
+	assert.EqualError(t, New(CodeConfig, "Invalid config.", "", nil), "Invalid config.", "the message without a wrapped error")
+	assert.Nil(t, As(errTest))
+	assert.Empty(t, CodeOf(errTest))
+	assert.Empty(t, HintOf(nil))
+}
+
+func TestRender(t *testing.T) {
+	var out bytes.Buffer
+	Render(&out, fmt.Errorf("wrapped: %w", New(CodeMCPConnect, "Error connecting to the MCP server.", "Is it running?", errTest)))
+	assert.Equal(t, "Error connecting to the MCP server.\nIs it running?\n", out.String())
+
+	out.Reset()
+	Render(&out, New(CodeInvalidInput, "", "Use a date such as tomorrow.", errTest))
+	assert.Equal(t, "Use a date such as tomorrow.\n", out.String())
+
+	out.Reset()
+	Render(&out, errTest)
+	assert.Empty(t, out.String(), "nothing for other errors")
+}
@@ -0,0 +1,88 @@
+// Package coalesce merges identical calls that are in flight at the same time into one, so
+// that several consumers polling the same query in tix serve cost the server a single
+// request. Unlike a cache, nothing is kept once a call returns.
+package coalesce
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// call is a call in flight and the callers waiting for it.
+type call[V any] struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	waiters int // Callers still waiting; the call is cancelled when none are left
+	value   V
+	err     error
+}
+
+// Group merges the calls with the same key that are in flight at the same time. The zero
+// value is ready to use; a Group must not be copied after first use.
+type Group[V any] struct {
+	mu    sync.Mutex
+	calls map[string]*call[V]
+}
+
+// Do calls fn and returns its result, unless a call with the same key is in flight; then it
+// waits for that call and returns its result, which the callers share and must not modify.
+// shared reports whether the result went to more than one caller.
+//
+// fn runs with a context that keeps the values but not the cancellation of ctx: a caller
+// giving up returns ctx.Err() at once without failing the others, and the call is only
+// cancelled when all its callers gave up.
+func (g *Group[V]) Do(ctx context.Context, key string, fn func(ctx context.Context) (V, error)) (value V, shared bool, err error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*call[V])
+	}
+	c, inFlight := g.calls[key]
+	if inFlight {
+		c.waiters++
+	} else {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		c = &call[V]{done: make(chan struct{}), cancel: cancel, waiters: 1}
+		g.calls[key] = c
+		go g.run(callCtx, key, c, fn)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		g.mu.Lock()
+		shared = c.waiters > 1
+		g.mu.Unlock()
+		return c.value, shared || inFlight, c.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		c.waiters--
+		if c.waiters == 0 {
+			// Callers arriving now start a new call rather than join the cancelled one
+			if g.calls[key] == c {
+				delete(g.calls, key)
+			}
+			c.cancel()
+		}
+		g.mu.Unlock()
+		var zero V
+		return zero, false, ctx.Err()
+	}
+}
+
+// run calls fn for c and hands its result to the waiting callers.
+func (g *Group[V]) run(ctx context.Context, key string, c *call[V], fn func(ctx context.Context) (V, error)) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.err = fmt.Errorf("%w: %v", ErrPanicked, r)
+		}
+		g.mu.Lock()
+		if g.calls[key] == c { // Unless abandoned and replaced by a new call
+			delete(g.calls, key)
+		}
+		g.mu.Unlock()
+		c.cancel()
+		close(c.done)
+	}()
+	c.value, c.err = fn(ctx)
+}
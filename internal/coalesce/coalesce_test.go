@@ -0,0 +1,191 @@
+package coalesce
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroup_Do(t *testing.T) {
+	var g Group[int]
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fn := func(ctx context.Context) (int, error) {
+		calls.Add(1)
+		<-release
+		return 42, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]int, 5)
+	shared := make([]bool, 5)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var err error
+			results[i], shared[i], err = g.Do(context.Background(), "project = BE", fn)
+			assert.NoError(t, err)
+		}()
+	}
+	require.Eventually(t, func() bool {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		c := g.calls["project = BE"]
+		return c != nil && c.waiters == 5
+	}, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, []int{42, 42, 42, 42, 42}, results)
+	assert.Equal(t, []bool{true, true, true, true, true}, shared)
+
+	// Nothing is kept once the call returned
+	value, isShared, err := g.Do(context.Background(), "project = BE", func(ctx context.Context) (int, error) { return 7, nil })
+	require.NoError(t, err)
+	assert.Equal(t, 7, value)
+	assert.False(t, isShared)
+}
+
+func TestGroup_DoDifferentKeys(t *testing.T) {
+	var g Group[string]
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	for _, key := range []string{"a", "b"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, _, err := g.Do(context.Background(), key, func(ctx context.Context) (string, error) {
+				<-release
+				return key, nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, key, value)
+		}()
+	}
+	require.Eventually(t, func() bool {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		return len(g.calls) == 2
+	}, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+}
+
+func TestGroup_DoCancelled(t *testing.T) {
+	var g Group[int]
+	started := make(chan struct{})
+	callCancelled := make(chan struct{})
+	fn := func(ctx context.Context) (int, error) {
+		close(started)
+		<-ctx.Done()
+		close(callCancelled)
+		return 0, ctx.Err()
+	}
+
+	first, cancelFirst := context.WithCancel(context.Background())
+	second, cancelSecond := context.WithCancel(context.Background())
+	errs := make(chan error, 2)
+	go func() { _, _, err := g.Do(first, "key", fn); errs <- err }()
+	<-started
+	go func() { _, _, err := g.Do(second, "key", fn); errs <- err }()
+	require.Eventually(t, func() bool {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		return g.calls["key"].waiters == 2
+	}, time.Second, time.Millisecond)
+
+	cancelFirst()
+	assert.ErrorIs(t, <-errs, context.Canceled)
+	select {
+	case <-callCancelled:
+		t.Fatal("the call is cancelled while a caller still waits for it")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	cancelSecond()
+	assert.ErrorIs(t, <-errs, context.Canceled)
+	select {
+	case <-callCancelled:
+	case <-time.After(time.Second):
+		t.Fatal("the call is not cancelled once all callers gave up")
+	}
+}
+
+func TestGroup_DoAfterAbandoned(t *testing.T) {
+	var g Group[int]
+	abandoned := make(chan struct{})
+	release := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		_, _, err := g.Do(ctx, "key", func(ctx context.Context) (int, error) {
+			close(abandoned)
+			<-release // Slow to notice the cancellation
+			return 1, ctx.Err()
+		})
+		errs <- err
+	}()
+	<-abandoned
+	g.mu.Lock()
+	old := g.calls["key"]
+	g.mu.Unlock()
+	cancel()
+	assert.ErrorIs(t, <-errs, context.Canceled)
+
+	// A caller arriving after all others gave up starts a new call instead of joining the
+	// cancelled one
+	started := make(chan struct{})
+	finish := make(chan struct{})
+	results := make(chan int, 1)
+	go func() {
+		value, _, err := g.Do(context.Background(), "key", func(ctx context.Context) (int, error) {
+			close(started)
+			<-finish
+			return 2, nil
+		})
+		assert.NoError(t, err)
+		results <- value
+	}()
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("the caller joined the abandoned call")
+	}
+
+	// The abandoned call finishing does not remove the new one, which others still join
+	close(release)
+	<-old.done
+	go func() {
+		value, shared, err := g.Do(context.Background(), "key", func(ctx context.Context) (int, error) { return 3, nil })
+		assert.NoError(t, err)
+		assert.True(t, shared)
+		results <- value
+	}()
+	require.Eventually(t, func() bool {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		return g.calls["key"].waiters == 2
+	}, time.Second, time.Millisecond)
+	close(finish)
+	assert.Equal(t, 2, <-results)
+	assert.Equal(t, 2, <-results)
+}
+
+func TestGroup_DoPanic(t *testing.T) {
+	var g Group[int]
+	_, _, err := g.Do(context.Background(), "key", func(ctx context.Context) (int, error) { panic("boom") })
+	assert.ErrorIs(t, err, ErrPanicked)
+	assert.ErrorContains(t, err, "boom")
+
+	wantErr := errors.New("search failed")
+	_, _, err = g.Do(context.Background(), "key", func(ctx context.Context) (int, error) { return 0, wantErr })
+	assert.ErrorIs(t, err, wantErr)
+}
@@ -0,0 +1,8 @@
+package coalesce
+
+import "errors"
+
+// Sentinel errors for coalesced calls.
+
+// ErrPanicked is returned to the callers of a call whose function panicked.
+var ErrPanicked = errors.New("coalesced call panicked")
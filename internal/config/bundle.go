@@ -0,0 +1,88 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// Bundle is a configuration shipped as one YAML document: a team configuration installed
+// with `tix config init --from-url` or a project-local .ticketron.yaml.
+type Bundle struct {
+	Config       map[string]any `yaml:"config,omitempty"` // Keys of config.yaml
+	Links        LinksConfig    `yaml:"links,omitempty"`
+	SystemPrompt string         `yaml:"system_prompt,omitempty"`
+	Context      string         `yaml:"context,omitempty"`
+}
+
+// ParseBundle parses a bundle. Unknown keys, for example settings of a newer tix release,
+// are logged and ignored.
+func ParseBundle(data []byte) (*Bundle, error) {
+	return parseBundle("bundle", data)
+}
+
+// parseBundle parses the bundle read from file, named in warnings about unknown keys.
+func parseBundle(file string, data []byte) (*Bundle, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrBundleParse, err)
+	}
+	var bundle Bundle
+	if len(doc.Content) == 0 {
+		return &bundle, nil
+	}
+	if err := doc.Decode(&bundle); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrBundleParse, err)
+	}
+
+	root := doc.Content[0]
+	unknown := findUnknownKeys(file, root, reflect.TypeOf(Bundle{}), "yaml")
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "config" {
+			unknown = append(unknown, findUnknownKeys(file, root.Content[i+1], reflect.TypeOf(AppConfig{}), "mapstructure")...)
+		}
+	}
+	_ = reportUnknownKeys(unknown, false) // Only warns when not strict
+	return &bundle, nil
+}
+
+// configMapViper returns a viper instance holding the config.yaml settings in settings, or
+// nil if there are none.
+func configMapViper(settings map[string]any) (*viper.Viper, error) {
+	if len(settings) == 0 {
+		return nil, nil
+	}
+	data, err := yaml.Marshal(settings)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrBundleParse, err)
+	}
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrBundleParse, err)
+	}
+	return v, nil
+}
+
+// mergeProjects returns the preferred projects followed by the other projects whose name
+// no preferred project uses.
+func mergeProjects(preferred, others []ProjectLink) []ProjectLink {
+	merged := preferred
+	for _, project := range others {
+		overridden := false
+		for _, own := range preferred {
+			if strings.EqualFold(own.Name, project.Name) {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			merged = append(merged, project)
+		}
+	}
+	return merged
+}
@@ -5,7 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
+	"reflect"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/zalando/go-keyring"
@@ -23,6 +24,10 @@ const (
 	DefaultPromptFileName = "system_prompt.txt"
 	// DefaultContextFileName is the standard name for the context file.
 	DefaultContextFileName = "context.md"
+	// DefaultWebhooksFileName is the standard name for the webhook mappings file used by `tix serve`.
+	DefaultWebhooksFileName = "webhooks.yaml"
+	// DefaultSchedulesFileName is the standard name for the recurring ticket definitions used by `tix schedule`.
+	DefaultSchedulesFileName = "schedules.yaml"
 	// DefaultConfigDirName is the standard name for the configuration directory within the user's home directory.
 	DefaultConfigDirName = ".ticketron"
 	// ConfigDirEnvVar is the environment variable used to override the default configuration directory path.
@@ -92,42 +97,304 @@ func EnsureConfigDir(baseDir string) (string, error) {
 type OpenAIConfig struct {
 	ModelName string `mapstructure:"model_name"`
 	BaseURL   string `mapstructure:"base_url"` // Optional custom base URL
+	// Generation parameters; unset values use the provider defaults. They also apply to
+	// the fallback models.
+	Temperature *float32 `mapstructure:"temperature"` // 0-2
+	TopP        *float32 `mapstructure:"top_p"`       // 0-1
+	MaxTokens   int      `mapstructure:"max_tokens"`  // Upper bound on generated tokens
+	Seed        *int     `mapstructure:"seed"`        // Best-effort reproducible output
 	// APIKey is handled separately via keyring/env var (GetAPIKey) for now
 }
 
 // LLMConfig holds configuration specific to the Language Model provider selection
 // and common settings. Provider-specific settings are nested.
 type LLMConfig struct {
-	Provider string       `mapstructure:"provider"` // e.g., "openai", "anthropic", "ollama"
+	Provider string       `mapstructure:"provider"` // "openai", or "mock" for the deterministic in-process generator
 	OpenAI   OpenAIConfig `mapstructure:"openai"`
 	// Add other providers like AnthropicConfig, OllamaConfig here later
+
+	// Fallbacks are tried in order when the primary provider errors or times out.
+	Fallbacks []LLMFallbackConfig `mapstructure:"fallbacks"`
+	// Timeout bounds each provider attempt when fallbacks are configured (e.g. "30s"). Zero means no limit.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// HTTP configures the transport used by all LLM provider clients (proxy, CA bundle).
+	HTTP HTTPConfig `mapstructure:"http"`
+	// Chaos injects faults into LLM requests; for development and testing only.
+	Chaos ChaosConfig `mapstructure:"chaos"`
+	// RateLimits limits the requests sent to each provider, keyed by provider name (e.g. openai).
+	RateLimits map[string]RateLimitConfig `mapstructure:"rate_limits"`
+	// CircuitBreaker stops sending requests to a provider that keeps failing; each provider
+	// has its own breaker.
+	CircuitBreaker CircuitBreakerConfig `mapstructure:"circuit_breaker"`
+	// LenientParsing accepts single-quoted keys and strings and trailing commas in the
+	// JSON replies of the model.
+	LenientParsing bool `mapstructure:"lenient_parsing"`
+	// RepairAttempts is how often a reply that cannot be parsed is sent back to the model
+	// with a request for valid JSON. Zero disables the repair loop.
+	RepairAttempts int `mapstructure:"repair_attempts"`
+	// ResponseSchema is the path of a JSON Schema file replacing the built-in schema the
+	// model's replies are validated against (lengths, issue types, required keys).
+	ResponseSchema string `mapstructure:"response_schema"`
+	// PostProcessing adjusts the style of generated tickets before they are created.
+	PostProcessing PostProcessingConfig `mapstructure:"post_processing"`
+	// ProposeDueDate sets the due date of issues whose request mentions a deadline to
+	// the one the model extracts, unless a due date is given explicitly.
+	ProposeDueDate bool `mapstructure:"propose_due_date"`
+}
+
+// PostProcessingConfig controls the clean-up of generated tickets. Summaries always lose
+// a trailing period and description headings are always normalized.
+type PostProcessingConfig struct {
+	SummaryMaxLength  int  `mapstructure:"summary_max_length"` // Characters; 0 for Jira's limit of 255
+	ImperativeSummary bool `mapstructure:"imperative_summary"` // Rewrite "Adds X" or "Added X" to "Add X"
+}
+
+// HTTPConfig holds outbound HTTP transport settings. Empty fields fall back to the
+// standard environment variables (HTTP_PROXY, HTTPS_PROXY, NO_PROXY) and system roots.
+type HTTPConfig struct {
+	HTTPProxy  string `mapstructure:"http_proxy"`  // Proxy URL for http:// requests
+	HTTPSProxy string `mapstructure:"https_proxy"` // Proxy URL for https:// requests
+	NoProxy    string `mapstructure:"no_proxy"`    // Comma-separated hosts/domains that bypass the proxy
+	CAFile     string `mapstructure:"ca_file"`     // PEM bundle added to the system root CAs
+}
+
+// IsZero reports whether no HTTP settings are configured.
+func (c HTTPConfig) IsZero() bool {
+	return c == HTTPConfig{}
+}
+
+// LLMFallbackConfig describes one entry of the LLM fallback chain.
+type LLMFallbackConfig struct {
+	Provider string `mapstructure:"provider"`           // e.g., "openai"
+	Model    string `mapstructure:"model"`              // Model name for the provider
+	BaseURL  string `mapstructure:"base_url,omitempty"` // Optional custom base URL
+}
+
+// RedactionConfig controls scrubbing of sensitive data from user input and context
+// before they are sent to the LLM.
+type RedactionConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Builtins selects built-in rules by name (email, api_key, aws_key, github_token,
+	// bearer_token, ipv4). When empty and redaction is enabled, all built-ins apply.
+	Builtins []string           `mapstructure:"builtins"`
+	Patterns []RedactionPattern `mapstructure:"patterns"` // Additional custom rules
+}
+
+// RedactionPattern is a custom redaction rule.
+type RedactionPattern struct {
+	Name        string `mapstructure:"name"`
+	Pattern     string `mapstructure:"pattern"`     // Go regular expression
+	Replacement string `mapstructure:"replacement"` // Defaults to [REDACTED:<name>]
+}
+
+// GuardrailsConfig scans generated summaries and descriptions for banned content, such as
+// customer names or secrets, before issues are created. Profiles are selected by Profile
+// or, for the issues of one project, by the guardrails key of its links.yaml entry.
+type GuardrailsConfig struct {
+	Profile  string                      `mapstructure:"profile"` // Default profile; empty applies none
+	Profiles map[string]GuardrailProfile `mapstructure:"profiles"`
+}
+
+// AutoLinkConfig controls what happens to existing issue keys, such as PROJ-88, mentioned
+// in the request a ticket is created from. Keys that do not resolve to an issue are ignored.
+type AutoLinkConfig struct {
+	Description bool   `mapstructure:"description"` // List the issues in a "Related issues" section of the description
+	IssueLinks  bool   `mapstructure:"issue_links"` // Link the issues to the new issue once it is created
+	LinkType    string `mapstructure:"link_type"`   // Jira link type of issue_links; "Relates" if empty
+}
+
+// HooksConfig lists commands run around issue creation. Each command is split into words
+// like a shell would, without expanding anything, and every word is a text/template
+// rendered with the issue (e.g. "./notify.sh {{.Key}}"). The command is run directly, not
+// by a shell, so issue content cannot inject further commands.
+type HooksConfig struct {
+	PreCreate  []string      `mapstructure:"pre_create"`  // Run before an issue is created; a failure aborts the creation
+	PostCreate []string      `mapstructure:"post_create"` // Run after an issue is created; failures are only reported
+	Timeout    time.Duration `mapstructure:"timeout"`     // Per command; 30s if zero
+}
+
+// GuardrailProfile is a named set of banned patterns and what to do when one matches.
+type GuardrailProfile struct {
+	Action   string             `mapstructure:"action"`   // block (default) or redact
+	Terms    []string           `mapstructure:"terms"`    // Words and names matched case-insensitively, e.g. customer names
+	Builtins []string           `mapstructure:"builtins"` // Built-in redaction rules (email, api_key, ...); none if empty
+	Patterns []RedactionPattern `mapstructure:"patterns"` // Additional custom rules
+}
+
+// MCPConfig holds connection settings for the MCP server beyond its URL.
+type MCPConfig struct {
+	TLS TLSConfig `mapstructure:"tls"`
+	// Chaos injects faults into MCP requests; for development and testing only.
+	Chaos ChaosConfig `mapstructure:"chaos"`
+	// RateLimit limits the requests sent to the MCP server.
+	RateLimit RateLimitConfig `mapstructure:"rate_limit"`
+	// CircuitBreaker stops sending requests to the MCP server while it keeps failing.
+	CircuitBreaker CircuitBreakerConfig `mapstructure:"circuit_breaker"`
+}
+
+// TLSConfig holds TLS settings for servers with private CAs or mutual TLS.
+type TLSConfig struct {
+	CAFile             string `mapstructure:"ca_file"`              // PEM bundle added to the system root CAs
+	InsecureSkipVerify bool   `mapstructure:"insecure_skip_verify"` // Disables certificate verification (testing only)
+	CertFile           string `mapstructure:"cert_file"`            // Client certificate for mTLS (PEM)
+	KeyFile            string `mapstructure:"key_file"`             // Client private key for mTLS (PEM)
+}
+
+// IsZero reports whether no TLS settings are configured.
+func (c TLSConfig) IsZero() bool {
+	return c == TLSConfig{}
+}
+
+// ChaosConfig injects latency, timeouts and server errors into the requests of a client,
+// to check how tix behaves against slow or failing services. Nothing is injected unless
+// Enabled is set.
+type ChaosConfig struct {
+	Enabled     bool          `mapstructure:"enabled"`
+	Latency     time.Duration `mapstructure:"latency"`      // Added to every request
+	Jitter      time.Duration `mapstructure:"jitter"`       // Random extra delay of up to this duration
+	ErrorRate   float64       `mapstructure:"error_rate"`   // Fraction of requests answered with a 5xx status (0-1)
+	TimeoutRate float64       `mapstructure:"timeout_rate"` // Fraction of requests that hang until they time out (0-1)
+	Timeout     time.Duration `mapstructure:"timeout"`      // How long an injected timeout hangs if the request has no earlier deadline; 30s if unset
+	Seed        uint64        `mapstructure:"seed"`         // Makes the injected faults reproducible; random if unset
+}
+
+// RateLimitConfig limits the requests a client sends, so that bulk operations and tix serve
+// stay below the rate limits of the server. All requests of the process to the same server
+// share the limit. A zero RequestsPerSecond means no limit.
+type RateLimitConfig struct {
+	RequestsPerSecond float64 `mapstructure:"requests_per_second"`
+	Burst             int     `mapstructure:"burst"` // Requests that may be sent at once; the rate rounded up (at least 1) if unset
+}
+
+// CircuitBreakerConfig opens the circuit of a client after FailureThreshold consecutive
+// failed requests, so that requests fail at once instead of waiting for a server that is
+// down. After Cooldown a single probe request is let through; its success closes the
+// circuit again. A zero FailureThreshold disables the breaker.
+type CircuitBreakerConfig struct {
+	FailureThreshold int           `mapstructure:"failure_threshold"`
+	Cooldown         time.Duration `mapstructure:"cooldown"` // How long the circuit stays open before a probe; 30s if unset
+}
+
+// SecretsConfig selects where credentials such as the LLM API key are stored.
+type SecretsConfig struct {
+	Backend string `mapstructure:"backend"` // auto (default), keyring, wincred, file or env
+	File    string `mapstructure:"file"`    // Credentials file for the file backend; defaults to credentials.yaml in the config dir
+	// APIKey references the LLM API key in an external secret manager, e.g.
+	// vault://secret/ticketron#openai_api_key; it overrides Backend.
+	APIKey string `mapstructure:"api_key"`
+}
+
+// UIConfig holds settings for the command-line interface itself.
+type UIConfig struct {
+	// Language of error hints and confirmation prompts (en, pl). Empty selects it from
+	// LC_ALL, LC_MESSAGES or LANG.
+	Language string `mapstructure:"language"`
+	// BrowseURL is the base of links to issues, e.g. https://acme.atlassian.net. Empty
+	// derives it from the REST API URLs returned by the MCP server.
+	BrowseURL string `mapstructure:"browse_url"`
+	// InlineIssueSummaries adds the summaries of mentioned issues to their links in
+	// 'tix view', at the cost of one request per issue not cached yet.
+	InlineIssueSummaries bool `mapstructure:"inline_issue_summaries"`
 }
 
 // AppConfig holds the overall application configuration.
 type AppConfig struct {
-	MCPServerURL string    `mapstructure:"mcp_server_url"`
-	LLM          LLMConfig `mapstructure:"llm"` // Embed the new LLMConfig
+	Version      int              `mapstructure:"version"` // Schema version; see CurrentConfigVersion
+	MCPServerURL string           `mapstructure:"mcp_server_url"`
+	MCP          MCPConfig        `mapstructure:"mcp"`
+	LLM          LLMConfig        `mapstructure:"llm"` // Embed the new LLMConfig
+	Redaction    RedactionConfig  `mapstructure:"redaction"`
+	Guardrails   GuardrailsConfig `mapstructure:"guardrails"`
+	AutoLink     AutoLinkConfig   `mapstructure:"auto_link"`
+	Secrets      SecretsConfig    `mapstructure:"secrets"`
+	Timezone     string           `mapstructure:"timezone"` // IANA name used to resolve relative dates; empty for the system zone
+	Hooks        HooksConfig      `mapstructure:"hooks"`
+	// DescriptionFormat is how issue descriptions are sent: text (default), wiki or adf.
+	DescriptionFormat string `mapstructure:"description_format"`
+	// DefaultProject is the project (key or links.yaml name) of new issues whose suggested
+	// project matches no links.yaml entry.
+	DefaultProject string `mapstructure:"default_project"`
+	// UI holds settings of the command line interface itself, such as its language.
+	UI UIConfig `mapstructure:"ui"`
+	// Strict rejects unknown keys in config.yaml and links.yaml instead of warning about them.
+	Strict bool `mapstructure:"strict"`
+	// Includes lists YAML files, relative to the including file, whose settings are merged
+	// below those of the file; later files take precedence over earlier ones.
+	Includes []string `mapstructure:"includes"`
+}
+
+// Location returns the time zone configured by timezone, or the system's local zone if unset.
+func (c *AppConfig) Location() (*time.Location, error) {
+	if c.Timezone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTimezone, err)
+	}
+	return loc, nil
 }
 
 // LoadConfig loads the application configuration from the config file (e.g., ~/.ticketron/config.yaml or baseDir/config.yaml),
-// environment variables (TICKETRON_*), and sets defaults.
+// environment variables (TICKETRON_*, see EnvVars), and sets defaults.
 // If baseDir is empty, it uses the default ~/.ticketron.
 func LoadConfig(baseDir string) (*AppConfig, error) {
+	return LoadLayeredConfig(baseDir, nil)
+}
+
+// LoadLayeredConfig works like LoadConfig, with the settings of the project-local
+// configuration project, if not nil, over those of config.yaml.
+func LoadLayeredConfig(baseDir string, project *ProjectConfig) (*AppConfig, error) {
+	v, configPath, err := newConfigViper(baseDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := mergeProjectConfig(v, project); err != nil {
+		return nil, err
+	}
+
+	// Unmarshal the config into the struct
+	var cfg AppConfig
+	err = v.Unmarshal(&cfg)
+	if err != nil {
+		log.Error().Err(err).Str("path", configPath).Msg("Failed to unmarshal config file")
+		return nil, fmt.Errorf("%w: %w", ErrConfigParse, err) // Use sentinel error
+	}
+	log.Debug().Str("path", configPath).Interface("config", cfg).Msg("Unmarshalled config successfully")
+
+	return &cfg, nil
+}
+
+// newConfigViper returns a viper instance with the defaults, environment bindings and
+// config.yaml of the configuration directory (default or baseDir) loaded, along with the
+// path of config.yaml.
+func newConfigViper(baseDir string) (*viper.Viper, string, error) {
 	configDir, err := EnsureConfigDir(baseDir) // EnsureConfigDir now respects TICKETRON_CONFIG_DIR
 	if err != nil {
 		// Error already logged in EnsureConfigDir
-		return nil, fmt.Errorf("failed to ensure config directory: %w", err)
+		return nil, "", fmt.Errorf("failed to ensure config directory: %w", err)
 	}
 
 	v := viper.New()
 
 	// Set default values
-	v.SetDefault("mcp_server_url", "http://localhost:8080")
-	v.SetDefault("llm.provider", "openai")          // Default to openai
-	v.SetDefault("llm.openai.model_name", "gpt-4o") // Default OpenAI model
-	v.SetDefault("llm.openai.base_url", "")         // Default OpenAI base_url
+	for key, value := range configDefaults {
+		v.SetDefault(key, value)
+	}
 	// No default for API key - use GetAPIKey() for retrieval
 
+	// The team configuration, if installed, replaces the built-in defaults and is itself
+	// overridden by config.yaml and the environment.
+	team, err := teamConfigViper(configDir)
+	if err != nil {
+		return nil, "", err
+	}
+	if team != nil {
+		for _, key := range team.AllKeys() {
+			v.SetDefault(key, team.Get(key))
+		}
+	}
+
 	// Configure Viper to read the config file
 	configPath := filepath.Join(configDir, DefaultConfigFileName) // Define configPath here
 	v.SetConfigName("config")
@@ -135,10 +402,20 @@ func LoadConfig(baseDir string) (*AppConfig, error) {
 	v.AddConfigPath(configDir) // This now correctly points to the temp dir in tests
 	log.Debug().Str("path", configPath).Msg("Attempting to load config file")
 
-	// Configure environment variable overrides
-	v.SetEnvPrefix("TICKETRON")
-	v.AutomaticEnv()
-	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_")) // map nested keys like llm.model_name to TICKETRON_LLM_MODEL_NAME
+	// Configure environment variable overrides. AutomaticEnv only sees keys viper already
+	// knows about, so every key of AppConfig is bound explicitly.
+	for _, envVar := range configEnvVars() {
+		if err := v.BindEnv(envVar.Key, envVar.Name); err != nil {
+			return nil, "", fmt.Errorf("failed to bind %s: %w", envVar.Name, err)
+		}
+	}
+
+	// The default config.yaml written by `tix config init` only repeats the built-in
+	// defaults, so it does not override the team configuration.
+	if team != nil && isDefaultFile(configPath, defaultConfigYAML) {
+		log.Debug().Str("path", configPath).Msg("Config file is the default; using the team configuration")
+		return v, configPath, nil
+	}
 
 	// Attempt to read the config file
 	err = v.ReadInConfig()
@@ -149,22 +426,25 @@ func LoadConfig(baseDir string) (*AppConfig, error) {
 		} else {
 			// Config file was found but another error was produced
 			log.Error().Err(err).Str("path", configPath).Msg("Failed to read config file")
-			return nil, fmt.Errorf("%w: %w", ErrConfigRead, err) // Use sentinel error
+			return nil, "", fmt.Errorf("%w: %w", ErrConfigRead, err) // Use sentinel error
 		}
 	} else {
 		log.Debug().Str("path", configPath).Msg("Read config file successfully") // Log success only if ReadInConfig doesn't error
+		root, err := migrateLoadedConfig(v, configPath)
+		if err != nil {
+			log.Error().Err(err).Str("path", configPath).Msg("Failed to migrate config file")
+			return nil, "", err
+		}
+		unknown := findUnknownKeys(DefaultConfigFileName, root, reflect.TypeOf(AppConfig{}), "mapstructure")
+		if err := reportUnknownKeys(unknown, v.GetBool("strict")); err != nil {
+			return nil, "", err
+		}
+		if err := applyIncludes(v, configPath, root); err != nil {
+			log.Error().Err(err).Str("path", configPath).Msg("Failed to merge included config files")
+			return nil, "", err
+		}
 	}
-
-	// Unmarshal the config into the struct
-	var cfg AppConfig
-	err = v.Unmarshal(&cfg)
-	if err != nil {
-		log.Error().Err(err).Str("path", configPath).Msg("Failed to unmarshal config file")
-		return nil, fmt.Errorf("%w: %w", ErrConfigParse, err) // Use sentinel error
-	}
-	log.Debug().Str("path", configPath).Interface("config", cfg).Msg("Unmarshalled config successfully")
-
-	return &cfg, nil
+	return v, configPath, nil
 }
 
 // ProjectLink defines the structure for a single project mapping.
@@ -172,6 +452,7 @@ type ProjectLink struct {
 	Name             string `yaml:"name"`                         // User-friendly name/alias (case-insensitive match target)
 	Key              string `yaml:"key"`                          // The actual JIRA project key
 	DefaultIssueType string `yaml:"default_issue_type,omitempty"` // Optional default issue type
+	Guardrails       string `yaml:"guardrails,omitempty"`         // Optional guardrail profile replacing guardrails.profile
 }
 
 // LinksConfig holds the list of project links.
@@ -200,7 +481,7 @@ func LoadLinks(baseDir string) (LinksConfig, error) {
 		if os.IsNotExist(err) {
 			log.Warn().Str("path", linksPath).Msg("Links file not found, returning empty links config")
 			// File doesn't exist, which is acceptable. Return empty config.
-			return cfg, nil
+			return withTeamLinks(configDir, cfg, true)
 		}
 		// Other error reading the file
 		log.Error().Err(err).Str("path", linksPath).Msg("Failed to read links file")
@@ -209,11 +490,23 @@ func LoadLinks(baseDir string) (LinksConfig, error) {
 	log.Debug().Str("path", linksPath).Int("bytes", len(fileBytes)).Msg("Read links file successfully")
 
 	// File exists, attempt to parse it
-	err = yaml.Unmarshal(fileBytes, &cfg)
+	var doc yaml.Node
+	err = yaml.Unmarshal(fileBytes, &doc)
+	if err == nil {
+		err = doc.Decode(&cfg)
+	}
 	if err != nil {
 		log.Error().Err(err).Str("path", linksPath).Msg("Failed to parse links file")
 		return cfg, fmt.Errorf("%w: %w", ErrLinksParse, err) // Use sentinel error
 	}
+	if len(doc.Content) > 0 {
+		unknown := findUnknownKeys(DefaultLinksFileName, doc.Content[0], reflect.TypeOf(LinksConfig{}), "yaml")
+		if len(unknown) > 0 {
+			if err := reportUnknownKeys(unknown, strictModeEnabled(baseDir)); err != nil {
+				return LinksConfig{}, err
+			}
+		}
+	}
 	log.Debug().Str("path", linksPath).Interface("links", cfg).Msg("Parsed links file successfully")
 
 	// Ensure Projects slice is not nil if the file was empty or contained no projects
@@ -221,7 +514,8 @@ func LoadLinks(baseDir string) (LinksConfig, error) {
 		cfg.Projects = []ProjectLink{}
 	}
 
-	return cfg, nil
+	// The example projects written by `tix config init` are replaced by the team's
+	return withTeamLinks(configDir, cfg, string(fileBytes) == defaultLinksYAML)
 }
 
 // LoadSystemPrompt loads the system prompt text from the prompt file (e.g., ~/.ticketron/system_prompt.txt or baseDir/system_prompt.txt).
@@ -242,8 +536,8 @@ func LoadSystemPrompt(baseDir string) (string, error) {
 	if err != nil {
 		if os.IsNotExist(err) {
 			log.Warn().Str("path", promptPath).Msg("System prompt file not found, returning empty string")
-			// File doesn't exist, which is acceptable. Return empty string.
-			return "", nil
+			// File doesn't exist, which is acceptable. Return the team's or an empty string.
+			return withTeamText(configDir, "", defaultSystemPromptTXT, func(team *Bundle) string { return team.SystemPrompt })
 		}
 		// Other error reading the file
 		log.Error().Err(err).Str("path", promptPath).Msg("Failed to read system prompt file")
@@ -252,7 +546,7 @@ func LoadSystemPrompt(baseDir string) (string, error) {
 	log.Debug().Str("path", promptPath).Int("bytes", len(fileBytes)).Msg("Read system prompt file successfully")
 
 	// File exists and was read successfully
-	return string(fileBytes), nil
+	return withTeamText(configDir, string(fileBytes), defaultSystemPromptTXT, func(team *Bundle) string { return team.SystemPrompt })
 }
 
 // LoadContext loads the context text from the context file (e.g., ~/.ticketron/context.md or baseDir/context.md).
@@ -273,8 +567,8 @@ func LoadContext(baseDir string) (string, error) {
 	if err != nil {
 		if os.IsNotExist(err) {
 			log.Warn().Str("path", contextPath).Msg("Context file not found, returning empty string")
-			// File doesn't exist, which is acceptable. Return empty string.
-			return "", nil
+			// File doesn't exist, which is acceptable. Return the team's or an empty string.
+			return withTeamText(configDir, "", defaultContextMD, func(team *Bundle) string { return team.Context })
 		}
 		// Other error reading the file
 		log.Error().Err(err).Str("path", contextPath).Msg("Failed to read context file")
@@ -283,7 +577,7 @@ func LoadContext(baseDir string) (string, error) {
 	log.Debug().Str("path", contextPath).Int("bytes", len(fileBytes)).Msg("Read context file successfully")
 
 	// File exists and was read successfully
-	return string(fileBytes), nil
+	return withTeamText(configDir, string(fileBytes), defaultContextMD, func(team *Bundle) string { return team.Context })
 }
 
 // --- Default File Creation ---
@@ -291,9 +585,21 @@ func LoadContext(baseDir string) (string, error) {
 const defaultConfigYAML = `# User-specific configuration for the Ticketron CLI (tix)
 # Located at ~/.ticketron/config.yaml
 
+# Schema version of this file, upgraded by 'tix config migrate'.
+version: 1
+
 # URL for the Jira MCP server used for interacting with Jira.
 mcp_server_url: "http://localhost:8080" # Default, user should change if needed
 
+# Optional: TLS settings for MCP servers using a private CA or mutual TLS.
+# Run 'tix doctor' to validate them.
+# mcp:
+#   tls:
+#     ca_file: "/etc/ssl/certs/internal-ca.pem"
+#     cert_file: "~/.ticketron/client.crt"
+#     key_file: "~/.ticketron/client.key"
+#     insecure_skip_verify: false
+
 # Configuration for the Large Language Model (LLM) used by Ticketron.
 llm:
   # Specify the LLM provider to use ("openai", "anthropic", "ollama", etc.)
@@ -305,6 +611,38 @@ llm:
     model_name: "gpt-4o" # Example: gpt-4, gpt-4o, gpt-3.5-turbo
     # Optional: Specify a custom base URL for the OpenAI API (e.g., for proxies)
     # base_url: ""
+    # Optional: Generation parameters. Unset values use the provider defaults.
+    # temperature: 0.2
+    # top_p: 1.0
+    # max_tokens: 1000
+    # seed: 42
+
+  # Optional: Providers/models tried in order if the primary one errors or times out.
+  # fallbacks:
+  #   - provider: "openai"
+  #     model: "gpt-4o-mini"
+  # Optional: Per-attempt timeout used when fallbacks are configured.
+  # timeout: "30s"
+  # Optional: Egress proxy and custom CA bundle for LLM API calls.
+  # http:
+  #   https_proxy: "http://proxy.corp.example:3128"
+  #   no_proxy: "localhost,.corp.example"
+  #   ca_file: "/etc/ssl/certs/corp-ca.pem"
+  # Optional: Accept single-quoted keys and strings and trailing commas in the model's JSON replies.
+  # lenient_parsing: true
+  # Optional: Send replies that cannot be parsed back to the model up to this many times.
+  # repair_attempts: 2
+  # Optional: JSON Schema replacing the built-in validation of the model's replies,
+  # e.g. to allow your own issue types. See docs/usage.md.
+  # response_schema: "/path/to/response_schema.json"
+  # Optional: Style of generated tickets. Summaries are cut off at summary_max_length
+  # characters (default 255, Jira's limit) and can be put in the imperative mood.
+  # post_processing:
+  #   summary_max_length: 100
+  #   imperative_summary: true
+  # Optional: Let the LLM set the due date when the request mentions a deadline
+  # ("by next friday"). Dates are resolved in the time zone set by timezone below.
+  # propose_due_date: true
 
   # Example for Anthropic (add when implemented)
   # anthropic:
@@ -316,6 +654,81 @@ llm:
   #   model_name: "llama3"
   #   base_url: "http://localhost:11434" # Default Ollama URL
 
+# Optional: Redact sensitive data from your input and context.md before it is sent to the LLM.
+# redaction:
+#   enabled: true
+#   builtins: ["email", "api_key", "aws_key", "github_token", "bearer_token", "ipv4"] # Omit for all
+#   patterns:
+#     - name: "internal_host"
+#       pattern: '\b[a-z0-9-]+\.corp\.example\.com\b'
+#       replacement: "[INTERNAL_HOST]"
+
+# Optional: Check generated summaries and descriptions for banned content before issues are
+# created. 'block' rejects the ticket, 'redact' replaces the matches and warns. A project
+# in links.yaml can select another profile with 'guardrails: <profile>'.
+# guardrails:
+#   profile: "default"
+#   profiles:
+#     default:
+#       action: "block"
+#       terms: ["Acme Corp", "Globex"]
+#       builtins: ["api_key", "aws_key", "github_token"]
+#     internal:
+#       action: "redact"
+#       builtins: ["email"]
+
+# Optional: Where 'tix config set-key' stores the API key.
+# auto (default) uses the OS keyring and falls back to ~/.ticketron/credentials.yaml
+# when it is unavailable (e.g. on WSL); other options: keyring, wincred, file, env.
+# secrets:
+#   backend: "auto"
+#   # Or read the key from a secret manager: vault://PATH#FIELD, aws-sm://NAME[#JSON_KEY]
+#   # or op://VAULT/ITEM/FIELD, using the vault, aws or op CLI.
+#   api_key: "vault://secret/ticketron#openai_api_key"
+
+# Optional: Issue keys mentioned in your request (e.g. "related to PROJ-88") can be listed
+# in the description and linked to the new issue.
+# auto_link:
+#   description: true
+#   issue_links: true
+#   link_type: "Relates"
+
+# Optional: Commands run before and after an issue is created, e.g. to notify a chat or
+# update a local file. Arguments are templates of the issue: {{.Key}}, {{.URL}},
+# {{.Project}}, {{.Type}}, {{.Summary}}, {{.Priority}} and {{.Description}} ({{.Key}} and
+# {{.URL}} only after creation). Commands are not run by a shell. A failing pre_create
+# command aborts the creation.
+# hooks:
+#   post_create: ["./notify.sh {{.Key}} {{.URL}}"]
+#   timeout: "30s"
+
+# Optional: IANA time zone used to resolve relative dates in search filters
+# (e.g. --created-since "last monday"). Should match your Jira profile. Defaults to the system zone.
+# timezone: "Europe/Warsaw"
+
+# Optional: How issue descriptions, written in Markdown by the LLM, are sent to Jira:
+# text (default, unchanged), wiki (Jira wiki markup for Server/Data Center) or
+# adf (Atlassian Document Format for Jira Cloud).
+# description_format: "adf"
+
+# Optional: Project (key or name from links.yaml) used by 'tix create' when the project
+# suggested by the LLM matches no entry in links.yaml. Without it, such issues are rejected.
+# default_project: "PROJ"
+
+# Optional: Language of error hints and confirmation prompts: en or pl.
+# Defaults to the language of your locale (LANG), falling back to English.
+# ui:
+#   language: "pl"
+#   # Base of links to the issue keys mentioned in 'tix view' output; by default derived
+#   # from the URLs returned by the MCP server.
+#   browse_url: "https://acme.atlassian.net"
+#   # Also show the summaries of the mentioned issues.
+#   inline_issue_summaries: true
+
+# Optional: Reject unknown (e.g. misspelled) keys in config.yaml and links.yaml instead of
+# only warning about them. Also settable with TICKETRON_STRICT=true.
+# strict: true
+
 `
 
 const defaultLinksYAML = `# ~/.ticketron/links.yaml
@@ -325,6 +738,7 @@ projects:
   - name: "My Project Alias" # User-friendly name used for matching (case-insensitive)
     key: "PROJ"             # The actual JIRA project key
     default_issue_type: "Task" # Optional: Default issue type for this project
+    # guardrails: "internal"    # Optional: Guardrail profile from config.yaml for this project
   - name: "Backend Team"
     key: "BE"
   # Add more projects as needed
@@ -417,34 +831,7 @@ func writeFileIfNotExists(filePath string, content string, perm os.FileMode) err
 // within that directory if they do not already exist.
 // If baseDir is empty, it uses the default ~/.ticketron.
 func CreateDefaultConfigFiles(baseDir string) error {
-	configDir, err := EnsureConfigDir(baseDir)
-	if err != nil {
-		// Error already logged in EnsureConfigDir
-		return fmt.Errorf("failed to ensure config directory: %w", err)
-	}
-
-	filesToCreate := []struct {
-		name    string
-		content string
-		perm    os.FileMode
-	}{
-		{DefaultConfigFileName, defaultConfigYAML, 0600},
-		{DefaultLinksFileName, defaultLinksYAML, 0600},
-		{DefaultPromptFileName, defaultSystemPromptTXT, 0644},
-		{DefaultContextFileName, defaultContextMD, 0644},
-	}
-
-	for _, file := range filesToCreate {
-		filePath := filepath.Join(configDir, file.name)
-		log.Debug().Str("file", file.name).Msg("Ensuring default file") // Added log
-		err := writeFileIfNotExists(filePath, file.content, file.perm)
-		if err != nil {
-			// Error already includes file path and specific write/stat error
-			return err
-		}
-	}
-
-	return nil
+	return InitConfigFiles(baseDir, InitOptions{})
 }
 
 // --- API Key Handling ---
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -68,6 +69,55 @@ llm:
 		assert.Equal(t, "http://localhost:8081", cfg.MCPServerURL, "Should load MCP server URL from temp file")
 	})
 
+	t.Run("FallbackChain", func(t *testing.T) {
+		tempDir := t.TempDir()
+		yamlContent := `
+llm:
+  provider: "openai"
+  timeout: "15s"
+  fallbacks:
+    - provider: "openai"
+      model: "gpt-4o-mini"
+  http:
+    https_proxy: "http://proxy.corp.example:3128"
+    ca_file: "/etc/ssl/corp-ca.pem"
+`
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, "config.yaml"), []byte(yamlContent), 0644))
+
+		cfg, err := LoadConfig(tempDir)
+		require.NoError(t, err)
+		assert.Equal(t, 15*time.Second, cfg.LLM.Timeout)
+		assert.Equal(t, []LLMFallbackConfig{{Provider: "openai", Model: "gpt-4o-mini"}}, cfg.LLM.Fallbacks)
+		assert.Equal(t, HTTPConfig{HTTPSProxy: "http://proxy.corp.example:3128", CAFile: "/etc/ssl/corp-ca.pem"}, cfg.LLM.HTTP)
+	})
+
+	t.Run("GuardrailProfiles", func(t *testing.T) {
+		tempDir := t.TempDir()
+		yamlContent := `
+strict: true
+guardrails:
+  profile: "Customer"
+  profiles:
+    Customer:
+      terms: ["Acme Corp"]
+      builtins: ["api_key"]
+    internal:
+      action: "redact"
+      patterns:
+        - name: "ticket_ref"
+          pattern: 'SR-\d+'
+`
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, "config.yaml"), []byte(yamlContent), 0644))
+
+		cfg, err := LoadConfig(tempDir)
+		require.NoError(t, err)
+		assert.Equal(t, "Customer", cfg.Guardrails.Profile)
+		assert.Equal(t, map[string]GuardrailProfile{
+			"customer": {Terms: []string{"Acme Corp"}, Builtins: []string{"api_key"}},
+			"internal": {Action: "redact", Patterns: []RedactionPattern{{Name: "ticket_ref", Pattern: `SR-\d+`}}},
+		}, cfg.Guardrails.Profiles, "viper lowercases profile names")
+	})
+
 	t.Run("FileNotFound", func(t *testing.T) {
 		tempDir := t.TempDir()          // Need temp dir to specify non-existent path
 		cfg, err := LoadConfig(tempDir) // Load from empty temp dir
@@ -235,3 +285,16 @@ func TestCreateDefaultConfigFiles(t *testing.T) {
 		require.FileExists(t, filepath.Join(tempDir, "context.md"), "Context file should exist")
 	})
 }
+
+func TestAppConfigLocation(t *testing.T) {
+	loc, err := (&AppConfig{}).Location()
+	require.NoError(t, err)
+	assert.Equal(t, time.Local, loc)
+
+	loc, err = (&AppConfig{Timezone: "Europe/Warsaw"}).Location()
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/Warsaw", loc.String())
+
+	_, err = (&AppConfig{Timezone: "Mars/Olympus"}).Location()
+	assert.ErrorIs(t, err, ErrInvalidTimezone)
+}
@@ -0,0 +1,163 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// EnvPrefix is the prefix of the environment variables overriding config.yaml keys.
+const EnvPrefix = "TICKETRON"
+
+// Sources of a configuration value, as reported by EnvVars.
+const (
+	SourceEnv     = "env"     // Set by the environment variable
+	SourceFile    = "file"    // Set in config.yaml
+	SourceTeam    = "team"    // Set by the team configuration
+	SourceProject = "project" // Set by the project-local configuration
+	SourceDefault = "default" // Built-in default
+	SourceUnset   = "unset"   // Not set anywhere
+)
+
+// configDefaults are the built-in values of config.yaml keys.
+var configDefaults = map[string]any{
+	"mcp_server_url":        "http://localhost:8080",
+	"llm.provider":          "openai",
+	"llm.openai.model_name": "gpt-4o",
+	"llm.openai.base_url":   "",
+	"secrets.backend":       "auto", // OS keyring with file fallback
+}
+
+// EnvVar describes an environment variable understood by tix and where the value it
+// controls currently comes from.
+type EnvVar struct {
+	Name   string `json:"name" yaml:"name"`
+	Key    string `json:"key,omitempty" yaml:"key,omitempty"` // config.yaml key; empty for variables without one
+	Source string `json:"source" yaml:"source"`               // One of the Source* constants
+	Value  string `json:"value" yaml:"value"`
+	// Location is where a file, project or team value is set, as path:line.
+	Location string `json:"location,omitempty" yaml:"location,omitempty"`
+}
+
+// configEnvVars returns one entry per config.yaml key that can be overridden from the
+// environment, in AppConfig field order. Lists and maps of structures, such as
+// llm.fallbacks and guardrails.profiles, can only be set in the file; the schema version
+// cannot be overridden.
+func configEnvVars() []EnvVar {
+	var vars []EnvVar
+	collectEnvVars(reflect.TypeOf(AppConfig{}), "", &vars)
+	return vars
+}
+
+// collectEnvVars appends the leaf keys of the struct type t, prefixed with prefix.
+func collectEnvVars(t reflect.Type, prefix string, vars *[]EnvVar) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		key := prefix + name
+		switch {
+		case key == "version" || key == includesKey:
+			continue
+		case field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeOf(time.Duration(0)):
+			collectEnvVars(field.Type, key+".", vars)
+		case (field.Type.Kind() == reflect.Slice || field.Type.Kind() == reflect.Map) && field.Type.Elem().Kind() == reflect.Struct:
+			continue
+		default:
+			*vars = append(*vars, EnvVar{Name: EnvPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_")), Key: key})
+		}
+	}
+}
+
+// EnvVars lists every environment variable understood by tix with the current value and
+// its source, with the file and line for values set in a file, using the configuration directory (default or baseDir) and the project-local
+// configuration project, if not nil. The variables for config.yaml keys come first,
+// followed by TICKETRON_CONFIG_DIR and TICKETRON_LLM_API_KEY, whose value is never shown.
+func EnvVars(baseDir string, project *ProjectConfig) ([]EnvVar, error) {
+	v, configPath, err := newConfigViper(baseDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := mergeProjectConfig(v, project); err != nil {
+		return nil, err
+	}
+	var projectSettings *viper.Viper
+	if project != nil {
+		if projectSettings, err = configMapViper(project.Config); err != nil {
+			return nil, err
+		}
+	}
+
+	configDirPath, err := EnsureConfigDir(baseDir)
+	if err != nil {
+		return nil, err
+	}
+	team, err := teamConfigViper(configDirPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var fileLocs, teamLocs locations
+	if v.ConfigFileUsed() != "" {
+		fileLocs = fileLocations(configPath)
+	}
+	if team != nil {
+		teamLocs = bundleLocations(filepath.Join(configDirPath, DefaultTeamDirName, teamBundleFileName))
+	}
+	projectLocs := projectLocations(project)
+
+	vars := configEnvVars()
+	for i := range vars {
+		envVar := &vars[i]
+		envVar.Value = formatEnvValue(v.Get(envVar.Key))
+		_, hasDefault := configDefaults[envVar.Key]
+		switch {
+		case os.Getenv(envVar.Name) != "":
+			envVar.Source = SourceEnv
+		case projectSettings != nil && projectSettings.IsSet(envVar.Key):
+			envVar.Source, envVar.Location = SourceProject, projectLocs[envVar.Key]
+		case v.InConfig(envVar.Key):
+			envVar.Source, envVar.Location = SourceFile, fileLocs[envVar.Key]
+		case team != nil && team.IsSet(envVar.Key):
+			envVar.Source, envVar.Location = SourceTeam, teamLocs[envVar.Key]
+		case hasDefault:
+			envVar.Source = SourceDefault
+		default:
+			envVar.Source = SourceUnset
+		}
+	}
+
+	configDir := EnvVar{Name: ConfigDirEnvVar, Source: SourceDefault, Value: configDirPath}
+	if envDir := os.Getenv(ConfigDirEnvVar); envDir != "" && (baseDir == "" || baseDir == envDir) {
+		configDir.Source = SourceEnv
+	}
+	apiKey := EnvVar{Name: EnvAPIKeyName, Source: SourceUnset}
+	if os.Getenv(EnvAPIKeyName) != "" {
+		apiKey.Source, apiKey.Value = SourceEnv, "(set)"
+	}
+	return append(vars, configDir, apiKey), nil
+}
+
+// formatEnvValue renders a configuration value the way it would be written in an
+// environment variable; lists are comma-separated.
+func formatEnvValue(value any) string {
+	if value == nil {
+		return ""
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Slice {
+		items := make([]string, rv.Len())
+		for i := range items {
+			items[i] = fmt.Sprint(rv.Index(i).Interface())
+		}
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(value)
+}
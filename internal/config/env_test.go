@@ -0,0 +1,105 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig_EnvOverridesKeysWithoutDefaults(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TICKETRON_MCP_TLS_CA_FILE", "/etc/ca.pem")
+	t.Setenv("TICKETRON_LLM_TIMEOUT", "45s")
+	t.Setenv("TICKETRON_LLM_HTTP_HTTPS_PROXY", "http://proxy:3128")
+	t.Setenv("TICKETRON_REDACTION_ENABLED", "true")
+	t.Setenv("TICKETRON_REDACTION_BUILTINS", "email,ipv4")
+	t.Setenv("TICKETRON_LLM_OPENAI_MODEL_NAME", "gpt-4o-mini")
+	t.Setenv("TICKETRON_MCP_CHAOS_ENABLED", "true")
+	t.Setenv("TICKETRON_MCP_CHAOS_ERROR_RATE", "0.25")
+	t.Setenv("TICKETRON_MCP_CHAOS_SEED", "42")
+
+	cfg, err := LoadConfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "/etc/ca.pem", cfg.MCP.TLS.CAFile)
+	assert.Equal(t, 45*time.Second, cfg.LLM.Timeout)
+	assert.Equal(t, "http://proxy:3128", cfg.LLM.HTTP.HTTPSProxy)
+	assert.True(t, cfg.Redaction.Enabled)
+	assert.Equal(t, []string{"email", "ipv4"}, cfg.Redaction.Builtins)
+	assert.Equal(t, "gpt-4o-mini", cfg.LLM.OpenAI.ModelName)
+	assert.Equal(t, ChaosConfig{Enabled: true, ErrorRate: 0.25, Seed: 42}, cfg.MCP.Chaos)
+}
+
+func TestConfigEnvVars(t *testing.T) {
+	names := map[string]string{}
+	for _, envVar := range configEnvVars() {
+		names[envVar.Key] = envVar.Name
+	}
+	assert.Equal(t, "TICKETRON_LLM_OPENAI_MODEL_NAME", names["llm.openai.model_name"])
+	assert.Equal(t, "TICKETRON_MCP_TLS_INSECURE_SKIP_VERIFY", names["mcp.tls.insecure_skip_verify"])
+	assert.Equal(t, "TICKETRON_DESCRIPTION_FORMAT", names["description_format"])
+	assert.NotContains(t, names, "version")
+	assert.NotContains(t, names, "llm.fallbacks")
+	assert.NotContains(t, names, "redaction.patterns")
+}
+
+func TestEnvVars_Sources(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, DefaultConfigFileName), []byte("timezone: Europe/Warsaw\nllm:\n  provider: openai\n"), 0600))
+	t.Setenv("TICKETRON_LLM_PROVIDER", "mock")
+	t.Setenv(EnvAPIKeyName, "sk-secret")
+	t.Setenv(ConfigDirEnvVar, "")
+
+	vars, err := EnvVars(dir, nil)
+	require.NoError(t, err)
+	byName := map[string]EnvVar{}
+	for _, envVar := range vars {
+		byName[envVar.Name] = envVar
+	}
+	assert.Equal(t, EnvVar{Name: "TICKETRON_LLM_PROVIDER", Key: "llm.provider", Source: SourceEnv, Value: "mock"}, byName["TICKETRON_LLM_PROVIDER"])
+	assert.Equal(t, EnvVar{Name: "TICKETRON_TIMEZONE", Key: "timezone", Source: SourceFile, Value: "Europe/Warsaw", Location: filepath.Join(dir, DefaultConfigFileName) + ":1"}, byName["TICKETRON_TIMEZONE"])
+	assert.Equal(t, SourceDefault, byName["TICKETRON_SECRETS_BACKEND"].Source)
+	assert.Equal(t, SourceUnset, byName["TICKETRON_MCP_TLS_CA_FILE"].Source)
+	assert.Equal(t, dir, byName[ConfigDirEnvVar].Value)
+	assert.Equal(t, EnvVar{Name: EnvAPIKeyName, Source: SourceEnv, Value: "(set)"}, byName[EnvAPIKeyName])
+}
+
+func TestEnvVars_Locations(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(ConfigDirEnvVar, "")
+	configPath := filepath.Join(dir, DefaultConfigFileName)
+	sharedPath := filepath.Join(dir, "shared.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("includes: [shared.yaml]\n\nllm:\n  provider: openai\n  openai:\n    model_name: gpt-4o-mini\n"), 0600))
+	require.NoError(t, os.WriteFile(sharedPath, []byte("timezone: UTC\nllm:\n  openai:\n    model_name: gpt-4o\n"), 0600))
+	projectPath := filepath.Join(t.TempDir(), ProjectConfigFileName)
+	require.NoError(t, os.WriteFile(projectPath, []byte("links:\n  projects: []\nconfig:\n  default_project: API\n"), 0600))
+	project, err := LoadProjectConfig(projectPath)
+	require.NoError(t, err)
+
+	vars, err := EnvVars(dir, project)
+	require.NoError(t, err)
+	byKey := map[string]EnvVar{}
+	for _, envVar := range vars {
+		byKey[envVar.Key] = envVar
+	}
+	assert.Equal(t, configPath+":6", byKey["llm.openai.model_name"].Location, "config.yaml overrides its includes")
+	assert.Equal(t, configPath+":4", byKey["llm.provider"].Location)
+	assert.Equal(t, sharedPath+":1", byKey["timezone"].Location)
+	assert.Equal(t, projectPath+":4", byKey["default_project"].Location)
+	assert.Empty(t, byKey["secrets.backend"].Location, "defaults have no location")
+
+	teamDir := t.TempDir()
+	_, err = InstallTeamBundle(teamDir, []byte("config:\n  mcp_server_url: https://mcp.team.example\n"), TeamSource{URL: "https://team.example/team.yaml"})
+	require.NoError(t, err)
+	vars, err = EnvVars(teamDir, nil)
+	require.NoError(t, err)
+	for _, envVar := range vars {
+		if envVar.Key == "mcp_server_url" {
+			assert.Equal(t, SourceTeam, envVar.Source)
+			assert.Equal(t, filepath.Join(teamDir, DefaultTeamDirName, teamBundleFileName)+":2", envVar.Location)
+		}
+	}
+}
@@ -49,6 +49,21 @@ var ErrDefaultFileWrite = errors.New("failed to write default config file")
 // ErrDefaultFileStat indicates an error occurred while checking a default config file.
 var ErrDefaultFileStat = errors.New("failed to check default config file")
 
+// ErrConfigExists indicates a configuration file would be replaced without being forced to.
+var ErrConfigExists = errors.New("configuration file already exists")
+
+// ErrConfigVersion indicates config.yaml has an invalid version or one newer than this build supports.
+var ErrConfigVersion = errors.New("unsupported configuration version")
+
+// ErrConfigInclude indicates a file listed under includes in config.yaml could not be read.
+var ErrConfigInclude = errors.New("failed to include configuration file")
+
+// ErrConfigIncludeCycle indicates configuration files include each other.
+var ErrConfigIncludeCycle = errors.New("configuration include cycle")
+
+// ErrUnknownConfigKey indicates a configuration file contains keys that are not settings, in strict mode.
+var ErrUnknownConfigKey = errors.New("unknown configuration keys")
+
 // ErrProjectMappingFailed indicates that a suggested project name could not be mapped to a key.
 var ErrProjectMappingFailed = errors.New("could not map project name suggestion to a known project key")
 
@@ -58,5 +73,23 @@ var ErrKeyringSet = errors.New("failed to set key in OS keyring")
 // ErrKeyringGet indicates an error occurred while getting a key from the OS keyring (excluding 'not found').
 var ErrKeyringGet = errors.New("failed to get key from OS keyring")
 
+// ErrInvalidTimezone indicates the configured timezone is not a known IANA time zone.
+var ErrInvalidTimezone = errors.New("invalid timezone")
+
+// ErrConfigWatch indicates the configuration directory could not be watched for changes.
+var ErrConfigWatch = errors.New("failed to watch configuration directory")
+
+// ErrTeamFetch indicates a team configuration bundle could not be downloaded.
+var ErrTeamFetch = errors.New("failed to download team configuration")
+
+// ErrTeamChecksum indicates a team configuration bundle has no valid checksum or does not match it.
+var ErrTeamChecksum = errors.New("team configuration checksum mismatch")
+
+// ErrBundleParse indicates a team or project configuration bundle could not be parsed.
+var ErrBundleParse = errors.New("failed to parse configuration bundle")
+
+// ErrTeamNotConfigured indicates no team configuration was installed with `tix config init --from-url`.
+var ErrTeamNotConfigured = errors.New("no team configuration installed")
+
 // ErrAPIKeyNotFound is defined in config.go for now due to usage scope, but logically belongs here.
 // Consider moving it if refactoring occurs.
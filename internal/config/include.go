@@ -0,0 +1,132 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// includesKey is the config.yaml key listing further YAML files whose settings are merged
+// below those of the file.
+const includesKey = "includes"
+
+// applyIncludes replaces the settings v read from configPath, whose document is root, with
+// those settings merged over the files they include. It does nothing if root has no includes.
+func applyIncludes(v *viper.Viper, configPath string, root *yaml.Node) error {
+	if !hasIncludes(root) {
+		return nil
+	}
+	settings, err := resolveIncludes(configPath, root, v.GetBool("strict"), nil)
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrConfigParse, err)
+	}
+	if err := v.ReadConfig(bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("%w: %w", ErrConfigParse, err)
+	}
+	return nil
+}
+
+// hasIncludes reports whether the mapping root has an includes key.
+func hasIncludes(root *yaml.Node) bool {
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == includesKey {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveIncludes returns the settings of the document root read from path, merged over the
+// settings of the files it includes, in order, each resolved the same way. chain holds the
+// files including path, to detect cycles. Unknown keys in included files are reported as in
+// config.yaml.
+func resolveIncludes(path string, root *yaml.Node, strict bool, chain []string) (map[string]any, error) {
+	chain = append(slices.Clip(chain), path)
+	var doc struct {
+		Includes []string `yaml:"includes"`
+	}
+	settings := map[string]any{}
+	if err := root.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: %s: includes must be a list of paths: %w", ErrConfigInclude, path, err)
+	}
+	if err := root.Decode(&settings); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrConfigParse, path, err)
+	}
+	delete(settings, includesKey)
+
+	merged := map[string]any{}
+	for _, include := range doc.Includes {
+		includePath, err := resolveIncludePath(path, include)
+		if err != nil {
+			return nil, err
+		}
+		if slices.Contains(chain, includePath) {
+			return nil, fmt.Errorf("%w: %s", ErrConfigIncludeCycle, strings.Join(append(chain, includePath), " -> "))
+		}
+		data, err := os.ReadFile(includePath)
+		if err != nil {
+			return nil, fmt.Errorf("%w: included from %s: %w", ErrConfigInclude, path, err)
+		}
+		var fragment yaml.Node
+		if err := yaml.Unmarshal(data, &fragment); err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrConfigParse, includePath, err)
+		}
+		if len(fragment.Content) == 0 {
+			continue // Empty file
+		}
+		unknown := findUnknownKeys(includePath, fragment.Content[0], reflect.TypeOf(AppConfig{}), "mapstructure")
+		if err := reportUnknownKeys(unknown, strict); err != nil {
+			return nil, err
+		}
+		included, err := resolveIncludes(includePath, fragment.Content[0], strict, chain)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug().Str("path", includePath).Str("included_from", path).Msg("Merged included config file")
+		mergeSettings(merged, included)
+	}
+	mergeSettings(merged, settings)
+	return merged, nil
+}
+
+// resolveIncludePath returns the absolute path of include, which is relative to the
+// directory of the including file unless absolute or starting with "~/".
+func resolveIncludePath(from, include string) (string, error) {
+	if rest, ok := strings.CutPrefix(include, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("%w: %s: %w", ErrConfigInclude, include, err)
+		}
+		include = filepath.Join(home, rest)
+	}
+	if !filepath.IsAbs(include) {
+		include = filepath.Join(filepath.Dir(from), include)
+	}
+	return filepath.Clean(include), nil
+}
+
+// mergeSettings merges src into dst. Mappings present in both are merged recursively;
+// any other value of src, including lists, replaces the one in dst.
+func mergeSettings(dst, src map[string]any) {
+	for key, value := range src {
+		srcMap, srcIsMap := value.(map[string]any)
+		dstMap, dstIsMap := dst[key].(map[string]any)
+		if srcIsMap && dstIsMap {
+			mergeSettings(dstMap, srcMap)
+			continue
+		}
+		dst[key] = value
+	}
+}
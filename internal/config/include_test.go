@@ -0,0 +1,88 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig_Includes(t *testing.T) {
+	dir := t.TempDir()
+	shared := filepath.Join(filepath.Dir(dir), "dotfiles")
+	require.NoError(t, os.Mkdir(shared, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(shared, "team.yaml"), []byte(`includes: ["base.yaml"]
+default_project: TEAM
+llm:
+  openai:
+    model_name: "gpt-4o-mini"
+`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(shared, "base.yaml"), []byte(`default_project: BASE
+timezone: "Europe/Warsaw"
+llm:
+  openai:
+    base_url: "https://llm.example"
+`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(shared, "later.yaml"), []byte("timezone: UTC\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, DefaultConfigFileName), []byte(`includes:
+  - `+filepath.Join(shared, "team.yaml")+`
+  - ../dotfiles/later.yaml
+llm:
+  openai:
+    base_url: "https://mine.example"
+`), 0o600))
+
+	cfg, err := LoadConfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "TEAM", cfg.DefaultProject, "an including file overrides what it includes")
+	assert.Equal(t, "UTC", cfg.Timezone, "later includes override earlier ones")
+	assert.Equal(t, "gpt-4o-mini", cfg.LLM.OpenAI.ModelName, "mappings are merged")
+	assert.Equal(t, "https://mine.example", cfg.LLM.OpenAI.BaseURL, "config.yaml overrides its includes")
+	assert.Equal(t, "openai", cfg.LLM.Provider, "built-in defaults remain")
+
+	t.Setenv("TICKETRON_TIMEZONE", "Asia/Tokyo")
+	cfg, err = LoadConfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Tokyo", cfg.Timezone, "the environment overrides includes")
+
+	vars, err := EnvVars(dir, nil)
+	require.NoError(t, err)
+	for _, envVar := range vars {
+		assert.NotEqual(t, "includes", envVar.Key)
+		if envVar.Key == "default_project" {
+			assert.Equal(t, SourceFile, envVar.Source)
+		}
+	}
+}
+
+func TestLoadConfig_IncludeCycle(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, DefaultConfigFileName), []byte("includes: [a.yaml]\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.yaml"), []byte("includes: [b.yaml]\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.yaml"), []byte("includes: [config.yaml]\n"), 0o600))
+
+	_, err := LoadConfig(dir)
+	assert.ErrorIs(t, err, ErrConfigIncludeCycle)
+	assert.ErrorContains(t, err, filepath.Join(dir, "a.yaml")+" -> "+filepath.Join(dir, "b.yaml")+" -> "+filepath.Join(dir, DefaultConfigFileName))
+}
+
+func TestLoadConfig_IncludeErrors(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, DefaultConfigFileName)
+
+	require.NoError(t, os.WriteFile(configPath, []byte("includes: [missing.yaml]\n"), 0o600))
+	_, err := LoadConfig(dir)
+	assert.ErrorIs(t, err, ErrConfigInclude)
+	assert.ErrorContains(t, err, "missing.yaml")
+
+	require.NoError(t, os.WriteFile(configPath, []byte("includes: shared.yaml\n"), 0o600))
+	_, err = LoadConfig(dir)
+	assert.ErrorIs(t, err, ErrConfigInclude, "includes must be a list")
+
+	require.NoError(t, os.WriteFile(configPath, []byte("strict: true\nincludes: [shared.yaml]\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "shared.yaml"), []byte("timezon: UTC\n"), 0o600))
+	_, err = LoadConfig(dir)
+	assert.ErrorIs(t, err, ErrUnknownConfigKey, "included files are checked for unknown keys")
+}
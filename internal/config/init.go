@@ -0,0 +1,121 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+)
+
+// InitOptions customizes the files written by InitConfigFiles. Empty fields keep the
+// defaults written by CreateDefaultConfigFiles.
+type InitOptions struct {
+	MCPServerURL string
+	LLMProvider  string
+	LLMModel     string
+	Projects     []ProjectLink // Replaces the example projects in links.yaml
+	Force        bool          // Overwrite existing files that the options above customize
+}
+
+// customizesConfig reports whether config.yaml differs from the default.
+func (o InitOptions) customizesConfig() bool {
+	return o.MCPServerURL != "" || o.LLMProvider != "" || o.LLMModel != ""
+}
+
+// Lines of defaultConfigYAML replaced by InitConfigFiles.
+const (
+	defaultMCPServerURLLine = `mcp_server_url: "http://localhost:8080" # Default, user should change if needed`
+	defaultProviderLine     = "\n  provider: \"openai\"\n"
+	defaultModelLine        = `    model_name: "gpt-4o" # Example: gpt-4, gpt-4o, gpt-3.5-turbo`
+)
+
+// InitConfigFiles works like CreateDefaultConfigFiles, with the settings in opts written to
+// config.yaml and links.yaml. A customized file that already exists is an error wrapping
+// ErrConfigExists unless opts.Force is set; this is checked before anything is written.
+// Files not customized by opts, such as the user-written system_prompt.txt and context.md,
+// are only created when missing.
+func InitConfigFiles(baseDir string, opts InitOptions) error {
+	configDir, err := EnsureConfigDir(baseDir)
+	if err != nil {
+		return fmt.Errorf("failed to ensure config directory: %w", err)
+	}
+
+	linksYAML, err := renderLinksYAML(opts.Projects)
+	if err != nil {
+		return err
+	}
+	files := []struct {
+		name       string
+		content    string
+		perm       os.FileMode
+		customized bool
+	}{
+		{DefaultConfigFileName, renderConfigYAML(opts), 0600, opts.customizesConfig()},
+		{DefaultLinksFileName, linksYAML, 0600, len(opts.Projects) > 0},
+		{DefaultPromptFileName, defaultSystemPromptTXT, 0644, false},
+		{DefaultContextFileName, defaultContextMD, 0644, false},
+	}
+
+	if !opts.Force {
+		for _, file := range files {
+			filePath := filepath.Join(configDir, file.name)
+			if _, err := os.Stat(filePath); file.customized && err == nil {
+				return fmt.Errorf("%w: %s", ErrConfigExists, filePath)
+			}
+		}
+	}
+
+	for _, file := range files {
+		filePath := filepath.Join(configDir, file.name)
+		if !file.customized || !opts.Force {
+			if err := writeFileIfNotExists(filePath, file.content, file.perm); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := os.WriteFile(filePath, []byte(file.content), file.perm); err != nil {
+			return fmt.Errorf("%w: %w", ErrDefaultFileWrite, err)
+		}
+		log.Info().Str("path", filePath).Msg("Wrote configuration file")
+	}
+	return nil
+}
+
+// renderConfigYAML returns the default config.yaml with the settings in opts filled in,
+// keeping the explanatory comments.
+func renderConfigYAML(opts InitOptions) string {
+	content := defaultConfigYAML
+	if opts.MCPServerURL != "" {
+		content = strings.Replace(content, defaultMCPServerURLLine, "mcp_server_url: "+yamlString(opts.MCPServerURL), 1)
+	}
+	if opts.LLMProvider != "" {
+		content = strings.Replace(content, defaultProviderLine, "\n  provider: "+yamlString(opts.LLMProvider)+"\n", 1)
+	}
+	if opts.LLMModel != "" {
+		content = strings.Replace(content, defaultModelLine, "    model_name: "+yamlString(opts.LLMModel), 1)
+	}
+	return content
+}
+
+// renderLinksYAML returns links.yaml listing projects, or the default example file if
+// projects is empty.
+func renderLinksYAML(projects []ProjectLink) (string, error) {
+	if len(projects) == 0 {
+		return defaultLinksYAML, nil
+	}
+	data, err := yaml.Marshal(LinksConfig{Projects: projects})
+	if err != nil {
+		return "", fmt.Errorf("failed to render links.yaml: %w", err)
+	}
+	return "# ~/.ticketron/links.yaml\n# Defines mappings between user-friendly project aliases and JIRA project keys.\n" + string(data), nil
+}
+
+// yamlString quotes s as a YAML double-quoted scalar; JSON strings are valid YAML.
+func yamlString(s string) string {
+	quoted, _ := json.Marshal(s) // Marshalling a string cannot fail
+	return string(quoted)
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitConfigFiles_Customized(t *testing.T) {
+	dir := t.TempDir()
+	opts := InitOptions{
+		MCPServerURL: "https://mcp.corp.example",
+		LLMProvider:  "mock",
+		LLMModel:     "gpt-4o-mini",
+		Projects:     []ProjectLink{{Name: "backend", Key: "BE"}, {Name: "web", Key: "WEB"}},
+	}
+	require.NoError(t, InitConfigFiles(dir, opts))
+
+	cfg, err := LoadConfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "https://mcp.corp.example", cfg.MCPServerURL)
+	assert.Equal(t, "mock", cfg.LLM.Provider)
+	assert.Equal(t, "gpt-4o-mini", cfg.LLM.OpenAI.ModelName)
+
+	links, err := LoadLinks(dir)
+	require.NoError(t, err)
+	assert.Equal(t, opts.Projects, links.Projects)
+
+	configYAML, err := os.ReadFile(filepath.Join(dir, DefaultConfigFileName))
+	require.NoError(t, err)
+	assert.Contains(t, string(configYAML), "# Optional: TLS settings", "comments are kept")
+	require.FileExists(t, filepath.Join(dir, DefaultContextFileName))
+}
+
+func TestInitConfigFiles_ExistingFiles(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, DefaultConfigFileName)
+	contextPath := filepath.Join(dir, DefaultContextFileName)
+	require.NoError(t, os.WriteFile(configPath, []byte("mcp_server_url: http://old\n"), 0600))
+	require.NoError(t, os.WriteFile(contextPath, []byte("my notes"), 0600))
+
+	err := InitConfigFiles(dir, InitOptions{MCPServerURL: "http://new"})
+	assert.ErrorIs(t, err, ErrConfigExists)
+	assert.NoFileExists(t, filepath.Join(dir, DefaultLinksFileName), "nothing is written when refusing")
+
+	// Defaults for the remaining files do not touch existing ones
+	require.NoError(t, InitConfigFiles(dir, InitOptions{Projects: []ProjectLink{{Name: "ops", Key: "OPS"}}}))
+	cfg, err := LoadConfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "http://old", cfg.MCPServerURL)
+
+	require.NoError(t, InitConfigFiles(dir, InitOptions{MCPServerURL: "http://new", Force: true}))
+	cfg, err = LoadConfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "http://new", cfg.MCPServerURL)
+	links, err := LoadLinks(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "OPS", links.Projects[0].Key, "files not given on the command line are kept")
+	contextData, err := os.ReadFile(contextPath)
+	require.NoError(t, err)
+	assert.Equal(t, "my notes", string(contextData), "context.md is never overwritten")
+}
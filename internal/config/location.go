@@ -0,0 +1,95 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// locations maps config.yaml keys, e.g. "llm.openai.model_name", to where they are set, as
+// "path:line".
+type locations map[string]string
+
+// readYAMLRoot returns the top-level node of the YAML file at path, or nil if the file is
+// missing, empty or invalid; errors in the files were already reported when loading them.
+func readYAMLRoot(path string) *yaml.Node {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 {
+		return nil
+	}
+	return doc.Content[0]
+}
+
+// add records the line of every key of the mapping node, recursively, with prefix before
+// the keys. Keys seen before are overridden, as later files override earlier ones.
+func (l locations) add(path string, node *yaml.Node, prefix string) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := prefix + strings.ToLower(node.Content[i].Value)
+		l[key] = fmt.Sprintf("%s:%d", path, node.Content[i].Line)
+		l.add(path, node.Content[i+1], key+".")
+	}
+}
+
+// fileLocations returns where the keys of config.yaml at path are set, following its
+// includes as resolveIncludes merges them: included files in order, then the file itself.
+func fileLocations(path string) locations {
+	l := locations{}
+	l.addFile(path, nil)
+	return l
+}
+
+// addFile records the keys of the config file at path and the files it includes; chain
+// holds the files including path.
+func (l locations) addFile(path string, chain []string) {
+	root := readYAMLRoot(path)
+	if root == nil || slices.Contains(chain, path) {
+		return
+	}
+	chain = append(slices.Clip(chain), path)
+	var doc struct {
+		Includes []string `yaml:"includes"`
+	}
+	if root.Decode(&doc) == nil {
+		for _, include := range doc.Includes {
+			if includePath, err := resolveIncludePath(path, include); err == nil {
+				l.addFile(includePath, chain)
+			}
+		}
+	}
+	l.add(path, root, "")
+	delete(l, includesKey)
+}
+
+// bundleLocations returns where the config keys of the bundle at path are set.
+func bundleLocations(path string) locations {
+	l := locations{}
+	if root := readYAMLRoot(path); root != nil && root.Kind == yaml.MappingNode {
+		l.add(path, mappingValue(root, "config"), "")
+	}
+	return l
+}
+
+// projectLocations returns where the config keys of project are set, or nil for no project.
+func projectLocations(project *ProjectConfig) locations {
+	if project == nil {
+		return nil
+	}
+	if filepath.Base(project.Path) == ProjectConfigFileName {
+		return bundleLocations(project.Path)
+	}
+	l := locations{}
+	path := filepath.Join(project.Path, DefaultConfigFileName)
+	l.add(path, readYAMLRoot(path), "")
+	return l
+}
@@ -0,0 +1,272 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentConfigVersion is the schema version of config.yaml understood by this build.
+// Files without a version field are treated as version 0.
+const CurrentConfigVersion = 1
+
+// configMigration upgrades a config.yaml document from version from to from+1.
+type configMigration struct {
+	from        int
+	description string
+	// apply rewrites the root mapping in place and reports whether anything changed.
+	apply func(root *yaml.Node) bool
+}
+
+// configMigrations are applied in order; each entry's from is one more than the previous.
+var configMigrations = []configMigration{
+	{from: 0, description: "moved top-level llm_provider, openai and model_name settings under llm", apply: migrateLLMNesting},
+}
+
+// MigrationResult describes the outcome of MigrateConfigFile.
+type MigrationResult struct {
+	Path        string
+	FromVersion int
+	ToVersion   int
+	Applied     []string // Descriptions of the migrations that changed the layout
+	BackupPath  string   // Copy of the original file; empty unless the file was written
+}
+
+// UpToDate reports whether the file already had the current version.
+func (r *MigrationResult) UpToDate() bool {
+	return r.FromVersion == r.ToVersion
+}
+
+// MigrateConfigFile upgrades config.yaml in the configuration directory (default or baseDir)
+// to CurrentConfigVersion. With write set, the upgraded file replaces the original after
+// a copy is saved as config.yaml.bak; comments are kept. A missing file is an error
+// wrapping ErrConfigNotFound, a file newer than this build one wrapping ErrConfigVersion.
+func MigrateConfigFile(baseDir string, write bool) (*MigrationResult, error) {
+	configDir, err := EnsureConfigDir(baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to ensure config directory: %w", err)
+	}
+	path := filepath.Join(configDir, DefaultConfigFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrConfigNotFound, path)
+		}
+		return nil, fmt.Errorf("%w: %w", ErrConfigRead, err)
+	}
+
+	migrated, result, err := migrateConfigData(data)
+	if err != nil {
+		return nil, err
+	}
+	result.Path = path
+	if !write || result.UpToDate() {
+		return result, nil
+	}
+
+	result.BackupPath = path + ".bak"
+	if err := os.WriteFile(result.BackupPath, data, 0600); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDefaultFileWrite, err)
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, migrated, 0600); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDefaultFileWrite, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return nil, fmt.Errorf("%w: %w", ErrDefaultFileWrite, err)
+	}
+	log.Info().Str("path", path).Int("version", result.ToVersion).Msg("Migrated configuration file")
+	return result, nil
+}
+
+// migrateLoadedConfig upgrades the config.yaml just read by v in memory and returns its
+// root mapping. Files that needed a layout change are re-read in the upgraded form with a
+// warning suggesting 'tix config migrate'; files that only lack the version field are
+// accepted silently.
+func migrateLoadedConfig(v *viper.Viper, configPath string) (*yaml.Node, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrConfigRead, err)
+	}
+	doc, result, err := migrateConfigDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Applied) == 0 {
+		return doc.Content[0], nil
+	}
+	migrated, err := encodeConfigDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	log.Warn().Str("path", configPath).Int("version", result.FromVersion).Strs("migrations", result.Applied).
+		Msg("Config file uses an old layout and was upgraded in memory; run 'tix config migrate' to update it")
+	if err := v.ReadConfig(bytes.NewReader(migrated)); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrConfigParse, err)
+	}
+	return doc.Content[0], nil
+}
+
+// migrateConfigData upgrades a config.yaml document and returns the upgraded YAML.
+func migrateConfigData(data []byte) ([]byte, *MigrationResult, error) {
+	doc, result, err := migrateConfigDocument(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if result.UpToDate() {
+		return data, result, nil
+	}
+	migrated, err := encodeConfigDocument(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return migrated, result, nil
+}
+
+// migrateConfigDocument parses a config.yaml document and upgrades it in place. Nodes keep
+// the line numbers of the original file.
+func migrateConfigDocument(data []byte) (*yaml.Node, *MigrationResult, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrConfigParse, err)
+	}
+	if len(doc.Content) == 0 {
+		// Empty file: nothing to migrate, but it still gets a version
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, nil, fmt.Errorf("%w: top level is not a mapping", ErrConfigParse)
+	}
+
+	version, err := documentVersion(root)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := &MigrationResult{FromVersion: version, ToVersion: CurrentConfigVersion}
+	if version > CurrentConfigVersion {
+		return nil, nil, fmt.Errorf("%w: config.yaml has version %d, this build supports up to %d", ErrConfigVersion, version, CurrentConfigVersion)
+	}
+	for _, m := range configMigrations {
+		if m.from < version {
+			continue
+		}
+		if m.apply(root) {
+			result.Applied = append(result.Applied, m.description)
+		}
+	}
+	if !result.UpToDate() {
+		setVersion(root, CurrentConfigVersion)
+	}
+	return &doc, result, nil
+}
+
+// encodeConfigDocument renders doc with the two-space indentation used by the default files.
+func encodeConfigDocument(doc *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrConfigParse, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// documentVersion returns the version field of root, or 0 if it has none.
+func documentVersion(root *yaml.Node) (int, error) {
+	value := mappingValue(root, "version")
+	if value == nil {
+		return 0, nil
+	}
+	version, err := strconv.Atoi(value.Value)
+	if err != nil || version < 0 {
+		return 0, fmt.Errorf("%w: invalid version %q", ErrConfigVersion, value.Value)
+	}
+	return version, nil
+}
+
+// setVersion sets the version field of root, adding it as the first key if missing.
+func setVersion(root *yaml.Node, version int) {
+	if value := mappingValue(root, "version"); value != nil {
+		value.Value = strconv.Itoa(version)
+		return
+	}
+	key := &yaml.Node{Kind: yaml.ScalarNode, Value: "version", HeadComment: "Schema version of this file, upgraded by 'tix config migrate'."}
+	value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(version)}
+	root.Content = append([]*yaml.Node{key, value}, root.Content...)
+}
+
+// migrateLLMNesting moves settings from the layout used before the llm section existed:
+// llm_provider (or provider), a top-level openai section and model_name. Values already
+// present under llm take precedence.
+func migrateLLMNesting(root *yaml.Node) bool {
+	changed := false
+	for _, key := range []string{"llm_provider", "provider"} {
+		if k, v := removeMappingKey(root, key); k != nil {
+			k.Value = "provider"
+			addMappingKey(ensureMapping(root, "llm"), k, v)
+			changed = true
+		}
+	}
+	if k, v := removeMappingKey(root, "openai"); k != nil {
+		changed = true
+		if v.Kind == yaml.MappingNode {
+			openai := ensureMapping(ensureMapping(root, "llm"), "openai")
+			for i := 0; i+1 < len(v.Content); i += 2 {
+				addMappingKey(openai, v.Content[i], v.Content[i+1])
+			}
+		}
+	}
+	if k, v := removeMappingKey(root, "model_name"); k != nil {
+		addMappingKey(ensureMapping(ensureMapping(root, "llm"), "openai"), k, v)
+		changed = true
+	}
+	return changed
+}
+
+// mappingValue returns the value of key in the mapping m, or nil.
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// removeMappingKey removes key from the mapping m and returns its key and value nodes.
+func removeMappingKey(m *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			k, v := m.Content[i], m.Content[i+1]
+			m.Content = append(m.Content[:i], m.Content[i+2:]...)
+			return k, v
+		}
+	}
+	return nil, nil
+}
+
+// addMappingKey appends key to the mapping m unless it is already present.
+func addMappingKey(m, key, value *yaml.Node) {
+	if mappingValue(m, key.Value) == nil {
+		m.Content = append(m.Content, key, value)
+	}
+}
+
+// ensureMapping returns the mapping stored under key in m, creating it if necessary.
+func ensureMapping(m *yaml.Node, key string) *yaml.Node {
+	if value := mappingValue(m, key); value != nil && value.Kind == yaml.MappingNode {
+		return value
+	}
+	removeMappingKey(m, key) // Replace a null or scalar placeholder
+	value := &yaml.Node{Kind: yaml.MappingNode}
+	m.Content = append(m.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+	return value
+}
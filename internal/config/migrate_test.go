@@ -0,0 +1,118 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const legacyConfigYAML = `# My settings
+mcp_server_url: "http://mcp.internal:8080"
+
+# Which LLM to use
+llm_provider: "openai"
+openai:
+  base_url: "https://proxy.internal/v1"
+model_name: "gpt-4o-mini" # cheaper
+`
+
+func TestMigrateConfigData_LegacyLayout(t *testing.T) {
+	migrated, result, err := migrateConfigData([]byte(legacyConfigYAML))
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.FromVersion)
+	assert.Equal(t, CurrentConfigVersion, result.ToVersion)
+	assert.Len(t, result.Applied, 1)
+
+	out := string(migrated)
+	assert.Contains(t, out, "# My settings")
+	assert.Contains(t, out, "version: 1")
+	assert.Contains(t, out, "llm:\n  # Which LLM to use\n  provider: \"openai\"\n  openai:\n    base_url: \"https://proxy.internal/v1\"\n    model_name: \"gpt-4o-mini\" # cheaper")
+	assert.NotContains(t, out, "llm_provider")
+
+	// Migrating again is a no-op
+	again, result, err := migrateConfigData(migrated)
+	require.NoError(t, err)
+	assert.True(t, result.UpToDate())
+	assert.Equal(t, migrated, again)
+}
+
+func TestMigrateConfigData_ExistingLLMValuesWin(t *testing.T) {
+	migrated, _, err := migrateConfigData([]byte("provider: mock\nmodel_name: old\nllm:\n  provider: openai\n  openai:\n    model_name: new\n"))
+	require.NoError(t, err)
+	assert.Contains(t, string(migrated), "provider: openai")
+	assert.Contains(t, string(migrated), "model_name: new")
+	assert.NotContains(t, string(migrated), "old")
+	assert.NotContains(t, string(migrated), "mock")
+}
+
+func TestMigrateConfigData_Versions(t *testing.T) {
+	_, result, err := migrateConfigData([]byte("mcp_server_url: http://x\n"))
+	require.NoError(t, err)
+	assert.Empty(t, result.Applied, "a file that only lacks the version needs no layout change")
+	assert.False(t, result.UpToDate())
+
+	_, _, err = migrateConfigData([]byte("version: 99\n"))
+	assert.ErrorIs(t, err, ErrConfigVersion)
+	_, _, err = migrateConfigData([]byte("version: latest\n"))
+	assert.ErrorIs(t, err, ErrConfigVersion)
+	_, _, err = migrateConfigData([]byte("- a list\n"))
+	assert.ErrorIs(t, err, ErrConfigParse)
+}
+
+func TestDefaultConfigIsCurrentVersion(t *testing.T) {
+	_, result, err := migrateConfigData([]byte(defaultConfigYAML))
+	require.NoError(t, err)
+	assert.True(t, result.UpToDate())
+}
+
+func TestLoadConfig_MigratesLegacyLayout(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, DefaultConfigFileName), []byte(legacyConfigYAML), 0600))
+
+	cfg, err := LoadConfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "openai", cfg.LLM.Provider)
+	assert.Equal(t, "gpt-4o-mini", cfg.LLM.OpenAI.ModelName)
+	assert.Equal(t, "https://proxy.internal/v1", cfg.LLM.OpenAI.BaseURL)
+	assert.Equal(t, "http://mcp.internal:8080", cfg.MCPServerURL)
+
+	data, err := os.ReadFile(filepath.Join(dir, DefaultConfigFileName))
+	require.NoError(t, err)
+	assert.Equal(t, legacyConfigYAML, string(data), "loading must not rewrite the file")
+}
+
+func TestMigrateConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, DefaultConfigFileName)
+	require.NoError(t, os.WriteFile(path, []byte(legacyConfigYAML), 0600))
+
+	result, err := MigrateConfigFile(dir, false)
+	require.NoError(t, err)
+	assert.Empty(t, result.BackupPath)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, legacyConfigYAML, string(data), "dry run must not write")
+
+	result, err = MigrateConfigFile(dir, true)
+	require.NoError(t, err)
+	assert.Equal(t, path, result.Path)
+	assert.Equal(t, path+".bak", result.BackupPath)
+	backup, err := os.ReadFile(result.BackupPath)
+	require.NoError(t, err)
+	assert.Equal(t, legacyConfigYAML, string(backup))
+
+	cfg, err := LoadConfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, CurrentConfigVersion, cfg.Version)
+	assert.Equal(t, "gpt-4o-mini", cfg.LLM.OpenAI.ModelName)
+
+	result, err = MigrateConfigFile(dir, true)
+	require.NoError(t, err)
+	assert.True(t, result.UpToDate())
+
+	_, err = MigrateConfigFile(t.TempDir(), true)
+	assert.ErrorIs(t, err, ErrConfigNotFound)
+}
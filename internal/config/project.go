@@ -0,0 +1,183 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ProjectConfigDirName is the project-local configuration directory, holding any of
+	// config.yaml, links.yaml, system_prompt.txt and context.md.
+	ProjectConfigDirName = ".ticketron"
+	// ProjectConfigFileName is the project-local configuration as one bundle; see Bundle.
+	ProjectConfigFileName = ".ticketron.yaml"
+)
+
+// projectConfigKeys are the top-level config.yaml keys a project-local configuration may
+// set. Settings that choose servers, credentials or what is redacted are left out, so that
+// a cloned repository cannot redirect requests or the API key.
+var projectConfigKeys = []string{"auto_link", "default_project", "description_format", "guardrails", "timezone", "ui"}
+
+// ProjectConfig is a project-local configuration, layered over the global one: its settings
+// override config.yaml (the environment still wins), its projects come before the links.yaml
+// projects of other names, its context is appended to context.md and its system prompt
+// replaces system_prompt.txt.
+type ProjectConfig struct {
+	Path string // The .ticketron directory or .ticketron.yaml file
+	Bundle
+}
+
+// FindProjectConfig returns the project-local configuration nearest to start: a .ticketron
+// directory or .ticketron.yaml file in start or a parent directory up to the root of the
+// git repository containing start. Outside a git repository only start is searched. The
+// global configuration directory globalDir is never returned. It returns "" if there is none.
+func FindProjectConfig(start, globalDir string) (string, error) {
+	start, err := filepath.Abs(start)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", start, err)
+	}
+	dirs := []string{start}
+	for dir := start; ; {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			dirs = dirs[:1] // Not in a git repository
+			break
+		}
+		dir = parent
+		dirs = append(dirs, dir)
+	}
+
+	globalInfo, _ := os.Stat(globalDir)
+	for _, dir := range dirs {
+		path := filepath.Join(dir, ProjectConfigDirName)
+		if info, err := os.Stat(path); err == nil && info.IsDir() && (globalInfo == nil || !os.SameFile(info, globalInfo)) {
+			return path, nil
+		}
+		path = filepath.Join(dir, ProjectConfigFileName)
+		if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+			return path, nil
+		}
+	}
+	return "", nil
+}
+
+// LoadProjectConfig reads the project-local configuration at path, a .ticketron directory
+// or .ticketron.yaml file. Settings other than projectConfigKeys are dropped with a warning.
+func LoadProjectConfig(path string) (*ProjectConfig, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrConfigRead, err)
+	}
+	project := &ProjectConfig{Path: path}
+	if info.IsDir() {
+		err = project.readDir()
+	} else {
+		var data []byte
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrConfigRead, err)
+		}
+		var bundle *Bundle
+		if bundle, err = parseBundle(path, data); err == nil {
+			project.Bundle = *bundle
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for key := range project.Config {
+		if !slices.Contains(projectConfigKeys, strings.ToLower(key)) {
+			log.Warn().Str("path", path).Str("key", key).Strs("allowed", projectConfigKeys).
+				Msg("Setting not allowed in a project-local configuration, ignored")
+			delete(project.Config, key)
+		}
+	}
+	return project, nil
+}
+
+// readDir reads the files of a .ticketron directory; missing files are skipped.
+func (p *ProjectConfig) readDir() error {
+	read := func(name string) ([]byte, error) {
+		data, err := os.ReadFile(filepath.Join(p.Path, name))
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrConfigRead, err)
+		}
+		return data, nil
+	}
+
+	data, err := read(DefaultConfigFileName)
+	if err != nil {
+		return err
+	}
+	if err := yaml.Unmarshal(data, &p.Config); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrConfigParse, filepath.Join(p.Path, DefaultConfigFileName), err)
+	}
+	if data, err = read(DefaultLinksFileName); err != nil {
+		return err
+	}
+	if err := yaml.Unmarshal(data, &p.Links); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrLinksParse, filepath.Join(p.Path, DefaultLinksFileName), err)
+	}
+	if data, err = read(DefaultPromptFileName); err != nil {
+		return err
+	}
+	p.SystemPrompt = string(data)
+	if data, err = read(DefaultContextFileName); err != nil {
+		return err
+	}
+	p.Context = string(data)
+	return nil
+}
+
+// mergeProjectConfig merges the settings of project, if any, over the config.yaml settings of v.
+func mergeProjectConfig(v *viper.Viper, project *ProjectConfig) error {
+	if project == nil || len(project.Config) == 0 {
+		return nil
+	}
+	if err := v.MergeConfigMap(project.Config); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrConfigParse, project.Path, err)
+	}
+	return nil
+}
+
+// ApplyLinks returns links with the project's projects layered over them.
+func (p *ProjectConfig) ApplyLinks(links LinksConfig) LinksConfig {
+	if p == nil || len(p.Links.Projects) == 0 {
+		return links
+	}
+	links.Projects = mergeProjects(slices.Clone(p.Links.Projects), links.Projects)
+	return links
+}
+
+// ApplySystemPrompt returns the project's system prompt if it has one, and prompt otherwise.
+func (p *ProjectConfig) ApplySystemPrompt(prompt string) string {
+	if p == nil || strings.TrimSpace(p.SystemPrompt) == "" {
+		return prompt
+	}
+	return p.SystemPrompt
+}
+
+// ApplyContext returns context followed by the project's context.
+func (p *ProjectConfig) ApplyContext(context string) string {
+	if p == nil || strings.TrimSpace(p.Context) == "" {
+		return context
+	}
+	if strings.TrimSpace(context) == "" {
+		return p.Context
+	}
+	return strings.TrimRight(context, "\n") + "\n\n" + p.Context
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindProjectConfig(t *testing.T) {
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	sub := filepath.Join(repo, "services", "api")
+	require.NoError(t, os.MkdirAll(sub, 0o755))
+	require.NoError(t, os.Mkdir(filepath.Join(repo, ".git"), 0o755))
+	global := filepath.Join(root, "global")
+	require.NoError(t, os.Mkdir(global, 0o700))
+
+	path, err := FindProjectConfig(sub, global)
+	require.NoError(t, err)
+	assert.Empty(t, path)
+
+	// Above the git root is outside the project
+	require.NoError(t, os.WriteFile(filepath.Join(root, ProjectConfigFileName), nil, 0o644))
+	path, err = FindProjectConfig(sub, global)
+	require.NoError(t, err)
+	assert.Empty(t, path)
+
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ProjectConfigFileName), nil, 0o644))
+	path, err = FindProjectConfig(sub, global)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(repo, ProjectConfigFileName), path, "found at the git root")
+
+	require.NoError(t, os.Mkdir(filepath.Join(sub, ProjectConfigDirName), 0o755))
+	path, err = FindProjectConfig(sub, global)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(sub, ProjectConfigDirName), path, "the nearest one wins")
+
+	// The global configuration directory is not a project-local one
+	path, err = FindProjectConfig(sub, filepath.Join(sub, ProjectConfigDirName))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(repo, ProjectConfigFileName), path)
+
+	// Outside a git repository only the start directory is searched
+	outside := filepath.Join(root, "outside", "deeper")
+	require.NoError(t, os.MkdirAll(outside, 0o755))
+	path, err = FindProjectConfig(outside, global)
+	require.NoError(t, err)
+	assert.Empty(t, path)
+}
+
+func TestLoadProjectConfig_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ProjectConfigFileName)
+	require.NoError(t, os.WriteFile(path, []byte(`config:
+  default_project: "API"
+  mcp_server_url: "https://evil.example"
+  llm:
+    openai:
+      base_url: "https://evil.example"
+links:
+  projects:
+    - name: api
+      key: API
+context: "The API team owns this repository."
+`), 0o644))
+
+	project, err := LoadProjectConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, path, project.Path)
+	assert.Equal(t, map[string]any{"default_project": "API"}, project.Config, "only allowed settings are kept")
+	assert.Equal(t, []ProjectLink{{Name: "api", Key: "API"}}, project.Links.Projects)
+	assert.Equal(t, "The API team owns this repository.", project.Context)
+}
+
+func TestLoadProjectConfig_Dir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), ProjectConfigDirName)
+	require.NoError(t, os.Mkdir(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, DefaultConfigFileName), []byte("timezone: Europe/Warsaw\nsecrets:\n  backend: file\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, DefaultContextFileName), []byte("Repo context"), 0o644))
+
+	project, err := LoadProjectConfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"timezone": "Europe/Warsaw"}, project.Config)
+	assert.Empty(t, project.Links.Projects, "missing files are skipped")
+	assert.Empty(t, project.SystemPrompt)
+	assert.Equal(t, "Repo context", project.Context)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, DefaultLinksFileName), []byte("projects: {"), 0o644))
+	_, err = LoadProjectConfig(dir)
+	assert.ErrorIs(t, err, ErrLinksParse)
+}
+
+func TestLoadLayeredConfig(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, DefaultConfigFileName), []byte("default_project: GLOBAL\ntimezone: UTC\n"), 0o600))
+	project := &ProjectConfig{Path: ".ticketron.yaml", Bundle: Bundle{Config: map[string]any{"default_project": "API"}}}
+
+	cfg, err := LoadLayeredConfig(dir, project)
+	require.NoError(t, err)
+	assert.Equal(t, "API", cfg.DefaultProject, "the project overrides config.yaml")
+	assert.Equal(t, "UTC", cfg.Timezone)
+
+	t.Setenv("TICKETRON_DEFAULT_PROJECT", "ENV")
+	cfg, err = LoadLayeredConfig(dir, project)
+	require.NoError(t, err)
+	assert.Equal(t, "ENV", cfg.DefaultProject, "the environment overrides the project")
+
+	vars, err := EnvVars(dir, &ProjectConfig{Bundle: Bundle{Config: map[string]any{"timezone": "Europe/Warsaw"}}})
+	require.NoError(t, err)
+	for _, envVar := range vars {
+		if envVar.Key == "timezone" {
+			assert.Equal(t, SourceProject, envVar.Source)
+			assert.Equal(t, "Europe/Warsaw", envVar.Value)
+		}
+	}
+}
+
+func TestProjectConfig_Apply(t *testing.T) {
+	var none *ProjectConfig
+	links := LinksConfig{Projects: []ProjectLink{{Name: "api", Key: "OLD"}, {Name: "ops", Key: "OPS"}}}
+	assert.Equal(t, links, none.ApplyLinks(links))
+	assert.Equal(t, "global", none.ApplyContext("global"))
+	assert.Equal(t, "global", none.ApplySystemPrompt("global"))
+
+	project := &ProjectConfig{Bundle: Bundle{
+		Links:        LinksConfig{Projects: []ProjectLink{{Name: "API", Key: "API"}, {Name: "web", Key: "WEB"}}},
+		SystemPrompt: "Project prompt",
+		Context:      "Project context",
+	}}
+	assert.Equal(t, []ProjectLink{{Name: "API", Key: "API"}, {Name: "web", Key: "WEB"}, {Name: "ops", Key: "OPS"}}, project.ApplyLinks(links).Projects)
+	assert.Equal(t, "Global context\n\nProject context", project.ApplyContext("Global context\n"))
+	assert.Equal(t, "Project context", project.ApplyContext(""))
+	assert.Equal(t, "Project prompt", project.ApplySystemPrompt("Global prompt"))
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Settings returns the configuration as nested maps keyed like config.yaml, e.g.
+// Settings()["llm"]["openai"]["model_name"], for structured output. Durations are rendered
+// as in the file, e.g. "30s".
+func (c *AppConfig) Settings() map[string]any {
+	settings, _ := settingsValue(reflect.ValueOf(*c)).(map[string]any)
+	return settings
+}
+
+// settingsValue converts v to maps, slices and plain values, naming struct fields after
+// their mapstructure tags.
+func settingsValue(v reflect.Value) any {
+	if v.Type() == durationType {
+		return time.Duration(v.Int()).String()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		m := make(map[string]any, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+			if name == "" || name == "-" || !field.IsExported() {
+				continue
+			}
+			m[name] = settingsValue(v.Field(i))
+		}
+		return m
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		items := make([]any, v.Len())
+		for i := range items {
+			items[i] = settingsValue(v.Index(i))
+		}
+		return items
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		m := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			m[fmt.Sprint(iter.Key().Interface())] = settingsValue(iter.Value())
+		}
+		return m
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return settingsValue(v.Elem())
+	default:
+		return v.Interface()
+	}
+}
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSettings(t *testing.T) {
+	cfg := &AppConfig{
+		MCPServerURL: "http://mcp.example.com",
+		LLM: LLMConfig{
+			Provider:  "openai",
+			OpenAI:    OpenAIConfig{ModelName: "gpt-test"},
+			Fallbacks: []LLMFallbackConfig{{Provider: "anthropic", Model: "claude"}},
+		},
+		Hooks: HooksConfig{PostCreate: []string{"./notify.sh {{.Key}}"}, Timeout: 10 * time.Second},
+	}
+	settings := cfg.Settings()
+
+	assert.Equal(t, "http://mcp.example.com", settings["mcp_server_url"])
+	llm := settings["llm"].(map[string]any)
+	assert.Equal(t, "gpt-test", llm["openai"].(map[string]any)["model_name"])
+	assert.Equal(t, []any{map[string]any{"provider": "anthropic", "model": "claude", "base_url": ""}}, llm["fallbacks"])
+	hooks := settings["hooks"].(map[string]any)
+	assert.Equal(t, "10s", hooks["timeout"])
+	assert.Equal(t, []any{"./notify.sh {{.Key}}"}, hooks["post_create"])
+	assert.Nil(t, hooks["pre_create"])
+}
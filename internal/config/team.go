@@ -0,0 +1,267 @@
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultTeamDirName is the directory in the configuration directory holding the team
+	// configuration installed by `tix config init --from-url`.
+	DefaultTeamDirName = "team"
+	// teamBundleFileName is the downloaded bundle, stored as fetched.
+	teamBundleFileName = "bundle.yaml"
+	// teamSourceFileName records where the bundle came from, for `tix config sync`.
+	teamSourceFileName = "source.yaml"
+	// maxTeamBundleSize bounds the download of a bundle.
+	maxTeamBundleSize = 1 << 20
+)
+
+// teamDeniedKeys are the top-level config.yaml keys a team bundle may not set. Hooks run
+// commands on the machine and secret references run secret manager CLIs with the user's
+// credentials, and a bundle changes whenever its URL serves a new one, so they have to be
+// configured locally.
+var teamDeniedKeys = []string{"hooks", "secrets"}
+
+// TeamSource records where the installed team bundle was downloaded from.
+type TeamSource struct {
+	URL       string    `yaml:"url"`
+	SHA256    string    `yaml:"sha256"` // Hex checksum of the bundle
+	FetchedAt time.Time `yaml:"fetched_at"`
+}
+
+// FetchTeamBundle downloads the bundle at bundleURL and verifies it against checksum, the
+// hex SHA-256 of the bundle with an optional "sha256:" prefix. An empty checksum is read
+// from bundleURL + ".sha256", in the format of sha256sum or as the bare hex value. Only
+// https URLs are accepted, except for loopback hosts. It returns the bundle and its
+// checksum. A nil client uses http.DefaultClient.
+func FetchTeamBundle(ctx context.Context, client *http.Client, bundleURL, checksum string) ([]byte, string, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if err := checkTeamURL(bundleURL); err != nil {
+		return nil, "", err
+	}
+	if checksum == "" {
+		sumFile, err := fetchTeamFile(ctx, client, bundleURL+".sha256")
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: no checksum given and %s.sha256 is unavailable: %w", ErrTeamChecksum, bundleURL, err)
+		}
+		fields := strings.Fields(string(sumFile))
+		if len(fields) == 0 {
+			return nil, "", fmt.Errorf("%w: %s.sha256 is empty", ErrTeamChecksum, bundleURL)
+		}
+		checksum = fields[0]
+	}
+	want := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(checksum), "sha256:"))
+	if decoded, err := hex.DecodeString(want); err != nil || len(decoded) != sha256.Size {
+		return nil, "", fmt.Errorf("%w: %q is not a SHA-256 checksum", ErrTeamChecksum, checksum)
+	}
+
+	data, err := fetchTeamFile(ctx, client, bundleURL)
+	if err != nil {
+		return nil, "", err
+	}
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return nil, "", fmt.Errorf("%w: expected %s, got %s", ErrTeamChecksum, want, got)
+	}
+	return data, want, nil
+}
+
+// checkTeamURL rejects bundle URLs that are not https, except on loopback hosts.
+func checkTeamURL(bundleURL string) error {
+	u, err := url.Parse(bundleURL)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("%w: invalid URL %q", ErrTeamFetch, bundleURL)
+	}
+	switch u.Scheme {
+	case "https":
+		return nil
+	case "http":
+		if host := u.Hostname(); host == "localhost" || net.ParseIP(host).IsLoopback() {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %q must be an https URL", ErrTeamFetch, bundleURL)
+}
+
+// fetchTeamFile downloads fileURL, up to maxTeamBundleSize bytes.
+func fetchTeamFile(ctx context.Context, client *http.Client, fileURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTeamFetch, err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTeamFetch, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s returned status %d", ErrTeamFetch, fileURL, resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxTeamBundleSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTeamFetch, err)
+	}
+	if len(data) > maxTeamBundleSize {
+		return nil, fmt.Errorf("%w: %s is larger than %d bytes", ErrTeamFetch, fileURL, maxTeamBundleSize)
+	}
+	return data, nil
+}
+
+// InstallTeamBundle parses data and stores it with its source in the team directory of the
+// configuration directory (default or baseDir), replacing the previous bundle.
+//
+// The team bundle is a base configuration that applies unless the local files override it:
+// config.yaml keys and environment variables take precedence over its settings, links.yaml
+// projects over its projects of the same name, and a system_prompt.txt or context.md that
+// differs from the default written by `tix config init` over its system prompt and context.
+func InstallTeamBundle(baseDir string, data []byte, source TeamSource) (*Bundle, error) {
+	bundle, err := ParseBundle(data)
+	if err != nil {
+		return nil, err
+	}
+	dropTeamDeniedKeys(source.URL, bundle)
+	configDir, err := EnsureConfigDir(baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to ensure config directory: %w", err)
+	}
+	teamDir := filepath.Join(configDir, DefaultTeamDirName)
+	if err := os.MkdirAll(teamDir, 0700); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrConfigDirCreate, err)
+	}
+	sourceYAML, err := yaml.Marshal(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the team source: %w", err)
+	}
+	files := []struct {
+		name    string
+		content []byte
+	}{
+		{teamBundleFileName, data},
+		{teamSourceFileName, sourceYAML},
+	}
+	for _, file := range files {
+		if err := os.WriteFile(filepath.Join(teamDir, file.name), file.content, 0600); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrDefaultFileWrite, err)
+		}
+	}
+	log.Info().Str("path", teamDir).Str("url", source.URL).Msg("Installed team configuration")
+	return bundle, nil
+}
+
+// LoadTeamSource returns where the installed team bundle of the configuration directory
+// (default or baseDir) came from, or ErrTeamNotConfigured if none is installed.
+func LoadTeamSource(baseDir string) (*TeamSource, error) {
+	configDir, err := EnsureConfigDir(baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to ensure config directory: %w", err)
+	}
+	data, err := os.ReadFile(filepath.Join(configDir, DefaultTeamDirName, teamSourceFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrTeamNotConfigured
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrConfigRead, err)
+	}
+	var source TeamSource
+	if err := yaml.Unmarshal(data, &source); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrBundleParse, err)
+	}
+	if source.URL == "" {
+		return nil, fmt.Errorf("%w: %s has no url", ErrBundleParse, teamSourceFileName)
+	}
+	return &source, nil
+}
+
+// readTeamBundle returns the team bundle installed in configDir, or nil if there is none.
+func readTeamBundle(configDir string) (*Bundle, error) {
+	data, err := os.ReadFile(filepath.Join(configDir, DefaultTeamDirName, teamBundleFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrConfigRead, err)
+	}
+	path := filepath.Join(DefaultTeamDirName, teamBundleFileName)
+	bundle, err := parseBundle(path, data)
+	if err != nil {
+		return nil, err
+	}
+	dropTeamDeniedKeys(path, bundle)
+	return bundle, nil
+}
+
+// dropTeamDeniedKeys removes the teamDeniedKeys settings of bundle, read from path, with a warning.
+func dropTeamDeniedKeys(path string, bundle *Bundle) {
+	for key := range bundle.Config {
+		if slices.Contains(teamDeniedKeys, strings.ToLower(key)) {
+			log.Warn().Str("path", path).Str("key", key).
+				Msg("Setting not allowed in a team configuration, ignored")
+			delete(bundle.Config, key)
+		}
+	}
+}
+
+// teamConfigViper returns a viper instance holding the config settings of the team bundle
+// installed in configDir, or nil if there are none.
+func teamConfigViper(configDir string) (*viper.Viper, error) {
+	bundle, err := readTeamBundle(configDir)
+	if err != nil || bundle == nil {
+		return nil, err
+	}
+	return configMapViper(bundle.Config)
+}
+
+// withTeamLinks adds the projects of the team bundle installed in configDir to cfg, or
+// replaces its projects with them if isDefault is set.
+func withTeamLinks(configDir string, cfg LinksConfig, isDefault bool) (LinksConfig, error) {
+	team, err := readTeamBundle(configDir)
+	if err != nil {
+		return LinksConfig{}, err
+	}
+	switch {
+	case team == nil || len(team.Links.Projects) == 0:
+	case isDefault:
+		cfg.Projects = team.Links.Projects
+	default:
+		cfg.Projects = mergeProjects(cfg.Projects, team.Links.Projects)
+	}
+	return cfg, nil
+}
+
+// withTeamText returns the text selected by field from the team bundle installed in
+// configDir if local is empty or still defaultText, and local otherwise.
+func withTeamText(configDir, local, defaultText string, field func(*Bundle) string) (string, error) {
+	team, err := readTeamBundle(configDir)
+	if err != nil || team == nil {
+		return local, err
+	}
+	if text := field(team); text != "" && (local == "" || local == defaultText) {
+		return text, nil
+	}
+	return local, nil
+}
+
+// isDefaultFile reports whether the file at path holds exactly defaultText.
+func isDefaultFile(path, defaultText string) bool {
+	data, err := os.ReadFile(path)
+	return err == nil && string(data) == defaultText
+}
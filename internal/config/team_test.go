@@ -0,0 +1,177 @@
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTeamBundle = `config:
+  mcp_server_url: "https://mcp.team.example"
+  llm:
+    openai:
+      model_name: "gpt-4o-mini"
+  timezone: "Europe/Warsaw"
+links:
+  projects:
+    - name: "backend"
+      key: "BE"
+    - name: "ops"
+      key: "OPS"
+system_prompt: "Team prompt"
+context: "Team context"
+`
+
+func sha256Hex(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestFetchTeamBundle(t *testing.T) {
+	sidecar := sha256Hex(testTeamBundle) + "  team.yaml\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/team.yaml", "/nosum.yaml":
+			_, _ = w.Write([]byte(testTeamBundle))
+		case "/team.yaml.sha256":
+			_, _ = w.Write([]byte(sidecar))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	data, sum, err := FetchTeamBundle(ctx, nil, server.URL+"/team.yaml", "")
+	require.NoError(t, err, "the checksum is read from the .sha256 file")
+	assert.Equal(t, testTeamBundle, string(data))
+	assert.Equal(t, sha256Hex(testTeamBundle), sum)
+
+	_, _, err = FetchTeamBundle(ctx, nil, server.URL+"/nosum.yaml", "sha256:"+sha256Hex(testTeamBundle))
+	require.NoError(t, err, "an explicit checksum needs no .sha256 file")
+
+	_, _, err = FetchTeamBundle(ctx, nil, server.URL+"/nosum.yaml", "")
+	assert.ErrorIs(t, err, ErrTeamChecksum)
+	_, _, err = FetchTeamBundle(ctx, nil, server.URL+"/team.yaml", sha256Hex("other"))
+	assert.ErrorIs(t, err, ErrTeamChecksum)
+	assert.ErrorContains(t, err, "expected "+sha256Hex("other"))
+	_, _, err = FetchTeamBundle(ctx, nil, server.URL+"/team.yaml", "abc")
+	assert.ErrorIs(t, err, ErrTeamChecksum)
+	_, _, err = FetchTeamBundle(ctx, nil, server.URL+"/missing.yaml", sha256Hex("x"))
+	assert.ErrorIs(t, err, ErrTeamFetch)
+	_, _, err = FetchTeamBundle(ctx, nil, "http://team.example/team.yaml", sha256Hex("x"))
+	assert.ErrorIs(t, err, ErrTeamFetch)
+	assert.ErrorContains(t, err, "must be an https URL")
+}
+
+func TestParseTeamBundle(t *testing.T) {
+	bundle, err := ParseBundle([]byte(testTeamBundle))
+	require.NoError(t, err)
+	assert.Equal(t, "https://mcp.team.example", bundle.Config["mcp_server_url"])
+	assert.Len(t, bundle.Links.Projects, 2)
+	assert.Equal(t, "Team prompt", bundle.SystemPrompt)
+
+	bundle, err = ParseBundle([]byte("config:\n  future_setting: true\nextra: 1\n"))
+	require.NoError(t, err, "unknown keys are only warned about")
+	assert.Equal(t, true, bundle.Config["future_setting"])
+
+	_, err = ParseBundle([]byte("links: [unclosed"))
+	assert.ErrorIs(t, err, ErrBundleParse)
+}
+
+func TestInstallTeamBundle_Layering(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, CreateDefaultConfigFiles(dir))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, DefaultConfigFileName), []byte("timezone: \"UTC\"\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, DefaultContextFileName), []byte("My context"), 0600))
+
+	source := TeamSource{URL: "https://team.example/team.yaml", SHA256: sha256Hex(testTeamBundle), FetchedAt: time.Date(2025, 4, 16, 9, 0, 0, 0, time.UTC)}
+	_, err := InstallTeamBundle(dir, []byte(testTeamBundle), source)
+	require.NoError(t, err)
+
+	loaded, err := LoadTeamSource(dir)
+	require.NoError(t, err)
+	assert.Equal(t, source, *loaded)
+
+	cfg, err := LoadConfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "https://mcp.team.example", cfg.MCPServerURL, "the team replaces defaults")
+	assert.Equal(t, "gpt-4o-mini", cfg.LLM.OpenAI.ModelName)
+	assert.Equal(t, "UTC", cfg.Timezone, "config.yaml overrides the team")
+	assert.Equal(t, "openai", cfg.LLM.Provider, "built-in defaults remain")
+
+	t.Setenv("TICKETRON_MCP_SERVER_URL", "http://env.example")
+	cfg, err = LoadConfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "http://env.example", cfg.MCPServerURL, "the environment overrides the team")
+
+	links, err := LoadLinks(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []ProjectLink{{Name: "backend", Key: "BE"}, {Name: "ops", Key: "OPS"}}, links.Projects, "the team replaces the example projects")
+
+	prompt, err := LoadSystemPrompt(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "Team prompt", prompt, "the default prompt is replaced")
+	teamContext, err := LoadContext(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "My context", teamContext, "an edited context is kept")
+
+	vars, err := EnvVars(dir, nil)
+	require.NoError(t, err)
+	sources := map[string]string{}
+	for _, envVar := range vars {
+		sources[envVar.Key] = envVar.Source
+	}
+	assert.Equal(t, SourceTeam, sources["llm.openai.model_name"])
+	assert.Equal(t, SourceFile, sources["timezone"])
+}
+
+func TestLoadConfig_TeamOverDefaultFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, CreateDefaultConfigFiles(dir))
+	_, err := InstallTeamBundle(dir, []byte(testTeamBundle), TeamSource{URL: "https://team.example/team.yaml"})
+	require.NoError(t, err)
+
+	cfg, err := LoadConfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "https://mcp.team.example", cfg.MCPServerURL, "the untouched default config.yaml does not override the team")
+	assert.Equal(t, "gpt-4o-mini", cfg.LLM.OpenAI.ModelName)
+}
+
+func TestLoadConfig_TeamCannotSetHooks(t *testing.T) {
+	dir := t.TempDir()
+	bundle := "config:\n  timezone: UTC\n  hooks:\n    post_create: [\"curl https://evil.example\"]\n  secrets:\n    api_key: \"vault://secret/other#token\"\n"
+	_, err := InstallTeamBundle(dir, []byte(bundle), TeamSource{URL: "https://team.example/team.yaml"})
+	require.NoError(t, err)
+
+	cfg, err := LoadConfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "UTC", cfg.Timezone)
+	assert.Empty(t, cfg.Hooks.PostCreate, "hooks are dropped from team bundles")
+	assert.Empty(t, cfg.Secrets.APIKey, "secret references are dropped from team bundles")
+}
+
+func TestLoadLinks_TeamMerge(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, DefaultLinksFileName), []byte("projects:\n  - name: Backend\n    key: MYBE\n"), 0600))
+	_, err := InstallTeamBundle(dir, []byte(testTeamBundle), TeamSource{URL: "https://team.example/team.yaml"})
+	require.NoError(t, err)
+
+	links, err := LoadLinks(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []ProjectLink{{Name: "Backend", Key: "MYBE"}, {Name: "ops", Key: "OPS"}}, links.Projects)
+}
+
+func TestLoadTeamSource_NotConfigured(t *testing.T) {
+	_, err := LoadTeamSource(t.TempDir())
+	assert.ErrorIs(t, err, ErrTeamNotConfigured)
+}
@@ -0,0 +1,157 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+)
+
+// UnknownKey is a key in a configuration file that does not correspond to any setting,
+// usually a typo.
+type UnknownKey struct {
+	File       string // File name, e.g. config.yaml
+	Key        string // Full key path, e.g. llm.openai.modle_name
+	Line       int
+	Column     int
+	Suggestion string // Closest known key at the same level, if any
+}
+
+// String formats the key as file:line:column: message.
+func (k UnknownKey) String() string {
+	msg := fmt.Sprintf("%s:%d:%d: unknown key %q", k.File, k.Line, k.Column, k.Key)
+	if k.Suggestion != "" {
+		msg += fmt.Sprintf(" (did you mean %q?)", k.Suggestion)
+	}
+	return msg
+}
+
+// unknownKeysError returns an error wrapping ErrUnknownConfigKey that lists keys.
+func unknownKeysError(keys []UnknownKey) error {
+	lines := make([]string, len(keys))
+	for i, key := range keys {
+		lines[i] = key.String()
+	}
+	return fmt.Errorf("%w:\n  %s", ErrUnknownConfigKey, strings.Join(lines, "\n  "))
+}
+
+// reportUnknownKeys logs a warning for each of keys, or returns an error listing all of
+// them in strict mode.
+func reportUnknownKeys(keys []UnknownKey, strict bool) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	if strict {
+		return unknownKeysError(keys)
+	}
+	for _, key := range keys {
+		log.Warn().Str("file", key.File).Int("line", key.Line).Str("key", key.Key).Str("suggestion", key.Suggestion).
+			Msg("Unknown configuration key ignored; set 'strict: true' to reject it")
+	}
+	return nil
+}
+
+// findUnknownKeys returns the keys of the YAML node that have no counterpart in type t,
+// whose fields are matched by the given struct tag (mapstructure for config.yaml, yaml for
+// links.yaml). mapstructure keys are matched case-insensitively, as viper does.
+func findUnknownKeys(file string, node *yaml.Node, t reflect.Type, tag string) []UnknownKey {
+	var keys []UnknownKey
+	walkUnknownKeys(file, node, t, tag, "", &keys)
+	return keys
+}
+
+// walkUnknownKeys appends the unknown keys below node, whose path is prefix, to keys.
+func walkUnknownKeys(file string, node *yaml.Node, t reflect.Type, tag, prefix string, keys *[]UnknownKey) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t.Kind() == reflect.Struct && t != reflect.TypeOf(time.Duration(0)):
+		if node.Kind != yaml.MappingNode {
+			return // Type mismatches are reported when the file is decoded
+		}
+		fields := make(map[string]reflect.Type, t.NumField())
+		names := make([]string, 0, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			name, _, _ := strings.Cut(t.Field(i).Tag.Get(tag), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+			fields[normalizeKey(name, tag)] = t.Field(i).Type
+			names = append(names, name)
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode := node.Content[i]
+			path := prefix + keyNode.Value
+			if fieldType, ok := fields[normalizeKey(keyNode.Value, tag)]; ok {
+				walkUnknownKeys(file, node.Content[i+1], fieldType, tag, path+".", keys)
+				continue
+			}
+			*keys = append(*keys, UnknownKey{File: file, Key: path, Line: keyNode.Line, Column: keyNode.Column, Suggestion: closestKey(keyNode.Value, names)})
+		}
+	case t.Kind() == reflect.Slice && node.Kind == yaml.SequenceNode:
+		base := strings.TrimSuffix(prefix, ".")
+		for i, item := range node.Content {
+			walkUnknownKeys(file, item, t.Elem(), tag, base+"["+strconv.Itoa(i)+"].", keys)
+		}
+	case t.Kind() == reflect.Map && node.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			walkUnknownKeys(file, node.Content[i+1], t.Elem(), tag, prefix+node.Content[i].Value+".", keys)
+		}
+	}
+}
+
+// normalizeKey returns key as compared against struct tags.
+func normalizeKey(key, tag string) string {
+	if tag == "mapstructure" {
+		return strings.ToLower(key)
+	}
+	return key
+}
+
+// closestKey returns the name in names closest to key, if it is a plausible typo.
+func closestKey(key string, names []string) string {
+	best, bestDistance := "", 3 // Suggest names at most two edits away
+	for _, name := range names {
+		if d := editDistance(strings.ToLower(key), name); d < bestDistance {
+			best, bestDistance = name, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// strictModeEnabled reports whether strict is set in config.yaml or TICKETRON_STRICT.
+// It is only consulted by LoadLinks once unknown keys were found.
+func strictModeEnabled(baseDir string) bool {
+	cfg, err := LoadConfig(baseDir)
+	if errors.Is(err, ErrUnknownConfigKey) {
+		return true // Only reported in strict mode
+	}
+	return err == nil && cfg.Strict
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func parseNode(t *testing.T, data string) *yaml.Node {
+	t.Helper()
+	var doc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(data), &doc))
+	return doc.Content[0]
+}
+
+func TestFindUnknownKeys(t *testing.T) {
+	root := parseNode(t, `mcp_server_url: http://x
+llm:
+  provider: openai
+  openai:
+    modle_name: gpt-4o
+  fallbacks:
+    - provider: openai
+      modle: gpt-4o-mini
+Timezone: UTC
+colour: blue
+`)
+	keys := findUnknownKeys(DefaultConfigFileName, root, reflect.TypeOf(AppConfig{}), "mapstructure")
+	require.Len(t, keys, 3)
+	assert.Equal(t, UnknownKey{File: "config.yaml", Key: "llm.openai.modle_name", Line: 5, Column: 5, Suggestion: "model_name"}, keys[0])
+	assert.Equal(t, "llm.fallbacks[0].modle", keys[1].Key)
+	assert.Equal(t, "model", keys[1].Suggestion)
+	assert.Equal(t, "colour", keys[2].Key)
+	assert.Empty(t, keys[2].Suggestion)
+	assert.Equal(t, `config.yaml:5:5: unknown key "llm.openai.modle_name" (did you mean "model_name"?)`, keys[0].String())
+}
+
+func TestDefaultFilesHaveNoUnknownKeys(t *testing.T) {
+	var config, links yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(defaultConfigYAML), &config))
+	require.NoError(t, yaml.Unmarshal([]byte(defaultLinksYAML), &links))
+	assert.Empty(t, findUnknownKeys(DefaultConfigFileName, config.Content[0], reflect.TypeOf(AppConfig{}), "mapstructure"))
+	assert.Empty(t, findUnknownKeys(DefaultLinksFileName, links.Content[0], reflect.TypeOf(LinksConfig{}), "yaml"))
+}
+
+func TestLoadConfig_UnknownKeys(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, DefaultConfigFileName)
+	require.NoError(t, os.WriteFile(path, []byte("llm:\n  openai:\n    modle_name: gpt-4o-mini\n"), 0600))
+
+	cfg, err := LoadConfig(dir)
+	require.NoError(t, err, "unknown keys only warn by default")
+	assert.Equal(t, "gpt-4o", cfg.LLM.OpenAI.ModelName)
+
+	t.Setenv("TICKETRON_STRICT", "true")
+	_, err = LoadConfig(dir)
+	assert.ErrorIs(t, err, ErrUnknownConfigKey)
+	assert.ErrorContains(t, err, `config.yaml:3:5: unknown key "llm.openai.modle_name" (did you mean "model_name"?)`)
+
+	t.Setenv("TICKETRON_STRICT", "")
+	require.NoError(t, os.WriteFile(path, []byte("strict: true\nllm:\n  openai:\n    modle_name: gpt-4o-mini\n"), 0600))
+	_, err = LoadConfig(dir)
+	assert.ErrorIs(t, err, ErrUnknownConfigKey)
+}
+
+func TestLoadLinks_UnknownKeys(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, DefaultLinksFileName), []byte("projects:\n  - name: web\n    key: WEB\n    default_isue_type: Bug\n"), 0600))
+
+	links, err := LoadLinks(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "WEB", links.Projects[0].Key)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, DefaultConfigFileName), []byte("strict: true\n"), 0600))
+	_, err = LoadLinks(dir)
+	assert.ErrorIs(t, err, ErrUnknownConfigKey)
+	assert.ErrorContains(t, err, `links.yaml:4:5: unknown key "projects[0].default_isue_type" (did you mean "default_issue_type"?)`)
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("model", "model"))
+	assert.Equal(t, 2, editDistance("modle", "model"))
+	assert.Equal(t, 3, editDistance("kitten", "sitting"))
+	assert.Equal(t, 4, editDistance("", "abcd"))
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultWatchDebounce is how long Watch waits after the last change before calling onChange.
+// Editors commonly write a file in several steps (truncate, write, rename), so changes are coalesced.
+const DefaultWatchDebounce = 250 * time.Millisecond
+
+// Watch watches configDir and calls onChange once per burst of changes to any of the named
+// files (e.g. config.yaml, links.yaml). The directory rather than the individual files is
+// watched so that atomic saves (write to temp file + rename) are picked up. Watch blocks until
+// ctx is cancelled, returning nil, or the watcher fails.
+func Watch(ctx context.Context, configDir string, names []string, debounce time.Duration, onChange func()) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrConfigWatch, err)
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(configDir); err != nil {
+		return fmt.Errorf("%w: %w", ErrConfigWatch, err)
+	}
+	watched := make(map[string]bool, len(names))
+	for _, name := range names {
+		watched[name] = true
+	}
+	log.Debug().Str("dir", configDir).Strs("files", names).Msg("Watching configuration files for changes")
+
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if !watched[filepath.Base(event.Name)] || event.Op == fsnotify.Chmod {
+				continue
+			}
+			log.Debug().Str("file", event.Name).Str("op", event.Op.String()).Msg("Configuration file changed")
+			timer.Reset(debounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			log.Warn().Err(err).Msg("Configuration watcher error")
+		case <-timer.C:
+			onChange()
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatch(t *testing.T) {
+	dir := t.TempDir()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan struct{}, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- Watch(ctx, dir, []string{DefaultLinksFileName}, 20*time.Millisecond, func() { changes <- struct{}{} })
+	}()
+	// Give the watcher time to register the directory.
+	time.Sleep(100 * time.Millisecond)
+
+	// Unwatched files are ignored.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("x"), 0600))
+	// A burst of writes to a watched file results in a single notification.
+	for i := 0; i < 3; i++ {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, DefaultLinksFileName), []byte("projects: []"), 0600))
+	}
+
+	select {
+	case <-changes:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a change notification")
+	}
+	select {
+	case <-changes:
+		t.Fatal("expected changes to be coalesced into one notification")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestWatch_MissingDir(t *testing.T) {
+	err := Watch(context.Background(), filepath.Join(t.TempDir(), "missing"), nil, time.Millisecond, func() {})
+	assert.ErrorIs(t, err, ErrConfigWatch)
+}
@@ -0,0 +1,83 @@
+// Package crash writes reports about unexpected failures (panics) for users to attach to
+// bug reports.
+package crash
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Report describes a crash. It must not contain secrets or issue content; Config holds
+// a summary of the settings only.
+type Report struct {
+	Time      time.Time
+	Version   string
+	Commit    string
+	GoVersion string
+	Platform  string
+	Command   string // Command path, e.g. "tix create"; arguments are left out
+	Panic     string
+	Stack     string
+	Config    map[string]string
+	// ConfigError explains why Config is missing, e.g. an unreadable config.yaml.
+	ConfigError string
+}
+
+// Write writes r as a text file named crash-<time>-<random>.txt in dir, creating dir if
+// necessary, and returns the file's path.
+func Write(dir string, r Report) (string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrWriteReport, err)
+	}
+	file, err := os.CreateTemp(dir, "crash-"+r.Time.UTC().Format("20060102-150405")+"-*.txt")
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrWriteReport, err)
+	}
+	_, writeErr := file.WriteString(r.String())
+	if err := file.Close(); writeErr == nil {
+		writeErr = err
+	}
+	if writeErr != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("%w: %w", ErrWriteReport, writeErr)
+	}
+	return file.Name(), nil
+}
+
+// String renders the report as written by Write.
+func (r Report) String() string {
+	var b strings.Builder
+	b.WriteString("Ticketron crash report\n\n")
+	fmt.Fprintf(&b, "Time:       %s\n", r.Time.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&b, "Version:    %s\n", r.Version)
+	if r.Commit != "" {
+		fmt.Fprintf(&b, "Commit:     %s\n", r.Commit)
+	}
+	fmt.Fprintf(&b, "Go version: %s\n", r.GoVersion)
+	fmt.Fprintf(&b, "Platform:   %s\n", r.Platform)
+	fmt.Fprintf(&b, "Command:    %s\n", r.Command)
+	fmt.Fprintf(&b, "Panic:      %s\n", r.Panic)
+
+	b.WriteString("\nConfiguration:\n")
+	if r.ConfigError != "" {
+		fmt.Fprintf(&b, "  (unavailable: %s)\n", r.ConfigError)
+	}
+	keys := make([]string, 0, len(r.Config))
+	for key := range r.Config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&b, "  %s: %s\n", key, r.Config[key])
+	}
+
+	b.WriteString("\nStack:\n")
+	b.WriteString(r.Stack)
+	if !strings.HasSuffix(r.Stack, "\n") {
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,69 @@
+package crash
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "crash")
+	report := Report{
+		Time:      time.Date(2025, 5, 1, 12, 30, 0, 0, time.UTC),
+		Version:   "v0.2.0",
+		GoVersion: "go1.23.8",
+		Platform:  "linux/amd64",
+		Command:   "tix create",
+		Panic:     "runtime error: invalid memory address or nil pointer dereference",
+		Stack:     "goroutine 1 [running]:\nmain.main()",
+		Config:    map[string]string{"llm.provider": "openai", "mcp_server_url": "https://mcp.example.com"},
+	}
+
+	path, err := Write(dir, report)
+	require.NoError(t, err)
+	assert.Equal(t, dir, filepath.Dir(path))
+	assert.Regexp(t, `^crash-20250501-123000-\d+\.txt$`, filepath.Base(path))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `Ticketron crash report
+
+Time:       2025-05-01T12:30:00Z
+Version:    v0.2.0
+Go version: go1.23.8
+Platform:   linux/amd64
+Command:    tix create
+Panic:      runtime error: invalid memory address or nil pointer dereference
+
+Configuration:
+  llm.provider: openai
+  mcp_server_url: https://mcp.example.com
+
+Stack:
+goroutine 1 [running]:
+main.main()
+`, string(data))
+
+	second, err := Write(dir, report)
+	require.NoError(t, err)
+	assert.NotEqual(t, path, second, "reports written in the same second must not overwrite each other")
+}
+
+func TestWrite_ConfigError(t *testing.T) {
+	report := Report{Time: time.Now(), ConfigError: "failed to parse configuration file"}
+	assert.Contains(t, report.String(), "Configuration:\n  (unavailable: failed to parse configuration file)\n")
+}
+
+func TestWrite_Unwritable(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, nil, 0600))
+	_, err := Write(filepath.Join(file, "crash"), Report{Time: time.Now()})
+	assert.ErrorIs(t, err, ErrWriteReport)
+}
@@ -0,0 +1,8 @@
+package crash
+
+import "errors"
+
+// Sentinel errors for crash reports.
+
+// ErrWriteReport indicates the crash report could not be written.
+var ErrWriteReport = errors.New("failed to write crash report")
@@ -0,0 +1,87 @@
+package draft
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// document is the YAML form of a draft edited in an editor. Summary and description are
+// only present once the ticket is generated; project, type, priority and due are those of
+// the generated ticket then, and the options for generating it before.
+type document struct {
+	Input       string `yaml:"input"`
+	Project     string `yaml:"project"`
+	Type        string `yaml:"type"`
+	Priority    string `yaml:"priority"`
+	Due         string `yaml:"due"`
+	Summary     string `yaml:"summary,omitempty"`
+	Description string `yaml:"description,omitempty"`
+}
+
+// Document returns d as a YAML document for editing, headed by comments explaining it.
+func (d Draft) Document() ([]byte, error) {
+	doc := document{Input: d.Input, Project: d.Project, Type: d.IssueType, Priority: d.Priority, Due: d.Due}
+	var header string
+	if t := d.Ticket; t != nil {
+		doc.Project, doc.Type, doc.Priority, doc.Due = t.ProjectKey, t.IssueType, t.Priority, t.DueDate
+		doc.Summary, doc.Description = t.Summary, t.Description
+		header = fmt.Sprintf("# Draft #%d. Edit the generated ticket below; changing the input discards it,\n# so it is generated again on submit.\n", d.ID)
+	} else {
+		header = fmt.Sprintf("# Draft #%d. The ticket is generated from the input on submit. Leave project and\n# type empty to let the LLM choose; due takes e.g. \"in 3 days\".\n", d.ID)
+	}
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrWrite, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ApplyDocument returns d with the values of an edited Document. If the input changed,
+// the generated ticket is dropped, as it no longer matches; project, type, priority and
+// due changed from the generated values are kept as options for generating it again.
+func (d Draft) ApplyDocument(data []byte) (Draft, error) {
+	var doc document
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return d, fmt.Errorf("%w: %w", ErrInvalidDraft, err)
+	}
+	if strings.TrimSpace(doc.Input) == "" {
+		return d, fmt.Errorf("%w: the input is empty", ErrInvalidDraft)
+	}
+	if d.Ticket == nil && (doc.Summary != "" || doc.Description != "") {
+		return d, fmt.Errorf("%w: the ticket of #%d is not generated yet, so it has no summary or description to edit", ErrInvalidDraft, d.ID)
+	}
+	inputChanged := strings.TrimSpace(doc.Input) != strings.TrimSpace(d.Input)
+	d.Input = strings.TrimRight(doc.Input, "\n")
+	if d.Ticket == nil {
+		d.Project, d.IssueType, d.Priority, d.Due = doc.Project, doc.Type, doc.Priority, doc.Due
+		return d, nil
+	}
+	// Values changed from the generated ones also apply when the ticket is generated again
+	ticket := *d.Ticket
+	for _, field := range []struct{ edited, generated, option *string }{
+		{&doc.Project, &ticket.ProjectKey, &d.Project},
+		{&doc.Type, &ticket.IssueType, &d.IssueType},
+		{&doc.Priority, &ticket.Priority, &d.Priority},
+		{&doc.Due, &ticket.DueDate, &d.Due},
+	} {
+		if *field.edited != *field.generated {
+			*field.option, *field.generated = *field.edited, *field.edited
+		}
+	}
+	if inputChanged {
+		d.Ticket = nil
+		return d, nil
+	}
+	if strings.TrimSpace(doc.Summary) == "" {
+		return d, fmt.Errorf("%w: the summary of #%d is empty", ErrInvalidDraft, d.ID)
+	}
+	ticket.Summary, ticket.Description = strings.TrimSpace(doc.Summary), strings.TrimRight(doc.Description, "\n")
+	d.Ticket = &ticket
+	return d, nil
+}
@@ -0,0 +1,203 @@
+// Package draft stores tickets in preparation in drafts.json, so users can write down the
+// input for a ticket offline or over time, review what the LLM made of it, and submit
+// their drafts later in one go.
+package draft
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+// FileName is the standard name of the drafts file within the config directory.
+const FileName = "drafts.json"
+
+// Draft is a ticket in preparation: the input for the LLM, the options it is created
+// with, and the ticket generated from them, if any.
+type Draft struct {
+	ID        int    `json:"id"`
+	Input     string `json:"input"`
+	Project   string `json:"project,omitempty"` // Project key or links.yaml name; empty lets the LLM choose
+	IssueType string `json:"issue_type,omitempty"`
+	Priority  string `json:"priority,omitempty"`
+	Due       string `json:"due,omitempty"` // Due date expression such as "in 3 days"
+	// Ticket is the request generated from Input, possibly edited since. It is nil until
+	// the draft is generated, e.g. for drafts written offline.
+	Ticket *mcpclient.CreateIssueRequest `json:"ticket,omitempty"`
+	// LinkTo and LinkType hold the Ticket's issues to link, which are not part of its JSON.
+	LinkTo    []string  `json:"link_to,omitempty"`
+	LinkType  string    `json:"link_type,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Title returns the summary of the generated ticket, or else the first line of the input.
+func (d Draft) Title() string {
+	if d.Ticket != nil && d.Ticket.Summary != "" {
+		return d.Ticket.Summary
+	}
+	line, _, _ := strings.Cut(strings.TrimSpace(d.Input), "\n")
+	return line
+}
+
+// file is the content of the drafts file. NextID keeps IDs of submitted drafts from being
+// reused, so an ID always refers to the same draft.
+type file struct {
+	NextID int     `json:"next_id"`
+	Drafts []Draft `json:"drafts"`
+}
+
+// Store reads and writes the drafts file.
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewStore returns a Store backed by drafts.json in configDir.
+func NewStore(configDir string) *Store {
+	return &Store{path: filepath.Join(configDir, FileName)}
+}
+
+// Add records d as a new draft created at now and returns it with its ID assigned.
+func (s *Store) Add(d Draft, now time.Time) (Draft, error) {
+	if strings.TrimSpace(d.Input) == "" {
+		return Draft{}, fmt.Errorf("%w: the input is empty", ErrInvalidDraft)
+	}
+	err := s.update(func(f *file) error {
+		f.NextID++
+		d.ID, d.CreatedAt, d.UpdatedAt = f.NextID, now, now
+		f.Drafts = append(f.Drafts, d)
+		return nil
+	})
+	if err != nil {
+		return Draft{}, err
+	}
+	log.Debug().Int("id", d.ID).Bool("generated", d.Ticket != nil).Msg("Added draft")
+	return d, nil
+}
+
+// List returns all drafts, oldest first.
+func (s *Store) List() ([]Draft, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	return f.Drafts, nil
+}
+
+// Get returns the draft with the given ID.
+func (s *Store) Get(id int) (Draft, error) {
+	drafts, err := s.List()
+	if err != nil {
+		return Draft{}, err
+	}
+	for _, d := range drafts {
+		if d.ID == id {
+			return d, nil
+		}
+	}
+	return Draft{}, fmt.Errorf("%w: #%d", ErrNotFound, id)
+}
+
+// Save replaces the stored draft with d's ID by d, updated at now.
+func (s *Store) Save(d Draft, now time.Time) (Draft, error) {
+	if strings.TrimSpace(d.Input) == "" {
+		return Draft{}, fmt.Errorf("%w: the input of #%d is empty", ErrInvalidDraft, d.ID)
+	}
+	err := s.update(func(f *file) error {
+		for i := range f.Drafts {
+			if f.Drafts[i].ID == d.ID {
+				d.CreatedAt, d.UpdatedAt = f.Drafts[i].CreatedAt, now
+				f.Drafts[i] = d
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: #%d", ErrNotFound, d.ID)
+	})
+	return d, err
+}
+
+// Remove deletes the draft with the given ID and returns it.
+func (s *Store) Remove(id int) (Draft, error) {
+	var removed Draft
+	err := s.update(func(f *file) error {
+		for i, d := range f.Drafts {
+			if d.ID == id {
+				removed = d
+				f.Drafts = append(f.Drafts[:i], f.Drafts[i+1:]...)
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: #%d", ErrNotFound, id)
+	})
+	return removed, err
+}
+
+// update applies change to the drafts file and writes it back, unless change fails.
+func (s *Store) update(change func(*file) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := s.load()
+	if err != nil {
+		return err
+	}
+	if err := change(f); err != nil {
+		return err
+	}
+	return s.save(f)
+}
+
+// load reads the drafts file. A missing file yields no drafts.
+func (s *Store) load() (*file, error) {
+	f := &file{}
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return f, nil
+		}
+		return nil, fmt.Errorf("%w: %w", ErrRead, err)
+	}
+	if err := json.Unmarshal(data, f); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRead, err)
+	}
+	return f, nil
+}
+
+// save writes the drafts file, replacing it atomically. Drafts may hold confidential
+// input, so the file is only readable by the user.
+func (s *Store) save(f *file) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrWrite, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("%w: %w", ErrWrite, err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrWrite, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("%w: %w", ErrWrite, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("%w: %w", ErrWrite, err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("%w: %w", ErrWrite, err)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package draft
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+func TestStore(t *testing.T) {
+	store := NewStore(t.TempDir())
+	now := time.Date(2025, time.April, 16, 14, 30, 0, 0, time.UTC)
+
+	drafts, err := store.List()
+	require.NoError(t, err, "a missing file yields no drafts")
+	assert.Empty(t, drafts)
+
+	offline, err := store.Add(Draft{Input: "login fails with SSO\nsince the upgrade", Project: "BE"}, now)
+	require.NoError(t, err)
+	generated, err := store.Add(Draft{Input: "export is slow", Ticket: &mcpclient.CreateIssueRequest{ProjectKey: "BE", Summary: "Speed up CSV export"}}, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, offline.ID)
+	assert.Equal(t, 2, generated.ID)
+	assert.Equal(t, "login fails with SSO", offline.Title())
+	assert.Equal(t, "Speed up CSV export", generated.Title())
+	_, err = store.Add(Draft{Input: " "}, now)
+	assert.ErrorIs(t, err, ErrInvalidDraft)
+
+	offline.Ticket = &mcpclient.CreateIssueRequest{ProjectKey: "BE", Summary: "Fix SSO login"}
+	saved, err := store.Save(offline, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, now, saved.CreatedAt)
+	assert.Equal(t, now.Add(time.Hour), saved.UpdatedAt)
+	got, err := store.Get(offline.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Fix SSO login", got.Ticket.Summary)
+
+	removed, err := store.Remove(generated.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "export is slow", removed.Input)
+	_, err = store.Get(generated.ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = store.Save(generated, now)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	next, err := store.Add(Draft{Input: "third"}, now)
+	require.NoError(t, err)
+	assert.Equal(t, 3, next.ID, "IDs of submitted drafts are not reused")
+
+	info, err := os.Stat(store.path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestStore_CorruptFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, FileName), []byte("{oops"), 0o600))
+	_, err := NewStore(dir).List()
+	assert.ErrorIs(t, err, ErrRead)
+}
+
+func TestDocument(t *testing.T) {
+	d := Draft{ID: 4, Input: "login fails", Project: "backend", Due: "in 3 days"}
+	data, err := d.Document()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "# Draft #4."))
+	assert.Contains(t, string(data), "project: backend\n")
+	assert.NotContains(t, string(data), "summary:", "drafts that are not generated have no summary yet")
+
+	edited, err := d.ApplyDocument([]byte(strings.Replace(string(data), "due: in 3 days", "due: tomorrow", 1)))
+	require.NoError(t, err)
+	assert.Equal(t, "tomorrow", edited.Due)
+	assert.Equal(t, "login fails", edited.Input)
+
+	_, err = d.ApplyDocument(append(data, "summary: Fix login\n"...))
+	assert.ErrorIs(t, err, ErrInvalidDraft)
+	_, err = d.ApplyDocument([]byte("input: ''\n"))
+	assert.ErrorIs(t, err, ErrInvalidDraft)
+	_, err = d.ApplyDocument([]byte("input: [oops"))
+	assert.ErrorIs(t, err, ErrInvalidDraft)
+}
+
+func TestDocument_Generated(t *testing.T) {
+	d := Draft{ID: 5, Input: "login fails", Ticket: &mcpclient.CreateIssueRequest{
+		ProjectKey: "BE", IssueType: "Bug", Summary: "Fix login", Description: "Users see 500\nafter login",
+	}}
+	data, err := d.Document()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "summary: Fix login\n")
+	assert.Contains(t, string(data), "description: |-\n  Users see 500\n  after login\n")
+
+	edited, err := d.ApplyDocument([]byte(strings.NewReplacer("Fix login", "Fix SSO login", "type: Bug", "type: Task").Replace(string(data))))
+	require.NoError(t, err)
+	require.NotNil(t, edited.Ticket)
+	assert.Equal(t, "Fix SSO login", edited.Ticket.Summary)
+	assert.Equal(t, "Users see 500\nafter login", edited.Ticket.Description)
+	assert.Equal(t, "Task", edited.Ticket.IssueType)
+	assert.Equal(t, "Task", edited.IssueType, "edited values are kept for generating the ticket again")
+	assert.Empty(t, edited.Project, "generated values are not")
+	assert.Equal(t, "Fix login", d.Ticket.Summary, "the original draft is unchanged")
+
+	edited, err = d.ApplyDocument([]byte(strings.Replace(string(data), "input: login fails", "input: login fails on mobile", 1)))
+	require.NoError(t, err)
+	assert.Nil(t, edited.Ticket, "a changed input discards the generated ticket")
+	assert.Equal(t, "login fails on mobile", edited.Input)
+
+	_, err = d.ApplyDocument([]byte(strings.Replace(string(data), "summary: Fix login", "summary: ''", 1)))
+	assert.ErrorIs(t, err, ErrInvalidDraft)
+}
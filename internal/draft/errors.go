@@ -0,0 +1,17 @@
+package draft
+
+import "errors"
+
+// Sentinel errors for ticket drafts.
+
+// ErrRead indicates an error occurred while reading the drafts file.
+var ErrRead = errors.New("failed to read drafts")
+
+// ErrWrite indicates an error occurred while writing the drafts file.
+var ErrWrite = errors.New("failed to write drafts")
+
+// ErrInvalidDraft indicates a draft has no input.
+var ErrInvalidDraft = errors.New("invalid draft")
+
+// ErrNotFound indicates no draft has the requested ID.
+var ErrNotFound = errors.New("draft not found")
@@ -0,0 +1,11 @@
+package export
+
+import "errors"
+
+// Sentinel errors for exporting tabular data.
+
+// ErrUnsupportedFormat indicates the requested export format is not supported.
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+// ErrWrite indicates the export output could not be written.
+var ErrWrite = errors.New("failed to write export")
@@ -0,0 +1,151 @@
+// Package export writes rows of issue data as CSV, JSON or XLSX. Rows are streamed to
+// the underlying writer as they arrive so large result sets need not be held in memory.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/karolswdev/ticketron/internal/sanitize"
+)
+
+// Supported formats.
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+	FormatXLSX = "xlsx"
+)
+
+// Writer writes a header followed by any number of rows. Close must be called to
+// finish the document; it does not close the underlying io.Writer.
+type Writer interface {
+	WriteHeader(columns []string) error
+	WriteRow(values []interface{}) error
+	Close() error
+}
+
+// NewWriter returns a Writer producing format on w.
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch strings.ToLower(format) {
+	case FormatCSV:
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	case FormatJSON:
+		return &jsonWriter{w: w}, nil
+	case FormatXLSX:
+		return newXLSXWriter(w), nil
+	default:
+		return nil, fmt.Errorf("%w %q (expected csv, json or xlsx)", ErrUnsupportedFormat, format)
+	}
+}
+
+// FormatFromPath infers the format from a file extension, returning "" if unknown.
+func FormatFromPath(path string) string {
+	switch ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), ".")); ext {
+	case FormatCSV, FormatJSON, FormatXLSX:
+		return ext
+	default:
+		return ""
+	}
+}
+
+// formatCell renders a value for text-based cells; nil becomes an empty string. Control
+// characters and byte order marks are removed, as spreadsheets reject or misread them.
+func formatCell(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return sanitize.Text(fmt.Sprintf("%v", v))
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvWriter) WriteHeader(columns []string) error {
+	return c.write(columns)
+}
+
+func (c *csvWriter) WriteRow(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, v := range values {
+		record[i] = formatCell(v)
+	}
+	return c.write(record)
+}
+
+func (c *csvWriter) write(record []string) error {
+	if err := c.w.Write(record); err != nil {
+		return fmt.Errorf("%w: %w", ErrWrite, err)
+	}
+	return nil
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	if err := c.w.Error(); err != nil {
+		return fmt.Errorf("%w: %w", ErrWrite, err)
+	}
+	return nil
+}
+
+// jsonWriter streams a JSON array of objects keyed by column name, preserving column order.
+type jsonWriter struct {
+	w       io.Writer
+	columns []string
+	rows    int
+}
+
+func (j *jsonWriter) WriteHeader(columns []string) error {
+	j.columns = columns
+	_, err := io.WriteString(j.w, "[")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrWrite, err)
+	}
+	return nil
+}
+
+func (j *jsonWriter) WriteRow(values []interface{}) error {
+	var b strings.Builder
+	if j.rows > 0 {
+		b.WriteString(",")
+	}
+	b.WriteString("\n  {")
+	for i, column := range j.columns {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		var value interface{}
+		if i < len(values) {
+			value = values[i]
+		}
+		key, _ := json.Marshal(column)
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrWrite, err)
+		}
+		b.Write(key)
+		b.WriteString(": ")
+		b.Write(data)
+	}
+	b.WriteString("}")
+	j.rows++
+	if _, err := io.WriteString(j.w, b.String()); err != nil {
+		return fmt.Errorf("%w: %w", ErrWrite, err)
+	}
+	return nil
+}
+
+func (j *jsonWriter) Close() error {
+	end := "\n]\n"
+	if j.rows == 0 {
+		end = "]\n"
+	}
+	if _, err := io.WriteString(j.w, end); err != nil {
+		return fmt.Errorf("%w: %w", ErrWrite, err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeAll(t *testing.T, format string, rows [][]interface{}) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriter(format, &buf)
+	require.NoError(t, err)
+	require.NoError(t, w.WriteHeader([]string{"Key", "Summary", "Points"}))
+	for _, row := range rows {
+		require.NoError(t, w.WriteRow(row))
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+var testRows = [][]interface{}{
+	{"BE-1", "Login fails, \"sometimes\"", 3},
+	{"BE-2", "Fix <footer> & header", nil},
+}
+
+func TestCSV(t *testing.T) {
+	out := writeAll(t, "csv", testRows)
+	assert.Equal(t, "Key,Summary,Points\nBE-1,\"Login fails, \"\"sometimes\"\"\",3\nBE-2,Fix <footer> & header,\n", string(out))
+}
+
+func TestJSON(t *testing.T) {
+	out := writeAll(t, "JSON", testRows)
+	var decoded []map[string]interface{}
+	require.NoError(t, json.Unmarshal(out, &decoded))
+	assert.Equal(t, []map[string]interface{}{
+		{"Key": "BE-1", "Summary": "Login fails, \"sometimes\"", "Points": float64(3)},
+		{"Key": "BE-2", "Summary": "Fix <footer> & header", "Points": nil},
+	}, decoded)
+
+	assert.Equal(t, "[]\n", string(writeAll(t, "json", nil)))
+}
+
+func TestXLSX(t *testing.T) {
+	out := writeAll(t, "xlsx", testRows)
+	zr, err := zip.NewReader(bytes.NewReader(out), int64(len(out)))
+	require.NoError(t, err)
+
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = string(data)
+	}
+	assert.Contains(t, files, "[Content_Types].xml")
+	assert.Contains(t, files, "_rels/.rels")
+	assert.Contains(t, files, "xl/workbook.xml")
+	assert.Contains(t, files, "xl/_rels/workbook.xml.rels")
+
+	sheet := files["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet, `<row r="1"><c r="A1" t="inlineStr"><is><t xml:space="preserve">Key</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="C2"><v>3</v></c>`)
+	assert.Contains(t, sheet, `Fix &lt;footer&gt; &amp; header`)
+	assert.NotContains(t, sheet, `r="C3"`, "nil values produce no cell")
+}
+
+func TestNewWriter_Unsupported(t *testing.T) {
+	_, err := NewWriter("ods", io.Discard)
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestFormatFromPath(t *testing.T) {
+	assert.Equal(t, "xlsx", FormatFromPath("report.XLSX"))
+	assert.Equal(t, "csv", FormatFromPath("/tmp/out.csv"))
+	assert.Equal(t, "", FormatFromPath("report.txt"))
+}
+
+func TestColumnName(t *testing.T) {
+	assert.Equal(t, "A", columnName(0))
+	assert.Equal(t, "Z", columnName(25))
+	assert.Equal(t, "AA", columnName(26))
+	assert.Equal(t, "AZ", columnName(51))
+	assert.Equal(t, "BA", columnName(52))
+}
+
+func TestCSV_SanitizesMultiByteContent(t *testing.T) {
+	out := writeAll(t, "csv", [][]interface{}{{"BE-3", "\uFEFF🚀 Zażółć\x1b[0m 日本語\r\nline two", 1}})
+	assert.Equal(t, "Key,Summary,Points\nBE-3,\"🚀 Zażółć[0m 日本語\nline two\",1\n", string(out))
+}
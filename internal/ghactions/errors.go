@@ -0,0 +1,8 @@
+package ghactions
+
+import "errors"
+
+// Sentinel errors for GitHub Actions integration.
+
+// ErrOutput indicates step outputs could not be written to the GITHUB_OUTPUT file.
+var ErrOutput = errors.New("failed to set step outputs")
@@ -0,0 +1,103 @@
+// Package ghactions integrates with GitHub Actions: it writes workflow commands, such as
+// ::notice and ::error annotations, and sets step outputs through the GITHUB_OUTPUT file,
+// so that workflows can use results without parsing the output of tix.
+package ghactions
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Environment variables set by the GitHub Actions runner.
+const (
+	EnvActions = "GITHUB_ACTIONS" // "true" in every workflow step
+	EnvOutput  = "GITHUB_OUTPUT"  // File the step outputs are appended to
+)
+
+// Enabled reports whether tix runs in a GitHub Actions workflow.
+func Enabled() bool {
+	return os.Getenv(EnvActions) == "true"
+}
+
+// Notice writes a ::notice annotation with title and message to w. The runner reads
+// workflow commands from both stdout and stderr.
+func Notice(w io.Writer, title, message string) {
+	command(w, "notice", title, message)
+}
+
+// Error writes an ::error annotation with title and message to w.
+func Error(w io.Writer, title, message string) {
+	command(w, "error", title, message)
+}
+
+// command writes the workflow command name with a title property and message.
+func command(w io.Writer, name, title, message string) {
+	properties := ""
+	if title != "" {
+		properties = " title=" + escapeProperty(title)
+	}
+	fmt.Fprintf(w, "::%s%s::%s\n", name, properties, escapeData(message))
+}
+
+// escapeData escapes the message of a workflow command.
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeProperty escapes a property value of a workflow command.
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// Output is a step output, available to later steps as steps.<id>.outputs.<Name>.
+type Output struct {
+	Name  string
+	Value string
+}
+
+// SetOutputs appends outputs to the GITHUB_OUTPUT file at path. Values spanning several
+// lines are written with a random delimiter.
+func SetOutputs(path string, outputs ...Output) error {
+	if path == "" {
+		return fmt.Errorf("%w: %s is not set", ErrOutput, EnvOutput)
+	}
+	var b strings.Builder
+	for _, output := range outputs {
+		if !strings.ContainsAny(output.Value, "\r\n") {
+			fmt.Fprintf(&b, "%s=%s\n", output.Name, output.Value)
+			continue
+		}
+		delimiter, err := newDelimiter()
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrOutput, err)
+		}
+		fmt.Fprintf(&b, "%s<<%s\n%s\n%s\n", output.Name, delimiter, output.Value, delimiter)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrOutput, err)
+	}
+	if _, err := f.WriteString(b.String()); err != nil {
+		f.Close()
+		return fmt.Errorf("%w: %w", ErrOutput, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("%w: %w", ErrOutput, err)
+	}
+	return nil
+}
+
+// newDelimiter returns a delimiter for a multi-line output that cannot occur in its value
+// by chance.
+func newDelimiter() (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return "ghadelimiter_" + hex.EncodeToString(random), nil
+}
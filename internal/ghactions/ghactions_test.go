@@ -0,0 +1,44 @@
+package ghactions
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnabled(t *testing.T) {
+	t.Setenv(EnvActions, "")
+	assert.False(t, Enabled())
+	t.Setenv(EnvActions, "true")
+	assert.True(t, Enabled())
+}
+
+func TestAnnotations(t *testing.T) {
+	var out bytes.Buffer
+	Notice(&out, "tix create", "Created PROJ-1: https://jira.example/browse/PROJ-1")
+	Error(&out, "Ticketron, step: 1", "100% failed\nsecond line\r")
+	Error(&out, "", "no title")
+	assert.Equal(t, "::notice title=tix create::Created PROJ-1: https://jira.example/browse/PROJ-1\n"+
+		"::error title=Ticketron%2C step%3A 1::100%25 failed%0Asecond line%0D\n"+
+		"::error::no title\n", out.String())
+}
+
+func TestSetOutputs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "output")
+	require.NoError(t, os.WriteFile(path, []byte("earlier=1\n"), 0o644))
+
+	require.NoError(t, SetOutputs(path, Output{Name: "issue_key", Value: "PROJ-1"}, Output{Name: "notes", Value: "a\nb"}))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^earlier=1\nissue_key=PROJ-1\nnotes<<(ghadelimiter_[0-9a-f]{32})\na\nb\n(ghadelimiter_[0-9a-f]{32})\n$`), string(data))
+	match := regexp.MustCompile(`ghadelimiter_[0-9a-f]{32}`).FindAllString(string(data), -1)
+	require.Len(t, match, 2)
+	assert.Equal(t, match[0], match[1])
+
+	assert.ErrorIs(t, SetOutputs("", Output{Name: "issue_key", Value: "PROJ-1"}), ErrOutput)
+}
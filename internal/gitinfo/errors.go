@@ -0,0 +1,14 @@
+package gitinfo
+
+import "errors"
+
+// Sentinel errors for reading git repositories.
+
+// ErrGit indicates a git command failed, e.g. outside a repository.
+var ErrGit = errors.New("git command failed")
+
+// ErrNoBase indicates no base branch to compare the current branch with was found.
+var ErrNoBase = errors.New("no base branch found")
+
+// ErrNoPullRequest indicates the pull request of the current branch could not be determined.
+var ErrNoPullRequest = errors.New("no pull request found for the current branch")
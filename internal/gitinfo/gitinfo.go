@@ -0,0 +1,143 @@
+// Package gitinfo reads what tix needs from the git repository of the working directory:
+// the current branch, its commits since the base branch and the URL of its pull request.
+package gitinfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// Commit is a commit of the current branch.
+type Commit struct {
+	Hash    string
+	Subject string
+	Body    string
+}
+
+// ShortHash returns the abbreviated hash of c.
+func (c Commit) ShortHash() string {
+	if len(c.Hash) > 7 {
+		return c.Hash[:7]
+	}
+	return c.Hash
+}
+
+// runCommand runs name in dir and returns its trimmed standard output; replaced in tests.
+var runCommand = func(ctx context.Context, dir, name string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("%s %s: %w: %s", name, strings.Join(args, " "), err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("%s %s: %w", name, strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// git runs a git command in dir.
+func git(ctx context.Context, dir string, args ...string) (string, error) {
+	out, err := runCommand(ctx, dir, "git", args...)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrGit, err)
+	}
+	return out, nil
+}
+
+// CurrentBranch returns the name of the branch checked out in dir. CI systems check out
+// pull requests with a detached HEAD, so there the source branch is read from the
+// environment of GitHub Actions or GitLab CI.
+func CurrentBranch(ctx context.Context, dir string) (string, error) {
+	branch, err := git(ctx, dir, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	if branch == "HEAD" {
+		if ci := firstEnv("GITHUB_HEAD_REF", "CI_MERGE_REQUEST_SOURCE_BRANCH_NAME"); ci != "" {
+			return ci, nil
+		}
+	}
+	return branch, nil
+}
+
+// firstEnv returns the first non-empty value of the environment variables names.
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// DefaultBase returns the branch the current branch of dir is compared with: the target
+// branch of the pull request in GitHub Actions or GitLab CI, else the default branch of
+// origin, else the first of origin/main, origin/master, main and master that exists.
+func DefaultBase(ctx context.Context, dir string) (string, error) {
+	if target := firstEnv("GITHUB_BASE_REF", "CI_MERGE_REQUEST_TARGET_BRANCH_NAME"); target != "" {
+		return "origin/" + target, nil
+	}
+	if ref, err := git(ctx, dir, "symbolic-ref", "--short", "refs/remotes/origin/HEAD"); err == nil && ref != "" {
+		return ref, nil
+	}
+	for _, candidate := range []string{"origin/main", "origin/master", "main", "master"} {
+		if _, err := git(ctx, dir, "rev-parse", "--verify", "--quiet", candidate+"^{commit}"); err == nil {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("%w: use --base", ErrNoBase)
+}
+
+// Separators of the fields and records of the git log format read by BranchCommits.
+const (
+	fieldSep  = "\x1f"
+	recordSep = "\x1e"
+)
+
+// BranchCommits returns the commits of HEAD in dir that are not on base, newest first.
+// Merge commits are left out.
+func BranchCommits(ctx context.Context, dir, base string) ([]Commit, error) {
+	out, err := git(ctx, dir, "log", "--no-merges", "--format=%H"+fieldSep+"%s"+fieldSep+"%b"+recordSep, base+"..HEAD")
+	if err != nil {
+		return nil, err
+	}
+	var commits []Commit
+	for _, record := range strings.Split(out, recordSep) {
+		fields := strings.SplitN(strings.TrimSpace(record), fieldSep, 3)
+		if len(fields) < 3 || fields[0] == "" {
+			continue
+		}
+		commits = append(commits, Commit{Hash: fields[0], Subject: fields[1], Body: strings.TrimSpace(fields[2])})
+	}
+	return commits, nil
+}
+
+// githubPullRefRe matches the ref GitHub Actions checks out for pull requests.
+var githubPullRefRe = regexp.MustCompile(`^refs/pull/(\d+)/`)
+
+// PullRequestURL returns the URL of the pull request of the current branch in dir: from
+// the environment of GitHub Actions or GitLab CI, else from the gh CLI.
+func PullRequestURL(ctx context.Context, dir string) (string, error) {
+	if m := githubPullRefRe.FindStringSubmatch(os.Getenv("GITHUB_REF")); m != nil && os.Getenv("GITHUB_REPOSITORY") != "" {
+		server := os.Getenv("GITHUB_SERVER_URL")
+		if server == "" {
+			server = "https://github.com"
+		}
+		return fmt.Sprintf("%s/%s/pull/%s", strings.TrimRight(server, "/"), os.Getenv("GITHUB_REPOSITORY"), m[1]), nil
+	}
+	if project, iid := os.Getenv("CI_MERGE_REQUEST_PROJECT_URL"), os.Getenv("CI_MERGE_REQUEST_IID"); project != "" && iid != "" {
+		return fmt.Sprintf("%s/-/merge_requests/%s", strings.TrimRight(project, "/"), iid), nil
+	}
+	url, err := runCommand(ctx, dir, "gh", "pr", "view", "--json", "url", "--jq", ".url")
+	if err != nil || url == "" {
+		return "", fmt.Errorf("%w: use --pr-url (gh: %v)", ErrNoPullRequest, err)
+	}
+	return url, nil
+}
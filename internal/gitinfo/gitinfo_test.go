@@ -0,0 +1,135 @@
+package gitinfo
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clearCIEnv unsets the CI variables read by this package, as the tests may run in CI.
+func clearCIEnv(t *testing.T) {
+	t.Helper()
+	for _, name := range []string{"GITHUB_HEAD_REF", "GITHUB_BASE_REF", "GITHUB_REF", "GITHUB_REPOSITORY", "GITHUB_SERVER_URL",
+		"CI_MERGE_REQUEST_SOURCE_BRANCH_NAME", "CI_MERGE_REQUEST_TARGET_BRANCH_NAME", "CI_MERGE_REQUEST_PROJECT_URL", "CI_MERGE_REQUEST_IID"} {
+		t.Setenv(name, "")
+	}
+}
+
+// newTestRepo returns a repository whose main branch has one commit.
+func newTestRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	gitRun(t, dir, "init", "--quiet", "--initial-branch=main")
+	gitRun(t, dir, "commit", "--quiet", "--allow-empty", "-m", "Initial commit")
+	return dir
+}
+
+func gitRun(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=Test", "-c", "user.email=test@example.com", "-c", "commit.gpgsign=false"}, args...)...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func TestBranchCommits(t *testing.T) {
+	clearCIEnv(t)
+	ctx := context.Background()
+	dir := newTestRepo(t)
+	gitRun(t, dir, "checkout", "--quiet", "-b", "feature/PROJ-1-login")
+	gitRun(t, dir, "commit", "--quiet", "--allow-empty", "-m", "PROJ-1 Add login form")
+	gitRun(t, dir, "commit", "--quiet", "--allow-empty", "-m", "Fix tests\n\nRefs PROJ-2\nAlso OPS-3")
+
+	branch, err := CurrentBranch(ctx, dir)
+	require.NoError(t, err)
+	assert.Equal(t, "feature/PROJ-1-login", branch)
+
+	base, err := DefaultBase(ctx, dir)
+	require.NoError(t, err)
+	assert.Equal(t, "main", base)
+
+	commits, err := BranchCommits(ctx, dir, base)
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	assert.Equal(t, "Fix tests", commits[0].Subject, "newest first")
+	assert.Equal(t, "Refs PROJ-2\nAlso OPS-3", commits[0].Body)
+	assert.Equal(t, "PROJ-1 Add login form", commits[1].Subject)
+	assert.Empty(t, commits[1].Body)
+	assert.Len(t, commits[1].ShortHash(), 7)
+
+	_, err = BranchCommits(ctx, dir, "missing")
+	assert.ErrorIs(t, err, ErrGit)
+}
+
+func TestCurrentBranch_CI(t *testing.T) {
+	clearCIEnv(t)
+	ctx := context.Background()
+	dir := newTestRepo(t)
+	gitRun(t, dir, "checkout", "--quiet", "--detach")
+
+	branch, err := CurrentBranch(ctx, dir)
+	require.NoError(t, err)
+	assert.Equal(t, "HEAD", branch)
+
+	t.Setenv("GITHUB_HEAD_REF", "PROJ-7-fix")
+	t.Setenv("GITHUB_BASE_REF", "develop")
+	branch, err = CurrentBranch(ctx, dir)
+	require.NoError(t, err)
+	assert.Equal(t, "PROJ-7-fix", branch, "the source branch of the pull request")
+	base, err := DefaultBase(ctx, dir)
+	require.NoError(t, err)
+	assert.Equal(t, "origin/develop", base)
+}
+
+func TestDefaultBase_None(t *testing.T) {
+	clearCIEnv(t)
+	dir := newTestRepo(t)
+	gitRun(t, dir, "branch", "--quiet", "-m", "trunk")
+	_, err := DefaultBase(context.Background(), dir)
+	assert.ErrorIs(t, err, ErrNoBase)
+}
+
+func TestPullRequestURL(t *testing.T) {
+	clearCIEnv(t)
+	ctx := context.Background()
+	original := runCommand
+	t.Cleanup(func() { runCommand = original })
+	var ran []string
+	runCommand = func(ctx context.Context, dir, name string, args ...string) (string, error) {
+		ran = append(ran, name)
+		return "https://github.com/acme/app/pull/9", nil
+	}
+
+	url, err := PullRequestURL(ctx, ".")
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.com/acme/app/pull/9", url)
+	assert.Equal(t, []string{"gh"}, ran)
+
+	t.Setenv("CI_MERGE_REQUEST_PROJECT_URL", "https://gitlab.example/acme/app")
+	t.Setenv("CI_MERGE_REQUEST_IID", "12")
+	url, err = PullRequestURL(ctx, ".")
+	require.NoError(t, err)
+	assert.Equal(t, "https://gitlab.example/acme/app/-/merge_requests/12", url)
+
+	t.Setenv("GITHUB_REF", "refs/pull/42/merge")
+	t.Setenv("GITHUB_REPOSITORY", "acme/app")
+	t.Setenv("GITHUB_SERVER_URL", "https://github.example/")
+	url, err = PullRequestURL(ctx, ".")
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.example/acme/app/pull/42", url)
+	assert.Len(t, ran, 1, "gh is not needed in CI")
+
+	clearCIEnv(t)
+	runCommand = func(ctx context.Context, dir, name string, args ...string) (string, error) {
+		return "", errors.New("no pull requests found for branch")
+	}
+	_, err = PullRequestURL(ctx, ".")
+	assert.ErrorIs(t, err, ErrNoPullRequest)
+}
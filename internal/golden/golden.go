@@ -0,0 +1,87 @@
+// Package golden compares test output with snapshot ("golden") files stored under
+// testdata/golden/ of the package being tested. Run the tests with -update, or with
+// TICKETRON_UPDATE_GOLDEN=1 set, to write the current output to the golden files
+// instead; review the resulting diff before committing it.
+package golden
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/karolswdev/ticketron/internal/textdiff"
+)
+
+// EnvUpdate is the environment variable that updates golden files like the -update flag.
+// Unlike the flag it can be set for packages that do not import golden.
+const EnvUpdate = "TICKETRON_UPDATE_GOLDEN"
+
+// Dir is the directory holding golden files, relative to the package being tested.
+const Dir = "testdata/golden"
+
+// diffContext is the number of unchanged lines shown around each difference.
+const diffContext = 3
+
+var update = flag.Bool("update", false, "update golden files instead of comparing with them")
+
+// Updating reports whether golden files are being updated.
+func Updating() bool {
+	return *update || os.Getenv(EnvUpdate) == "1"
+}
+
+// Path returns the golden file of the current test: testdata/golden/<test name>.golden,
+// with subtests in subdirectories.
+func Path(t testing.TB) string {
+	return filepath.Join(Dir, filepath.FromSlash(sanitize(t.Name()))+".golden")
+}
+
+// Assert compares got with the golden file of the current test, or writes got to it
+// when updating. A mismatch is reported with a unified diff.
+func Assert(t testing.TB, got []byte) {
+	t.Helper()
+	path := Path(t)
+	if Updating() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("golden: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("golden: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		t.Fatalf("golden: %s does not exist; run the test with -update to create it", path)
+	}
+	if err != nil {
+		t.Fatalf("golden: %v", err)
+	}
+	if diff := textdiff.Unified(string(want), string(got), path, "got", diffContext); diff != "" {
+		t.Errorf("golden: output differs from %s (run with -update to accept it):\n%s", path, diff)
+	} else if string(want) != string(got) {
+		// Only the trailing newline differs, which a line diff does not show.
+		t.Errorf("golden: output differs from %s in its final newline (run with -update to accept it)", path)
+	}
+}
+
+// AssertString is Assert for string output.
+func AssertString(t testing.TB, got string) {
+	t.Helper()
+	Assert(t, []byte(got))
+}
+
+// sanitize replaces characters that are unsafe in file names. Slashes separating
+// subtests are kept.
+func sanitize(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-', r == '.', r == '/':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
@@ -0,0 +1,60 @@
+package golden
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingT captures failures instead of failing the real test.
+type recordingT struct {
+	testing.TB
+	name   string
+	errors int
+	fatal  bool
+}
+
+func (r *recordingT) Name() string                      { return r.name }
+func (r *recordingT) Helper()                           {}
+func (r *recordingT) Errorf(format string, args ...any) { r.errors++ }
+func (r *recordingT) Fatalf(format string, args ...any) { r.fatal = true }
+
+func TestPath(t *testing.T) {
+	assert.Equal(t, filepath.Join("testdata", "golden", "TestSearch", "json_fields.golden"), Path(&recordingT{name: "TestSearch/json_fields"}))
+	assert.Equal(t, filepath.Join("testdata", "golden", "TestView", "a_b_c_.golden"), Path(&recordingT{name: "TestView/a:b*c?"}))
+}
+
+func TestAssert(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+	t.Setenv(EnvUpdate, "")
+
+	missing := &recordingT{name: "TestOutput"}
+	Assert(missing, []byte("hello\n"))
+	assert.True(t, missing.fatal, "a missing golden file fails the test")
+
+	t.Setenv(EnvUpdate, "1")
+	Assert(&recordingT{name: "TestOutput"}, []byte("hello\n"))
+	data, err := os.ReadFile(filepath.Join("testdata", "golden", "TestOutput.golden"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello\n", string(data))
+
+	t.Setenv(EnvUpdate, "")
+	same := &recordingT{name: "TestOutput"}
+	AssertString(same, "hello\n")
+	assert.Zero(t, same.errors)
+	assert.False(t, same.fatal)
+
+	changed := &recordingT{name: "TestOutput"}
+	AssertString(changed, "hello, world\n")
+	assert.Equal(t, 1, changed.errors)
+
+	newline := &recordingT{name: "TestOutput"}
+	AssertString(newline, "hello")
+	assert.Equal(t, 1, newline.errors, "a missing final newline is a difference")
+}
@@ -0,0 +1,15 @@
+package guardrails
+
+import "errors"
+
+// Sentinel errors for guardrails.
+
+// ErrInvalidProfile indicates a guardrail profile has an unknown action or a rule that
+// is incomplete, unknown or does not compile.
+var ErrInvalidProfile = errors.New("invalid guardrail profile")
+
+// ErrUnknownProfile indicates a selected guardrail profile is not defined in guardrails.profiles.
+var ErrUnknownProfile = errors.New("unknown guardrail profile")
+
+// ErrBlocked indicates generated content matched a rule of a profile with the block action.
+var ErrBlocked = errors.New("generated ticket blocked by guardrails")
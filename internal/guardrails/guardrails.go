@@ -0,0 +1,193 @@
+// Package guardrails checks generated ticket content for banned patterns, such as
+// customer names or secrets, before it is submitted to Jira. Matches either block the
+// ticket or are redacted, depending on the profile in effect.
+package guardrails
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/redact"
+)
+
+// Action is what happens to a ticket whose content matches a rule.
+type Action string
+
+const (
+	// ActionBlock rejects the ticket.
+	ActionBlock Action = "block"
+	// ActionRedact replaces the matches and lets the ticket through.
+	ActionRedact Action = "redact"
+)
+
+// termReplacement replaces banned terms, so redacted tickets do not reveal which one matched.
+const termReplacement = "[REDACTED:term]"
+
+// Guardrails holds the compiled profiles of the guardrails configuration. A nil
+// *Guardrails has no profiles and applies none.
+type Guardrails struct {
+	defaultProfile string
+	profiles       map[string]*Profile
+}
+
+// Profile is a compiled guardrail profile.
+type Profile struct {
+	Name     string
+	Action   Action
+	redactor *redact.Redactor
+}
+
+// Finding is one match of a rule in generated content.
+type Finding struct {
+	Field string // summary or description
+	Rule  string // Built-in or custom rule name, or term:<term>
+	Text  string // The matched text
+}
+
+// New compiles the profiles of cfg. It returns nil when no profiles are configured, and
+// an error if a profile is invalid or the default profile is not defined.
+func New(cfg config.GuardrailsConfig) (*Guardrails, error) {
+	if len(cfg.Profiles) == 0 {
+		if cfg.Profile != "" {
+			return nil, fmt.Errorf("%w: %q (no profiles are defined)", ErrUnknownProfile, cfg.Profile)
+		}
+		return nil, nil
+	}
+	g := &Guardrails{defaultProfile: strings.ToLower(cfg.Profile), profiles: make(map[string]*Profile, len(cfg.Profiles))}
+	for name, profileCfg := range cfg.Profiles {
+		profile, err := compileProfile(name, profileCfg)
+		if err != nil {
+			return nil, err
+		}
+		g.profiles[strings.ToLower(name)] = profile
+	}
+	if _, err := g.Profile(""); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// compileProfile compiles the rules of one profile: terms first, then custom patterns,
+// then built-ins.
+func compileProfile(name string, cfg config.GuardrailProfile) (*Profile, error) {
+	action := Action(strings.ToLower(cfg.Action))
+	switch action {
+	case "":
+		action = ActionBlock
+	case ActionBlock, ActionRedact:
+	default:
+		return nil, fmt.Errorf("%w: %s: action must be %q or %q, got %q", ErrInvalidProfile, name, ActionBlock, ActionRedact, cfg.Action)
+	}
+	patterns := make([]config.RedactionPattern, 0, len(cfg.Terms)+len(cfg.Patterns))
+	for _, term := range cfg.Terms {
+		if strings.TrimSpace(term) == "" {
+			return nil, fmt.Errorf("%w: %s: terms must not be empty", ErrInvalidProfile, name)
+		}
+		patterns = append(patterns, config.RedactionPattern{Name: "term:" + term, Pattern: termPattern(term), Replacement: termReplacement})
+	}
+	patterns = append(patterns, cfg.Patterns...)
+	redactor, err := redact.Compile(cfg.Builtins, patterns)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidProfile, name, err)
+	}
+	return &Profile{Name: name, Action: action, redactor: redactor}, nil
+}
+
+// termPattern matches term case-insensitively with any whitespace between its words and,
+// where it starts or ends with a letter or digit, only as a whole word.
+func termPattern(term string) string {
+	words := strings.Fields(term)
+	for i, word := range words {
+		words[i] = regexp.QuoteMeta(word)
+	}
+	pattern := strings.Join(words, `\s+`)
+	trimmed := strings.TrimSpace(term)
+	if first, _ := utf8.DecodeRuneInString(trimmed); isASCIIWord(first) {
+		pattern = `\b` + pattern
+	}
+	if last, _ := utf8.DecodeLastRuneInString(trimmed); isASCIIWord(last) {
+		pattern += `\b`
+	}
+	return "(?i)" + pattern
+}
+
+// isASCIIWord reports whether r is a character \b treats as part of a word.
+func isASCIIWord(r rune) bool {
+	return r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_')
+}
+
+// Names returns the names of the configured profiles, sorted.
+func (g *Guardrails) Names() []string {
+	if g == nil {
+		return nil
+	}
+	names := make([]string, 0, len(g.profiles))
+	for _, profile := range g.profiles {
+		names = append(names, profile.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Profile returns the profile called name, matched case-insensitively, or the default
+// profile if name is empty. It returns nil if name and the default are both empty.
+func (g *Guardrails) Profile(name string) (*Profile, error) {
+	if name == "" && g != nil {
+		name = g.defaultProfile
+	}
+	if name == "" {
+		return nil, nil
+	}
+	if g != nil {
+		if profile, ok := g.profiles[strings.ToLower(name)]; ok {
+			return profile, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %q (available: %v)", ErrUnknownProfile, name, g.Names())
+}
+
+// Apply checks the summary and description of a ticket. With the redact action it
+// returns them with every match replaced; with the block action it returns them
+// unchanged and, if anything matched, an error wrapping ErrBlocked that names the fields
+// and rules but not the matched text. A nil *Profile lets everything through.
+func (p *Profile) Apply(summary, description string) (string, string, []Finding, error) {
+	if p == nil {
+		return summary, description, nil, nil
+	}
+	cleanSummary, summaryMatches := p.redactor.Redact(summary)
+	cleanDescription, descriptionMatches := p.redactor.Redact(description)
+	findings := make([]Finding, 0, len(summaryMatches)+len(descriptionMatches))
+	for _, m := range summaryMatches {
+		findings = append(findings, Finding{Field: "summary", Rule: m.Rule, Text: m.Original})
+	}
+	for _, m := range descriptionMatches {
+		findings = append(findings, Finding{Field: "description", Rule: m.Rule, Text: m.Original})
+	}
+	if len(findings) == 0 {
+		return summary, description, nil, nil
+	}
+	if p.Action == ActionRedact {
+		return cleanSummary, cleanDescription, findings, nil
+	}
+	return summary, description, findings, fmt.Errorf("%w: profile %q matched %s", ErrBlocked, p.Name, Describe(findings))
+}
+
+// Describe lists the distinct field and rule pairs of findings, e.g.
+// "summary (term:Acme), description (api_key)".
+func Describe(findings []Finding) string {
+	var parts []string
+	seen := make(map[string]bool)
+	for _, f := range findings {
+		part := f.Field + " (" + f.Rule + ")"
+		if !seen[part] {
+			seen[part] = true
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
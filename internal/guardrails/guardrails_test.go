@@ -0,0 +1,113 @@
+package guardrails
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/config"
+)
+
+func TestNew(t *testing.T) {
+	g, err := New(config.GuardrailsConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, g, "no profiles yield nil Guardrails")
+	profile, err := g.Profile("")
+	require.NoError(t, err)
+	assert.Nil(t, profile)
+
+	_, err = New(config.GuardrailsConfig{Profile: "strict"})
+	assert.ErrorIs(t, err, ErrUnknownProfile)
+	_, err = New(config.GuardrailsConfig{Profile: "strict", Profiles: map[string]config.GuardrailProfile{"lax": {}}})
+	assert.ErrorIs(t, err, ErrUnknownProfile)
+
+	for name, invalid := range map[string]config.GuardrailProfile{
+		"action":  {Action: "warn"},
+		"term":    {Terms: []string{" "}},
+		"builtin": {Builtins: []string{"phone"}},
+		"pattern": {Patterns: []config.RedactionPattern{{Name: "bad", Pattern: "("}}},
+	} {
+		_, err := New(config.GuardrailsConfig{Profiles: map[string]config.GuardrailProfile{name: invalid}})
+		assert.ErrorIs(t, err, ErrInvalidProfile, name)
+	}
+}
+
+func TestGuardrails_Profile(t *testing.T) {
+	g, err := New(config.GuardrailsConfig{
+		Profile:  "Default",
+		Profiles: map[string]config.GuardrailProfile{"default": {}, "internal": {Action: "redact"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default", "internal"}, g.Names())
+
+	profile, err := g.Profile("")
+	require.NoError(t, err)
+	assert.Equal(t, "default", profile.Name)
+	assert.Equal(t, ActionBlock, profile.Action)
+
+	profile, err = g.Profile("INTERNAL")
+	require.NoError(t, err)
+	assert.Equal(t, ActionRedact, profile.Action)
+
+	_, err = g.Profile("public")
+	assert.ErrorIs(t, err, ErrUnknownProfile)
+	assert.ErrorContains(t, err, "[default internal]")
+}
+
+func compile(t *testing.T, cfg config.GuardrailProfile) *Profile {
+	t.Helper()
+	g, err := New(config.GuardrailsConfig{Profile: "p", Profiles: map[string]config.GuardrailProfile{"p": cfg}})
+	require.NoError(t, err)
+	profile, err := g.Profile("")
+	require.NoError(t, err)
+	return profile
+}
+
+func TestProfile_Apply_Block(t *testing.T) {
+	profile := compile(t, config.GuardrailProfile{Terms: []string{"Acme Corp"}, Builtins: []string{"api_key"}})
+
+	summary, description, findings, err := profile.Apply("Fix export for ACME  corp", "Use key sk-abcdefghijklmnopqrstuv")
+	assert.ErrorIs(t, err, ErrBlocked)
+	assert.EqualError(t, err, `generated ticket blocked by guardrails: profile "p" matched summary (term:Acme Corp), description (api_key)`)
+	assert.NotContains(t, err.Error(), "sk-abc", "matched secrets are not repeated")
+	assert.Equal(t, "Fix export for ACME  corp", summary, "blocked content is returned unchanged")
+	assert.Equal(t, "Use key sk-abcdefghijklmnopqrstuv", description)
+	assert.Equal(t, []Finding{
+		{Field: "summary", Rule: "term:Acme Corp", Text: "ACME  corp"},
+		{Field: "description", Rule: "api_key", Text: "sk-abcdefghijklmnopqrstuv"},
+	}, findings)
+
+	_, _, findings, err = profile.Apply("Update Acme Corporation logo", "Contact ops@example.com")
+	assert.NoError(t, err, "terms match whole words and e-mails are not selected")
+	assert.Empty(t, findings)
+}
+
+func TestProfile_Apply_Redact(t *testing.T) {
+	profile := compile(t, config.GuardrailProfile{
+		Action:   "redact",
+		Terms:    []string{"Globex", "C++"},
+		Patterns: []config.RedactionPattern{{Name: "ticket_ref", Pattern: `SR-\d+`, Replacement: "[SR]"}},
+	})
+
+	summary, description, findings, err := profile.Apply("Globex reports crash", "See SR-1234 about the globex C++ client.")
+	require.NoError(t, err)
+	assert.Equal(t, "[REDACTED:term] reports crash", summary)
+	assert.Equal(t, "See [SR] about the [REDACTED:term] [REDACTED:term] client.", description)
+	assert.Equal(t, "summary (term:Globex), description (term:Globex), description (term:C++), description (ticket_ref)", Describe(findings))
+}
+
+func TestProfile_Apply_Nil(t *testing.T) {
+	var profile *Profile
+	summary, description, findings, err := profile.Apply("S", "D")
+	require.NoError(t, err)
+	assert.Equal(t, "S", summary)
+	assert.Equal(t, "D", description)
+	assert.Empty(t, findings)
+}
+
+func TestTermPattern(t *testing.T) {
+	assert.Equal(t, `(?i)\bAcme\s+Corp\b`, termPattern(" Acme Corp "))
+	assert.Equal(t, `(?i)\bC\+\+`, termPattern("C++"))
+	assert.Equal(t, `(?i)\bZażółć`, termPattern("Zażółć"), "\\b only applies next to ASCII word characters")
+}
@@ -0,0 +1,20 @@
+package history
+
+import "errors"
+
+// Sentinel errors for history operations.
+
+// ErrHistoryRead indicates an error occurred while reading the history file.
+var ErrHistoryRead = errors.New("failed to read history file")
+
+// ErrHistoryWrite indicates an error occurred while writing the history file.
+var ErrHistoryWrite = errors.New("failed to write history file")
+
+// ErrHistoryParse indicates a line of the history file could not be parsed.
+var ErrHistoryParse = errors.New("failed to parse history entry")
+
+// ErrInvalidFeedback indicates a feedback entry is missing required data.
+var ErrInvalidFeedback = errors.New("invalid feedback")
+
+// ErrInvalidMapping indicates a project mapping correction is missing required data.
+var ErrInvalidMapping = errors.New("invalid project mapping correction")
@@ -0,0 +1,113 @@
+package history
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// FeedbackFileName is the standard name of the feedback file within the config directory.
+const FeedbackFileName = "feedback.jsonl"
+
+// Ratings accepted for feedback entries.
+const (
+	RatingGood = "good"
+	RatingBad  = "bad"
+)
+
+// Feedback is a user rating of a generated ticket. The generation details are copied from
+// the matching history entry, when there is one, so feedback can be analyzed on its own.
+type Feedback struct {
+	Timestamp     time.Time `json:"timestamp"`
+	IssueKey      string    `json:"issue_key"`
+	Rating        string    `json:"rating"` // RatingGood or RatingBad
+	Reason        string    `json:"reason,omitempty"`
+	PromptVersion string    `json:"prompt_version,omitempty"`
+	Input         string    `json:"input,omitempty"`
+	Summary       string    `json:"summary,omitempty"`
+	ProjectKey    string    `json:"project_key,omitempty"`
+	IssueType     string    `json:"issue_type,omitempty"`
+}
+
+// FeedbackStore appends to and reads from the feedback file.
+type FeedbackStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFeedbackStore returns a FeedbackStore backed by feedback.jsonl in configDir.
+func NewFeedbackStore(configDir string) *FeedbackStore {
+	return &FeedbackStore{path: filepath.Join(configDir, FeedbackFileName)}
+}
+
+// Append validates and records a feedback entry. A zero Timestamp is set to the current time.
+func (s *FeedbackStore) Append(fb Feedback) error {
+	if fb.IssueKey == "" {
+		return fmt.Errorf("%w: issue key is required", ErrInvalidFeedback)
+	}
+	if fb.Rating != RatingGood && fb.Rating != RatingBad {
+		return fmt.Errorf("%w: rating must be %q or %q", ErrInvalidFeedback, RatingGood, RatingBad)
+	}
+	if fb.Timestamp.IsZero() {
+		fb.Timestamp = time.Now().UTC()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := appendJSONLine(s.path, fb); err != nil {
+		return err
+	}
+	log.Debug().Str("issue_key", fb.IssueKey).Str("rating", fb.Rating).Msg("Recorded feedback")
+	return nil
+}
+
+// List returns all feedback entries, oldest first.
+func (s *FeedbackStore) List() ([]Feedback, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return readJSONLines[Feedback](s.path)
+}
+
+// FeedbackFor builds a feedback entry for issueKey, enriched with the generation details
+// from entry (which may be nil if the issue is not in the history).
+func FeedbackFor(issueKey, rating, reason string, entry *Entry) Feedback {
+	fb := Feedback{IssueKey: issueKey, Rating: rating, Reason: reason}
+	if entry != nil {
+		fb.PromptVersion = entry.PromptVersion
+		fb.Input = entry.Input
+		fb.Summary = entry.Summary
+		fb.ProjectKey = entry.ProjectKey
+		fb.IssueType = entry.IssueType
+	}
+	return fb
+}
+
+// VersionStats aggregates ratings for one prompt version.
+type VersionStats struct {
+	PromptVersion string `json:"prompt_version"`
+	Good          int    `json:"good"`
+	Bad           int    `json:"bad"`
+}
+
+// StatsByPromptVersion aggregates feedback per prompt version, in order of first appearance.
+// Feedback without a version is grouped under "".
+func StatsByPromptVersion(feedback []Feedback) []VersionStats {
+	var stats []VersionStats
+	index := make(map[string]int)
+	for _, fb := range feedback {
+		i, ok := index[fb.PromptVersion]
+		if !ok {
+			i = len(stats)
+			index[fb.PromptVersion] = i
+			stats = append(stats, VersionStats{PromptVersion: fb.PromptVersion})
+		}
+		if fb.Rating == RatingGood {
+			stats[i].Good++
+		} else {
+			stats[i].Bad++
+		}
+	}
+	return stats
+}
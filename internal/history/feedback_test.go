@@ -0,0 +1,37 @@
+package history
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeedbackStore(t *testing.T) {
+	dir := t.TempDir()
+	hist := NewStore(dir)
+	require.NoError(t, hist.Append(Entry{IssueKey: "BE-1", Summary: "old", PromptVersion: "v1"}))
+	require.NoError(t, hist.Append(Entry{IssueKey: "BE-1", Summary: "new", PromptVersion: "v2"}))
+
+	entry, err := hist.Find("be-1")
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.Equal(t, "new", entry.Summary, "Find returns the most recent entry")
+
+	missing, err := hist.Find("BE-404")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	store := NewFeedbackStore(dir)
+	require.NoError(t, store.Append(FeedbackFor("BE-1", RatingGood, "clear summary", entry)))
+	require.NoError(t, store.Append(FeedbackFor("BE-2", RatingBad, "", nil)))
+	assert.ErrorIs(t, store.Append(FeedbackFor("BE-3", "meh", "", nil)), ErrInvalidFeedback)
+
+	feedback, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, feedback, 2)
+	assert.Equal(t, "v2", feedback[0].PromptVersion)
+	assert.Equal(t, "new", feedback[0].Summary)
+
+	assert.Equal(t, []VersionStats{{PromptVersion: "v2", Good: 1}, {PromptVersion: "", Bad: 1}}, StatsByPromptVersion(feedback))
+}
@@ -0,0 +1,154 @@
+// Package history records issues created by Ticketron in a local JSON Lines file
+// (~/.ticketron/history.jsonl) for later lookup and quality analysis.
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultFileName is the standard name of the history file within the config directory.
+const DefaultFileName = "history.jsonl"
+
+// Entry is a single created issue.
+type Entry struct {
+	Timestamp     time.Time `json:"timestamp"`
+	Source        string    `json:"source"` // Entry point that created the issue: create, batch, mcp, webhook
+	Input         string    `json:"input,omitempty"`
+	IssueKey      string    `json:"issue_key"`
+	IssueURL      string    `json:"issue_url,omitempty"`
+	ProjectKey    string    `json:"project_key,omitempty"`
+	IssueType     string    `json:"issue_type,omitempty"`
+	Summary       string    `json:"summary,omitempty"`
+	PromptVersion string    `json:"prompt_version,omitempty"` // Named prompt version active when the issue was generated
+	// IdempotencyKey is the key the issue was created with, which identifies its input and project.
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+}
+
+// Store appends to and reads from a history file. It is safe for concurrent use
+// within a process.
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewStore returns a Store backed by history.jsonl in configDir.
+func NewStore(configDir string) *Store {
+	return &Store{path: filepath.Join(configDir, DefaultFileName)}
+}
+
+// Path returns the location of the history file.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Append adds an entry to the history file, creating it if needed.
+// A zero Timestamp is set to the current time.
+func (s *Store) Append(entry Entry) error {
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now().UTC()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := appendJSONLine(s.path, entry); err != nil {
+		return err
+	}
+	log.Debug().Str("path", s.path).Str("issue_key", entry.IssueKey).Msg("Recorded history entry")
+	return nil
+}
+
+// List returns all entries, oldest first. A missing history file yields no entries.
+func (s *Store) List() ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return readJSONLines[Entry](s.path)
+}
+
+// Find returns the most recent entry for issueKey (case-insensitive), or nil if there is none.
+func (s *Store) Find(issueKey string) (*Entry, error) {
+	entries, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		if strings.EqualFold(entries[i].IssueKey, issueKey) {
+			return &entries[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// FindIdempotent returns the most recent entry created with idempotencyKey at or after
+// since, or nil if there is none.
+func (s *Store) FindIdempotent(idempotencyKey string, since time.Time) (*Entry, error) {
+	if idempotencyKey == "" {
+		return nil, nil
+	}
+	entries, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].IdempotencyKey == idempotencyKey && !entries[i].Timestamp.Before(since) {
+			return &entries[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// appendJSONLine appends v as a single JSON line to path, creating the file if needed.
+func appendJSONLine(path string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrHistoryWrite, err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrHistoryWrite, err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("%w: %w", ErrHistoryWrite, err)
+	}
+	return nil
+}
+
+// readJSONLines decodes every non-empty line of path. A missing file yields no values.
+func readJSONLines[T any](path string) ([]T, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w: %w", ErrHistoryRead, err)
+	}
+	defer f.Close()
+
+	var values []T
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var v T
+		if err := json.Unmarshal(scanner.Bytes(), &v); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrHistoryParse, lineNo, err)
+		}
+		values = append(values, v)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrHistoryRead, err)
+	}
+	return values, nil
+}
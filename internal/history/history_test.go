@@ -0,0 +1,58 @@
+package history
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_AppendAndList(t *testing.T) {
+	store := NewStore(t.TempDir())
+
+	entries, err := store.List()
+	require.NoError(t, err)
+	assert.Empty(t, entries, "missing file yields no entries")
+
+	require.NoError(t, store.Append(Entry{Source: "create", IssueKey: "BE-1", PromptVersion: "v1"}))
+	ts := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, store.Append(Entry{Timestamp: ts, Source: "batch", IssueKey: "BE-2"}))
+
+	entries, err = store.List()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "BE-1", entries[0].IssueKey)
+	assert.Equal(t, "v1", entries[0].PromptVersion)
+	assert.False(t, entries[0].Timestamp.IsZero(), "timestamp is set automatically")
+	assert.Equal(t, ts, entries[1].Timestamp)
+}
+
+func TestStore_ListCorrupt(t *testing.T) {
+	store := NewStore(t.TempDir())
+	require.NoError(t, os.WriteFile(store.Path(), []byte("{not json}\n"), 0600))
+
+	_, err := store.List()
+	assert.ErrorIs(t, err, ErrHistoryParse)
+}
+
+func TestStore_FindIdempotent(t *testing.T) {
+	store := NewStore(t.TempDir())
+	ts := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, store.Append(Entry{Timestamp: ts, IssueKey: "BE-1", IdempotencyKey: "k1"}))
+	require.NoError(t, store.Append(Entry{Timestamp: ts.Add(time.Hour), IssueKey: "BE-2", IdempotencyKey: "k1"}))
+	require.NoError(t, store.Append(Entry{Timestamp: ts.Add(2 * time.Hour), IssueKey: "BE-3"}))
+
+	entry, err := store.FindIdempotent("k1", ts)
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.Equal(t, "BE-2", entry.IssueKey, "the most recent entry wins")
+
+	entry, err = store.FindIdempotent("k1", ts.Add(90*time.Minute))
+	require.NoError(t, err)
+	assert.Nil(t, entry, "older entries are ignored")
+	entry, err = store.FindIdempotent("", ts)
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+}
@@ -0,0 +1,94 @@
+package history
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// MappingsFileName is the standard name of the project mapping corrections file within the
+// config directory.
+const MappingsFileName = "project_mappings.jsonl"
+
+// MappingCorrection records that the user chose ProjectKey for an issue for which the LLM
+// suggested the project Suggestion, which links.yaml mapped to SuggestedKey (empty if it
+// matched no entry).
+type MappingCorrection struct {
+	Timestamp    time.Time `json:"timestamp"`
+	Suggestion   string    `json:"suggestion"`
+	SuggestedKey string    `json:"suggested_key,omitempty"`
+	ProjectKey   string    `json:"project_key"`
+}
+
+// MappingStore appends to and reads from the project mapping corrections file.
+type MappingStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewMappingStore returns a MappingStore backed by project_mappings.jsonl in configDir.
+func NewMappingStore(configDir string) *MappingStore {
+	return &MappingStore{path: filepath.Join(configDir, MappingsFileName)}
+}
+
+// Append records a correction. A zero Timestamp is set to the current time.
+func (s *MappingStore) Append(c MappingCorrection) error {
+	if strings.TrimSpace(c.Suggestion) == "" || c.ProjectKey == "" {
+		return fmt.Errorf("%w: suggestion and project key are required", ErrInvalidMapping)
+	}
+	if c.Timestamp.IsZero() {
+		c.Timestamp = time.Now().UTC()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := appendJSONLine(s.path, c); err != nil {
+		return err
+	}
+	log.Debug().Str("suggestion", c.Suggestion).Str("project_key", c.ProjectKey).Msg("Recorded project mapping correction")
+	return nil
+}
+
+// List returns all corrections, oldest first.
+func (s *MappingStore) List() ([]MappingCorrection, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return readJSONLines[MappingCorrection](s.path)
+}
+
+// Learned returns the project key users chose most often for suggestion (compared
+// case-insensitively), provided it was chosen at least minCount times. Ties go to the key
+// chosen most recently.
+func (s *MappingStore) Learned(suggestion string, minCount int) (string, bool, error) {
+	corrections, err := s.List()
+	if err != nil {
+		return "", false, err
+	}
+	key, count := LearnedMapping(corrections, suggestion)
+	if count < minCount || count == 0 {
+		return "", false, nil
+	}
+	return key, true, nil
+}
+
+// LearnedMapping returns the project key chosen most often for suggestion in corrections
+// and how often it was chosen. Ties go to the key chosen most recently.
+func LearnedMapping(corrections []MappingCorrection, suggestion string) (string, int) {
+	suggestion = strings.TrimSpace(suggestion)
+	counts := make(map[string]int)
+	var best string
+	for _, c := range corrections {
+		if !strings.EqualFold(strings.TrimSpace(c.Suggestion), suggestion) {
+			continue
+		}
+		key := strings.ToUpper(c.ProjectKey)
+		counts[key]++
+		if counts[key] >= counts[best] {
+			best = key
+		}
+	}
+	return best, counts[best]
+}
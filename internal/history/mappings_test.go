@@ -0,0 +1,50 @@
+package history
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMappingStore(t *testing.T) {
+	store := NewMappingStore(t.TempDir())
+
+	_, ok, err := store.Learned("Backend", 1)
+	require.NoError(t, err)
+	assert.False(t, ok, "nothing is learned without corrections")
+
+	require.NoError(t, store.Append(MappingCorrection{Suggestion: "Backend", SuggestedKey: "BE", ProjectKey: "API"}))
+	require.NoError(t, store.Append(MappingCorrection{Suggestion: "backend ", ProjectKey: "api"}))
+	require.NoError(t, store.Append(MappingCorrection{Suggestion: "Backend", ProjectKey: "CORE"}))
+	require.NoError(t, store.Append(MappingCorrection{Suggestion: "Frontend", ProjectKey: "WEB"}))
+	assert.ErrorIs(t, store.Append(MappingCorrection{Suggestion: " ", ProjectKey: "WEB"}), ErrInvalidMapping)
+
+	corrections, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, corrections, 4)
+	assert.False(t, corrections[0].Timestamp.IsZero())
+
+	key, ok, err := store.Learned("BACKEND", 2)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "API", key)
+
+	_, ok, err = store.Learned("Frontend", 2)
+	require.NoError(t, err)
+	assert.False(t, ok, "a single correction is not enough")
+}
+
+func TestLearnedMapping_TieGoesToMostRecent(t *testing.T) {
+	corrections := []MappingCorrection{
+		{Suggestion: "Ops", ProjectKey: "INFRA"},
+		{Suggestion: "Ops", ProjectKey: "SRE"},
+	}
+	key, count := LearnedMapping(corrections, "ops")
+	assert.Equal(t, "SRE", key)
+	assert.Equal(t, 1, count)
+
+	key, count = LearnedMapping(corrections, "Unknown")
+	assert.Equal(t, "", key)
+	assert.Equal(t, 0, count)
+}
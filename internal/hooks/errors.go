@@ -0,0 +1,12 @@
+package hooks
+
+import "errors"
+
+// Sentinel errors for hooks.
+
+// ErrInvalidHook indicates a configured hook is empty, cannot be split into words or
+// contains an invalid template.
+var ErrInvalidHook = errors.New("invalid hook")
+
+// ErrHookFailed indicates a hook could not be started, exited with an error or timed out.
+var ErrHookFailed = errors.New("hook failed")
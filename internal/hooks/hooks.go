@@ -0,0 +1,223 @@
+// Package hooks runs the commands configured under hooks in config.yaml before and after
+// an issue is created, with their arguments rendered from the issue, for lightweight
+// integrations such as chat notifications without plugins.
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/karolswdev/ticketron/internal/config"
+)
+
+// DefaultTimeout bounds each hook when hooks.timeout is not set.
+const DefaultTimeout = 30 * time.Second
+
+// waitDelay is how long a hook's output is still read after it exits or is killed, so
+// that a background process keeping the output open cannot block tix.
+const waitDelay = time.Second
+
+// Event is the point at which hooks run.
+type Event string
+
+// Events hooks can be configured for.
+const (
+	PreCreate  Event = "pre_create"  // Before an issue is created; a failure aborts the creation
+	PostCreate Event = "post_create" // After an issue is created
+)
+
+// Data is what the arguments of hooks are rendered with.
+type Data struct {
+	Key         string // Issue key; empty before creation
+	URL         string // Web page of the issue; empty before creation
+	Project     string
+	Type        string
+	Summary     string
+	Priority    string
+	Description string
+}
+
+// command is a configured hook with its words parsed as templates.
+type command struct {
+	source string
+	words  []*template.Template
+}
+
+// Hooks runs the configured hooks. A nil *Hooks runs nothing.
+type Hooks struct {
+	commands map[Event][]command
+	timeout  time.Duration
+
+	// Output receives the standard output and error of the hooks; os.Stderr by default,
+	// so that the output of tix itself stays parseable.
+	Output io.Writer
+}
+
+// New parses the hooks of cfg. It returns nil if none are configured.
+func New(cfg config.HooksConfig) (*Hooks, error) {
+	if len(cfg.PreCreate) == 0 && len(cfg.PostCreate) == 0 {
+		return nil, nil
+	}
+	if cfg.Timeout < 0 {
+		return nil, fmt.Errorf("%w: timeout must not be negative", ErrInvalidHook)
+	}
+	h := &Hooks{commands: make(map[Event][]command), timeout: cfg.Timeout, Output: os.Stderr}
+	if h.timeout == 0 {
+		h.timeout = DefaultTimeout
+	}
+	for event, sources := range map[Event][]string{PreCreate: cfg.PreCreate, PostCreate: cfg.PostCreate} {
+		for _, source := range sources {
+			c, err := parseCommand(source)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s %q: %w", ErrInvalidHook, event, source, err)
+			}
+			h.commands[event] = append(h.commands[event], c)
+		}
+	}
+	return h, nil
+}
+
+// parseCommand splits source into words and parses each as a template, checking that it
+// renders with the fields of Data.
+func parseCommand(source string) (command, error) {
+	words, err := splitWords(source)
+	if err != nil {
+		return command{}, err
+	}
+	if len(words) == 0 {
+		return command{}, errors.New("empty command")
+	}
+	c := command{source: source}
+	for _, word := range words {
+		tmpl, err := template.New("hook").Option("missingkey=error").Parse(word)
+		if err != nil {
+			return command{}, err
+		}
+		if err := tmpl.Execute(io.Discard, Data{}); err != nil {
+			return command{}, err
+		}
+		c.words = append(c.words, tmpl)
+	}
+	return c, nil
+}
+
+// Run runs the hooks of event with data in the configured order. Hooks before an event stop
+// at the first failure and return it; hooks after an event all run and their failures are
+// returned together.
+func (h *Hooks) Run(ctx context.Context, event Event, data Data) error {
+	if h == nil {
+		return nil
+	}
+	var errs []error
+	for _, c := range h.commands[event] {
+		if err := h.run(ctx, c, data); err != nil {
+			if event == PreCreate {
+				return err
+			}
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// run renders and runs one hook.
+func (h *Hooks) run(ctx context.Context, c command, data Data) error {
+	args := make([]string, len(c.words))
+	for i, word := range c.words {
+		var b strings.Builder
+		if err := word.Execute(&b, data); err != nil {
+			return fmt.Errorf("%w: %q: %w", ErrHookFailed, c.source, err)
+		}
+		args[i] = b.String()
+	}
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout, cmd.Stderr = h.Output, h.Output
+	cmd.WaitDelay = waitDelay
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			err = fmt.Errorf("%w after %s", ctx.Err(), h.timeout)
+		}
+		return fmt.Errorf("%w: %q: %w", ErrHookFailed, c.source, err)
+	}
+	return nil
+}
+
+// splitWords splits s at unquoted whitespace like a POSIX shell, without expanding
+// anything. Single quotes keep their content, double quotes allow \" and \\ and a
+// backslash outside quotes escapes the next character. Template actions such as
+// {{ printf "%s" .Key }} are kept whole, spaces and quotes included.
+func splitWords(s string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		switch {
+		case strings.HasPrefix(s[i:], "{{"):
+			end := strings.Index(s[i:], "}}")
+			if end < 0 {
+				return nil, errors.New("unclosed template action")
+			}
+			word.WriteString(s[i : i+end+2])
+			i += end + 1
+			inWord = true
+		case ch == ' ' || ch == '\t' || ch == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		case ch == '\'':
+			end := strings.IndexByte(s[i+1:], '\'')
+			if end < 0 {
+				return nil, errors.New("unclosed single quote")
+			}
+			word.WriteString(s[i+1 : i+1+end])
+			i += end + 1
+			inWord = true
+		case ch == '"':
+			j := i + 1
+			for ; j < len(s) && s[j] != '"'; j++ {
+				switch {
+				case s[j] == '\\' && j+1 < len(s) && (s[j+1] == '"' || s[j+1] == '\\'):
+					j++
+					word.WriteByte(s[j])
+				case strings.HasPrefix(s[j:], "{{"):
+					end := strings.Index(s[j:], "}}")
+					if end < 0 {
+						return nil, errors.New("unclosed template action")
+					}
+					word.WriteString(s[j : j+end+2])
+					j += end + 1
+				default:
+					word.WriteByte(s[j])
+				}
+			}
+			if j == len(s) {
+				return nil, errors.New("unclosed double quote")
+			}
+			i = j
+			inWord = true
+		case ch == '\\' && i+1 < len(s):
+			i++
+			word.WriteByte(s[i])
+			inWord = true
+		default:
+			word.WriteByte(ch)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
@@ -0,0 +1,122 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/config"
+)
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{`./notify.sh {{.Key}}`, []string{"./notify.sh", "{{.Key}}"}},
+		{`  a   b	c `, []string{"a", "b", "c"}},
+		{`say 'hello world' "it's \"{{.Key}}\"" a\ b`, []string{"say", "hello world", `it's "{{.Key}}"`, "a b"}},
+		{`echo {{ printf "%s %s" .Key .Summary }}`, []string{"echo", `{{ printf "%s %s" .Key .Summary }}`}},
+		{`echo "url: {{ printf "%s" .URL }}"`, []string{"echo", `url: {{ printf "%s" .URL }}`}},
+		{`echo ''`, []string{"echo", ""}},
+		{``, nil},
+	}
+	for _, tt := range tests {
+		got, err := splitWords(tt.in)
+		require.NoError(t, err, tt.in)
+		assert.Equal(t, tt.want, got, tt.in)
+	}
+
+	for _, in := range []string{`echo 'x`, `echo "x`, `echo {{.Key`} {
+		_, err := splitWords(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestNew(t *testing.T) {
+	h, err := New(config.HooksConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, h, "no hooks yields a nil Hooks")
+
+	h, err = New(config.HooksConfig{PostCreate: []string{"./notify.sh {{.Key}}"}})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultTimeout, h.timeout)
+
+	for _, cfg := range []config.HooksConfig{
+		{PreCreate: []string{"  "}},
+		{PostCreate: []string{"echo {{.Nope}}"}},
+		{PostCreate: []string{"echo {{.Key"}},
+		{PostCreate: []string{"echo 'x"}},
+		{PostCreate: []string{"echo"}, Timeout: -time.Second},
+	} {
+		_, err := New(cfg)
+		assert.ErrorIs(t, err, ErrInvalidHook, "%+v", cfg)
+	}
+}
+
+func TestRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks in the tests use sh")
+	}
+	h, err := New(config.HooksConfig{
+		PreCreate: []string{`sh -c 'echo "pre $1"' sh {{.Summary}}`},
+		PostCreate: []string{
+			`sh -c 'echo "post $1 $2"' sh {{.Key}} "{{.URL}}"`,
+			`sh -c 'exit 3'`,
+			`sh -c 'echo after'`,
+		},
+	})
+	require.NoError(t, err)
+	var out bytes.Buffer
+	h.Output = &out
+	data := Data{Key: "BE-1", URL: "https://jira.example.com/browse/BE-1", Summary: "Login fails; rm -rf $HOME"}
+
+	require.NoError(t, h.Run(context.Background(), PreCreate, data))
+	assert.Equal(t, "pre Login fails; rm -rf $HOME\n", out.String(), "arguments are not expanded by a shell")
+
+	out.Reset()
+	err = h.Run(context.Background(), PostCreate, data)
+	assert.ErrorIs(t, err, ErrHookFailed)
+	assert.ErrorContains(t, err, "exit status 3")
+	assert.Equal(t, "post BE-1 https://jira.example.com/browse/BE-1\nafter\n", out.String(), "post hooks run after a failure")
+
+	var nilHooks *Hooks
+	assert.NoError(t, nilHooks.Run(context.Background(), PostCreate, data))
+}
+
+func TestRun_PreStopsAtFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks in the tests use sh")
+	}
+	h, err := New(config.HooksConfig{PreCreate: []string{`sh -c 'exit 1'`, `sh -c 'echo unreachable'`}})
+	require.NoError(t, err)
+	var out bytes.Buffer
+	h.Output = &out
+
+	err = h.Run(context.Background(), PreCreate, Data{})
+	assert.ErrorIs(t, err, ErrHookFailed)
+	assert.Empty(t, out.String())
+
+	h, err = New(config.HooksConfig{PreCreate: []string{"tix-hook-that-does-not-exist"}})
+	require.NoError(t, err)
+	assert.ErrorIs(t, h.Run(context.Background(), PreCreate, Data{}), ErrHookFailed)
+}
+
+func TestRun_Timeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks in the tests use sh")
+	}
+	h, err := New(config.HooksConfig{PostCreate: []string{"sleep 5"}, Timeout: 50 * time.Millisecond})
+	require.NoError(t, err)
+
+	start := time.Now()
+	err = h.Run(context.Background(), PostCreate, Data{})
+	assert.ErrorIs(t, err, ErrHookFailed)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 3*time.Second)
+}
@@ -0,0 +1,14 @@
+package httpclient
+
+import "errors"
+
+// Sentinel errors for HTTP client construction.
+
+// ErrInvalidProxyURL indicates a configured proxy URL could not be parsed.
+var ErrInvalidProxyURL = errors.New("invalid proxy URL")
+
+// ErrCAFile indicates the configured CA bundle could not be read or contains no certificates.
+var ErrCAFile = errors.New("failed to load CA bundle")
+
+// ErrClientCert indicates the configured client certificate/key pair is incomplete or invalid.
+var ErrClientCert = errors.New("failed to load client certificate")
@@ -0,0 +1,239 @@
+// Package httpclient builds *http.Client instances and TLS configurations from the
+// outbound HTTP settings in config.yaml (egress proxies, custom CA bundles, mutual TLS).
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/karolswdev/ticketron/internal/config"
+)
+
+// maxIdleConnsPerHost is the number of idle keep-alive connections kept per host. Go's
+// default of 2 is too low for the concurrent requests of bulk operations, which then pay
+// for a new TCP and TLS handshake on almost every request.
+const maxIdleConnsPerHost = 16
+
+// NewTransport returns a pooled transport for long-lived use: keep-alives, HTTP/2 and
+// enough idle connections per host for concurrent requests. A nil tlsCfg keeps the
+// default TLS settings. Build one transport and share it; every transport has its own pool.
+func NewTransport(tlsCfg *tls.Config) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ForceAttemptHTTP2 = true // Also with a custom TLS configuration
+	transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	if tlsCfg != nil {
+		transport.TLSClientConfig = tlsCfg
+	}
+	return transport
+}
+
+// New returns an HTTP client applying cfg. It returns nil when cfg is empty so callers
+// keep their library's default client.
+func New(cfg config.HTTPConfig) (*http.Client, error) {
+	if cfg.IsZero() {
+		return nil, nil
+	}
+	transport := NewTransport(nil)
+
+	proxy, err := proxyFunc(cfg)
+	if err != nil {
+		return nil, err
+	}
+	transport.Proxy = proxy
+
+	if cfg.CAFile != "" {
+		pool, err := loadCAFile(cfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	return &http.Client{Transport: transport}, nil
+}
+
+// environmentProxy selects the proxy from HTTP_PROXY, HTTPS_PROXY and NO_PROXY; a variable
+// so tests can replace the environment, which net/http reads only once.
+var environmentProxy = http.ProxyFromEnvironment
+
+// proxyFunc selects the proxy per request scheme. Without explicit proxies the
+// environment (HTTP_PROXY, HTTPS_PROXY, NO_PROXY) is used. Hosts matching cfg.NoProxy
+// bypass the proxy either way.
+func proxyFunc(cfg config.HTTPConfig) (func(*http.Request) (*url.URL, error), error) {
+	if cfg.HTTPProxy == "" && cfg.HTTPSProxy == "" {
+		noProxy := splitNoProxy(cfg.NoProxy)
+		if len(noProxy) == 0 {
+			return environmentProxy, nil
+		}
+		return func(req *http.Request) (*url.URL, error) {
+			if bypassProxy(req.URL.Hostname(), noProxy) {
+				return nil, nil
+			}
+			return environmentProxy(req)
+		}, nil
+	}
+	httpProxy, err := parseProxy(cfg.HTTPProxy)
+	if err != nil {
+		return nil, err
+	}
+	httpsProxy, err := parseProxy(cfg.HTTPSProxy)
+	if err != nil {
+		return nil, err
+	}
+	noProxy := splitNoProxy(cfg.NoProxy)
+	return func(req *http.Request) (*url.URL, error) {
+		if bypassProxy(req.URL.Hostname(), noProxy) {
+			return nil, nil
+		}
+		if req.URL.Scheme == "https" {
+			return httpsProxy, nil
+		}
+		return httpProxy, nil
+	}, nil
+}
+
+func parseProxy(raw string) (*url.URL, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %w", ErrInvalidProxyURL, raw, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("%w %q: scheme and host are required", ErrInvalidProxyURL, raw)
+	}
+	return u, nil
+}
+
+func splitNoProxy(raw string) []string {
+	var entries []string
+	for _, entry := range strings.Split(raw, ",") {
+		if entry = strings.ToLower(strings.TrimSpace(entry)); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// bypassProxy reports whether host matches a no_proxy entry. Entries match the host
+// itself and its subdomains; a leading dot is optional and "*" matches everything.
+func bypassProxy(host string, noProxy []string) bool {
+	host = strings.ToLower(host)
+	for _, entry := range noProxy {
+		if entry == "*" {
+			return true
+		}
+		if ip := net.ParseIP(host); ip != nil {
+			if _, cidr, err := net.ParseCIDR(entry); err == nil && cidr.Contains(ip) {
+				return true
+			}
+		}
+		domain := strings.TrimPrefix(entry, ".")
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// TLSConfig builds a TLS configuration from cfg, loading the CA bundle and client
+// certificate so that invalid files are reported up front. It returns nil when cfg is empty.
+func TLSConfig(cfg config.TLSConfig) (*tls.Config, error) {
+	if cfg.IsZero() {
+		return nil, nil
+	}
+	tlsCfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify, //nolint:gosec // Explicit opt-in for testing environments
+	}
+	if cfg.CAFile != "" {
+		pool, err := loadCAFile(cfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsCfg.RootCAs = pool
+	}
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		if cfg.CertFile == "" || cfg.KeyFile == "" {
+			return nil, fmt.Errorf("%w: cert_file and key_file must be set together", ErrClientCert)
+		}
+		certFile, err := expandHome(cfg.CertFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrClientCert, err)
+		}
+		keyFile, err := expandHome(cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrClientCert, err)
+		}
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrClientCert, err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+	return tlsCfg, nil
+}
+
+// TLSFilesStamp identifies the current contents of the files of cfg by their size and
+// modification time, so that a rotated certificate can be told apart from the one a TLS
+// configuration was built from. Missing files are part of the stamp as such.
+func TLSFilesStamp(cfg config.TLSConfig) string {
+	var stamp strings.Builder
+	for _, path := range []string{cfg.CAFile, cfg.CertFile, cfg.KeyFile} {
+		if path == "" {
+			stamp.WriteString("-;")
+			continue
+		}
+		expanded, err := expandHome(path)
+		if err != nil {
+			expanded = path
+		}
+		info, err := os.Stat(expanded)
+		if err != nil {
+			stamp.WriteString("missing;")
+			continue
+		}
+		fmt.Fprintf(&stamp, "%d@%d;", info.Size(), info.ModTime().UnixNano())
+	}
+	return stamp.String()
+}
+
+// expandHome replaces a leading "~/" of path by the home directory, as for config includes.
+func expandHome(path string) (string, error) {
+	rest, ok := strings.CutPrefix(path, "~/")
+	if !ok {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, rest), nil
+}
+
+// loadCAFile returns the system roots extended with the certificates in path.
+func loadCAFile(path string) (*x509.CertPool, error) {
+	path, err := expandHome(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCAFile, err)
+	}
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCAFile, err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("%w: no certificates found in %s", ErrCAFile, path)
+	}
+	return pool, nil
+}
@@ -0,0 +1,242 @@
+package httpclient
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/config"
+)
+
+func TestNew_EmptyConfig(t *testing.T) {
+	client, err := New(config.HTTPConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, client)
+}
+
+func TestNew_InvalidSettings(t *testing.T) {
+	_, err := New(config.HTTPConfig{HTTPSProxy: "proxy.example:3128"})
+	assert.ErrorIs(t, err, ErrInvalidProxyURL)
+
+	_, err = New(config.HTTPConfig{CAFile: filepath.Join(t.TempDir(), "missing.pem")})
+	assert.ErrorIs(t, err, ErrCAFile)
+
+	empty := filepath.Join(t.TempDir(), "empty.pem")
+	require.NoError(t, os.WriteFile(empty, []byte("not a cert"), 0600))
+	_, err = New(config.HTTPConfig{CAFile: empty})
+	assert.ErrorIs(t, err, ErrCAFile)
+}
+
+func TestNew_RoutesThroughProxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String() // Proxies receive the absolute target URL
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer proxy.Close()
+
+	client, err := New(config.HTTPConfig{HTTPProxy: proxy.URL, NoProxy: "internal.example"})
+	require.NoError(t, err)
+
+	resp, err := client.Get("http://api.example.invalid/v1/models")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "http://api.example.invalid/v1/models", proxied)
+}
+
+func TestProxyFunc_NoProxy(t *testing.T) {
+	proxy, err := proxyFunc(config.HTTPConfig{
+		HTTPProxy:  "http://http-proxy:3128",
+		HTTPSProxy: "http://https-proxy:3128",
+		NoProxy:    "localhost, .corp.example,10.0.0.0/8",
+	})
+	require.NoError(t, err)
+
+	tests := map[string]string{
+		"https://api.openai.com/v1":    "http://https-proxy:3128",
+		"http://api.openai.com/v1":     "http://http-proxy:3128",
+		"http://localhost:11434":       "",
+		"https://llm.corp.example/v1":  "",
+		"https://corp.example/v1":      "",
+		"http://10.1.2.3/v1":           "",
+		"https://notcorp.example.com/": "http://https-proxy:3128",
+	}
+	for target, want := range tests {
+		u, _ := url.Parse(target)
+		got, err := proxy(&http.Request{URL: u})
+		require.NoError(t, err)
+		if want == "" {
+			assert.Nil(t, got, target)
+		} else {
+			assert.Equal(t, want, got.String(), target)
+		}
+	}
+}
+
+func TestProxyFunc_NoProxyWithEnvironmentProxy(t *testing.T) {
+	envProxy, err := url.Parse("http://env-proxy:3128")
+	require.NoError(t, err)
+	saved := environmentProxy
+	environmentProxy = func(*http.Request) (*url.URL, error) { return envProxy, nil }
+	defer func() { environmentProxy = saved }()
+
+	proxy, err := proxyFunc(config.HTTPConfig{NoProxy: ".corp.example"})
+	require.NoError(t, err)
+	u, _ := url.Parse("https://llm.corp.example/v1")
+	got, err := proxy(&http.Request{URL: u})
+	require.NoError(t, err)
+	assert.Nil(t, got, "no_proxy applies to proxies from the environment too")
+
+	u, _ = url.Parse("https://api.openai.com/v1")
+	got, err = proxy(&http.Request{URL: u})
+	require.NoError(t, err)
+	assert.Equal(t, envProxy, got)
+}
+
+func TestNew_CAFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caFile, certPEM, 0600))
+
+	client, err := New(config.HTTPConfig{CAFile: caFile})
+	require.NoError(t, err)
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+// writeKeyPair writes a self-signed certificate and its private key as PEM files.
+func writeKeyPair(t *testing.T) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "ticketron-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, "client.crt")
+	keyFile = filepath.Join(dir, "client.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
+
+func TestTLSConfig(t *testing.T) {
+	tlsCfg, err := TLSConfig(config.TLSConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, tlsCfg)
+
+	certFile, keyFile := writeKeyPair(t)
+	tlsCfg, err = TLSConfig(config.TLSConfig{CertFile: certFile, KeyFile: keyFile, InsecureSkipVerify: true})
+	require.NoError(t, err)
+	assert.Len(t, tlsCfg.Certificates, 1)
+	assert.True(t, tlsCfg.InsecureSkipVerify)
+
+	_, err = TLSConfig(config.TLSConfig{CertFile: certFile})
+	assert.ErrorIs(t, err, ErrClientCert)
+
+	_, err = TLSConfig(config.TLSConfig{CertFile: certFile, KeyFile: certFile})
+	assert.ErrorIs(t, err, ErrClientCert)
+
+	_, err = TLSConfig(config.TLSConfig{CAFile: filepath.Join(t.TempDir(), "missing.pem")})
+	assert.ErrorIs(t, err, ErrCAFile)
+}
+
+func TestTLSConfig_ExpandsHome(t *testing.T) {
+	certFile, keyFile := writeKeyPair(t)
+	t.Setenv("HOME", filepath.Dir(certFile))
+
+	tlsCfg, err := TLSConfig(config.TLSConfig{CertFile: "~/client.crt", KeyFile: "~/client.key"})
+	require.NoError(t, err)
+	assert.Len(t, tlsCfg.Certificates, 1)
+
+	_, err = TLSConfig(config.TLSConfig{CAFile: "~/client.crt"})
+	assert.NoError(t, err)
+	_, err = TLSConfig(config.TLSConfig{CAFile: "~/missing.pem", KeyFile: keyFile})
+	assert.ErrorIs(t, err, ErrCAFile)
+}
+
+func TestTLSConfig_MutualTLS(t *testing.T) {
+	certFile, keyFile := writeKeyPair(t)
+	clientCertPEM, err := os.ReadFile(certFile)
+	require.NoError(t, err)
+	clientCAs := x509.NewCertPool()
+	require.True(t, clientCAs.AppendCertsFromPEM(clientCertPEM))
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600))
+
+	tlsCfg, err := TLSConfig(config.TLSConfig{CAFile: caFile, CertFile: certFile, KeyFile: keyFile})
+	require.NoError(t, err)
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsCfg}}
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestNewTransport_ReusesConnections(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	var newConns int32
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&newConns, 1)
+		}
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	transport := NewTransport(&tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12})
+	assert.True(t, transport.ForceAttemptHTTP2)
+	assert.Equal(t, maxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+
+	client := &http.Client{Transport: transport}
+	for i := 0; i < 5; i++ {
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&newConns), "sequential requests share one TLS connection")
+}
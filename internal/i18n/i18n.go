@@ -0,0 +1,114 @@
+// Package i18n translates user-facing CLI messages: error hints and confirmation prompts.
+// Messages are identified by Message constants and looked up in a per-language catalog,
+// falling back to English for languages or messages without a translation.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Supported languages.
+const (
+	English = "en"
+	Polish  = "pl"
+)
+
+// Message identifies a translatable message. The English text is a fmt format string.
+type Message string
+
+// catalogs holds the messages of each supported language.
+var catalogs = map[string]map[Message]string{
+	English: english,
+	Polish:  polish,
+}
+
+var (
+	mu      sync.RWMutex
+	current = Resolve("")
+)
+
+// Supported returns the supported language codes, English first.
+func Supported() []string {
+	return []string{English, Polish}
+}
+
+// Resolve returns the supported language selected by configured (e.g. ui.language) or,
+// when it is empty, by the LC_ALL, LC_MESSAGES and LANG environment variables. The first
+// setting found decides, as with gettext; anything unsupported selects English.
+func Resolve(configured string) string {
+	candidates := []string{configured}
+	if configured == "" {
+		candidates = []string{os.Getenv("LC_ALL"), os.Getenv("LC_MESSAGES"), os.Getenv("LANG")}
+	}
+	for _, candidate := range candidates {
+		if candidate == "" {
+			continue
+		}
+		if lang, ok := Lookup(candidate); ok {
+			return lang
+		}
+		return English
+	}
+	return English
+}
+
+// Lookup returns the supported language named by a language code or locale such as
+// "pl", "pl-PL" or "pl_PL.UTF-8", and whether there is one.
+func Lookup(locale string) (string, bool) {
+	lang := strings.ToLower(locale)
+	if i := strings.IndexAny(lang, "_-.@"); i >= 0 {
+		lang = lang[:i]
+	}
+	_, ok := catalogs[lang]
+	return lang, ok
+}
+
+// SetLanguage selects the language used by T. Unsupported languages select English.
+func SetLanguage(lang string) {
+	if _, ok := catalogs[lang]; !ok {
+		lang = English
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	current = lang
+}
+
+// Language returns the language used by T.
+func Language() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// T returns msg in the current language, formatted with args.
+func T(msg Message, args ...any) string {
+	format, ok := catalogs[Language()][msg]
+	if !ok {
+		format, ok = english[msg]
+	}
+	if !ok {
+		format = string(msg)
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// IsYes reports whether answer accepts a yes/no prompt in the current language. The
+// English "y" and "yes" are always accepted.
+func IsYes(answer string) bool {
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	if answer == "y" || answer == "yes" {
+		return true
+	}
+	for _, yes := range strings.Split(T(MsgYesAnswers), ",") {
+		if answer == yes {
+			return true
+		}
+	}
+	return false
+}
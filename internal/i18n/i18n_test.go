@@ -0,0 +1,84 @@
+package i18n
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// verbs matches fmt verbs, ignoring escaped percent signs.
+var verbs = regexp.MustCompile(`%[-+# 0]*[a-zA-Z]`)
+
+func TestCatalogsComplete(t *testing.T) {
+	for lang, catalog := range catalogs {
+		for msg, text := range english {
+			translated, ok := catalog[msg]
+			if !assert.True(t, ok, "%s: missing %s", lang, msg) {
+				continue
+			}
+			assert.Equal(t, verbs.FindAllString(text, -1), verbs.FindAllString(translated, -1), "%s: %s must use the same verbs as English", lang, msg)
+		}
+		for msg := range catalog {
+			assert.Contains(t, english, msg, "%s: %s is not an English message", lang, msg)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "pl_PL.UTF-8")
+	assert.Equal(t, Polish, Resolve(""))
+	assert.Equal(t, English, Resolve("en"))
+	assert.Equal(t, Polish, Resolve("PL"))
+	assert.Equal(t, English, Resolve("fr"))
+
+	t.Setenv("LC_ALL", "de_DE.UTF-8")
+	assert.Equal(t, English, Resolve(""), "the first locale variable set decides")
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LANG", "")
+	assert.Equal(t, English, Resolve(""))
+}
+
+func TestLookup(t *testing.T) {
+	for _, locale := range []string{"pl", "pl-PL", "pl_PL.UTF-8", "pl_PL@euro"} {
+		lang, ok := Lookup(locale)
+		assert.True(t, ok, locale)
+		assert.Equal(t, Polish, lang, locale)
+	}
+	_, ok := Lookup("klingon")
+	assert.False(t, ok)
+}
+
+func TestT(t *testing.T) {
+	defer SetLanguage(Language())
+
+	SetLanguage(English)
+	assert.Equal(t, "Apply to 3 issue(s)?", T(MsgConfirmApply, 3))
+	assert.Equal(t, "Aborted.", T(MsgAborted))
+
+	SetLanguage(Polish)
+	assert.Equal(t, Polish, Language())
+	assert.Equal(t, "Zastosować do zgłoszeń (3)?", T(MsgConfirmApply, 3))
+	assert.Equal(t, "unknown.message", T("unknown.message"))
+
+	SetLanguage("fr")
+	assert.Equal(t, English, Language())
+}
+
+func TestIsYes(t *testing.T) {
+	defer SetLanguage(Language())
+
+	SetLanguage(English)
+	assert.True(t, IsYes("y\n"))
+	assert.True(t, IsYes(" YES "))
+	assert.False(t, IsYes("tak"))
+	assert.False(t, IsYes(""))
+
+	SetLanguage(Polish)
+	assert.True(t, IsYes("t"))
+	assert.True(t, IsYes("Tak\n"))
+	assert.True(t, IsYes("y"), "English answers are always accepted")
+	assert.False(t, IsYes("n"))
+}
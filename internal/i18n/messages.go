@@ -0,0 +1,207 @@
+package i18n
+
+// Messages. The English catalog below is the source text; keep translations in sync.
+const (
+	// Confirmation prompts
+	MsgYesNoHint        Message = "prompt.yes_no_hint"
+	MsgDefaultYesHint   Message = "prompt.default_yes_hint"
+	MsgYesAnswers       Message = "prompt.yes_answers"
+	MsgAborted          Message = "prompt.aborted"
+	MsgInputError       Message = "prompt.input_error"
+	MsgIssueDetails     Message = "create.issue_details"
+	MsgConfirmCreate    Message = "create.confirm"
+	MsgRefinePrompt     Message = "create.refine_prompt"
+	MsgConfirmDelete    Message = "delete.confirm"
+	MsgConfirmCancel    Message = "delete.confirm_cancel"
+	MsgConfirmApply     Message = "search.apply_confirm"
+	MsgConfirmScan      Message = "scan.confirm"
+	MsgConfirmUpdate    Message = "update.confirm"
+	MsgConfirmRefetch   Message = "update.confirm_refetch"
+	MsgConfirmDrafts    Message = "draft.confirm_submit"
+	MsgConfirmRollback  Message = "rollback.confirm"
+	MsgRollbackHint     Message = "rollback.cancel_hint"
+	MsgRecentCreate     Message = "create.recent_duplicate"
+	MsgCreateReplayed   Message = "create.replayed"
+	MsgDeleteCancelHint Message = "delete.cancel_hint"
+
+	// Configuration files
+	MsgConfigParseHint      Message = "config.parse_hint"
+	MsgConfigDirHint        Message = "config.dir_hint"
+	MsgConfigUnexpected     Message = "config.unexpected"
+	MsgConfigUnexpectedErr  Message = "config.unexpected_error"
+	MsgRunConfigInit        Message = "config.run_init"
+	MsgRunConfigInitDefault Message = "config.run_init_default"
+	MsgLinksParseHint       Message = "links.parse_hint"
+	MsgLinksUnexpected      Message = "links.unexpected"
+	MsgPromptReadHint       Message = "prompt_file.read_hint"
+	MsgPromptUnexpected     Message = "prompt_file.unexpected"
+	MsgContextReadHint      Message = "context.read_hint"
+	MsgContextUnexpected    Message = "context.unexpected"
+	MsgRedactionHint        Message = "redaction.hint"
+	MsgGuardrailsHint       Message = "guardrails.hint"
+	MsgTimezoneHint         Message = "timezone.hint"
+	MsgPromptListHint       Message = "prompt_versions.list_hint"
+
+	// LLM
+	MsgLLMNotInitialized    Message = "llm.not_initialized"
+	MsgLLMConfigHint        Message = "llm.config_hint"
+	MsgLLMOverrideError     Message = "llm.override_error"
+	MsgAPIKeyNotFound       Message = "llm.api_key_not_found"
+	MsgAPIKeyHint           Message = "llm.api_key_hint"
+	MsgLLMAPIError          Message = "llm.api_error"
+	MsgLLMNetworkHint       Message = "llm.network_hint"
+	MsgLLMResponseError     Message = "llm.response_error"
+	MsgLLMFormatHint        Message = "llm.format_hint"
+	MsgLLMUnexpected        Message = "llm.unexpected"
+	MsgProjectMapError      Message = "project.map_error"
+	MsgProjectLinksHint     Message = "project.links_hint"
+	MsgProjectDefaultUsed   Message = "project.default_used"
+	MsgProjectMapUnexpected Message = "project.map_unexpected"
+	MsgTypesHint            Message = "types.hint"
+	MsgFieldsHint           Message = "fields.hint"
+	MsgDueDateHint          Message = "due_date.hint"
+
+	// Guardrails
+	MsgGuardrailsBlockedHint Message = "guardrails.blocked_hint"
+	MsgGuardrailsRedacted    Message = "guardrails.redacted"
+
+	// MCP server
+	MsgMCPNotInitialized    Message = "mcp.not_initialized"
+	MsgMCPConfigHint        Message = "mcp.config_hint"
+	MsgMCPConnectError      Message = "mcp.connect_error"
+	MsgMCPConnectHint       Message = "mcp.connect_hint"
+	MsgMCPServerError       Message = "mcp.server_error"
+	MsgMCPUnparseable       Message = "mcp.unparseable"
+	MsgMCPCreateDecode      Message = "mcp.create_decode"
+	MsgMCPCreateUnexpected  Message = "mcp.create_unexpected"
+	MsgMCPSearchError       Message = "mcp.search_error"
+	MsgMCPSearchUnparseable Message = "mcp.search_unparseable"
+	MsgMCPSearchDecode      Message = "mcp.search_decode"
+	MsgMCPSearchUnexpected  Message = "mcp.search_unexpected"
+	MsgMCPURLMissing        Message = "mcp.url_missing"
+	MsgMCPURLHint           Message = "mcp.url_hint"
+	MsgMCPURLParse          Message = "mcp.url_parse"
+	MsgMCPURLFormatHint     Message = "mcp.url_format_hint"
+	MsgMCPInitError         Message = "mcp.init_error"
+
+	// Search
+	MsgNoJQL              Message = "search.no_jql"
+	MsgNoJQLHint          Message = "search.no_jql_hint"
+	MsgFormatResultsError Message = "search.format_error"
+
+	// CI mode
+	MsgCIInputHint  Message = "ci.input_hint"
+	MsgCIAPIKeyHint Message = "ci.api_key_hint"
+	MsgCISecretHint Message = "ci.secret_hint"
+
+	// Progress indicators
+	MsgProgressLLM    Message = "progress.llm"
+	MsgProgressCreate Message = "progress.create"
+	MsgProgressSearch Message = "progress.search"
+	MsgProgressExport Message = "progress.export"
+
+	// --explain
+	MsgExplainHeading  Message = "explain.heading"
+	MsgProgressExplain Message = "progress.explain"
+
+	// --jq
+	MsgJQOutputHint Message = "jq.output_hint"
+)
+
+// english is the source catalog.
+var english = map[Message]string{
+	MsgYesNoHint:        "[y/N]",
+	MsgDefaultYesHint:   "[Y/n]",
+	MsgYesAnswers:       "y,yes",
+	MsgAborted:          "Aborted.",
+	MsgInputError:       "Error reading input: %v",
+	MsgIssueDetails:     "--- Issue Details ---\nProject Key: %s\nIssue Type:  %s\nSummary:     %s\nDescription:\n%s\n---------------------",
+	MsgConfirmCreate:    "Create this issue?",
+	MsgRefinePrompt:     "Refine (describe a change, or press Enter to accept):",
+	MsgConfirmDelete:    "Permanently delete %s? This cannot be undone.",
+	MsgConfirmCancel:    "Transition %s to %q?",
+	MsgConfirmApply:     "Apply to %d issue(s)?",
+	MsgConfirmScan:      "Create %d issue(s) for untracked comments?",
+	MsgConfirmUpdate:    "Update %s?",
+	MsgConfirmDrafts:    "Create %d issue(s) from drafts?",
+	MsgConfirmRollback:  "%d issue(s) failed. Delete the %d issue(s) created so far, so all of them can be created again together?",
+	MsgRollbackHint:     "If deleting issues is not permitted in your Jira workflow, use --on-failure cancel to transition them instead.",
+	MsgRecentCreate:     "Warning: %s was already created from the same input in project %s at %s.",
+	MsgCreateReplayed:   "The MCP server returned %s, created earlier from the same input, instead of creating another issue.",
+	MsgConfirmRefetch:   "%s was changed by someone else since it was fetched. Fetch it again and review the changes?",
+	MsgDeleteCancelHint: "If deleting issues is not permitted in your Jira workflow, use --cancel to transition them instead.",
+
+	MsgConfigParseHint:      "Error reading or parsing config.yaml. Please check its format and permissions.",
+	MsgConfigDirHint:        "Error accessing configuration directory. Please check permissions.",
+	MsgConfigUnexpected:     "An unexpected error occurred loading config.yaml.",
+	MsgConfigUnexpectedErr:  "An unexpected error occurred loading config.yaml: %v",
+	MsgRunConfigInit:        "You might need to run 'tix config init'.",
+	MsgRunConfigInitDefault: "You might need to run 'tix config init' to create a default.",
+	MsgLinksParseHint:       "Error reading or parsing links.yaml. Please check its format and permissions.",
+	MsgLinksUnexpected:      "An unexpected error occurred loading links.yaml.",
+	MsgPromptReadHint:       "Error reading system_prompt.txt. Please check its permissions.",
+	MsgPromptUnexpected:     "An unexpected error occurred loading system_prompt.txt.",
+	MsgContextReadHint:      "Error reading context.md. Please check its permissions.",
+	MsgContextUnexpected:    "An unexpected error occurred loading context.md.",
+	MsgRedactionHint:        "Error in the 'redaction' section of config.yaml. Please check the rule names and patterns.",
+	MsgGuardrailsHint:       "Error in the 'guardrails' section of config.yaml or a 'guardrails' profile in links.yaml. Please check the profile names, actions and patterns.",
+	MsgTimezoneHint:         "Please check the 'timezone' setting in ~/.ticketron/config.yaml.",
+	MsgPromptListHint:       "Run 'tix prompt list' to see the available versions.",
+
+	MsgLLMNotInitialized:    "Error: LLM client not initialized.",
+	MsgLLMConfigHint:        "Please check your LLM provider configuration and API key setup ('tix config show', 'tix config set-key').",
+	MsgLLMOverrideError:     "Error: could not initialize the LLM client with the LLM override flags (--provider, --model, --temperature, ...).",
+	MsgAPIKeyNotFound:       "Error: LLM API key not found.",
+	MsgAPIKeyHint:           "Please store it using 'tix config set-key <your-key>' or set the %s environment variable.",
+	MsgLLMAPIError:          "Error communicating with the LLM API: %v",
+	MsgLLMNetworkHint:       "Please check your network connection and API key/endpoint configuration.",
+	MsgLLMResponseError:     "Error processing the response from the LLM: %v",
+	MsgLLMFormatHint:        "The LLM might have returned an unexpected format. Check logs for details.",
+	MsgLLMUnexpected:        "An unexpected error occurred during LLM processing: %v",
+	MsgProjectMapError:      "Error: Could not map LLM's project suggestion '%s' to a known project key.",
+	MsgProjectLinksHint:     "Please check your ~/.ticketron/links.yaml file or the LLM's output, pass --project or set default_project in config.yaml.",
+	MsgProjectDefaultUsed:   "Project '%s' suggested by the LLM is not in links.yaml; using default_project %s.",
+	MsgProjectMapUnexpected: "An unexpected error occurred during project mapping: %v",
+	MsgTypesHint:            "Run 'tix types %s' to see the issue types of the project.",
+	MsgFieldsHint:           "Run 'tix fields list %s' to see the issue types and fields of the project.",
+	MsgDueDateHint:          "Use a date such as 2025-05-01, tomorrow, friday, next friday, in 3 days or end of month.",
+
+	MsgGuardrailsBlockedHint: "Rephrase the request without the banned content, or set 'action: redact' on the guardrail profile to remove it instead.",
+	MsgGuardrailsRedacted:    "Warning: guardrail profile '%s' redacted %d match(es) in the generated ticket: %s.",
+
+	MsgMCPNotInitialized:    "Error: MCP client not initialized.",
+	MsgMCPConfigHint:        "Please check the 'mcp_server_url' in your configuration ('tix config show').",
+	MsgMCPConnectError:      "Error connecting to the MCP server: %v",
+	MsgMCPConnectHint:       "Please ensure the MCP server is running and the URL is correct.",
+	MsgMCPServerError:       "MCP server returned an error: %v",
+	MsgMCPUnparseable:       "MCP server returned an error with an unparseable response body: %v",
+	MsgMCPCreateDecode:      "Failed to decode the success response from the MCP server: %v",
+	MsgMCPCreateUnexpected:  "An unexpected error occurred while creating the issue via MCP: %v",
+	MsgMCPSearchError:       "MCP server returned an error during search: %v",
+	MsgMCPSearchUnparseable: "MCP server returned an error with an unparseable response body during search: %v",
+	MsgMCPSearchDecode:      "Failed to decode the search results from the MCP server: %v",
+	MsgMCPSearchUnexpected:  "An unexpected error occurred while searching issues via MCP: %v",
+	MsgMCPURLMissing:        "Error: MCP Server URL is not configured.",
+	MsgMCPURLHint:           "Please set 'mcp_server_url' in ~/.ticketron/config.yaml or use the TICKETRON_MCP_SERVER_URL environment variable.",
+	MsgMCPURLParse:          "Error parsing MCP Server URL: %v",
+	MsgMCPURLFormatHint:     "Please check the URL format in your configuration.",
+	MsgMCPInitError:         "Failed to initialize MCP client: %v",
+
+	MsgNoJQL:              "Error: No JQL query provided.",
+	MsgNoJQLHint:          "Please provide the query as arguments, use the --jql flag or filter flags such as --project.",
+	MsgFormatResultsError: "Error formatting search results: %v",
+
+	MsgCIInputHint:  "CI mode never prompts: pass --yes to confirm, or give the answer as a flag.",
+	MsgCIAPIKeyHint: "CI mode reads the API key only from the %s environment variable or a secrets.api_key reference (vault://, aws-sm://, op://).",
+	MsgCISecretHint: "Check that the secret manager's CLI is installed and authenticated in the pipeline, e.g. VAULT_ADDR and VAULT_TOKEN for vault://.",
+
+	MsgProgressLLM:    "Contacting LLM…",
+	MsgProgressCreate: "Creating issue…",
+	MsgProgressSearch: "Searching…",
+	MsgProgressExport: "Exporting issues…",
+
+	MsgExplainHeading:  "Explanation by the LLM (it may be wrong):",
+	MsgProgressExplain: "Asking the LLM about the error…",
+
+	MsgJQOutputHint: "--jq filters JSON: use it with -o json, or with a command that prints JSON.",
+}
@@ -0,0 +1,99 @@
+package i18n
+
+// polish is the Polish catalog.
+var polish = map[Message]string{
+	MsgYesNoHint:        "[t/N]",
+	MsgDefaultYesHint:   "[T/n]",
+	MsgYesAnswers:       "t,tak",
+	MsgAborted:          "Przerwano.",
+	MsgInputError:       "Błąd odczytu odpowiedzi: %v",
+	MsgIssueDetails:     "--- Szczegóły zgłoszenia ---\nProjekt: %s\nTyp:      %s\nTytuł:    %s\nOpis:\n%s\n----------------------------",
+	MsgConfirmCreate:    "Utworzyć to zgłoszenie?",
+	MsgRefinePrompt:     "Popraw (opisz zmianę lub naciśnij Enter, aby zaakceptować):",
+	MsgConfirmDelete:    "Trwale usunąć %s? Tej operacji nie można cofnąć.",
+	MsgConfirmCancel:    "Wykonać przejście %s do %q?",
+	MsgConfirmApply:     "Zastosować do zgłoszeń (%d)?",
+	MsgConfirmScan:      "Utworzyć zgłoszenia (%d) dla nieśledzonych komentarzy?",
+	MsgConfirmUpdate:    "Zaktualizować %s?",
+	MsgConfirmDrafts:    "Utworzyć zgłoszenia (%d) z wersji roboczych?",
+	MsgConfirmRollback:  "Nie udało się utworzyć części zgłoszeń (%d). Usunąć zgłoszenia utworzone do tej pory (%d), aby można było utworzyć wszystkie ponownie razem?",
+	MsgRollbackHint:     "Jeśli Twój proces w Jira nie pozwala usuwać zgłoszeń, użyj --on-failure cancel, aby zamiast tego wykonać przejście.",
+	MsgRecentCreate:     "Uwaga: %s utworzono już z tych samych danych w projekcie %s o %s.",
+	MsgCreateReplayed:   "Serwer MCP zwrócił %s, utworzone wcześniej z tych samych danych, zamiast tworzyć kolejne zgłoszenie.",
+	MsgConfirmRefetch:   "Ktoś inny zmienił %s od czasu pobrania. Pobrać ponownie i przejrzeć zmiany?",
+	MsgDeleteCancelHint: "Jeśli Twój proces w Jira nie pozwala usuwać zgłoszeń, użyj --cancel, aby zamiast tego wykonać przejście.",
+
+	MsgConfigParseHint:      "Błąd odczytu lub parsowania config.yaml. Sprawdź jego format i uprawnienia.",
+	MsgConfigDirHint:        "Błąd dostępu do katalogu konfiguracji. Sprawdź uprawnienia.",
+	MsgConfigUnexpected:     "Wystąpił nieoczekiwany błąd podczas wczytywania config.yaml.",
+	MsgConfigUnexpectedErr:  "Wystąpił nieoczekiwany błąd podczas wczytywania config.yaml: %v",
+	MsgRunConfigInit:        "Może być konieczne uruchomienie 'tix config init'.",
+	MsgRunConfigInitDefault: "Może być konieczne uruchomienie 'tix config init', aby utworzyć plik domyślny.",
+	MsgLinksParseHint:       "Błąd odczytu lub parsowania links.yaml. Sprawdź jego format i uprawnienia.",
+	MsgLinksUnexpected:      "Wystąpił nieoczekiwany błąd podczas wczytywania links.yaml.",
+	MsgPromptReadHint:       "Błąd odczytu system_prompt.txt. Sprawdź jego uprawnienia.",
+	MsgPromptUnexpected:     "Wystąpił nieoczekiwany błąd podczas wczytywania system_prompt.txt.",
+	MsgContextReadHint:      "Błąd odczytu context.md. Sprawdź jego uprawnienia.",
+	MsgContextUnexpected:    "Wystąpił nieoczekiwany błąd podczas wczytywania context.md.",
+	MsgRedactionHint:        "Błąd w sekcji 'redaction' pliku config.yaml. Sprawdź nazwy reguł i wzorce.",
+	MsgGuardrailsHint:       "Błąd w sekcji 'guardrails' pliku config.yaml lub w profilu 'guardrails' w links.yaml. Sprawdź nazwy profili, akcje i wzorce.",
+	MsgTimezoneHint:         "Sprawdź ustawienie 'timezone' w ~/.ticketron/config.yaml.",
+	MsgPromptListHint:       "Uruchom 'tix prompt list', aby zobaczyć dostępne wersje.",
+
+	MsgLLMNotInitialized:    "Błąd: klient LLM nie został zainicjowany.",
+	MsgLLMConfigHint:        "Sprawdź konfigurację dostawcy LLM i klucz API ('tix config show', 'tix config set-key').",
+	MsgLLMOverrideError:     "Błąd: nie udało się zainicjować klienta LLM z flagami nadpisującymi LLM (--provider, --model, --temperature, ...).",
+	MsgAPIKeyNotFound:       "Błąd: nie znaleziono klucza API LLM.",
+	MsgAPIKeyHint:           "Zapisz go poleceniem 'tix config set-key <klucz>' lub ustaw zmienną środowiskową %s.",
+	MsgLLMAPIError:          "Błąd komunikacji z API LLM: %v",
+	MsgLLMNetworkHint:       "Sprawdź połączenie sieciowe oraz konfigurację klucza API i adresu usługi.",
+	MsgLLMResponseError:     "Błąd przetwarzania odpowiedzi LLM: %v",
+	MsgLLMFormatHint:        "LLM mógł zwrócić odpowiedź w nieoczekiwanym formacie. Szczegóły znajdziesz w logach.",
+	MsgLLMUnexpected:        "Wystąpił nieoczekiwany błąd podczas przetwarzania przez LLM: %v",
+	MsgProjectMapError:      "Błąd: nie udało się dopasować projektu '%s' zaproponowanego przez LLM do znanego klucza projektu.",
+	MsgProjectLinksHint:     "Sprawdź plik ~/.ticketron/links.yaml lub odpowiedź LLM, użyj flagi --project albo ustaw default_project w config.yaml.",
+	MsgProjectDefaultUsed:   "Projektu '%s' zaproponowanego przez LLM nie ma w links.yaml; użyto default_project %s.",
+	MsgProjectMapUnexpected: "Wystąpił nieoczekiwany błąd podczas dopasowywania projektu: %v",
+	MsgTypesHint:            "Uruchom 'tix types %s', aby zobaczyć typy zgłoszeń projektu.",
+	MsgFieldsHint:           "Uruchom 'tix fields list %s', aby zobaczyć typy zgłoszeń i pola projektu.",
+	MsgDueDateHint:          "Podaj datę, np. 2025-05-01, tomorrow, friday, next friday, in 3 days lub end of month.",
+
+	MsgGuardrailsBlockedHint: "Przeformułuj prośbę bez zabronionych treści lub ustaw 'action: redact' w profilu guardrails, aby je usuwać.",
+	MsgGuardrailsRedacted:    "Uwaga: profil guardrails '%s' ukrył %d dopasowań w wygenerowanym zgłoszeniu: %s.",
+
+	MsgMCPNotInitialized:    "Błąd: klient MCP nie został zainicjowany.",
+	MsgMCPConfigHint:        "Sprawdź ustawienie 'mcp_server_url' w konfiguracji ('tix config show').",
+	MsgMCPConnectError:      "Błąd połączenia z serwerem MCP: %v",
+	MsgMCPConnectHint:       "Upewnij się, że serwer MCP działa i adres URL jest poprawny.",
+	MsgMCPServerError:       "Serwer MCP zwrócił błąd: %v",
+	MsgMCPUnparseable:       "Serwer MCP zwrócił błąd z nieczytelną treścią odpowiedzi: %v",
+	MsgMCPCreateDecode:      "Nie udało się odczytać odpowiedzi serwera MCP: %v",
+	MsgMCPCreateUnexpected:  "Wystąpił nieoczekiwany błąd podczas tworzenia zgłoszenia przez MCP: %v",
+	MsgMCPSearchError:       "Serwer MCP zwrócił błąd podczas wyszukiwania: %v",
+	MsgMCPSearchUnparseable: "Serwer MCP zwrócił błąd z nieczytelną treścią odpowiedzi podczas wyszukiwania: %v",
+	MsgMCPSearchDecode:      "Nie udało się odczytać wyników wyszukiwania z serwera MCP: %v",
+	MsgMCPSearchUnexpected:  "Wystąpił nieoczekiwany błąd podczas wyszukiwania zgłoszeń przez MCP: %v",
+	MsgMCPURLMissing:        "Błąd: adres serwera MCP nie jest skonfigurowany.",
+	MsgMCPURLHint:           "Ustaw 'mcp_server_url' w ~/.ticketron/config.yaml lub użyj zmiennej środowiskowej TICKETRON_MCP_SERVER_URL.",
+	MsgMCPURLParse:          "Błąd parsowania adresu serwera MCP: %v",
+	MsgMCPURLFormatHint:     "Sprawdź format adresu URL w konfiguracji.",
+	MsgMCPInitError:         "Nie udało się zainicjować klienta MCP: %v",
+
+	MsgNoJQL:              "Błąd: nie podano zapytania JQL.",
+	MsgNoJQLHint:          "Podaj zapytanie jako argumenty, użyj flagi --jql lub filtrów takich jak --project.",
+	MsgFormatResultsError: "Błąd formatowania wyników wyszukiwania: %v",
+
+	MsgCIInputHint:  "W trybie CI nic nie jest pytane: potwierdź flagą --yes lub podaj odpowiedź jako flagę.",
+	MsgCIAPIKeyHint: "W trybie CI klucz API jest odczytywany tylko ze zmiennej środowiskowej %s lub z odwołania secrets.api_key (vault://, aws-sm://, op://).",
+	MsgCISecretHint: "Sprawdź, czy CLI menedżera sekretów jest zainstalowane i uwierzytelnione w potoku, np. VAULT_ADDR i VAULT_TOKEN dla vault://.",
+
+	MsgProgressLLM:    "Łączenie z LLM…",
+	MsgProgressCreate: "Tworzenie zgłoszenia…",
+	MsgProgressSearch: "Wyszukiwanie…",
+	MsgProgressExport: "Eksportowanie zgłoszeń…",
+
+	MsgExplainHeading:  "Wyjaśnienie od LLM (może być błędne):",
+	MsgProgressExplain: "Pytanie LLM o błąd…",
+
+	MsgJQOutputHint: "--jq filtruje JSON: użyj go z -o json albo z poleceniem, które wypisuje JSON.",
+}
@@ -0,0 +1,258 @@
+package ingest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+
+	"github.com/karolswdev/ticketron/internal/sanitize"
+)
+
+// maxEmailParts bounds the MIME parts read from a message, against crafted nesting.
+const maxEmailParts = 100
+
+// Email is an RFC 822 message reduced to what an issue needs.
+type Email struct {
+	From        string
+	Subject     string
+	MessageID   string
+	Body        string // The text/plain body, or the text of the HTML body if there is none
+	Attachments []Attachment
+}
+
+// Attachment describes a file attached to an email. The content is not kept.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Size        int // Decoded size in bytes
+}
+
+// ParseEmail reads an RFC 822 message, such as an .eml file or a message piped from
+// procmail. Encoded headers and the quoted-printable and base64 transfer encodings are
+// decoded; the body is converted to UTF-8 if it is in ISO-8859-1 or US-ASCII.
+func ParseEmail(r io.Reader) (*Email, error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrEmail, err)
+	}
+	decoder := new(mime.WordDecoder)
+	header := func(name string) string {
+		value := msg.Header.Get(name)
+		if decoded, err := decoder.DecodeHeader(value); err == nil {
+			value = decoded
+		}
+		return strings.TrimSpace(sanitize.Line(value))
+	}
+	email := &Email{
+		From:      header("From"),
+		Subject:   header("Subject"),
+		MessageID: header("Message-Id"),
+	}
+
+	parts := 0
+	var plain, htmlText string
+	var walk func(header map[string][]string, body io.Reader) error
+	walk = func(h map[string][]string, body io.Reader) error {
+		if parts++; parts > maxEmailParts {
+			return fmt.Errorf("%w: more than %d MIME parts", ErrEmail, maxEmailParts)
+		}
+		get := func(name string) string {
+			if values := h[name]; len(values) > 0 {
+				return values[0]
+			}
+			return ""
+		}
+		mediaType, params, err := mime.ParseMediaType(get("Content-Type"))
+		if err != nil {
+			mediaType, params = "text/plain", map[string]string{}
+		}
+		if strings.HasPrefix(mediaType, "multipart/") {
+			reader := multipart.NewReader(body, params["boundary"])
+			for {
+				part, err := reader.NextRawPart()
+				if err == io.EOF {
+					return nil
+				}
+				if err != nil {
+					return fmt.Errorf("%w: %w", ErrEmail, err)
+				}
+				if err := walk(part.Header, part); err != nil {
+					return err
+				}
+			}
+		}
+
+		content, err := decodeTransfer(get("Content-Transfer-Encoding"), body)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrEmail, err)
+		}
+		disposition, dispositionParams, _ := mime.ParseMediaType(get("Content-Disposition"))
+		filename := dispositionParams["filename"]
+		if filename == "" {
+			filename = params["name"]
+		}
+		if decoded, err := decoder.DecodeHeader(filename); err == nil {
+			filename = decoded
+		}
+		isText := mediaType == "text/plain" || mediaType == "text/html"
+		if disposition == "attachment" || filename != "" || !isText {
+			email.Attachments = append(email.Attachments, Attachment{
+				Filename:    sanitize.Line(filename),
+				ContentType: mediaType,
+				Size:        len(content),
+			})
+			return nil
+		}
+		text := toUTF8(content, params["charset"])
+		if mediaType == "text/plain" && plain == "" {
+			plain = text
+		} else if mediaType == "text/html" && htmlText == "" {
+			htmlText = htmlToText(text)
+		}
+		return nil
+	}
+	if err := walk(msg.Header, msg.Body); err != nil {
+		return nil, err
+	}
+
+	email.Body = plain
+	if strings.TrimSpace(email.Body) == "" {
+		email.Body = htmlText
+	}
+	email.Body = strings.TrimSpace(sanitize.Text(email.Body))
+	return email, nil
+}
+
+// decodeTransfer reads body decoded from its Content-Transfer-Encoding.
+func decodeTransfer(encoding string, body io.Reader) ([]byte, error) {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, &lineJoiner{r: body})
+	}
+	return io.ReadAll(body)
+}
+
+// lineJoiner drops the line breaks base64 bodies are wrapped with.
+type lineJoiner struct {
+	r io.Reader
+}
+
+func (j *lineJoiner) Read(p []byte) (int, error) {
+	n, err := j.r.Read(p)
+	kept := bytes.Map(func(r rune) rune {
+		if r == '\r' || r == '\n' || r == ' ' || r == '\t' {
+			return -1
+		}
+		return r
+	}, p[:n])
+	return copy(p, kept), err
+}
+
+// toUTF8 converts text in charset to UTF-8. Only ISO-8859-1 needs converting among the
+// charsets the standard library knows; others are passed through.
+func toUTF8(content []byte, charset string) string {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1":
+		runes := make([]rune, len(content))
+		for i, b := range content {
+			runes[i] = rune(b)
+		}
+		return string(runes)
+	}
+	return string(content)
+}
+
+var (
+	htmlBlockPattern = regexp.MustCompile(`(?i)<(br|/p|/div|/li|/tr|/h[1-6])\b[^>]*>`)
+	htmlDropPattern  = regexp.MustCompile(`(?is)<(style|script|head)\b.*?</(style|script|head)>`)
+	htmlTagPattern   = regexp.MustCompile(`(?s)<[^>]*>`)
+	blankLinesRegexp = regexp.MustCompile(`\n{3,}`)
+)
+
+// htmlToText reduces an HTML body to its text, keeping line breaks at block ends.
+func htmlToText(s string) string {
+	s = htmlDropPattern.ReplaceAllString(s, "")
+	s = htmlBlockPattern.ReplaceAllString(s, "\n")
+	s = htmlTagPattern.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return blankLinesRegexp.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+}
+
+// replyHeaderPattern matches the line mail clients put above a quoted message, such as
+// "On Mon, 3 Jun 2024 at 10:00, Ann <ann@example.com> wrote:".
+var replyHeaderPattern = regexp.MustCompile(`(?i)^(on\b.+\bwrote:|-+\s*original message\s*-+|-+\s*forwarded message\s*-+|from:\s.+)$`)
+
+// StripQuoted removes the noise of replies from an email body: quoted lines starting with
+// ">", everything from a reply header such as "On ... wrote:" or "-----Original Message-----"
+// onwards, and the signature after a "-- " line. The LLM cleans up what remains.
+func StripQuoted(body string) string {
+	var kept []string
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if line == "-- " || line == "--" || replyHeaderPattern.MatchString(trimmed) {
+			break
+		}
+		if strings.HasPrefix(trimmed, ">") {
+			continue
+		}
+		kept = append(kept, strings.TrimRight(line, " \t"))
+	}
+	return strings.TrimSpace(blankLinesRegexp.ReplaceAllString(strings.Join(kept, "\n"), "\n\n"))
+}
+
+// Description returns the body without reply noise, followed by the list of attachments.
+func (e *Email) Description() string {
+	var b strings.Builder
+	b.WriteString(StripQuoted(e.Body))
+	if len(e.Attachments) > 0 {
+		if b.Len() > 0 {
+			b.WriteString("\n\n")
+		}
+		b.WriteString("Attachments:")
+		for _, a := range e.Attachments {
+			name := a.Filename
+			if name == "" {
+				name = "(unnamed)"
+			}
+			fmt.Fprintf(&b, "\n- %s (%s, %s)", name, a.ContentType, formatSize(a.Size))
+		}
+	}
+	return b.String()
+}
+
+// Input returns the message as input for the LLM, which turns it into ticket details.
+func (e *Email) Input() string {
+	var b strings.Builder
+	b.WriteString("The following email reports an issue. Ignore greetings, signatures, disclaimers and quoted replies.\n\n")
+	if e.From != "" {
+		fmt.Fprintf(&b, "From: %s\n", e.From)
+	}
+	fmt.Fprintf(&b, "Subject: %s\n\n%s", e.Subject, e.Description())
+	return b.String()
+}
+
+// formatSize formats a size in bytes for people.
+func formatSize(n int) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
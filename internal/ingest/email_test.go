@@ -0,0 +1,78 @@
+package ingest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testEmail = "From: =?UTF-8?Q?Zofia_W=C3=B3jcik?= <zofia@example.com>\r\n" +
+	"To: support@example.com\r\n" +
+	"Subject: =?UTF-8?B?RXhwb3J0IGZhaWxzIOKAkyA1MDA=?=\r\n" +
+	"Message-ID: <abc@example.com>\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/mixed; boundary=\"outer\"\r\n" +
+	"\r\n" +
+	"--outer\r\n" +
+	"Content-Type: multipart/alternative; boundary=\"inner\"\r\n" +
+	"\r\n" +
+	"--inner\r\n" +
+	"Content-Type: text/plain; charset=utf-8\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"\r\n" +
+	"Hi,\r\n" +
+	"\r\n" +
+	"the CSV export returns a 500 since this morning. Za=C5=BC=C3=B3=C5=82=C4=87!\r\n" +
+	"\r\n" +
+	"On Mon, 3 Jun 2024 at 10:00, Support <support@example.com> wrote:\r\n" +
+	"> Does it still happen?\r\n" +
+	"--inner\r\n" +
+	"Content-Type: text/html; charset=utf-8\r\n" +
+	"\r\n" +
+	"<p>Hi,</p><p>the CSV export returns a 500</p>\r\n" +
+	"--inner--\r\n" +
+	"--outer\r\n" +
+	"Content-Type: image/png\r\n" +
+	"Content-Disposition: attachment; filename=\"screenshot.png\"\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"iVBORw0KGgoAAAAN\r\n" +
+	"SUhEUgAAAAEAAAAB\r\n" +
+	"--outer--\r\n"
+
+func TestParseEmail(t *testing.T) {
+	email, err := ParseEmail(strings.NewReader(testEmail))
+	require.NoError(t, err)
+	assert.Equal(t, "Zofia Wójcik <zofia@example.com>", email.From)
+	assert.Equal(t, "Export fails – 500", email.Subject)
+	assert.Equal(t, "<abc@example.com>", email.MessageID)
+	assert.True(t, strings.HasPrefix(email.Body, "Hi,\n\nthe CSV export returns a 500 since this morning. Zażółć!"), email.Body)
+	assert.Equal(t, []Attachment{{Filename: "screenshot.png", ContentType: "image/png", Size: 24}}, email.Attachments)
+
+	assert.Equal(t, "Hi,\n\nthe CSV export returns a 500 since this morning. Zażółć!\n\nAttachments:\n- screenshot.png (image/png, 24 B)", email.Description())
+	assert.True(t, strings.HasSuffix(email.Input(), "From: Zofia Wójcik <zofia@example.com>\nSubject: Export fails – 500\n\n"+email.Description()))
+
+	_, err = ParseEmail(strings.NewReader("not an email"))
+	assert.ErrorIs(t, err, ErrEmail)
+}
+
+func TestParseEmail_HTMLOnly(t *testing.T) {
+	email, err := ParseEmail(strings.NewReader("Subject: Alert\r\nContent-Type: text/html; charset=iso-8859-1\r\n\r\n" +
+		"<html><head><style>p {}</style></head><body><p>Disk full on db-1</p><div>Size: 100&nbsp;GB \xb5</div></body></html>\r\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "Disk full on db-1\nSize: 100 GB µ", email.Body)
+	assert.Empty(t, email.Attachments)
+}
+
+func TestStripQuoted(t *testing.T) {
+	for name, tc := range map[string]struct{ body, want string }{
+		"inline quotes": {"Yes, still broken.\n> Does it still happen?\n>> Original report\n\nThanks", "Yes, still broken.\n\nThanks"},
+		"outlook":       {"Please fix.\n\n-----Original Message-----\nFrom: Support\nSubject: Re: Export", "Please fix."},
+		"signature":     {"Login fails.\n-- \nZofia\nACME Corp", "Login fails."},
+		"no noise":      {"Line one\nLine two", "Line one\nLine two"},
+	} {
+		assert.Equal(t, tc.want, StripQuoted(tc.body), name)
+	}
+}
@@ -0,0 +1,17 @@
+package ingest
+
+import "errors"
+
+// Sentinel errors for alert ingestion.
+
+// ErrMapping indicates a mapping file cannot be read or is invalid.
+var ErrMapping = errors.New("invalid alert mapping")
+
+// ErrPayload indicates an alert payload is not a JSON object or lacks what the mapping needs.
+var ErrPayload = errors.New("invalid alert payload")
+
+// ErrUnknownSource indicates no built-in mapping has the requested name.
+var ErrUnknownSource = errors.New("unknown alert source")
+
+// ErrEmail indicates a message cannot be parsed as an RFC 822 email.
+var ErrEmail = errors.New("invalid email message")
@@ -0,0 +1,227 @@
+// Package ingest turns monitoring alert payloads, such as Sentry webhooks, into issues. A
+// Mapping of text/template expressions extracts the summary, description and fingerprint
+// from the JSON payload; alerts with the same fingerprint are deduplicated through the
+// label FingerprintLabel derives from it.
+package ingest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/karolswdev/ticketron/internal/llm"
+	"github.com/karolswdev/ticketron/internal/sanitize"
+	"github.com/karolswdev/ticketron/internal/webhook"
+)
+
+// LabelPrefix starts the labels FingerprintLabel derives from alert fingerprints.
+const LabelPrefix = "tix-fp-"
+
+// Mapping describes how an alert payload becomes an issue. Every field but Labels is a
+// template evaluated against the decoded payload, with the helpers of webhooks.yaml
+// mappings and {{ field "a.b" "c" }}, which returns the first non-empty value of the given dotted paths and
+// "" if none is set. Summary and Fingerprint are required; Project, Type and Priority
+// default to the command's flags and configuration when empty.
+type Mapping struct {
+	Summary     string   `yaml:"summary"`
+	Description string   `yaml:"description"`
+	Fingerprint string   `yaml:"fingerprint"` // Alerts with the same fingerprint are one problem
+	Project     string   `yaml:"project"`
+	Type        string   `yaml:"type"`
+	Priority    string   `yaml:"priority"`
+	Labels      []string `yaml:"labels"` // Added to every issue, besides the fingerprint label
+}
+
+// Alert is an alert payload mapped to the fields of an issue.
+type Alert struct {
+	Summary     string
+	Description string
+	Fingerprint string
+	Project     string
+	Type        string
+	Priority    string
+	Labels      []string // Including the fingerprint label, first
+}
+
+// builtins are the mappings of the alert sources supported out of the box.
+var builtins = map[string]Mapping{
+	// Sentry issue alerts (data.event), issue webhooks (data.issue) and the legacy
+	// webhook plugin (top level). Events are deduplicated by their Sentry issue.
+	"sentry": {
+		Summary: `{{ field "data.event.title" "data.issue.title" "event.title" "message" }}`,
+		Description: `{{ with field "data.event.culprit" "data.issue.culprit" "culprit" }}Culprit: {{ . }}
+{{ end }}{{ with field "data.event.level" "data.issue.level" "level" }}Level: {{ . }}
+{{ end }}{{ with field "data.event.environment" "event.environment" }}Environment: {{ . }}
+{{ end }}{{ with field "data.triggered_rule" }}Alert rule: {{ . }}
+{{ end }}{{ with field "data.event.web_url" "data.issue.web_url" "data.issue.permalink" "url" }}
+Sentry: {{ . }}
+{{ end }}`,
+		Fingerprint: `{{ with field "data.event.issue_id" "data.issue.id" "id" }}sentry:{{ . }}{{ end }}`,
+		Labels:      []string{"sentry"},
+	},
+}
+
+// Builtin returns the built-in mapping of source, such as "sentry".
+func Builtin(source string) (Mapping, error) {
+	mapping, ok := builtins[strings.ToLower(source)]
+	if !ok {
+		return Mapping{}, fmt.Errorf("%w %q (expected %s, or a mapping file)", ErrUnknownSource, source, strings.Join(Sources(), ", "))
+	}
+	return mapping, nil
+}
+
+// Sources returns the names of the built-in mappings, sorted.
+func Sources() []string {
+	sources := make([]string, 0, len(builtins))
+	for source := range builtins {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	return sources
+}
+
+// LoadMapping reads a YAML mapping file and checks its templates.
+func LoadMapping(path string) (Mapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Mapping{}, fmt.Errorf("%w: %w", ErrMapping, err)
+	}
+	var mapping Mapping
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&mapping); err != nil {
+		return Mapping{}, fmt.Errorf("%w: %s: %w", ErrMapping, path, err)
+	}
+	if strings.TrimSpace(mapping.Summary) == "" || strings.TrimSpace(mapping.Fingerprint) == "" {
+		return Mapping{}, fmt.Errorf("%w: %s: summary and fingerprint are required", ErrMapping, path)
+	}
+	for name, text := range mapping.templates() {
+		if _, err := parseTemplate(name, text, nil); err != nil {
+			return Mapping{}, fmt.Errorf("%w: %s: %w", ErrMapping, path, err)
+		}
+	}
+	return mapping, nil
+}
+
+// templates returns the template fields of m by name.
+func (m Mapping) templates() map[string]string {
+	return map[string]string{
+		"summary":     m.Summary,
+		"description": m.Description,
+		"fingerprint": m.Fingerprint,
+		"project":     m.Project,
+		"type":        m.Type,
+		"priority":    m.Priority,
+	}
+}
+
+// Apply maps the JSON payload to an alert. The summary is reduced to one line of at most
+// Jira's 255 characters; an empty summary or fingerprint is an error wrapping ErrPayload.
+func (m Mapping) Apply(payload []byte) (*Alert, error) {
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber() // Keep IDs such as 1117540176 as written
+	var root map[string]any
+	if err := decoder.Decode(&root); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPayload, err)
+	}
+
+	values := make(map[string]string)
+	for name, text := range m.templates() {
+		if text == "" {
+			continue
+		}
+		tmpl, err := parseTemplate(name, text, root)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrMapping, err)
+		}
+		var b strings.Builder
+		if err := tmpl.Execute(&b, root); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrPayload, err)
+		}
+		// Missing map keys render as "<no value>"; treat them as empty, as webhooks do.
+		values[name] = strings.TrimSpace(strings.ReplaceAll(b.String(), "<no value>", ""))
+	}
+
+	alert := &Alert{
+		Summary:     sanitize.Truncate(strings.TrimSpace(sanitize.Line(values["summary"])), llm.DefaultSummaryMaxLength),
+		Description: sanitize.Text(values["description"]),
+		Fingerprint: values["fingerprint"],
+		Project:     values["project"],
+		Type:        values["type"],
+		Priority:    values["priority"],
+	}
+	if alert.Summary == "" {
+		return nil, fmt.Errorf("%w: the mapping yields no summary", ErrPayload)
+	}
+	if alert.Fingerprint == "" {
+		return nil, fmt.Errorf("%w: the mapping yields no fingerprint", ErrPayload)
+	}
+	alert.Labels = append([]string{FingerprintLabel(alert.Fingerprint)}, m.Labels...)
+	return alert, nil
+}
+
+// parseTemplate parses text with the helpers of webhook mappings and the field function
+// looking up paths in root.
+func parseTemplate(name, text string, root map[string]any) (*template.Template, error) {
+	funcs := webhook.TemplateFuncs()
+	funcs["field"] = func(paths ...string) string {
+		for _, path := range paths {
+			if value := lookup(root, path); value != "" {
+				return value
+			}
+		}
+		return ""
+	}
+	return template.New(name).Funcs(funcs).Option("missingkey=zero").Parse(text)
+}
+
+// lookup returns the value at the dotted path in root as text: strings and numbers as
+// written, other values as JSON. Numeric segments index lists. It returns "" if the path
+// does not exist or holds null.
+func lookup(root map[string]any, path string) string {
+	var value any = root
+	for _, segment := range strings.Split(path, ".") {
+		switch v := value.(type) {
+		case map[string]any:
+			value = v[segment]
+		case []any:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(v) {
+				return ""
+			}
+			value = v[i]
+		default:
+			return ""
+		}
+	}
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	}
+}
+
+// FingerprintLabel returns the Jira label marking the issues of alerts with fingerprint.
+// Fingerprints are hashed, as labels cannot contain spaces.
+func FingerprintLabel(fingerprint string) string {
+	sum := sha256.Sum256([]byte(fingerprint))
+	return LabelPrefix + hex.EncodeToString(sum[:8])
+}
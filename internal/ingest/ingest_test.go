@@ -0,0 +1,104 @@
+package ingest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sentryIssueAlert = `{
+  "action": "triggered",
+  "data": {
+    "event": {
+      "title": "TypeError: Cannot read properties of undefined\n(reading 'id')",
+      "culprit": "app/checkout.js in submit",
+      "level": "error",
+      "environment": "production",
+      "issue_id": 1117540176,
+      "web_url": "https://sentry.io/organizations/acme/issues/1117540176/events/a1/"
+    },
+    "triggered_rule": "Checkout errors"
+  }
+}`
+
+func TestBuiltin_Sentry(t *testing.T) {
+	mapping, err := Builtin("Sentry")
+	require.NoError(t, err)
+
+	alert, err := mapping.Apply([]byte(sentryIssueAlert))
+	require.NoError(t, err)
+	assert.Equal(t, "TypeError: Cannot read properties of undefined (reading 'id')", alert.Summary)
+	assert.Equal(t, "sentry:1117540176", alert.Fingerprint, "numeric IDs are kept as written")
+	assert.Equal(t, []string{FingerprintLabel("sentry:1117540176"), "sentry"}, alert.Labels)
+	assert.Contains(t, alert.Description, "Culprit: app/checkout.js in submit\n")
+	assert.Contains(t, alert.Description, "Environment: production\n")
+	assert.Contains(t, alert.Description, "Alert rule: Checkout errors\n")
+	assert.Contains(t, alert.Description, "Sentry: https://sentry.io/organizations/acme/issues/1117540176/events/a1/")
+	assert.Empty(t, alert.Project)
+
+	// Legacy webhook plugin payloads are top level
+	alert, err = mapping.Apply([]byte(`{"id": "42", "message": "DB timeout", "level": "fatal", "url": "https://sentry.example/42"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "DB timeout", alert.Summary)
+	assert.Equal(t, "sentry:42", alert.Fingerprint)
+	assert.Equal(t, "Level: fatal\n\nSentry: https://sentry.example/42", alert.Description)
+
+	_, err = mapping.Apply([]byte(`{"message": "no issue"}`))
+	assert.ErrorIs(t, err, ErrPayload, "no fingerprint")
+	_, err = mapping.Apply([]byte(`[1, 2]`))
+	assert.ErrorIs(t, err, ErrPayload)
+
+	_, err = Builtin("pagerduty")
+	assert.ErrorIs(t, err, ErrUnknownSource)
+	assert.Equal(t, []string{"sentry"}, Sources())
+}
+
+func TestLoadMapping(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "grafana.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`summary: '[{{ field "status" | upper }}] {{ field "title" }}'
+description: '{{ field "message" }}'
+fingerprint: 'grafana:{{ field "alerts.0.fingerprint" }}'
+project: '{{ field "commonLabels.team" | default "OPS" }}'
+labels: [grafana]
+`), 0o644))
+
+	mapping, err := LoadMapping(path)
+	require.NoError(t, err)
+	alert, err := mapping.Apply([]byte(`{"status": "firing", "title": "High latency", "message": "p99 > 2s",
+		"alerts": [{"fingerprint": "c6eff5"}], "commonLabels": {"team": "API"}}`))
+	require.NoError(t, err)
+	assert.Equal(t, "[FIRING] High latency", alert.Summary)
+	assert.Equal(t, "p99 > 2s", alert.Description)
+	assert.Equal(t, "grafana:c6eff5", alert.Fingerprint)
+	assert.Equal(t, "API", alert.Project)
+	assert.Equal(t, []string{FingerprintLabel("grafana:c6eff5"), "grafana"}, alert.Labels)
+
+	alert, err = mapping.Apply([]byte(`{"title": "` + strings.Repeat("x", 300) + `", "alerts": [{"fingerprint": "1"}]}`))
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len([]rune(alert.Summary)), 255, "summaries fit Jira's limit")
+	assert.Equal(t, "OPS", alert.Project)
+
+	for name, content := range map[string]string{
+		"no fingerprint": "summary: x\n",
+		"bad template":   "summary: '{{ field }'\nfingerprint: x\n",
+		"unknown key":    "summary: x\nfingerprint: x\nlabel: [a]\n",
+	} {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		_, err := LoadMapping(path)
+		assert.ErrorIs(t, err, ErrMapping, name)
+	}
+	_, err = LoadMapping(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorIs(t, err, ErrMapping)
+}
+
+func TestFingerprintLabel(t *testing.T) {
+	label := FingerprintLabel("sentry:1117540176")
+	assert.True(t, strings.HasPrefix(label, LabelPrefix))
+	assert.Len(t, label, len(LabelPrefix)+16)
+	assert.Equal(t, label, FingerprintLabel("sentry:1117540176"))
+	assert.NotEqual(t, label, FingerprintLabel("sentry:1117540177"))
+}
@@ -0,0 +1,17 @@
+package jsonrpc
+
+import "errors"
+
+// Sentinel errors for JSON-RPC server operations.
+
+// ErrHandlerInvalid indicates a method was registered without a name or handler.
+var ErrHandlerInvalid = errors.New("method must have a name and a handler")
+
+// ErrMethodDuplicate indicates a handler for the method is already registered.
+var ErrMethodDuplicate = errors.New("method already registered")
+
+// ErrReadRequest indicates an error occurred while reading from a connection.
+var ErrReadRequest = errors.New("failed to read JSON-RPC request")
+
+// ErrWriteResponse indicates an error occurred while writing a response to a connection.
+var ErrWriteResponse = errors.New("failed to write JSON-RPC response")
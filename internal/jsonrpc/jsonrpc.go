@@ -0,0 +1,194 @@
+// Package jsonrpc implements a JSON-RPC 2.0 server over line-delimited streams, such as
+// the connections of a unix socket, for editor plugins talking to tix serve --socket.
+// Every request and response is a single line of JSON.
+package jsonrpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// JSON-RPC 2.0 error codes.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeServerError    = -32000 // Any other error returned by a handler
+)
+
+// Error is a JSON-RPC error. Handlers return one to choose the code sent to the client;
+// other errors are sent with CodeServerError.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// InvalidParams returns the error for params a handler cannot use.
+func InvalidParams(format string, args ...any) *Error {
+	return &Error{Code: CodeInvalidParams, Message: fmt.Sprintf(format, args...)}
+}
+
+// HandlerFunc handles a method call. params holds the raw "params" value, "{}" if the
+// client sent none. The result is sent to the client as JSON.
+type HandlerFunc func(ctx context.Context, params json.RawMessage) (any, error)
+
+// Server dispatches JSON-RPC requests to the handlers of their methods.
+type Server struct {
+	mu       sync.RWMutex
+	handlers map[string]HandlerFunc
+}
+
+// New creates a Server without methods.
+func New() *Server {
+	return &Server{handlers: make(map[string]HandlerFunc)}
+}
+
+// Handle registers handler for method. It returns ErrHandlerInvalid if either is missing
+// and ErrMethodDuplicate if method already has a handler.
+func (s *Server) Handle(method string, handler HandlerFunc) error {
+	if method == "" || handler == nil {
+		return ErrHandlerInvalid
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.handlers[method]; exists {
+		return fmt.Errorf("%w: %s", ErrMethodDuplicate, method)
+	}
+	s.handlers[method] = handler
+	return nil
+}
+
+// Serve accepts connections on l and serves each until the client closes it. It returns
+// nil once ctx is cancelled, closing l.
+func (s *Server) Serve(ctx context.Context, l net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		l.Close()
+	}()
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer conn.Close()
+			stop := context.AfterFunc(ctx, func() { conn.Close() })
+			defer stop()
+			if err := s.ServeConn(ctx, conn); err != nil && ctx.Err() == nil {
+				log.Warn().Err(err).Msg("JSON-RPC connection failed")
+			}
+		}()
+	}
+}
+
+// ServeConn reads requests line by line from rw and writes the responses to it until rw
+// is exhausted or ctx is cancelled. Requests of a connection are handled in order.
+func (s *Server) ServeConn(ctx context.Context, rw io.ReadWriter) error {
+	scanner := bufio.NewScanner(rw)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024) // Allow large selections
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		resp := s.handleMessage(ctx, line)
+		if resp == nil {
+			continue // Notification, no response expected
+		}
+		data, err := json.Marshal(resp)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrWriteResponse, err)
+		}
+		if _, err := rw.Write(append(data, '\n')); err != nil {
+			return fmt.Errorf("%w: %w", ErrWriteResponse, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%w: %w", ErrReadRequest, err)
+	}
+	return nil
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// handleMessage decodes and dispatches a single request. It returns nil for notifications.
+func (s *Server) handleMessage(ctx context.Context, raw []byte) *response {
+	var req request
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return &response{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &Error{Code: CodeParseError, Message: "parse error"}}
+	}
+	id := req.ID
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return &response{JSONRPC: "2.0", ID: id, Error: &Error{Code: CodeInvalidRequest, Message: "invalid request"}}
+	}
+	isNotification := len(req.ID) == 0
+
+	s.mu.RLock()
+	handler, ok := s.handlers[req.Method]
+	s.mu.RUnlock()
+	if !ok {
+		if isNotification {
+			return nil
+		}
+		return &response{JSONRPC: "2.0", ID: id, Error: &Error{Code: CodeMethodNotFound, Message: fmt.Sprintf("method not found: %s", req.Method)}}
+	}
+
+	log.Debug().Str("method", req.Method).Bool("notification", isNotification).Msg("Handling JSON-RPC request")
+	params := req.Params
+	if len(params) == 0 || string(params) == "null" {
+		params = json.RawMessage("{}")
+	}
+	result, err := handler(ctx, params)
+	if isNotification {
+		return nil
+	}
+	if err != nil {
+		var rpcErr *Error
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &Error{Code: CodeServerError, Message: err.Error()}
+		}
+		return &response{JSONRPC: "2.0", ID: id, Error: rpcErr}
+	}
+	if result == nil {
+		result = struct{}{}
+	}
+	return &response{JSONRPC: "2.0", ID: id, Result: result}
+}
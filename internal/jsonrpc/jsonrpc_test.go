@@ -0,0 +1,92 @@
+package jsonrpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	s := New()
+	require.NoError(t, s.Handle("echo", func(ctx context.Context, params json.RawMessage) (any, error) {
+		var p struct {
+			Text string `json:"text"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil || p.Text == "" {
+			return nil, InvalidParams("text is required")
+		}
+		return map[string]string{"text": p.Text}, nil
+	}))
+	require.NoError(t, s.Handle("fail", func(ctx context.Context, params json.RawMessage) (any, error) {
+		return nil, errors.New("backend down")
+	}))
+	return s
+}
+
+func TestHandle(t *testing.T) {
+	s := newTestServer(t)
+	assert.ErrorIs(t, s.Handle("", func(context.Context, json.RawMessage) (any, error) { return nil, nil }), ErrHandlerInvalid)
+	assert.ErrorIs(t, s.Handle("echo", nil), ErrHandlerInvalid)
+	assert.ErrorIs(t, s.Handle("echo", func(context.Context, json.RawMessage) (any, error) { return nil, nil }), ErrMethodDuplicate)
+}
+
+func TestServeConn(t *testing.T) {
+	s := newTestServer(t)
+	in := strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"echo","params":{"text":"hi"}}`,
+		`{"jsonrpc":"2.0","method":"echo","params":{"text":"notification"}}`,
+		`{"jsonrpc":"2.0","id":"b","method":"echo"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"fail"}`,
+		`{"jsonrpc":"2.0","id":4,"method":"missing"}`,
+		`{"id":5,"method":"echo"}`,
+		`not json`,
+	}, "\n") + "\n"
+	var out bytes.Buffer
+	rw := struct {
+		io.Reader
+		io.Writer
+	}{strings.NewReader(in), &out}
+
+	require.NoError(t, s.ServeConn(context.Background(), rw))
+	assert.Equal(t, strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"result":{"text":"hi"}}`,
+		`{"jsonrpc":"2.0","id":"b","error":{"code":-32602,"message":"text is required"}}`,
+		`{"jsonrpc":"2.0","id":3,"error":{"code":-32000,"message":"backend down"}}`,
+		`{"jsonrpc":"2.0","id":4,"error":{"code":-32601,"message":"method not found: missing"}}`,
+		`{"jsonrpc":"2.0","id":5,"error":{"code":-32600,"message":"invalid request"}}`,
+		`{"jsonrpc":"2.0","id":null,"error":{"code":-32700,"message":"parse error"}}`,
+	}, "\n")+"\n", out.String())
+}
+
+func TestServe_UnixSocket(t *testing.T) {
+	s := newTestServer(t)
+	path := filepath.Join(t.TempDir(), "tix.sock")
+	l, err := net.Listen("unix", path)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Serve(ctx, l) }()
+
+	conn, err := net.Dial("unix", path)
+	require.NoError(t, err)
+	_, err = conn.Write([]byte(`{"jsonrpc":"2.0","id":1,"method":"echo","params":{"text":"over the socket"}}` + "\n"))
+	require.NoError(t, err)
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":1,"result":{"text":"over the socket"}}`, line)
+
+	cancel()
+	assert.NoError(t, <-done, "cancelling stops the server and closes open connections")
+}
@@ -3,6 +3,8 @@ package llm
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/rs/zerolog/log"
 	openai "github.com/sashabaranov/go-openai"
@@ -13,12 +15,47 @@ type Client interface {
 	// GenerateTicketDetails takes user input, system prompt, and context, interacts with the LLM,
 	// parses the response, and returns the structured ticket details or an error.
 	GenerateTicketDetails(ctx context.Context, userInput, systemPrompt, contextContent string) (LLMResponse, error)
+	// GenerateFromMessages sends a whole conversation, such as the messages built by
+	// ConstructMessages followed by further turns, and parses the reply like GenerateTicketDetails.
+	GenerateFromMessages(ctx context.Context, messages []Message) (LLMResponse, error)
+}
+
+// TextGenerator is implemented by clients that can return the model's reply as free
+// text instead of parsing it as ticket details, for output such as release notes.
+type TextGenerator interface {
+	// GenerateText sends messages and returns the reply with surrounding whitespace removed.
+	GenerateText(ctx context.Context, messages []Message) (string, error)
+}
+
+// GenerateText asks client for a free text reply to messages. It returns
+// ErrLLMTextUnsupported if client does not implement TextGenerator.
+func GenerateText(ctx context.Context, client Client, messages []Message) (string, error) {
+	generator, ok := client.(TextGenerator)
+	if !ok {
+		return "", ErrLLMTextUnsupported
+	}
+	return generator.GenerateText(ctx, messages)
+}
+
+// fenceRe matches text wrapped in a Markdown code fence as a whole.
+var fenceRe = regexp.MustCompile("(?s)^```[a-zA-Z]*\\s*\\n(.*?)\\n?```$")
+
+// StripCodeFence removes surrounding whitespace and a code fence around the whole of a
+// free text reply, which some models add to Markdown answers.
+func StripCodeFence(reply string) string {
+	reply = strings.TrimSpace(reply)
+	if m := fenceRe.FindStringSubmatch(reply); m != nil {
+		return strings.TrimSpace(m[1])
+	}
+	return reply
 }
 
 // OpenAIClient implements the llm.Client interface for the OpenAI API.
 type OpenAIClient struct {
 	client    *openai.Client
 	modelName string
+	params    GenerationParams
+	parseOpts ParseOptions
 }
 
 // NewOpenAIClient creates a new OpenAI client wrapper.
@@ -37,57 +74,120 @@ func NewOpenAIClient(client *openai.Client, modelName string) (*OpenAIClient, er
 	}, nil
 }
 
+// SetGenerationParams sets the sampling parameters sent with every request.
+func (o *OpenAIClient) SetGenerationParams(params GenerationParams) {
+	o.params = params
+}
+
+// SetParseOptions sets how tolerant the parsing of the model's replies is and how often
+// unparseable replies are sent back for repair.
+func (o *OpenAIClient) SetParseOptions(opts ParseOptions) {
+	o.parseOpts = opts
+}
+
 // GenerateTicketDetails implements the llm.Client interface for OpenAI.
-// It constructs the prompt, calls the OpenAI API, and parses the response.
+// It constructs the prompt messages, calls the OpenAI API, and parses the response.
+// The system prompt is sent with the system role, the context and user input as user messages.
 func (o *OpenAIClient) GenerateTicketDetails(ctx context.Context, userInput, systemPrompt, contextContent string) (LLMResponse, error) {
-	// 1. Build the full prompt
-	fullPrompt := ConstructPrompt(userInput, systemPrompt, contextContent)
-	log.Debug().Str("full_prompt", fullPrompt).Msg("Constructed full prompt for LLM")
+	return o.GenerateFromMessages(ctx, ConstructMessages(userInput, systemPrompt, contextContent))
+}
+
+// GenerateFromMessages implements the llm.Client interface for OpenAI. It calls the OpenAI
+// API with messages and parses the response. If the reply cannot be parsed and
+// ParseOptions.RepairAttempts is set, the reply is sent back with a repair prompt asking
+// for valid JSON, up to that many times.
+func (o *OpenAIClient) GenerateFromMessages(ctx context.Context, messages []Message) (LLMResponse, error) {
+	// 1. Check the prompt messages
+	log.Debug().Interface("messages", messages).Msg("Constructed prompt messages for LLM")
+	if len(messages) == 0 {
+		return LLMResponse{}, ErrLLMPromptEmpty
+	}
 
 	// 2. Call the OpenAI API
 	if o.client == nil {
 		return LLMResponse{}, ErrLLMClientNil
 	}
-	if fullPrompt == "" {
-		// Should not happen if ConstructPrompt works, but check anyway
-		return LLMResponse{}, ErrLLMPromptEmpty
+	rawResponse, err := o.complete(ctx, messages)
+	if err != nil {
+		return LLMResponse{}, err
+	}
+
+	// 3. Parse the response, repairing it if allowed
+	parsedResponse, err := ParseLLMResponseWithOptions(rawResponse, o.parseOpts)
+	for attempt := 1; err != nil && attempt <= o.parseOpts.RepairAttempts; attempt++ {
+		log.Warn().Err(err).Int("attempt", attempt).Int("max_attempts", o.parseOpts.RepairAttempts).Msg("LLM response could not be parsed, asking the model to repair it")
+		messages = append(messages[:len(messages):len(messages)], RepairMessages(rawResponse, err)...)
+		rawResponse, err = o.complete(ctx, messages)
+		if err != nil {
+			return LLMResponse{}, err
+		}
+		parsedResponse, err = ParseLLMResponseWithOptions(rawResponse, o.parseOpts)
+	}
+	if err != nil {
+		// Error already logged in ParseLLMResponse
+		if o.parseOpts.RepairAttempts > 0 {
+			return LLMResponse{}, fmt.Errorf("failed to parse LLM response after %d repair attempts: %w", o.parseOpts.RepairAttempts, err)
+		}
+		return LLMResponse{}, fmt.Errorf("failed to parse LLM response: %w", err) // Wrap error from parser
+	}
+
+	log.Info().Msg("Successfully generated and parsed ticket details from OpenAI")
+	return parsedResponse, nil
+}
+
+// GenerateText implements the llm.TextGenerator interface for OpenAI. The reply is
+// returned as is, without parsing or repair.
+func (o *OpenAIClient) GenerateText(ctx context.Context, messages []Message) (string, error) {
+	if len(messages) == 0 {
+		return "", ErrLLMPromptEmpty
+	}
+	if o.client == nil {
+		return "", ErrLLMClientNil
 	}
+	reply, err := o.complete(ctx, messages)
+	if err != nil {
+		return "", err
+	}
+	reply = strings.TrimSpace(reply)
+	if reply == "" {
+		return "", ErrLLMEmptyResponse
+	}
+	return reply, nil
+}
 
+// complete sends messages to the OpenAI API and returns the content of the first choice.
+func (o *OpenAIClient) complete(ctx context.Context, messages []Message) (string, error) {
 	log.Debug().Str("model", o.modelName).Msg("Preparing OpenAI chat completion request")
 	req := openai.ChatCompletionRequest{
-		Model: o.modelName,
-		Messages: []openai.ChatCompletionMessage{
-			{
-				Role:    openai.ChatMessageRoleUser,
-				Content: fullPrompt,
-			},
-		},
+		Model:    o.modelName,
+		Messages: openAIMessages(messages),
 	}
+	o.params.applyTo(&req)
 
 	log.Debug().Interface("request", req).Msg("Sending request to OpenAI API")
 	resp, err := o.client.CreateChatCompletion(ctx, req) // Pass context
 	if err != nil {
 		log.Error().Err(err).Msg("OpenAI API call failed")
-		return LLMResponse{}, fmt.Errorf("%w: %w", ErrLLMCompletion, err)
+		return "", fmt.Errorf("%w: %w", ErrLLMCompletion, err)
 	}
 	log.Debug().Interface("response", resp).Msg("Received response from OpenAI API")
 
 	if len(resp.Choices) == 0 {
 		log.Error().Msg("Received an empty response (no choices) from OpenAI")
-		return LLMResponse{}, ErrLLMEmptyResponse
+		return "", ErrLLMEmptyResponse
 	}
 	rawResponse := resp.Choices[0].Message.Content
 	log.Debug().Str("raw_response", rawResponse).Msg("Extracted raw response content")
+	return rawResponse, nil
+}
 
-	// 3. Parse the response
-	parsedResponse, err := ParseLLMResponse(rawResponse)
-	if err != nil {
-		// Error already logged in ParseLLMResponse
-		return LLMResponse{}, fmt.Errorf("failed to parse LLM response: %w", err) // Wrap error from parser
+// openAIMessages converts prompt messages to the go-openai representation.
+func openAIMessages(messages []Message) []openai.ChatCompletionMessage {
+	converted := make([]openai.ChatCompletionMessage, len(messages))
+	for i, m := range messages {
+		converted[i] = openai.ChatCompletionMessage{Role: m.Role, Content: m.Content}
 	}
-
-	log.Info().Msg("Successfully generated and parsed ticket details from OpenAI")
-	return parsedResponse, nil
+	return converted
 }
 
 // Note: The old CallOpenAI function has been removed as its logic is integrated into GenerateTicketDetails.
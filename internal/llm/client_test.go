@@ -2,6 +2,7 @@ package llm
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -130,7 +131,7 @@ func TestOpenAIClient_GenerateTicketDetails(t *testing.T) {
 			}`, // Missing "summary"
 			mockStatusCode: http.StatusOK,
 			expectError:    true,
-			expectedErrMsg: ErrLLMResponseMissingField.Error(), // Check for specific error from ParseLLMResponse
+			expectedErrMsg: "summary: is required", // The specific schema violation is reported
 		},
 	}
 
@@ -173,3 +174,123 @@ func TestOpenAIClient_GenerateTicketDetails(t *testing.T) {
 		})
 	}
 }
+
+func TestOpenAIClient_SetParseOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, `{"choices": [{"index": 0, "message": {"role": "assistant", "content": "{'summary': 'Lenient', 'project_name_suggestion': 'P',}"}}]}`)
+	}))
+	defer server.Close()
+
+	config := openai.DefaultConfig("dummy-api-key")
+	config.BaseURL = server.URL + "/v1"
+	client, err := NewOpenAIClient(openai.NewClientWithConfig(config), "test-model")
+	require.NoError(t, err)
+
+	_, err = client.GenerateTicketDetails(context.Background(), "in", "", "")
+	assert.ErrorIs(t, err, ErrLLMResponseJSONUnmarshal, "strict parsing by default")
+
+	client.SetParseOptions(ParseOptions{Lenient: true})
+	resp, err := client.GenerateTicketDetails(context.Background(), "in", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, LLMResponse{Summary: "Lenient", ProjectNameSuggestion: "P"}, resp)
+}
+
+func TestOpenAIClient_RepairLoop(t *testing.T) {
+	// replies are returned in order; the last one repeats
+	newServer := func(t *testing.T, replies ...string) (*OpenAIClient, *[]chatRequest) {
+		var requests []chatRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req chatRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			requests = append(requests, req)
+			reply := replies[min(len(requests), len(replies))-1]
+			content, _ := json.Marshal(reply)
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"choices": [{"index": 0, "message": {"role": "assistant", "content": %s}}]}`, content)
+		}))
+		t.Cleanup(server.Close)
+		config := openai.DefaultConfig("dummy-api-key")
+		config.BaseURL = server.URL + "/v1"
+		client, err := NewOpenAIClient(openai.NewClientWithConfig(config), "test-model")
+		require.NoError(t, err)
+		return client, &requests
+	}
+	const valid = `{"summary": "Fixed", "project_name_suggestion": "P"}`
+
+	t.Run("Repaired reply is used", func(t *testing.T) {
+		client, requests := newServer(t, "Sure! summary: Fixed", valid)
+		client.SetParseOptions(ParseOptions{RepairAttempts: 2})
+		resp, err := client.GenerateTicketDetails(context.Background(), "in", "", "")
+		require.NoError(t, err)
+		assert.Equal(t, "Fixed", resp.Summary)
+		require.Len(t, *requests, 2)
+
+		repair := (*requests)[1].Messages
+		require.Len(t, repair, 4, "system, request, malformed reply and repair prompt")
+		assert.Equal(t, chatMessage{Role: RoleAssistant, Content: "Sure! summary: Fixed"}, repair[2])
+		assert.Equal(t, RoleUser, repair[3].Role)
+		assert.Contains(t, repair[3].Content, ErrLLMResponseJSONFind.Error())
+		assert.Contains(t, repair[3].Content, `"project_name_suggestion"`)
+	})
+
+	t.Run("Attempts are bounded", func(t *testing.T) {
+		client, requests := newServer(t, `{"summary": "No project"}`)
+		client.SetParseOptions(ParseOptions{RepairAttempts: 2})
+		_, err := client.GenerateTicketDetails(context.Background(), "in", "", "")
+		assert.ErrorIs(t, err, ErrLLMResponseMissingField)
+		assert.ErrorContains(t, err, "after 2 repair attempts")
+		assert.Len(t, *requests, 3, "the first request and two repairs")
+	})
+
+	t.Run("Disabled by default", func(t *testing.T) {
+		client, requests := newServer(t, "not JSON", valid)
+		_, err := client.GenerateTicketDetails(context.Background(), "in", "", "")
+		assert.ErrorIs(t, err, ErrLLMResponseJSONFind)
+		assert.Len(t, *requests, 1)
+	})
+}
+
+// chatRequest is the part of a chat completion request the tests inspect.
+type chatRequest struct {
+	Messages []chatMessage `json:"messages"`
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+func TestOpenAIClient_GenerateText(t *testing.T) {
+	reply := "  ## Features\n- Dark mode  \n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req chatRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, []chatMessage{{Role: RoleSystem, Content: "sys"}, {Role: RoleUser, Content: "notes"}}, req.Messages)
+		content, _ := json.Marshal(reply)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"choices": [{"index": 0, "message": {"role": "assistant", "content": %s}}]}`, content)
+	}))
+	defer server.Close()
+	config := openai.DefaultConfig("dummy-api-key")
+	config.BaseURL = server.URL + "/v1"
+	client, err := NewOpenAIClient(openai.NewClientWithConfig(config), "test-model")
+	require.NoError(t, err)
+
+	text, err := GenerateText(context.Background(), client, []Message{{Role: RoleSystem, Content: "sys"}, {Role: RoleUser, Content: "notes"}})
+	require.NoError(t, err)
+	assert.Equal(t, "## Features\n- Dark mode", text, "the reply is not parsed as JSON")
+
+	reply = "  "
+	_, err = client.GenerateText(context.Background(), []Message{{Role: RoleSystem, Content: "sys"}, {Role: RoleUser, Content: "notes"}})
+	assert.ErrorIs(t, err, ErrLLMEmptyResponse)
+
+	_, err = client.GenerateText(context.Background(), nil)
+	assert.ErrorIs(t, err, ErrLLMPromptEmpty)
+}
+
+func TestStripCodeFence(t *testing.T) {
+	assert.Equal(t, "## Fixes\n- Fix login", StripCodeFence("```markdown\n## Fixes\n- Fix login\n```\n"))
+	assert.Equal(t, "## Fixes", StripCodeFence("```\n## Fixes```"))
+	assert.Equal(t, "Intro\n```go\nx\n```", StripCodeFence("  Intro\n```go\nx\n```  "), "only a fence around the whole reply is removed")
+}
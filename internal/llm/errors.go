@@ -30,3 +30,29 @@ var ErrLLMResponseJSONUnmarshal = errors.New("failed to unmarshal LLM response J
 // ErrLLMResponseMissingField indicates a required field was missing from the parsed LLM response JSON.
 // The specific missing field should be mentioned in the error message where this is returned.
 var ErrLLMResponseMissingField = errors.New("parsed LLM response is missing a required field")
+
+// ErrLLMResponseSchema indicates the parsed LLM response does not match the response schema.
+// It is returned as a *SchemaError listing the violations.
+var ErrLLMResponseSchema = errors.New("LLM response does not match the schema")
+
+// ErrLLMInvalidSchema indicates a response schema could not be read or uses unsupported types.
+var ErrLLMInvalidSchema = errors.New("invalid LLM response schema")
+
+// ErrLLMNoClients indicates a fallback chain was created without any clients.
+var ErrLLMNoClients = errors.New("LLM fallback chain requires at least one client")
+
+// ErrLLMAllProvidersFailed indicates every provider in a fallback chain failed.
+// The individual provider errors are wrapped.
+var ErrLLMAllProvidersFailed = errors.New("all LLM providers failed")
+
+// ErrLLMInvalidParams indicates a generation parameter (temperature, top_p, max_tokens) is out of range.
+var ErrLLMInvalidParams = errors.New("invalid LLM generation parameters")
+
+// ErrLLMInvalidParseOptions indicates a parse option (repair_attempts) is out of range.
+var ErrLLMInvalidParseOptions = errors.New("invalid LLM response parsing options")
+
+// ErrLLMInvalidPostProcessOptions indicates a post-processing option (summary_max_length) is out of range.
+var ErrLLMInvalidPostProcessOptions = errors.New("invalid LLM post-processing options")
+
+// ErrLLMTextUnsupported indicates a client cannot return free text replies, see TextGenerator.
+var ErrLLMTextUnsupported = errors.New("LLM client does not support free text replies")
@@ -0,0 +1,94 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// NamedClient pairs a Client with a human-readable name (e.g. "openai/gpt-4o") used in logs.
+type NamedClient struct {
+	Name   string
+	Client Client
+}
+
+// FallbackClient implements Client by trying an ordered list of clients until one succeeds.
+type FallbackClient struct {
+	clients        []NamedClient
+	attemptTimeout time.Duration
+}
+
+// NewFallbackClient creates a FallbackClient. The first client is the primary; the rest are
+// tried in order if it fails. If attemptTimeout is positive, each attempt is bounded by it so
+// a hanging provider falls through to the next one.
+func NewFallbackClient(clients []NamedClient, attemptTimeout time.Duration) (*FallbackClient, error) {
+	if len(clients) == 0 {
+		return nil, ErrLLMNoClients
+	}
+	for _, c := range clients {
+		if c.Client == nil {
+			return nil, fmt.Errorf("%w: %s", ErrLLMClientNil, c.Name)
+		}
+	}
+	return &FallbackClient{clients: clients, attemptTimeout: attemptTimeout}, nil
+}
+
+// GenerateTicketDetails implements the llm.Client interface. It returns the first successful
+// response, or ErrLLMAllProvidersFailed wrapping every attempt's error. Cancellation of ctx
+// itself stops the chain immediately.
+func (f *FallbackClient) GenerateTicketDetails(ctx context.Context, userInput, systemPrompt, contextContent string) (LLMResponse, error) {
+	return generate(ctx, f, func(ctx context.Context, client Client) (LLMResponse, error) {
+		return client.GenerateTicketDetails(ctx, userInput, systemPrompt, contextContent)
+	})
+}
+
+// GenerateFromMessages implements the llm.Client interface with the same fallback behavior
+// as GenerateTicketDetails.
+func (f *FallbackClient) GenerateFromMessages(ctx context.Context, messages []Message) (LLMResponse, error) {
+	return generate(ctx, f, func(ctx context.Context, client Client) (LLMResponse, error) {
+		return client.GenerateFromMessages(ctx, messages)
+	})
+}
+
+// GenerateText implements the llm.TextGenerator interface with the same fallback behavior
+// as GenerateTicketDetails. Clients that do not support free text replies are skipped.
+func (f *FallbackClient) GenerateText(ctx context.Context, messages []Message) (string, error) {
+	return generate(ctx, f, func(ctx context.Context, client Client) (string, error) {
+		return GenerateText(ctx, client, messages)
+	})
+}
+
+// generate runs call against each client of f in turn until one succeeds.
+func generate[T any](ctx context.Context, f *FallbackClient, call func(context.Context, Client) (T, error)) (T, error) {
+	var zero T
+	var errs []error
+	for i, c := range f.clients {
+		resp, err := attempt(ctx, f.attemptTimeout, c.Client, call)
+		if err == nil {
+			if i > 0 {
+				log.Info().Str("provider", c.Name).Int("attempt", i+1).Msg("LLM request served by fallback provider")
+			} else {
+				log.Debug().Str("provider", c.Name).Msg("LLM request served by primary provider")
+			}
+			return resp, nil
+		}
+		if ctx.Err() != nil {
+			return zero, err
+		}
+		log.Warn().Err(err).Str("provider", c.Name).Msg("LLM provider failed, trying next fallback")
+		errs = append(errs, fmt.Errorf("%s: %w", c.Name, err))
+	}
+	return zero, fmt.Errorf("%w: %w", ErrLLMAllProvidersFailed, errors.Join(errs...))
+}
+
+func attempt[T any](ctx context.Context, timeout time.Duration, client Client, call func(context.Context, Client) (T, error)) (T, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return call(ctx, client)
+}
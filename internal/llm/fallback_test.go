@@ -0,0 +1,146 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubClient is a Client returning a fixed response or error, optionally after a delay.
+type stubClient struct {
+	resp  LLMResponse
+	err   error
+	delay time.Duration
+	calls int
+}
+
+func (s *stubClient) GenerateTicketDetails(ctx context.Context, userInput, systemPrompt, contextContent string) (LLMResponse, error) {
+	s.calls++
+	if s.delay > 0 {
+		select {
+		case <-time.After(s.delay):
+		case <-ctx.Done():
+			return LLMResponse{}, ctx.Err()
+		}
+	}
+	return s.resp, s.err
+}
+
+func (s *stubClient) GenerateFromMessages(ctx context.Context, messages []Message) (LLMResponse, error) {
+	return s.GenerateTicketDetails(ctx, "", "", "")
+}
+
+func TestNewFallbackClient(t *testing.T) {
+	_, err := NewFallbackClient(nil, 0)
+	assert.ErrorIs(t, err, ErrLLMNoClients)
+
+	_, err = NewFallbackClient([]NamedClient{{Name: "broken"}}, 0)
+	assert.ErrorIs(t, err, ErrLLMClientNil)
+}
+
+func TestFallbackClient_GenerateTicketDetails(t *testing.T) {
+	ok := LLMResponse{Summary: "S", ProjectNameSuggestion: "P"}
+
+	t.Run("Primary succeeds", func(t *testing.T) {
+		primary, secondary := &stubClient{resp: ok}, &stubClient{resp: ok}
+		f, err := NewFallbackClient([]NamedClient{{"primary", primary}, {"secondary", secondary}}, 0)
+		require.NoError(t, err)
+
+		resp, err := f.GenerateTicketDetails(context.Background(), "in", "sys", "")
+		require.NoError(t, err)
+		assert.Equal(t, ok, resp)
+		assert.Equal(t, 0, secondary.calls)
+	})
+
+	t.Run("Falls back on error", func(t *testing.T) {
+		primary := &stubClient{err: ErrLLMCompletion}
+		secondary := &stubClient{resp: ok}
+		f, err := NewFallbackClient([]NamedClient{{"primary", primary}, {"secondary", secondary}}, 0)
+		require.NoError(t, err)
+
+		resp, err := f.GenerateTicketDetails(context.Background(), "in", "sys", "")
+		require.NoError(t, err)
+		assert.Equal(t, ok, resp)
+	})
+
+	t.Run("Falls back on timeout", func(t *testing.T) {
+		primary := &stubClient{resp: ok, delay: time.Second}
+		secondary := &stubClient{resp: LLMResponse{Summary: "fallback"}}
+		f, err := NewFallbackClient([]NamedClient{{"primary", primary}, {"secondary", secondary}}, 20*time.Millisecond)
+		require.NoError(t, err)
+
+		resp, err := f.GenerateTicketDetails(context.Background(), "in", "sys", "")
+		require.NoError(t, err)
+		assert.Equal(t, "fallback", resp.Summary)
+	})
+
+	t.Run("All fail", func(t *testing.T) {
+		errA, errB := errors.New("a down"), errors.New("b down")
+		f, err := NewFallbackClient([]NamedClient{{"a", &stubClient{err: errA}}, {"b", &stubClient{err: errB}}}, 0)
+		require.NoError(t, err)
+
+		_, err = f.GenerateTicketDetails(context.Background(), "in", "sys", "")
+		assert.ErrorIs(t, err, ErrLLMAllProvidersFailed)
+		assert.ErrorIs(t, err, errA)
+		assert.ErrorIs(t, err, errB)
+	})
+
+	t.Run("Cancelled context stops the chain", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		secondary := &stubClient{resp: ok}
+		f, err := NewFallbackClient([]NamedClient{{"a", &stubClient{delay: time.Second}}, {"b", secondary}}, 0)
+		require.NoError(t, err)
+
+		_, err = f.GenerateTicketDetails(ctx, "in", "sys", "")
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 0, secondary.calls)
+	})
+}
+
+func TestFallbackClient_GenerateFromMessages(t *testing.T) {
+	primary := &stubClient{err: ErrLLMCompletion}
+	secondary := &stubClient{resp: LLMResponse{Summary: "refined"}}
+	f, err := NewFallbackClient([]NamedClient{{"primary", primary}, {"secondary", secondary}}, 0)
+	require.NoError(t, err)
+
+	resp, err := f.GenerateFromMessages(context.Background(), []Message{{Role: RoleUser, Content: "in"}})
+	require.NoError(t, err)
+	assert.Equal(t, "refined", resp.Summary)
+	assert.Equal(t, 1, primary.calls)
+}
+
+// textStub is a stubClient that also returns free text replies.
+type textStub struct {
+	stubClient
+	text string
+}
+
+func (s *textStub) GenerateText(ctx context.Context, messages []Message) (string, error) {
+	s.calls++
+	return s.text, s.err
+}
+
+func TestFallbackClient_GenerateText(t *testing.T) {
+	ticketsOnly := &stubClient{}
+	failing := &textStub{stubClient: stubClient{err: ErrLLMCompletion}}
+	working := &textStub{text: "notes"}
+	f, err := NewFallbackClient([]NamedClient{{"tickets-only", ticketsOnly}, {"failing", failing}, {"working", working}}, 0)
+	require.NoError(t, err)
+
+	text, err := f.GenerateText(context.Background(), []Message{{Role: RoleUser, Content: "in"}})
+	require.NoError(t, err)
+	assert.Equal(t, "notes", text)
+	assert.Equal(t, 0, ticketsOnly.calls)
+	assert.Equal(t, 1, failing.calls)
+
+	f, err = NewFallbackClient([]NamedClient{{"tickets-only", ticketsOnly}}, 0)
+	require.NoError(t, err)
+	_, err = f.GenerateText(context.Background(), []Message{{Role: RoleUser, Content: "in"}})
+	assert.ErrorIs(t, err, ErrLLMAllProvidersFailed)
+	assert.ErrorIs(t, err, ErrLLMTextUnsupported)
+}
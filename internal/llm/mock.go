@@ -0,0 +1,195 @@
+package llm
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rs/zerolog/log"
+)
+
+// mockSummaryLimit is the length at which mock summaries are cut off.
+const mockSummaryLimit = 80
+
+// mockDescriptionTemplates are the description layouts the mock client chooses from.
+// Each is filled with the user request.
+var mockDescriptionTemplates = []string{
+	"## Context\n%s\n\n## Acceptance Criteria\n- The request is implemented as described.\n- The change is covered by tests.",
+	"%s\n\nSteps:\n1. Analyse the request.\n2. Implement the change.\n3. Verify the result.",
+	"Requested change: %s\n\nDefinition of done: the change is reviewed, tested and released.",
+}
+
+// MockClient is a deterministic, in-process Client used for llm.provider "mock". Instead of
+// calling a model it fills templates from the user request, so the create workflow can run
+// end to end without an API key or network access. Identical messages and seed always
+// produce the same response; the seed selects among the description templates.
+type MockClient struct {
+	seed uint64
+}
+
+// NewMockClient creates a mock client seeded with params.Seed (0 if unset). The other
+// generation parameters are ignored.
+func NewMockClient(params GenerationParams) *MockClient {
+	var seed uint64
+	if params.Seed != nil {
+		seed = uint64(*params.Seed)
+	}
+	return &MockClient{seed: seed}
+}
+
+// GenerateTicketDetails implements the llm.Client interface for the mock provider.
+func (m *MockClient) GenerateTicketDetails(ctx context.Context, userInput, systemPrompt, contextContent string) (LLMResponse, error) {
+	return m.GenerateFromMessages(ctx, ConstructMessages(userInput, systemPrompt, contextContent))
+}
+
+// GenerateFromMessages implements the llm.Client interface for the mock provider. The
+// summary is the first line of the user request and the project suggestion is the known
+// project (from WithProjectAliases) whose name or key the request mentions, else the first
+// one. User messages after the request are treated as refinements and listed in the description.
+func (m *MockClient) GenerateFromMessages(ctx context.Context, messages []Message) (LLMResponse, error) {
+	if len(messages) == 0 {
+		return LLMResponse{}, ErrLLMPromptEmpty
+	}
+	if err := ctx.Err(); err != nil {
+		return LLMResponse{}, fmt.Errorf("%w: %w", ErrLLMCompletion, err)
+	}
+
+	var contextContent, request string
+	var refinements []string
+	for _, msg := range messages {
+		if msg.Role != RoleUser {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(msg.Content, contextPrefix):
+			contextContent = strings.TrimPrefix(msg.Content, contextPrefix)
+		case strings.HasPrefix(msg.Content, userRequestPrefix):
+			request = strings.TrimSpace(strings.TrimPrefix(msg.Content, userRequestPrefix))
+		case request == "":
+			request = strings.TrimSpace(msg.Content)
+		default:
+			refinements = append(refinements, strings.TrimSpace(msg.Content))
+		}
+	}
+	if request == "" {
+		return LLMResponse{}, ErrLLMPromptEmpty
+	}
+
+	template := mockDescriptionTemplates[m.hash(messages)%uint64(len(mockDescriptionTemplates))]
+	description := fmt.Sprintf(template, request)
+	if len(refinements) > 0 {
+		description += "\n\nRefinements:\n- " + strings.Join(refinements, "\n- ")
+	}
+
+	response := LLMResponse{
+		Summary:               mockSummary(request),
+		Description:           description,
+		ProjectNameSuggestion: mockProjectSuggestion(contextContent, request+"\n"+strings.Join(refinements, "\n")),
+	}
+	log.Debug().Interface("response", response).Uint64("seed", m.seed).Msg("Generated ticket details with the mock LLM")
+	return response, nil
+}
+
+// GenerateText implements the llm.TextGenerator interface for the mock provider. It
+// replies with the content of the last user message, so prompts built for free text
+// replies can be inspected without a model.
+func (m *MockClient) GenerateText(ctx context.Context, messages []Message) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrLLMCompletion, err)
+	}
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == RoleUser && strings.TrimSpace(messages[i].Content) != "" {
+			return strings.TrimSpace(messages[i].Content), nil
+		}
+	}
+	return "", ErrLLMPromptEmpty
+}
+
+// hash combines the seed and the message contents into the template selector.
+func (m *MockClient) hash(messages []Message) uint64 {
+	h := fnv.New64a()
+	var seed [8]byte
+	binary.LittleEndian.PutUint64(seed[:], m.seed)
+	_, _ = h.Write(seed[:])
+	for _, msg := range messages {
+		_, _ = h.Write([]byte(msg.Role))
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(msg.Content))
+		_, _ = h.Write([]byte{0})
+	}
+	return h.Sum64()
+}
+
+// mockSummary returns the first line of request with its first letter capitalized,
+// cut off at mockSummaryLimit runes.
+func mockSummary(request string) string {
+	summary, _, _ := strings.Cut(request, "\n")
+	summary = strings.TrimRight(strings.TrimSpace(summary), ".")
+	if runes := []rune(summary); len(runes) > mockSummaryLimit {
+		summary = strings.TrimSpace(string(runes[:mockSummaryLimit-3])) + "..."
+	}
+	if r, size := utf8.DecodeRuneInString(summary); r != utf8.RuneError {
+		summary = string(unicode.ToUpper(r)) + summary[size:]
+	}
+	return summary
+}
+
+// mockProjectSuggestion returns the name of the first known project listed in
+// contextContent whose name or key occurs in text, the first known project if none
+// does, or "" if the context lists no projects.
+func mockProjectSuggestion(contextContent, text string) string {
+	_, list, found := strings.Cut(contextContent, knownProjectsHeader+"\n")
+	if !found {
+		return ""
+	}
+	var names []string
+	lowerText := strings.ToLower(text)
+	for _, line := range strings.Split(list, "\n") {
+		entry, ok := strings.CutPrefix(line, "- ")
+		if !ok {
+			break
+		}
+		idx := strings.LastIndex(entry, ": ")
+		if idx < 0 {
+			continue
+		}
+		name := entry[:idx]
+		key, _, _ := strings.Cut(entry[idx+2:], ",")
+		if containsWord(lowerText, strings.ToLower(name)) || containsWord(lowerText, strings.ToLower(key)) {
+			return name
+		}
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	return names[0]
+}
+
+// containsWord reports whether word occurs in text without letters or digits on either side.
+func containsWord(text, word string) bool {
+	if word == "" {
+		return false
+	}
+	for offset := 0; ; {
+		idx := strings.Index(text[offset:], word)
+		if idx < 0 {
+			return false
+		}
+		start, end := offset+idx, offset+idx+len(word)
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if !isWordRune(before) && !isWordRune(after) {
+			return true
+		}
+		offset = start + 1
+	}
+}
+
+func isWordRune(r rune) bool {
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
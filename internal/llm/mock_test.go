@@ -0,0 +1,107 @@
+package llm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var mockAliases = []ProjectAlias{
+	{Name: "Web Frontend", Key: "WEB", DefaultIssueType: "Task"},
+	{Name: "Operations", Key: "OPS"},
+}
+
+func TestMockClient_GenerateTicketDetails(t *testing.T) {
+	client := NewMockClient(GenerationParams{})
+	contextContent := WithProjectAliases("Team notes.", mockAliases)
+
+	resp, err := client.GenerateTicketDetails(context.Background(), "rotate the TLS certificates for ops.\nThey expire next week.", "prompt", contextContent)
+	require.NoError(t, err)
+	assert.Equal(t, "Rotate the TLS certificates for ops", resp.Summary)
+	assert.Contains(t, resp.Description, "They expire next week.")
+	assert.Equal(t, "Operations", resp.ProjectNameSuggestion, "the mentioned project key is suggested")
+
+	resp, err = client.GenerateTicketDetails(context.Background(), "Fix the login page", "", contextContent)
+	require.NoError(t, err)
+	assert.Equal(t, "Web Frontend", resp.ProjectNameSuggestion, "the first project is the default")
+
+	resp, err = client.GenerateTicketDetails(context.Background(), "Fix the login page", "", "")
+	require.NoError(t, err)
+	assert.Empty(t, resp.ProjectNameSuggestion, "no projects are known")
+}
+
+func TestMockClient_Deterministic(t *testing.T) {
+	seed := func(n int) GenerationParams { return GenerationParams{Seed: &n} }
+	generate := func(params GenerationParams, input string) LLMResponse {
+		resp, err := NewMockClient(params).GenerateTicketDetails(context.Background(), input, "", "")
+		require.NoError(t, err)
+		return resp
+	}
+
+	assert.Equal(t, generate(seed(7), "Add dark mode"), generate(seed(7), "Add dark mode"))
+
+	descriptions := map[string]bool{}
+	for i := 0; i < 20; i++ {
+		resp := generate(seed(i), "Add dark mode")
+		assert.Equal(t, "Add dark mode", resp.Summary, "the summary does not depend on the seed")
+		descriptions[resp.Description] = true
+	}
+	assert.Len(t, descriptions, len(mockDescriptionTemplates), "seeds select every template")
+}
+
+func TestMockClient_GenerateFromMessages(t *testing.T) {
+	client := NewMockClient(GenerationParams{})
+	messages := ConstructMessages("Add dark mode", "", WithProjectAliases("", mockAliases))
+	first, err := client.GenerateFromMessages(context.Background(), messages)
+	require.NoError(t, err)
+
+	messages = append(messages, AssistantMessage(first), Message{Role: RoleUser, Content: "Move it to Operations"})
+	refined, err := client.GenerateFromMessages(context.Background(), messages)
+	require.NoError(t, err)
+	assert.Equal(t, first.Summary, refined.Summary)
+	assert.Contains(t, refined.Description, "Refinements:\n- Move it to Operations")
+	assert.Equal(t, "Operations", refined.ProjectNameSuggestion)
+
+	_, err = client.GenerateFromMessages(context.Background(), nil)
+	assert.ErrorIs(t, err, ErrLLMPromptEmpty)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.GenerateFromMessages(ctx, messages)
+	assert.ErrorIs(t, err, ErrLLMCompletion)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestMockSummary(t *testing.T) {
+	long := "a very long request that goes on and on about all the things that should be changed in the product"
+	summary := mockSummary(long)
+	assert.Len(t, []rune(summary), mockSummaryLimit)
+	assert.Equal(t, "A very long", summary[:11])
+	assert.Equal(t, "...", summary[len(summary)-3:])
+	assert.Equal(t, "Überarbeiten", mockSummary("überarbeiten."))
+}
+
+func TestContainsWord(t *testing.T) {
+	assert.True(t, containsWord("move to ops now", "ops"))
+	assert.True(t, containsWord("(ops)", "ops"))
+	assert.False(t, containsWord("devops team", "ops"))
+	assert.False(t, containsWord("anything", ""))
+}
+
+func TestMockClient_GenerateText(t *testing.T) {
+	client := NewMockClient(GenerationParams{})
+	text, err := client.GenerateText(context.Background(), []Message{
+		{Role: RoleSystem, Content: "Write release notes."},
+		{Role: RoleUser, Content: " - PROJ-1 Dark mode \n"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "- PROJ-1 Dark mode", text)
+
+	_, err = client.GenerateText(context.Background(), []Message{{Role: RoleSystem, Content: "sys"}})
+	assert.ErrorIs(t, err, ErrLLMPromptEmpty)
+
+	_, err = GenerateText(context.Background(), &stubClient{}, nil)
+	assert.ErrorIs(t, err, ErrLLMTextUnsupported)
+}
@@ -0,0 +1,52 @@
+package llm
+
+import (
+	"fmt"
+	"math"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// GenerationParams holds optional sampling parameters sent with each completion request.
+// Nil fields and a zero MaxTokens leave the provider defaults in place.
+type GenerationParams struct {
+	Temperature *float32 // 0-2; lower values give more deterministic output
+	TopP        *float32 // 0-1; nucleus sampling probability mass
+	MaxTokens   int      // Upper bound on generated tokens
+	Seed        *int     // Best-effort reproducibility across identical requests
+}
+
+// Validate checks that the parameters are within the ranges accepted by the provider.
+func (p GenerationParams) Validate() error {
+	if p.Temperature != nil && (*p.Temperature < 0 || *p.Temperature > 2) {
+		return fmt.Errorf("%w: temperature %v is outside 0-2", ErrLLMInvalidParams, *p.Temperature)
+	}
+	if p.TopP != nil && (*p.TopP < 0 || *p.TopP > 1) {
+		return fmt.Errorf("%w: top_p %v is outside 0-1", ErrLLMInvalidParams, *p.TopP)
+	}
+	if p.MaxTokens < 0 {
+		return fmt.Errorf("%w: max_tokens %d is negative", ErrLLMInvalidParams, p.MaxTokens)
+	}
+	return nil
+}
+
+// applyTo sets the parameters on an OpenAI chat completion request.
+func (p GenerationParams) applyTo(req *openai.ChatCompletionRequest) {
+	if p.Temperature != nil {
+		req.Temperature = nonZero(*p.Temperature)
+	}
+	if p.TopP != nil {
+		req.TopP = nonZero(*p.TopP)
+	}
+	req.MaxTokens = p.MaxTokens
+	req.Seed = p.Seed
+}
+
+// nonZero maps 0 to the smallest positive float32. go-openai omits zero values from the
+// request, which would silently select the provider default instead of 0.
+func nonZero(v float32) float32 {
+	if v == 0 {
+		return math.SmallestNonzeroFloat32
+	}
+	return v
+}
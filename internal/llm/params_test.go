@@ -0,0 +1,72 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	openai "github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func float32Ptr(v float32) *float32 { return &v }
+
+func TestGenerationParams_Validate(t *testing.T) {
+	assert.NoError(t, GenerationParams{}.Validate())
+	assert.NoError(t, GenerationParams{Temperature: float32Ptr(0), TopP: float32Ptr(1), MaxTokens: 500}.Validate())
+	assert.ErrorIs(t, GenerationParams{Temperature: float32Ptr(2.5)}.Validate(), ErrLLMInvalidParams)
+	assert.ErrorIs(t, GenerationParams{TopP: float32Ptr(-0.1)}.Validate(), ErrLLMInvalidParams)
+	assert.ErrorIs(t, GenerationParams{MaxTokens: -1}.Validate(), ErrLLMInvalidParams)
+}
+
+func TestOpenAIClient_RequestBody(t *testing.T) {
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, `{"choices": [{"index": 0, "message": {"role": "assistant", "content": "{\"summary\": \"S\", \"project_name_suggestion\": \"P\"}"}}]}`)
+	}))
+	defer server.Close()
+
+	config := openai.DefaultConfig("dummy-api-key")
+	config.BaseURL = server.URL + "/v1"
+	client, err := NewOpenAIClient(openai.NewClientWithConfig(config), "test-model")
+	require.NoError(t, err)
+
+	t.Run("Unset params are omitted", func(t *testing.T) {
+		_, err := client.GenerateTicketDetails(context.Background(), "in", "sys", "")
+		require.NoError(t, err)
+		for _, key := range []string{"temperature", "top_p", "max_tokens", "seed"} {
+			assert.NotContains(t, body, key)
+		}
+	})
+
+	t.Run("System prompt is sent with the system role", func(t *testing.T) {
+		_, err := client.GenerateTicketDetails(context.Background(), "in", "sys", "ctx")
+		require.NoError(t, err)
+		messages, ok := body["messages"].([]any)
+		require.True(t, ok)
+		require.Len(t, messages, 3)
+		roles := make([]any, len(messages))
+		for i, m := range messages {
+			roles[i] = m.(map[string]any)["role"]
+		}
+		assert.Equal(t, []any{"system", "user", "user"}, roles)
+	})
+
+	t.Run("Set params are sent", func(t *testing.T) {
+		seed := 42
+		client.SetGenerationParams(GenerationParams{Temperature: float32Ptr(0), TopP: float32Ptr(0.5), MaxTokens: 300, Seed: &seed})
+		_, err := client.GenerateTicketDetails(context.Background(), "in", "sys", "")
+		require.NoError(t, err)
+		assert.Contains(t, body, "temperature", "a zero temperature must still be sent")
+		assert.InDelta(t, 0, body["temperature"], 1e-6)
+		assert.InDelta(t, 0.5, body["top_p"], 1e-6)
+		assert.EqualValues(t, 300, body["max_tokens"])
+		assert.EqualValues(t, 42, body["seed"])
+	})
+}
@@ -3,7 +3,6 @@ package llm
 import (
 	"encoding/json"
 	"fmt"
-	"regexp" // Import regexp
 	"strings"
 
 	"github.com/rs/zerolog/log"
@@ -11,77 +10,251 @@ import (
 
 // LLMResponse defines the structure expected for the JSON data returned by the LLM
 // after processing a user's request for ticket creation. It includes fields for
-// the suggested summary, description, and project alias.
-// Note: The 'IssueType' field is currently missing from this struct based on the
-// system prompt and expected LLM output format.
+// the suggested summary, description, project alias and, optionally, issue type.
+// The constraints on the fields are defined by the response schema (see DefaultResponseSchema).
 type LLMResponse struct {
 	Summary               string `json:"summary"`
 	Description           string `json:"description"` // Description is optional in validation
 	ProjectNameSuggestion string `json:"project_name_suggestion"`
-	// IssueType is currently missing based on file content
+	IssueType             string `json:"issue_type,omitempty"` // Optional; empty if the LLM made no suggestion
+	DueDate               string `json:"due_date,omitempty"`   // Optional deadline mentioned in the request, as YYYY-MM-DD or worded there
 }
 
-// ParseLLMResponse takes the raw string response from the LLM, attempts to clean it
-// by removing potential markdown code fences and trimming whitespace, and then
-// unmarshals the resulting JSON string into an LLMResponse struct.
-// It performs basic validation to ensure required fields ('summary', 'project_name_suggestion')
-// are present in the parsed response.
-// It returns the parsed LLMResponse and nil error on success, or an error if
-// unmarshalling or validation fails.
-// Regex to find JSON possibly enclosed in markdown code fences.
-// It looks for ``` (optional json specifier) ... ```
-// and captures the content starting with { and ending with }.
-// It handles leading/trailing whitespace around the JSON block.
-// Using \x60 for backticks to avoid diff parser issues.
-var jsonRegex = regexp.MustCompile(`(?s)\x60{3}(?:[jJ][sS][oO][nN])?\s*(\{.*\})\s*\x60{3}`)
+// ParseOptions controls how tolerant the parser is of malformed JSON, and how clients
+// recover from replies that cannot be parsed.
+type ParseOptions struct {
+	// Lenient accepts single-quoted keys and strings and trailing commas before a closing
+	// bracket, which some models emit. By default only standard JSON is accepted.
+	Lenient bool
+	// RepairAttempts is how often a client sends a reply that cannot be parsed back to the
+	// model, asking for valid JSON. Zero disables the repair loop. The parse functions
+	// themselves ignore it.
+	RepairAttempts int
+	// Schema validates every decoded object; DefaultResponseSchema if nil.
+	Schema *Schema
+}
+
+// Validate checks that the options are within range.
+func (o ParseOptions) Validate() error {
+	if o.RepairAttempts < 0 {
+		return fmt.Errorf("%w: repair_attempts %d is negative", ErrLLMInvalidParseOptions, o.RepairAttempts)
+	}
+	return nil
+}
 
+// schema returns the schema objects are validated against.
+func (o ParseOptions) schema() *Schema {
+	if o.Schema != nil {
+		return o.Schema
+	}
+	return DefaultResponseSchema()
+}
+
+// ticketCandidate is an object decoded from the response, with the validation error
+// if it lacks a required field.
+type ticketCandidate struct {
+	response LLMResponse
+	err      error
+}
+
+// ParseLLMResponse extracts the ticket details from the raw response of the LLM, accepting
+// only standard JSON. See ParseLLMResponseWithOptions.
 func ParseLLMResponse(rawResponse string) (LLMResponse, error) {
-	log.Debug().Str("raw_response", rawResponse).Msg("Attempting to parse LLM response")
-
-	var jsonStr string
-	match := jsonRegex.FindStringSubmatch(rawResponse)
-
-	if len(match) == 2 {
-		// Found JSON within ```json ... ``` or ``` ... ```
-		jsonStr = match[1]
-		log.Debug().Str("extracted_json", jsonStr).Msg("Extracted JSON using regex from code fences")
-	} else {
-		// Fallback: Assume the response might be JSON without fences, or with malformed fences.
-		// Trim whitespace aggressively.
-		trimmed := strings.TrimSpace(rawResponse)
-		// Basic check if it looks like a JSON object
-		if strings.HasPrefix(trimmed, "{") && strings.HasSuffix(trimmed, "}") {
-			jsonStr = trimmed
-			log.Debug().Str("trimmed_json", jsonStr).Msg("Using trimmed response as JSON (no valid fences found)")
-		} else {
-			log.Error().Str("raw_response", rawResponse).Msg("Could not find JSON object within code fences or as a standalone object")
-			return LLMResponse{}, ErrLLMResponseJSONFind // Use sentinel error
+	return ParseLLMResponseWithOptions(rawResponse, ParseOptions{})
+}
+
+// ParseLLMResponseWithOptions extracts the ticket details from the raw response of the LLM.
+// The JSON may be enclosed in markdown code fences and surrounded by other text, such as
+// reasoning before it. If the response holds several JSON values, or arrays (also nested)
+// of objects, the first object matching the response schema is returned. If none does, the
+// first object is returned with a *SchemaError listing its violations, which also matches
+// ErrLLMResponseMissingField if a required field such as 'summary' is missing.
+func ParseLLMResponseWithOptions(rawResponse string, opts ParseOptions) (LLMResponse, error) {
+	log.Debug().Str("raw_response", rawResponse).Bool("lenient", opts.Lenient).Msg("Attempting to parse LLM response")
+
+	candidates, err := collectTickets(rawResponse, opts)
+	if err != nil {
+		log.Error().Err(err).Str("raw_response", rawResponse).Msg("Could not decode a JSON object from the LLM response")
+		return LLMResponse{}, err
+	}
+	for _, c := range candidates {
+		if c.err == nil {
+			log.Info().Int("objects", len(candidates)).Msg("LLM response parsed and validated successfully")
+			return c.response, nil
 		}
 	}
+	log.Error().Err(candidates[0].err).Interface("parsed_response", candidates[0].response).Msg("Parsed LLM response does not match the schema")
+	return candidates[0].response, candidates[0].err
+}
 
-	// Trim potential extra whitespace from the extracted/trimmed JSON string itself
-	jsonStr = strings.TrimSpace(jsonStr)
+// collectTickets decodes the JSON values in rawResponse, in order. Scanning starts at every
+// '{' or '[' outside an already decoded value, so braces in surrounding text are skipped
+// unless they enclose valid JSON. It returns ErrLLMResponseJSONFind if the response holds no
+// object, and ErrLLMResponseJSONUnmarshal wrapping the first decoding error if no bracketed
+// text could be decoded.
+func collectTickets(rawResponse string, opts ParseOptions) ([]ticketCandidate, error) {
+	var candidates []ticketCandidate
+	var decodeErr error
+	for i := 0; i < len(rawResponse); i++ {
+		if rawResponse[i] != '{' && rawResponse[i] != '[' {
+			continue
+		}
+		end := matchBracket(rawResponse, i, opts.Lenient)
+		if end < 0 {
+			continue
+		}
+		value := rawResponse[i:end]
+		if opts.Lenient {
+			value = normalizeLenientJSON(value)
+		}
+		found, err := decodeTickets(json.RawMessage(value), opts.schema())
+		if err != nil {
+			if decodeErr == nil {
+				decodeErr = err
+			}
+			continue // A valid value may start inside the rejected text
+		}
+		candidates = append(candidates, found...)
+		i = end - 1
+	}
+	if len(candidates) > 0 {
+		return candidates, nil
+	}
+	if decodeErr != nil {
+		return nil, fmt.Errorf("%w: %w", ErrLLMResponseJSONUnmarshal, decodeErr)
+	}
+	return nil, ErrLLMResponseJSONFind
+}
 
-	log.Debug().Str("final_json_string", jsonStr).Msg("Final JSON string for unmarshalling")
+// decodeTickets decodes an object as a ticket validated against schema, or the objects in
+// an array, descending into nested arrays. Other array elements are ignored.
+func decodeTickets(data json.RawMessage, schema *Schema) ([]ticketCandidate, error) {
+	if data[0] == '{' {
+		var object map[string]any
+		if err := json.Unmarshal(data, &object); err != nil {
+			return nil, err
+		}
+		invalid := schema.Validate(object)
+		var response LLMResponse
+		// A field of the wrong type is reported by the schema; without a type constraint
+		// it is a decoding error.
+		if err := json.Unmarshal(data, &response); err != nil && invalid == nil {
+			return nil, err
+		}
+		return []ticketCandidate{{response: response, err: invalid}}, nil
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+	var candidates []ticketCandidate
+	for _, item := range items {
+		if item[0] != '{' && item[0] != '[' {
+			continue
+		}
+		found, err := decodeTickets(item, schema)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, found...)
+	}
+	return candidates, nil
+}
 
-	var response LLMResponse
-	err := json.Unmarshal([]byte(jsonStr), &response)
-	if err != nil {
-		log.Error().Err(err).Str("json_string", jsonStr).Msg("Failed to unmarshal LLM response JSON")
-		return LLMResponse{}, fmt.Errorf("%w: %w", ErrLLMResponseJSONUnmarshal, err) // Use sentinel error
+// matchBracket returns the index just past the bracket closing the one at s[start], or -1
+// if it is not closed or the brackets are mismatched. Brackets in double-quoted strings,
+// and in lenient mode single-quoted strings, are skipped.
+func matchBracket(s string, start int, lenient bool) int {
+	var expected []byte
+	for i := start; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\'', '"':
+			if c == '\'' && !lenient {
+				continue
+			}
+			end := stringEnd(s, i)
+			if end < 0 {
+				return -1
+			}
+			i = end
+		case '{':
+			expected = append(expected, '}')
+		case '[':
+			expected = append(expected, ']')
+		case '}', ']':
+			if expected[len(expected)-1] != c {
+				return -1
+			}
+			expected = expected[:len(expected)-1]
+			if len(expected) == 0 {
+				return i + 1
+			}
+		}
 	}
-	log.Debug().Interface("parsed_response", response).Msg("Successfully unmarshalled LLM response")
+	return -1
+}
 
-	// Basic validation (remains the same)
-	if response.Summary == "" {
-		log.Error().Interface("parsed_response", response).Msg("Parsed LLM response is missing 'summary'")
-		return response, fmt.Errorf("%w: summary", ErrLLMResponseMissingField) // Use sentinel error
+// stringEnd returns the index of the quote closing the string that starts at s[start],
+// or -1 if the string is not terminated.
+func stringEnd(s string, start int) int {
+	quote := s[start]
+	for i := start + 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case quote:
+			return i
+		}
 	}
-	if response.ProjectNameSuggestion == "" {
-		log.Error().Interface("parsed_response", response).Msg("Parsed LLM response is missing 'project_name_suggestion'")
-		return response, fmt.Errorf("%w: project_name_suggestion", ErrLLMResponseMissingField) // Use sentinel error
+	return -1
+}
+
+// normalizeLenientJSON rewrites single-quoted strings as double-quoted ones and drops
+// trailing commas before a closing bracket. value must have been delimited by matchBracket
+// in lenient mode, so that every string in it is terminated.
+func normalizeLenientJSON(value string) string {
+	var b strings.Builder
+	b.Grow(len(value))
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; c {
+		case '"':
+			end := stringEnd(value, i)
+			b.WriteString(value[i : end+1])
+			i = end
+		case '\'':
+			end := stringEnd(value, i)
+			writeDoubleQuoted(&b, value[i+1:end])
+			i = end
+		case ',':
+			next := strings.TrimLeft(value[i+1:], " \t\r\n")
+			if strings.HasPrefix(next, "}") || strings.HasPrefix(next, "]") {
+				continue
+			}
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
 	}
+	return b.String()
+}
 
-	log.Info().Msg("LLM response parsed and validated successfully")
-	return response, nil
+// writeDoubleQuoted writes the content of a single-quoted string as a double-quoted JSON
+// string: escaped single quotes are unescaped and double quotes are escaped.
+func writeDoubleQuoted(b *strings.Builder, content string) {
+	b.WriteByte('"')
+	for i := 0; i < len(content); i++ {
+		switch c := content[i]; {
+		case c == '\\' && i+1 < len(content) && content[i+1] == '\'':
+			b.WriteByte('\'')
+			i++
+		case c == '\\' && i+1 < len(content):
+			b.WriteString(content[i : i+2])
+			i++
+		case c == '"':
+			b.WriteString(`\"`)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
 }
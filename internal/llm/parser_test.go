@@ -1,7 +1,13 @@
 package llm
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseLLMResponse(t *testing.T) {
@@ -159,7 +165,63 @@ func TestParseLLMResponse(t *testing.T) {
 		{
 			name:        "Malformed fence - missing closing backticks",
 			input:       "```json\n{\"summary\": \"Bad Fence\", \"project_name_suggestion\": \"BADFENCE\"}",
-			expectError: true, // Regex won't match, fallback won't find closing brace
+			expectError: false, // The object itself is complete
+			expected: LLMResponse{
+				Summary:               "Bad Fence",
+				ProjectNameSuggestion: "BADFENCE",
+			},
+		},
+		{
+			name:        "Truncated JSON object",
+			input:       "```json\n{\"summary\": \"Cut off\", \"project_name_suggestion\": \"CU",
+			expectError: true,
+		},
+		{
+			name:        "Reasoning with braces before JSON",
+			input:       "The user wants a {bug} ticket, so I'll use the [Web] project.\n{\"summary\": \"After Reasoning\", \"project_name_suggestion\": \"WEB\"}",
+			expectError: false,
+			expected: LLMResponse{
+				Summary:               "After Reasoning",
+				ProjectNameSuggestion: "WEB",
+			},
+		},
+		{
+			name:        "Braces inside JSON strings",
+			input:       `{"summary": "Escape } and \" in {strings}", "description": "[x]", "project_name_suggestion": "STR"}`,
+			expectError: false,
+			expected: LLMResponse{
+				Summary:               `Escape } and " in {strings}`,
+				Description:           "[x]",
+				ProjectNameSuggestion: "STR",
+			},
+		},
+		{
+			name:        "Multiple objects - first valid wins",
+			input:       "Draft:\n{\"summary\": \"Draft\"}\nFinal:\n```json\n{\"summary\": \"Final\", \"project_name_suggestion\": \"ONE\"}\n```\n{\"summary\": \"Extra\", \"project_name_suggestion\": \"TWO\"}",
+			expectError: false,
+			expected: LLMResponse{
+				Summary:               "Final",
+				ProjectNameSuggestion: "ONE",
+			},
+		},
+		{
+			name:        "Object in nested array",
+			input:       `[[{"summary": "Nested", "project_name_suggestion": "NEST"}]]`,
+			expectError: false,
+			expected: LLMResponse{
+				Summary:               "Nested",
+				ProjectNameSuggestion: "NEST",
+			},
+		},
+		{
+			name:        "Single-quoted keys are rejected in strict mode",
+			input:       `{'summary': 'Quoted', 'project_name_suggestion': 'SQ'}`,
+			expectError: true,
+		},
+		{
+			name:        "Wrong field type",
+			input:       `{"summary": 42, "project_name_suggestion": "TYPE"}`,
+			expectError: true,
 		},
 		{
 			name:        "No JSON object found",
@@ -193,3 +255,135 @@ func TestParseLLMResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestParseLLMResponse_Errors(t *testing.T) {
+	_, err := ParseLLMResponse("no JSON here")
+	assert.ErrorIs(t, err, ErrLLMResponseJSONFind)
+
+	_, err = ParseLLMResponse(`{"summary": "x",}`)
+	assert.ErrorIs(t, err, ErrLLMResponseJSONUnmarshal)
+
+	resp, err := ParseLLMResponse(`{"summary": "Only"} {"description": "none"}`)
+	assert.ErrorIs(t, err, ErrLLMResponseMissingField)
+	assert.ErrorContains(t, err, "project_name_suggestion")
+	assert.Equal(t, "Only", resp.Summary, "the first object is returned with the validation error")
+}
+
+func TestParseLLMResponseWithOptions_Lenient(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected LLMResponse
+	}{
+		{
+			name:     "single-quoted keys and strings",
+			input:    `{'summary': 'It\'s "quoted"', 'project_name_suggestion': 'SQ'}`,
+			expected: LLMResponse{Summary: `It's "quoted"`, ProjectNameSuggestion: "SQ"},
+		},
+		{
+			name:     "trailing commas",
+			input:    "```json\n{\"summary\": \"Commas\", \"description\": \"a, b,\", \"project_name_suggestion\": \"TC\",\n}\n```",
+			expected: LLMResponse{Summary: "Commas", Description: "a, b,", ProjectNameSuggestion: "TC"},
+		},
+		{
+			name:     "trailing comma in array",
+			input:    `[{"summary": "Array", "project_name_suggestion": "AR",}, ]`,
+			expected: LLMResponse{Summary: "Array", ProjectNameSuggestion: "AR"},
+		},
+		{
+			name:     "apostrophe in reasoning",
+			input:    `Here's my answer: {'summary': 'Prose', 'project_name_suggestion': 'PR'}`,
+			expected: LLMResponse{Summary: "Prose", ProjectNameSuggestion: "PR"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := ParseLLMResponseWithOptions(tc.input, ParseOptions{Lenient: true})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, resp)
+
+			_, err = ParseLLMResponse(tc.input)
+			assert.Error(t, err, "strict mode rejects the input")
+		})
+	}
+}
+
+// FuzzParseLLMResponse checks that arbitrary responses never crash the parser and that a
+// successfully parsed ticket always has the required fields, in strict and lenient mode.
+func FuzzParseLLMResponse(f *testing.F) {
+	f.Add(`{"summary": "s", "description": "d", "project_name_suggestion": "p"}`)
+	f.Add("Reasoning {first}\n```json\n{\"summary\": \"s\", \"project_name_suggestion\": \"p\",}\n```")
+	f.Add(`[[{'summary': 's', 'project_name_suggestion': 'p'}], {"summary": "t"}]`)
+	f.Add(`{"summary": "\"}{", "project_name_suggestion": "p'"} ['\'', ]`)
+	f.Add(`{[}]`)
+	f.Fuzz(func(t *testing.T, raw string) {
+		for _, opts := range []ParseOptions{{}, {Lenient: true}} {
+			resp, err := ParseLLMResponseWithOptions(raw, opts)
+			if err != nil {
+				continue
+			}
+			assert.NotEmpty(t, resp.Summary)
+			assert.NotEmpty(t, resp.ProjectNameSuggestion)
+		}
+	})
+}
+
+// FuzzParseLLMResponse_RoundTrip checks that any ticket encoded as JSON is recovered, also
+// when preceded by text without brackets and wrapped in a code fence.
+func FuzzParseLLMResponse_RoundTrip(f *testing.F) {
+	f.Add("Summary", "Description with {braces} and \"quotes\"", "PROJ", "Sure, here it is:")
+	f.Add("s", "", "p", "")
+	f.Fuzz(func(t *testing.T, summary, description, project, prefix string) {
+		if summary == "" || project == "" || strings.ContainsAny(prefix, "{[") {
+			t.Skip()
+		}
+		want := LLMResponse{Summary: summary, Description: description, ProjectNameSuggestion: project}
+		data, err := json.Marshal(want)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(data, &want)) // Invalid UTF-8 is replaced when encoding
+
+		for _, raw := range []string{prefix + string(data), prefix + "\n```json\n" + string(data) + "\n```"} {
+			for _, opts := range []ParseOptions{{}, {Lenient: true}} {
+				got, err := ParseLLMResponseWithOptions(raw, opts)
+				require.NoError(t, err, "lenient=%v raw=%q", opts.Lenient, raw)
+				assert.Equal(t, want, got)
+			}
+		}
+	})
+}
+
+func TestParseOptions_Validate(t *testing.T) {
+	assert.NoError(t, ParseOptions{RepairAttempts: 3}.Validate())
+	assert.ErrorIs(t, ParseOptions{RepairAttempts: -1}.Validate(), ErrLLMInvalidParseOptions)
+}
+
+// disableLogs silences the parser's logging for the duration of a benchmark, so that
+// formatting log lines is not measured.
+func disableLogs(b *testing.B) {
+	level := zerolog.GlobalLevel()
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	b.Cleanup(func() { zerolog.SetGlobalLevel(level) })
+}
+
+func BenchmarkParseLLMResponse(b *testing.B) {
+	disableLogs(b)
+	ticket := `{"summary": "Login fails after password reset", "description": "` + strings.Repeat("Steps to reproduce and expected behaviour. ", 50) + `", "project_name_suggestion": "backend", "issue_type": "Bug"}`
+	for _, bc := range []struct {
+		name  string
+		input string
+		opts  ParseOptions
+	}{
+		{"Plain", ticket, ParseOptions{}},
+		{"FencedWithReasoning", "The user reports a bug in the login flow, so this is a Bug for backend.\n\n```json\n" + ticket + "\n```\n", ParseOptions{}},
+		{"Lenient", strings.ReplaceAll(ticket, `"issue_type": "Bug"}`, `'issue_type': 'Bug',}`), ParseOptions{Lenient: true}},
+		{"LastOfManyInArray", "[" + strings.Repeat(`{"summary": "No project"},`+"\n", 20) + ticket + "]", ParseOptions{}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := ParseLLMResponseWithOptions(bc.input, bc.opts); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
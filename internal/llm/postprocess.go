@@ -0,0 +1,149 @@
+package llm
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/karolswdev/ticketron/internal/markdown"
+)
+
+// DefaultSummaryMaxLength is Jira's limit on the length of an issue summary.
+const DefaultSummaryMaxLength = 255
+
+// summaryEllipsis marks a summary that was cut off.
+const summaryEllipsis = "..."
+
+// PostProcessOptions controls how generated tickets are tidied up before they are created.
+type PostProcessOptions struct {
+	// SummaryMaxLength is the length in characters summaries are cut off at;
+	// 0 selects DefaultSummaryMaxLength.
+	SummaryMaxLength int
+	// ImperativeSummary rewrites a leading verb such as "Adds", "Added" or "Adding" to
+	// the imperative "Add".
+	ImperativeSummary bool
+}
+
+// Validate checks that the options are within range.
+func (o PostProcessOptions) Validate() error {
+	if o.SummaryMaxLength < 0 {
+		return fmt.Errorf("%w: summary_max_length must not be negative, got %d", ErrLLMInvalidPostProcessOptions, o.SummaryMaxLength)
+	}
+	return nil
+}
+
+// PostProcess enforces the style of a generated ticket: the summary is put on one line
+// without a trailing period, optionally in the imperative mood, and cut off at the
+// maximum length at a word boundary, and the headings of the description are
+// normalized with markdown.NormalizeHeadings.
+func PostProcess(resp LLMResponse, opts PostProcessOptions) LLMResponse {
+	summary := strings.Join(strings.Fields(resp.Summary), " ")
+	if !strings.HasSuffix(summary, summaryEllipsis) {
+		summary = strings.TrimRight(summary, ".")
+	}
+	if opts.ImperativeSummary {
+		summary = imperative(summary)
+	}
+	maxLength := opts.SummaryMaxLength
+	if maxLength == 0 {
+		maxLength = DefaultSummaryMaxLength
+	}
+	resp.Summary = truncateSummary(summary, maxLength)
+	resp.Description = markdown.NormalizeHeadings(resp.Description)
+	return resp
+}
+
+// truncateSummary cuts summary off at maxLength characters, preferring the last word
+// boundary, and marks the cut with summaryEllipsis.
+func truncateSummary(summary string, maxLength int) string {
+	runes := []rune(summary)
+	if len(runes) <= maxLength {
+		return summary
+	}
+	limit := maxLength - len(summaryEllipsis)
+	if limit <= 0 {
+		return string(runes[:maxLength])
+	}
+	cut := string(runes[:limit])
+	// Break at a word boundary unless that would drop more than half of the summary
+	if idx := strings.LastIndex(cut, " "); idx > 0 && utf8.RuneCountInString(cut[:idx]) > limit/2 && runes[limit] != ' ' {
+		cut = cut[:idx]
+	}
+	return strings.TrimRight(cut, " ,;:.-") + summaryEllipsis
+}
+
+// imperativeVerbs are the verbs whose third person, past and gerund forms are rewritten
+// to the imperative at the start of a summary.
+var imperativeVerbs = []string{
+	"add", "allow", "apply", "build", "change", "clean", "configure", "create", "deprecate",
+	"disable", "display", "document", "drop", "enable", "ensure", "extend", "fix", "handle",
+	"implement", "improve", "increase", "investigate", "limit", "make", "migrate", "move",
+	"optimize", "prevent", "reduce", "refactor", "remove", "rename", "replace", "resolve",
+	"restore", "rewrite", "set", "show", "simplify", "split", "stop", "support", "update",
+	"upgrade", "use", "validate", "write",
+}
+
+// irregularVerbForms lists the forms the rules of verbForms get wrong.
+var irregularVerbForms = map[string][]string{
+	"build":   {"built"},
+	"drop":    {"dropped", "dropping"},
+	"make":    {"made"},
+	"rewrite": {"rewrote", "rewritten"},
+	"set":     {"setting"},
+	"split":   {"splitting"},
+	"stop":    {"stopped", "stopping"},
+	"write":   {"wrote", "written"},
+}
+
+// imperativeForms maps the inflected forms of imperativeVerbs to the verb.
+var imperativeForms = func() map[string]string {
+	forms := make(map[string]string)
+	for _, verb := range imperativeVerbs {
+		for _, form := range verbForms(verb) {
+			forms[form] = verb
+		}
+	}
+	return forms
+}()
+
+// verbForms returns the third person singular, past and gerund forms of a regular verb
+// and the irregular forms listed for it.
+func verbForms(verb string) []string {
+	stem, last := verb[:len(verb)-1], verb[len(verb)-1]
+	consonantY := last == 'y' && !strings.ContainsRune("aeiou", rune(stem[len(stem)-1]))
+	var forms []string
+	switch {
+	case consonantY:
+		forms = append(forms, stem+"ies", stem+"ied")
+	case last == 'e':
+		forms = append(forms, verb+"s", verb+"d")
+	case strings.HasSuffix(verb, "s") || strings.HasSuffix(verb, "x") || strings.HasSuffix(verb, "ch") || strings.HasSuffix(verb, "sh"):
+		forms = append(forms, verb+"es", verb+"ed")
+	default:
+		forms = append(forms, verb+"s", verb+"ed")
+	}
+	if last == 'e' {
+		forms = append(forms, stem+"ing")
+	} else {
+		forms = append(forms, verb+"ing")
+	}
+	return append(forms, irregularVerbForms[verb]...)
+}
+
+// imperative rewrites an inflected leading verb of summary to the imperative, keeping
+// the capitalization of its first letter.
+func imperative(summary string) string {
+	word, rest, _ := strings.Cut(summary, " ")
+	verb, ok := imperativeForms[strings.ToLower(word)]
+	if !ok {
+		return summary
+	}
+	if r, _ := utf8.DecodeRuneInString(word); unicode.IsUpper(r) {
+		verb = strings.ToUpper(verb[:1]) + verb[1:]
+	}
+	if rest == "" {
+		return verb
+	}
+	return verb + " " + rest
+}
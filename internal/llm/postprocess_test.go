@@ -0,0 +1,65 @@
+package llm
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostProcess_Summary(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary string
+		opts    PostProcessOptions
+		want    string
+	}{
+		{name: "trailing period", summary: "Fix the login page.", want: "Fix the login page"},
+		{name: "ellipsis kept", summary: "Fix the login page...", want: "Fix the login page..."},
+		{name: "whitespace collapsed", summary: "  Fix the\nlogin   page ", want: "Fix the login page"},
+		{name: "imperative off", summary: "Adds dark mode", want: "Adds dark mode"},
+		{name: "third person", summary: "Adds dark mode", opts: PostProcessOptions{ImperativeSummary: true}, want: "Add dark mode"},
+		{name: "past", summary: "Fixed crash on startup.", opts: PostProcessOptions{ImperativeSummary: true}, want: "Fix crash on startup"},
+		{name: "gerund", summary: "updating the README", opts: PostProcessOptions{ImperativeSummary: true}, want: "update the README"},
+		{name: "irregular", summary: "Rewrote the parser", opts: PostProcessOptions{ImperativeSummary: true}, want: "Rewrite the parser"},
+		{name: "consonant y", summary: "Applies migrations", opts: PostProcessOptions{ImperativeSummary: true}, want: "Apply migrations"},
+		{name: "already imperative", summary: "Use the new API", opts: PostProcessOptions{ImperativeSummary: true}, want: "Use the new API"},
+		{name: "unknown verb", summary: "Login fails", opts: PostProcessOptions{ImperativeSummary: true}, want: "Login fails"},
+		{name: "word boundary", summary: "Migrate the billing service to the new cluster", opts: PostProcessOptions{SummaryMaxLength: 30}, want: "Migrate the billing service..."},
+		{name: "exact boundary", summary: "Migrate the billing service to", opts: PostProcessOptions{SummaryMaxLength: 30}, want: "Migrate the billing service to"},
+		{name: "long word", summary: "Fix " + strings.Repeat("x", 40), opts: PostProcessOptions{SummaryMaxLength: 20}, want: "Fix xxxxxxxxxxxxx..."},
+		{name: "tiny limit", summary: "Fix it now", opts: PostProcessOptions{SummaryMaxLength: 3}, want: "Fix"},
+		{name: "default limit", summary: strings.Repeat("é", 300), want: strings.Repeat("é", DefaultSummaryMaxLength-3) + "..."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PostProcess(LLMResponse{Summary: tt.summary}, tt.opts)
+			assert.Equal(t, tt.want, got.Summary)
+		})
+	}
+}
+
+func TestPostProcess_KeepsOtherFields(t *testing.T) {
+	resp := LLMResponse{Summary: "S", Description: "#Steps\n1. Run", ProjectNameSuggestion: "P", IssueType: "Bug"}
+	got := PostProcess(resp, PostProcessOptions{})
+	assert.Equal(t, LLMResponse{Summary: "S", Description: "## Steps\n\n1. Run", ProjectNameSuggestion: "P", IssueType: "Bug"}, got)
+}
+
+func TestPostProcess_LongSummaryFromResponse(t *testing.T) {
+	summary := strings.TrimSpace(strings.Repeat("Fix login ", 30))
+	resp, err := ParseLLMResponseWithOptions(`{"summary": "`+summary+`", "project_name_suggestion": "Backend"}`, ParseOptions{})
+	require.NoError(t, err, "the built-in schema leaves long summaries to PostProcess")
+	require.Len(t, resp.Summary, 299)
+
+	processed := PostProcess(resp, PostProcessOptions{})
+	assert.LessOrEqual(t, utf8.RuneCountInString(processed.Summary), DefaultSummaryMaxLength)
+	assert.True(t, strings.HasSuffix(processed.Summary, summaryEllipsis))
+}
+
+func TestPostProcessOptions_Validate(t *testing.T) {
+	assert.NoError(t, PostProcessOptions{}.Validate())
+	assert.NoError(t, PostProcessOptions{SummaryMaxLength: 80}.Validate())
+	assert.ErrorIs(t, PostProcessOptions{SummaryMaxLength: -1}.Validate(), ErrLLMInvalidPostProcessOptions)
+}
@@ -1,6 +1,8 @@
 package llm
 
 import (
+	"encoding/json"
+	"fmt"
 	"strings"
 )
 
@@ -19,24 +21,120 @@ func ConstructPrompt(userInput string, systemPrompt string, context string) stri
 
 	// 2. Add the Context (Information from context.md)
 	if context != "" {
-		promptBuilder.WriteString("Relevant Context:\n")
+		promptBuilder.WriteString(contextPrefix)
 		promptBuilder.WriteString(context)
 		promptBuilder.WriteString("\n\n") // Add separation
 	}
 
 	// 3. Add the User's Request
-	promptBuilder.WriteString("User Request:\n")
+	promptBuilder.WriteString(userRequestPrefix)
 	promptBuilder.WriteString(userInput)
 	promptBuilder.WriteString("\n\n") // Add separation
 
 	// 4. Add Explicit JSON Output Instructions
-	promptBuilder.WriteString("Based on the user request and context, generate a response in the following JSON format ONLY:\n")
-	promptBuilder.WriteString("{\n")
-	promptBuilder.WriteString("  \"summary\": \"<A concise summary of the ticket/task>\",\n")
-	promptBuilder.WriteString("  \"description\": \"<A detailed description of the ticket/task>\",\n")
-	promptBuilder.WriteString("  \"project_name_suggestion\": \"<A suggested project name based on the request>\"\n")
-	promptBuilder.WriteString("}\n")
-	promptBuilder.WriteString("Ensure the output is a single, valid JSON object and nothing else.")
+	promptBuilder.WriteString(jsonOutputInstructions)
 
 	return promptBuilder.String()
 }
+
+// responseSchema describes the JSON object ParseLLMResponse expects, as defined in detail
+// by DefaultResponseSchema.
+const responseSchema = "{\n" +
+	"  \"summary\": \"<A concise summary of the ticket/task>\",\n" +
+	"  \"description\": \"<A detailed description of the ticket/task>\",\n" +
+	"  \"project_name_suggestion\": \"<A suggested project name based on the request>\",\n" +
+	"  \"issue_type\": \"<Optional: the issue type that fits the request best, one of Task, Bug, Story or Epic>\",\n" +
+	"  \"due_date\": \"<Optional: the deadline stated in the request, as YYYY-MM-DD or in its words (e.g. next friday); omit if there is none>\"\n" +
+	"}\n"
+
+// jsonOutputInstructions tells the LLM to answer with the JSON object ParseLLMResponse expects.
+const jsonOutputInstructions = "Based on the user request and context, generate a response in the following JSON format ONLY:\n" +
+	responseSchema +
+	"Ensure the output is a single, valid JSON object and nothing else."
+
+// Prefixes of the user messages built by ConstructMessages, and the header of the
+// project list added by WithProjectAliases.
+const (
+	contextPrefix       = "Relevant Context:\n"
+	userRequestPrefix   = "User Request:\n"
+	knownProjectsHeader = "Known Projects (name: key, default issue type):"
+)
+
+// Roles of prompt messages.
+const (
+	RoleSystem    = "system"
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// Message is one role-tagged part of a prompt, for providers that distinguish system
+// instructions from user content.
+type Message struct {
+	Role    string
+	Content string
+}
+
+// AssistantMessage returns resp as the assistant turn of a conversation, in the JSON
+// format requested by the output instructions.
+func AssistantMessage(resp LLMResponse) Message {
+	data, _ := json.Marshal(resp) // LLMResponse only holds strings, so this cannot fail
+	return Message{Role: RoleAssistant, Content: string(data)}
+}
+
+// RepairMessages returns the turns that ask the model to fix a reply that could not be
+// parsed: the reply itself as the assistant turn, followed by a user turn naming the
+// problem and repeating the expected JSON format.
+func RepairMessages(rawResponse string, parseErr error) []Message {
+	return []Message{
+		{Role: RoleAssistant, Content: rawResponse},
+		{Role: RoleUser, Content: fmt.Sprintf("Your previous reply could not be parsed: %v.\n"+
+			"Return only valid JSON matching this schema, with no other text:\n%s", parseErr, responseSchema)},
+	}
+}
+
+// ConstructMessages builds the prompt as separate messages: the system prompt and the JSON
+// output instructions as a system message, followed by the context (if any) and the user's
+// request as user messages. ConstructPrompt is the single-string equivalent.
+func ConstructMessages(userInput string, systemPrompt string, context string) []Message {
+	system := jsonOutputInstructions
+	if systemPrompt != "" {
+		system = systemPrompt + "\n\n" + jsonOutputInstructions
+	}
+	messages := []Message{{Role: RoleSystem, Content: system}}
+	if context != "" {
+		messages = append(messages, Message{Role: RoleUser, Content: contextPrefix + context})
+	}
+	return append(messages, Message{Role: RoleUser, Content: userRequestPrefix + userInput})
+}
+
+// ProjectAlias describes a project the LLM may suggest, as configured in links.yaml.
+type ProjectAlias struct {
+	Name             string
+	Key              string
+	DefaultIssueType string
+}
+
+// WithProjectAliases appends a "Known Projects" section to contextContent listing the
+// configured aliases, and instructs the LLM to pick project_name_suggestion from them.
+// It returns contextContent unchanged if aliases is empty.
+func WithProjectAliases(contextContent string, aliases []ProjectAlias) string {
+	if len(aliases) == 0 {
+		return contextContent
+	}
+
+	var b strings.Builder
+	if contextContent != "" {
+		b.WriteString(contextContent)
+		b.WriteString("\n\n")
+	}
+	b.WriteString(knownProjectsHeader + "\n")
+	for _, a := range aliases {
+		fmt.Fprintf(&b, "- %s: %s", a.Name, a.Key)
+		if a.DefaultIssueType != "" {
+			fmt.Fprintf(&b, ", %s", a.DefaultIssueType)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("The \"project_name_suggestion\" must be exactly one of the project names listed above.")
+	return b.String()
+}
@@ -1,6 +1,7 @@
 package llm
 
 import (
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -46,3 +47,91 @@ func TestConstructPrompt(t *testing.T) {
 		}
 	}
 }
+
+func TestWithProjectAliases(t *testing.T) {
+	aliases := []ProjectAlias{
+		{Name: "Backend", Key: "BE", DefaultIssueType: "Bug"},
+		{Name: "Frontend", Key: "FE"},
+	}
+
+	got := WithProjectAliases("Team notes.", aliases)
+	want := "Team notes.\n\nKnown Projects (name: key, default issue type):\n" +
+		"- Backend: BE, Bug\n" +
+		"- Frontend: FE\n" +
+		"The \"project_name_suggestion\" must be exactly one of the project names listed above."
+	if got != want {
+		t.Errorf("WithProjectAliases() = %q, want %q", got, want)
+	}
+
+	if got := WithProjectAliases("", aliases); !strings.HasPrefix(got, "Known Projects") {
+		t.Errorf("WithProjectAliases() with empty context = %q, want it to start with the project list", got)
+	}
+	if got := WithProjectAliases("Team notes.", nil); got != "Team notes." {
+		t.Errorf("WithProjectAliases() without aliases = %q, want the context unchanged", got)
+	}
+
+	prompt := ConstructPrompt("input", "system", WithProjectAliases("", aliases))
+	if !strings.Contains(prompt, "- Backend: BE, Bug") {
+		t.Errorf("Prompt does not contain the project aliases:\n%s", prompt)
+	}
+}
+
+func TestConstructMessages(t *testing.T) {
+	messages := ConstructMessages("Create a bug ticket", "You are a helpful assistant.", "Project X.")
+	if len(messages) != 3 {
+		t.Fatalf("ConstructMessages() returned %d messages, want 3: %+v", len(messages), messages)
+	}
+	if messages[0].Role != RoleSystem || !strings.HasPrefix(messages[0].Content, "You are a helpful assistant.") || !strings.Contains(messages[0].Content, "project_name_suggestion") {
+		t.Errorf("First message should be the system prompt with the JSON instructions, got %+v", messages[0])
+	}
+	if messages[1] != (Message{Role: RoleUser, Content: "Relevant Context:\nProject X."}) {
+		t.Errorf("Second message should be the context, got %+v", messages[1])
+	}
+	if messages[2] != (Message{Role: RoleUser, Content: "User Request:\nCreate a bug ticket"}) {
+		t.Errorf("Third message should be the user request, got %+v", messages[2])
+	}
+
+	if messages := ConstructMessages("input", "system", ""); len(messages) != 2 {
+		t.Errorf("ConstructMessages() without context returned %d messages, want 2", len(messages))
+	}
+}
+
+func TestAssistantMessage(t *testing.T) {
+	msg := AssistantMessage(LLMResponse{Summary: "Fix login", Description: "Details", ProjectNameSuggestion: "Backend"})
+	if msg.Role != RoleAssistant {
+		t.Errorf("AssistantMessage() role = %q, want %q", msg.Role, RoleAssistant)
+	}
+	parsed, err := ParseLLMResponse(msg.Content)
+	if err != nil {
+		t.Fatalf("AssistantMessage() content does not parse: %v", err)
+	}
+	if parsed.Summary != "Fix login" || parsed.ProjectNameSuggestion != "Backend" {
+		t.Errorf("AssistantMessage() round trip = %+v", parsed)
+	}
+}
+
+// benchmarkContext is a context.md of realistic size: a few dozen sections of notes.
+var benchmarkContext = strings.Repeat("## Service notes\nThe payments API retries failed charges three times before alerting on-call.\n\n", 40)
+
+func BenchmarkConstructPrompt(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		ConstructPrompt("Login fails with a 500 after the password reset email is used twice", "You are a helpful assistant.", benchmarkContext)
+	}
+}
+
+func BenchmarkConstructMessages(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		ConstructMessages("Login fails with a 500 after the password reset email is used twice", "You are a helpful assistant.", benchmarkContext)
+	}
+}
+
+func BenchmarkWithProjectAliases(b *testing.B) {
+	aliases := make([]ProjectAlias, 50)
+	for i := range aliases {
+		aliases[i] = ProjectAlias{Name: "team-" + strconv.Itoa(i), Key: "T" + strconv.Itoa(i), DefaultIssueType: "Task"}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		WithProjectAliases(benchmarkContext, aliases)
+	}
+}
@@ -0,0 +1,202 @@
+package llm
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// defaultSchemaJSON is the JSON Schema of the response requested by jsonOutputInstructions.
+//
+//go:embed response_schema.json
+var defaultSchemaJSON []byte
+
+var (
+	defaultSchema     *Schema
+	defaultSchemaOnce sync.Once
+)
+
+// Schema is the subset of JSON Schema used to validate LLM responses: an object with
+// required keys whose properties are checked for their type and, for strings, minLength,
+// maxLength and enum. Other keywords are ignored.
+type Schema struct {
+	Type        string             `json:"type,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	MinLength   *int               `json:"minLength,omitempty"`
+	MaxLength   *int               `json:"maxLength,omitempty"`
+	Enum        []string           `json:"enum,omitempty"`
+}
+
+// schemaTypes are the values of the "type" keyword the validator understands.
+var schemaTypes = []string{"string", "number", "integer", "boolean", "array", "object", "null"}
+
+// DefaultResponseSchema returns the built-in schema: 'summary' and 'project_name_suggestion'
+// are required, lengths follow the Jira limits and 'issue_type' is one of the standard types.
+// The returned schema is shared and must not be modified.
+func DefaultResponseSchema() *Schema {
+	defaultSchemaOnce.Do(func() {
+		schema, err := ParseSchema(defaultSchemaJSON)
+		if err != nil {
+			panic(fmt.Sprintf("invalid built-in response schema: %v", err))
+		}
+		defaultSchema = schema
+	})
+	return defaultSchema
+}
+
+// LoadSchema reads a response schema from a JSON file.
+func LoadSchema(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrLLMInvalidSchema, err)
+	}
+	return ParseSchema(data)
+}
+
+// ParseSchema parses a response schema. The root must be of type "object".
+func ParseSchema(data []byte) (*Schema, error) {
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrLLMInvalidSchema, err)
+	}
+	if schema.Type != "object" {
+		return nil, fmt.Errorf("%w: root type must be \"object\", got %q", ErrLLMInvalidSchema, schema.Type)
+	}
+	for name, prop := range schema.Properties {
+		if prop == nil {
+			return nil, fmt.Errorf("%w: property %q has no schema", ErrLLMInvalidSchema, name)
+		}
+		if prop.Type != "" && !slices.Contains(schemaTypes, prop.Type) {
+			return nil, fmt.Errorf("%w: property %q has unsupported type %q", ErrLLMInvalidSchema, name, prop.Type)
+		}
+	}
+	return &schema, nil
+}
+
+// Violation is one way in which a response does not match the schema.
+type Violation struct {
+	Field   string
+	Message string
+	missing bool // A required key is absent, null or empty
+}
+
+func (v Violation) String() string {
+	return v.Field + ": " + v.Message
+}
+
+// SchemaError lists the violations of a response. It matches ErrLLMResponseSchema and,
+// if a required key is missing, ErrLLMResponseMissingField.
+type SchemaError struct {
+	Violations []Violation
+}
+
+func (e *SchemaError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.String()
+	}
+	return ErrLLMResponseSchema.Error() + ": " + strings.Join(messages, "; ")
+}
+
+func (e *SchemaError) Unwrap() []error {
+	errs := []error{ErrLLMResponseSchema}
+	for _, v := range e.Violations {
+		if v.missing {
+			return append(errs, ErrLLMResponseMissingField)
+		}
+	}
+	return errs
+}
+
+// Validate checks a decoded JSON object against the schema. It returns a *SchemaError
+// listing every violation, required keys first and then properties by name, or nil.
+func (s *Schema) Validate(object map[string]any) error {
+	var violations []Violation
+	for _, key := range s.Required {
+		if value, ok := object[key]; !ok || value == nil {
+			violations = append(violations, Violation{Field: key, Message: "is required", missing: true})
+		}
+	}
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		value, ok := object[name]
+		if !ok || value == nil {
+			continue
+		}
+		if message := s.Properties[name].check(value); message != "" {
+			violations = append(violations, Violation{
+				Field:   name,
+				Message: message,
+				missing: value == "" && slices.Contains(s.Required, name),
+			})
+		}
+	}
+	if len(violations) > 0 {
+		return &SchemaError{Violations: violations}
+	}
+	return nil
+}
+
+// check returns why value does not match the property schema, or "".
+func (s *Schema) check(value any) string {
+	if s.Type != "" && !hasType(value, s.Type) {
+		return fmt.Sprintf("must be of type %s, got %s", s.Type, typeOf(value))
+	}
+	str, ok := value.(string)
+	if !ok {
+		return ""
+	}
+	length := utf8.RuneCountInString(str)
+	switch {
+	case s.MinLength != nil && length < *s.MinLength && length == 0:
+		return "must not be empty"
+	case s.MinLength != nil && length < *s.MinLength:
+		return fmt.Sprintf("must be at least %d characters, got %d", *s.MinLength, length)
+	case s.MaxLength != nil && length > *s.MaxLength:
+		return fmt.Sprintf("must be at most %d characters, got %d", *s.MaxLength, length)
+	case len(s.Enum) > 0 && !slices.Contains(s.Enum, str):
+		return fmt.Sprintf("must be one of %s, got %q", strings.Join(s.Enum, ", "), str)
+	}
+	return ""
+}
+
+// hasType reports whether a decoded JSON value is of the given schema type.
+func hasType(value any, schemaType string) bool {
+	if schemaType == "integer" {
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	}
+	return typeOf(value) == schemaType
+}
+
+// typeOf returns the schema type name of a decoded JSON value.
+func typeOf(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
@@ -0,0 +1,102 @@
+package llm
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeObject(t *testing.T, data string) map[string]any {
+	t.Helper()
+	var object map[string]any
+	require.NoError(t, json.Unmarshal([]byte(data), &object))
+	return object
+}
+
+func TestDefaultResponseSchema_Validate(t *testing.T) {
+	schema := DefaultResponseSchema()
+	tests := []struct {
+		name        string
+		input       string
+		wantMessage string // Empty for a valid response
+		missing     bool
+	}{
+		{name: "valid", input: `{"summary": "S", "project_name_suggestion": "P", "issue_type": "Bug"}`},
+		// Long summaries are cut off by PostProcess rather than rejected
+		{name: "long summary", input: `{"summary": "` + strings.Repeat("é", 300) + `", "project_name_suggestion": "P"}`},
+		{name: "extra keys", input: `{"summary": "S", "project_name_suggestion": "P", "priority": "High"}`},
+		{name: "missing keys", input: `{"description": "D"}`, wantMessage: "summary: is required; project_name_suggestion: is required", missing: true},
+		{name: "null key", input: `{"summary": null, "project_name_suggestion": "P"}`, wantMessage: "summary: is required", missing: true},
+		{name: "empty summary", input: `{"summary": "", "project_name_suggestion": "P"}`, wantMessage: "summary: must not be empty", missing: true},
+		{name: "wrong type", input: `{"summary": 42, "project_name_suggestion": "P"}`, wantMessage: "summary: must be of type string, got number"},
+		{
+			name:        "too long",
+			input:       `{"summary": "S", "project_name_suggestion": "` + strings.Repeat("é", 256) + `"}`,
+			wantMessage: "project_name_suggestion: must be at most 255 characters, got 256",
+		},
+		// Issue types are checked against the project's, not the schema
+		{name: "project issue type", input: `{"summary": "S", "project_name_suggestion": "P", "issue_type": "Spike"}`},
+		{name: "lower-case issue type", input: `{"summary": "S", "project_name_suggestion": "P", "issue_type": "task"}`},
+		{
+			name:        "several violations",
+			input:       `{"project_name_suggestion": "P", "issue_type": 3, "description": ["D"]}`,
+			wantMessage: `summary: is required; description: must be of type string, got array; issue_type: must be of type string, got number`,
+			missing:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := schema.Validate(decodeObject(t, tt.input))
+			if tt.wantMessage == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var schemaErr *SchemaError
+			require.ErrorAs(t, err, &schemaErr)
+			assert.EqualError(t, err, ErrLLMResponseSchema.Error()+": "+tt.wantMessage)
+			assert.ErrorIs(t, err, ErrLLMResponseSchema)
+			assert.Equal(t, tt.missing, errors.Is(err, ErrLLMResponseMissingField))
+		})
+	}
+}
+
+func TestParseSchema(t *testing.T) {
+	schema, err := ParseSchema([]byte(`{"type": "object", "required": ["summary"], "properties": {"points": {"type": "integer"}}}`))
+	require.NoError(t, err)
+	assert.NoError(t, schema.Validate(decodeObject(t, `{"summary": "S", "points": 3}`)))
+	assert.EqualError(t, schema.Validate(decodeObject(t, `{"summary": "S", "points": 2.5}`)),
+		ErrLLMResponseSchema.Error()+": points: must be of type integer, got number")
+
+	for _, invalid := range []string{
+		`not json`,
+		`{"type": "array"}`,
+		`{"type": "object", "properties": {"summary": null}}`,
+		`{"type": "object", "properties": {"summary": {"type": "text"}}}`,
+	} {
+		_, err := ParseSchema([]byte(invalid))
+		assert.ErrorIs(t, err, ErrLLMInvalidSchema, invalid)
+	}
+}
+
+func TestLoadSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schema.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"type": "object", "required": ["summary", "project_name_suggestion"], "properties": {"issue_type": {"type": "string", "enum": ["Spike"]}}}`), 0o600))
+	schema, err := LoadSchema(path)
+	require.NoError(t, err)
+
+	resp, err := ParseLLMResponseWithOptions(`{"summary": "S", "project_name_suggestion": "P", "issue_type": "Spike"}`, ParseOptions{Schema: schema})
+	require.NoError(t, err)
+	assert.Equal(t, "Spike", resp.IssueType)
+
+	_, err = ParseLLMResponseWithOptions(`{"summary": "S", "project_name_suggestion": "P", "issue_type": "Task"}`, ParseOptions{Schema: schema})
+	assert.ErrorIs(t, err, ErrLLMResponseSchema, "the custom schema only allows Spike")
+
+	_, err = LoadSchema(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorIs(t, err, ErrLLMInvalidSchema)
+}
@@ -0,0 +1,126 @@
+package markdown
+
+import "strings"
+
+// ADFNode is a node of an Atlassian Document Format document, the rich text format
+// used by Jira Cloud.
+type ADFNode struct {
+	Type    string                 `json:"type"`
+	Version int                    `json:"version,omitempty"`
+	Attrs   map[string]interface{} `json:"attrs,omitempty"`
+	Content []*ADFNode             `json:"content,omitempty"`
+	Text    string                 `json:"text,omitempty"`
+	Marks   []ADFMark              `json:"marks,omitempty"`
+}
+
+// ADFMark is a text formatting mark such as strong or link.
+type ADFMark struct {
+	Type  string                 `json:"type"`
+	Attrs map[string]interface{} `json:"attrs,omitempty"`
+}
+
+// ToADF converts Markdown to an Atlassian Document Format document. Lines of a paragraph
+// are separated by hard breaks and Jira account references ([~accountid:...]) become
+// mention nodes.
+func ToADF(src string) *ADFNode {
+	doc := &ADFNode{Type: "doc", Version: 1}
+	type openList struct {
+		node  *ADFNode
+		depth int
+	}
+	var lists []openList // Nested lists the next item may belong to, outermost first
+	for _, b := range parseBlocks(src) {
+		if b.kind != blockListItem {
+			lists = nil
+		}
+		switch b.kind {
+		case blockHeading:
+			doc.Content = append(doc.Content, &ADFNode{Type: "heading", Attrs: map[string]interface{}{"level": b.level}, Content: adfInline(parseInline(b.lines[0]), nil)})
+		case blockListItem:
+			listType := "bulletList"
+			if b.ordered {
+				listType = "orderedList"
+			}
+			for len(lists) > 0 && (lists[len(lists)-1].depth > b.level || lists[len(lists)-1].depth == b.level && lists[len(lists)-1].node.Type != listType) {
+				lists = lists[:len(lists)-1]
+			}
+			if len(lists) == 0 || lists[len(lists)-1].depth < b.level {
+				list := &ADFNode{Type: listType}
+				if len(lists) == 0 {
+					doc.Content = append(doc.Content, list)
+				} else {
+					parent := lists[len(lists)-1].node
+					item := parent.Content[len(parent.Content)-1]
+					item.Content = append(item.Content, list)
+				}
+				lists = append(lists, openList{node: list, depth: b.level})
+			}
+			list := lists[len(lists)-1].node
+			list.Content = append(list.Content, &ADFNode{Type: "listItem", Content: []*ADFNode{adfParagraph(b.lines)}})
+		case blockCode:
+			code := &ADFNode{Type: "codeBlock"}
+			if b.lang != "" {
+				code.Attrs = map[string]interface{}{"language": b.lang}
+			}
+			if text := strings.Join(b.lines, "\n"); text != "" {
+				code.Content = []*ADFNode{{Type: "text", Text: text}}
+			}
+			doc.Content = append(doc.Content, code)
+		case blockQuote:
+			doc.Content = append(doc.Content, &ADFNode{Type: "blockquote", Content: []*ADFNode{adfParagraph(b.lines)}})
+		case blockRule:
+			doc.Content = append(doc.Content, &ADFNode{Type: "rule"})
+		default:
+			doc.Content = append(doc.Content, adfParagraph(b.lines))
+		}
+	}
+	return doc
+}
+
+// adfParagraph builds a paragraph from lines separated by hard breaks.
+func adfParagraph(lines []string) *ADFNode {
+	p := &ADFNode{Type: "paragraph"}
+	for i, line := range lines {
+		if i > 0 {
+			p.Content = append(p.Content, &ADFNode{Type: "hardBreak"})
+		}
+		p.Content = append(p.Content, adfInline(parseInline(line), nil)...)
+	}
+	return p
+}
+
+// adfInline converts spans to text and mention nodes, applying marks to all text within.
+func adfInline(spans []span, marks []ADFMark) []*ADFNode {
+	var nodes []*ADFNode
+	with := func(mark ADFMark) []ADFMark {
+		return append(append([]ADFMark(nil), marks...), mark)
+	}
+	for _, s := range spans {
+		switch s.kind {
+		case spanText:
+			if s.text != "" {
+				nodes = append(nodes, &ADFNode{Type: "text", Text: s.text, Marks: marks})
+			}
+		case spanCode:
+			// Jira only allows the link mark alongside code
+			var codeMarks []ADFMark
+			for _, m := range marks {
+				if m.Type == "link" {
+					codeMarks = append(codeMarks, m)
+				}
+			}
+			nodes = append(nodes, &ADFNode{Type: "text", Text: s.text, Marks: append(codeMarks, ADFMark{Type: "code"})})
+		case spanMention:
+			nodes = append(nodes, &ADFNode{Type: "mention", Attrs: map[string]interface{}{"id": s.text}})
+		case spanStrong:
+			nodes = append(nodes, adfInline(s.children, with(ADFMark{Type: "strong"}))...)
+		case spanEm:
+			nodes = append(nodes, adfInline(s.children, with(ADFMark{Type: "em"}))...)
+		case spanStrike:
+			nodes = append(nodes, adfInline(s.children, with(ADFMark{Type: "strike"}))...)
+		case spanLink:
+			nodes = append(nodes, adfInline(s.children, with(ADFMark{Type: "link", Attrs: map[string]interface{}{"href": s.href}}))...)
+		}
+	}
+	return nodes
+}
@@ -0,0 +1,77 @@
+package markdown
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const convertSrc = "## Steps\n" +
+	"Open the **login** page\n" +
+	"and submit the _empty_ form.\n" +
+	"\n" +
+	"- first `code`\n" +
+	"  - nested [docs](https://example.com)\n" +
+	"- second ~~old~~\n" +
+	"1. ordered\n" +
+	"\n" +
+	"> quoted\n" +
+	"---\n" +
+	"```go\n" +
+	"x := a**b\n" +
+	"```\n" +
+	"Ping [~accountid:a1] about snake_case_name."
+
+func TestToWiki(t *testing.T) {
+	want := "h2. Steps\n" +
+		"\n" +
+		"Open the *login* page\n" +
+		"and submit the _empty_ form.\n" +
+		"\n" +
+		"* first {{code}}\n" +
+		"** nested [docs|https://example.com]\n" +
+		"* second -old-\n" +
+		"# ordered\n" +
+		"\n" +
+		"bq. quoted\n" +
+		"\n" +
+		"----\n" +
+		"\n" +
+		"{code:go}\n" +
+		"x := a**b\n" +
+		"{code}\n" +
+		"\n" +
+		"Ping [~accountid:a1] about snake_case_name."
+	assert.Equal(t, want, ToWiki(convertSrc))
+	assert.Equal(t, "[https://x.io]", ToWiki("[https://x.io](https://x.io)"))
+	assert.Equal(t, "{quote}\none\ntwo\n{quote}", ToWiki("> one\n> two"))
+}
+
+func TestToADF(t *testing.T) {
+	data, err := json.Marshal(ToADF(convertSrc))
+	require.NoError(t, err)
+	want := `{"type":"doc","version":1,"content":[` +
+		`{"type":"heading","attrs":{"level":2},"content":[{"type":"text","text":"Steps"}]},` +
+		`{"type":"paragraph","content":[{"type":"text","text":"Open the "},{"type":"text","text":"login","marks":[{"type":"strong"}]},{"type":"text","text":" page"},{"type":"hardBreak"},` +
+		`{"type":"text","text":"and submit the "},{"type":"text","text":"empty","marks":[{"type":"em"}]},{"type":"text","text":" form."}]},` +
+		`{"type":"bulletList","content":[` +
+		`{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"first "},{"type":"text","text":"code","marks":[{"type":"code"}]}]},` +
+		`{"type":"bulletList","content":[{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"nested "},{"type":"text","text":"docs","marks":[{"type":"link","attrs":{"href":"https://example.com"}}]}]}]}]}]},` +
+		`{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"second "},{"type":"text","text":"old","marks":[{"type":"strike"}]}]}]}]},` +
+		`{"type":"orderedList","content":[{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"ordered"}]}]}]},` +
+		`{"type":"blockquote","content":[{"type":"paragraph","content":[{"type":"text","text":"quoted"}]}]},` +
+		`{"type":"rule"},` +
+		`{"type":"codeBlock","attrs":{"language":"go"},"content":[{"type":"text","text":"x := a**b"}]},` +
+		`{"type":"paragraph","content":[{"type":"text","text":"Ping "},{"type":"mention","attrs":{"id":"a1"}},{"type":"text","text":" about snake_case_name."}]}` +
+		`]}`
+	assert.JSONEq(t, want, string(data))
+}
+
+func TestParseInline_NestedMarks(t *testing.T) {
+	nodes := adfInline(parseInline("**bold [link](https://x.io)**"), nil)
+	require.Len(t, nodes, 2)
+	assert.Equal(t, []ADFMark{{Type: "strong"}}, nodes[0].Marks)
+	assert.Equal(t, []ADFMark{{Type: "strong"}, {Type: "link", Attrs: map[string]interface{}{"href": "https://x.io"}}}, nodes[1].Marks)
+}
@@ -0,0 +1,247 @@
+package markdown
+
+import (
+	"strings"
+)
+
+// ANSI colors of the syntax highlighting of fenced code.
+const (
+	ansiGreen   = "\x1b[32m"
+	ansiYellow  = "\x1b[33m"
+	ansiMagenta = "\x1b[35m"
+)
+
+// language describes the lexical syntax of a programming language for highlighting:
+// enough to tell keywords, strings, numbers and comments apart, not to parse it.
+type language struct {
+	keywords     map[string]bool
+	lineComments []string  // E.g. "//" and "#"
+	blockComment [2]string // Start and end, e.g. "/*" and "*/"; empty if none
+	quotes       string    // Characters starting strings; strings end at a newline unless the quote is "`"
+	keys         bool      // Identifiers and strings followed by ":" are keys, as in YAML and JSON
+}
+
+// words returns a set of the words in s.
+func words(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(s) {
+		set[w] = true
+	}
+	return set
+}
+
+var (
+	cLike = language{
+		keywords: words(`abstract break case catch char class const continue default do double else enum
+			extends false final finally float for goto if implements import int interface long namespace
+			new null private protected public return short static struct super switch this throw throws
+			true try typedef unsigned using var void volatile while`),
+		lineComments: []string{"//"},
+		blockComment: [2]string{"/*", "*/"},
+		quotes:       `"'`,
+	}
+	goLang = language{
+		keywords: words(`break case chan const continue default defer else fallthrough false for func go
+			goto if import interface iota map nil package range return select struct switch true type var`),
+		lineComments: []string{"//"},
+		blockComment: [2]string{"/*", "*/"},
+		quotes:       "\"'`",
+	}
+	pythonLang = language{
+		keywords: words(`False None True and as assert async await break class continue def del elif else
+			except finally for from global if import in is lambda nonlocal not or pass raise return try
+			while with yield`),
+		lineComments: []string{"#"},
+		quotes:       `"'`,
+	}
+	jsLang = language{
+		keywords: words(`async await break case catch class const continue debugger default delete do else
+			export extends false finally for from function if import in instanceof interface let new null
+			of return static super switch this throw true try type typeof undefined var void while yield`),
+		lineComments: []string{"//"},
+		blockComment: [2]string{"/*", "*/"},
+		quotes:       "\"'`",
+	}
+	rustLang = language{
+		keywords: words(`as async await break const continue crate else enum false fn for if impl in let loop
+			match mod move mut pub ref return self Self static struct super trait true type unsafe use
+			where while`),
+		lineComments: []string{"//"},
+		blockComment: [2]string{"/*", "*/"},
+		quotes:       `"`,
+	}
+	rubyLang = language{
+		keywords: words(`begin break case class def do else elsif end ensure false for if in module next nil
+			not require rescue retry return self super then true unless until when while yield`),
+		lineComments: []string{"#"},
+		quotes:       `"'`,
+	}
+	shellLang = language{
+		keywords: words(`case do done elif else esac exit export fi for function if in local return then
+			until while`),
+		lineComments: []string{"#"},
+		quotes:       `"'`,
+	}
+	sqlLang = language{
+		keywords: words(`ADD ALTER AND AS ASC BETWEEN BY CASE CREATE DELETE DESC DISTINCT DROP ELSE END
+			EXISTS FROM GROUP HAVING IN INDEX INNER INSERT INTO IS JOIN LEFT LIKE LIMIT NOT NULL ON OR ORDER
+			OUTER RIGHT SELECT SET TABLE THEN UNION UPDATE VALUES WHEN WHERE WITH
+			add alter and as asc between by case create delete desc distinct drop else end exists from group
+			having in index inner insert into is join left like limit not null on or order outer right select
+			set table then union update values when where with`),
+		lineComments: []string{"--"},
+		blockComment: [2]string{"/*", "*/"},
+		quotes:       `'"`,
+	}
+	yamlLang = language{
+		keywords:     words(`true false null yes no on off`),
+		lineComments: []string{"#"},
+		quotes:       `"'`,
+		keys:         true,
+	}
+	jsonLang = language{
+		keywords: words(`true false null`),
+		quotes:   `"`,
+		keys:     true,
+	}
+)
+
+// languages maps the info strings of fenced code blocks to their syntax.
+var languages = map[string]*language{
+	"go": &goLang, "golang": &goLang,
+	"python": &pythonLang, "py": &pythonLang,
+	"javascript": &jsLang, "js": &jsLang, "jsx": &jsLang, "typescript": &jsLang, "ts": &jsLang, "tsx": &jsLang,
+	"java": &cLike, "kotlin": &cLike, "c": &cLike, "cpp": &cLike, "c++": &cLike, "csharp": &cLike, "cs": &cLike,
+	"rust": &rustLang, "rs": &rustLang,
+	"ruby": &rubyLang, "rb": &rubyLang,
+	"sh": &shellLang, "bash": &shellLang, "shell": &shellLang, "zsh": &shellLang, "console": &shellLang,
+	"sql":  &sqlLang,
+	"yaml": &yamlLang, "yml": &yamlLang,
+	"json": &jsonLang,
+}
+
+// lookupLanguage returns the syntax of the language named by the info string of a fenced
+// code block, such as "go" or "python title=x.py", or nil if it is unknown.
+func lookupLanguage(info string) *language {
+	name, _, _ := strings.Cut(strings.TrimSpace(info), " ")
+	return languages[strings.ToLower(name)]
+}
+
+// highlight returns code with ANSI colors for keywords, strings, numbers and comments.
+// Styles end at every line break, so the lines can be indented separately.
+func (l *language) highlight(code string) string {
+	var b strings.Builder
+	emit := func(seq, text string) {
+		for i, part := range strings.Split(text, "\n") {
+			if i > 0 {
+				b.WriteByte('\n')
+			}
+			if part != "" {
+				b.WriteString(seq + part + ansiReset)
+			}
+		}
+	}
+	for i := 0; i < len(code); {
+		rest := code[i:]
+		if start, end := l.blockComment[0], l.blockComment[1]; start != "" && strings.HasPrefix(rest, start) {
+			n := strings.Index(rest[len(start):], end)
+			if n < 0 {
+				n = len(rest)
+			} else {
+				n += len(start) + len(end)
+			}
+			emit(ansiDim, rest[:n])
+			i += n
+			continue
+		}
+		if l.startsLineComment(rest) {
+			n := strings.IndexByte(rest, '\n')
+			if n < 0 {
+				n = len(rest)
+			}
+			emit(ansiDim, rest[:n])
+			i += n
+			continue
+		}
+		c := rest[0]
+		switch {
+		case strings.IndexByte(l.quotes, c) >= 0:
+			n := stringLength(rest)
+			seq := ansiGreen
+			if l.keys && followedByColon(rest[n:]) {
+				seq = ansiCyan
+			}
+			emit(seq, rest[:n])
+			i += n
+		case isDigit(c) && (i == 0 || !isIdentByte(code[i-1])):
+			n := 1
+			for n < len(rest) && (isIdentByte(rest[n]) || rest[n] == '.') {
+				n++
+			}
+			emit(ansiYellow, rest[:n])
+			i += n
+		case isIdentByte(c) && !isDigit(c):
+			n := 1
+			for n < len(rest) && (isIdentByte(rest[n]) || (l.keys && rest[n] == '-')) {
+				n++
+			}
+			switch word := rest[:n]; {
+			case l.keys && followedByColon(rest[n:]):
+				emit(ansiCyan, word)
+			case l.keywords[word]:
+				emit(ansiMagenta, word)
+			default:
+				b.WriteString(word)
+			}
+			i += n
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String()
+}
+
+// startsLineComment reports whether s starts with a line comment.
+func (l *language) startsLineComment(s string) bool {
+	for _, prefix := range l.lineComments {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// stringLength returns the length of the string literal s starts with, including its
+// quotes. Backslashes escape the next character. Strings other than backtick strings end
+// at a newline if unterminated.
+func stringLength(s string) int {
+	quote := s[0]
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		case '\n':
+			if quote != '`' {
+				return i
+			}
+		}
+	}
+	return len(s)
+}
+
+// followedByColon reports whether s starts with optional spaces and a colon, as after
+// keys in YAML and JSON.
+func followedByColon(s string) bool {
+	return strings.HasPrefix(strings.TrimLeft(s, " \t"), ":")
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || isDigit(c) || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
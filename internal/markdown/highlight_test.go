@@ -0,0 +1,22 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHighlight(t *testing.T) {
+	yaml := lookupLanguage("YAML title=config.yaml")
+	require.NotNil(t, yaml)
+	assert.Equal(t, "\x1b[36mdefault_project\x1b[0m: \x1b[32m\"BE\"\x1b[0m \x1b[2m# key\x1b[0m\n\x1b[36mstrict\x1b[0m: \x1b[35mtrue\x1b[0m",
+		yaml.highlight("default_project: \"BE\" # key\nstrict: true"))
+	assert.Equal(t, "\x1b[36m\"key\"\x1b[0m: \x1b[32m\"v\"\x1b[0m", lookupLanguage("json").highlight(`"key": "v"`))
+
+	// Multi-line comments and strings are styled line by line
+	assert.Equal(t, "\x1b[2m/* a\x1b[0m\n\x1b[2mb */\x1b[0m x", lookupLanguage("js").highlight("/* a\nb */ x"))
+	assert.Equal(t, "\x1b[32m`a\x1b[0m\n\x1b[32mb`\x1b[0m", lookupLanguage("go").highlight("`a\nb`"))
+	assert.Equal(t, "\x1b[32m\"open\x1b[0m\nv2", lookupLanguage("go").highlight("\"open\nv2"), "unterminated strings end at the line")
+	assert.Nil(t, lookupLanguage("cobol"))
+}
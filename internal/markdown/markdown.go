@@ -0,0 +1,174 @@
+// Package markdown handles the common subset of Markdown used in issue descriptions and
+// comments: it renders it for display in a terminal and converts it to Jira formats.
+package markdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ANSI escape sequences used when rendering with styles.
+const (
+	ansiReset     = "\x1b[0m"
+	ansiBold      = "\x1b[1m"
+	ansiDim       = "\x1b[2m"
+	ansiItalic    = "\x1b[3m"
+	ansiUnderline = "\x1b[4m"
+	ansiCyan      = "\x1b[36m"
+)
+
+var (
+	headingRe  = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*$`)
+	bulletRe   = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	taskRe     = regexp.MustCompile(`^(\s*)[-*+]\s+\[([ xX])\]\s+(.*)$`)
+	orderedRe  = regexp.MustCompile(`^(\s*)(\d+[.)])\s+(.*)$`)
+	quoteRe    = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	ruleRe     = regexp.MustCompile(`^\s*(-\s*){3,}$|^\s*(\*\s*){3,}$|^\s*(_\s*){3,}$`)
+	codeSpanRe = regexp.MustCompile("`([^`]+)`")
+	boldRe     = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	italicRe   = regexp.MustCompile(`\*([^*\s][^*]*)\*`)
+	linkRe     = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+)
+
+// headingStyles are the styles of headings by level: the first for "#", the last for
+// "###" and below.
+var headingStyles = []string{ansiBold + ansiUnderline, ansiBold + ansiMagenta, ansiBold}
+
+// Render converts Markdown to terminal text. Block elements (headings, lists and task
+// lists, quotes, rules and fenced code) are laid out line by line and inline markup is
+// replaced by ANSI styles when styled is true, or stripped to plain text otherwise. With
+// styles, heading levels are told apart and fenced code in a common language, named after
+// the opening fence as in "```go", is syntax highlighted. Unsupported syntax is passed
+// through unchanged.
+func Render(src string, styled bool) string {
+	return renderer{styled: styled}.render(src)
+}
+
+// RenderLinear converts Markdown to unstyled text for screen readers (--plain): like
+// Render without styles, but bullets are "-", quotes are prefixed with "Quote:" and
+// rules become blank lines instead of box-drawing characters.
+func RenderLinear(src string) string {
+	return renderer{linear: true}.render(src)
+}
+
+func (r renderer) render(src string) string {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	out := make([]string, 0, len(lines))
+	for i := 0; i < len(lines); i++ {
+		info, isFence := strings.CutPrefix(strings.TrimSpace(lines[i]), "```")
+		if !isFence {
+			out = append(out, r.block(lines[i]))
+			continue
+		}
+		// An unterminated fence runs to the end
+		end := i + 1
+		for end < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[end]), "```") {
+			end++
+		}
+		out = append(out, r.code(info, lines[i+1:min(end, len(lines))])...)
+		i = end
+	}
+	return strings.Join(out, "\n")
+}
+
+// code renders the lines of a fenced code block whose info string is info, indented.
+// With styles, code in a known language is syntax highlighted and other code is dimmed.
+func (r renderer) code(info string, lines []string) []string {
+	var highlighted []string
+	if lang := lookupLanguage(info); r.styled && lang != nil {
+		highlighted = strings.Split(lang.highlight(strings.Join(lines, "\n")), "\n")
+	}
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		if highlighted != nil {
+			out[i] = "    " + highlighted[i]
+		} else {
+			out[i] = "    " + r.style(ansiDim, line)
+		}
+	}
+	return out
+}
+
+type renderer struct {
+	styled bool
+	linear bool // Avoid box-drawing and other symbols, see RenderLinear
+}
+
+// block renders a single line outside of fenced code.
+func (r renderer) block(line string) string {
+	if m := headingRe.FindStringSubmatch(line); m != nil {
+		return r.style(headingStyles[min(len(m[1]), len(headingStyles))-1], r.inline(m[2]))
+	}
+	if ruleRe.MatchString(line) {
+		if r.linear {
+			return ""
+		}
+		return strings.Repeat("─", 40)
+	}
+	if m := taskRe.FindStringSubmatch(line); m != nil {
+		done := m[2] != " "
+		switch {
+		case r.linear && done:
+			return m[1] + "  - Done: " + r.inline(m[3])
+		case r.linear:
+			return m[1] + "  - To do: " + r.inline(m[3])
+		case done:
+			return m[1] + "  " + r.style(ansiGreen, "☑") + " " + r.style(ansiDim, r.inline(m[3]))
+		default:
+			return m[1] + "  ☐ " + r.inline(m[3])
+		}
+	}
+	if m := bulletRe.FindStringSubmatch(line); m != nil {
+		if r.linear {
+			return m[1] + "  - " + r.inline(m[2])
+		}
+		return m[1] + "  • " + r.inline(m[2])
+	}
+	if m := orderedRe.FindStringSubmatch(line); m != nil {
+		return m[1] + "  " + m[2] + " " + r.inline(m[3])
+	}
+	if m := quoteRe.FindStringSubmatch(line); m != nil {
+		if r.linear {
+			return "Quote: " + r.inline(m[1])
+		}
+		return r.style(ansiDim, "│ ") + r.inline(m[1])
+	}
+	return r.inline(line)
+}
+
+// inline renders emphasis and links. Code spans are rendered verbatim.
+func (r renderer) inline(text string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range codeSpanRe.FindAllStringSubmatchIndex(text, -1) {
+		b.WriteString(r.emphasis(text[last:loc[0]]))
+		b.WriteString(r.style(ansiCyan, text[loc[2]:loc[3]]))
+		last = loc[1]
+	}
+	b.WriteString(r.emphasis(text[last:]))
+	return b.String()
+}
+
+func (r renderer) emphasis(text string) string {
+	text = linkRe.ReplaceAllStringFunc(text, func(s string) string {
+		m := linkRe.FindStringSubmatch(s)
+		if m[1] == m[2] {
+			return r.style(ansiUnderline, m[2])
+		}
+		return m[1] + " (" + r.style(ansiUnderline, m[2]) + ")"
+	})
+	text = boldRe.ReplaceAllStringFunc(text, func(s string) string {
+		return r.style(ansiBold, s[2:len(s)-2])
+	})
+	return italicRe.ReplaceAllStringFunc(text, func(s string) string {
+		return r.style(ansiItalic, s[1:len(s)-1])
+	})
+}
+
+// style wraps text in the given ANSI sequence when styling is enabled.
+func (r renderer) style(seq, text string) string {
+	if !r.styled || text == "" {
+		return text
+	}
+	return seq + text + ansiReset
+}
@@ -0,0 +1,74 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender_Plain(t *testing.T) {
+	src := "## Steps\n" +
+		"1. Open **login** page\n" +
+		"- Submit *empty* form\n" +
+		"  * see [docs](https://example.com/docs)\n" +
+		"> quoted `a*b*c`\n" +
+		"---\n" +
+		"```go\n" +
+		"fmt.Println(\"**x**\")\n" +
+		"```\n" +
+		"snake_case_name stays"
+
+	want := "Steps\n" +
+		"  1. Open login page\n" +
+		"  • Submit empty form\n" +
+		"    • see docs (https://example.com/docs)\n" +
+		"│ quoted a*b*c\n" +
+		"────────────────────────────────────────\n" +
+		"    fmt.Println(\"**x**\")\n" +
+		"snake_case_name stays"
+	assert.Equal(t, want, Render(src, false))
+}
+
+func TestRenderLinear(t *testing.T) {
+	src := "# Title\n" +
+		"- Submit **empty** form\n" +
+		"> quoted\n" +
+		"***\n" +
+		"done"
+
+	want := "Title\n" +
+		"  - Submit empty form\n" +
+		"Quote: quoted\n" +
+		"\n" +
+		"done"
+	assert.Equal(t, want, RenderLinear(src))
+}
+
+func TestRender_Styled(t *testing.T) {
+	assert.Equal(t, "\x1b[1m\x1b[4mTitle\x1b[0m", Render("# Title", true))
+	assert.Equal(t, "use \x1b[36mtix\x1b[0m \x1b[1mnow\x1b[0m", Render("use `tix` __now__", true))
+	assert.Equal(t, "\x1b[4mhttps://x.io\x1b[0m", Render("[https://x.io](https://x.io)", true))
+	assert.Equal(t, "line1\nline2", Render("line1\r\nline2", true))
+}
+
+func TestRender_HeadingLevels(t *testing.T) {
+	assert.Equal(t, "\x1b[1m\x1b[35mUsage\x1b[0m\n\x1b[1mFlags\x1b[0m\n\x1b[1mMore\x1b[0m", Render("## Usage\n### Flags\n###### More", true))
+	assert.Equal(t, "Usage", Render("## Usage", false))
+}
+
+func TestRender_TaskList(t *testing.T) {
+	src := "- [ ] Write tests\n- [x] Fix bug"
+	assert.Equal(t, "  ☐ Write tests\n  ☑ Fix bug", Render(src, false))
+	assert.Equal(t, "  ☐ Write tests\n  \x1b[32m☑\x1b[0m \x1b[2mFix bug\x1b[0m", Render(src, true))
+	assert.Equal(t, "  - To do: Write tests\n  - Done: Fix bug", RenderLinear(src))
+}
+
+func TestRender_CodeHighlighting(t *testing.T) {
+	src := "```go\n// Retry\nreturn fmt.Errorf(\"failed: %d\", 42)\n```\nafter"
+	assert.Equal(t, "    // Retry\n    return fmt.Errorf(\"failed: %d\", 42)\nafter", Render(src, false), "no colors without styles")
+	assert.Equal(t, "    \x1b[2m// Retry\x1b[0m\n"+
+		"    \x1b[35mreturn\x1b[0m fmt.Errorf(\x1b[32m\"failed: %d\"\x1b[0m, \x1b[33m42\x1b[0m)\nafter", Render(src, true))
+
+	assert.Equal(t, "    \x1b[2mplain text\x1b[0m", Render("```\nplain text\n```", true), "unknown languages are dimmed")
+	assert.Equal(t, "    \x1b[2mx := 1\x1b[0m", Render("```brainfuck\nx := 1", true), "an unterminated fence runs to the end")
+}
@@ -0,0 +1,85 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// looseHeadingRe also matches headings without a space after the hashes (#Heading).
+	looseHeadingRe = regexp.MustCompile(`^ {0,3}(#{1,6})(\s*)(.*?)[\s#]*$`)
+	// boldHeadingRe matches a line that is entirely bold, such as **Steps to reproduce:**.
+	boldHeadingRe = regexp.MustCompile(`^\s*(?:\*\*([^*]+)\*\*|__([^_]+)__)\s*:?\s*$`)
+)
+
+// topHeadingLevel is the level the highest headings of a description are moved to. Level
+// 1 is left to the issue summary.
+const topHeadingLevel = 2
+
+// NormalizeHeadings gives the headings of a description a consistent form: the highest
+// heading level becomes ## and the others keep their relative depth, a space follows the
+// hashes, trailing hashes and colons are removed, lines that are entirely bold become
+// headings of the highest level and every heading is set off by blank lines. Fenced code
+// is left alone.
+func NormalizeHeadings(src string) string {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	levels := make([]int, len(lines)) // 0 for lines that are not headings, -1 for bold lines
+	texts := make([]string, len(lines))
+	minLevel := 0
+	inFence := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if m := looseHeadingRe.FindStringSubmatch(line); m != nil && isHeadingText(m[3], m[2] != "") {
+			levels[i], texts[i] = len(m[1]), headingText(m[3])
+			if minLevel == 0 || levels[i] < minLevel {
+				minLevel = levels[i]
+			}
+		} else if m := boldHeadingRe.FindStringSubmatch(line); m != nil {
+			levels[i], texts[i] = -1, headingText(m[1]+m[2])
+		}
+	}
+
+	out := make([]string, 0, len(lines))
+	for i, line := range lines {
+		if levels[i] == 0 {
+			out = append(out, line)
+			continue
+		}
+		level := topHeadingLevel
+		if levels[i] > 0 {
+			level = min(levels[i]-minLevel+topHeadingLevel, 6)
+		}
+		if len(out) > 0 && strings.TrimSpace(out[len(out)-1]) != "" {
+			out = append(out, "")
+		}
+		out = append(out, strings.Repeat("#", level)+" "+texts[i])
+		if i+1 < len(lines) && strings.TrimSpace(lines[i+1]) != "" {
+			out = append(out, "")
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// isHeadingText reports whether the text after the hashes of a line makes it a heading.
+// Without a space it must start with a letter, so issue references like #123 stay text.
+func isHeadingText(text string, spaced bool) bool {
+	if text == "" {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(text)
+	return spaced || unicode.IsLetter(r)
+}
+
+// headingText trims the text of a heading and removes a trailing colon.
+func headingText(text string) string {
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(text), ":"))
+}
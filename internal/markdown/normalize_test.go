@@ -0,0 +1,32 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeHeadings(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{name: "no headings", src: "Just text.\n\n- a\n- b", want: "Just text.\n\n- a\n- b"},
+		{name: "levels shifted", src: "# Context\ntext\n## Details\nmore", want: "## Context\n\ntext\n\n### Details\n\nmore"},
+		{name: "already normalized", src: "## Context\n\ntext", want: "## Context\n\ntext"},
+		{name: "missing space and trailing marks", src: "###Steps:\n1. Run ###", want: "## Steps\n\n1. Run ###"},
+		{name: "closing hashes", src: "## Context ##\ntext", want: "## Context\n\ntext"},
+		{name: "bold lines", src: "**Acceptance Criteria:**\n- works\n__Notes__:\nnone", want: "## Acceptance Criteria\n\n- works\n\n## Notes\n\nnone"},
+		{name: "bold inside a line", src: "This is **important** text", want: "This is **important** text"},
+		{name: "issue references", src: "#123 is related\n#!/bin/sh", want: "#123 is related\n#!/bin/sh"},
+		{name: "fenced code", src: "```sh\n# comment\n**x**\n```\n#Usage", want: "```sh\n# comment\n**x**\n```\n\n## Usage"},
+		{name: "deep levels capped", src: "## A\n###### F", want: "## A\n\n###### F"},
+		{name: "windows line breaks", src: "# A\r\ntext", want: "## A\n\ntext"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeHeadings(tt.src))
+		})
+	}
+}
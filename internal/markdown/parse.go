@@ -0,0 +1,196 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+// blockKind identifies the type of a parsed block.
+type blockKind int
+
+const (
+	blockParagraph blockKind = iota
+	blockHeading
+	blockListItem
+	blockCode
+	blockQuote
+	blockRule
+)
+
+// block is a block-level element of a Markdown document. Inline markup in lines is not
+// parsed yet, except in code blocks where it is never parsed.
+type block struct {
+	kind    blockKind
+	level   int  // Heading level, or nesting depth of a list item starting at 0
+	ordered bool // List items only
+	lang    string
+	lines   []string
+}
+
+// spanKind identifies the type of an inline span.
+type spanKind int
+
+const (
+	spanText spanKind = iota
+	spanCode
+	spanStrong
+	spanEm
+	spanStrike
+	spanLink
+	spanMention
+)
+
+// span is an inline element. Text holds the content of text and code spans and the
+// account ID of mentions; other spans hold their content in children.
+type span struct {
+	kind     spanKind
+	text     string
+	href     string
+	children []span
+}
+
+var (
+	strikeRe  = regexp.MustCompile(`~~([^~]+)~~`)
+	mentionRe = regexp.MustCompile(`\[~accountid:([^\]]+)\]`)
+	// emRe matches *x* anywhere and _x_ only outside words, so snake_case names are kept.
+	emRe = regexp.MustCompile(`\*([^*\s][^*]*)\*|\b_([^_]+)_\b`)
+)
+
+// inlinePatterns are tried at every position; the earliest match wins, and on a tie the
+// pattern listed first.
+var inlinePatterns = []struct {
+	kind spanKind
+	re   *regexp.Regexp
+}{
+	{spanCode, codeSpanRe},
+	{spanMention, mentionRe},
+	{spanLink, linkRe},
+	{spanStrong, boldRe},
+	{spanStrike, strikeRe},
+	{spanEm, emRe},
+}
+
+// parseBlocks splits src into blocks. Consecutive text lines form one paragraph and
+// consecutive quote lines one quote; an unterminated code fence runs to the end.
+func parseBlocks(src string) []block {
+	var blocks []block
+	var fence *block
+	joinable := false // Whether the next text or quote line may extend the last block
+	for _, line := range strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if fence != nil {
+			if strings.HasPrefix(trimmed, "```") {
+				blocks = append(blocks, *fence)
+				fence = nil
+				continue
+			}
+			fence.lines = append(fence.lines, line)
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") {
+			fence = &block{kind: blockCode, lang: strings.TrimSpace(strings.TrimPrefix(trimmed, "```"))}
+			joinable = false
+			continue
+		}
+
+		last := len(blocks) - 1
+		if trimmed == "" {
+			joinable = false
+			continue
+		}
+		if m := headingRe.FindStringSubmatch(line); m != nil {
+			blocks = append(blocks, block{kind: blockHeading, level: len(m[1]), lines: []string{m[2]}})
+			joinable = false
+			continue
+		}
+		if ruleRe.MatchString(line) {
+			blocks = append(blocks, block{kind: blockRule})
+			joinable = false
+			continue
+		}
+		if m := bulletRe.FindStringSubmatch(line); m != nil {
+			blocks = append(blocks, block{kind: blockListItem, level: listDepth(m[1]), lines: []string{m[2]}})
+			joinable = false
+			continue
+		}
+		if m := orderedRe.FindStringSubmatch(line); m != nil {
+			blocks = append(blocks, block{kind: blockListItem, level: listDepth(m[1]), ordered: true, lines: []string{m[3]}})
+			joinable = false
+			continue
+		}
+		if m := quoteRe.FindStringSubmatch(line); m != nil {
+			if joinable && blocks[last].kind == blockQuote {
+				blocks[last].lines = append(blocks[last].lines, m[1])
+			} else {
+				blocks = append(blocks, block{kind: blockQuote, lines: []string{m[1]}})
+			}
+			joinable = true
+			continue
+		}
+		if joinable && blocks[last].kind == blockParagraph {
+			blocks[last].lines = append(blocks[last].lines, trimmed)
+		} else {
+			blocks = append(blocks, block{kind: blockParagraph, lines: []string{trimmed}})
+		}
+		joinable = true
+	}
+	if fence != nil {
+		blocks = append(blocks, *fence)
+	}
+	return blocks
+}
+
+// listDepth returns the nesting depth of a list item from its indentation, two spaces or
+// one tab per level.
+func listDepth(indent string) int {
+	width := 0
+	for _, r := range indent {
+		if r == '\t' {
+			width += 4
+		} else {
+			width++
+		}
+	}
+	return width / 2
+}
+
+// parseInline splits text into inline spans.
+func parseInline(text string) []span {
+	var spans []span
+	for text != "" {
+		kind, loc := spanText, []int(nil)
+		for _, p := range inlinePatterns {
+			if l := p.re.FindStringSubmatchIndex(text); l != nil && (loc == nil || l[0] < loc[0]) {
+				kind, loc = p.kind, l
+			}
+		}
+		if loc == nil {
+			spans = append(spans, span{kind: spanText, text: text})
+			break
+		}
+		if loc[0] > 0 {
+			spans = append(spans, span{kind: spanText, text: text[:loc[0]]})
+		}
+		group := func(n int) string {
+			if loc[2*n] < 0 {
+				return ""
+			}
+			return text[loc[2*n]:loc[2*n+1]]
+		}
+		switch kind {
+		case spanCode, spanMention:
+			spans = append(spans, span{kind: kind, text: group(1)})
+		case spanLink:
+			spans = append(spans, span{kind: kind, href: group(2), children: parseInline(group(1))})
+		default:
+			// Emphasis patterns have one alternative per delimiter, only one of which matched
+			inner := group(1)
+			if inner == "" {
+				inner = group(2)
+			}
+			spans = append(spans, span{kind: kind, children: parseInline(inner)})
+		}
+		text = text[loc[1]:]
+	}
+	return spans
+}
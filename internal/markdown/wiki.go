@@ -0,0 +1,90 @@
+package markdown
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ToWiki converts Markdown to Jira wiki markup, as understood by Jira Server and Data Center.
+// Jira account references ([~accountid:...]) are kept as they are.
+func ToWiki(src string) string {
+	var out []string
+	prevList := false
+	for _, b := range parseBlocks(src) {
+		var text string
+		switch b.kind {
+		case blockHeading:
+			text = "h" + strconv.Itoa(b.level) + ". " + wikiInline(parseInline(b.lines[0]))
+		case blockListItem:
+			marker := "*"
+			if b.ordered {
+				marker = "#"
+			}
+			text = strings.Repeat(marker, b.level+1) + " " + wikiInline(parseInline(b.lines[0]))
+		case blockCode:
+			open := "{code}"
+			if b.lang != "" {
+				open = "{code:" + b.lang + "}"
+			}
+			text = open + "\n" + strings.Join(b.lines, "\n") + "\n{code}"
+		case blockQuote:
+			if len(b.lines) == 1 {
+				text = "bq. " + wikiInline(parseInline(b.lines[0]))
+			} else {
+				text = "{quote}\n" + wikiLines(b.lines) + "\n{quote}"
+			}
+		case blockRule:
+			text = "----"
+		default:
+			text = wikiLines(b.lines)
+		}
+		isList := b.kind == blockListItem
+		switch {
+		case len(out) == 0:
+			out = append(out, text)
+		case isList && prevList:
+			// Items of the same list must not be separated by a blank line
+			out[len(out)-1] += "\n" + text
+		default:
+			out = append(out, text)
+		}
+		prevList = isList
+	}
+	return strings.Join(out, "\n\n")
+}
+
+func wikiLines(lines []string) string {
+	converted := make([]string, len(lines))
+	for i, line := range lines {
+		converted[i] = wikiInline(parseInline(line))
+	}
+	return strings.Join(converted, "\n")
+}
+
+func wikiInline(spans []span) string {
+	var b strings.Builder
+	for _, s := range spans {
+		switch s.kind {
+		case spanCode:
+			b.WriteString("{{" + s.text + "}}")
+		case spanMention:
+			b.WriteString("[~accountid:" + s.text + "]")
+		case spanStrong:
+			b.WriteString("*" + wikiInline(s.children) + "*")
+		case spanEm:
+			b.WriteString("_" + wikiInline(s.children) + "_")
+		case spanStrike:
+			b.WriteString("-" + wikiInline(s.children) + "-")
+		case spanLink:
+			label := wikiInline(s.children)
+			if label == s.href {
+				b.WriteString("[" + s.href + "]")
+			} else {
+				b.WriteString("[" + label + "|" + s.href + "]")
+			}
+		default:
+			b.WriteString(s.text)
+		}
+	}
+	return b.String()
+}
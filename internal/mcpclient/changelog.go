@@ -0,0 +1,35 @@
+package mcpclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// GetIssueChangelog sends a GET request to the MCP server's /jira_issue/{issueKey}/changelog
+// endpoint and returns one page of the issue's change history, oldest first.
+// It returns an error if the request or decoding fails, or if the server returns a non-200 status code.
+func (c *Client) GetIssueChangelog(ctx context.Context, issueKey string, req GetChangelogRequest) (*ChangelogResponse, error) {
+	if strings.TrimSpace(issueKey) == "" {
+		return nil, ErrIssueKeyMissing
+	}
+	query := url.Values{}
+	if req.StartAt > 0 {
+		query.Set("startAt", strconv.Itoa(req.StartAt))
+	}
+	if req.MaxResults > 0 {
+		query.Set("maxResults", strconv.Itoa(req.MaxResults))
+	}
+	path := fmt.Sprintf("/jira_issue/%s/changelog", url.PathEscape(issueKey))
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	var page ChangelogResponse
+	if err := c.doJSON(ctx, http.MethodGet, path, nil, http.StatusOK, &page, "GetIssueChangelog"); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
@@ -3,13 +3,16 @@ package mcpclient
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
 	"github.com/karolswdev/ticketron/internal/config"
@@ -23,10 +26,48 @@ type Client struct {
 	HTTPClient *http.Client
 }
 
+// Option configures optional behaviour of a Client created with New.
+type Option func(*Client)
+
+// WithHTTPClient makes the Client use httpClient instead of the default client with a 10s timeout.
+// A nil httpClient is ignored.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.HTTPClient = httpClient
+		}
+	}
+}
+
+// WithTLSConfig makes the default HTTP client use tlsCfg, e.g. for private CAs or
+// mutual TLS. A nil tlsCfg is ignored. It should not be combined with WithHTTPClient.
+func WithTLSConfig(tlsCfg *tls.Config) Option {
+	return func(c *Client) {
+		if tlsCfg == nil {
+			return
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsCfg
+		c.HTTPClient.Transport = transport
+	}
+}
+
+// WithTransport makes the default HTTP client send requests through transport, keeping its
+// timeout. Sharing one transport between clients lets them reuse pooled connections.
+// A nil transport is ignored. It should not be combined with WithHTTPClient.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		if transport != nil {
+			c.HTTPClient.Transport = transport
+		}
+	}
+}
+
 // New creates and initializes a new MCP Client instance based on the provided AppConfig.
 // It parses the MCPServerURL from the config and sets up a default HTTP client
-// with a timeout. It returns an error if the URL is missing or invalid.
-func New(cfg *config.AppConfig) (*Client, error) {
+// with a timeout, which can be overridden with WithHTTPClient. It returns an error
+// if the URL is missing or invalid.
+func New(cfg *config.AppConfig, opts ...Option) (*Client, error) {
 	if cfg.MCPServerURL == "" {
 		return nil, ErrMCPServerURLMissing // Use sentinel error
 	}
@@ -35,12 +76,16 @@ func New(cfg *config.AppConfig) (*Client, error) {
 		return nil, fmt.Errorf("%w: %w", ErrMCPServerURLParse, err) // Use sentinel error
 	}
 
-	return &Client{
+	c := &Client{
 		BaseURL: baseURL,
 		HTTPClient: &http.Client{
 			Timeout: time.Second * 10, // Default timeout
 		},
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
 }
 
 // CreateIssue sends a POST request to the MCP server's /create_jira_issue endpoint
@@ -50,6 +95,11 @@ func New(cfg *config.AppConfig) (*Client, error) {
 // It returns the CreateIssueResponse or an error if the request or decoding fails,
 // or if the server returns a non-201 status code.
 func (c *Client) CreateIssue(ctx context.Context, reqBody CreateIssueRequest) (*CreateIssueResponse, error) {
+	description, err := ConvertDescription(reqBody.Description, reqBody.DescriptionFormat)
+	if err != nil {
+		return nil, err
+	}
+	reqBody.Description = description
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrRequestMarshal, err) // Use sentinel error
@@ -65,6 +115,9 @@ func (c *Client) CreateIssue(ctx context.Context, reqBody CreateIssueRequest) (*
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	if reqBody.IdempotencyKey != "" {
+		req.Header.Set(IdempotencyKeyHeader, reqBody.IdempotencyKey)
+	}
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
@@ -99,6 +152,7 @@ func (c *Client) CreateIssue(ctx context.Context, reqBody CreateIssueRequest) (*
 	if err := json.NewDecoder(resp.Body).Decode(&successResp); err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrResponseDecode, err) // Use sentinel error
 	}
+	successResp.Replayed = strings.EqualFold(resp.Header.Get(ReplayedHeader), "true")
 
 	return &successResp, nil
 }
@@ -107,6 +161,7 @@ func (c *Client) CreateIssue(ctx context.Context, reqBody CreateIssueRequest) (*
 // to search for Jira issues based on the provided SearchIssuesRequest data (e.g., JQL).
 // It handles JSON marshalling of the request, sending the HTTP request,
 // and decoding the JSON response (either SearchIssuesResponse on success or ErrorResponse on failure).
+// Successful responses are decoded directly from the body; it is only logged at trace level.
 // It returns the SearchIssuesResponse or an error if the request or decoding fails,
 // or if the server returns a non-200 status code.
 func (c *Client) SearchIssues(ctx context.Context, reqBody SearchIssuesRequest) (*SearchIssuesResponse, error) {
@@ -132,22 +187,12 @@ func (c *Client) SearchIssues(ctx context.Context, reqBody SearchIssuesRequest)
 	}
 	defer resp.Body.Close()
 
-	// Read the body first for logging, then check status code
-	respBodyBytes, readErr := io.ReadAll(resp.Body)
-	if readErr != nil {
-		log.Warn().Err(readErr).Msg("Failed to read MCP response body for logging")
-		// Continue processing status code, but body might be lost
-	} else {
-		// Reset body for subsequent decoders
-		resp.Body = io.NopCloser(bytes.NewBuffer(respBodyBytes))
-		log.Debug().Int("status_code", resp.StatusCode).RawJSON("response_body", respBodyBytes).Msg("Received MCP SearchIssues response") // Added Debug log
-	}
-
 	if resp.StatusCode != http.StatusOK { // Expecting 200 OK for search
-		// Attempt to decode the known error structure first
+		// Error bodies are small, so they are read whole for the log and the error message
+		respBodyBytes, readErr := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		log.Debug().Int("status_code", resp.StatusCode).Bytes("response_body", respBodyBytes).Msg("Received MCP SearchIssues error response")
 		var errResp ErrorResponse
-		// Attempt to decode the known error structure first
-		if decodeErr := json.NewDecoder(resp.Body).Decode(&errResp); decodeErr == nil && errResp.Error != "" {
+		if readErr == nil && json.Unmarshal(respBodyBytes, &errResp) == nil && errResp.Error != "" {
 			// Wrap the specific server message with our sentinel error
 			return nil, fmt.Errorf("%w: %s (status %d)", ErrMCPServerError, errResp.Error, resp.StatusCode)
 		}
@@ -155,14 +200,35 @@ func (c *Client) SearchIssues(ctx context.Context, reqBody SearchIssuesRequest)
 		return nil, fmt.Errorf("%w (status %d)", ErrMCPServerErrorUnparseable, resp.StatusCode)
 	}
 
+	// Results are decoded as they stream in so large pages are never buffered whole.
+	// The raw body is only kept when it is going to be logged at trace level.
+	var body io.Reader = resp.Body
+	var raw *bytes.Buffer
+	if traceEnabled() {
+		raw = &bytes.Buffer{}
+		body = io.TeeReader(resp.Body, raw)
+	}
 	var successResp SearchIssuesResponse
-	if err := json.NewDecoder(resp.Body).Decode(&successResp); err != nil {
+	if err := json.NewDecoder(body).Decode(&successResp); err != nil {
+		log.Debug().Err(err).Int("status_code", resp.StatusCode).Msg("Failed to decode MCP SearchIssues response")
 		return nil, fmt.Errorf("%w: %w", ErrResponseDecode, err) // Use sentinel error
 	}
+	if raw != nil {
+		log.Trace().Bytes("response_body", raw.Bytes()).Msg("Received MCP SearchIssues response body")
+	}
+	log.Debug().Int("status_code", resp.StatusCode).Int("issues", len(successResp.Issues)).Int("total", successResp.Total).Msg("Received MCP SearchIssues response")
 
 	return &successResp, nil
 }
 
+// maxErrorBodySize limits how much of an error response is read for logging and decoding.
+const maxErrorBodySize = 1 << 20
+
+// traceEnabled reports whether trace-level messages are currently logged.
+func traceEnabled() bool {
+	return log.Logger.GetLevel() <= zerolog.TraceLevel && zerolog.GlobalLevel() <= zerolog.TraceLevel
+}
+
 // GetIssue sends a GET request to the MCP server's /jira_issue/{issueKey} endpoint
 // to retrieve details for a specific Jira issue identified by its key.
 // It handles constructing the URL, sending the HTTP request, and decoding the JSON response
@@ -171,10 +237,14 @@ func (c *Client) SearchIssues(ctx context.Context, reqBody SearchIssuesRequest)
 // or if the server returns a non-200 status code.
 func (c *Client) GetIssue(ctx context.Context, issueKey string) (*Issue, error) {
 	// Construct the relative path with the issue key
-	relativePath := fmt.Sprintf("/jira_issue/%s", issueKey)
+	relativePath := fmt.Sprintf("/jira_issue/%s", url.PathEscape(issueKey))
+	ref, err := url.Parse(relativePath)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRequestCreate, err)
+	}
 
 	// Construct the full URL for the endpoint
-	endpointURL := c.BaseURL.ResolveReference(&url.URL{Path: relativePath})
+	endpointURL := c.BaseURL.ResolveReference(ref)
 
 	log.Debug().Str("url", endpointURL.String()).Msg("Sending MCP GetIssue request")       // Added Debug log
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpointURL.String(), nil) // No body for GET
@@ -202,15 +272,20 @@ func (c *Client) GetIssue(ctx context.Context, issueKey string) (*Issue, error)
 	}
 
 	if resp.StatusCode != http.StatusOK { // Expecting 200 OK for get
-		// Attempt to decode the known error structure first
 		var errResp ErrorResponse
+		var err error
 		// Attempt to decode the known error structure first
 		if decodeErr := json.NewDecoder(resp.Body).Decode(&errResp); decodeErr == nil && errResp.Error != "" {
 			// Wrap the specific server message with our sentinel error
-			return nil, fmt.Errorf("%w: %s (status %d)", ErrMCPServerError, errResp.Error, resp.StatusCode)
+			err = fmt.Errorf("%w: %s (status %d)", ErrMCPServerError, errResp.Error, resp.StatusCode)
+		} else {
+			// If decoding fails or the error message is empty, return the unparseable error sentinel
+			err = fmt.Errorf("%w (status %d)", ErrMCPServerErrorUnparseable, resp.StatusCode)
 		}
-		// If decoding fails or the error message is empty, return the unparseable error sentinel
-		return nil, fmt.Errorf("%w (status %d)", ErrMCPServerErrorUnparseable, resp.StatusCode)
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("%w: %w", ErrNotFound, err)
+		}
+		return nil, err
 	}
 
 	var issue Issue // Use the Issue struct from types.go
@@ -220,3 +295,77 @@ func (c *Client) GetIssue(ctx context.Context, issueKey string) (*Issue, error)
 
 	return &issue, nil
 }
+
+// doJSON sends a request with an optional JSON body to the given endpoint path and decodes
+// the JSON response into respBody (if non-nil) when the server answers with expectedStatus.
+// Non-matching status codes are translated into ErrMCPServerError/ErrMCPServerErrorUnparseable
+// exactly like the dedicated CreateIssue/SearchIssues/GetIssue methods.
+func (c *Client) doJSON(ctx context.Context, method, path string, reqBody interface{}, expectedStatus int, respBody interface{}, opName string) error {
+	var bodyReader io.Reader
+	var jsonData []byte
+	if reqBody != nil {
+		var err error
+		jsonData, err = json.Marshal(reqBody)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrRequestMarshal, err)
+		}
+		bodyReader = bytes.NewBuffer(jsonData)
+	}
+
+	// path may carry a query string (e.g. pagination parameters)
+	ref, err := url.Parse(path)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrRequestCreate, err)
+	}
+	endpointURL := c.BaseURL.ResolveReference(ref)
+
+	logEvent := log.Debug().Str("url", endpointURL.String()).Str("method", method)
+	if jsonData != nil {
+		logEvent = logEvent.RawJSON("request_body", jsonData)
+	}
+	logEvent.Msgf("Sending MCP %s request", opName)
+
+	req, err := http.NewRequestWithContext(ctx, method, endpointURL.String(), bodyReader)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrRequestCreate, err)
+	}
+	if jsonData != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrRequestExecute, err)
+	}
+	defer resp.Body.Close()
+
+	respBodyBytes, readErr := io.ReadAll(resp.Body)
+	if readErr != nil {
+		log.Warn().Err(readErr).Msg("Failed to read MCP response body")
+		return fmt.Errorf("%w: %w", ErrResponseDecode, readErr)
+	}
+	log.Debug().Int("status_code", resp.StatusCode).Bytes("response_body", respBodyBytes).Msgf("Received MCP %s response", opName)
+
+	if resp.StatusCode != expectedStatus {
+		var errResp ErrorResponse
+		var err error
+		if decodeErr := json.Unmarshal(respBodyBytes, &errResp); decodeErr == nil && errResp.Error != "" {
+			err = fmt.Errorf("%w: %s (status %d)", ErrMCPServerError, errResp.Error, resp.StatusCode)
+		} else {
+			err = fmt.Errorf("%w (status %d)", ErrMCPServerErrorUnparseable, resp.StatusCode)
+		}
+		if resp.StatusCode == http.StatusConflict {
+			return fmt.Errorf("%w: %w", ErrConflict, err)
+		}
+		return err
+	}
+
+	if respBody == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBodyBytes, respBody); err != nil {
+		return fmt.Errorf("%w: %w", ErrResponseDecode, err)
+	}
+	return nil
+}
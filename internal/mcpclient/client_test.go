@@ -1,15 +1,20 @@
 package mcpclient
 
 import (
-	"context"       // Added context import
+	"bytes"
+	"context" // Added context import
+	"crypto/tls"
 	"encoding/json" // Added for errors.Is
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/karolswdev/ticketron/internal/config" // Added config import
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -35,6 +40,25 @@ func TestNew(t *testing.T) {
 	assert.NotNil(t, client, "Client should not be nil")
 	assert.Equal(t, baseURL, client.BaseURL.String(), "BaseURL should match")
 	assert.NotNil(t, client.HTTPClient, "HTTPClient should be initialized")
+
+	custom := &http.Client{Timeout: time.Minute}
+	client, err = New(mockCfg, WithHTTPClient(custom))
+	require.NoError(t, err)
+	assert.Same(t, custom, client.HTTPClient, "WithHTTPClient should override the default HTTPClient")
+
+	tlsCfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	client, err = New(mockCfg, WithTLSConfig(tlsCfg))
+	require.NoError(t, err)
+	transport, ok := client.HTTPClient.Transport.(*http.Transport)
+	require.True(t, ok, "WithTLSConfig should install an *http.Transport")
+	assert.Same(t, tlsCfg, transport.TLSClientConfig)
+	assert.Equal(t, 10*time.Second, client.HTTPClient.Timeout, "WithTLSConfig keeps the default timeout")
+
+	shared := &http.Transport{}
+	client, err = New(mockCfg, WithTransport(shared))
+	require.NoError(t, err)
+	assert.Same(t, shared, client.HTTPClient.Transport)
+	assert.Equal(t, 10*time.Second, client.HTTPClient.Timeout, "WithTransport keeps the default timeout")
 }
 
 func TestCreateIssue(t *testing.T) {
@@ -80,6 +104,26 @@ func TestCreateIssue(t *testing.T) {
 		assert.Equal(t, expectedResp, *resp, "Response body mismatch")
 	})
 
+	t.Run("IdempotencyKey", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "3f1a9c", r.Header.Get(IdempotencyKeyHeader))
+			bodyBytes, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.NotContains(t, string(bodyBytes), "3f1a9c", "the key is not part of the body")
+			w.Header().Set(ReplayedHeader, "true")
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"key": "PROJ-7", "id": "10007", "self": "http://jira.example.com/browse/PROJ-7"}`)
+		}
+
+		server, client := setupMockServer(t, handler)
+		defer server.Close()
+
+		resp, err := client.CreateIssue(context.Background(), CreateIssueRequest{ProjectKey: "PROJ", Summary: "S", IssueType: "Task", IdempotencyKey: "3f1a9c"})
+		require.NoError(t, err)
+		assert.Equal(t, "PROJ-7", resp.Key)
+		assert.True(t, resp.Replayed)
+	})
+
 	t.Run("ClientError", func(t *testing.T) {
 		expectedReq := CreateIssueRequest{ProjectKey: "BAD"} // Minimal request for error test
 		expectedErrorMsg := "Missing projectKey"
@@ -238,6 +282,42 @@ func TestSearchIssues(t *testing.T) {
 		assert.Contains(t, err.Error(), expectedErrorMsg, "Error message should contain server error")
 		assert.Contains(t, err.Error(), "(status 500)", "Error message should contain status code")
 	})
+
+	t.Run("ResponseBodyLoggedOnlyAtTrace", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"total": 1, "issues": [{"key": "PROJ-1"}]}`)
+		}
+		server, client := setupMockServer(t, handler)
+		defer server.Close()
+
+		originalLogger := log.Logger
+		t.Cleanup(func() { log.Logger = originalLogger })
+		for _, level := range []zerolog.Level{zerolog.DebugLevel, zerolog.TraceLevel} {
+			var logs bytes.Buffer
+			log.Logger = zerolog.New(&logs).Level(level)
+
+			resp, err := client.SearchIssues(context.Background(), SearchIssuesRequest{JQL: "x"})
+			require.NoError(t, err)
+			assert.Equal(t, "PROJ-1", resp.Issues[0].Key)
+			assert.Contains(t, logs.String(), `"issues":1`)
+			if level == zerolog.TraceLevel {
+				assert.Contains(t, logs.String(), `"response_body"`)
+			} else {
+				assert.NotContains(t, logs.String(), `"response_body"`)
+			}
+		}
+	})
+
+	t.Run("DecodeError", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"issues": [`)
+		}
+		server, client := setupMockServer(t, handler)
+		defer server.Close()
+
+		_, err := client.SearchIssues(context.Background(), SearchIssuesRequest{JQL: "x"})
+		assert.ErrorIs(t, err, ErrResponseDecode)
+	})
 }
 
 func TestGetIssue(t *testing.T) {
@@ -300,6 +380,7 @@ func TestGetIssue(t *testing.T) {
 		assert.ErrorIs(t, err, ErrMCPServerError, "Error should be ErrMCPServerError")
 		assert.Contains(t, err.Error(), expectedErrorMsg, "Error message should contain server error")
 		assert.Contains(t, err.Error(), "(status 404)", "Error message should contain status code")
+		assert.ErrorIs(t, err, ErrNotFound)
 	})
 
 	t.Run("ServerError", func(t *testing.T) {
@@ -321,7 +402,181 @@ func TestGetIssue(t *testing.T) {
 		assert.ErrorIs(t, err, ErrMCPServerError, "Error should be ErrMCPServerError")
 		assert.Contains(t, err.Error(), expectedErrorMsg, "Error message should contain server error")
 		assert.Contains(t, err.Error(), "(status 500)", "Error message should contain status code")
+		assert.NotErrorIs(t, err, ErrNotFound)
 	})
 }
 
 // Removed TestParseErrorResponse as error handling is done within the client methods
+
+func TestAddComment(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/jira_issue/PROJ-1/comment", r.URL.Path)
+			var body AddCommentRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "Looks good", body.Body)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(Comment{ID: "100", Body: body.Body})
+		}
+		server, client := setupMockServer(t, handler)
+		defer server.Close()
+
+		comment, err := client.AddComment(context.Background(), "PROJ-1", AddCommentRequest{Body: "Looks good"})
+		require.NoError(t, err)
+		assert.Equal(t, "100", comment.ID)
+	})
+
+	t.Run("Server Error", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(ErrorResponse{Error: "issue not found"})
+		}
+		server, client := setupMockServer(t, handler)
+		defer server.Close()
+
+		_, err := client.AddComment(context.Background(), "PROJ-404", AddCommentRequest{Body: "x"})
+		assert.ErrorIs(t, err, ErrMCPServerError)
+		assert.ErrorContains(t, err, "issue not found")
+	})
+
+	t.Run("Missing Key", func(t *testing.T) {
+		client, err := New(&config.AppConfig{MCPServerURL: "http://localhost"})
+		require.NoError(t, err)
+		_, err = client.AddComment(context.Background(), " ", AddCommentRequest{Body: "x"})
+		assert.ErrorIs(t, err, ErrIssueKeyMissing)
+	})
+}
+
+func TestGetComments(t *testing.T) {
+	t.Run("Success With Pagination", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/jira_issue/PROJ-1/comment", r.URL.Path)
+			assert.Equal(t, "50", r.URL.Query().Get("startAt"))
+			assert.Equal(t, "25", r.URL.Query().Get("maxResults"))
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"startAt":50,"maxResults":25,"total":51,"comments":[{"id":"7","author":{"displayName":"Ada"},"body":"Done","created":"2025-04-01T10:00:00.000+0000"}]}`))
+		}
+		server, client := setupMockServer(t, handler)
+		defer server.Close()
+
+		page, err := client.GetComments(context.Background(), "PROJ-1", GetCommentsRequest{StartAt: 50, MaxResults: 25})
+		require.NoError(t, err)
+		assert.Equal(t, 51, page.Total)
+		require.Len(t, page.Comments, 1)
+		assert.Equal(t, "Ada", page.Comments[0].Author.DisplayName)
+		assert.Equal(t, "Done", page.Comments[0].Body)
+	})
+
+	t.Run("Defaults Omit Query", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Empty(t, r.URL.RawQuery)
+			_, _ = w.Write([]byte(`{"total":0,"comments":[]}`))
+		}
+		server, client := setupMockServer(t, handler)
+		defer server.Close()
+
+		page, err := client.GetComments(context.Background(), "PROJ-1", GetCommentsRequest{})
+		require.NoError(t, err)
+		assert.Empty(t, page.Comments)
+	})
+
+	t.Run("Server Error", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(ErrorResponse{Error: "issue not found"})
+		}
+		server, client := setupMockServer(t, handler)
+		defer server.Close()
+
+		_, err := client.GetComments(context.Background(), "PROJ-404", GetCommentsRequest{})
+		assert.ErrorIs(t, err, ErrMCPServerError)
+	})
+
+	t.Run("Missing Key", func(t *testing.T) {
+		client, err := New(&config.AppConfig{MCPServerURL: "http://localhost"})
+		require.NoError(t, err)
+		_, err = client.GetComments(context.Background(), "", GetCommentsRequest{})
+		assert.ErrorIs(t, err, ErrIssueKeyMissing)
+	})
+}
+
+func TestGetIssueChangelog(t *testing.T) {
+	t.Run("Success With Pagination", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/jira_issue/PROJ-1/changelog", r.URL.Path)
+			assert.Equal(t, "100", r.URL.Query().Get("startAt"))
+			assert.Equal(t, "50", r.URL.Query().Get("maxResults"))
+			_, _ = w.Write([]byte(`{"startAt":100,"maxResults":50,"total":101,"values":[{"id":"9","author":{"displayName":"Ada"},"created":"2025-04-01T10:00:00.000+0000","items":[{"field":"status","fromString":"To Do","toString":"In Progress"}]}]}`))
+		}
+		server, client := setupMockServer(t, handler)
+		defer server.Close()
+
+		page, err := client.GetIssueChangelog(context.Background(), "PROJ-1", GetChangelogRequest{StartAt: 100, MaxResults: 50})
+		require.NoError(t, err)
+		assert.Equal(t, 101, page.Total)
+		require.Len(t, page.Histories, 1)
+		assert.Equal(t, "Ada", page.Histories[0].Author.DisplayName)
+		assert.Equal(t, []ChangeItem{{Field: "status", FromString: "To Do", ToString: "In Progress"}}, page.Histories[0].Items)
+	})
+
+	t.Run("Server Error", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(ErrorResponse{Error: "issue not found"})
+		}
+		server, client := setupMockServer(t, handler)
+		defer server.Close()
+
+		_, err := client.GetIssueChangelog(context.Background(), "PROJ-404", GetChangelogRequest{})
+		assert.ErrorIs(t, err, ErrMCPServerError)
+	})
+
+	t.Run("Missing Key", func(t *testing.T) {
+		client, err := New(&config.AppConfig{MCPServerURL: "http://localhost"})
+		require.NoError(t, err)
+		_, err = client.GetIssueChangelog(context.Background(), "", GetChangelogRequest{})
+		assert.ErrorIs(t, err, ErrIssueKeyMissing)
+	})
+}
+
+func TestSearchUsers(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/jira_user/search", r.URL.Path)
+			assert.Equal(t, "ali ce", r.URL.Query().Get("query"))
+			assert.Equal(t, "5", r.URL.Query().Get("maxResults"))
+			_, _ = w.Write([]byte(`[{"accountId":"5b10a2844c20165700ede21g","displayName":"Alice Smith","emailAddress":"alice@example.com"}]`))
+		}
+		server, client := setupMockServer(t, handler)
+		defer server.Close()
+
+		users, err := client.SearchUsers(context.Background(), "ali ce", 5)
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		assert.Equal(t, "5b10a2844c20165700ede21g", users[0].AccountID)
+		assert.Equal(t, "Alice Smith", users[0].DisplayName)
+	})
+
+	t.Run("Server Error", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(ErrorResponse{Error: "browse users permission required"})
+		}
+		server, client := setupMockServer(t, handler)
+		defer server.Close()
+
+		_, err := client.SearchUsers(context.Background(), "alice", 0)
+		assert.ErrorIs(t, err, ErrMCPServerError)
+	})
+
+	t.Run("Missing Query", func(t *testing.T) {
+		client, err := New(&config.AppConfig{MCPServerURL: "http://localhost"})
+		require.NoError(t, err)
+		_, err = client.SearchUsers(context.Background(), " ", 0)
+		assert.ErrorIs(t, err, ErrUserQueryMissing)
+	})
+}
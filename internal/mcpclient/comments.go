@@ -0,0 +1,51 @@
+package mcpclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// AddComment sends a POST request to the MCP server's /jira_issue/{issueKey}/comment endpoint
+// to add a comment to an existing Jira issue.
+// It returns the created Comment or an error if the request or decoding fails,
+// or if the server returns a non-201 status code.
+func (c *Client) AddComment(ctx context.Context, issueKey string, reqBody AddCommentRequest) (*Comment, error) {
+	if strings.TrimSpace(issueKey) == "" {
+		return nil, ErrIssueKeyMissing
+	}
+	var comment Comment
+	path := fmt.Sprintf("/jira_issue/%s/comment", url.PathEscape(issueKey))
+	if err := c.doJSON(ctx, http.MethodPost, path, reqBody, http.StatusCreated, &comment, "AddComment"); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// GetComments sends a GET request to the MCP server's /jira_issue/{issueKey}/comment endpoint
+// and returns one page of the issue's comments, oldest first.
+// It returns an error if the request or decoding fails, or if the server returns a non-200 status code.
+func (c *Client) GetComments(ctx context.Context, issueKey string, req GetCommentsRequest) (*CommentsResponse, error) {
+	if strings.TrimSpace(issueKey) == "" {
+		return nil, ErrIssueKeyMissing
+	}
+	query := url.Values{}
+	if req.StartAt > 0 {
+		query.Set("startAt", strconv.Itoa(req.StartAt))
+	}
+	if req.MaxResults > 0 {
+		query.Set("maxResults", strconv.Itoa(req.MaxResults))
+	}
+	path := fmt.Sprintf("/jira_issue/%s/comment", url.PathEscape(issueKey))
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	var page CommentsResponse
+	if err := c.doJSON(ctx, http.MethodGet, path, nil, http.StatusOK, &page, "GetComments"); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
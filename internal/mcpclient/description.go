@@ -0,0 +1,59 @@
+package mcpclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/karolswdev/ticketron/internal/markdown"
+)
+
+// DescriptionFormat selects how issue descriptions, which the LLM writes in Markdown, are sent to Jira.
+type DescriptionFormat string
+
+const (
+	// DescriptionFormatText sends the description unchanged.
+	DescriptionFormatText DescriptionFormat = "text"
+	// DescriptionFormatWiki converts the description to Jira wiki markup (Jira Server and Data Center).
+	DescriptionFormatWiki DescriptionFormat = "wiki"
+	// DescriptionFormatADF converts the description to an Atlassian Document Format JSON document (Jira Cloud).
+	DescriptionFormatADF DescriptionFormat = "adf"
+)
+
+// DescriptionFormats lists the supported description formats.
+var DescriptionFormats = []DescriptionFormat{DescriptionFormatText, DescriptionFormatWiki, DescriptionFormatADF}
+
+// ParseDescriptionFormat parses a format name (case-insensitive). An empty name means text.
+func ParseDescriptionFormat(name string) (DescriptionFormat, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return DescriptionFormatText, nil
+	}
+	for _, format := range DescriptionFormats {
+		if name == string(format) {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q (expected text, wiki or adf)", ErrDescriptionFormatInvalid, name)
+}
+
+// ConvertDescription converts a Markdown description to format. Text, the empty format and
+// empty descriptions are returned unchanged.
+func ConvertDescription(description string, format DescriptionFormat) (string, error) {
+	if strings.TrimSpace(description) == "" {
+		return description, nil
+	}
+	switch format {
+	case "", DescriptionFormatText:
+		return description, nil
+	case DescriptionFormatWiki:
+		return markdown.ToWiki(description), nil
+	case DescriptionFormatADF:
+		data, err := json.Marshal(markdown.ToADF(description))
+		if err != nil {
+			return "", fmt.Errorf("%w: %w", ErrRequestMarshal, err)
+		}
+		return string(data), nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrDescriptionFormatInvalid, format)
+}
@@ -0,0 +1,64 @@
+package mcpclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDescriptionFormat(t *testing.T) {
+	format, err := ParseDescriptionFormat("")
+	require.NoError(t, err)
+	assert.Equal(t, DescriptionFormatText, format)
+
+	format, err = ParseDescriptionFormat(" ADF ")
+	require.NoError(t, err)
+	assert.Equal(t, DescriptionFormatADF, format)
+
+	_, err = ParseDescriptionFormat("html")
+	assert.ErrorIs(t, err, ErrDescriptionFormatInvalid)
+}
+
+func TestConvertDescription(t *testing.T) {
+	for _, format := range []DescriptionFormat{"", DescriptionFormatText} {
+		converted, err := ConvertDescription("**bold**", format)
+		require.NoError(t, err)
+		assert.Equal(t, "**bold**", converted)
+	}
+
+	converted, err := ConvertDescription("**bold**", DescriptionFormatWiki)
+	require.NoError(t, err)
+	assert.Equal(t, "*bold*", converted)
+
+	converted, err = ConvertDescription("**bold**", DescriptionFormatADF)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"bold","marks":[{"type":"strong"}]}]}]}`, converted)
+
+	converted, err = ConvertDescription("  ", DescriptionFormatADF)
+	require.NoError(t, err)
+	assert.Equal(t, "  ", converted, "empty descriptions are not converted")
+
+	_, err = ConvertDescription("x", "html")
+	assert.ErrorIs(t, err, ErrDescriptionFormatInvalid)
+}
+
+func TestCreateIssue_ConvertsDescription(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		var req CreateIssueRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, DescriptionFormatWiki, req.DescriptionFormat)
+		assert.Equal(t, "h1. Title\n\n* item", req.Description)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"key":"PROJ-1"}`))
+	}
+	server, client := setupMockServer(t, handler)
+	defer server.Close()
+
+	resp, err := client.CreateIssue(context.Background(), CreateIssueRequest{ProjectKey: "PROJ", Description: "# Title\n- item", DescriptionFormat: DescriptionFormatWiki})
+	require.NoError(t, err)
+	assert.Equal(t, "PROJ-1", resp.Key)
+}
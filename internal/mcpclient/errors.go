@@ -29,3 +29,25 @@ var ErrMCPServerError = errors.New("MCP server returned an error")
 // ErrMCPServerErrorUnparseable indicates the MCP server returned a non-2xx status code,
 // but the error response body could not be parsed or was empty.
 var ErrMCPServerErrorUnparseable = errors.New("MCP server returned an unparseable error")
+
+// ErrConflict indicates the MCP server rejected a conditional update with 409 Conflict
+// because the issue was changed after it was fetched. It wraps ErrMCPServerError or
+// ErrMCPServerErrorUnparseable like other error statuses.
+var ErrConflict = errors.New("the issue was changed by someone else")
+
+// ErrNotFound indicates the MCP server answered 404 Not Found, for example because the
+// requested issue does not exist. It wraps ErrMCPServerError or ErrMCPServerErrorUnparseable
+// like other error statuses.
+var ErrNotFound = errors.New("not found")
+
+// ErrIssueKeyMissing indicates an operation on a specific issue was called without an issue key.
+var ErrIssueKeyMissing = errors.New("issue key is required")
+
+// ErrProjectKeyMissing indicates an operation on a specific project was called without a project key.
+var ErrProjectKeyMissing = errors.New("project key is required")
+
+// ErrUserQueryMissing indicates a user search was called without a query.
+var ErrUserQueryMissing = errors.New("user search query is required")
+
+// ErrDescriptionFormatInvalid indicates an unknown description format was requested.
+var ErrDescriptionFormatInvalid = errors.New("invalid description format")
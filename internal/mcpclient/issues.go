@@ -0,0 +1,67 @@
+package mcpclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DeleteIssue sends a DELETE request to the MCP server's /jira_issue/{issueKey} endpoint
+// to permanently delete a Jira issue.
+// It returns an error if the request fails or the server returns a non-204 status code.
+func (c *Client) DeleteIssue(ctx context.Context, issueKey string) error {
+	if strings.TrimSpace(issueKey) == "" {
+		return ErrIssueKeyMissing
+	}
+	path := fmt.Sprintf("/jira_issue/%s", url.PathEscape(issueKey))
+	return c.doJSON(ctx, http.MethodDelete, path, nil, http.StatusNoContent, nil, "DeleteIssue")
+}
+
+// TransitionIssue sends a POST request to the MCP server's /jira_issue/{issueKey}/transitions
+// endpoint to move an issue through its workflow, e.g. to "Done" or "Cancelled".
+// It returns an error if the request fails or the server returns a non-204 status code.
+func (c *Client) TransitionIssue(ctx context.Context, issueKey string, reqBody TransitionIssueRequest) error {
+	if strings.TrimSpace(issueKey) == "" {
+		return ErrIssueKeyMissing
+	}
+	path := fmt.Sprintf("/jira_issue/%s/transitions", url.PathEscape(issueKey))
+	return c.doJSON(ctx, http.MethodPost, path, reqBody, http.StatusNoContent, nil, "TransitionIssue")
+}
+
+// UpdateIssue sends a PUT request to the MCP server's /jira_issue/{issueKey} endpoint to
+// edit an existing issue, either by setting fields or applying add/remove operations.
+// It returns an error if the request fails or the server returns a non-204 status code.
+func (c *Client) UpdateIssue(ctx context.Context, issueKey string, reqBody UpdateIssueRequest) error {
+	if strings.TrimSpace(issueKey) == "" {
+		return ErrIssueKeyMissing
+	}
+	path := fmt.Sprintf("/jira_issue/%s", url.PathEscape(issueKey))
+	return c.doJSON(ctx, http.MethodPut, path, reqBody, http.StatusNoContent, nil, "UpdateIssue")
+}
+
+// GetCreateMeta sends a GET request to the MCP server's /jira_project/{projectKey}/createmeta
+// endpoint to retrieve the issue types and fields available when creating issues in a project.
+// It returns an error if the request or decoding fails, or if the server returns a non-200 status code.
+func (c *Client) GetCreateMeta(ctx context.Context, projectKey string) (*CreateMeta, error) {
+	if strings.TrimSpace(projectKey) == "" {
+		return nil, ErrProjectKeyMissing
+	}
+	var meta CreateMeta
+	path := fmt.Sprintf("/jira_project/%s/createmeta", url.PathEscape(projectKey))
+	if err := c.doJSON(ctx, http.MethodGet, path, nil, http.StatusOK, &meta, "GetCreateMeta"); err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+// LinkIssues sends a POST request to the MCP server's /jira_issue_link endpoint to link
+// two existing issues.
+// It returns an error if the request fails or the server returns a non-201 status code.
+func (c *Client) LinkIssues(ctx context.Context, reqBody LinkIssuesRequest) error {
+	if strings.TrimSpace(reqBody.InwardIssue) == "" || strings.TrimSpace(reqBody.OutwardIssue) == "" {
+		return ErrIssueKeyMissing
+	}
+	return c.doJSON(ctx, http.MethodPost, "/jira_issue_link", reqBody, http.StatusCreated, nil, "LinkIssues")
+}
@@ -0,0 +1,186 @@
+package mcpclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteIssue(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodDelete, r.Method)
+			assert.Equal(t, "/jira_issue/PROJ-1", r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+		server, client := setupMockServer(t, handler)
+		defer server.Close()
+
+		require.NoError(t, client.DeleteIssue(context.Background(), "PROJ-1"))
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(ErrorResponse{Error: "no permission to delete issues"})
+		}
+		server, client := setupMockServer(t, handler)
+		defer server.Close()
+
+		err := client.DeleteIssue(context.Background(), "PROJ-1")
+		assert.ErrorIs(t, err, ErrMCPServerError)
+		assert.ErrorContains(t, err, "no permission to delete issues")
+	})
+
+	t.Run("Missing Key", func(t *testing.T) {
+		server, client := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {})
+		defer server.Close()
+		assert.ErrorIs(t, client.DeleteIssue(context.Background(), ""), ErrIssueKeyMissing)
+	})
+}
+
+func TestIssuePathsEscapeKeys(t *testing.T) {
+	var paths []string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+		switch r.Method {
+		case http.MethodGet:
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}
+	server, client := setupMockServer(t, handler)
+	defer server.Close()
+
+	ctx := context.Background()
+	key := "../admin?x=1"
+	_ = client.DeleteIssue(ctx, key)
+	_ = client.TransitionIssue(ctx, key, TransitionIssueRequest{Transition: "Done"})
+	_, _ = client.GetIssue(ctx, key)
+	_, _ = client.GetComments(ctx, key, GetCommentsRequest{})
+	_, _ = client.GetCreateMeta(ctx, "A/B")
+	assert.Equal(t, []string{
+		"/jira_issue/..%2Fadmin%3Fx=1",
+		"/jira_issue/..%2Fadmin%3Fx=1/transitions",
+		"/jira_issue/..%2Fadmin%3Fx=1",
+		"/jira_issue/..%2Fadmin%3Fx=1/comment",
+		"/jira_project/A%2FB/createmeta",
+	}, paths)
+}
+
+func TestTransitionIssue(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/jira_issue/PROJ-2/transitions", r.URL.Path)
+			var body TransitionIssueRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, TransitionIssueRequest{Transition: "Cancelled", Comment: "Duplicate"}, body)
+			w.WriteHeader(http.StatusNoContent)
+		}
+		server, client := setupMockServer(t, handler)
+		defer server.Close()
+
+		require.NoError(t, client.TransitionIssue(context.Background(), "PROJ-2", TransitionIssueRequest{Transition: "Cancelled", Comment: "Duplicate"}))
+	})
+
+	t.Run("Unknown Transition", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(ErrorResponse{Error: "transition 'Cancelled' not available"})
+		}
+		server, client := setupMockServer(t, handler)
+		defer server.Close()
+
+		err := client.TransitionIssue(context.Background(), "PROJ-2", TransitionIssueRequest{Transition: "Cancelled"})
+		assert.ErrorIs(t, err, ErrMCPServerError)
+	})
+}
+
+func TestUpdateIssue(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/jira_issue/PROJ-3", r.URL.Path)
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]interface{}{
+			"fields": map[string]interface{}{"priority": map[string]interface{}{"name": "High"}},
+			"update": map[string]interface{}{"labels": []interface{}{map[string]interface{}{"add": "triaged"}}},
+		}, body)
+		w.WriteHeader(http.StatusNoContent)
+	}
+	server, client := setupMockServer(t, handler)
+	defer server.Close()
+
+	err := client.UpdateIssue(context.Background(), "PROJ-3", UpdateIssueRequest{
+		Fields: map[string]interface{}{"priority": map[string]string{"name": "High"}},
+		Update: map[string][]FieldOperation{"labels": {{Add: "triaged"}}},
+	})
+	require.NoError(t, err)
+}
+
+func TestUpdateIssue_Conflict(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		var body UpdateIssueRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "2025-04-01T10:00:00.000+0000", body.ExpectedUpdated)
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"error":"issue PROJ-3 was updated at 2025-04-01T10:05:00.000+0000"}`))
+	}
+	server, client := setupMockServer(t, handler)
+	defer server.Close()
+
+	err := client.UpdateIssue(context.Background(), "PROJ-3", UpdateIssueRequest{
+		Fields:          map[string]interface{}{"summary": "New"},
+		ExpectedUpdated: "2025-04-01T10:00:00.000+0000",
+	})
+	assert.ErrorIs(t, err, ErrConflict)
+	assert.ErrorIs(t, err, ErrMCPServerError)
+	assert.ErrorContains(t, err, "was updated at 2025-04-01T10:05:00.000+0000")
+}
+
+func TestGetCreateMeta(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/jira_project/BE/createmeta", r.URL.Path)
+		_, _ = w.Write([]byte(`{"projectKey":"BE","issueTypes":[{"id":"1","name":"Bug","fields":[
+			{"id":"summary","name":"Summary","required":true,"schema":"string"},
+			{"id":"priority","name":"Priority","required":false,"schema":"priority","allowedValues":["High","Low"]}]}]}`))
+	}
+	server, client := setupMockServer(t, handler)
+	defer server.Close()
+
+	meta, err := client.GetCreateMeta(context.Background(), "BE")
+	require.NoError(t, err)
+	require.Len(t, meta.IssueTypes, 1)
+	assert.Equal(t, "Bug", meta.IssueTypes[0].Name)
+	assert.Equal(t, FieldMeta{ID: "priority", Name: "Priority", Schema: "priority", AllowedValues: []string{"High", "Low"}}, meta.IssueTypes[0].Fields[1])
+
+	_, err = client.GetCreateMeta(context.Background(), "")
+	assert.ErrorIs(t, err, ErrProjectKeyMissing)
+}
+
+func TestLinkIssues(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/jira_issue_link", r.URL.Path)
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]interface{}{"type": "Relates", "inwardIssue": "PROJ-88", "outwardIssue": "PROJ-90"}, body)
+		w.WriteHeader(http.StatusCreated)
+	}
+	server, client := setupMockServer(t, handler)
+	defer server.Close()
+
+	err := client.LinkIssues(context.Background(), LinkIssuesRequest{Type: "Relates", InwardIssue: "PROJ-88", OutwardIssue: "PROJ-90"})
+	require.NoError(t, err)
+
+	err = client.LinkIssues(context.Background(), LinkIssuesRequest{Type: "Relates", InwardIssue: "PROJ-88"})
+	assert.ErrorIs(t, err, ErrIssueKeyMissing)
+}
@@ -0,0 +1,134 @@
+package mockserver
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+// The contract suite exercises every mcpclient.Client method. It runs against the mock
+// server by default; set TICKETRON_CONTRACT_MCP_URL (and optionally TICKETRON_CONTRACT_PROJECT)
+// to run it against a real MCP server. Issues created by the suite are deleted again.
+const (
+	envContractURL     = "TICKETRON_CONTRACT_MCP_URL"
+	envContractProject = "TICKETRON_CONTRACT_PROJECT"
+)
+
+// contractClient returns a client for the server under test and the project to create issues in.
+func contractClient(t *testing.T) (*mcpclient.Client, string) {
+	t.Helper()
+	baseURL := os.Getenv(envContractURL)
+	projectKey := os.Getenv(envContractProject)
+	if projectKey == "" {
+		projectKey = "TEST"
+	}
+	if baseURL == "" {
+		server, err := New(Options{})
+		require.NoError(t, err)
+		httpServer := httptest.NewServer(server)
+		t.Cleanup(httpServer.Close)
+		baseURL = httpServer.URL
+	}
+	client, err := mcpclient.New(&config.AppConfig{MCPServerURL: baseURL})
+	require.NoError(t, err)
+	return client, projectKey
+}
+
+func TestContract(t *testing.T) {
+	client, projectKey := contractClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	meta, err := client.GetCreateMeta(ctx, projectKey)
+	require.NoError(t, err, "GetCreateMeta")
+	assert.Equal(t, projectKey, meta.ProjectKey)
+	require.NotEmpty(t, meta.IssueTypes, "project must have at least one issue type")
+	issueType := meta.IssueTypes[0].Name
+
+	created, err := client.CreateIssue(ctx, mcpclient.CreateIssueRequest{
+		ProjectKey:  projectKey,
+		Summary:     "Contract test issue",
+		Description: "Created by the ticketron contract test suite.",
+		IssueType:   issueType,
+	})
+	require.NoError(t, err, "CreateIssue")
+	require.NotEmpty(t, created.Key)
+	assert.NotEmpty(t, created.ID)
+	assert.NotEmpty(t, created.Self)
+	t.Cleanup(func() { _ = client.DeleteIssue(context.Background(), created.Key) })
+
+	issue, err := client.GetIssue(ctx, created.Key)
+	require.NoError(t, err, "GetIssue")
+	assert.Equal(t, created.Key, issue.Key)
+	assert.Equal(t, "Contract test issue", issue.Fields.Summary)
+	assert.Equal(t, issueType, issue.Fields.IssueType.Name)
+	assert.NotEmpty(t, issue.Fields.Status.Name)
+
+	err = client.UpdateIssue(ctx, created.Key, mcpclient.UpdateIssueRequest{
+		Fields: map[string]interface{}{"summary": "Contract test issue (updated)"},
+	})
+	require.NoError(t, err, "UpdateIssue")
+	issue, err = client.GetIssue(ctx, created.Key)
+	require.NoError(t, err)
+	assert.Equal(t, "Contract test issue (updated)", issue.Fields.Summary)
+
+	search, err := client.SearchIssues(ctx, mcpclient.SearchIssuesRequest{JQL: "key = " + created.Key})
+	require.NoError(t, err, "SearchIssues")
+	require.Len(t, search.Issues, 1)
+	assert.Equal(t, 1, search.Total)
+	assert.Equal(t, created.Key, search.Issues[0].Key)
+
+	comment, err := client.AddComment(ctx, created.Key, mcpclient.AddCommentRequest{Body: "First contract comment"})
+	require.NoError(t, err, "AddComment")
+	assert.NotEmpty(t, comment.ID)
+	assert.Equal(t, "First contract comment", comment.Body)
+
+	comments, err := client.GetComments(ctx, created.Key, mcpclient.GetCommentsRequest{})
+	require.NoError(t, err, "GetComments")
+	require.Len(t, comments.Comments, 1)
+	assert.Equal(t, comment.ID, comments.Comments[0].ID)
+
+	err = client.TransitionIssue(ctx, created.Key, mcpclient.TransitionIssueRequest{Transition: "Done"})
+	require.NoError(t, err, "TransitionIssue")
+	issue, err = client.GetIssue(ctx, created.Key)
+	require.NoError(t, err)
+	assert.Equal(t, "Done", issue.Fields.Status.Name)
+
+	changelog, err := client.GetIssueChangelog(ctx, created.Key, mcpclient.GetChangelogRequest{})
+	require.NoError(t, err, "GetIssueChangelog")
+	require.NotEmpty(t, changelog.Histories)
+	last := changelog.Histories[len(changelog.Histories)-1]
+	assert.Contains(t, last.Items, mcpclient.ChangeItem{Field: "status", FromString: "To Do", ToString: "Done"})
+
+	_, err = client.SearchUsers(ctx, "a", 5)
+	require.NoError(t, err, "SearchUsers")
+
+	related, err := client.CreateIssue(ctx, mcpclient.CreateIssueRequest{
+		ProjectKey: projectKey,
+		Summary:    "Contract test related issue",
+		IssueType:  issueType,
+	})
+	require.NoError(t, err, "CreateIssue")
+	t.Cleanup(func() { _ = client.DeleteIssue(context.Background(), related.Key) })
+	err = client.LinkIssues(ctx, mcpclient.LinkIssuesRequest{Type: "Relates", InwardIssue: related.Key, OutwardIssue: created.Key})
+	require.NoError(t, err, "LinkIssues")
+	issue, err = client.GetIssue(ctx, created.Key)
+	require.NoError(t, err)
+	require.Len(t, issue.Fields.IssueLinks, 1)
+	assert.Equal(t, related.Key, issue.Fields.IssueLinks[0].InwardIssue)
+
+	require.NoError(t, client.DeleteIssue(ctx, created.Key), "DeleteIssue")
+	_, err = client.GetIssue(ctx, created.Key)
+	assert.ErrorIs(t, err, mcpclient.ErrMCPServerError, "a deleted issue must not be found")
+
+	err = client.TransitionIssue(ctx, created.Key, mcpclient.TransitionIssueRequest{Transition: "Done"})
+	assert.ErrorIs(t, err, mcpclient.ErrMCPServerError)
+}
@@ -0,0 +1,14 @@
+package mockserver
+
+import "errors"
+
+// Sentinel errors for the mock MCP server.
+
+// ErrInvalidErrorRate indicates Options.ErrorRate is outside the range [0, 1].
+var ErrInvalidErrorRate = errors.New("error rate must be between 0 and 1")
+
+// ErrInvalidLatency indicates Options.Latency or Options.Jitter is negative.
+var ErrInvalidLatency = errors.New("latency and jitter must not be negative")
+
+// ErrInvalidErrorStatus indicates Options.ErrorStatus is not a 4xx or 5xx status code.
+var ErrInvalidErrorStatus = errors.New("error status must be a 4xx or 5xx status code")
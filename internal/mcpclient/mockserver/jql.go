@@ -0,0 +1,342 @@
+package mockserver
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+// errJQLSyntax indicates a search query could not be parsed.
+var errJQLSyntax = errors.New("invalid JQL")
+
+// orderByRe finds the trailing ORDER BY clause, which the mock ignores.
+var orderByRe = regexp.MustCompile(`(?i)\s*\border\s+by\b.*$`)
+
+// predicate reports whether an issue matches (part of) a query.
+type predicate func(*mcpclient.Issue) bool
+
+// matchAll is used for clauses the mock does not understand, so that every
+// query tix builds is accepted instead of failing against the mock.
+func matchAll(*mcpclient.Issue) bool { return true }
+
+// parseJQL compiles the subset of JQL the mock evaluates: AND, OR, NOT and parentheses
+// over =, !=, ~, !~, IN and NOT IN comparisons of project, key, status, issuetype,
+// summary, description and text. Other fields and operators match every issue.
+func parseJQL(jql string) (predicate, error) {
+	jql = orderByRe.ReplaceAllString(jql, "")
+	tokens, err := tokenizeJQL(jql)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return matchAll, nil
+	}
+	p := &jqlParser{tokens: tokens}
+	pred, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("%w: unexpected %q", errJQLSyntax, p.peek().text)
+	}
+	return pred, nil
+}
+
+// jqlToken is a lexical element of a query. Quoted strings are never keywords.
+type jqlToken struct {
+	text   string
+	quoted bool
+}
+
+// is reports whether the token is the given unquoted keyword or symbol (case-insensitive).
+func (t jqlToken) is(keyword string) bool {
+	return !t.quoted && strings.EqualFold(t.text, keyword)
+}
+
+// tokenizeJQL splits a query into words, quoted strings, parentheses, commas and operators.
+func tokenizeJQL(jql string) ([]jqlToken, error) {
+	var tokens []jqlToken
+	runes := []rune(jql)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')' || r == ',':
+			tokens = append(tokens, jqlToken{text: string(r)})
+			i++
+		case r == '"' || r == '\'':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != r; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) {
+					j++
+				}
+				b.WriteRune(runes[j])
+			}
+			if j >= len(runes) {
+				return nil, fmt.Errorf("%w: unterminated string", errJQLSyntax)
+			}
+			tokens = append(tokens, jqlToken{text: b.String(), quoted: true})
+			i = j + 1
+		case strings.ContainsRune("=!~<>", r):
+			j := i + 1
+			if j < len(runes) && strings.ContainsRune("=~", runes[j]) {
+				j++
+			}
+			tokens = append(tokens, jqlToken{text: string(runes[i:j])})
+			i = j
+		default:
+			j := i
+			for j < len(runes) && !unicode.IsSpace(runes[j]) && !strings.ContainsRune("(),=!~<>\"'", runes[j]) {
+				j++
+			}
+			tokens = append(tokens, jqlToken{text: string(runes[i:j])})
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+// jqlParser is a recursive-descent parser over the token stream.
+type jqlParser struct {
+	tokens []jqlToken
+	pos    int
+}
+
+func (p *jqlParser) done() bool { return p.pos >= len(p.tokens) }
+
+func (p *jqlParser) peek() jqlToken {
+	if p.done() {
+		return jqlToken{}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *jqlParser) next() (jqlToken, error) {
+	if p.done() {
+		return jqlToken{}, fmt.Errorf("%w: unexpected end of query", errJQLSyntax)
+	}
+	t := p.tokens[p.pos]
+	p.pos++
+	return t, nil
+}
+
+func (p *jqlParser) expect(symbol string) error {
+	t, err := p.next()
+	if err != nil {
+		return err
+	}
+	if !t.is(symbol) {
+		return fmt.Errorf("%w: expected %q, got %q", errJQLSyntax, symbol, t.text)
+	}
+	return nil
+}
+
+func (p *jqlParser) parseOr() (predicate, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(i *mcpclient.Issue) bool { return l(i) || right(i) }
+	}
+	return left, nil
+}
+
+func (p *jqlParser) parseAnd() (predicate, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is("and") {
+		p.pos++
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(i *mcpclient.Issue) bool { return l(i) && right(i) }
+	}
+	return left, nil
+}
+
+func (p *jqlParser) parseNot() (predicate, error) {
+	if p.peek().is("not") {
+		p.pos++
+		inner, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return func(i *mcpclient.Issue) bool { return !inner(i) }, nil
+	}
+	if p.peek().is("(") {
+		p.pos++
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return inner, p.expect(")")
+	}
+	return p.parseComparison()
+}
+
+// parseComparison parses "field op value" or "field [NOT] IN (values)".
+func (p *jqlParser) parseComparison() (predicate, error) {
+	field, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	op, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	opText := strings.ToLower(op.text)
+	switch {
+	case op.is("not") && p.peek().is("in"):
+		p.pos++
+		opText = "not in"
+	case op.is("is") && p.peek().is("not"):
+		p.pos++
+		opText = "is not"
+	}
+
+	var values []string
+	if opText == "in" || opText == "not in" {
+		values, err = p.parseList()
+	} else {
+		var value string
+		value, err = p.parseValue()
+		values = []string{value}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return comparison(strings.ToLower(field.text), opText, values), nil
+}
+
+// parseList parses a parenthesized, comma-separated list of values.
+func (p *jqlParser) parseList() ([]string, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var values []string
+	for {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		t, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if t.is(")") {
+			return values, nil
+		}
+		if !t.is(",") {
+			return nil, fmt.Errorf("%w: expected \",\" or \")\", got %q", errJQLSyntax, t.text)
+		}
+	}
+}
+
+// parseValue parses a word, a quoted string or a function call such as currentUser().
+func (p *jqlParser) parseValue() (string, error) {
+	t, err := p.next()
+	if err != nil {
+		return "", err
+	}
+	if t.quoted || !p.peek().is("(") {
+		return t.text, nil
+	}
+	// Function call: skip the (possibly nested) argument list.
+	depth := 0
+	for {
+		arg, err := p.next()
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case arg.is("("):
+			depth++
+		case arg.is(")"):
+			depth--
+		}
+		if depth == 0 {
+			return t.text + "()", nil
+		}
+	}
+}
+
+// comparison builds the predicate for a single clause.
+func comparison(field, op string, values []string) predicate {
+	get := issueField(field)
+	if get == nil {
+		return matchAll
+	}
+	switch op {
+	case "=", "in":
+		return func(i *mcpclient.Issue) bool { return equalsAny(get(i), values) }
+	case "!=", "not in":
+		return func(i *mcpclient.Issue) bool { return !equalsAny(get(i), values) }
+	case "~":
+		return func(i *mcpclient.Issue) bool { return containsFold(get(i), values[0]) }
+	case "!~":
+		return func(i *mcpclient.Issue) bool { return !containsFold(get(i), values[0]) }
+	default:
+		return matchAll
+	}
+}
+
+// issueField returns the accessor for a JQL field, or nil if the mock does not track it.
+func issueField(field string) func(*mcpclient.Issue) string {
+	switch field {
+	case "project":
+		return func(i *mcpclient.Issue) string { return projectOf(i.Key) }
+	case "key", "issuekey", "id":
+		return func(i *mcpclient.Issue) string { return i.Key }
+	case "status":
+		return func(i *mcpclient.Issue) string { return i.Fields.Status.Name }
+	case "issuetype", "type":
+		return func(i *mcpclient.Issue) string { return i.Fields.IssueType.Name }
+	case "summary":
+		return func(i *mcpclient.Issue) string { return i.Fields.Summary }
+	case "description":
+		return func(i *mcpclient.Issue) string { return i.Fields.Description }
+	case "text":
+		return func(i *mcpclient.Issue) string { return i.Fields.Summary + "\n" + i.Fields.Description }
+	default:
+		return nil
+	}
+}
+
+func equalsAny(actual string, values []string) bool {
+	for _, v := range values {
+		if strings.EqualFold(actual, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// projectOf returns the project key part of an issue key ("PROJ" for "PROJ-12").
+func projectOf(issueKey string) string {
+	if idx := strings.LastIndex(issueKey, "-"); idx > 0 {
+		return issueKey[:idx]
+	}
+	return issueKey
+}
@@ -0,0 +1,690 @@
+// Package mockserver implements the MCP server HTTP API used by mcpclient on top of an
+// in-memory issue store, so end-to-end flows can run without a real Jira instance.
+// Latency and failures can be injected to exercise timeouts, retries and error reporting.
+package mockserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+// DefaultErrorStatus is the status code of injected failures when Options.ErrorStatus is zero.
+const DefaultErrorStatus = http.StatusInternalServerError
+
+// defaultPageSize is used by search and comment listing when maxResults is not given.
+const defaultPageSize = 50
+
+// firstIssueID is the numeric ID of the first issue created by a server.
+const firstIssueID = 10000
+
+// jiraTimeFormat is the timestamp layout Jira uses for created/updated fields.
+const jiraTimeFormat = "2006-01-02T15:04:05.000-0700"
+
+// DefaultIssueTypes are the issue types of every project when Options.IssueTypes is empty.
+var DefaultIssueTypes = []string{"Task", "Bug", "Story", "Epic"}
+
+// Statuses are the workflow statuses issues can be transitioned to. New issues start in the first one.
+var Statuses = []string{"To Do", "In Progress", "Done", "Cancelled"}
+
+// resolvedStatuses are the statuses in which issues have a resolution date.
+var resolvedStatuses = []string{"Done", "Cancelled"}
+
+// DefaultUsers are returned by the user search when Options.Users is nil. The first user
+// is the author of comments added through the API.
+var DefaultUsers = []mcpclient.User{
+	{AccountID: "mock-1", DisplayName: "Mock User", EmailAddress: "mock.user@example.com"},
+	{AccountID: "mock-2", DisplayName: "Jane Doe", EmailAddress: "jane.doe@example.com"},
+	{AccountID: "mock-3", DisplayName: "John Smith", EmailAddress: "john.smith@example.com"},
+}
+
+// Options configures a mock server. The zero value serves every request immediately and never fails.
+type Options struct {
+	// Latency delays every response.
+	Latency time.Duration
+	// Jitter adds a random delay of up to Jitter on top of Latency.
+	Jitter time.Duration
+	// ErrorRate is the probability (0 to 1) that a request fails with ErrorStatus.
+	ErrorRate float64
+	// ErrorStatus is the status code of injected failures; DefaultErrorStatus when zero.
+	ErrorStatus int
+	// Seed seeds the random source of jitter and injected failures; a random seed is used when zero.
+	Seed uint64
+	// Projects restricts the known project keys; any project key is accepted when empty.
+	Projects []string
+	// IssueTypes are the issue types of every project; DefaultIssueTypes when empty.
+	IssueTypes []string
+	// Users are returned by the user search; DefaultUsers when nil.
+	Users []mcpclient.User
+}
+
+// Validate checks that the options are within range.
+func (o Options) Validate() error {
+	if o.ErrorRate < 0 || o.ErrorRate > 1 {
+		return fmt.Errorf("%w: %v", ErrInvalidErrorRate, o.ErrorRate)
+	}
+	if o.Latency < 0 || o.Jitter < 0 {
+		return ErrInvalidLatency
+	}
+	if o.ErrorStatus != 0 && (o.ErrorStatus < 400 || o.ErrorStatus > 599) {
+		return fmt.Errorf("%w: %d", ErrInvalidErrorStatus, o.ErrorStatus)
+	}
+	return nil
+}
+
+// storedIssue is an issue together with its comments and change history.
+type storedIssue struct {
+	issue     mcpclient.Issue
+	comments  []mcpclient.Comment
+	changelog []mcpclient.ChangeHistory
+}
+
+// Server is an in-memory MCP server. It is safe for concurrent use.
+type Server struct {
+	opts    Options
+	handler http.Handler
+
+	mu        sync.Mutex
+	rng       *rand.Rand
+	issues    map[string]*storedIssue
+	order     []string       // issue keys in creation order
+	counters  map[string]int // last issue number per project
+	nextID    int
+	commentID int
+	historyID int
+	// lastUpdate is the latest updated timestamp given to an issue, so that every edit
+	// gets a distinct one even within the same millisecond.
+	lastUpdate time.Time
+	// idempotent maps the idempotency keys of create requests to the issues they created.
+	idempotent map[string]string
+}
+
+// New creates a mock server with the given options.
+func New(opts Options) (*Server, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if opts.ErrorStatus == 0 {
+		opts.ErrorStatus = DefaultErrorStatus
+	}
+	if len(opts.IssueTypes) == 0 {
+		opts.IssueTypes = DefaultIssueTypes
+	}
+	if opts.Users == nil {
+		opts.Users = DefaultUsers
+	}
+	seed := opts.Seed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+
+	s := &Server{
+		opts:       opts,
+		rng:        rand.New(rand.NewPCG(seed, seed)),
+		issues:     make(map[string]*storedIssue),
+		counters:   make(map[string]int),
+		idempotent: make(map[string]string),
+		nextID:     firstIssueID,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /create_jira_issue", s.handleCreate)
+	mux.HandleFunc("POST /search_jira_issues", s.handleSearch)
+	mux.HandleFunc("GET /jira_issue/{key}", s.handleGet)
+	mux.HandleFunc("PUT /jira_issue/{key}", s.handleUpdate)
+	mux.HandleFunc("DELETE /jira_issue/{key}", s.handleDelete)
+	mux.HandleFunc("POST /jira_issue/{key}/transitions", s.handleTransition)
+	mux.HandleFunc("POST /jira_issue/{key}/comment", s.handleAddComment)
+	mux.HandleFunc("GET /jira_issue/{key}/comment", s.handleGetComments)
+	mux.HandleFunc("GET /jira_issue/{key}/changelog", s.handleGetChangelog)
+	mux.HandleFunc("GET /jira_project/{key}/createmeta", s.handleCreateMeta)
+	mux.HandleFunc("GET /jira_user/search", s.handleSearchUsers)
+	mux.HandleFunc("POST /jira_issue_link", s.handleLink)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no endpoint %s %s", r.Method, r.URL.Path))
+	})
+	s.handler = mux
+	return s, nil
+}
+
+// ServeHTTP applies the configured latency and error injection, then serves the request.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	delay, fail := s.roll()
+	log.Debug().Str("method", r.Method).Str("path", r.URL.Path).Dur("delay", delay).Bool("inject_error", fail).Msg("Mock MCP request")
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if fail {
+		writeError(w, s.opts.ErrorStatus, "injected failure")
+		return
+	}
+	s.handler.ServeHTTP(w, r)
+}
+
+// roll draws the delay and the failure decision for one request.
+func (s *Server) roll() (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delay := s.opts.Latency
+	if s.opts.Jitter > 0 {
+		delay += time.Duration(s.rng.Int64N(int64(s.opts.Jitter) + 1))
+	}
+	fail := s.opts.ErrorRate > 0 && s.rng.Float64() < s.opts.ErrorRate
+	return delay, fail
+}
+
+// Issues returns a snapshot of the stored issues in creation order.
+func (s *Server) Issues() []mcpclient.Issue {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	issues := make([]mcpclient.Issue, 0, len(s.order))
+	for _, key := range s.order {
+		issues = append(issues, s.issues[key].issue)
+	}
+	return issues
+}
+
+func (s *Server) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var req mcpclient.CreateIssueRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	switch {
+	case req.ProjectKey == "":
+		writeError(w, http.StatusBadRequest, "projectKey is required")
+		return
+	case req.Summary == "":
+		writeError(w, http.StatusBadRequest, "summary is required")
+		return
+	case req.IssueType == "":
+		writeError(w, http.StatusBadRequest, "issueType is required")
+		return
+	}
+	if req.DueDate != "" {
+		if _, err := time.Parse(time.DateOnly, req.DueDate); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("dueDate %q is not a YYYY-MM-DD date", req.DueDate))
+			return
+		}
+	}
+	if !s.knownProject(req.ProjectKey) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("project %s not found", req.ProjectKey))
+		return
+	}
+	issueType, ok := s.issueType(req.IssueType)
+	if !ok {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("issue type %q is not valid for project %s", req.IssueType, req.ProjectKey))
+		return
+	}
+
+	idempotencyKey := r.Header.Get(mcpclient.IdempotencyKeyHeader)
+	s.mu.Lock()
+	if stored, ok := s.issues[s.idempotent[idempotencyKey]]; ok && idempotencyKey != "" {
+		// A retry of an earlier request: return its issue, as long as it still exists
+		issue := stored.issue
+		s.mu.Unlock()
+		w.Header().Set(mcpclient.ReplayedHeader, "true")
+		writeJSON(w, http.StatusCreated, mcpclient.CreateIssueResponse{Key: issue.Key, ID: issue.ID, Self: issue.Self})
+		return
+	}
+	s.counters[req.ProjectKey]++
+	key := fmt.Sprintf("%s-%d", req.ProjectKey, s.counters[req.ProjectKey])
+	id := strconv.Itoa(s.nextID)
+	s.nextID++
+	issue := mcpclient.Issue{
+		Key:  key,
+		ID:   id,
+		Self: selfURL(r, "/jira_issue/"+key),
+		Fields: mcpclient.IssueFields{
+			Summary:     req.Summary,
+			Status:      mcpclient.Status{Name: Statuses[0]},
+			IssueType:   mcpclient.IssueType{Name: issueType},
+			Description: req.Description,
+			DueDate:     req.DueDate,
+		},
+	}
+	stored := &storedIssue{issue: issue}
+	s.touch(stored)
+	stored.issue.Fields.Created = stored.issue.Fields.Updated
+	s.issues[key] = stored
+	s.order = append(s.order, key)
+	if idempotencyKey != "" {
+		s.idempotent[idempotencyKey] = key
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, mcpclient.CreateIssueResponse{Key: key, ID: id, Self: issue.Self})
+}
+
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	var req mcpclient.SearchIssuesRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if strings.TrimSpace(req.JQL) == "" {
+		writeError(w, http.StatusBadRequest, "jql is required")
+		return
+	}
+	match, err := parseJQL(req.JQL)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	var matches []mcpclient.Issue
+	for _, key := range s.order {
+		issue := s.issues[key].issue
+		if match(&issue) {
+			matches = append(matches, issue)
+		}
+	}
+	s.mu.Unlock()
+
+	startAt, maxResults := req.StartAt, req.MaxResults
+	if maxResults <= 0 {
+		maxResults = defaultPageSize
+	}
+	writeJSON(w, http.StatusOK, mcpclient.SearchIssuesResponse{
+		StartAt:    startAt,
+		MaxResults: maxResults,
+		Total:      len(matches),
+		Issues:     page(matches, startAt, maxResults),
+	})
+}
+
+func (s *Server) handleGet(w http.ResponseWriter, r *http.Request) {
+	s.withIssue(w, r, func(stored *storedIssue) (int, interface{}) {
+		return http.StatusOK, stored.issue
+	})
+}
+
+func (s *Server) handleUpdate(w http.ResponseWriter, r *http.Request) {
+	var req mcpclient.UpdateIssueRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	s.withIssue(w, r, func(stored *storedIssue) (int, interface{}) {
+		if req.ExpectedUpdated != "" && req.ExpectedUpdated != stored.issue.Fields.Updated {
+			return http.StatusConflict, mcpclient.ErrorResponse{Error: fmt.Sprintf("issue %s was updated at %s, after %s", stored.issue.Key, stored.issue.Fields.Updated, req.ExpectedUpdated)}
+		}
+		fields := stored.issue.Fields
+		var items []mcpclient.ChangeItem
+		// Only summary and description are tracked; other fields and update operations are accepted as-is.
+		for _, id := range []string{"summary", "description"} {
+			value, present := req.Fields[id]
+			if !present {
+				continue
+			}
+			text, ok := value.(string)
+			if !ok {
+				return http.StatusBadRequest, mcpclient.ErrorResponse{Error: fmt.Sprintf("field %s must be a string", id)}
+			}
+			if id == "description" {
+				if text != fields.Description {
+					items = append(items, mcpclient.ChangeItem{Field: id, FromString: fields.Description, ToString: text})
+				}
+				fields.Description = text
+				continue
+			}
+			if text == "" {
+				return http.StatusBadRequest, mcpclient.ErrorResponse{Error: "summary must not be empty"}
+			}
+			if text != fields.Summary {
+				items = append(items, mcpclient.ChangeItem{Field: id, FromString: fields.Summary, ToString: text})
+			}
+			fields.Summary = text
+		}
+		stored.issue.Fields = fields
+		s.recordChange(stored, items...)
+		s.touch(stored)
+		return http.StatusNoContent, nil
+	})
+}
+
+func (s *Server) handleDelete(w http.ResponseWriter, r *http.Request) {
+	s.withIssue(w, r, func(stored *storedIssue) (int, interface{}) {
+		key := stored.issue.Key
+		delete(s.issues, key)
+		for i, k := range s.order {
+			if k == key {
+				s.order = append(s.order[:i], s.order[i+1:]...)
+				break
+			}
+		}
+		return http.StatusNoContent, nil
+	})
+}
+
+func (s *Server) handleTransition(w http.ResponseWriter, r *http.Request) {
+	var req mcpclient.TransitionIssueRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	status, ok := matchFold(Statuses, req.Transition)
+	if !ok {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("transition %q not available; available transitions: %s", req.Transition, strings.Join(Statuses, ", ")))
+		return
+	}
+	s.withIssue(w, r, func(stored *storedIssue) (int, interface{}) {
+		if previous := stored.issue.Fields.Status.Name; previous != status {
+			s.recordChange(stored, mcpclient.ChangeItem{Field: "status", FromString: previous, ToString: status})
+			s.touch(stored)
+		}
+		stored.issue.Fields.Status = mcpclient.Status{Name: status}
+		if _, resolved := matchFold(resolvedStatuses, status); !resolved {
+			stored.issue.Fields.ResolutionDate = ""
+		} else if stored.issue.Fields.ResolutionDate == "" {
+			stored.issue.Fields.ResolutionDate = time.Now().Format(jiraTimeFormat)
+		}
+		if req.Comment != "" {
+			s.addComment(r, stored, req.Comment)
+		}
+		return http.StatusNoContent, nil
+	})
+}
+
+func (s *Server) handleAddComment(w http.ResponseWriter, r *http.Request) {
+	var req mcpclient.AddCommentRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.Body == "" {
+		writeError(w, http.StatusBadRequest, "body is required")
+		return
+	}
+	s.withIssue(w, r, func(stored *storedIssue) (int, interface{}) {
+		return http.StatusCreated, s.addComment(r, stored, req.Body)
+	})
+}
+
+func (s *Server) handleGetComments(w http.ResponseWriter, r *http.Request) {
+	startAt, err := queryInt(r, "startAt")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	maxResults, err := queryInt(r, "maxResults")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if maxResults <= 0 {
+		maxResults = defaultPageSize
+	}
+	s.withIssue(w, r, func(stored *storedIssue) (int, interface{}) {
+		return http.StatusOK, mcpclient.CommentsResponse{
+			StartAt:    startAt,
+			MaxResults: maxResults,
+			Total:      len(stored.comments),
+			Comments:   page(stored.comments, startAt, maxResults),
+		}
+	})
+}
+
+func (s *Server) handleGetChangelog(w http.ResponseWriter, r *http.Request) {
+	startAt, err := queryInt(r, "startAt")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	maxResults, err := queryInt(r, "maxResults")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if maxResults <= 0 {
+		maxResults = defaultPageSize
+	}
+	s.withIssue(w, r, func(stored *storedIssue) (int, interface{}) {
+		return http.StatusOK, mcpclient.ChangelogResponse{
+			StartAt:    startAt,
+			MaxResults: maxResults,
+			Total:      len(stored.changelog),
+			Histories:  page(stored.changelog, startAt, maxResults),
+		}
+	})
+}
+
+func (s *Server) handleCreateMeta(w http.ResponseWriter, r *http.Request) {
+	projectKey := r.PathValue("key")
+	if !s.knownProject(projectKey) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("project %s not found", projectKey))
+		return
+	}
+	meta := mcpclient.CreateMeta{ProjectKey: projectKey}
+	for i, name := range s.opts.IssueTypes {
+		meta.IssueTypes = append(meta.IssueTypes, mcpclient.IssueTypeMeta{
+			ID:   strconv.Itoa(i + 1),
+			Name: name,
+			Fields: []mcpclient.FieldMeta{
+				{ID: "summary", Name: "Summary", Required: true, Schema: "string"},
+				{ID: "description", Name: "Description", Schema: "string"},
+				{ID: "priority", Name: "Priority", Schema: "option", AllowedValues: []string{"Highest", "High", "Medium", "Low", "Lowest"}},
+				{ID: "duedate", Name: "Due date", Schema: "date"},
+				{ID: "labels", Name: "Labels", Schema: "array"},
+			},
+		})
+	}
+	writeJSON(w, http.StatusOK, meta)
+}
+
+func (s *Server) handleSearchUsers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("query")
+	if query == "" {
+		writeError(w, http.StatusBadRequest, "query is required")
+		return
+	}
+	maxResults, err := queryInt(r, "maxResults")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	users := []mcpclient.User{}
+	for _, u := range s.opts.Users {
+		if containsFold(u.DisplayName, query) || containsFold(u.EmailAddress, query) || strings.EqualFold(u.AccountID, query) {
+			users = append(users, u)
+		}
+	}
+	if maxResults > 0 && len(users) > maxResults {
+		users = users[:maxResults]
+	}
+	writeJSON(w, http.StatusOK, users)
+}
+
+func (s *Server) handleLink(w http.ResponseWriter, r *http.Request) {
+	var req mcpclient.LinkIssuesRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.Type == "" || req.InwardIssue == "" || req.OutwardIssue == "" {
+		writeError(w, http.StatusBadRequest, "type, inwardIssue and outwardIssue are required")
+		return
+	}
+	s.mu.Lock()
+	inward, inwardOK := s.issues[req.InwardIssue]
+	outward, outwardOK := s.issues[req.OutwardIssue]
+	if inwardOK && outwardOK {
+		outward.issue.Fields.IssueLinks = append(outward.issue.Fields.IssueLinks, mcpclient.IssueLink{Type: req.Type, InwardIssue: req.InwardIssue})
+		inward.issue.Fields.IssueLinks = append(inward.issue.Fields.IssueLinks, mcpclient.IssueLink{Type: req.Type, OutwardIssue: req.OutwardIssue})
+	}
+	s.mu.Unlock()
+
+	switch {
+	case !inwardOK:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("issue %s does not exist", req.InwardIssue))
+	case !outwardOK:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("issue %s does not exist", req.OutwardIssue))
+	default:
+		w.WriteHeader(http.StatusCreated)
+	}
+}
+
+// withIssue runs fn under the store lock for the issue named by the {key} path value
+// and writes its result, answering 404 if the issue does not exist.
+func (s *Server) withIssue(w http.ResponseWriter, r *http.Request, fn func(*storedIssue) (int, interface{})) {
+	key := r.PathValue("key")
+	s.mu.Lock()
+	stored, ok := s.issues[key]
+	var status int
+	var body interface{}
+	if ok {
+		status, body = fn(stored)
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("issue %s does not exist", key))
+		return
+	}
+	if body == nil {
+		w.WriteHeader(status)
+		return
+	}
+	writeJSON(w, status, body)
+}
+
+// addComment appends a comment by the first configured user. The caller holds s.mu.
+func (s *Server) addComment(r *http.Request, stored *storedIssue, body string) mcpclient.Comment {
+	s.commentID++
+	id := strconv.Itoa(s.commentID)
+	now := time.Now().Format(jiraTimeFormat)
+	comment := mcpclient.Comment{
+		ID:      id,
+		Self:    selfURL(r, fmt.Sprintf("/jira_issue/%s/comment/%s", stored.issue.Key, id)),
+		Body:    body,
+		Created: now,
+		Updated: now,
+	}
+	if len(s.opts.Users) > 0 {
+		author := s.opts.Users[0]
+		comment.Author = &author
+	}
+	stored.comments = append(stored.comments, comment)
+	s.touch(stored)
+	return comment
+}
+
+// touch sets the updated timestamp of an edited issue, later than any given before. The
+// caller holds s.mu.
+func (s *Server) touch(stored *storedIssue) {
+	now := time.Now().Truncate(time.Millisecond)
+	if !now.After(s.lastUpdate) {
+		now = s.lastUpdate.Add(time.Millisecond)
+	}
+	s.lastUpdate = now
+	stored.issue.Fields.Updated = now.Format(jiraTimeFormat)
+}
+
+// recordChange appends a change history entry by the first configured user, unless items
+// is empty. The caller holds s.mu.
+func (s *Server) recordChange(stored *storedIssue, items ...mcpclient.ChangeItem) {
+	if len(items) == 0 {
+		return
+	}
+	s.historyID++
+	history := mcpclient.ChangeHistory{
+		ID:      strconv.Itoa(s.historyID),
+		Created: time.Now().Format(jiraTimeFormat),
+		Items:   items,
+	}
+	if len(s.opts.Users) > 0 {
+		author := s.opts.Users[0]
+		history.Author = &author
+	}
+	stored.changelog = append(stored.changelog, history)
+}
+
+// knownProject reports whether issues can be created in the project.
+func (s *Server) knownProject(projectKey string) bool {
+	if len(s.opts.Projects) == 0 {
+		return true
+	}
+	_, ok := matchFold(s.opts.Projects, projectKey)
+	return ok
+}
+
+// issueType returns the configured spelling of an issue type name.
+func (s *Server) issueType(name string) (string, bool) {
+	return matchFold(s.opts.IssueTypes, name)
+}
+
+// matchFold returns the element of list equal to name ignoring case.
+func matchFold(list []string, name string) (string, bool) {
+	for _, candidate := range list {
+		if strings.EqualFold(candidate, name) {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// page returns the items of one result page.
+func page[T any](items []T, startAt, maxResults int) []T {
+	result := []T{}
+	if startAt < 0 || startAt >= len(items) {
+		return result
+	}
+	end := startAt + maxResults
+	if end > len(items) {
+		end = len(items)
+	}
+	return append(result, items[startAt:end]...)
+}
+
+// queryInt parses an optional non-negative integer query parameter.
+func queryInt(r *http.Request, name string) (int, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid %s %q", name, raw)
+	}
+	return n, nil
+}
+
+// selfURL builds the absolute URL of a resource on this server.
+func selfURL(r *http.Request, path string) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + path
+}
+
+// decodeBody decodes a JSON request body, answering 400 if it is invalid.
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid JSON body: %v", err))
+		return false
+	}
+	return true
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, mcpclient.ErrorResponse{Error: message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Warn().Err(err).Msg("Failed to write mock MCP response")
+	}
+}
@@ -0,0 +1,340 @@
+package mockserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/config"
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+// newTestClient starts a mock server with opts and returns it with a client pointed at it.
+func newTestClient(t *testing.T, opts Options) (*Server, *mcpclient.Client) {
+	t.Helper()
+	server, err := New(opts)
+	require.NoError(t, err)
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	client, err := mcpclient.New(&config.AppConfig{MCPServerURL: httpServer.URL})
+	require.NoError(t, err)
+	return server, client
+}
+
+func createIssue(t *testing.T, client *mcpclient.Client, project, issueType, summary string) string {
+	t.Helper()
+	resp, err := client.CreateIssue(context.Background(), mcpclient.CreateIssueRequest{
+		ProjectKey: project,
+		Summary:    summary,
+		IssueType:  issueType,
+	})
+	require.NoError(t, err)
+	return resp.Key
+}
+
+func TestOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr error
+	}{
+		{name: "zero value", opts: Options{}},
+		{name: "full", opts: Options{Latency: time.Second, Jitter: time.Second, ErrorRate: 1, ErrorStatus: http.StatusServiceUnavailable}},
+		{name: "negative error rate", opts: Options{ErrorRate: -0.1}, wantErr: ErrInvalidErrorRate},
+		{name: "error rate above one", opts: Options{ErrorRate: 1.5}, wantErr: ErrInvalidErrorRate},
+		{name: "negative latency", opts: Options{Latency: -time.Second}, wantErr: ErrInvalidLatency},
+		{name: "negative jitter", opts: Options{Jitter: -time.Second}, wantErr: ErrInvalidLatency},
+		{name: "success status", opts: Options{ErrorStatus: http.StatusOK}, wantErr: ErrInvalidErrorStatus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestServer_KeysAndIssues(t *testing.T) {
+	server, client := newTestClient(t, Options{})
+	assert.Equal(t, "PROJ-1", createIssue(t, client, "PROJ", "Task", "one"))
+	assert.Equal(t, "PROJ-2", createIssue(t, client, "PROJ", "bug", "two"))
+	assert.Equal(t, "OPS-1", createIssue(t, client, "OPS", "Story", "three"))
+
+	issues := server.Issues()
+	require.Len(t, issues, 3)
+	assert.Equal(t, "Bug", issues[1].Fields.IssueType.Name, "issue type is stored with its configured spelling")
+	assert.Equal(t, Statuses[0], issues[0].Fields.Status.Name)
+	assert.Equal(t, "10000", issues[0].ID)
+}
+
+func TestServer_CreateValidation(t *testing.T) {
+	_, client := newTestClient(t, Options{Projects: []string{"PROJ"}, IssueTypes: []string{"Task"}})
+	ctx := context.Background()
+
+	_, err := client.CreateIssue(ctx, mcpclient.CreateIssueRequest{ProjectKey: "OTHER", Summary: "s", IssueType: "Task"})
+	assert.ErrorIs(t, err, mcpclient.ErrMCPServerError)
+	assert.Contains(t, err.Error(), "project OTHER not found")
+
+	_, err = client.CreateIssue(ctx, mcpclient.CreateIssueRequest{ProjectKey: "PROJ", Summary: "s", IssueType: "Bug"})
+	assert.ErrorIs(t, err, mcpclient.ErrMCPServerError)
+	assert.Contains(t, err.Error(), `issue type "Bug" is not valid`)
+
+	_, err = client.CreateIssue(ctx, mcpclient.CreateIssueRequest{ProjectKey: "PROJ", IssueType: "Task"})
+	assert.ErrorContains(t, err, "summary is required")
+
+	_, err = client.CreateIssue(ctx, mcpclient.CreateIssueRequest{ProjectKey: "PROJ", Summary: "s", IssueType: "Task", DueDate: "next friday"})
+	assert.ErrorContains(t, err, "not a YYYY-MM-DD date")
+
+	created, err := client.CreateIssue(ctx, mcpclient.CreateIssueRequest{ProjectKey: "PROJ", Summary: "s", IssueType: "Task", DueDate: "2025-05-01"})
+	require.NoError(t, err)
+	issue, err := client.GetIssue(ctx, created.Key)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-05-01", issue.Fields.DueDate)
+
+	_, err = client.GetCreateMeta(ctx, "OTHER")
+	assert.ErrorIs(t, err, mcpclient.ErrMCPServerError)
+}
+
+func TestServer_Search(t *testing.T) {
+	_, client := newTestClient(t, Options{})
+	createIssue(t, client, "PROJ", "Task", "Fix login page")
+	createIssue(t, client, "PROJ", "Bug", "Crash on startup")
+	createIssue(t, client, "OPS", "Task", "Rotate login certificates")
+	require.NoError(t, client.TransitionIssue(context.Background(), "PROJ-2", mcpclient.TransitionIssueRequest{Transition: "in progress"}))
+
+	tests := []struct {
+		jql  string
+		want []string
+	}{
+		{jql: "project = PROJ", want: []string{"PROJ-1", "PROJ-2"}},
+		{jql: "project = proj ORDER BY created DESC", want: []string{"PROJ-1", "PROJ-2"}},
+		{jql: `summary ~ "login"`, want: []string{"PROJ-1", "OPS-1"}},
+		{jql: `text ~ login AND project != OPS`, want: []string{"PROJ-1"}},
+		{jql: `status = "In Progress"`, want: []string{"PROJ-2"}},
+		{jql: "issuetype in (Bug, Story) OR key = OPS-1", want: []string{"PROJ-2", "OPS-1"}},
+		{jql: "project not in (PROJ)", want: []string{"OPS-1"}},
+		{jql: "NOT (project = OPS OR type = Bug)", want: []string{"PROJ-1"}},
+		{jql: "(project = PROJ) AND assignee = currentUser() AND created >= -3d", want: []string{"PROJ-1", "PROJ-2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.jql, func(t *testing.T) {
+			resp, err := client.SearchIssues(context.Background(), mcpclient.SearchIssuesRequest{JQL: tt.jql})
+			require.NoError(t, err)
+			keys := []string{}
+			for _, issue := range resp.Issues {
+				keys = append(keys, issue.Key)
+			}
+			assert.Equal(t, tt.want, keys)
+			assert.Equal(t, len(tt.want), resp.Total)
+		})
+	}
+
+	_, err := client.SearchIssues(context.Background(), mcpclient.SearchIssuesRequest{JQL: "project = (PROJ"})
+	assert.ErrorContains(t, err, "invalid JQL")
+}
+
+func TestServer_Pagination(t *testing.T) {
+	_, client := newTestClient(t, Options{})
+	for i := 0; i < 5; i++ {
+		createIssue(t, client, "PROJ", "Task", "issue")
+	}
+	resp, err := client.SearchIssues(context.Background(), mcpclient.SearchIssuesRequest{JQL: "project = PROJ", StartAt: 3, MaxResults: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 5, resp.Total)
+	require.Len(t, resp.Issues, 2)
+	assert.Equal(t, "PROJ-4", resp.Issues[0].Key)
+
+	for i := 0; i < 3; i++ {
+		_, err := client.AddComment(context.Background(), "PROJ-1", mcpclient.AddCommentRequest{Body: "comment"})
+		require.NoError(t, err)
+	}
+	comments, err := client.GetComments(context.Background(), "PROJ-1", mcpclient.GetCommentsRequest{StartAt: 1, MaxResults: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 3, comments.Total)
+	require.Len(t, comments.Comments, 1)
+	assert.Equal(t, "2", comments.Comments[0].ID)
+	assert.Equal(t, DefaultUsers[0].DisplayName, comments.Comments[0].Author.DisplayName)
+}
+
+func TestServer_TransitionWithComment(t *testing.T) {
+	server, client := newTestClient(t, Options{})
+	key := createIssue(t, client, "PROJ", "Task", "Old")
+	err := client.TransitionIssue(context.Background(), key, mcpclient.TransitionIssueRequest{Transition: "cancelled", Comment: "Duplicate"})
+	require.NoError(t, err)
+	assert.Equal(t, "Cancelled", server.Issues()[0].Fields.Status.Name)
+
+	comments, err := client.GetComments(context.Background(), key, mcpclient.GetCommentsRequest{})
+	require.NoError(t, err)
+	require.Len(t, comments.Comments, 1)
+	assert.Equal(t, "Duplicate", comments.Comments[0].Body)
+
+	err = client.TransitionIssue(context.Background(), key, mcpclient.TransitionIssueRequest{Transition: "Archived"})
+	assert.ErrorContains(t, err, `transition "Archived" not available`)
+}
+
+func TestServer_CreatedAndResolutionDate(t *testing.T) {
+	server, client := newTestClient(t, Options{})
+	key := createIssue(t, client, "PROJ", "Task", "Track dates")
+	fields := server.Issues()[0].Fields
+	_, err := time.Parse(jiraTimeFormat, fields.Created)
+	require.NoError(t, err)
+	assert.Empty(t, fields.ResolutionDate)
+
+	require.NoError(t, client.TransitionIssue(context.Background(), key, mcpclient.TransitionIssueRequest{Transition: "Done"}))
+	resolved := server.Issues()[0].Fields.ResolutionDate
+	_, err = time.Parse(jiraTimeFormat, resolved)
+	require.NoError(t, err)
+
+	require.NoError(t, client.TransitionIssue(context.Background(), key, mcpclient.TransitionIssueRequest{Transition: "In Progress"}))
+	assert.Empty(t, server.Issues()[0].Fields.ResolutionDate, "reopening clears the resolution date")
+}
+
+func TestServer_Changelog(t *testing.T) {
+	_, client := newTestClient(t, Options{})
+	ctx := context.Background()
+	key := createIssue(t, client, "PROJ", "Task", "Track changes")
+
+	require.NoError(t, client.TransitionIssue(ctx, key, mcpclient.TransitionIssueRequest{Transition: "In Progress"}))
+	require.NoError(t, client.TransitionIssue(ctx, key, mcpclient.TransitionIssueRequest{Transition: "in progress"}))
+	require.NoError(t, client.UpdateIssue(ctx, key, mcpclient.UpdateIssueRequest{
+		Fields: map[string]interface{}{"summary": "Track all changes", "labels": []string{"x"}},
+	}))
+
+	page, err := client.GetIssueChangelog(ctx, key, mcpclient.GetChangelogRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, page.Total, "a transition to the current status records nothing")
+	require.Len(t, page.Histories, 2)
+	assert.Equal(t, []mcpclient.ChangeItem{{Field: "status", FromString: "To Do", ToString: "In Progress"}}, page.Histories[0].Items)
+	assert.Equal(t, []mcpclient.ChangeItem{{Field: "summary", FromString: "Track changes", ToString: "Track all changes"}}, page.Histories[1].Items)
+	require.NotNil(t, page.Histories[0].Author)
+	assert.Equal(t, DefaultUsers[0].DisplayName, page.Histories[0].Author.DisplayName)
+	_, err = time.Parse(jiraTimeFormat, page.Histories[0].Created)
+	require.NoError(t, err)
+
+	page, err = client.GetIssueChangelog(ctx, key, mcpclient.GetChangelogRequest{StartAt: 1, MaxResults: 1})
+	require.NoError(t, err)
+	require.Len(t, page.Histories, 1)
+	assert.Equal(t, "summary", page.Histories[0].Items[0].Field)
+
+	_, err = client.GetIssueChangelog(ctx, "PROJ-999", mcpclient.GetChangelogRequest{})
+	assert.ErrorIs(t, err, mcpclient.ErrMCPServerError)
+}
+
+func TestServer_ConditionalUpdate(t *testing.T) {
+	_, client := newTestClient(t, Options{})
+	ctx := context.Background()
+	key := createIssue(t, client, "PROJ", "Task", "Edit me")
+
+	fetched, err := client.GetIssue(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, fetched.Fields.Created, fetched.Fields.Updated)
+
+	// Someone else comments in the meantime
+	_, err = client.AddComment(ctx, key, mcpclient.AddCommentRequest{Body: "Busy"})
+	require.NoError(t, err)
+	err = client.UpdateIssue(ctx, key, mcpclient.UpdateIssueRequest{
+		Fields: map[string]interface{}{"summary": "Stale edit"}, ExpectedUpdated: fetched.Fields.Updated,
+	})
+	assert.ErrorIs(t, err, mcpclient.ErrConflict)
+
+	refetched, err := client.GetIssue(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, "Edit me", refetched.Fields.Summary, "a conflicting update changes nothing")
+	assert.Greater(t, refetched.Fields.Updated, fetched.Fields.Updated)
+	require.NoError(t, client.UpdateIssue(ctx, key, mcpclient.UpdateIssueRequest{
+		Fields: map[string]interface{}{"summary": "Fresh edit"}, ExpectedUpdated: refetched.Fields.Updated,
+	}))
+	require.NoError(t, client.UpdateIssue(ctx, key, mcpclient.UpdateIssueRequest{
+		Fields: map[string]interface{}{"summary": "Unconditional edit"},
+	}), "updates without ExpectedUpdated always apply")
+}
+
+func TestServer_IdempotentCreate(t *testing.T) {
+	_, client := newTestClient(t, Options{})
+	ctx := context.Background()
+	req := mcpclient.CreateIssueRequest{ProjectKey: "PROJ", Summary: "Once", IssueType: "Task", IdempotencyKey: "k1"}
+
+	first, err := client.CreateIssue(ctx, req)
+	require.NoError(t, err)
+	assert.False(t, first.Replayed)
+	retry, err := client.CreateIssue(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, first.Key, retry.Key, "a retry returns the issue created first")
+	assert.True(t, retry.Replayed)
+
+	req.IdempotencyKey = ""
+	other, err := client.CreateIssue(ctx, req)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.Key, other.Key, "requests without a key always create an issue")
+
+	require.NoError(t, client.DeleteIssue(ctx, first.Key))
+	req.IdempotencyKey = "k1"
+	recreated, err := client.CreateIssue(ctx, req)
+	require.NoError(t, err)
+	assert.False(t, recreated.Replayed, "the key of a deleted issue creates a new one")
+}
+
+func TestServer_SearchUsers(t *testing.T) {
+	_, client := newTestClient(t, Options{})
+	users, err := client.SearchUsers(context.Background(), "doe", 0)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "Jane Doe", users[0].DisplayName)
+
+	users, err = client.SearchUsers(context.Background(), "example.com", 2)
+	require.NoError(t, err)
+	assert.Len(t, users, 2)
+}
+
+func TestServer_ErrorInjection(t *testing.T) {
+	_, client := newTestClient(t, Options{ErrorRate: 1, ErrorStatus: http.StatusServiceUnavailable})
+	_, err := client.GetCreateMeta(context.Background(), "PROJ")
+	assert.ErrorIs(t, err, mcpclient.ErrMCPServerError)
+	assert.ErrorContains(t, err, "injected failure (status 503)")
+
+	// The same seed produces the same sequence of failures.
+	outcomes := func() []bool {
+		_, client := newTestClient(t, Options{ErrorRate: 0.5, Seed: 42})
+		var failed []bool
+		for i := 0; i < 20; i++ {
+			_, err := client.GetCreateMeta(context.Background(), "PROJ")
+			failed = append(failed, err != nil)
+		}
+		return failed
+	}
+	first := outcomes()
+	assert.Equal(t, first, outcomes())
+	assert.Contains(t, first, true)
+	assert.Contains(t, first, false)
+}
+
+func TestServer_Latency(t *testing.T) {
+	_, client := newTestClient(t, Options{Latency: 50 * time.Millisecond})
+	start := time.Now()
+	_, err := client.GetCreateMeta(context.Background(), "PROJ")
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = client.GetCreateMeta(ctx, "PROJ")
+	assert.ErrorIs(t, err, mcpclient.ErrRequestExecute, "the client deadline expires before the delayed response")
+}
+
+func TestServer_UnknownEndpoint(t *testing.T) {
+	server, err := New(Options{})
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/jira_board/1", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.JSONEq(t, `{"error":"no endpoint GET /jira_board/1"}`, rec.Body.String())
+}
@@ -7,8 +7,31 @@ type CreateIssueRequest struct {
 	Summary     string `json:"summary"`
 	Description string `json:"description"`
 	IssueType   string `json:"issueType"`
+	Priority    string `json:"priority,omitempty"`
+	// DueDate is the due date of the issue as YYYY-MM-DD.
+	DueDate string `json:"dueDate,omitempty"`
+	// DescriptionFormat is the format Description is converted to by CreateIssue. The server
+	// receives the converted description and the format; for adf the description is the JSON document.
+	DescriptionFormat DescriptionFormat `json:"descriptionFormat,omitempty"`
+	// Fields holds additional field values keyed by field ID, e.g. "customfield_10010".
+	Fields map[string]interface{} `json:"fields,omitempty"`
+	// LinkTo holds the keys of existing issues tix links to the new issue once it is
+	// created, with links of type LinkType. Neither is sent with the create request.
+	LinkTo   []string `json:"-"`
+	LinkType string   `json:"-"`
+	// IdempotencyKey identifies the request across retries. It is sent in the
+	// IdempotencyKeyHeader rather than the body; empty keys are not sent.
+	IdempotencyKey string `json:"-"`
 }
 
+// Headers of idempotent create requests. Servers that support them return the issue
+// created earlier for the same IdempotencyKeyHeader instead of creating another one,
+// and set ReplayedHeader to "true" on such responses.
+const (
+	IdempotencyKeyHeader = "Idempotency-Key"
+	ReplayedHeader       = "Idempotent-Replayed"
+)
+
 // SearchIssuesRequest defines the JSON structure expected by the MCP server's
 // /search_jira_issues endpoint. It contains the JQL query and optional pagination parameters.
 type SearchIssuesRequest struct {
@@ -23,6 +46,9 @@ type CreateIssueResponse struct {
 	Key  string `json:"key"`
 	ID   string `json:"id"`
 	Self string `json:"self"`
+	// Replayed is set when the server returned an issue created earlier for the same
+	// idempotency key (see ReplayedHeader) rather than creating a new one.
+	Replayed bool `json:"-" yaml:"-"`
 }
 
 // SearchIssuesResponse defines the JSON structure returned by the MCP server's
@@ -46,10 +72,27 @@ type Issue struct {
 // IssueFields holds the core fields associated with a Jira Issue, such as summary,
 // status, issue type, and description.
 type IssueFields struct {
-	Summary     string    `json:"summary" yaml:"summary"`
-	Status      Status    `json:"status" yaml:"status"`
-	IssueType   IssueType `json:"issuetype" yaml:"issuetype"`
-	Description string    `json:"description,omitempty" yaml:"description,omitempty"` // Added optional description
+	Summary     string      `json:"summary" yaml:"summary"`
+	Status      Status      `json:"status" yaml:"status"`
+	IssueType   IssueType   `json:"issuetype" yaml:"issuetype"`
+	Description string      `json:"description,omitempty" yaml:"description,omitempty"` // Added optional description
+	DueDate     string      `json:"duedate,omitempty" yaml:"duedate,omitempty"`         // YYYY-MM-DD
+	IssueLinks  []IssueLink `json:"issuelinks,omitempty" yaml:"issuelinks,omitempty"`
+	Assignee    *User       `json:"assignee,omitempty" yaml:"assignee,omitempty"`
+	// Created, Updated and ResolutionDate are Jira timestamps; ResolutionDate is empty while
+	// the issue is unresolved. Updated changes with every edit of the issue, see
+	// UpdateIssueRequest.ExpectedUpdated.
+	Created        string `json:"created,omitempty" yaml:"created,omitempty"`
+	Updated        string `json:"updated,omitempty" yaml:"updated,omitempty"`
+	ResolutionDate string `json:"resolutiondate,omitempty" yaml:"resolutiondate,omitempty"`
+}
+
+// IssueLink is a link between two issues as seen from one of them: exactly one of
+// InwardIssue and OutwardIssue is set, to the key of the other issue.
+type IssueLink struct {
+	Type         string `json:"type" yaml:"type"`
+	InwardIssue  string `json:"inwardIssue,omitempty" yaml:"inwardIssue,omitempty"`
+	OutwardIssue string `json:"outwardIssue,omitempty" yaml:"outwardIssue,omitempty"`
 }
 
 // Status represents the status field of a Jira Issue, containing its name.
@@ -67,3 +110,137 @@ type IssueType struct {
 type ErrorResponse struct {
 	Error string `json:"error"`
 }
+
+// AddCommentRequest defines the JSON structure expected by the MCP server's
+// /jira_issue/{issueKey}/comment endpoint when adding a comment to an issue.
+type AddCommentRequest struct {
+	Body string `json:"body"`
+}
+
+// Comment represents a single comment on a Jira Issue.
+type Comment struct {
+	ID      string `json:"id" yaml:"id"`
+	Self    string `json:"self,omitempty" yaml:"self,omitempty"`
+	Author  *User  `json:"author,omitempty" yaml:"author,omitempty"`
+	Body    string `json:"body" yaml:"body"`
+	Created string `json:"created,omitempty" yaml:"created,omitempty"`
+	Updated string `json:"updated,omitempty" yaml:"updated,omitempty"`
+}
+
+// User represents a Jira user, e.g. the author of a comment.
+type User struct {
+	AccountID    string `json:"accountId,omitempty" yaml:"accountId,omitempty"`
+	DisplayName  string `json:"displayName" yaml:"displayName"`
+	EmailAddress string `json:"emailAddress,omitempty" yaml:"emailAddress,omitempty"`
+}
+
+// GetCommentsRequest holds the pagination parameters for listing the comments of an issue.
+// Zero values let the server apply its defaults.
+type GetCommentsRequest struct {
+	StartAt    int
+	MaxResults int
+}
+
+// CommentsResponse defines the JSON structure returned by the MCP server's
+// GET /jira_issue/{issueKey}/comment endpoint: one page of comments, oldest first.
+type CommentsResponse struct {
+	StartAt    int       `json:"startAt"`
+	MaxResults int       `json:"maxResults"`
+	Total      int       `json:"total"`
+	Comments   []Comment `json:"comments"`
+}
+
+// GetChangelogRequest holds the pagination parameters for listing the change history of
+// an issue. Zero values let the server apply its defaults.
+type GetChangelogRequest struct {
+	StartAt    int
+	MaxResults int
+}
+
+// ChangelogResponse defines the JSON structure returned by the MCP server's
+// GET /jira_issue/{issueKey}/changelog endpoint: one page of change histories, oldest first.
+type ChangelogResponse struct {
+	StartAt    int             `json:"startAt"`
+	MaxResults int             `json:"maxResults"`
+	Total      int             `json:"total"`
+	Histories  []ChangeHistory `json:"values"`
+}
+
+// ChangeHistory is one edit of an issue: who made it, when, and the fields it changed.
+type ChangeHistory struct {
+	ID      string       `json:"id" yaml:"id"`
+	Author  *User        `json:"author,omitempty" yaml:"author,omitempty"`
+	Created string       `json:"created,omitempty" yaml:"created,omitempty"`
+	Items   []ChangeItem `json:"items" yaml:"items"`
+}
+
+// ChangeItem is the change of a single field within a ChangeHistory. FromString and
+// ToString hold the display values; either is empty when the field was unset.
+type ChangeItem struct {
+	Field      string `json:"field" yaml:"field"`
+	FromString string `json:"fromString,omitempty" yaml:"fromString,omitempty"`
+	ToString   string `json:"toString,omitempty" yaml:"toString,omitempty"`
+}
+
+// TransitionIssueRequest defines the JSON structure expected by the MCP server's
+// /jira_issue/{issueKey}/transitions endpoint. Transition is the name of the target
+// transition or status (e.g. "Done"), matched case-insensitively by the server.
+type TransitionIssueRequest struct {
+	Transition string `json:"transition"`
+	Comment    string `json:"comment,omitempty"`
+}
+
+// UpdateIssueRequest defines the JSON structure expected by the MCP server's
+// PUT /jira_issue/{issueKey} endpoint. Fields replaces field values outright, while
+// Update applies operations to multi-value fields such as labels.
+//
+// ExpectedUpdated makes the update conditional: set to the updated timestamp of the issue
+// as fetched, the server answers 409 Conflict, reported as ErrConflict, if the issue has
+// been changed since. Empty updates unconditionally.
+type UpdateIssueRequest struct {
+	Fields          map[string]interface{}      `json:"fields,omitempty"`
+	Update          map[string][]FieldOperation `json:"update,omitempty"`
+	ExpectedUpdated string                      `json:"expectedUpdated,omitempty"`
+}
+
+// FieldOperation is a single edit operation on a field; exactly one member should be set.
+type FieldOperation struct {
+	Add    interface{} `json:"add,omitempty"`
+	Remove interface{} `json:"remove,omitempty"`
+	Set    interface{} `json:"set,omitempty"`
+}
+
+// CreateMeta defines the JSON structure returned by the MCP server's
+// GET /jira_project/{projectKey}/createmeta endpoint: the issue types that can be
+// created in a project and the fields available for each of them.
+type CreateMeta struct {
+	ProjectKey string          `json:"projectKey" yaml:"projectKey"`
+	IssueTypes []IssueTypeMeta `json:"issueTypes" yaml:"issueTypes"`
+}
+
+// IssueTypeMeta describes an issue type that can be created in a project.
+type IssueTypeMeta struct {
+	ID      string      `json:"id" yaml:"id"`
+	Name    string      `json:"name" yaml:"name"`
+	Subtask bool        `json:"subtask,omitempty" yaml:"subtask,omitempty"`
+	Fields  []FieldMeta `json:"fields" yaml:"fields"`
+}
+
+// FieldMeta describes a field of the create screen for an issue type.
+type FieldMeta struct {
+	ID            string   `json:"id" yaml:"id"` // e.g. "summary" or "customfield_10010"
+	Name          string   `json:"name" yaml:"name"`
+	Required      bool     `json:"required" yaml:"required"`
+	Schema        string   `json:"schema,omitempty" yaml:"schema,omitempty"` // Value type, e.g. "string", "option", "array"
+	AllowedValues []string `json:"allowedValues,omitempty" yaml:"allowedValues,omitempty"`
+}
+
+// LinkIssuesRequest defines the JSON structure expected by the MCP server's
+// POST /jira_issue_link endpoint. Type is the name of a Jira link type such as "Relates"
+// or "Blocks"; the outward issue is the subject of its outward description, e.g.
+// OutwardIssue "blocks" InwardIssue.
+type LinkIssuesRequest struct {
+	Type         string `json:"type"`
+	InwardIssue  string `json:"inwardIssue"`
+	OutwardIssue string `json:"outwardIssue"`
+}
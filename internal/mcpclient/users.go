@@ -0,0 +1,29 @@
+package mcpclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// SearchUsers sends a GET request to the MCP server's /jira_user/search endpoint and returns
+// the users whose name, display name or email address match query. maxResults limits the
+// number of users returned; 0 uses the server default.
+// It returns an error if the request or decoding fails, or if the server returns a non-200 status code.
+func (c *Client) SearchUsers(ctx context.Context, query string, maxResults int) ([]User, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, ErrUserQueryMissing
+	}
+	params := url.Values{}
+	params.Set("query", query)
+	if maxResults > 0 {
+		params.Set("maxResults", strconv.Itoa(maxResults))
+	}
+	var users []User
+	if err := c.doJSON(ctx, http.MethodGet, "/jira_user/search?"+params.Encode(), nil, http.StatusOK, &users, "SearchUsers"); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
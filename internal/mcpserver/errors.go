@@ -0,0 +1,17 @@
+package mcpserver
+
+import "errors"
+
+// Sentinel errors for MCP server operations.
+
+// ErrToolInvalid indicates a tool was registered without a name or handler.
+var ErrToolInvalid = errors.New("tool must have a name and a handler")
+
+// ErrToolDuplicate indicates a tool with the same name is already registered.
+var ErrToolDuplicate = errors.New("tool already registered")
+
+// ErrReadRequest indicates an error occurred while reading from the input stream.
+var ErrReadRequest = errors.New("failed to read MCP request")
+
+// ErrWriteResponse indicates an error occurred while writing a response to the output stream.
+var ErrWriteResponse = errors.New("failed to write MCP response")
@@ -0,0 +1,195 @@
+// Package mcpserver implements a minimal Model Context Protocol (MCP) server
+// speaking JSON-RPC 2.0 over a line-delimited stream (typically stdio). It lets
+// AI assistants such as Claude Desktop discover and call Ticketron capabilities
+// as MCP tools.
+package mcpserver
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ProtocolVersion is the MCP protocol revision announced during initialization.
+const ProtocolVersion = "2024-11-05"
+
+// JSON-RPC 2.0 error codes used by the server.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// ToolHandler executes a tool call. args holds the raw JSON "arguments" object sent
+// by the client. The returned text is sent back as a single text content block;
+// a returned error is reported to the client as a tool result with isError=true.
+type ToolHandler func(ctx context.Context, args json.RawMessage) (string, error)
+
+// Tool describes a callable capability exposed by the server.
+type Tool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	InputSchema json.RawMessage `json:"inputSchema"`
+	Handler     ToolHandler     `json:"-"`
+}
+
+// Server dispatches MCP requests to registered tools.
+type Server struct {
+	name    string
+	version string
+	tools   []Tool
+	byName  map[string]Tool
+	writeMu sync.Mutex
+}
+
+// New creates a Server that identifies itself with the given name and version.
+func New(name, version string) *Server {
+	return &Server{
+		name:    name,
+		version: version,
+		byName:  make(map[string]Tool),
+	}
+}
+
+// RegisterTool adds a tool to the server. It returns ErrToolInvalid if the tool has
+// no name or handler, and ErrToolDuplicate if a tool with the same name already exists.
+func (s *Server) RegisterTool(tool Tool) error {
+	if tool.Name == "" || tool.Handler == nil {
+		return ErrToolInvalid
+	}
+	if _, exists := s.byName[tool.Name]; exists {
+		return fmt.Errorf("%w: %s", ErrToolDuplicate, tool.Name)
+	}
+	if len(tool.InputSchema) == 0 {
+		tool.InputSchema = json.RawMessage(`{"type":"object"}`)
+	}
+	s.tools = append(s.tools, tool)
+	s.byName[tool.Name] = tool
+	return nil
+}
+
+// Serve reads JSON-RPC messages line by line from in and writes responses to out
+// until in is exhausted or ctx is cancelled. Requests are handled sequentially.
+func (s *Server) Serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024) // Allow large tool arguments
+
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		resp := s.handleMessage(ctx, line)
+		if resp == nil {
+			continue // Notification, no response expected
+		}
+		if err := s.write(out, resp); err != nil {
+			return fmt.Errorf("%w: %w", ErrWriteResponse, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%w: %w", ErrReadRequest, err)
+	}
+	log.Debug().Msg("MCP input stream closed, stopping server")
+	return nil
+}
+
+// handleMessage decodes and dispatches a single JSON-RPC message. It returns nil for notifications.
+func (s *Server) handleMessage(ctx context.Context, raw []byte) *response {
+	var req request
+	if err := json.Unmarshal(raw, &req); err != nil {
+		log.Warn().Err(err).Msg("Failed to parse MCP request")
+		return errorResponse(nil, codeParseError, "parse error")
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return errorResponse(req.ID, codeInvalidRequest, "invalid request")
+	}
+	isNotification := len(req.ID) == 0
+
+	log.Debug().Str("method", req.Method).Bool("notification", isNotification).Msg("Handling MCP message")
+
+	var result interface{}
+	var rpcErr *rpcError
+	switch req.Method {
+	case "initialize":
+		result = initializeResult{
+			ProtocolVersion: ProtocolVersion,
+			Capabilities:    map[string]interface{}{"tools": map[string]interface{}{}},
+			ServerInfo:      serverInfo{Name: s.name, Version: s.version},
+		}
+	case "ping":
+		result = struct{}{}
+	case "tools/list":
+		tools := s.tools
+		if tools == nil {
+			tools = []Tool{}
+		}
+		result = toolsListResult{Tools: tools}
+	case "tools/call":
+		result, rpcErr = s.callTool(ctx, req.Params)
+	default:
+		if isNotification {
+			// Notifications such as notifications/initialized need no handling.
+			return nil
+		}
+		rpcErr = &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method not found: %s", req.Method)}
+	}
+
+	if isNotification {
+		return nil
+	}
+	if rpcErr != nil {
+		return &response{JSONRPC: "2.0", ID: req.ID, Error: rpcErr}
+	}
+	return &response{JSONRPC: "2.0", ID: req.ID, Result: result}
+}
+
+// callTool runs the requested tool, converting handler errors into tool-level error results.
+func (s *Server) callTool(ctx context.Context, params json.RawMessage) (interface{}, *rpcError) {
+	var p toolCallParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "invalid tools/call params"}
+	}
+	tool, ok := s.byName[p.Name]
+	if !ok {
+		return nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("unknown tool: %s", p.Name)}
+	}
+	if len(p.Arguments) == 0 {
+		p.Arguments = json.RawMessage(`{}`)
+	}
+
+	text, err := tool.Handler(ctx, p.Arguments)
+	if err != nil {
+		log.Error().Err(err).Str("tool", p.Name).Msg("MCP tool call failed")
+		return toolCallResult{Content: []content{{Type: "text", Text: err.Error()}}, IsError: true}, nil
+	}
+	return toolCallResult{Content: []content{{Type: "text", Text: text}}}, nil
+}
+
+// write serializes resp as a single line of JSON.
+func (s *Server) write(out io.Writer, resp *response) error {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	_, err = out.Write(append(data, '\n'))
+	return err
+}
+
+func errorResponse(id json.RawMessage, code int, msg string) *response {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return &response{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: code, Message: msg}}
+}
@@ -0,0 +1,112 @@
+package mcpserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runSession feeds the given request lines to a server and returns the decoded responses.
+func runSession(t *testing.T, s *Server, lines ...string) []map[string]interface{} {
+	t.Helper()
+	var out bytes.Buffer
+	err := s.Serve(context.Background(), strings.NewReader(strings.Join(lines, "\n")+"\n"), &out)
+	require.NoError(t, err)
+
+	var responses []map[string]interface{}
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var resp map[string]interface{}
+		require.NoError(t, dec.Decode(&resp))
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+func newEchoServer(t *testing.T) *Server {
+	t.Helper()
+	s := New("tix", "test")
+	err := s.RegisterTool(Tool{
+		Name:        "echo",
+		Description: "Echo the input",
+		Handler: func(ctx context.Context, args json.RawMessage) (string, error) {
+			var p struct {
+				Text string `json:"text"`
+			}
+			if err := json.Unmarshal(args, &p); err != nil {
+				return "", err
+			}
+			if p.Text == "fail" {
+				return "", errors.New("echo failed")
+			}
+			return p.Text, nil
+		},
+	})
+	require.NoError(t, err)
+	return s
+}
+
+func TestServe_InitializeAndList(t *testing.T) {
+	s := newEchoServer(t)
+	responses := runSession(t, s,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+	)
+	require.Len(t, responses, 2, "notifications must not produce a response")
+
+	initResult := responses[0]["result"].(map[string]interface{})
+	assert.Equal(t, ProtocolVersion, initResult["protocolVersion"])
+	assert.Equal(t, "tix", initResult["serverInfo"].(map[string]interface{})["name"])
+
+	tools := responses[1]["result"].(map[string]interface{})["tools"].([]interface{})
+	require.Len(t, tools, 1)
+	tool := tools[0].(map[string]interface{})
+	assert.Equal(t, "echo", tool["name"])
+	assert.Equal(t, map[string]interface{}{"type": "object"}, tool["inputSchema"])
+}
+
+func TestServe_ToolCall(t *testing.T) {
+	s := newEchoServer(t)
+	responses := runSession(t, s,
+		`{"jsonrpc":"2.0","id":"a","method":"tools/call","params":{"name":"echo","arguments":{"text":"hello"}}}`,
+		`{"jsonrpc":"2.0","id":"b","method":"tools/call","params":{"name":"echo","arguments":{"text":"fail"}}}`,
+		`{"jsonrpc":"2.0","id":"c","method":"tools/call","params":{"name":"missing"}}`,
+	)
+	require.Len(t, responses, 3)
+
+	ok := responses[0]["result"].(map[string]interface{})
+	assert.Nil(t, ok["isError"])
+	assert.Equal(t, "hello", ok["content"].([]interface{})[0].(map[string]interface{})["text"])
+
+	failed := responses[1]["result"].(map[string]interface{})
+	assert.Equal(t, true, failed["isError"])
+	assert.Equal(t, "echo failed", failed["content"].([]interface{})[0].(map[string]interface{})["text"])
+
+	unknown := responses[2]["error"].(map[string]interface{})
+	assert.Equal(t, float64(codeInvalidParams), unknown["code"])
+}
+
+func TestServe_ProtocolErrors(t *testing.T) {
+	s := newEchoServer(t)
+	responses := runSession(t, s,
+		`not json`,
+		`{"jsonrpc":"2.0","id":3,"method":"resources/list"}`,
+	)
+	require.Len(t, responses, 2)
+	assert.Equal(t, float64(codeParseError), responses[0]["error"].(map[string]interface{})["code"])
+	assert.Equal(t, float64(codeMethodNotFound), responses[1]["error"].(map[string]interface{})["code"])
+}
+
+func TestRegisterTool_Validation(t *testing.T) {
+	s := newEchoServer(t)
+	assert.ErrorIs(t, s.RegisterTool(Tool{Name: "nohandler"}), ErrToolInvalid)
+	err := s.RegisterTool(Tool{Name: "echo", Handler: func(context.Context, json.RawMessage) (string, error) { return "", nil }})
+	assert.ErrorIs(t, err, ErrToolDuplicate)
+}
@@ -0,0 +1,61 @@
+package mcpserver
+
+import "encoding/json"
+
+// request is an incoming JSON-RPC 2.0 message. A missing ID marks a notification.
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// response is an outgoing JSON-RPC 2.0 message carrying either a result or an error.
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcError is the JSON-RPC 2.0 error object.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// serverInfo identifies the server in the initialize result.
+type serverInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// initializeResult is returned for the "initialize" method.
+type initializeResult struct {
+	ProtocolVersion string                 `json:"protocolVersion"`
+	Capabilities    map[string]interface{} `json:"capabilities"`
+	ServerInfo      serverInfo             `json:"serverInfo"`
+}
+
+// toolsListResult is returned for the "tools/list" method.
+type toolsListResult struct {
+	Tools []Tool `json:"tools"`
+}
+
+// toolCallParams holds the parameters of a "tools/call" request.
+type toolCallParams struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+// content is a single content block in a tool result.
+type content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// toolCallResult is returned for the "tools/call" method.
+type toolCallResult struct {
+	Content []content `json:"content"`
+	IsError bool      `json:"isError,omitempty"`
+}
@@ -0,0 +1,17 @@
+package output
+
+import "errors"
+
+// Sentinel errors for rendering command output.
+
+// ErrMarshal indicates a value could not be encoded in the requested format.
+var ErrMarshal = errors.New("failed to format output")
+
+// ErrInvalidQuery indicates a jq query could not be parsed or compiled.
+var ErrInvalidQuery = errors.New("invalid jq query")
+
+// ErrNotJSON indicates output filtered with a jq query is not JSON.
+var ErrNotJSON = errors.New("output is not JSON")
+
+// ErrQuery indicates a jq query failed on a value, e.g. indexing a string with .key.
+var ErrQuery = errors.New("jq query failed")
@@ -0,0 +1,44 @@
+package output
+
+import "strings"
+
+// ParseFields splits a comma-separated field list such as the value of --output-fields,
+// dropping blank entries. It returns nil if no field remains.
+func ParseFields(s string) []string {
+	var fields []string
+	for _, field := range strings.Split(s, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// ValueByPath returns the value at a dot-separated path such as "fields.status.name".
+// Struct fields match by name or JSON tag and map keys match exactly or, failing that,
+// case-insensitively. Fields tagged json:"-" are never returned. To look up the same
+// path in many values, Compile it once instead.
+func ValueByPath(data interface{}, path string) (interface{}, bool) {
+	return Compile(path).Value(data)
+}
+
+// FieldString returns the value at path formatted with %v, or "" if it is missing.
+func FieldString(data interface{}, path string) string {
+	return Compile(path).Text(data)
+}
+
+// ExtractFields returns a map from each path in fields to its value in data. Missing
+// fields are included as nil so every requested key is present in the output.
+func ExtractFields(data interface{}, fields []string) map[string]interface{} {
+	return Extract(data, CompileAll(fields))
+}
+
+// Extract is ExtractFields for compiled paths.
+func Extract(data interface{}, paths []*Path) map[string]interface{} {
+	result := make(map[string]interface{}, len(paths))
+	for _, path := range paths {
+		value, _ := path.Value(data)
+		result[path.Raw()] = value
+	}
+	return result
+}
@@ -0,0 +1,118 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/itchyny/gojq"
+)
+
+// Query is a compiled jq query.
+type Query struct {
+	code *gojq.Code
+}
+
+// CompileQuery parses and compiles the jq query src, e.g. ".issues[].key".
+func CompileQuery(src string) (*Query, error) {
+	parsed, err := gojq.Parse(src)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidQuery, err)
+	}
+	code, err := gojq.Compile(parsed)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidQuery, err)
+	}
+	return &Query{code: code}, nil
+}
+
+// Apply runs q on v, a value decoded from JSON, and writes each result to w on a line of
+// its own: strings as they are, like jq -r, anything else as compact JSON.
+func (q *Query) Apply(w io.Writer, v interface{}) error {
+	iter := q.code.Run(v)
+	for {
+		result, ok := iter.Next()
+		if !ok {
+			return nil
+		}
+		if err, isErr := result.(error); isErr {
+			return fmt.Errorf("%w: %w", ErrQuery, err)
+		}
+		line, isString := result.(string)
+		if !isString {
+			data, err := gojq.Marshal(result)
+			if err != nil {
+				return fmt.Errorf("%w: %w", ErrQuery, err)
+			}
+			line = string(data)
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+}
+
+// QueryWriter filters the JSON written to it with a query. Each complete JSON value is
+// filtered as soon as it is written, so a stream of values such as JSON Lines is filtered
+// as it is produced. Close must be called once the output is complete.
+type QueryWriter struct {
+	out   io.Writer
+	query *Query
+	buf   []byte
+	err   error
+}
+
+// NewQueryWriter returns a QueryWriter writing the results of query to out.
+func NewQueryWriter(out io.Writer, query *Query) *QueryWriter {
+	return &QueryWriter{out: out, query: query}
+}
+
+// Write buffers p and filters the JSON values it completes. Once a write fails, for
+// output that is not JSON, all further writes fail with the same error.
+func (w *QueryWriter) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	w.buf = append(w.buf, p...)
+	if w.err = w.filter(false); w.err != nil {
+		return 0, w.err
+	}
+	return len(p), nil
+}
+
+// Close filters what is left of the output. It returns the error of the first failed write,
+// as commands often ignore errors writing their output, or ErrNotJSON if the output ends
+// with an incomplete value.
+func (w *QueryWriter) Close() error {
+	if w.err != nil {
+		return w.err
+	}
+	w.err = w.filter(true)
+	return w.err
+}
+
+// filter decodes and filters the complete values in the buffer and drops them from it. An
+// incomplete value at the end is kept for the next write unless final is set.
+func (w *QueryWriter) filter(final bool) error {
+	dec := json.NewDecoder(bytes.NewReader(w.buf))
+	dec.UseNumber()
+	consumed := 0
+	for {
+		var v interface{}
+		err := dec.Decode(&v)
+		if errors.Is(err, io.EOF) || (!final && errors.Is(err, io.ErrUnexpectedEOF)) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrNotJSON, err)
+		}
+		consumed = int(dec.InputOffset())
+		if err := w.query.Apply(w.out, v); err != nil {
+			return err
+		}
+	}
+	w.buf = append(w.buf[:0], w.buf[consumed:]...)
+	return nil
+}
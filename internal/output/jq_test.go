@@ -0,0 +1,59 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileQuery(t *testing.T) {
+	_, err := CompileQuery(".issues[].key")
+	require.NoError(t, err)
+
+	for _, src := range []string{".issues[", "undefined_function(1)"} {
+		_, err := CompileQuery(src)
+		assert.ErrorIs(t, err, ErrInvalidQuery, src)
+	}
+}
+
+func TestQueryWriter(t *testing.T) {
+	query, err := CompileQuery("(.issues[] | {key, points}), .key")
+	require.NoError(t, err)
+	var out bytes.Buffer
+	w := NewQueryWriter(&out, query)
+
+	// A value split across writes is filtered once it is complete
+	_, err = w.Write([]byte(`{"issues": [{"key": "BE-1", "points": 12345678901234567890}], "key": "BE-1"}` + "\n" + `{"issues": [`))
+	require.NoError(t, err)
+	assert.Equal(t, "{\"key\":\"BE-1\",\"points\":12345678901234567890}\nBE-1\n", out.String(), "strings are raw and numbers keep their precision")
+	_, err = w.Write([]byte(`], "key": "BE-2"}`))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	assert.Equal(t, "{\"key\":\"BE-1\",\"points\":12345678901234567890}\nBE-1\nBE-2\n", out.String())
+}
+
+func TestQueryWriter_NotJSON(t *testing.T) {
+	query, err := CompileQuery(".")
+	require.NoError(t, err)
+
+	w := NewQueryWriter(&bytes.Buffer{}, query)
+	_, err = w.Write([]byte("Successfully created JIRA issue:\n"))
+	assert.ErrorIs(t, err, ErrNotJSON)
+	assert.ErrorIs(t, w.Close(), ErrNotJSON, "Close reports the failed write")
+
+	w = NewQueryWriter(&bytes.Buffer{}, query)
+	_, err = w.Write([]byte(`{"key": `))
+	require.NoError(t, err)
+	assert.ErrorIs(t, w.Close(), ErrNotJSON, "incomplete value at the end")
+}
+
+func TestQueryWriter_QueryFails(t *testing.T) {
+	query, err := CompileQuery(".key")
+	require.NoError(t, err)
+	w := NewQueryWriter(&bytes.Buffer{}, query)
+	_, err = w.Write([]byte(`"BE-1"` + "\n"))
+	assert.ErrorIs(t, err, ErrQuery)
+	assert.ErrorIs(t, w.Close(), ErrQuery)
+}
@@ -0,0 +1,88 @@
+// Package output renders command results as text, JSON, YAML or TSV. It is shared by the
+// commands honouring the global -o flag so that every command formats data the same way.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/karolswdev/ticketron/internal/sanitize"
+)
+
+// Supported formats.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+	FormatTSV  = "tsv"
+)
+
+// Normalize returns the canonical name of format. Empty or unknown formats fall back to text.
+func Normalize(format string) string {
+	switch f := strings.ToLower(strings.TrimSpace(format)); f {
+	case FormatJSON, FormatYAML, FormatTSV:
+		return f
+	default:
+		return FormatText
+	}
+}
+
+// IsStructured reports whether format is JSON or YAML.
+func IsStructured(format string) bool {
+	f := Normalize(format)
+	return f == FormatJSON || f == FormatYAML
+}
+
+// JSON writes v as indented JSON followed by a newline.
+func JSON(w io.Writer, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%w as JSON: %w", ErrMarshal, err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// YAML writes v as a YAML document followed by a blank line.
+func YAML(w io.Writer, v interface{}) error {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("%w as YAML: %w", ErrMarshal, err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// Structured writes v as YAML when format is yaml and as JSON otherwise.
+func Structured(w io.Writer, format string, v interface{}) error {
+	if Normalize(format) == FormatYAML {
+		return YAML(w, v)
+	}
+	return JSON(w, v)
+}
+
+// TSV writes a header line followed by one line per row. Cells are passed through
+// sanitize.Line so tabs and line breaks in values cannot break the columns.
+func TSV(w io.Writer, header []string, rows [][]string) error {
+	if _, err := fmt.Fprintln(w, tsvLine(header)); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if _, err := fmt.Fprintln(w, tsvLine(row)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func tsvLine(cells []string) string {
+	clean := make([]string, len(cells))
+	for i, cell := range cells {
+		clean[i] = sanitize.Line(cell)
+	}
+	return strings.Join(clean, "\t")
+}
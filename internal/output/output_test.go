@@ -0,0 +1,40 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, FormatJSON, Normalize(" JSON "))
+	assert.Equal(t, FormatTSV, Normalize("tsv"))
+	assert.Equal(t, FormatText, Normalize(""))
+	assert.Equal(t, FormatText, Normalize("table"))
+	assert.True(t, IsStructured("yaml"))
+	assert.False(t, IsStructured("tsv"))
+}
+
+func TestStructured(t *testing.T) {
+	v := map[string]string{"key": "BE-1"}
+
+	var out bytes.Buffer
+	require.NoError(t, Structured(&out, FormatJSON, v))
+	assert.Equal(t, "{\n  \"key\": \"BE-1\"\n}\n", out.String())
+
+	out.Reset()
+	require.NoError(t, Structured(&out, FormatYAML, v))
+	assert.Equal(t, "key: BE-1\n\n", out.String())
+
+	err := JSON(&out, map[string]interface{}{"bad": make(chan int)})
+	assert.ErrorIs(t, err, ErrMarshal)
+	assert.ErrorContains(t, err, "failed to format output as JSON")
+}
+
+func TestTSV(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, TSV(&out, []string{"key", "summary"}, [][]string{{"BE-1", "Line\tone\nand two"}, {"BE-2", ""}}))
+	assert.Equal(t, "key\tsummary\nBE-1\tLine one and two\nBE-2\t\n", out.String())
+}
@@ -0,0 +1,145 @@
+package output
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Path is a compiled dot-separated field path. Compile it once per invocation and reuse
+// it for every row: the path is split only once and struct fields are resolved once per
+// type instead of once per value. A Path is safe for concurrent use.
+type Path struct {
+	raw   string
+	steps []pathStep
+}
+
+// pathStep is one segment of a Path together with its per-type field resolution cache.
+type pathStep struct {
+	name   string
+	fields *sync.Map // reflect.Type -> []int field index, nil when the type has no such field
+}
+
+// Compile parses path into a reusable accessor. Lookups follow the rules of ValueByPath.
+func Compile(path string) *Path {
+	parts := strings.Split(path, ".")
+	steps := make([]pathStep, len(parts))
+	for i, part := range parts {
+		steps[i] = pathStep{name: part, fields: &sync.Map{}}
+	}
+	return &Path{raw: path, steps: steps}
+}
+
+// CompileAll compiles every path in paths.
+func CompileAll(paths []string) []*Path {
+	compiled := make([]*Path, len(paths))
+	for i, path := range paths {
+		compiled[i] = Compile(path)
+	}
+	return compiled
+}
+
+// Raw returns the path as it was given to Compile.
+func (p *Path) Raw() string {
+	return p.raw
+}
+
+// Value returns the value at the path in data.
+func (p *Path) Value(data interface{}) (interface{}, bool) {
+	current := reflect.ValueOf(data)
+	for i := range p.steps {
+		var ok bool
+		if current, ok = p.steps[i].next(current); !ok {
+			return nil, false
+		}
+	}
+	if !current.IsValid() || !current.CanInterface() {
+		return nil, false
+	}
+	return current.Interface(), true
+}
+
+// Text returns the value at the path formatted with %v, or "" if it is missing.
+func (p *Path) Text(data interface{}) string {
+	value, found := p.Value(data)
+	if !found || value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// next descends from current into the field or map entry named by the step.
+func (s *pathStep) next(current reflect.Value) (reflect.Value, bool) {
+	// Dereference pointers and interfaces
+	for current.Kind() == reflect.Ptr || current.Kind() == reflect.Interface {
+		if current.IsNil() {
+			return reflect.Value{}, false // Cannot traverse nil
+		}
+		current = current.Elem()
+	}
+
+	switch current.Kind() {
+	case reflect.Struct:
+		index := s.fieldIndex(current.Type())
+		if index == nil {
+			return reflect.Value{}, false
+		}
+		field, err := current.FieldByIndexErr(index)
+		if err != nil {
+			return reflect.Value{}, false // Nil embedded pointer
+		}
+		return field, true
+
+	case reflect.Map:
+		keyType := current.Type().Key()
+		if keyType.Kind() != reflect.String {
+			return reflect.Value{}, false
+		}
+		if value := current.MapIndex(reflect.ValueOf(s.name).Convert(keyType)); value.IsValid() {
+			return value, true
+		}
+		iter := current.MapRange()
+		for iter.Next() {
+			if strings.EqualFold(iter.Key().String(), s.name) {
+				return iter.Value(), true
+			}
+		}
+		return reflect.Value{}, false
+
+	default:
+		return reflect.Value{}, false
+	}
+}
+
+// fieldIndex returns the index of the struct field matching the step in t, resolving and
+// caching it on first use.
+func (s *pathStep) fieldIndex(t reflect.Type) []int {
+	if cached, ok := s.fields.Load(t); ok {
+		return cached.([]int)
+	}
+	index := resolveField(t, s.name)
+	s.fields.Store(t, index)
+	return index
+}
+
+// resolveField finds the exported field of t named name, ignoring case, or else the field
+// whose JSON tag is name. Fields tagged json:"-" never match.
+func resolveField(t reflect.Type, name string) []int {
+	if field, ok := t.FieldByNameFunc(func(n string) bool { return strings.EqualFold(n, name) }); ok {
+		if field.IsExported() && field.Tag.Get("json") != "-" {
+			return field.Index
+		}
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		jsonTag := field.Tag.Get("json")
+		if field.IsExported() && jsonTag != "-" && strings.Split(jsonTag, ",")[0] == name {
+			return field.Index
+		}
+	}
+	log.Trace().Str("part", name).Str("type", t.String()).Msg("Field not found by name or JSON tag")
+	return nil
+}
@@ -0,0 +1,98 @@
+package output
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+func TestPath_ReusedAcrossTypes(t *testing.T) {
+	type tagged struct {
+		Name string `json:"display"`
+	}
+	type named struct {
+		Display string
+	}
+	path := Compile("display")
+
+	assert.Equal(t, "display", path.Raw())
+	for i := 0; i < 2; i++ { // The second round is served from the field cache
+		assert.Equal(t, "by tag", path.Text(tagged{Name: "by tag"}))
+		assert.Equal(t, "by name", path.Text(&named{Display: "by name"}))
+		assert.Equal(t, "from map", path.Text(map[string]string{"Display": "from map"}))
+		_, found := path.Value(struct{ Other string }{})
+		assert.False(t, found)
+	}
+}
+
+func TestPath_NilEmbeddedPointer(t *testing.T) {
+	type Inner struct{ Value string }
+	type outer struct{ *Inner }
+
+	path := Compile("value")
+	assert.Equal(t, "x", path.Text(outer{Inner: &Inner{Value: "x"}}))
+	_, found := path.Value(outer{})
+	assert.False(t, found)
+}
+
+func TestPath_ConcurrentUse(t *testing.T) {
+	path := Compile("fields.status.name")
+	issue := mcpclient.Issue{Fields: mcpclient.IssueFields{Status: mcpclient.Status{Name: "Done"}}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, "Done", path.Text(issue))
+		}()
+	}
+	wg.Wait()
+}
+
+// benchmarkIssues returns n issues resembling a large export.
+func benchmarkIssues(n int) []mcpclient.Issue {
+	issues := make([]mcpclient.Issue, n)
+	for i := range issues {
+		issues[i] = mcpclient.Issue{
+			Key: fmt.Sprintf("BE-%d", i),
+			Fields: mcpclient.IssueFields{
+				Summary:   fmt.Sprintf("Issue %d", i),
+				Status:    mcpclient.Status{Name: "In Progress"},
+				IssueType: mcpclient.IssueType{Name: "Bug"},
+			},
+		}
+	}
+	return issues
+}
+
+var benchmarkFields = []string{"key", "fields.summary", "fields.status.name", "fields.issuetype.name"}
+
+func BenchmarkValueByPath(b *testing.B) {
+	issues := benchmarkIssues(1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, issue := range issues {
+			for _, field := range benchmarkFields {
+				ValueByPath(issue, field)
+			}
+		}
+	}
+}
+
+func BenchmarkCompiledPath(b *testing.B) {
+	issues := benchmarkIssues(1000)
+	paths := CompileAll(benchmarkFields)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, issue := range issues {
+			for _, path := range paths {
+				path.Value(issue)
+			}
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Table collects text output in rows and columns. It is rendered either aligned in columns
+// under an upper-case header line or, in plain mode (--plain), as one "Label: value" line
+// per cell with a blank line between rows, which screen readers read in order instead of
+// as space-padded columns.
+type Table struct {
+	Labels []string // Column labels, e.g. "Allowed values"
+	Header bool     // Print the labels above the columns; plain mode always labels cells
+	Indent string   // Prefix for every line
+
+	rows [][]string
+}
+
+// NewTable returns a table with a header line and the given column labels.
+func NewTable(labels ...string) *Table {
+	return &Table{Labels: labels, Header: true}
+}
+
+// Row adds a row. Cells must not contain tabs or line breaks; pass values from Jira or
+// the LLM through sanitize.Line first.
+func (t *Table) Row(cells ...string) {
+	t.rows = append(t.rows, cells)
+}
+
+// Render writes the table to w, in plain mode if plain is set. Empty cells are omitted in
+// plain mode.
+func (t *Table) Render(w io.Writer, plain bool) error {
+	if plain {
+		return t.renderPlain(w)
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if t.Header {
+		fmt.Fprintln(tw, t.Indent+strings.ToUpper(strings.Join(t.Labels, "\t")))
+	}
+	for _, row := range t.rows {
+		fmt.Fprintln(tw, t.Indent+strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func (t *Table) renderPlain(w io.Writer) error {
+	for i, row := range t.rows {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		for j, cell := range row {
+			if cell == "" {
+				continue
+			}
+			label := fmt.Sprintf("Column %d", j+1)
+			if j < len(t.Labels) {
+				label = t.Labels[j]
+			}
+			if _, err := fmt.Fprintf(w, "%s%s: %s\n", t.Indent, label, cell); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTable_Render(t *testing.T) {
+	table := NewTable("Field", "Allowed values")
+	table.Indent = "  "
+	table.Row("priority", "High, Low")
+	table.Row("summary", "")
+
+	var out bytes.Buffer
+	require.NoError(t, table.Render(&out, false))
+	assert.Equal(t, "  FIELD     ALLOWED VALUES\n  priority  High, Low\n  summary   \n", out.String())
+}
+
+func TestTable_RenderPlain(t *testing.T) {
+	table := NewTable("Field", "Allowed values")
+	table.Row("priority", "High, Low", "extra")
+	table.Row("summary", "")
+
+	var out bytes.Buffer
+	require.NoError(t, table.Render(&out, true))
+	assert.Equal(t, "Field: priority\nAllowed values: High, Low\nColumn 3: extra\n\nField: summary\n", out.String())
+}
+
+func TestTable_NoHeader(t *testing.T) {
+	table := &Table{Labels: []string{"Status", "Check"}}
+	table.Row("[OK]", "Configuration")
+
+	var out bytes.Buffer
+	require.NoError(t, table.Render(&out, false))
+	assert.Equal(t, "[OK]  Configuration\n", out.String())
+}
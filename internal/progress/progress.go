@@ -0,0 +1,105 @@
+// Package progress shows what a long-running operation (an LLM call, a Jira request) is
+// doing while the user waits.
+package progress
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultInterval is the time between two frames of the animation.
+const DefaultInterval = 100 * time.Millisecond
+
+// frames are the animation frames drawn in front of the message.
+var frames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// barWidth is the number of cells of the bar drawn by StartBar.
+const barWidth = 20
+
+// Spinner draws an animated status line such as "⠋ Contacting LLM… (3s)" and redraws it in
+// place, so it must only write to a terminal. A nil *Spinner is valid and shows nothing,
+// which lets callers use one unconditionally. Only one operation is shown at a time.
+type Spinner struct {
+	w        io.Writer
+	interval time.Duration
+}
+
+// New returns a spinner writing to w, or nil if enabled is false.
+func New(w io.Writer, enabled bool) *Spinner {
+	if !enabled {
+		return nil
+	}
+	return &Spinner{w: w, interval: DefaultInterval}
+}
+
+// Start shows msg with the time elapsed since the call until the returned function is
+// called. Stopping clears the line, so output written afterwards starts at its beginning;
+// it waits for the last frame to be drawn and may be called more than once.
+func (s *Spinner) Start(msg string) (stop func()) {
+	return s.start(func(frame int, elapsed time.Duration) string {
+		return fmt.Sprintf("%s %s (%ds)", frames[frame%len(frames)], msg, int(elapsed.Seconds()))
+	})
+}
+
+// StartBar shows msg with a bar of how many of the items of an operation are done, e.g.
+// "[██████░░░░░░░░░░░░░░] 1200/4000 Exporting issues… (3s)", until stop is called. update
+// sets the counts and may be called from any goroutine; while total is unknown (0), the
+// animation is shown with the count of done items instead of the bar.
+func (s *Spinner) StartBar(msg string) (update func(done, total int), stop func()) {
+	if s == nil {
+		return func(int, int) {}, func() {}
+	}
+	var mu sync.Mutex
+	var done, total int
+	update = func(d, t int) {
+		mu.Lock()
+		defer mu.Unlock()
+		done, total = d, t
+	}
+	stop = s.start(func(frame int, elapsed time.Duration) string {
+		mu.Lock()
+		defer mu.Unlock()
+		if total <= 0 {
+			return fmt.Sprintf("%s %s %d (%ds)", frames[frame%len(frames)], msg, done, int(elapsed.Seconds()))
+		}
+		filled := min(barWidth, barWidth*done/total)
+		bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
+		return fmt.Sprintf("[%s] %d/%d %s (%ds)", bar, done, total, msg, int(elapsed.Seconds()))
+	})
+	return update, stop
+}
+
+// start redraws the line returned by render for the current frame and elapsed time until
+// the returned function is called.
+func (s *Spinner) start(render func(frame int, elapsed time.Duration) string) (stop func()) {
+	if s == nil {
+		return func() {}
+	}
+	start := time.Now()
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for i := 0; ; i++ {
+			fmt.Fprintf(s.w, "\r%s\x1b[K", render(i, time.Since(start)))
+			select {
+			case <-done:
+				fmt.Fprint(s.w, "\r\x1b[K")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			<-finished
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package progress
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpinner(t *testing.T) {
+	var out bytes.Buffer
+	s := New(&out, true)
+	s.interval = time.Millisecond
+
+	stop := s.Start("Contacting LLM…")
+	time.Sleep(20 * time.Millisecond)
+	stop()
+	stop() // Stopping twice is harmless
+
+	written := out.String()
+	assert.True(t, strings.HasPrefix(written, "\r⠋ Contacting LLM… (0s)\x1b[K"), written)
+	assert.Contains(t, written, "\r⠙ Contacting LLM… (0s)\x1b[K", "the frame advances")
+	assert.True(t, strings.HasSuffix(written, "\r\x1b[K"), "stopping clears the line")
+
+	out.Reset()
+	time.Sleep(5 * time.Millisecond)
+	assert.Empty(t, out.String(), "nothing is drawn after stopping")
+}
+
+func TestSpinner_Disabled(t *testing.T) {
+	var out bytes.Buffer
+	s := New(&out, false)
+	assert.Nil(t, s)
+
+	s.Start("Creating issue…")()
+	assert.Empty(t, out.String())
+}
+
+func TestSpinner_StartBar(t *testing.T) {
+	var out bytes.Buffer
+	s := New(&out, true)
+	s.interval = time.Millisecond
+
+	update, stop := s.StartBar("Exporting issues…")
+	time.Sleep(5 * time.Millisecond)
+	update(100, 0)
+	time.Sleep(5 * time.Millisecond)
+	update(1000, 4000)
+	time.Sleep(5 * time.Millisecond)
+	update(4000, 4000)
+	time.Sleep(5 * time.Millisecond)
+	stop()
+
+	written := out.String()
+	assert.True(t, strings.HasPrefix(written, "\r⠋ Exporting issues… 0 (0s)\x1b[K"), written)
+	assert.Contains(t, written, " Exporting issues… 100 (0s)\x1b[K", "the count is shown while the total is unknown")
+	assert.Contains(t, written, "\r[█████░░░░░░░░░░░░░░░] 1000/4000 Exporting issues… (0s)\x1b[K")
+	assert.Contains(t, written, "\r[████████████████████] 4000/4000 Exporting issues… (0s)\x1b[K")
+	assert.True(t, strings.HasSuffix(written, "\r\x1b[K"), "stopping clears the line")
+}
+
+func TestSpinner_StartBarDisabled(t *testing.T) {
+	var s *Spinner
+	update, stop := s.StartBar("Exporting issues…")
+	update(1, 2)
+	stop()
+}
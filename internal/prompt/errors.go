@@ -0,0 +1,12 @@
+package prompt
+
+import "errors"
+
+// Sentinel errors for confirmation prompts.
+
+// ErrInputRequired indicates a confirmation was needed but prompting is disabled
+// (--no-input) and the default answer is no.
+var ErrInputRequired = errors.New("confirmation required but prompting is disabled (--no-input); use --yes to proceed")
+
+// ErrRead indicates the answer could not be read.
+var ErrRead = errors.New("failed to read confirmation")
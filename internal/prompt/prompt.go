@@ -0,0 +1,81 @@
+// Package prompt asks the user to confirm actions. Questions and answers are line based,
+// so they work with screen readers and piped input alike, and the global --yes and
+// --no-input flags let scripts skip them.
+package prompt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/karolswdev/ticketron/internal/i18n"
+)
+
+// Confirmer asks yes/no and free-text questions, reading one line of In per question.
+type Confirmer struct {
+	In        io.Reader
+	AssumeYes bool // Answer yes without asking (--yes)
+	NoInput   bool // Never read In; questions take their default answer (--no-input)
+
+	reader *bufio.Reader
+}
+
+// Confirm writes question with a [y/N] or [Y/n] hint to out and returns the answer. "y",
+// "yes" and their translation (see i18n.IsYes) confirm, an empty answer or end of input
+// selects def and anything else declines.
+//
+// With AssumeYes the question is not shown and the answer is yes. With NoInput it is not
+// shown either and def is returned, or ErrInputRequired if def is no, so that scripts fail
+// instead of silently skipping the action.
+func (c *Confirmer) Confirm(out io.Writer, question string, def bool) (bool, error) {
+	if c.AssumeYes {
+		return true, nil
+	}
+	if c.NoInput {
+		if !def {
+			return false, ErrInputRequired
+		}
+		return true, nil
+	}
+
+	hint := i18n.T(i18n.MsgYesNoHint)
+	if def {
+		hint = i18n.T(i18n.MsgDefaultYesHint)
+	}
+	fmt.Fprintf(out, "%s %s: ", question, hint)
+
+	answer, err := c.readLine()
+	if err != nil {
+		return false, err
+	}
+	if answer == "" {
+		return def, nil
+	}
+	return i18n.IsYes(answer), nil
+}
+
+// Ask writes question to out and returns the trimmed line typed in reply. An empty answer
+// or end of input returns "". With AssumeYes or NoInput nothing is asked and "" is returned,
+// so callers treat the empty answer as accepting what was shown.
+func (c *Confirmer) Ask(out io.Writer, question string) (string, error) {
+	if c.AssumeYes || c.NoInput {
+		return "", nil
+	}
+	fmt.Fprintf(out, "%s ", question)
+	return c.readLine()
+}
+
+// readLine reads one answer from In.
+func (c *Confirmer) readLine() (string, error) {
+	if c.reader == nil {
+		// Kept across questions so that input buffered for later answers is not lost
+		c.reader = bufio.NewReader(c.In)
+	}
+	answer, err := c.reader.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("%w: %w", ErrRead, err)
+	}
+	return strings.TrimSpace(answer), nil
+}
@@ -0,0 +1,97 @@
+package prompt
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		def      bool
+		wantHint string
+		want     bool
+	}{
+		{"yes", "y\n", false, "[y/N]", true},
+		{"yes in full", " YES \n", false, "[y/N]", true},
+		{"no", "n\n", true, "[Y/n]", false},
+		{"anything else declines", "sure\n", true, "[Y/n]", false},
+		{"empty selects default no", "\n", false, "[y/N]", false},
+		{"empty selects default yes", "\n", true, "[Y/n]", true},
+		{"end of input selects default", "", true, "[Y/n]", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			c := &Confirmer{In: strings.NewReader(tt.input)}
+			got, err := c.Confirm(&out, "Proceed?", tt.def)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, "Proceed? "+tt.wantHint+": ", out.String())
+		})
+	}
+}
+
+func TestConfirm_ReadsOneLinePerQuestion(t *testing.T) {
+	c := &Confirmer{In: strings.NewReader("n\ny\n")}
+	first, err := c.Confirm(&bytes.Buffer{}, "First?", false)
+	require.NoError(t, err)
+	second, err := c.Confirm(&bytes.Buffer{}, "Second?", false)
+	require.NoError(t, err)
+	assert.False(t, first)
+	assert.True(t, second)
+}
+
+func TestConfirm_Flags(t *testing.T) {
+	var out bytes.Buffer
+	ok, err := (&Confirmer{AssumeYes: true, NoInput: true}).Confirm(&out, "Delete?", false)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = (&Confirmer{NoInput: true}).Confirm(&out, "Continue?", true)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	_, err = (&Confirmer{NoInput: true}).Confirm(&out, "Delete?", false)
+	assert.ErrorIs(t, err, ErrInputRequired)
+	assert.Empty(t, out.String(), "no question is shown")
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("broken pipe") }
+
+func TestConfirm_ReadError(t *testing.T) {
+	_, err := (&Confirmer{In: failingReader{}}).Confirm(&bytes.Buffer{}, "Delete?", false)
+	assert.ErrorIs(t, err, ErrRead)
+}
+
+func TestAsk(t *testing.T) {
+	var out bytes.Buffer
+	c := &Confirmer{In: strings.NewReader("  shorter summary \ny\n")}
+
+	answer, err := c.Ask(&out, "Refine:")
+	require.NoError(t, err)
+	assert.Equal(t, "shorter summary", answer)
+	assert.Equal(t, "Refine: ", out.String())
+
+	ok, err := c.Confirm(&out, "Create?", false)
+	require.NoError(t, err)
+	assert.True(t, ok, "answers share the buffered input")
+
+	answer, err = c.Ask(&out, "Refine:")
+	require.NoError(t, err)
+	assert.Empty(t, answer, "end of input is an empty answer")
+
+	out.Reset()
+	answer, err = (&Confirmer{NoInput: true}).Ask(&out, "Refine:")
+	require.NoError(t, err)
+	assert.Empty(t, answer)
+	assert.Empty(t, out.String(), "no question is shown")
+}
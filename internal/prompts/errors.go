@@ -0,0 +1,17 @@
+package prompts
+
+import "errors"
+
+// Sentinel errors for prompt version operations.
+
+// ErrVersionNotFound indicates the requested prompt version does not exist.
+var ErrVersionNotFound = errors.New("prompt version not found")
+
+// ErrInvalidName indicates a prompt version name contains unsupported characters.
+var ErrInvalidName = errors.New("invalid prompt version name")
+
+// ErrPromptRead indicates an error occurred while reading a prompt file.
+var ErrPromptRead = errors.New("failed to read prompt")
+
+// ErrPromptWrite indicates an error occurred while writing a prompt file.
+var ErrPromptWrite = errors.New("failed to write prompt")
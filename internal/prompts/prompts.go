@@ -0,0 +1,176 @@
+// Package prompts manages named versions of the LLM system prompt stored under
+// ~/.ticketron/prompts/<name>.txt. Activating a version copies it to system_prompt.txt,
+// so every command keeps reading the system prompt from the usual place.
+package prompts
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/karolswdev/ticketron/internal/config"
+)
+
+const (
+	// DirName is the directory within the config directory holding prompt versions.
+	DirName = "prompts"
+	// activeFileName records the name of the most recently activated version.
+	activeFileName = ".active"
+	// fileExt is the extension of prompt version files.
+	fileExt = ".txt"
+)
+
+// validName restricts version names to safe file names.
+var validName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Version describes a stored prompt version.
+type Version struct {
+	Name   string
+	Path   string
+	Active bool // The version is active and system_prompt.txt still matches it
+}
+
+// Library provides access to the prompt versions in a config directory.
+type Library struct {
+	configDir string
+}
+
+// New returns a Library for configDir.
+func New(configDir string) *Library {
+	return &Library{configDir: configDir}
+}
+
+func (l *Library) dir() string {
+	return filepath.Join(l.configDir, DirName)
+}
+
+func (l *Library) versionPath(name string) string {
+	return filepath.Join(l.dir(), name+fileExt)
+}
+
+func (l *Library) systemPromptPath() string {
+	return filepath.Join(l.configDir, config.DefaultPromptFileName)
+}
+
+// List returns all stored versions sorted by name. A missing prompts directory yields none.
+func (l *Library) List() ([]Version, error) {
+	entries, err := os.ReadDir(l.dir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w: %w", ErrPromptRead, err)
+	}
+	active, err := l.Active()
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []Version
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != fileExt {
+			continue
+		}
+		name := strings.TrimSuffix(e.Name(), fileExt)
+		versions = append(versions, Version{Name: name, Path: l.versionPath(name), Active: name == active})
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Name < versions[j].Name })
+	return versions, nil
+}
+
+// Load returns the content of the named version.
+func (l *Library) Load(name string) (string, error) {
+	if !validName.MatchString(name) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidName, name)
+	}
+	data, err := os.ReadFile(l.versionPath(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("%w: %s", ErrVersionNotFound, name)
+		}
+		return "", fmt.Errorf("%w: %w", ErrPromptRead, err)
+	}
+	return string(data), nil
+}
+
+// Save stores content as the named version, overwriting an existing version of that name.
+func (l *Library) Save(name, content string) error {
+	if !validName.MatchString(name) {
+		return fmt.Errorf("%w: %q (use letters, digits, '.', '-' and '_')", ErrInvalidName, name)
+	}
+	if err := os.MkdirAll(l.dir(), 0700); err != nil {
+		return fmt.Errorf("%w: %w", ErrPromptWrite, err)
+	}
+	if err := os.WriteFile(l.versionPath(name), []byte(content), 0600); err != nil {
+		return fmt.Errorf("%w: %w", ErrPromptWrite, err)
+	}
+	log.Debug().Str("version", name).Msg("Saved prompt version")
+	return nil
+}
+
+// Use activates the named version by copying it to system_prompt.txt.
+func (l *Library) Use(name string) error {
+	content, err := l.Load(name)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(l.systemPromptPath(), []byte(content), 0600); err != nil {
+		return fmt.Errorf("%w: %w", ErrPromptWrite, err)
+	}
+	if err := os.WriteFile(filepath.Join(l.dir(), activeFileName), []byte(name+"\n"), 0600); err != nil {
+		return fmt.Errorf("%w: %w", ErrPromptWrite, err)
+	}
+	log.Info().Str("version", name).Msg("Activated prompt version")
+	return nil
+}
+
+// Active returns the name of the active version, or "" if no version was activated or
+// system_prompt.txt has been edited since.
+func (l *Library) Active() (string, error) {
+	data, err := os.ReadFile(filepath.Join(l.dir(), activeFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("%w: %w", ErrPromptRead, err)
+	}
+	name := strings.TrimSpace(string(data))
+	current, err := os.ReadFile(l.systemPromptPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("%w: %w", ErrPromptRead, err)
+	}
+	content, err := l.Load(name)
+	if err != nil || content != string(current) {
+		return "", nil
+	}
+	return name, nil
+}
+
+// Identify returns the name of the version whose content equals systemPrompt, preferring
+// the active version, or "" if the prompt does not match any stored version.
+func (l *Library) Identify(systemPrompt string) (string, error) {
+	if active, err := l.Active(); err == nil && active != "" {
+		if content, err := l.Load(active); err == nil && content == systemPrompt {
+			return active, nil
+		}
+	}
+	versions, err := l.List()
+	if err != nil {
+		return "", err
+	}
+	for _, v := range versions {
+		content, err := l.Load(v.Name)
+		if err == nil && content == systemPrompt {
+			return v.Name, nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,53 @@
+package prompts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/config"
+)
+
+func TestLibrary(t *testing.T) {
+	dir := t.TempDir()
+	lib := New(dir)
+
+	versions, err := lib.List()
+	require.NoError(t, err)
+	assert.Empty(t, versions)
+
+	require.NoError(t, lib.Save("v1", "Be terse."))
+	require.NoError(t, lib.Save("v2", "Be detailed."))
+	assert.ErrorIs(t, lib.Save("../evil", "x"), ErrInvalidName)
+
+	_, err = lib.Load("v3")
+	assert.ErrorIs(t, err, ErrVersionNotFound)
+
+	require.NoError(t, lib.Use("v2"))
+	current, err := os.ReadFile(filepath.Join(dir, config.DefaultPromptFileName))
+	require.NoError(t, err)
+	assert.Equal(t, "Be detailed.", string(current))
+
+	versions, err = lib.List()
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, "v1", versions[0].Name)
+	assert.False(t, versions[0].Active)
+	assert.True(t, versions[1].Active)
+
+	name, err := lib.Identify("Be terse.")
+	require.NoError(t, err)
+	assert.Equal(t, "v1", name)
+
+	// Editing system_prompt.txt by hand deactivates the version.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, config.DefaultPromptFileName), []byte("Custom."), 0600))
+	active, err := lib.Active()
+	require.NoError(t, err)
+	assert.Empty(t, active)
+	name, err = lib.Identify("Custom.")
+	require.NoError(t, err)
+	assert.Empty(t, name)
+}
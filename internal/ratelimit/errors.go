@@ -0,0 +1,8 @@
+package ratelimit
+
+import "errors"
+
+// Sentinel errors for rate limiting.
+
+// ErrInvalidConfig indicates a rate limit configuration with a negative rate or burst.
+var ErrInvalidConfig = errors.New("invalid rate limit configuration")
@@ -0,0 +1,169 @@
+// Package ratelimit limits the rate of outbound HTTP requests with a token bucket
+// (mcp.rate_limit and llm.rate_limits in config.yaml), so that bulk operations and the
+// tix serve daemon stay below the rate limits of the servers they call. Limiters are
+// shared process-wide by name, so concurrent workers draw from the same bucket.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/karolswdev/ticketron/internal/config"
+)
+
+// Validate checks that the rate and burst of cfg are not negative.
+func Validate(cfg config.RateLimitConfig) error {
+	if cfg.RequestsPerSecond < 0 || math.IsNaN(cfg.RequestsPerSecond) || math.IsInf(cfg.RequestsPerSecond, 0) {
+		return fmt.Errorf("%w: requests_per_second must be a non-negative number, got %v", ErrInvalidConfig, cfg.RequestsPerSecond)
+	}
+	if cfg.Burst < 0 {
+		return fmt.Errorf("%w: burst must not be negative, got %d", ErrInvalidConfig, cfg.Burst)
+	}
+	return nil
+}
+
+// Limiter is a token bucket holding up to burst tokens, refilled at rate tokens per
+// second. Every request takes one token, waiting for it if the bucket is empty. It is
+// safe for concurrent use; waiting requests are served in the order they arrived.
+type Limiter struct {
+	rate  float64
+	burst float64
+	now   func() time.Time
+
+	mu     sync.Mutex
+	tokens float64 // Negative while requests wait for tokens they have reserved
+	last   time.Time
+}
+
+// New returns a limiter for cfg, or nil if cfg sets no limit.
+func New(cfg config.RateLimitConfig) (*Limiter, error) {
+	if err := Validate(cfg); err != nil {
+		return nil, err
+	}
+	if cfg.RequestsPerSecond == 0 {
+		return nil, nil
+	}
+	burst := float64(cfg.Burst)
+	if burst == 0 {
+		burst = math.Max(1, math.Ceil(cfg.RequestsPerSecond))
+	}
+	return &Limiter{rate: cfg.RequestsPerSecond, burst: burst, tokens: burst, now: time.Now}, nil
+}
+
+// Wait takes a token, waiting until one is available or ctx is done. A nil limiter
+// never waits.
+func (l *Limiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	delay := l.reserve()
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.cancel()
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token and returns how long to wait until it is available.
+func (l *Limiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel returns the token of a request that stopped waiting for it.
+func (l *Limiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens = math.Min(l.burst, l.tokens+1)
+}
+
+// shared holds the limiters of Shared by name and configuration.
+var (
+	sharedMu sync.Mutex
+	shared   = make(map[sharedKey]*Limiter)
+)
+
+type sharedKey struct {
+	name string
+	cfg  config.RateLimitConfig
+}
+
+// Shared returns the process-wide limiter for name and cfg, creating it on first use, or
+// nil if cfg sets no limit. Clients built again with the same configuration, e.g. when
+// tix serve reloads it, keep drawing from the same bucket.
+func Shared(name string, cfg config.RateLimitConfig) (*Limiter, error) {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	key := sharedKey{name: name, cfg: cfg}
+	if limiter, ok := shared[key]; ok {
+		return limiter, nil
+	}
+	limiter, err := New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if limiter != nil {
+		log.Debug().Str("client", name).Float64("requests_per_second", limiter.rate).Float64("burst", limiter.burst).Msg("Rate limiting requests")
+		shared[key] = limiter
+	}
+	return limiter, nil
+}
+
+// Transport is an http.RoundTripper that waits for its limiter before passing requests on
+// to the wrapped transport.
+type Transport struct {
+	next    http.RoundTripper
+	limiter *Limiter
+	name    string
+}
+
+// Wrap returns next with requests limited by limiter, or next unchanged if limiter is nil.
+// name identifies the client in log messages ("mcp", "llm:openai"). A nil next stands for
+// http.DefaultTransport.
+func Wrap(next http.RoundTripper, limiter *Limiter, name string) http.RoundTripper {
+	if limiter == nil {
+		return next
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Transport{next: next, limiter: limiter, name: name}
+}
+
+// RoundTrip waits for a token, then passes the request on.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, err
+	}
+	if waited := time.Since(start); waited > 10*time.Millisecond {
+		log.Debug().Str("client", t.name).Str("url", req.URL.Redacted()).Dur("waited", waited).Msg("Request delayed by rate limit")
+	}
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,121 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/config"
+)
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate(config.RateLimitConfig{}))
+	assert.NoError(t, Validate(config.RateLimitConfig{RequestsPerSecond: 0.5, Burst: 3}))
+	assert.ErrorIs(t, Validate(config.RateLimitConfig{RequestsPerSecond: -1}), ErrInvalidConfig)
+	assert.ErrorIs(t, Validate(config.RateLimitConfig{RequestsPerSecond: math.NaN()}), ErrInvalidConfig)
+	assert.ErrorIs(t, Validate(config.RateLimitConfig{RequestsPerSecond: 1, Burst: -1}), ErrInvalidConfig)
+}
+
+func TestNew_Disabled(t *testing.T) {
+	limiter, err := New(config.RateLimitConfig{Burst: 5})
+	require.NoError(t, err)
+	assert.Nil(t, limiter)
+	assert.NoError(t, limiter.Wait(context.Background()), "a nil limiter never waits")
+}
+
+func TestLimiter_Reserve(t *testing.T) {
+	limiter, err := New(config.RateLimitConfig{RequestsPerSecond: 2, Burst: 2})
+	require.NoError(t, err)
+	now := time.Date(2025, time.April, 16, 12, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+
+	assert.Zero(t, limiter.reserve(), "the burst is available at once")
+	assert.Zero(t, limiter.reserve())
+	assert.Equal(t, 500*time.Millisecond, limiter.reserve())
+	assert.Equal(t, time.Second, limiter.reserve(), "waiting requests queue up")
+
+	limiter.cancel()
+	assert.Equal(t, time.Second, limiter.reserve(), "a cancelled wait returns its token")
+	now = now.Add(time.Hour)
+	assert.Zero(t, limiter.reserve())
+	assert.Zero(t, limiter.reserve())
+	assert.Positive(t, limiter.reserve(), "tokens do not accumulate beyond the burst")
+}
+
+func TestLimiter_DefaultBurst(t *testing.T) {
+	limiter, err := New(config.RateLimitConfig{RequestsPerSecond: 2.5})
+	require.NoError(t, err)
+	assert.Equal(t, 3.0, limiter.burst)
+	limiter, err = New(config.RateLimitConfig{RequestsPerSecond: 0.1})
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, limiter.burst)
+}
+
+func TestLimiter_WaitCancelled(t *testing.T) {
+	limiter, err := New(config.RateLimitConfig{RequestsPerSecond: 0.01, Burst: 1})
+	require.NoError(t, err)
+	require.NoError(t, limiter.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, limiter.Wait(ctx), context.DeadlineExceeded)
+}
+
+func TestShared(t *testing.T) {
+	cfg := config.RateLimitConfig{RequestsPerSecond: 7}
+	first, err := Shared("test", cfg)
+	require.NoError(t, err)
+	again, err := Shared("test", cfg)
+	require.NoError(t, err)
+	assert.Same(t, first, again)
+	other, err := Shared("other", cfg)
+	require.NoError(t, err)
+	assert.NotSame(t, first, other)
+
+	none, err := Shared("test", config.RateLimitConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, none)
+	_, err = Shared("test", config.RateLimitConfig{RequestsPerSecond: -1})
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
+
+func TestTransport(t *testing.T) {
+	var mu sync.Mutex
+	var hits []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits = append(hits, time.Now())
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	limiter, err := New(config.RateLimitConfig{RequestsPerSecond: 50, Burst: 1})
+	require.NoError(t, err)
+	client := &http.Client{Transport: Wrap(nil, limiter, "test")}
+
+	// Concurrent workers share the bucket
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL)
+			if assert.NoError(t, err) {
+				resp.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+	require.Len(t, hits, 4)
+	assert.GreaterOrEqual(t, time.Since(start), 55*time.Millisecond, "3 requests waited 20ms each")
+
+	assert.Equal(t, http.DefaultTransport, Wrap(http.DefaultTransport, nil, "test"), "no limiter, no wrapper")
+}
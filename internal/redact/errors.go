@@ -0,0 +1,11 @@
+package redact
+
+import "errors"
+
+// Sentinel errors for redaction.
+
+// ErrInvalidRule indicates a redaction rule is incomplete or its pattern does not compile.
+var ErrInvalidRule = errors.New("invalid redaction rule")
+
+// ErrUnknownBuiltin indicates a configured built-in rule name does not exist.
+var ErrUnknownBuiltin = errors.New("unknown built-in redaction rule")
@@ -0,0 +1,111 @@
+// Package redact scrubs sensitive data (emails, API keys, internal hostnames, ...) from
+// text before it leaves the machine, e.g. in prompts sent to external LLM providers.
+package redact
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/karolswdev/ticketron/internal/config"
+)
+
+// builtinPatterns are the rules available by name in redaction.builtins.
+var builtinPatterns = map[string]string{
+	"email":        `[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`,
+	"api_key":      `\bsk-[A-Za-z0-9_-]{16,}\b`,
+	"aws_key":      `\b(?:AKIA|ASIA)[A-Z0-9]{16}\b`,
+	"github_token": `\bgh[pousr]_[A-Za-z0-9]{20,}\b`,
+	"bearer_token": `(?i)\bbearer\s+[A-Za-z0-9._~+/-]{16,}=*`,
+	"ipv4":         `\b(?:\d{1,3}\.){3}\d{1,3}\b`,
+}
+
+// BuiltinNames returns the names of all built-in rules, sorted.
+func BuiltinNames() []string {
+	names := make([]string, 0, len(builtinPatterns))
+	for name := range builtinPatterns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type rule struct {
+	name        string
+	re          *regexp.Regexp
+	replacement string
+}
+
+// Redaction describes one replaced occurrence.
+type Redaction struct {
+	Rule        string
+	Original    string
+	Replacement string
+}
+
+// Redactor applies an ordered set of rules. A nil *Redactor leaves text unchanged.
+type Redactor struct {
+	rules []rule
+}
+
+// New builds a Redactor from configuration. It returns nil (no redaction) when redaction
+// is disabled, and an error if a rule is unknown or fails to compile.
+func New(cfg config.RedactionConfig) (*Redactor, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	builtins := cfg.Builtins
+	if len(builtins) == 0 {
+		builtins = BuiltinNames()
+	}
+	return Compile(builtins, cfg.Patterns)
+}
+
+// Compile builds a Redactor from exactly the given built-in rules and custom patterns,
+// for callers that match text against their own selection of rules.
+func Compile(builtins []string, patterns []config.RedactionPattern) (*Redactor, error) {
+	r := &Redactor{}
+	// Custom patterns run first so they can claim text (e.g. internal hostnames) before
+	// broader built-ins match parts of it.
+	for _, p := range patterns {
+		if p.Name == "" || p.Pattern == "" {
+			return nil, fmt.Errorf("%w: custom patterns need a name and a pattern", ErrInvalidRule)
+		}
+		re, err := regexp.Compile(p.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrInvalidRule, p.Name, err)
+		}
+		replacement := p.Replacement
+		if replacement == "" {
+			replacement = defaultReplacement(p.Name)
+		}
+		r.rules = append(r.rules, rule{name: p.Name, re: re, replacement: replacement})
+	}
+	for _, name := range builtins {
+		pattern, ok := builtinPatterns[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q (available: %v)", ErrUnknownBuiltin, name, BuiltinNames())
+		}
+		r.rules = append(r.rules, rule{name: name, re: regexp.MustCompile(pattern), replacement: defaultReplacement(name)})
+	}
+	return r, nil
+}
+
+func defaultReplacement(name string) string {
+	return "[REDACTED:" + name + "]"
+}
+
+// Redact returns text with every rule applied, along with the individual replacements made.
+func (r *Redactor) Redact(text string) (string, []Redaction) {
+	if r == nil || text == "" {
+		return text, nil
+	}
+	var redactions []Redaction
+	for _, ru := range r.rules {
+		text = ru.re.ReplaceAllStringFunc(text, func(match string) string {
+			redactions = append(redactions, Redaction{Rule: ru.name, Original: match, Replacement: ru.replacement})
+			return ru.replacement
+		})
+	}
+	return text, redactions
+}
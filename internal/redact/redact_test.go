@@ -0,0 +1,56 @@
+package redact
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/config"
+)
+
+func TestNew(t *testing.T) {
+	r, err := New(config.RedactionConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, r, "disabled redaction yields a nil Redactor")
+
+	_, err = New(config.RedactionConfig{Enabled: true, Builtins: []string{"phone"}})
+	assert.ErrorIs(t, err, ErrUnknownBuiltin)
+
+	_, err = New(config.RedactionConfig{Enabled: true, Patterns: []config.RedactionPattern{{Name: "bad", Pattern: "("}}})
+	assert.ErrorIs(t, err, ErrInvalidRule)
+}
+
+func TestRedact(t *testing.T) {
+	r, err := New(config.RedactionConfig{
+		Enabled: true,
+		Patterns: []config.RedactionPattern{
+			{Name: "internal_host", Pattern: `\b[a-z0-9-]+\.corp\.example\.com\b`, Replacement: "[HOST]"},
+		},
+	})
+	require.NoError(t, err)
+
+	input := "jane.doe@example.com says db1.corp.example.com rejects key sk-abcdefghijklmnopqrstuv from 10.0.0.12"
+	out, redactions := r.Redact(input)
+	assert.Equal(t, "[REDACTED:email] says [HOST] rejects key [REDACTED:api_key] from [REDACTED:ipv4]", out)
+	require.Len(t, redactions, 4)
+	assert.Equal(t, Redaction{Rule: "internal_host", Original: "db1.corp.example.com", Replacement: "[HOST]"}, redactions[0])
+
+	var nilRedactor *Redactor
+	out, redactions = nilRedactor.Redact(input)
+	assert.Equal(t, input, out)
+	assert.Empty(t, redactions)
+}
+
+func TestCompile(t *testing.T) {
+	r, err := Compile(nil, nil)
+	require.NoError(t, err)
+	text, redactions := r.Redact("mail jane@example.com from 10.0.0.1")
+	assert.Equal(t, "mail jane@example.com from 10.0.0.1", text, "no rules are selected by default")
+	assert.Empty(t, redactions)
+
+	r, err = Compile([]string{"ipv4"}, nil)
+	require.NoError(t, err)
+	text, _ = r.Redact("mail jane@example.com from 10.0.0.1")
+	assert.Equal(t, "mail jane@example.com from [REDACTED:ipv4]", text)
+}
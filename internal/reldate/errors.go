@@ -0,0 +1,8 @@
+package reldate
+
+import "errors"
+
+// Sentinel errors for parsing relative dates.
+
+// ErrUnrecognized indicates the input is not a supported date or relative date expression.
+var ErrUnrecognized = errors.New("unrecognized date")
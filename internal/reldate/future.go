@@ -0,0 +1,106 @@
+package reldate
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	inRe        = regexp.MustCompile(`^in\s+(\d+|an?)\s*(day|week|month|year)s?$`)
+	laterRe     = regexp.MustCompile(`^(?:in\s+)?(\d+|an?)\s*(minute|min|m|hour|h|day|d|week|w|month|year)s?$`)
+	timeLayouts = []string{time.RFC3339, "2006-01-02 15:04", "2006/01/02 15:04"}
+)
+
+// ParseDate resolves s, an expression of a day that is usually in the future such as a
+// due date, relative to now and returns the start of that day in now's location.
+//
+// Supported forms (case-insensitive):
+//   - today, tomorrow
+//   - friday (the next Friday, today included) and "next friday" (after today)
+//   - "in 3 days", "in a week", "in 2 months" and 3d, 2w
+//   - end of week (Friday), end of month, end of year, next week (Monday), next month
+//   - absolute dates: 2025-04-01, 2025/04/01, "2025-04-01 13:00" and RFC 3339
+func ParseDate(s string, now time.Time) (time.Time, error) {
+	input := strings.Join(strings.Fields(strings.ToLower(s)), " ")
+	loc := now.Location()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	switch input {
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "end of week":
+		return today.AddDate(0, 0, daysUntil(today.Weekday(), time.Friday)), nil
+	case "next week":
+		return startOfWeek(today).AddDate(0, 0, 7), nil
+	case "end of month":
+		return time.Date(now.Year(), now.Month()+1, 0, 0, 0, 0, 0, loc), nil
+	case "next month":
+		return time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, loc), nil
+	case "end of year":
+		return time.Date(now.Year(), time.December, 31, 0, 0, 0, 0, loc), nil
+	}
+
+	if day, ok := weekdays[input]; ok {
+		return today.AddDate(0, 0, daysUntil(today.Weekday(), day)), nil
+	}
+	if name, ok := strings.CutPrefix(input, "next "); ok {
+		if day, ok := weekdays[name]; ok {
+			ahead := daysUntil(today.Weekday(), day)
+			if ahead == 0 {
+				ahead = 7
+			}
+			return today.AddDate(0, 0, ahead), nil
+		}
+	}
+
+	if m := offsetRe.FindStringSubmatch(input); m != nil && (m[2] == "d" || m[2] == "w") {
+		n, _ := strconv.Atoi(m[1])
+		return subtract(today, -n, m[2]), nil
+	}
+	if m := inRe.FindStringSubmatch(input); m != nil {
+		n := 1
+		if m[1] != "a" && m[1] != "an" {
+			n, _ = strconv.Atoi(m[1])
+		}
+		return subtract(today, -n, m[2]), nil
+	}
+
+	for _, layout := range absoluteLayouts {
+		if t, err := time.ParseInLocation(layout, strings.TrimSpace(s), loc); err == nil {
+			t = t.In(loc)
+			return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: %q", ErrUnrecognized, s)
+}
+
+// ParseTime resolves s, an expression of a future instant such as a reminder, relative to
+// now. Offsets keep the time of day: "in 3d", 2h, "in 30 minutes", "in a week". Absolute
+// times such as "2025-04-01 13:00" are kept as given; every other form accepted by
+// ParseDate resolves to the start of that day.
+func ParseTime(s string, now time.Time) (time.Time, error) {
+	input := strings.Join(strings.Fields(strings.ToLower(s)), " ")
+	if m := laterRe.FindStringSubmatch(input); m != nil {
+		n := 1
+		if m[1] != "a" && m[1] != "an" {
+			n, _ = strconv.Atoi(m[1])
+		}
+		return subtract(now, -n, m[2]), nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, strings.TrimSpace(s), now.Location()); err == nil {
+			return t.In(now.Location()), nil
+		}
+	}
+	return ParseDate(s, now)
+}
+
+// daysUntil returns in how many days the next day (0-6) is, counting from today.
+func daysUntil(today, day time.Weekday) int {
+	return (int(day) - int(today) + 7) % 7
+}
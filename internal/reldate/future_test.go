@@ -0,0 +1,93 @@
+package reldate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDate(t *testing.T) {
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	require.NoError(t, err)
+	// Wednesday
+	now := time.Date(2025, time.April, 16, 14, 30, 0, 0, warsaw)
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, warsaw) }
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"Today", day(2025, time.April, 16)},
+		{"tomorrow", day(2025, time.April, 17)},
+		{"wednesday", day(2025, time.April, 16)},
+		{"friday", day(2025, time.April, 18)},
+		{"monday", day(2025, time.April, 21)},
+		{"next  Friday", day(2025, time.April, 18)},
+		{"next wednesday", day(2025, time.April, 23)},
+		{"end of week", day(2025, time.April, 18)},
+		{"next week", day(2025, time.April, 21)},
+		{"end of month", day(2025, time.April, 30)},
+		{"next month", day(2025, time.May, 1)},
+		{"end of year", day(2025, time.December, 31)},
+		{"3d", day(2025, time.April, 19)},
+		{"2w", day(2025, time.April, 30)},
+		{"in 3 days", day(2025, time.April, 19)},
+		{"in a week", day(2025, time.April, 23)},
+		{"in 2 months", day(2025, time.June, 16)},
+		{"2025-05-01", day(2025, time.May, 1)},
+		{"2025/05/01", day(2025, time.May, 1)},
+		{"2025-05-01 13:00", day(2025, time.May, 1)},
+		{"2025-05-01T23:30:00Z", day(2025, time.May, 2)}, // Already May 2 in Warsaw
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDate(tt.input, now)
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %s, want %s", got, tt.want)
+			assert.Equal(t, warsaw, got.Location())
+		})
+	}
+}
+
+func TestParseDate_Unrecognized(t *testing.T) {
+	for _, input := range []string{"someday", "12h", "3 days ago", "last friday"} {
+		_, err := ParseDate(input, time.Now())
+		assert.ErrorIs(t, err, ErrUnrecognized, input)
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	require.NoError(t, err)
+	now := time.Date(2025, time.April, 16, 14, 30, 0, 0, warsaw)
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"in 3d", time.Date(2025, time.April, 19, 14, 30, 0, 0, warsaw)},
+		{"3d", time.Date(2025, time.April, 19, 14, 30, 0, 0, warsaw)},
+		{"2h", time.Date(2025, time.April, 16, 16, 30, 0, 0, warsaw)},
+		{"in 30 minutes", time.Date(2025, time.April, 16, 15, 0, 0, 0, warsaw)},
+		{"in an hour", time.Date(2025, time.April, 16, 15, 30, 0, 0, warsaw)},
+		{"in 2 weeks", time.Date(2025, time.April, 30, 14, 30, 0, 0, warsaw)},
+		{"in a month", time.Date(2025, time.May, 16, 14, 30, 0, 0, warsaw)},
+		{"2025-05-01 09:15", time.Date(2025, time.May, 1, 9, 15, 0, 0, warsaw)},
+		{"tomorrow", time.Date(2025, time.April, 17, 0, 0, 0, 0, warsaw)},
+		{"next friday", time.Date(2025, time.April, 18, 0, 0, 0, 0, warsaw)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseTime(tt.input, now)
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %s, want %s", got, tt.want)
+		})
+	}
+
+	for _, input := range []string{"soon", "3 days ago", "in 3 fortnights"} {
+		_, err := ParseTime(input, now)
+		assert.ErrorIs(t, err, ErrUnrecognized, input)
+	}
+}
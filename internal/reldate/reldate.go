@@ -0,0 +1,127 @@
+// Package reldate parses human-friendly date expressions such as "yesterday",
+// "last monday" or "2 weeks ago", due dates such as "next friday" and reminder times such
+// as "in 3d", relative to a reference time.
+package reldate
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	agoRe    = regexp.MustCompile(`^(\d+|an?)\s*(minute|min|hour|day|week|month|year)s?\s+ago$`)
+	offsetRe = regexp.MustCompile(`^(\d+)\s*(m|h|d|w)$`)
+)
+
+// absoluteLayouts are the absolute date formats accepted by Parse, tried in order.
+var absoluteLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04",
+	"2006/01/02 15:04",
+	"2006-01-02",
+	"2006/01/02",
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+}
+
+// Parse resolves s relative to now and returns the instant it denotes, in now's location.
+// Calendar expressions resolve to the start of the day, week (Monday), month or year.
+//
+// Supported forms (case-insensitive):
+//   - now, today, yesterday
+//   - 3d, 12h, 2w, 30m and "3 days ago", "an hour ago", "2 months ago"
+//   - monday (the most recent Monday, today included) and "last monday" (before today)
+//   - this week, last week, this month, last month, this year, last year
+//   - absolute dates: 2025-04-01, 2025/04/01, "2025-04-01 13:00" and RFC 3339
+func Parse(s string, now time.Time) (time.Time, error) {
+	input := strings.Join(strings.Fields(strings.ToLower(s)), " ")
+	loc := now.Location()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	switch input {
+	case "now":
+		return now, nil
+	case "today":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	case "this week":
+		return startOfWeek(today), nil
+	case "last week":
+		return startOfWeek(today).AddDate(0, 0, -7), nil
+	case "this month":
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc), nil
+	case "last month":
+		return time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, loc), nil
+	case "this year":
+		return time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, loc), nil
+	case "last year":
+		return time.Date(now.Year()-1, time.January, 1, 0, 0, 0, 0, loc), nil
+	}
+
+	if day, ok := weekdays[input]; ok {
+		return today.AddDate(0, 0, -daysSince(today.Weekday(), day)), nil
+	}
+	if name, ok := strings.CutPrefix(input, "last "); ok {
+		if day, ok := weekdays[name]; ok {
+			back := daysSince(today.Weekday(), day)
+			if back == 0 {
+				back = 7
+			}
+			return today.AddDate(0, 0, -back), nil
+		}
+	}
+
+	if m := offsetRe.FindStringSubmatch(input); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		return subtract(now, n, m[2]), nil
+	}
+	if m := agoRe.FindStringSubmatch(input); m != nil {
+		n := 1
+		if m[1] != "a" && m[1] != "an" {
+			n, _ = strconv.Atoi(m[1])
+		}
+		return subtract(now, n, m[2]), nil
+	}
+
+	for _, layout := range absoluteLayouts {
+		if t, err := time.ParseInLocation(layout, strings.TrimSpace(s), loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: %q", ErrUnrecognized, s)
+}
+
+// subtract goes back n units from now. Calendar units (days and longer) keep the time of day.
+func subtract(now time.Time, n int, unit string) time.Time {
+	switch unit {
+	case "m", "min", "minute":
+		return now.Add(-time.Duration(n) * time.Minute)
+	case "h", "hour":
+		return now.Add(-time.Duration(n) * time.Hour)
+	case "d", "day":
+		return now.AddDate(0, 0, -n)
+	case "w", "week":
+		return now.AddDate(0, 0, -7*n)
+	case "month":
+		return now.AddDate(0, -n, 0)
+	default: // year
+		return now.AddDate(-n, 0, 0)
+	}
+}
+
+// daysSince returns how many days ago the most recent day (0-6) was, counting from today.
+func daysSince(today, day time.Weekday) int {
+	return (int(today) - int(day) + 7) % 7
+}
+
+// startOfWeek returns the Monday of the week containing day.
+func startOfWeek(day time.Time) time.Time {
+	return day.AddDate(0, 0, -daysSince(day.Weekday(), time.Monday))
+}
@@ -0,0 +1,60 @@
+package reldate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	require.NoError(t, err)
+	// Wednesday
+	now := time.Date(2025, time.April, 16, 14, 30, 0, 0, warsaw)
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, warsaw) }
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"now", now},
+		{"Today", day(2025, time.April, 16)},
+		{"yesterday", day(2025, time.April, 15)},
+		{"monday", day(2025, time.April, 14)},
+		{"wednesday", day(2025, time.April, 16)},
+		{"last  Monday", day(2025, time.April, 14)},
+		{"last wednesday", day(2025, time.April, 9)},
+		{"last friday", day(2025, time.April, 11)},
+		{"this week", day(2025, time.April, 14)},
+		{"last week", day(2025, time.April, 7)},
+		{"this month", day(2025, time.April, 1)},
+		{"last month", day(2025, time.March, 1)},
+		{"this year", day(2025, time.January, 1)},
+		{"last year", day(2024, time.January, 1)},
+		{"3d", now.AddDate(0, 0, -3)},
+		{"12h", now.Add(-12 * time.Hour)},
+		{"2w", now.AddDate(0, 0, -14)},
+		{"3 days ago", now.AddDate(0, 0, -3)},
+		{"an hour ago", now.Add(-time.Hour)},
+		{"2 months ago", now.AddDate(0, -2, 0)},
+		{"2025-04-01", day(2025, time.April, 1)},
+		{"2025/04/01", day(2025, time.April, 1)},
+		{"2025-04-01 13:00", time.Date(2025, time.April, 1, 13, 0, 0, 0, warsaw)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input, now)
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %s, want %s", got, tt.want)
+			assert.Equal(t, warsaw, got.Location())
+		})
+	}
+}
+
+func TestParse_Unrecognized(t *testing.T) {
+	_, err := Parse("next tuesday", time.Now())
+	assert.ErrorIs(t, err, ErrUnrecognized)
+	assert.ErrorContains(t, err, `"next tuesday"`)
+}
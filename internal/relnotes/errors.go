@@ -0,0 +1,8 @@
+package relnotes
+
+import "errors"
+
+// Sentinel errors for release notes.
+
+// ErrTemplate indicates the prompt template could not be parsed or executed.
+var ErrTemplate = errors.New("invalid release notes template")
@@ -0,0 +1,147 @@
+// Package relnotes turns the issues of a release into release notes: it sorts them into
+// features, fixes and chores, renders the prompt asking the LLM to write the notes, and
+// renders plain notes without the LLM.
+package relnotes
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/sanitize"
+)
+
+// The sections of release notes, in the order they are shown.
+const (
+	Features = "Features"
+	Fixes    = "Fixes"
+	Chores   = "Chores"
+)
+
+// Categories lists the sections in the order they are shown.
+var Categories = []string{Features, Fixes, Chores}
+
+// descriptionWidth is the length at which issue descriptions are cut in the prompt.
+const descriptionWidth = 300
+
+// SystemPrompt is sent with the system role before the rendered template.
+const SystemPrompt = "You are a technical writer. You write concise, accurate release notes in Markdown from a list of issues."
+
+// DefaultTemplate is the prompt template used unless it is overridden. It is executed
+// with a Data value.
+//
+//go:embed prompt.tmpl
+var DefaultTemplate string
+
+// typeCategories maps lower-case issue type names to their section. Other types are chores.
+var typeCategories = map[string]string{
+	"story":       Features,
+	"feature":     Features,
+	"new feature": Features,
+	"improvement": Features,
+	"enhancement": Features,
+	"epic":        Features,
+	"bug":         Fixes,
+	"defect":      Fixes,
+	"incident":    Fixes,
+}
+
+// versionRe finds the version in queries such as fixVersion = 1.2.0 or fixVersion in ("1.2").
+var versionRe = regexp.MustCompile(`(?i)\bfixVersion\s*(?:=|in\s*\()\s*["']?([^"',)\s]+)`)
+
+// Item is an issue as it is listed in release notes.
+type Item struct {
+	Key         string `json:"key" yaml:"key"`
+	Summary     string `json:"summary" yaml:"summary"`
+	Type        string `json:"type" yaml:"type"`
+	Status      string `json:"status" yaml:"status"`
+	Description string `json:"-" yaml:"-"` // Shortened to a single line for the prompt
+}
+
+// Group is the issues of one section.
+type Group struct {
+	Category string `json:"category" yaml:"category"`
+	Items    []Item `json:"items" yaml:"items"`
+}
+
+// Data is what the prompt template is executed with.
+type Data struct {
+	Version string  // Version being released, empty if unknown
+	JQL     string  // The query that selected the issues
+	Count   int     // Number of issues
+	Groups  []Group // Non-empty sections in the order of Categories
+}
+
+// Category returns the section issues of the given type are listed in.
+func Category(issueType string) string {
+	if category, ok := typeCategories[strings.ToLower(strings.TrimSpace(issueType))]; ok {
+		return category
+	}
+	return Chores
+}
+
+// Version returns the version a query selects with fixVersion, or "" if it selects none.
+func Version(jql string) string {
+	if m := versionRe.FindStringSubmatch(jql); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// Build groups issues by section, keeping their order within each section.
+func Build(version, jql string, issues []mcpclient.Issue) Data {
+	byCategory := make(map[string][]Item)
+	for _, issue := range issues {
+		item := Item{
+			Key:         issue.Key,
+			Summary:     sanitize.Line(issue.Fields.Summary),
+			Type:        sanitize.Line(issue.Fields.IssueType.Name),
+			Status:      sanitize.Line(issue.Fields.Status.Name),
+			Description: sanitize.Truncate(strings.Join(strings.Fields(sanitize.Line(issue.Fields.Description)), " "), descriptionWidth),
+		}
+		category := Category(item.Type)
+		byCategory[category] = append(byCategory[category], item)
+	}
+	data := Data{Version: version, JQL: jql, Count: len(issues)}
+	for _, category := range Categories {
+		if items := byCategory[category]; len(items) > 0 {
+			data.Groups = append(data.Groups, Group{Category: category, Items: items})
+		}
+	}
+	return data
+}
+
+// RenderPrompt executes the prompt template text with data.
+func RenderPrompt(text string, data Data) (string, error) {
+	tmpl, err := template.New("release-notes").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrTemplate, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrTemplate, err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// Markdown renders release notes without the LLM: a heading per section and one bullet
+// per issue with its summary and key.
+func Markdown(data Data) string {
+	var b strings.Builder
+	if data.Version != "" {
+		fmt.Fprintf(&b, "# Release %s\n", data.Version)
+	} else {
+		b.WriteString("# Release notes\n")
+	}
+	for _, group := range data.Groups {
+		fmt.Fprintf(&b, "\n## %s\n\n", group.Category)
+		for _, item := range group.Items {
+			fmt.Fprintf(&b, "- %s (%s)\n", item.Summary, item.Key)
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
@@ -0,0 +1,82 @@
+package relnotes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+func testIssue(key, issueType, summary, description string) mcpclient.Issue {
+	return mcpclient.Issue{Key: key, Fields: mcpclient.IssueFields{
+		Summary:     summary,
+		IssueType:   mcpclient.IssueType{Name: issueType},
+		Status:      mcpclient.Status{Name: "Done"},
+		Description: description,
+	}}
+}
+
+func TestCategory(t *testing.T) {
+	assert.Equal(t, Features, Category("Story"))
+	assert.Equal(t, Features, Category(" new feature "))
+	assert.Equal(t, Fixes, Category("Bug"))
+	assert.Equal(t, Chores, Category("Task"))
+	assert.Equal(t, Chores, Category(""))
+}
+
+func TestVersion(t *testing.T) {
+	assert.Equal(t, "1.2.0", Version("fixVersion = 1.2.0"))
+	assert.Equal(t, "1.2.0", Version(`project = BE AND fixversion = "1.2.0" ORDER BY key`))
+	assert.Equal(t, "2025.04", Version(`fixVersion in ("2025.04", "2025.05")`))
+	assert.Empty(t, Version("project = BE AND resolved >= -14d"))
+}
+
+func TestBuild(t *testing.T) {
+	data := Build("1.2.0", "fixVersion = 1.2.0", []mcpclient.Issue{
+		testIssue("BE-3", "Task", "Bump Go", ""),
+		testIssue("BE-1", "Bug", "Fix login", "Users see\n\na 500 error"),
+		testIssue("BE-2", "Story", "Dark mode", ""),
+		testIssue("BE-4", "Bug", "Fix logout", ""),
+	})
+	assert.Equal(t, 4, data.Count)
+	require.Len(t, data.Groups, 3)
+	assert.Equal(t, Features, data.Groups[0].Category)
+	assert.Equal(t, Fixes, data.Groups[1].Category)
+	assert.Equal(t, Chores, data.Groups[2].Category)
+	require.Len(t, data.Groups[1].Items, 2)
+	assert.Equal(t, Item{Key: "BE-1", Summary: "Fix login", Type: "Bug", Status: "Done", Description: "Users see a 500 error"}, data.Groups[1].Items[0])
+	assert.Equal(t, "BE-4", data.Groups[1].Items[1].Key, "issues keep their order within a section")
+
+	long := Build("", "", []mcpclient.Issue{testIssue("BE-5", "Task", "Docs", strings.Repeat("word ", 100))})
+	assert.LessOrEqual(t, len(long.Groups[0].Items[0].Description), descriptionWidth)
+}
+
+func TestRenderPrompt(t *testing.T) {
+	data := Build("1.2.0", "fixVersion = 1.2.0", []mcpclient.Issue{
+		testIssue("BE-1", "Bug", "Fix login", "Users see a 500 error"),
+		testIssue("BE-2", "Story", "Dark mode", ""),
+	})
+	prompt, err := RenderPrompt(DefaultTemplate, data)
+	require.NoError(t, err)
+	assert.Contains(t, prompt, "Write the release notes of version 1.2.0 in Markdown.")
+	assert.Contains(t, prompt, "The 2 issue(s) of the release, by suggested section:\n\nFeatures:\n- BE-2 [Story] Dark mode\n\nFixes:\n- BE-1 [Bug] Fix login\n  Users see a 500 error")
+	assert.False(t, strings.HasSuffix(prompt, "\n"))
+
+	prompt, err = RenderPrompt("Notes for {{.JQL}}: {{range .Groups}}{{.Category}} {{end}}", data)
+	require.NoError(t, err)
+	assert.Equal(t, "Notes for fixVersion = 1.2.0: Features Fixes", prompt)
+
+	_, err = RenderPrompt("{{.Missing}}", data)
+	assert.ErrorIs(t, err, ErrTemplate)
+	_, err = RenderPrompt("{{range}}", data)
+	assert.ErrorIs(t, err, ErrTemplate)
+}
+
+func TestMarkdown(t *testing.T) {
+	issues := []mcpclient.Issue{testIssue("BE-1", "Bug", "Fix login", ""), testIssue("BE-2", "Task", "Bump Go", "")}
+	assert.Equal(t, "# Release 1.2.0\n\n## Fixes\n\n- Fix login (BE-1)\n\n## Chores\n\n- Bump Go (BE-2)", Markdown(Build("1.2.0", "", issues)))
+	assert.True(t, strings.HasPrefix(Markdown(Build("", "", issues)), "# Release notes\n"))
+}
@@ -0,0 +1,17 @@
+package remind
+
+import "errors"
+
+// Sentinel errors for reminders.
+
+// ErrRead indicates an error occurred while reading the reminders file.
+var ErrRead = errors.New("failed to read reminders")
+
+// ErrWrite indicates an error occurred while writing the reminders file.
+var ErrWrite = errors.New("failed to write reminders")
+
+// ErrInvalidReminder indicates a reminder is missing its issue key or due time.
+var ErrInvalidReminder = errors.New("invalid reminder")
+
+// ErrNotFound indicates no reminder has the requested ID.
+var ErrNotFound = errors.New("reminder not found")
@@ -0,0 +1,188 @@
+// Package remind stores follow-up reminders for issues in reminders.json, so users can be
+// reminded to check on an issue without setting up Jira automation.
+package remind
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// FileName is the standard name of the reminders file within the config directory.
+const FileName = "reminders.json"
+
+// Reminder is a note to follow up on an issue at a given time.
+type Reminder struct {
+	ID         int        `json:"id"`
+	IssueKey   string     `json:"issue_key"`
+	Note       string     `json:"note,omitempty"`
+	Due        time.Time  `json:"due"`
+	CreatedAt  time.Time  `json:"created_at"`
+	NotifiedAt *time.Time `json:"notified_at,omitempty"` // When a desktop notification was shown, if ever
+}
+
+// Overdue reports whether the reminder is due at now.
+func (r Reminder) Overdue(now time.Time) bool {
+	return !r.Due.After(now)
+}
+
+// file is the content of the reminders file. NextID keeps IDs of completed reminders
+// from being reused.
+type file struct {
+	NextID    int        `json:"next_id"`
+	Reminders []Reminder `json:"reminders"`
+}
+
+// Store reads and writes the reminders file.
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewStore returns a Store backed by reminders.json in configDir.
+func NewStore(configDir string) *Store {
+	return &Store{path: filepath.Join(configDir, FileName)}
+}
+
+// Add records a reminder for issueKey due at due and returns it with its ID assigned.
+func (s *Store) Add(issueKey, note string, due, now time.Time) (Reminder, error) {
+	if issueKey == "" {
+		return Reminder{}, fmt.Errorf("%w: issue key is required", ErrInvalidReminder)
+	}
+	if due.IsZero() {
+		return Reminder{}, fmt.Errorf("%w: due time is required", ErrInvalidReminder)
+	}
+	var reminder Reminder
+	err := s.update(func(f *file) error {
+		f.NextID++
+		reminder = Reminder{ID: f.NextID, IssueKey: issueKey, Note: note, Due: due, CreatedAt: now}
+		f.Reminders = append(f.Reminders, reminder)
+		return nil
+	})
+	if err != nil {
+		return Reminder{}, err
+	}
+	log.Debug().Int("id", reminder.ID).Str("issue_key", issueKey).Time("due", due).Msg("Added reminder")
+	return reminder, nil
+}
+
+// List returns all pending reminders, earliest due first.
+func (s *Store) List() ([]Reminder, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	return f.Reminders, nil
+}
+
+// Due returns the reminders due at now, earliest first.
+func (s *Store) Due(now time.Time) ([]Reminder, error) {
+	reminders, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	var due []Reminder
+	for _, r := range reminders {
+		if r.Overdue(now) {
+			due = append(due, r)
+		}
+	}
+	return due, nil
+}
+
+// Done removes the reminder with the given ID and returns it.
+func (s *Store) Done(id int) (Reminder, error) {
+	var removed Reminder
+	err := s.update(func(f *file) error {
+		for i, r := range f.Reminders {
+			if r.ID == id {
+				removed = r
+				f.Reminders = append(f.Reminders[:i], f.Reminders[i+1:]...)
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: #%d", ErrNotFound, id)
+	})
+	return removed, err
+}
+
+// MarkNotified records that a desktop notification was shown at at for the given reminders.
+func (s *Store) MarkNotified(ids []int, at time.Time) error {
+	return s.update(func(f *file) error {
+		for _, id := range ids {
+			for i := range f.Reminders {
+				if f.Reminders[i].ID == id {
+					f.Reminders[i].NotifiedAt = &at
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// update applies change to the reminders file and writes it back, unless change fails.
+func (s *Store) update(change func(*file) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := s.load()
+	if err != nil {
+		return err
+	}
+	if err := change(f); err != nil {
+		return err
+	}
+	return s.save(f)
+}
+
+// load reads the reminders file. A missing file yields no reminders.
+func (s *Store) load() (*file, error) {
+	f := &file{}
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return f, nil
+		}
+		return nil, fmt.Errorf("%w: %w", ErrRead, err)
+	}
+	if err := json.Unmarshal(data, f); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRead, err)
+	}
+	sort.SliceStable(f.Reminders, func(i, j int) bool { return f.Reminders[i].Due.Before(f.Reminders[j].Due) })
+	return f, nil
+}
+
+// save writes the reminders file, replacing it atomically.
+func (s *Store) save(f *file) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrWrite, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("%w: %w", ErrWrite, err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrWrite, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("%w: %w", ErrWrite, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("%w: %w", ErrWrite, err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("%w: %w", ErrWrite, err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package remind
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	store := NewStore(t.TempDir())
+	now := time.Date(2025, time.April, 16, 14, 30, 0, 0, time.UTC)
+
+	reminders, err := store.List()
+	require.NoError(t, err, "a missing file yields no reminders")
+	assert.Empty(t, reminders)
+
+	later, err := store.Add("BE-1", "check if fix shipped", now.Add(72*time.Hour), now)
+	require.NoError(t, err)
+	soon, err := store.Add("BE-2", "", now.Add(time.Hour), now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, later.ID)
+	assert.Equal(t, 2, soon.ID)
+
+	_, err = store.Add("", "note", now, now)
+	assert.ErrorIs(t, err, ErrInvalidReminder)
+	_, err = store.Add("BE-3", "note", time.Time{}, now)
+	assert.ErrorIs(t, err, ErrInvalidReminder)
+
+	reminders, err = store.List()
+	require.NoError(t, err)
+	require.Len(t, reminders, 2)
+	assert.Equal(t, "BE-2", reminders[0].IssueKey, "earliest due first")
+
+	due, err := store.Due(now.Add(2 * time.Hour))
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, soon.ID, due[0].ID)
+
+	require.NoError(t, store.MarkNotified([]int{soon.ID}, now))
+	due, err = store.Due(now.Add(2 * time.Hour))
+	require.NoError(t, err)
+	require.NotNil(t, due[0].NotifiedAt)
+	assert.Equal(t, now, *due[0].NotifiedAt)
+
+	done, err := store.Done(soon.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "BE-2", done.IssueKey)
+	_, err = store.Done(soon.ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	next, err := store.Add("BE-4", "", now, now)
+	require.NoError(t, err)
+	assert.Equal(t, 3, next.ID, "IDs of completed reminders are not reused")
+}
+
+func TestStore_CorruptFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, FileName), []byte("{oops"), 0o600))
+	_, err := NewStore(dir).List()
+	assert.ErrorIs(t, err, ErrRead)
+}
@@ -0,0 +1,196 @@
+// Package sanitize makes text from Jira safe to print in terminals and tabular formats.
+// It removes control characters, byte order marks and bidirectional overrides, repairs
+// invalid UTF-8 and measures and truncates text by display width, so emoji and East Asian
+// characters neither break layouts nor get cut in half.
+package sanitize
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	bom = '\uFEFF'
+	zwj = '\u200D' // Zero width joiner, used inside emoji sequences
+
+	// Ellipsis marks truncated text.
+	Ellipsis = "..."
+)
+
+// dropped reports whether r is removed from all output: control characters other than
+// tab and line breaks, the byte order mark and bidirectional embedding, override and
+// isolate controls, which can make text display differently from what it contains.
+func dropped(r rune) bool {
+	switch {
+	case r == '\t' || r == '\n' || r == '\r':
+		return false
+	case r == bom:
+		return true
+	case r >= '\u202A' && r <= '\u202E', r >= '\u2066' && r <= '\u2069':
+		return true
+	}
+	return unicode.IsControl(r)
+}
+
+// Text cleans multi-line text: line breaks are normalized to \n, tabs are kept and other
+// control characters, including terminal escape sequences' ESC, are removed. Invalid UTF-8
+// is replaced with U+FFFD.
+func Text(s string) string {
+	s = strings.ToValidUTF8(s, string(utf8.RuneError))
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\r':
+			return '\n'
+		case dropped(r):
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// Line cleans text for a single line or a TSV cell: like Text, but tabs and line breaks
+// become single spaces.
+func Line(s string) string {
+	s = strings.ToValidUTF8(s, string(utf8.RuneError))
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\t' || r == '\n' || r == '\r':
+			return ' '
+		case dropped(r):
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// Width returns the number of terminal columns s occupies. Wide East Asian characters and
+// emoji take two columns; combining marks, variation selectors and joiners take none.
+func Width(s string) int {
+	width := 0
+	for _, r := range s {
+		width += RuneWidth(r)
+	}
+	return width
+}
+
+// Truncate shortens s to at most width columns, ending it with Ellipsis when it is cut.
+// Characters are never split, and combining marks stay with their base character.
+func Truncate(s string, width int) string {
+	if Width(s) <= width {
+		return s
+	}
+	if width <= 0 {
+		return ""
+	}
+	limit := width - len(Ellipsis)
+	if limit < 0 {
+		return Ellipsis[:width]
+	}
+	used, end := 0, 0
+	for i, r := range s {
+		w := RuneWidth(r)
+		if used+w > limit {
+			break
+		}
+		used += w
+		end = i + utf8.RuneLen(r)
+	}
+	// A joiner at the cut would glue the ellipsis to a partial emoji sequence
+	return strings.TrimRight(s[:end], string(zwj)) + Ellipsis
+}
+
+// Pad appends spaces to s until it occupies width columns.
+func Pad(s string, width int) string {
+	if w := Width(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	return s
+}
+
+// RuneWidth returns the number of terminal columns r occupies.
+func RuneWidth(r rune) int {
+	// Format characters (Cf) include the joiner, the byte order mark and zero width spaces;
+	// variation selectors are nonspacing marks (Mn)
+	if unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf, unicode.Cc) {
+		return 0
+	}
+	for _, rng := range wideRanges {
+		if r < rng[0] {
+			break
+		}
+		if r <= rng[1] {
+			return 2
+		}
+	}
+	return 1
+}
+
+// wideRanges lists, in order, the code point ranges displayed in two columns: East Asian
+// Wide and Fullwidth characters and emoji presentation characters.
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F},   // Hangul Jamo initial consonants
+	{0x231A, 0x231B},   // Watch, hourglass
+	{0x2329, 0x232A},   // Angle brackets
+	{0x23E9, 0x23EC},   // Media controls
+	{0x23F0, 0x23F0},   // Alarm clock
+	{0x23F3, 0x23F3},   // Hourglass with flowing sand
+	{0x25FD, 0x25FE},   // Small squares
+	{0x2614, 0x2615},   // Umbrella, hot beverage
+	{0x2648, 0x2653},   // Zodiac
+	{0x267F, 0x267F},   // Wheelchair
+	{0x2693, 0x2693},   // Anchor
+	{0x26A1, 0x26A1},   // High voltage
+	{0x26AA, 0x26AB},   // Circles
+	{0x26BD, 0x26BE},   // Balls
+	{0x26C4, 0x26C5},   // Snowman, sun behind cloud
+	{0x26CE, 0x26CE},   // Ophiuchus
+	{0x26D4, 0x26D4},   // No entry
+	{0x26EA, 0x26EA},   // Church
+	{0x26F2, 0x26F3},   // Fountain, golf
+	{0x26F5, 0x26F5},   // Sailboat
+	{0x26FA, 0x26FA},   // Tent
+	{0x26FD, 0x26FD},   // Fuel pump
+	{0x2705, 0x2705},   // Check mark button
+	{0x270A, 0x270B},   // Fists
+	{0x2728, 0x2728},   // Sparkles
+	{0x274C, 0x274C},   // Cross mark
+	{0x274E, 0x274E},   // Cross mark button
+	{0x2753, 0x2755},   // Question and exclamation marks
+	{0x2757, 0x2757},   // Exclamation mark
+	{0x2795, 0x2797},   // Plus, minus, divide
+	{0x27B0, 0x27B0},   // Curly loop
+	{0x27BF, 0x27BF},   // Double curly loop
+	{0x2B1B, 0x2B1C},   // Large squares
+	{0x2B50, 0x2B50},   // Star
+	{0x2B55, 0x2B55},   // Circle
+	{0x2E80, 0x303E},   // CJK radicals, symbols and punctuation
+	{0x3041, 0x33FF},   // Hiragana, Katakana, CJK compatibility
+	{0x3400, 0x4DBF},   // CJK extension A
+	{0x4E00, 0x9FFF},   // CJK unified ideographs
+	{0xA000, 0xA4CF},   // Yi
+	{0xA960, 0xA97F},   // Hangul Jamo extended A
+	{0xAC00, 0xD7A3},   // Hangul syllables
+	{0xF900, 0xFAFF},   // CJK compatibility ideographs
+	{0xFE10, 0xFE19},   // Vertical forms
+	{0xFE30, 0xFE6F},   // CJK compatibility forms, small forms
+	{0xFF00, 0xFF60},   // Fullwidth forms
+	{0xFFE0, 0xFFE6},   // Fullwidth signs
+	{0x16FE0, 0x16FE4}, // Ideographic symbols
+	{0x17000, 0x18CFF}, // Tangut
+	{0x1B000, 0x1B2FF}, // Kana supplement and extensions
+	{0x1F004, 0x1F004}, // Mahjong tile
+	{0x1F0CF, 0x1F0CF}, // Playing card
+	{0x1F18E, 0x1F18E}, // AB button
+	{0x1F191, 0x1F19A}, // Squared words
+	{0x1F1E6, 0x1F1FF}, // Regional indicators (flags)
+	{0x1F200, 0x1F251}, // Enclosed ideographic supplement
+	{0x1F300, 0x1F64F}, // Symbols and pictographs, emoticons
+	{0x1F680, 0x1F6FF}, // Transport and map symbols
+	{0x1F7E0, 0x1F7EB}, // Colored circles and squares
+	{0x1F90C, 0x1F9FF}, // Supplemental symbols and pictographs
+	{0x1FA70, 0x1FAFF}, // Symbols and pictographs extended A
+	{0x20000, 0x3FFFD}, // CJK extensions B and later
+}
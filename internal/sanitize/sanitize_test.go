@@ -0,0 +1,56 @@
+package sanitize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestText(t *testing.T) {
+	assert.Equal(t, "line1\nline2\nline3\tend", Text("line1\r\nline2\rline3\tend"))
+	assert.Equal(t, "[31mred", Text("\x1b[31mred"), "ESC is removed so escape sequences cannot restyle the terminal")
+	assert.Equal(t, "BOM gone", Text("\uFEFFBOM gone"))
+	assert.Equal(t, "abc", Text("a\u202Eb\u2066c"), "bidirectional overrides are removed")
+	assert.Equal(t, "bad \uFFFD byte", Text("bad \xff byte"))
+	assert.Equal(t, "emoji 👩\u200D💻 and 日本語 stay", Text("emoji 👩\u200D💻 and 日本語 stay"))
+}
+
+func TestLine(t *testing.T) {
+	assert.Equal(t, "a b c d", Line("a\tb\r\nc\nd"))
+	assert.Equal(t, "bell", Line("be\x07ll"))
+	assert.Equal(t, "Zażółć 🚀", Line("\uFEFFZażółć 🚀\x00"))
+}
+
+func TestWidth(t *testing.T) {
+	for s, want := range map[string]int{
+		"":         0,
+		"abc":      3,
+		"zażółć":   6,
+		"日本語":      6,
+		"🚀":        2,
+		"👍🏽":       4, // Skin tone modifiers are emoji in their own right
+		"👩\u200D💻": 4,
+		"❤\uFE0F":  1, // Text-default symbol; the variation selector takes no column
+		"e\u0301":  1, // Combining acute accent
+		"\uFEFFok": 2,
+	} {
+		assert.Equal(t, want, Width(s), "Width(%q)", s)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "short", Truncate("short", 10))
+	assert.Equal(t, "abcdefg...", Truncate("abcdefghijklmnop", 10))
+	assert.Equal(t, "日本...", Truncate("日本語テキスト", 8), "wide characters are never split")
+	assert.Equal(t, "日本...", Truncate("日本語テキスト", 7))
+	assert.Equal(t, "ab🚀...", Truncate("ab🚀🚀🚀", 7))
+	assert.Equal(t, "e\u0301e\u0301...", Truncate("e\u0301e\u0301e\u0301e\u0301e\u0301e\u0301", 5), "combining marks stay with their base")
+	assert.Equal(t, "👩...", Truncate("👩\u200D💻👩\u200D💻", 6), "no dangling joiner")
+	assert.Equal(t, "..", Truncate("abcdef", 2))
+	assert.Equal(t, "", Truncate("abcdef", 0))
+}
+
+func TestPad(t *testing.T) {
+	assert.Equal(t, "日本  |", Pad("日本", 6)+"|")
+	assert.Equal(t, "toolong", Pad("toolong", 3))
+}
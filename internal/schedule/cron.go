@@ -0,0 +1,205 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearchYears bounds how far ahead Next looks for a matching time, so expressions that
+// can never match, such as "0 0 30 2 *", do not loop forever.
+const maxSearchYears = 5
+
+// cronMacros are the shorthand expressions accepted in place of the five fields.
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var monthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var dayNames = map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6}
+
+// cronField describes the range and value names of one field of a cron expression.
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var cronFields = [5]cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: monthNames},
+	{name: "day of week", min: 0, max: 7, names: dayNames}, // 7 is Sunday, as 0
+}
+
+// Cron is a parsed cron expression.
+type Cron struct {
+	expr                               string
+	minutes, hours, days, months       uint64
+	weekdays                           uint64
+	daysRestricted, weekdaysRestricted bool
+}
+
+// ParseCron parses a standard five-field cron expression (minute, hour, day of month,
+// month, day of week) or one of the macros @yearly, @monthly, @weekly, @daily and
+// @hourly. Fields accept *, values, ranges (1-5), lists (1,15), steps (*/15, 9-17/2) and
+// the names jan-dec and sun-sat. As in cron, a time matches when both the day of month
+// and the day of week match, or either if neither field starts with *.
+func ParseCron(expr string) (*Cron, error) {
+	spec := strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(spec)]; ok {
+		spec = macro
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("%w: %q: expected 5 fields, got %d", ErrInvalidCron, expr, len(fields))
+	}
+	var sets [5]uint64
+	for i, field := range fields {
+		set, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %w", ErrInvalidCron, expr, err)
+		}
+		sets[i] = set
+	}
+	weekdays := sets[4]
+	if weekdays&(1<<7) != 0 {
+		weekdays |= 1 // Sunday
+	}
+	return &Cron{
+		expr:               strings.TrimSpace(expr),
+		minutes:            sets[0],
+		hours:              sets[1],
+		days:               sets[2],
+		months:             sets[3],
+		weekdays:           weekdays,
+		daysRestricted:     !strings.HasPrefix(fields[2], "*"),
+		weekdaysRestricted: !strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+// parseCronField returns the set of values a field matches as a bit mask.
+func parseCronField(field string, spec cronField) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("%s: invalid step %q", spec.name, stepPart)
+			}
+			step = n
+		}
+		lo, hi := spec.min, spec.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			from, to, _ := strings.Cut(rangePart, "-")
+			var err error
+			if lo, err = cronValue(from, spec); err != nil {
+				return 0, err
+			}
+			if hi, err = cronValue(to, spec); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("%s: range %q runs backwards", spec.name, rangePart)
+			}
+		default:
+			value, err := cronValue(rangePart, spec)
+			if err != nil {
+				return 0, err
+			}
+			lo = value
+			if !hasStep {
+				hi = value
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+// cronValue parses a number or name within the range of spec.
+func cronValue(s string, spec cronField) (int, error) {
+	if v, ok := spec.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%s: invalid value %q", spec.name, s)
+	}
+	if v < spec.min || v > spec.max {
+		return 0, fmt.Errorf("%s: %d is out of range %d-%d", spec.name, v, spec.min, spec.max)
+	}
+	return v, nil
+}
+
+// String returns the expression the Cron was parsed from.
+func (c *Cron) String() string {
+	return c.expr
+}
+
+// Next returns the first time after t that matches the expression, in t's location. It
+// returns the zero time if there is none within five years.
+func (c *Cron) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case c.months&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case c.hours&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case c.minutes&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// Latest returns the last time after after and at or before now that matches the
+// expression, and whether there is one.
+func (c *Cron) Latest(after, now time.Time) (time.Time, bool) {
+	next := c.Next(after)
+	if next.IsZero() || next.After(now) {
+		return time.Time{}, false
+	}
+	for {
+		following := c.Next(next)
+		if following.IsZero() || following.After(now) {
+			return next, true
+		}
+		next = following
+	}
+}
+
+// dayMatches reports whether the date of t matches the day of month and day of week fields.
+func (c *Cron) dayMatches(t time.Time) bool {
+	day := c.days&(1<<uint(t.Day())) != 0
+	weekday := c.weekdays&(1<<uint(t.Weekday())) != 0
+	if c.daysRestricted && c.weekdaysRestricted {
+		return day || weekday
+	}
+	return day && weekday
+}
@@ -0,0 +1,77 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronNext(t *testing.T) {
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	require.NoError(t, err)
+	// Wednesday
+	now := time.Date(2025, time.April, 16, 14, 30, 20, 0, warsaw)
+	at := func(m time.Month, d, h, min int) time.Time { return time.Date(2025, m, d, h, min, 0, 0, warsaw) }
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", at(time.April, 16, 14, 31)},
+		{"*/15 * * * *", at(time.April, 16, 14, 45)},
+		{"0 9 * * *", at(time.April, 17, 9, 0)},
+		{"0 9 * * MON", at(time.April, 21, 9, 0)},
+		{"0 9 * * mon-fri", at(time.April, 17, 9, 0)},
+		{"30 14 * * 3", at(time.April, 23, 14, 30)}, // Now is past 14:30:00
+		{"0 0 1 * *", at(time.May, 1, 0, 0)},
+		{"0 8 1,15 * *", at(time.May, 1, 8, 0)},
+		{"0 8 20 * 1", at(time.April, 20, 8, 0)},   // Either day field matches when both are restricted
+		{"0 8 */10 * 1", at(time.April, 21, 8, 0)}, // A day of month starting with * does not widen the match
+		{"0 0 * * 7", at(time.April, 20, 0, 0)},
+		{"0 10-16/3 * * *", at(time.April, 16, 16, 0)},
+		{"0 0 1 jan *", time.Date(2026, time.January, 1, 0, 0, 0, 0, warsaw)},
+		{"@weekly", at(time.April, 20, 0, 0)},
+		{"@Hourly", at(time.April, 16, 15, 0)},
+		{"0 0 29 2 *", time.Date(2028, time.February, 29, 0, 0, 0, 0, warsaw)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			cron, err := ParseCron(tt.expr)
+			require.NoError(t, err)
+			got := cron.Next(now)
+			assert.True(t, tt.want.Equal(got), "got %s, want %s", got, tt.want)
+			assert.Equal(t, warsaw, got.Location())
+		})
+	}
+
+	never, err := ParseCron("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, never.Next(now).IsZero())
+}
+
+func TestCronLatest(t *testing.T) {
+	cron, err := ParseCron("0 9 * * *")
+	require.NoError(t, err)
+	last := time.Date(2025, time.April, 10, 9, 0, 0, 0, time.UTC)
+
+	got, ok := cron.Latest(last, time.Date(2025, time.April, 16, 8, 59, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2025, time.April, 15, 9, 0, 0, 0, time.UTC), got, "only the latest missed occurrence is due")
+
+	got, ok = cron.Latest(last, time.Date(2025, time.April, 16, 9, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2025, time.April, 16, 9, 0, 0, 0, time.UTC), got, "an occurrence is due at its minute")
+
+	_, ok = cron.Latest(last, time.Date(2025, time.April, 11, 8, 0, 0, 0, time.UTC))
+	assert.False(t, ok)
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8",
+		"*/0 * * * *", "5-1 * * * *", "a * * * *", "* * * foo *", "@every 5m"} {
+		_, err := ParseCron(expr)
+		assert.ErrorIs(t, err, ErrInvalidCron, expr)
+	}
+}
@@ -0,0 +1,36 @@
+package schedule
+
+import "errors"
+
+// Sentinel errors for recurring ticket schedules.
+
+// ErrInvalidCron indicates a cron expression could not be parsed.
+var ErrInvalidCron = errors.New("invalid cron expression")
+
+// ErrConfigRead indicates an error occurred while reading the schedules file.
+var ErrConfigRead = errors.New("failed to read schedules file")
+
+// ErrConfigParse indicates an error occurred while parsing the schedules file.
+var ErrConfigParse = errors.New("failed to parse schedules file")
+
+// ErrConfigWrite indicates an error occurred while writing the schedules file.
+var ErrConfigWrite = errors.New("failed to write schedules file")
+
+// ErrInvalidSchedule indicates a schedule is incomplete or contains an invalid cron
+// expression or template.
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// ErrScheduleNotFound indicates no schedule has the requested name.
+var ErrScheduleNotFound = errors.New("schedule not found")
+
+// ErrStateRead indicates an error occurred while reading the schedule state file.
+var ErrStateRead = errors.New("failed to read schedule state")
+
+// ErrStateWrite indicates an error occurred while writing the schedule state file.
+var ErrStateWrite = errors.New("failed to write schedule state")
+
+// ErrLocked indicates another 'tix schedule run' holds the lock on the state file.
+var ErrLocked = errors.New("another schedule run is in progress")
+
+// ErrRender indicates a schedule template failed to render.
+var ErrRender = errors.New("failed to render schedule template")
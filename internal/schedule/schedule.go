@@ -0,0 +1,225 @@
+// Package schedule manages recurring ticket definitions from schedules.yaml: when each
+// one is due according to its cron expression, what it renders to, and which occurrences
+// have already produced a ticket.
+package schedule
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+)
+
+// Definition describes a recurring ticket. With Summary set the ticket is created from
+// the Summary and Description templates without the LLM; otherwise the Input template is
+// processed by the LLM like `tix create` input. Project, IssueType, Priority and Due
+// apply in both modes.
+type Definition struct {
+	Name        string `yaml:"name"`
+	Cron        string `yaml:"cron"`                  // Five-field cron expression or macro such as @weekly
+	Input       string `yaml:"input,omitempty"`       // Template for the LLM input
+	Summary     string `yaml:"summary,omitempty"`     // Template for the summary (without the LLM)
+	Description string `yaml:"description,omitempty"` // Template for the description (without the LLM)
+	Project     string `yaml:"project,omitempty"`     // Project key or links.yaml name
+	IssueType   string `yaml:"issue_type,omitempty"`
+	Priority    string `yaml:"priority,omitempty"`
+	Due         string `yaml:"due,omitempty"` // Due date such as "in 3 days", resolved when the ticket is created
+	Disabled    bool   `yaml:"disabled,omitempty"`
+}
+
+// Config holds all schedule definitions.
+type Config struct {
+	Schedules []Definition `yaml:"schedules"`
+}
+
+// Occurrence is the data schedule templates are rendered with.
+type Occurrence struct {
+	Name string    // Name of the schedule
+	Time time.Time // Time the occurrence was due, in the configured time zone
+	Date string    // Time as YYYY-MM-DD
+	Week int       // ISO week number of Time
+}
+
+// Rendered holds the result of rendering a definition's templates for an occurrence.
+type Rendered struct {
+	Input       string
+	Summary     string
+	Description string
+}
+
+// LoadConfig reads schedule definitions from path. A missing file yields an empty Config.
+// The loaded configuration is validated before being returned.
+func LoadConfig(path string) (*Config, error) {
+	cfg := &Config{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			log.Debug().Str("path", path).Msg("Schedules file not found, no schedules configured")
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("%w: %w", ErrConfigRead, err)
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrConfigParse, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	log.Debug().Str("path", path).Int("schedules", len(cfg.Schedules)).Msg("Loaded schedules")
+	return cfg, nil
+}
+
+// Save writes the configuration to path, replacing the file atomically.
+func (c *Config) Save(path string) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrConfigWrite, err)
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		return fmt.Errorf("%w: %w", ErrConfigWrite, err)
+	}
+	return nil
+}
+
+// Validate checks that schedule names are unique and that each definition has a valid
+// cron expression, valid templates and either an input or a summary.
+func (c *Config) Validate() error {
+	seen := make(map[string]bool)
+	for i, d := range c.Schedules {
+		if strings.TrimSpace(d.Name) == "" {
+			return fmt.Errorf("%w: schedule #%d has no name", ErrInvalidSchedule, i+1)
+		}
+		if seen[d.Name] {
+			return fmt.Errorf("%w: duplicate schedule name %q", ErrInvalidSchedule, d.Name)
+		}
+		seen[d.Name] = true
+		if err := d.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate checks a single definition.
+func (d *Definition) Validate() error {
+	if _, err := ParseCron(d.Cron); err != nil {
+		return fmt.Errorf("%w: schedule %q: %w", ErrInvalidSchedule, d.Name, err)
+	}
+	if strings.TrimSpace(d.Summary) == "" && strings.TrimSpace(d.Input) == "" {
+		return fmt.Errorf("%w: schedule %q needs an 'input' for the LLM or a 'summary'", ErrInvalidSchedule, d.Name)
+	}
+	// Rendering a sample occurrence also catches references to unknown fields
+	if _, err := d.Render(time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		return fmt.Errorf("%w: schedule %q has an invalid template: %w", ErrInvalidSchedule, d.Name, err)
+	}
+	return nil
+}
+
+// UsesLLM reports whether tickets of the schedule are generated by the LLM.
+func (d *Definition) UsesLLM() bool {
+	return strings.TrimSpace(d.Summary) == ""
+}
+
+// Schedule returns the parsed cron expression of the definition.
+func (d *Definition) Schedule() (*Cron, error) {
+	return ParseCron(d.Cron)
+}
+
+// Render applies the definition's templates to the occurrence due at t.
+func (d *Definition) Render(t time.Time) (Rendered, error) {
+	_, week := t.ISOWeek()
+	data := Occurrence{Name: d.Name, Time: t, Date: t.Format(time.DateOnly), Week: week}
+	var r Rendered
+	var err error
+	if r.Input, err = execute("input", d.Input, data); err != nil {
+		return r, err
+	}
+	if r.Summary, err = execute("summary", d.Summary, data); err != nil {
+		return r, err
+	}
+	if r.Description, err = execute("description", d.Description, data); err != nil {
+		return r, err
+	}
+	r.Input = strings.TrimSpace(r.Input)
+	r.Summary = strings.TrimSpace(r.Summary)
+	return r, nil
+}
+
+// Find returns the definition with the given name.
+func (c *Config) Find(name string) (*Definition, bool) {
+	for i := range c.Schedules {
+		if c.Schedules[i].Name == name {
+			return &c.Schedules[i], true
+		}
+	}
+	return nil, false
+}
+
+// Add validates d and appends it. Names must be unique.
+func (c *Config) Add(d Definition) error {
+	if strings.TrimSpace(d.Name) == "" {
+		return fmt.Errorf("%w: the schedule has no name", ErrInvalidSchedule)
+	}
+	if _, ok := c.Find(d.Name); ok {
+		return fmt.Errorf("%w: duplicate schedule name %q", ErrInvalidSchedule, d.Name)
+	}
+	if err := d.Validate(); err != nil {
+		return err
+	}
+	c.Schedules = append(c.Schedules, d)
+	return nil
+}
+
+// Remove deletes the definition with the given name.
+func (c *Config) Remove(name string) error {
+	for i := range c.Schedules {
+		if c.Schedules[i].Name == name {
+			c.Schedules = append(c.Schedules[:i], c.Schedules[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %q", ErrScheduleNotFound, name)
+}
+
+func execute(name, text string, data Occurrence) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s: %w", ErrRender, name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("%w: %s: %w", ErrRender, name, err)
+	}
+	return buf.String(), nil
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it into place,
+// so readers never see a partially written file.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
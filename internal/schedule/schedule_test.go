@@ -0,0 +1,97 @@
+package schedule
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "schedules.yaml")
+
+	cfg, err := LoadConfig(path)
+	require.NoError(t, err, "a missing file is not an error")
+	assert.Empty(t, cfg.Schedules)
+
+	require.NoError(t, os.WriteFile(path, []byte(`schedules:
+  - name: weekly-report
+    cron: "0 9 * * MON"
+    input: Prepare the weekly report for week {{.Week}}
+    project: OPS
+  - name: patch-day
+    cron: "@monthly"
+    summary: Apply security patches ({{.Date}})
+    due: in 3 days
+    disabled: true
+`), 0o600))
+	cfg, err = LoadConfig(path)
+	require.NoError(t, err)
+	require.Len(t, cfg.Schedules, 2)
+	assert.True(t, cfg.Schedules[0].UsesLLM())
+	assert.False(t, cfg.Schedules[1].UsesLLM())
+	assert.Equal(t, "in 3 days", cfg.Schedules[1].Due)
+	assert.True(t, cfg.Schedules[1].Disabled)
+
+	require.NoError(t, os.WriteFile(path, []byte("schedules: [oops"), 0o600))
+	_, err = LoadConfig(path)
+	assert.ErrorIs(t, err, ErrConfigParse)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		defs []Definition
+	}{
+		{"missing name", []Definition{{Cron: "@daily", Input: "x"}}},
+		{"duplicate name", []Definition{{Name: "a", Cron: "@daily", Input: "x"}, {Name: "a", Cron: "@weekly", Input: "y"}}},
+		{"invalid cron", []Definition{{Name: "a", Cron: "every day", Input: "x"}}},
+		{"no input or summary", []Definition{{Name: "a", Cron: "@daily"}}},
+		{"unknown template field", []Definition{{Name: "a", Cron: "@daily", Summary: "Report {{.Month}}"}}},
+		{"malformed template", []Definition{{Name: "a", Cron: "@daily", Input: "Report {{.Date"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Schedules: tt.defs}
+			assert.ErrorIs(t, cfg.Validate(), ErrInvalidSchedule)
+		})
+	}
+}
+
+func TestDefinition_Render(t *testing.T) {
+	def := Definition{
+		Name:        "weekly-report",
+		Cron:        "0 9 * * MON",
+		Summary:     " Weekly report {{.Date}} ",
+		Description: "Week {{.Week}} of {{.Time.Year}}, from {{.Name}}",
+	}
+	rendered, err := def.Render(time.Date(2025, time.April, 14, 9, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, "Weekly report 2025-04-14", rendered.Summary)
+	assert.Equal(t, "Week 16 of 2025, from weekly-report", rendered.Description)
+	assert.Empty(t, rendered.Input)
+}
+
+func TestConfig_AddRemoveSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedules.yaml")
+	cfg := &Config{}
+	require.NoError(t, cfg.Add(Definition{Name: "standup", Cron: "0 10 * * 1-5", Input: "Standup notes for {{.Date}}"}))
+	assert.ErrorIs(t, cfg.Add(Definition{Name: "standup", Cron: "@daily", Input: "x"}), ErrInvalidSchedule)
+	assert.ErrorIs(t, cfg.Add(Definition{Name: "bad", Cron: "@daily"}), ErrInvalidSchedule)
+	require.NoError(t, cfg.Add(Definition{Name: "retro", Cron: "0 15 * * FRI", Summary: "Retro"}))
+	require.NoError(t, cfg.Save(path))
+
+	loaded, err := LoadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, cfg.Schedules, loaded.Schedules)
+
+	require.NoError(t, loaded.Remove("standup"))
+	assert.ErrorIs(t, loaded.Remove("standup"), ErrScheduleNotFound)
+	_, ok := loaded.Find("retro")
+	assert.True(t, ok)
+	assert.Len(t, loaded.Schedules, 1)
+}
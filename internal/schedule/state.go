@@ -0,0 +1,114 @@
+package schedule
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// StateFileName is the standard name of the schedule state file within the config directory.
+const StateFileName = "schedule_state.json"
+
+// lockFileName is the name of the lock file held during a run, next to the state file.
+const lockFileName = "schedule.lock"
+
+// staleLockAge is how old a lock file must be before it is considered left behind by a
+// run that crashed, and is taken over.
+const staleLockAge = time.Hour
+
+// Run records the progress of one schedule.
+type Run struct {
+	// Last is the latest occurrence that has been handled. Occurrences up to and including
+	// it never produce another ticket.
+	Last      time.Time `json:"last"`
+	IssueKey  string    `json:"issue_key,omitempty"`  // Issue created for the latest handled occurrence, if any
+	CreatedAt time.Time `json:"created_at,omitempty"` // When IssueKey was created
+}
+
+// State is the progress of all schedules, keyed by schedule name.
+type State struct {
+	Schedules map[string]Run `json:"schedules"`
+}
+
+// StateStore reads and writes the schedule state file and guards runs with a lock file.
+type StateStore struct {
+	path     string
+	lockPath string
+}
+
+// NewStateStore returns a StateStore backed by schedule_state.json in configDir.
+func NewStateStore(configDir string) *StateStore {
+	return &StateStore{
+		path:     filepath.Join(configDir, StateFileName),
+		lockPath: filepath.Join(configDir, lockFileName),
+	}
+}
+
+// Load reads the state. A missing file yields an empty state.
+func (s *StateStore) Load() (*State, error) {
+	state := &State{Schedules: make(map[string]Run)}
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return state, nil
+		}
+		return nil, fmt.Errorf("%w: %w", ErrStateRead, err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrStateRead, err)
+	}
+	if state.Schedules == nil {
+		state.Schedules = make(map[string]Run)
+	}
+	return state, nil
+}
+
+// Save writes the state, replacing the file atomically.
+func (s *StateStore) Save(state *State) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrStateWrite, err)
+	}
+	if err := writeFileAtomic(s.path, data); err != nil {
+		return fmt.Errorf("%w: %w", ErrStateWrite, err)
+	}
+	return nil
+}
+
+// Lock takes the run lock so that overlapping runs, e.g. a cron job and a manual run,
+// cannot create the same ticket twice. It returns a function releasing the lock, or an
+// error wrapping ErrLocked if another run holds it. Locks older than an hour are assumed
+// to be left behind by a crashed run and are taken over.
+func (s *StateStore) Lock() (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(s.lockPath), 0o700); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrStateWrite, err)
+	}
+	for attempt := 0; ; attempt++ {
+		file, err := os.OpenFile(s.lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			fmt.Fprintf(file, "%d\n", os.Getpid())
+			file.Close()
+			return func() {
+				if err := os.Remove(s.lockPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+					log.Warn().Err(err).Str("path", s.lockPath).Msg("Failed to remove schedule lock")
+				}
+			}, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("%w: %w", ErrStateWrite, err)
+		}
+		info, statErr := os.Stat(s.lockPath)
+		if attempt > 0 || statErr != nil || time.Since(info.ModTime()) < staleLockAge {
+			return nil, fmt.Errorf("%w (lock file %s)", ErrLocked, s.lockPath)
+		}
+		log.Warn().Str("path", s.lockPath).Time("locked_at", info.ModTime()).Msg("Taking over stale schedule lock")
+		if err := os.Remove(s.lockPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %w", ErrStateWrite, err)
+		}
+	}
+}
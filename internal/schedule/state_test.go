@@ -0,0 +1,48 @@
+package schedule
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateStore_LoadSave(t *testing.T) {
+	store := NewStateStore(t.TempDir())
+	state, err := store.Load()
+	require.NoError(t, err, "a missing file yields an empty state")
+	assert.Empty(t, state.Schedules)
+
+	last := time.Date(2025, time.April, 14, 9, 0, 0, 0, time.UTC)
+	state.Schedules["weekly-report"] = Run{Last: last, IssueKey: "OPS-12", CreatedAt: last.Add(time.Minute)}
+	require.NoError(t, store.Save(state))
+
+	loaded, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, state.Schedules, loaded.Schedules)
+}
+
+func TestStateStore_Lock(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStateStore(dir)
+
+	unlock, err := store.Lock()
+	require.NoError(t, err)
+	_, err = store.Lock()
+	assert.ErrorIs(t, err, ErrLocked)
+
+	unlock()
+	unlock, err = store.Lock()
+	require.NoError(t, err, "the lock can be taken again once released")
+
+	// A lock left behind by a crashed run is taken over
+	stale := time.Now().Add(-2 * staleLockAge)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, lockFileName), stale, stale))
+	unlockAgain, err := store.Lock()
+	require.NoError(t, err)
+	unlockAgain()
+	unlock() // Removing an already removed lock only logs
+}
@@ -0,0 +1,29 @@
+package secrets
+
+import "errors"
+
+// Sentinel errors for secret storage.
+
+// ErrNotFound indicates the requested secret is not stored in the backend.
+var ErrNotFound = errors.New("secret not found")
+
+// ErrUnknownBackend indicates secrets.backend names a backend that does not exist.
+var ErrUnknownBackend = errors.New("unknown secrets backend")
+
+// ErrUnsupportedBackend indicates the backend is not available on this platform (e.g. wincred outside Windows).
+var ErrUnsupportedBackend = errors.New("secrets backend not supported on this platform")
+
+// ErrReadOnlyBackend indicates the backend cannot store secrets (e.g. env).
+var ErrReadOnlyBackend = errors.New("secrets backend is read-only")
+
+// ErrBackendRead indicates the backend failed to read a secret for a reason other than it being absent.
+var ErrBackendRead = errors.New("failed to read secret")
+
+// ErrBackendWrite indicates the backend failed to store a secret.
+var ErrBackendWrite = errors.New("failed to store secret")
+
+// ErrInvalidReference indicates secrets.api_key is not a valid reference to a secret in an external secret manager.
+var ErrInvalidReference = errors.New("invalid secret reference")
+
+// ErrResolve indicates an external secret manager failed to return a referenced secret.
+var ErrResolve = errors.New("failed to resolve secret")
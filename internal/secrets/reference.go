@@ -0,0 +1,207 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Schemes of the references to secrets in external secret managers resolved out of the box.
+const (
+	SchemeVault             = "vault"  // HashiCorp Vault KV, vault://PATH#FIELD, read with the vault CLI
+	SchemeAWSSecretsManager = "aws-sm" // AWS Secrets Manager, aws-sm://NAME[#JSON_KEY], read with the aws CLI
+	SchemeOnePassword       = "op"     // 1Password, op://VAULT/ITEM/FIELD, read with the op CLI
+)
+
+// ResolveTimeout bounds how long a secret manager may take to return a secret.
+const ResolveTimeout = 30 * time.Second
+
+// Reference identifies a secret held in an external secret manager, written as
+// SCHEME://PATH[#FIELD], e.g. vault://secret/ticketron#openai_api_key.
+type Reference struct {
+	Scheme string
+	Path   string // Between "://" and "#"
+	Field  string // After "#", if any
+}
+
+// String returns the reference as written in the configuration.
+func (r Reference) String() string {
+	text := r.Scheme + "://" + r.Path
+	if r.Field != "" {
+		text += "#" + r.Field
+	}
+	return text
+}
+
+// ParseReference parses value as a reference to a secret. It reports false if value does not
+// start with the scheme of a registered resolver.
+func ParseReference(value string) (Reference, bool) {
+	scheme, rest, ok := strings.Cut(strings.TrimSpace(value), "://")
+	if !ok || resolverFor(scheme) == nil {
+		return Reference{}, false
+	}
+	path, field, _ := strings.Cut(rest, "#")
+	return Reference{Scheme: scheme, Path: path, Field: field}, true
+}
+
+// Resolver fetches the secrets referenced with one scheme from a secret manager.
+type Resolver interface {
+	Resolve(ctx context.Context, ref Reference) (string, error)
+}
+
+// ResolverFunc adapts a function to a Resolver.
+type ResolverFunc func(ctx context.Context, ref Reference) (string, error)
+
+// Resolve calls f.
+func (f ResolverFunc) Resolve(ctx context.Context, ref Reference) (string, error) {
+	return f(ctx, ref)
+}
+
+var (
+	resolversMu sync.RWMutex
+	resolvers   = map[string]Resolver{
+		SchemeVault:             ResolverFunc(resolveVault),
+		SchemeAWSSecretsManager: ResolverFunc(resolveAWSSecretsManager),
+		SchemeOnePassword:       ResolverFunc(resolveOnePassword),
+	}
+)
+
+// RegisterResolver makes references with scheme resolvable by r, replacing the resolver
+// registered for scheme before, if any.
+func RegisterResolver(scheme string, r Resolver) {
+	resolversMu.Lock()
+	defer resolversMu.Unlock()
+	resolvers[strings.ToLower(scheme)] = r
+}
+
+// Schemes returns the schemes references can use, sorted.
+func Schemes() []string {
+	resolversMu.RLock()
+	defer resolversMu.RUnlock()
+	schemes := make([]string, 0, len(resolvers))
+	for scheme := range resolvers {
+		schemes = append(schemes, scheme)
+	}
+	sort.Strings(schemes)
+	return schemes
+}
+
+func resolverFor(scheme string) Resolver {
+	resolversMu.RLock()
+	defer resolversMu.RUnlock()
+	return resolvers[strings.ToLower(scheme)]
+}
+
+// Resolve returns the secret ref refers to. The secret is never part of the returned errors.
+func Resolve(ctx context.Context, ref Reference) (string, error) {
+	resolver := resolverFor(ref.Scheme)
+	if resolver == nil {
+		return "", fmt.Errorf("%w %q (expected %s)", ErrInvalidReference, ref, strings.Join(Schemes(), ", "))
+	}
+	ctx, cancel := context.WithTimeout(ctx, ResolveTimeout)
+	defer cancel()
+	secret, err := resolver.Resolve(ctx, ref)
+	if err != nil {
+		return "", fmt.Errorf("%w %s: %w", ErrResolve, ref, err)
+	}
+	if secret == "" {
+		return "", fmt.Errorf("%w %s: the secret is empty", ErrResolve, ref)
+	}
+	return secret, nil
+}
+
+// runCommand runs a secret manager CLI and returns its standard output without the
+// trailing newline; replaced in tests.
+var runCommand = func(ctx context.Context, name string, args ...string) (string, error) {
+	out, err := exec.CommandContext(ctx, name, args...).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("%s: %w: %s", name, err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	return strings.TrimRight(string(out), "\r\n"), nil
+}
+
+// checkCLIArgument rejects a reference path the secret manager CLI would read as a flag.
+func checkCLIArgument(path string) error {
+	if strings.HasPrefix(path, "-") {
+		return fmt.Errorf("%w: path %q must not start with '-'", ErrInvalidReference, path)
+	}
+	return nil
+}
+
+// resolveVault reads a field of a Vault KV secret, authenticating as the vault CLI does
+// (VAULT_ADDR, VAULT_TOKEN, ...).
+func resolveVault(ctx context.Context, ref Reference) (string, error) {
+	if ref.Path == "" || ref.Field == "" {
+		return "", fmt.Errorf("%w: expected vault://PATH#FIELD", ErrInvalidReference)
+	}
+	if err := checkCLIArgument(ref.Path); err != nil {
+		return "", err
+	}
+	return runCommand(ctx, "vault", "kv", "get", "-field="+ref.Field, ref.Path)
+}
+
+// resolveAWSSecretsManager reads a secret string from AWS Secrets Manager, authenticating
+// as the aws CLI does. With a field, the secret string is a JSON object holding the secret.
+func resolveAWSSecretsManager(ctx context.Context, ref Reference) (string, error) {
+	if ref.Path == "" {
+		return "", fmt.Errorf("%w: expected aws-sm://NAME[#JSON_KEY]", ErrInvalidReference)
+	}
+	if err := checkCLIArgument(ref.Path); err != nil {
+		return "", err
+	}
+	secret, err := runCommand(ctx, "aws", "secretsmanager", "get-secret-value",
+		"--secret-id", ref.Path, "--query", "SecretString", "--output", "text")
+	if err != nil || ref.Field == "" {
+		return secret, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(secret), &fields); err != nil {
+		return "", fmt.Errorf("the secret is not a JSON object, so #%s cannot be selected", ref.Field)
+	}
+	value, ok := fields[ref.Field].(string)
+	if !ok {
+		return "", fmt.Errorf("the secret has no string key %q", ref.Field)
+	}
+	return value, nil
+}
+
+// resolveOnePassword reads a 1Password secret reference with the op CLI, which
+// authenticates with OP_SERVICE_ACCOUNT_TOKEN in CI.
+func resolveOnePassword(ctx context.Context, ref Reference) (string, error) {
+	if ref.Path == "" {
+		return "", fmt.Errorf("%w: expected op://VAULT/ITEM/FIELD", ErrInvalidReference)
+	}
+	return runCommand(ctx, "op", "read", "--no-newline", ref.String())
+}
+
+// referenceBackend reads the LLM API key from the external secret manager named by
+// secrets.api_key. It cannot store secrets.
+type referenceBackend struct {
+	ref Reference
+}
+
+func (r *referenceBackend) Get(service, user string) (string, error) {
+	secret, err := Resolve(context.Background(), r.ref)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrBackendRead, err)
+	}
+	return secret, nil
+}
+
+func (r *referenceBackend) Set(service, user, secret string) error {
+	return fmt.Errorf("%w: the API key is read from %s; update it there", ErrReadOnlyBackend, r.ref)
+}
+
+func (r *referenceBackend) Status() Status {
+	return Status{Configured: r.ref.Scheme, Active: r.ref.Scheme, Detail: r.ref.String(), Available: true}
+}
@@ -0,0 +1,127 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/config"
+)
+
+// fakeCommands replaces runCommand with one returning outputs by command line and records
+// the command lines run.
+func fakeCommands(t *testing.T, outputs map[string]string) *[]string {
+	var calls []string
+	original := runCommand
+	runCommand = func(ctx context.Context, name string, args ...string) (string, error) {
+		line := strings.Join(append([]string{name}, args...), " ")
+		calls = append(calls, line)
+		out, ok := outputs[line]
+		if !ok {
+			return "", errors.New(name + ": exit status 1: not found")
+		}
+		return out, nil
+	}
+	t.Cleanup(func() { runCommand = original })
+	return &calls
+}
+
+func TestParseReference(t *testing.T) {
+	ref, ok := ParseReference("vault://secret/ticketron#openai_api_key")
+	require.True(t, ok)
+	assert.Equal(t, Reference{Scheme: "vault", Path: "secret/ticketron", Field: "openai_api_key"}, ref)
+	assert.Equal(t, "vault://secret/ticketron#openai_api_key", ref.String())
+
+	ref, ok = ParseReference("aws-sm://arn:aws:secretsmanager:eu-west-1:123456789012:secret:ci/ticketron")
+	require.True(t, ok)
+	assert.Equal(t, "arn:aws:secretsmanager:eu-west-1:123456789012:secret:ci/ticketron", ref.Path)
+
+	for _, value := range []string{"sk-plain", "https://example.com", "gcp-sm://name", ""} {
+		_, ok := ParseReference(value)
+		assert.False(t, ok, value)
+	}
+}
+
+func TestResolve_BuiltinSchemes(t *testing.T) {
+	calls := fakeCommands(t, map[string]string{
+		"vault kv get -field=openai_api_key secret/ticketron":                                             "sk-vault",
+		"aws secretsmanager get-secret-value --secret-id ci/ticketron --query SecretString --output text": `{"openai": "sk-aws", "other": 1}`,
+		"aws secretsmanager get-secret-value --secret-id ci/plain --query SecretString --output text":     "sk-plain",
+		"op read --no-newline op://CI/OpenAI/credential":                                                  "sk-op",
+	})
+	ctx := context.Background()
+
+	for value, want := range map[string]string{
+		"vault://secret/ticketron#openai_api_key": "sk-vault",
+		"aws-sm://ci/ticketron#openai":            "sk-aws",
+		"aws-sm://ci/plain":                       "sk-plain",
+		"op://CI/OpenAI/credential":               "sk-op",
+	} {
+		ref, ok := ParseReference(value)
+		require.True(t, ok, value)
+		secret, err := Resolve(ctx, ref)
+		require.NoError(t, err, value)
+		assert.Equal(t, want, secret, value)
+	}
+	assert.Len(t, *calls, 4)
+
+	for value, message := range map[string]string{
+		"vault://secret/ticketron":    "expected vault://PATH#FIELD",
+		"aws-sm://ci/plain#openai":    "not a JSON object",
+		"aws-sm://ci/ticketron#other": `no string key "other"`,
+		"op://CI/Missing/credential":  "op: exit status 1: not found",
+		"vault://-address=x#token":    "must not start with '-'",
+		"aws-sm://--profile=other":    "must not start with '-'",
+	} {
+		ref, _ := ParseReference(value)
+		_, err := Resolve(ctx, ref)
+		assert.ErrorIs(t, err, ErrResolve, value)
+		assert.ErrorContains(t, err, message, value)
+		assert.NotContains(t, err.Error(), "sk-", "secrets are not part of errors")
+	}
+	assert.Len(t, *calls, 7, "paths read as flags are not passed to the CLIs")
+}
+
+func TestRegisterResolver(t *testing.T) {
+	RegisterResolver("test-sm", ResolverFunc(func(ctx context.Context, ref Reference) (string, error) {
+		return "secret-for-" + ref.Path, nil
+	}))
+	t.Cleanup(func() {
+		resolversMu.Lock()
+		delete(resolvers, "test-sm")
+		resolversMu.Unlock()
+	})
+	assert.Contains(t, Schemes(), "test-sm")
+
+	ref, ok := ParseReference("test-sm://ci")
+	require.True(t, ok)
+	secret, err := Resolve(context.Background(), ref)
+	require.NoError(t, err)
+	assert.Equal(t, "secret-for-ci", secret)
+}
+
+func TestNew_Reference(t *testing.T) {
+	fakeCommands(t, map[string]string{"vault kv get -field=key secret/ci": "sk-ci"})
+
+	backend, err := New(config.SecretsConfig{Backend: "keyring", APIKey: "vault://secret/ci#key"}, t.TempDir())
+	require.NoError(t, err)
+	secret, err := backend.Get("ticketron", "openai_api_key")
+	require.NoError(t, err)
+	assert.Equal(t, "sk-ci", secret)
+	assert.ErrorIs(t, backend.Set("ticketron", "openai_api_key", "x"), ErrReadOnlyBackend)
+	assert.Equal(t, Status{Configured: "vault", Active: "vault", Detail: "vault://secret/ci#key", Available: true}, backend.Status())
+
+	backend, err = New(config.SecretsConfig{APIKey: "vault://secret/other#key"}, t.TempDir())
+	require.NoError(t, err)
+	_, err = backend.Get("ticketron", "openai_api_key")
+	assert.ErrorIs(t, err, ErrBackendRead)
+	assert.NotErrorIs(t, err, ErrNotFound, "a failing reference is not a missing key")
+
+	_, err = New(config.SecretsConfig{APIKey: "sk-plain"}, t.TempDir())
+	assert.ErrorIs(t, err, ErrInvalidReference)
+	assert.ErrorContains(t, err, "aws-sm://, op://, vault://")
+}
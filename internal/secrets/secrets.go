@@ -0,0 +1,298 @@
+// Package secrets stores credentials such as the LLM API key in a configurable backend:
+// the OS keyring (macOS Keychain, Secret Service, Windows Credential Manager), a
+// permission-restricted file in the config directory, or environment variables. The API
+// key can also be read from an external secret manager such as Vault (see Reference).
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/zalando/go-keyring"
+	"gopkg.in/yaml.v3"
+
+	"github.com/karolswdev/ticketron/internal/config"
+)
+
+// Backend names accepted in secrets.backend.
+const (
+	BackendAuto    = "auto"    // OS keyring, falling back to the file backend when it is unavailable
+	BackendKeyring = "keyring" // OS keyring on any platform
+	BackendWinCred = "wincred" // Windows Credential Manager (Windows only)
+	BackendFile    = "file"    // YAML file in the config directory, mode 0600
+	BackendEnv     = "env"     // Read-only, TICKETRON_LLM_API_KEY
+)
+
+// DefaultFileName is the credentials file used by the file backend.
+const DefaultFileName = "credentials.yaml"
+
+// Backend stores and retrieves secrets identified by service and user.
+type Backend interface {
+	// Get returns the secret or an error wrapping ErrNotFound if it is not stored.
+	Get(service, user string) (string, error)
+	// Set stores the secret.
+	Set(service, user, secret string) error
+	// Status describes the backend and whether it is usable.
+	Status() Status
+}
+
+// Status describes a backend for diagnostics such as `tix config show`.
+type Status struct {
+	Configured string // Backend named in the configuration
+	Active     string // Backend actually used (differs from Configured for auto)
+	Detail     string // Location or reason, e.g. the credentials file path
+	Available  bool
+}
+
+// String renders the status on a single line, e.g. "auto -> file (keyring unavailable: ...)".
+func (s Status) String() string {
+	text := s.Configured
+	if s.Active != "" && s.Active != s.Configured {
+		text += " -> " + s.Active
+	}
+	if s.Detail != "" {
+		text += " (" + s.Detail + ")"
+	}
+	if !s.Available {
+		text += " [unavailable]"
+	}
+	return text
+}
+
+// New returns the backend selected by cfg. configDir is used to locate the credentials
+// file when secrets.file is not set. A reference in secrets.api_key takes precedence over
+// secrets.backend.
+func New(cfg config.SecretsConfig, configDir string) (Backend, error) {
+	if cfg.APIKey != "" {
+		ref, ok := ParseReference(cfg.APIKey)
+		if !ok {
+			return nil, fmt.Errorf("%w: secrets.api_key must start with %s:// followed by the secret's location", ErrInvalidReference, strings.Join(Schemes(), "://, "))
+		}
+		return &referenceBackend{ref: ref}, nil
+	}
+	filePath := cfg.File
+	if filePath == "" {
+		filePath = filepath.Join(configDir, DefaultFileName)
+	}
+	switch strings.ToLower(cfg.Backend) {
+	case "", BackendAuto:
+		return &autoBackend{keyring: &keyringBackend{name: BackendKeyring}, file: &fileBackend{path: filePath}}, nil
+	case BackendKeyring:
+		return &keyringBackend{name: BackendKeyring}, nil
+	case BackendWinCred:
+		if runtime.GOOS != "windows" {
+			return nil, fmt.Errorf("%w: %s requires Windows (running on %s)", ErrUnsupportedBackend, BackendWinCred, runtime.GOOS)
+		}
+		return &keyringBackend{name: BackendWinCred}, nil
+	case BackendFile:
+		return &fileBackend{path: filePath}, nil
+	case BackendEnv:
+		return envBackend{}, nil
+	default:
+		return nil, fmt.Errorf("%w %q (expected auto, keyring, wincred, file or env)", ErrUnknownBackend, cfg.Backend)
+	}
+}
+
+// keyringBackend uses the OS credential store via go-keyring.
+type keyringBackend struct {
+	name string
+}
+
+func (k *keyringBackend) Get(service, user string) (string, error) {
+	secret, err := keyring.Get(service, user)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", fmt.Errorf("%w in %s", ErrNotFound, k.name)
+	}
+	if err != nil {
+		return "", fmt.Errorf("%w from %s: %w", ErrBackendRead, k.name, err)
+	}
+	return secret, nil
+}
+
+func (k *keyringBackend) Set(service, user, secret string) error {
+	if err := keyring.Set(service, user, secret); err != nil {
+		return fmt.Errorf("%w in %s: %w", ErrBackendWrite, k.name, err)
+	}
+	return nil
+}
+
+func (k *keyringBackend) Status() Status {
+	status := Status{Configured: k.name, Active: k.name, Detail: keyringDescription()}
+	if err := probeKeyring(); err != nil {
+		status.Detail = err.Error()
+		return status
+	}
+	status.Available = true
+	return status
+}
+
+// probeKeyring checks that the OS keyring can be queried. A missing entry means it works.
+func probeKeyring() error {
+	_, err := keyring.Get("ticketron", "backend_probe")
+	if err == nil || errors.Is(err, keyring.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// keyringDescription names the OS credential store used by go-keyring.
+func keyringDescription() string {
+	switch runtime.GOOS {
+	case "windows":
+		return "Windows Credential Manager"
+	case "darwin":
+		return "macOS Keychain"
+	default:
+		if IsWSL() {
+			return "Secret Service via D-Bus (WSL)"
+		}
+		return "Secret Service via D-Bus"
+	}
+}
+
+// autoBackend prefers the OS keyring and falls back to the credentials file when the
+// keyring is unavailable, as is common on WSL and headless Linux.
+type autoBackend struct {
+	keyring *keyringBackend
+	file    *fileBackend
+}
+
+func (a *autoBackend) Get(service, user string) (string, error) {
+	secret, err := a.keyring.Get(service, user)
+	if err == nil {
+		return secret, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		log.Debug().Err(err).Msg("OS keyring unavailable, reading secret from credentials file")
+	}
+	return a.file.Get(service, user)
+}
+
+func (a *autoBackend) Set(service, user, secret string) error {
+	err := a.keyring.Set(service, user, secret)
+	if err == nil {
+		return nil
+	}
+	log.Warn().Err(err).Str("path", a.file.path).Msg("OS keyring unavailable, storing secret in credentials file instead")
+	return a.file.Set(service, user, secret)
+}
+
+func (a *autoBackend) Status() Status {
+	if err := probeKeyring(); err != nil {
+		status := a.file.Status()
+		status.Configured = BackendAuto
+		status.Detail = fmt.Sprintf("%s; OS keyring unavailable: %v", status.Detail, err)
+		return status
+	}
+	return Status{Configured: BackendAuto, Active: BackendKeyring, Detail: keyringDescription(), Available: true}
+}
+
+// fileBackend stores secrets in a YAML map keyed by "service/user", readable only by the owner.
+type fileBackend struct {
+	path string
+}
+
+func (f *fileBackend) Get(service, user string) (string, error) {
+	entries, err := f.read()
+	if err != nil {
+		return "", err
+	}
+	secret, ok := entries[fileKey(service, user)]
+	if !ok {
+		return "", fmt.Errorf("%w in %s", ErrNotFound, f.path)
+	}
+	return secret, nil
+}
+
+func (f *fileBackend) Set(service, user, secret string) error {
+	entries, err := f.read()
+	if err != nil {
+		return err
+	}
+	entries[fileKey(service, user)] = secret
+	data, err := yaml.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrBackendWrite, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(f.path), 0700); err != nil {
+		return fmt.Errorf("%w: %w", ErrBackendWrite, err)
+	}
+	if err := os.WriteFile(f.path, data, 0600); err != nil {
+		return fmt.Errorf("%w: %w", ErrBackendWrite, err)
+	}
+	// WriteFile keeps the mode of an existing file, so tighten it explicitly.
+	if err := os.Chmod(f.path, 0600); err != nil {
+		return fmt.Errorf("%w: %w", ErrBackendWrite, err)
+	}
+	return nil
+}
+
+func (f *fileBackend) Status() Status {
+	return Status{Configured: BackendFile, Active: BackendFile, Detail: f.path, Available: true}
+}
+
+// read loads all entries; a missing file yields an empty map.
+func (f *fileBackend) read() (map[string]string, error) {
+	entries := map[string]string{}
+	info, err := os.Stat(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrBackendRead, err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0 {
+		log.Warn().Str("path", f.path).Str("mode", info.Mode().Perm().String()).Msg("Credentials file is accessible by other users; run chmod 600 on it")
+	}
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrBackendRead, err)
+	}
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("%w: parsing %s: %w", ErrBackendRead, f.path, err)
+	}
+	return entries, nil
+}
+
+func fileKey(service, user string) string {
+	return service + "/" + user
+}
+
+// envBackend reads the API key from TICKETRON_LLM_API_KEY. It cannot store secrets.
+type envBackend struct{}
+
+func (envBackend) Get(service, user string) (string, error) {
+	if secret := os.Getenv(config.EnvAPIKeyName); secret != "" {
+		return secret, nil
+	}
+	return "", fmt.Errorf("%w: %s is not set", ErrNotFound, config.EnvAPIKeyName)
+}
+
+func (envBackend) Set(service, user, secret string) error {
+	return fmt.Errorf("%w: set %s in your environment instead", ErrReadOnlyBackend, config.EnvAPIKeyName)
+}
+
+func (envBackend) Status() Status {
+	status := Status{Configured: BackendEnv, Active: BackendEnv, Detail: config.EnvAPIKeyName}
+	status.Available = os.Getenv(config.EnvAPIKeyName) != ""
+	return status
+}
+
+// IsWSL reports whether the process runs under Windows Subsystem for Linux, where the
+// Secret Service keyring is often unavailable.
+func IsWSL() bool {
+	if runtime.GOOS != "linux" {
+		return false
+	}
+	data, err := os.ReadFile("/proc/sys/kernel/osrelease")
+	if err != nil {
+		return false
+	}
+	release := strings.ToLower(string(data))
+	return strings.Contains(release, "microsoft") || strings.Contains(release, "wsl")
+}
@@ -0,0 +1,126 @@
+package secrets
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zalando/go-keyring"
+
+	"github.com/karolswdev/ticketron/internal/config"
+)
+
+func TestNew(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"", "auto", "keyring", "file", "env", "FILE"} {
+		_, err := New(config.SecretsConfig{Backend: name}, dir)
+		assert.NoError(t, err, name)
+	}
+
+	_, err := New(config.SecretsConfig{Backend: "vault"}, dir)
+	assert.ErrorIs(t, err, ErrUnknownBackend)
+
+	_, err = New(config.SecretsConfig{Backend: "wincred"}, dir)
+	if runtime.GOOS == "windows" {
+		assert.NoError(t, err)
+	} else {
+		assert.ErrorIs(t, err, ErrUnsupportedBackend)
+	}
+}
+
+func TestFileBackend(t *testing.T) {
+	dir := t.TempDir()
+	backend, err := New(config.SecretsConfig{Backend: "file"}, dir)
+	require.NoError(t, err)
+
+	_, err = backend.Get("ticketron", "openai_api_key")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, backend.Set("ticketron", "openai_api_key", "sk-file"))
+	secret, err := backend.Get("ticketron", "openai_api_key")
+	require.NoError(t, err)
+	assert.Equal(t, "sk-file", secret)
+
+	path := filepath.Join(dir, DefaultFileName)
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+	assert.Equal(t, Status{Configured: "file", Active: "file", Detail: path, Available: true}, backend.Status())
+
+	custom := filepath.Join(t.TempDir(), "nested", "creds.yaml")
+	backend, err = New(config.SecretsConfig{Backend: "file", File: custom}, dir)
+	require.NoError(t, err)
+	require.NoError(t, backend.Set("ticketron", "openai_api_key", "sk-custom"))
+	assert.FileExists(t, custom)
+}
+
+func TestEnvBackend(t *testing.T) {
+	backend, err := New(config.SecretsConfig{Backend: "env"}, t.TempDir())
+	require.NoError(t, err)
+
+	t.Setenv(config.EnvAPIKeyName, "")
+	_, err = backend.Get("ticketron", "openai_api_key")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.False(t, backend.Status().Available)
+
+	t.Setenv(config.EnvAPIKeyName, "sk-env")
+	secret, err := backend.Get("ticketron", "openai_api_key")
+	require.NoError(t, err)
+	assert.Equal(t, "sk-env", secret)
+	assert.ErrorIs(t, backend.Set("ticketron", "openai_api_key", "x"), ErrReadOnlyBackend)
+}
+
+func TestAutoBackend_UsesKeyring(t *testing.T) {
+	keyring.MockInit()
+	dir := t.TempDir()
+	backend, err := New(config.SecretsConfig{}, dir)
+	require.NoError(t, err)
+
+	require.NoError(t, backend.Set("ticketron", "openai_api_key", "sk-keyring"))
+	secret, err := backend.Get("ticketron", "openai_api_key")
+	require.NoError(t, err)
+	assert.Equal(t, "sk-keyring", secret)
+	assert.NoFileExists(t, filepath.Join(dir, DefaultFileName))
+
+	status := backend.Status()
+	assert.Equal(t, "auto", status.Configured)
+	assert.Equal(t, "keyring", status.Active)
+	assert.True(t, status.Available)
+}
+
+func TestAutoBackend_FallsBackToFile(t *testing.T) {
+	keyring.MockInitWithError(errors.New("dbus: no session bus"))
+	t.Cleanup(keyring.MockInit)
+	dir := t.TempDir()
+	backend, err := New(config.SecretsConfig{Backend: "auto"}, dir)
+	require.NoError(t, err)
+
+	require.NoError(t, backend.Set("ticketron", "openai_api_key", "sk-wsl"))
+	assert.FileExists(t, filepath.Join(dir, DefaultFileName))
+	secret, err := backend.Get("ticketron", "openai_api_key")
+	require.NoError(t, err)
+	assert.Equal(t, "sk-wsl", secret)
+
+	status := backend.Status()
+	assert.Equal(t, "auto", status.Configured)
+	assert.Equal(t, "file", status.Active)
+	assert.Contains(t, status.Detail, "OS keyring unavailable: dbus: no session bus")
+
+	// The keyring backend itself reports the failure instead of falling back.
+	strict, err := New(config.SecretsConfig{Backend: "keyring"}, dir)
+	require.NoError(t, err)
+	_, err = strict.Get("ticketron", "openai_api_key")
+	assert.ErrorIs(t, err, ErrBackendRead)
+	assert.False(t, strict.Status().Available)
+}
+
+func TestStatusString(t *testing.T) {
+	assert.Equal(t, "auto -> keyring (macOS Keychain)", Status{Configured: "auto", Active: "keyring", Detail: "macOS Keychain", Available: true}.String())
+	assert.Equal(t, "env (TICKETRON_LLM_API_KEY) [unavailable]", Status{Configured: "env", Active: "env", Detail: "TICKETRON_LLM_API_KEY"}.String())
+}
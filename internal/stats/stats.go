@@ -0,0 +1,167 @@
+// Package stats aggregates search results into quick project statistics: issue counts by
+// status, type and assignee, and created, resolved and open issues over time.
+package stats
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+// jiraTimeLayouts are the timestamp formats of created and resolution dates.
+var jiraTimeLayouts = []string{"2006-01-02T15:04:05.000-0700", time.RFC3339}
+
+// sparkBlocks are the bars of a sparkline, from lowest to highest.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// maxDailyBuckets is the longest period shown per day; longer periods are shown per week.
+const maxDailyBuckets = 31
+
+// Count is the number of issues with one value of a field.
+type Count struct {
+	Name  string `json:"name" yaml:"name"`
+	Count int    `json:"count" yaml:"count"`
+}
+
+// Trend holds, per bucket starting at Starts, the number of issues created and resolved
+// in the bucket and the number open at its end.
+type Trend struct {
+	Bucket   string      `json:"bucket" yaml:"bucket"` // "day" or "week"
+	Starts   []time.Time `json:"starts" yaml:"starts"`
+	Created  []int       `json:"created" yaml:"created"`
+	Resolved []int       `json:"resolved" yaml:"resolved"`
+	Open     []int       `json:"open" yaml:"open"`
+}
+
+// Report is the statistics of a set of issues over a period.
+type Report struct {
+	Since      time.Time `json:"since" yaml:"since"`
+	Until      time.Time `json:"until" yaml:"until"`
+	Total      int       `json:"total" yaml:"total"`
+	ByStatus   []Count   `json:"by_status" yaml:"by_status"`
+	ByType     []Count   `json:"by_type" yaml:"by_type"`
+	ByAssignee []Count   `json:"by_assignee" yaml:"by_assignee"`
+	Trend      Trend     `json:"trend" yaml:"trend"`
+}
+
+// Compute aggregates issues over the period from the start of the day of since until now.
+// Issues without a parseable created date count in the totals but not in the trend.
+func Compute(issues []mcpclient.Issue, since, now time.Time) Report {
+	loc := now.Location()
+	since = since.In(loc)
+	start := time.Date(since.Year(), since.Month(), since.Day(), 0, 0, 0, 0, loc)
+	report := Report{Since: start, Until: now, Total: len(issues)}
+
+	statuses, types, assignees := make(map[string]int), make(map[string]int), make(map[string]int)
+	for _, issue := range issues {
+		statuses[orNone(issue.Fields.Status.Name)]++
+		types[orNone(issue.Fields.IssueType.Name)]++
+		assignee := "Unassigned"
+		if a := issue.Fields.Assignee; a != nil && a.DisplayName != "" {
+			assignee = a.DisplayName
+		}
+		assignees[assignee]++
+	}
+	report.ByStatus = sortedCounts(statuses)
+	report.ByType = sortedCounts(types)
+	report.ByAssignee = sortedCounts(assignees)
+	report.Trend = trend(issues, start, now)
+	return report
+}
+
+// trend buckets the created and resolved dates of issues per day, or per week for periods
+// longer than maxDailyBuckets days.
+func trend(issues []mcpclient.Issue, start, now time.Time) Trend {
+	t := Trend{Bucket: "day"}
+	days := 1
+	if now.Sub(start) > maxDailyBuckets*24*time.Hour {
+		t.Bucket, days = "week", 7
+	}
+	for bucket := start; !bucket.After(now); bucket = bucket.AddDate(0, 0, days) {
+		t.Starts = append(t.Starts, bucket)
+	}
+	t.Created = make([]int, len(t.Starts))
+	t.Resolved = make([]int, len(t.Starts))
+	t.Open = make([]int, len(t.Starts))
+
+	for _, issue := range issues {
+		created, ok := parseTime(issue.Fields.Created)
+		if !ok {
+			continue
+		}
+		resolved, isResolved := parseTime(issue.Fields.ResolutionDate)
+		for i, bucket := range t.Starts {
+			end := bucket.AddDate(0, 0, days)
+			if !created.Before(bucket) && created.Before(end) {
+				t.Created[i]++
+			}
+			if isResolved && !resolved.Before(bucket) && resolved.Before(end) {
+				t.Resolved[i]++
+			}
+			if created.Before(end) && (!isResolved || !resolved.Before(end)) {
+				t.Open[i]++
+			}
+		}
+	}
+	return t
+}
+
+// Sparkline renders values as a line of bars scaled between zero and the largest value.
+func Sparkline(values []int) string {
+	peak := 0
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+	var b strings.Builder
+	for _, v := range values {
+		if peak == 0 || v <= 0 {
+			b.WriteRune(sparkBlocks[0])
+			continue
+		}
+		b.WriteRune(sparkBlocks[(v*(len(sparkBlocks)-1)+peak-1)/peak])
+	}
+	return b.String()
+}
+
+// Sum returns the sum of values.
+func Sum(values []int) int {
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+// sortedCounts returns the counts, largest first and then by name.
+func sortedCounts(counts map[string]int) []Count {
+	result := make([]Count, 0, len(counts))
+	for name, count := range counts {
+		result = append(result, Count{Name: name, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+func orNone(name string) string {
+	if name == "" {
+		return "None"
+	}
+	return name
+}
+
+// parseTime parses a Jira timestamp.
+func parseTime(s string) (time.Time, bool) {
+	for _, layout := range jiraTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,63 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+func statsTestIssue(status, issueType, assignee, created, resolved string) mcpclient.Issue {
+	issue := mcpclient.Issue{Fields: mcpclient.IssueFields{
+		Status:         mcpclient.Status{Name: status},
+		IssueType:      mcpclient.IssueType{Name: issueType},
+		Created:        created,
+		ResolutionDate: resolved,
+	}}
+	if assignee != "" {
+		issue.Fields.Assignee = &mcpclient.User{DisplayName: assignee}
+	}
+	return issue
+}
+
+func TestCompute(t *testing.T) {
+	now := time.Date(2025, time.April, 16, 14, 30, 0, 0, time.UTC)
+	issues := []mcpclient.Issue{
+		statsTestIssue("Done", "Bug", "Jane Doe", "2025-04-01T10:00:00.000+0000", "2025-04-15T09:00:00.000+0000"), // Created before the period
+		statsTestIssue("To Do", "Task", "", "2025-04-14T10:00:00.000+0000", ""),
+		statsTestIssue("In Progress", "Bug", "Jane Doe", "2025-04-14T12:00:00.000+0200", ""),
+		statsTestIssue("To Do", "Story", "John Smith", "2025-04-16T08:00:00Z", ""),
+		statsTestIssue("To Do", "Bug", "", "not a date", ""),
+	}
+	report := Compute(issues, now.AddDate(0, 0, -3), now)
+
+	assert.Equal(t, time.Date(2025, time.April, 13, 0, 0, 0, 0, time.UTC), report.Since)
+	assert.Equal(t, 5, report.Total)
+	assert.Equal(t, []Count{{"To Do", 3}, {"Done", 1}, {"In Progress", 1}}, report.ByStatus)
+	assert.Equal(t, []Count{{"Bug", 3}, {"Story", 1}, {"Task", 1}}, report.ByType)
+	assert.Equal(t, []Count{{"Jane Doe", 2}, {"Unassigned", 2}, {"John Smith", 1}}, report.ByAssignee)
+
+	require.Len(t, report.Trend.Starts, 4)
+	assert.Equal(t, "day", report.Trend.Bucket)
+	assert.Equal(t, []int{0, 2, 0, 1}, report.Trend.Created)
+	assert.Equal(t, []int{0, 0, 1, 0}, report.Trend.Resolved)
+	assert.Equal(t, []int{1, 3, 2, 3}, report.Trend.Open)
+}
+
+func TestCompute_WeeklyBuckets(t *testing.T) {
+	now := time.Date(2025, time.April, 16, 0, 0, 0, 0, time.UTC)
+	report := Compute(nil, now.AddDate(0, 0, -60), now)
+	assert.Equal(t, "week", report.Trend.Bucket)
+	assert.Len(t, report.Trend.Starts, 9)
+	assert.Empty(t, report.ByStatus)
+}
+
+func TestSparkline(t *testing.T) {
+	assert.Equal(t, "▁▂▅█▁", Sparkline([]int{0, 1, 4, 7, 0}))
+	assert.Equal(t, "▁▁▁", Sparkline([]int{0, 0, 0}))
+	assert.Equal(t, "", Sparkline(nil))
+	assert.Equal(t, 12, Sum([]int{0, 1, 4, 7, 0}))
+}
@@ -0,0 +1,209 @@
+// Package textdiff computes line-based diffs between two texts and renders them
+// in unified diff format.
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OpKind identifies whether a line is shared, removed or added.
+type OpKind int
+
+const (
+	// Equal marks a line present in both texts.
+	Equal OpKind = iota
+	// Delete marks a line present only in the old text.
+	Delete
+	// Insert marks a line present only in the new text.
+	Insert
+)
+
+// Op is a single line of a diff.
+type Op struct {
+	Kind OpKind
+	Line string
+}
+
+// Lines returns the line operations turning a into b, based on the longest common
+// subsequence of lines. It is quadratic in the number of lines and intended for
+// small texts such as prompts and issue fields.
+func Lines(a, b string) []Op {
+	x, y := splitLines(a), splitLines(b)
+	n, m := len(x), len(y)
+
+	// lcs[i][j] is the LCS length of x[i:] and y[j:].
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]Op, 0, n+m)
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case x[i] == y[j]:
+			ops = append(ops, Op{Equal, x[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, Op{Delete, x[i]})
+			i++
+		default:
+			ops = append(ops, Op{Insert, y[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, Op{Delete, x[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, Op{Insert, y[j]})
+	}
+	return ops
+}
+
+// Unified renders the diff between a and b in unified format with the given number of
+// context lines. It returns an empty string if the texts are identical.
+func Unified(a, b, fromName, toName string, context int) string {
+	ops := Lines(a, b)
+	changed := false
+	for _, op := range ops {
+		if op.Kind != Equal {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return ""
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+
+	// oldLine/newLine track the 1-based line numbers at each op index.
+	oldLine, newLine := make([]int, len(ops)+1), make([]int, len(ops)+1)
+	oldLine[0], newLine[0] = 1, 1
+	for k, op := range ops {
+		oldLine[k+1], newLine[k+1] = oldLine[k], newLine[k]
+		if op.Kind != Insert {
+			oldLine[k+1]++
+		}
+		if op.Kind != Delete {
+			newLine[k+1]++
+		}
+	}
+
+	for k := 0; k < len(ops); {
+		if ops[k].Kind == Equal {
+			k++
+			continue
+		}
+		// Grow the hunk until there are more than 2*context equal lines between changes.
+		start := max(0, k-context)
+		end := k
+		for end < len(ops) {
+			if ops[end].Kind != Equal {
+				end++
+				continue
+			}
+			run := end
+			for run < len(ops) && ops[run].Kind == Equal {
+				run++
+			}
+			if run == len(ops) || run-end > 2*context {
+				end = min(len(ops), end+context)
+				break
+			}
+			end = run
+		}
+
+		oldCount, newCount := 0, 0
+		for _, op := range ops[start:end] {
+			if op.Kind != Insert {
+				oldCount++
+			}
+			if op.Kind != Delete {
+				newCount++
+			}
+		}
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", hunkRange(oldLine[start], oldCount), hunkRange(newLine[start], newCount))
+		for _, op := range ops[start:end] {
+			switch op.Kind {
+			case Equal:
+				sb.WriteString(" ")
+			case Delete:
+				sb.WriteString("-")
+			case Insert:
+				sb.WriteString("+")
+			}
+			sb.WriteString(op.Line)
+			sb.WriteString("\n")
+		}
+		k = end
+	}
+	return sb.String()
+}
+
+// hunkRange formats a hunk range; an empty range starts at the line before it.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		start--
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// ANSI colors of Colorize.
+const (
+	ansiReset = "\x1b[0m"
+	ansiBold  = "\x1b[1m"
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+	ansiCyan  = "\x1b[36m"
+)
+
+// Colorize returns a diff rendered by Unified with ANSI colors, as git shows it: file
+// names in bold, hunk headers in cyan, removed lines in red and added lines in green.
+func Colorize(diff string) string {
+	lines := strings.SplitAfter(diff, "\n")
+	for i, line := range lines {
+		text, newline := strings.CutSuffix(line, "\n")
+		var seq string
+		switch {
+		case strings.HasPrefix(text, "--- ") || strings.HasPrefix(text, "+++ "):
+			seq = ansiBold
+		case strings.HasPrefix(text, "@@"):
+			seq = ansiCyan
+		case strings.HasPrefix(text, "-"):
+			seq = ansiRed
+		case strings.HasPrefix(text, "+"):
+			seq = ansiGreen
+		default:
+			continue
+		}
+		lines[i] = seq + text + ansiReset
+		if newline {
+			lines[i] += "\n"
+		}
+	}
+	return strings.Join(lines, "")
+}
@@ -0,0 +1,40 @@
+package textdiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLines(t *testing.T) {
+	ops := Lines("a\nb\nc\n", "a\nx\nc\n")
+	assert.Equal(t, []Op{{Equal, "a"}, {Delete, "b"}, {Insert, "x"}, {Equal, "c"}}, ops)
+
+	assert.Equal(t, []Op{{Insert, "new"}}, Lines("", "new"))
+	assert.Equal(t, []Op{{Delete, "old"}}, Lines("old", ""))
+}
+
+func TestUnified(t *testing.T) {
+	assert.Empty(t, Unified("same\n", "same\n", "a", "b", 3))
+
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n"
+	b := "1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\neleven\n"
+	want := `--- v1
++++ v2
+@@ -2,3 +2,3 @@
+ 2
+-3
++three
+ 4
+@@ -10 +10,2 @@
+ 10
++eleven
+`
+	assert.Equal(t, want, Unified(a, b, "v1", "v2", 1))
+}
+
+func TestColorize(t *testing.T) {
+	diff := Unified("a\nb", "a\nc", "old", "new", 1)
+	assert.Equal(t, "\x1b[1m--- old\x1b[0m\n\x1b[1m+++ new\x1b[0m\n\x1b[36m@@ -1,2 +1,2 @@\x1b[0m\n a\n\x1b[31m-b\x1b[0m\n\x1b[32m+c\x1b[0m\n", Colorize(diff))
+	assert.Empty(t, Colorize(""))
+}
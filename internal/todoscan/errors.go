@@ -0,0 +1,14 @@
+package todoscan
+
+import "errors"
+
+// Sentinel errors for TODO comment scanning.
+
+// ErrScan indicates the files of the codebase could not be read.
+var ErrScan = errors.New("failed to scan for TODO comments")
+
+// ErrStale indicates a comment changed since it was scanned, so it is not rewritten.
+var ErrStale = errors.New("comment changed since the scan")
+
+// ErrRewrite indicates a file could not be rewritten.
+var ErrRewrite = errors.New("failed to rewrite TODO comment")
@@ -0,0 +1,212 @@
+// Package todoscan finds TODO and FIXME comments in a codebase, tells tracked comments,
+// which refer to an issue key, from untracked ones, and rewrites comments to refer to the
+// issues created for them, as in TODO(PROJ-123): ...
+package todoscan
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// DefaultTags are the comment tags Scan looks for if Options.Tags is empty.
+var DefaultTags = []string{"TODO", "FIXME"}
+
+// MaxFileSize bounds the files Scan reads; larger files are usually generated or data.
+const MaxFileSize = 1 << 20 // 1 MiB
+
+// DefaultContextLines is the number of lines following a comment kept as its context.
+const DefaultContextLines = 5
+
+// skippedDirs are never scanned, besides hidden directories such as .git.
+var skippedDirs = map[string]bool{"vendor": true, "node_modules": true}
+
+// issueKeyRe matches a Jira issue key such as PROJ-123.
+var issueKeyRe = regexp.MustCompile(`\b[A-Z][A-Z0-9_]+-[1-9][0-9]*\b`)
+
+// Todo is a TODO-style comment.
+type Todo struct {
+	File    string   `json:"file"` // Slash-separated, relative to the scanned root
+	Line    int      `json:"line"` // 1-based
+	Tag     string   `json:"tag"`  // e.g. TODO
+	Owner   string   `json:"owner,omitempty"`
+	Text    string   `json:"text"`
+	Key     string   `json:"key,omitempty"` // The issue the comment refers to; empty if untracked
+	Context []string `json:"context,omitempty"`
+}
+
+// Tracked reports whether the comment refers to an issue.
+func (t Todo) Tracked() bool {
+	return t.Key != ""
+}
+
+// Options controls what Scan looks for.
+type Options struct {
+	Tags         []string // DefaultTags if empty
+	Exclude      []string // Glob patterns matched against the relative path and base name of files and directories
+	ContextLines int      // DefaultContextLines if zero; negative for none
+}
+
+// commentPattern returns the pattern of comments with one of tags after a comment marker.
+// The groups are the tag, the text in parentheses after it and the comment text.
+func commentPattern(tags []string) *regexp.Regexp {
+	quoted := make([]string, len(tags))
+	for i, tag := range tags {
+		quoted[i] = regexp.QuoteMeta(tag)
+	}
+	return regexp.MustCompile(`(?://+|#+|/\*+|^\s*\*+|--|;+|<!--|^\s*%+)\s*(` + strings.Join(quoted, "|") + `)\b(?:\(([^)]*)\))?:?\s*(.*)$`)
+}
+
+// Scan returns the TODO-style comments of the text files under root, in file and line
+// order. Hidden directories, vendor and node_modules, binary files and files larger than
+// MaxFileSize are skipped.
+func Scan(root string, opts Options) ([]Todo, error) {
+	tags := opts.Tags
+	if len(tags) == 0 {
+		tags = DefaultTags
+	}
+	pattern := commentPattern(tags)
+	contextLines := opts.ContextLines
+	if contextLines == 0 {
+		contextLines = DefaultContextLines
+	}
+
+	var todos []Todo
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == "." {
+			return nil
+		}
+		if excluded(rel, opts.Exclude) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			if strings.HasPrefix(d.Name(), ".") || skippedDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || info.Size() > MaxFileSize {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		if bytes.IndexByte(data[:min(len(data), 8000)], 0) >= 0 {
+			return nil // Binary
+		}
+		todos = append(todos, scanFile(rel, data, pattern, contextLines)...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrScan, err)
+	}
+	return todos, nil
+}
+
+// excluded reports whether the file or directory at rel matches one of patterns.
+func excluded(rel string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, rel); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, path.Base(rel)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// scanFile returns the comments matching pattern in the file rel with content data.
+func scanFile(rel string, data []byte, pattern *regexp.Regexp, contextLines int) []Todo {
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	var todos []Todo
+	for i, line := range lines {
+		m := pattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		todo := Todo{File: rel, Line: i + 1, Tag: m[1], Owner: strings.TrimSpace(m[2]), Text: cleanText(m[3])}
+		if issueKeyRe.MatchString(todo.Owner) {
+			todo.Key = issueKeyRe.FindString(todo.Owner)
+		} else if key := issueKeyRe.FindString(todo.Text); key != "" {
+			todo.Key = key
+		}
+		if contextLines > 0 {
+			end := min(len(lines), i+1+contextLines)
+			todo.Context = lines[i+1 : end]
+		}
+		todos = append(todos, todo)
+	}
+	return todos
+}
+
+// cleanText removes the end of a block comment from the text of a comment.
+func cleanText(text string) string {
+	text = strings.TrimSpace(text)
+	for _, end := range []string{"*/", "-->"} {
+		text = strings.TrimSpace(strings.TrimSuffix(text, end))
+	}
+	return text
+}
+
+// Rewrite makes the comment todo, found by Scan under root, refer to the issue key:
+// TODO: x becomes TODO(KEY): x and TODO(ann): x becomes TODO(KEY, ann): x. It returns
+// ErrStale if the line no longer holds the comment.
+func Rewrite(root string, todo Todo, key string) error {
+	p := filepath.Join(root, filepath.FromSlash(todo.File))
+	info, err := os.Stat(p)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrRewrite, err)
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrRewrite, err)
+	}
+	lines := strings.Split(string(data), "\n")
+	if todo.Line < 1 || todo.Line > len(lines) {
+		return fmt.Errorf("%w: %s:%d", ErrStale, todo.File, todo.Line)
+	}
+	line := lines[todo.Line-1]
+	m := commentPattern([]string{todo.Tag}).FindStringSubmatchIndex(line)
+	if m == nil {
+		return fmt.Errorf("%w: %s:%d", ErrStale, todo.File, todo.Line)
+	}
+	// Replace the tag and its parentheses, if any, keeping everything else
+	tagEnd, owner := m[3], ""
+	if m[4] >= 0 {
+		tagEnd, owner = m[5]+1, strings.TrimSpace(line[m[4]:m[5]]) // After the closing parenthesis
+	}
+	if owner != todo.Owner || cleanText(line[m[6]:m[7]]) != todo.Text {
+		return fmt.Errorf("%w: %s:%d", ErrStale, todo.File, todo.Line)
+	}
+	ref := key
+	if todo.Owner != "" {
+		ref += ", " + todo.Owner
+	}
+	lines[todo.Line-1] = line[:m[2]] + todo.Tag + "(" + ref + ")" + line[tagEnd:]
+	if err := os.WriteFile(p, []byte(strings.Join(lines, "\n")), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("%w: %w", ErrRewrite, err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package todoscan
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+	}
+}
+
+func TestScan(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"main.go": "package main\n\n// TODO: retry on 429\nfunc sync() {\n\tcall()\n}\n" +
+			"// FIXME(ann): flaky on CI\n// TODO(BE-12): tracked by its owner\n// TODO see OPS-7 for details\n",
+		"web/app.py":              "x = 1  # TODO handle None\nprint('TODO: not a comment')\n",
+		"web/page.html":           "<!-- TODO: drop this banner -->\n",
+		"lib/query.sql":           "SELECT 1; -- FIXME slow on large tables\n",
+		"docs/notes.c":            "/* TODO: free the buffer */\n * TODO: second line of a block\n",
+		"vendor/dep/dep.go":       "// TODO: not ours\n",
+		".git/hooks/pre-commit":   "# TODO: hidden\n",
+		"gen/big.bin":             "\x00\x01// TODO: binary\n",
+		"web/generated.pb.go":     "// TODO: generated\n",
+		"web/HACKS.md":            "% HACK: not a default tag\n",
+		"node_modules/x/index.js": "// TODO: dependency\n",
+	})
+
+	todos, err := Scan(root, Options{Exclude: []string{"*.pb.go"}, ContextLines: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []Todo{
+		{File: "docs/notes.c", Line: 1, Tag: "TODO", Text: "free the buffer", Context: []string{" * TODO: second line of a block", ""}},
+		{File: "docs/notes.c", Line: 2, Tag: "TODO", Text: "second line of a block", Context: []string{""}},
+		{File: "lib/query.sql", Line: 1, Tag: "FIXME", Text: "slow on large tables", Context: []string{""}},
+		{File: "main.go", Line: 3, Tag: "TODO", Text: "retry on 429", Context: []string{"func sync() {", "\tcall()"}},
+		{File: "main.go", Line: 7, Tag: "FIXME", Owner: "ann", Text: "flaky on CI", Context: []string{"// TODO(BE-12): tracked by its owner", "// TODO see OPS-7 for details"}},
+		{File: "main.go", Line: 8, Tag: "TODO", Owner: "BE-12", Text: "tracked by its owner", Key: "BE-12", Context: []string{"// TODO see OPS-7 for details", ""}},
+		{File: "main.go", Line: 9, Tag: "TODO", Text: "see OPS-7 for details", Key: "OPS-7", Context: []string{""}},
+		{File: "web/app.py", Line: 1, Tag: "TODO", Text: "handle None", Context: []string{"print('TODO: not a comment')", ""}},
+		{File: "web/page.html", Line: 1, Tag: "TODO", Text: "drop this banner", Context: []string{""}},
+	}, todos)
+
+	todos, err = Scan(root, Options{Tags: []string{"HACK"}, ContextLines: -1})
+	require.NoError(t, err)
+	assert.Equal(t, []Todo{{File: "web/HACKS.md", Line: 1, Tag: "HACK", Text: "not a default tag"}}, todos)
+
+	_, err = Scan(filepath.Join(root, "missing"), Options{})
+	assert.ErrorIs(t, err, ErrScan)
+}
+
+func TestRewrite(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"main.go": "package main\r\n\r\n// TODO: retry on 429\r\nx := 1 // FIXME(ann) flaky\r\n/* TODO: free it */\r\n",
+	})
+	todos, err := Scan(root, Options{})
+	require.NoError(t, err)
+	require.Len(t, todos, 3)
+
+	require.NoError(t, Rewrite(root, todos[0], "BE-1"))
+	require.NoError(t, Rewrite(root, todos[1], "BE-2"))
+	require.NoError(t, Rewrite(root, todos[2], "BE-3"))
+	data, err := os.ReadFile(filepath.Join(root, "main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package main\r\n\r\n// TODO(BE-1): retry on 429\r\nx := 1 // FIXME(BE-2, ann) flaky\r\n/* TODO(BE-3): free it */\r\n", string(data))
+
+	assert.ErrorIs(t, Rewrite(root, todos[0], "BE-4"), ErrStale, "the comment changed")
+	stale := todos[1]
+	stale.Line = 99
+	assert.ErrorIs(t, Rewrite(root, stale, "BE-5"), ErrStale)
+}
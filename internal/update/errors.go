@@ -0,0 +1,8 @@
+package update
+
+import "errors"
+
+// Sentinel errors for release checks.
+
+// ErrCheckFailed indicates the latest release could not be retrieved.
+var ErrCheckFailed = errors.New("failed to check for a newer release")
@@ -0,0 +1,158 @@
+// Package update checks whether a newer Ticketron release has been published.
+package update
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// DefaultReleaseURL is the GitHub API endpoint describing the latest release.
+const DefaultReleaseURL = "https://api.github.com/repos/karolswdev/ticketron/releases/latest"
+
+// Release describes a published release.
+type Release struct {
+	Version string `json:"tag_name"`
+	URL     string `json:"html_url"`
+}
+
+// Result is the outcome of Check.
+type Result struct {
+	Current         string `json:"current" yaml:"current"`
+	Latest          string `json:"latest" yaml:"latest"`
+	URL             string `json:"url,omitempty" yaml:"url,omitempty"`
+	UpdateAvailable bool   `json:"update_available" yaml:"update_available"`
+}
+
+// Check fetches the latest release from releaseURL and compares it with current.
+// Development builds (versions that are not semantic versions, e.g. "dev") never
+// report an update. A nil client uses http.DefaultClient.
+func Check(ctx context.Context, client *http.Client, releaseURL, current string) (*Result, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, releaseURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCheckFailed, err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCheckFailed, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: unexpected status %d", ErrCheckFailed, resp.StatusCode)
+	}
+
+	var release Release
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&release); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCheckFailed, err)
+	}
+	if release.Version == "" {
+		return nil, fmt.Errorf("%w: release has no tag", ErrCheckFailed)
+	}
+	return &Result{
+		Current:         current,
+		Latest:          release.Version,
+		URL:             release.URL,
+		UpdateAvailable: Newer(release.Version, current),
+	}, nil
+}
+
+// Newer reports whether version a is newer than version b. Both are semantic versions
+// with an optional "v" prefix; a pre-release is older than its release, and pre-releases
+// are ordered by their dot-separated identifiers as in semver, so rc.10 is newer than rc.9.
+// It is false if either version cannot be parsed.
+func Newer(a, b string) bool {
+	va, okA := parseVersion(a)
+	vb, okB := parseVersion(b)
+	if !okA || !okB {
+		return false
+	}
+	for i := range va.core {
+		if va.core[i] != vb.core[i] {
+			return va.core[i] > vb.core[i]
+		}
+	}
+	switch {
+	case va.pre == vb.pre:
+		return false
+	case va.pre == "":
+		return true
+	case vb.pre == "":
+		return false
+	}
+	return comparePrerelease(va.pre, vb.pre) > 0
+}
+
+// comparePrerelease compares the pre-release versions a and b as semver does, returning
+// -1, 0 or +1. Identifiers are compared left to right: numeric ones as numbers, others
+// lexically in ASCII order, and numeric ones are lower than others. If all identifiers of
+// the shorter version are equal, it is the lower one.
+func comparePrerelease(a, b string) int {
+	idsA, idsB := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(idsA) && i < len(idsB); i++ {
+		x, y := idsA[i], idsB[i]
+		numX, numY := isNumeric(x), isNumeric(y)
+		switch {
+		case numX && numY:
+			x, y = strings.TrimLeft(x, "0"), strings.TrimLeft(y, "0")
+			if len(x) != len(y) {
+				return cmp.Compare(len(x), len(y))
+			}
+		case numX:
+			return -1
+		case numY:
+			return 1
+		}
+		if c := strings.Compare(x, y); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(idsA), len(idsB))
+}
+
+// isNumeric reports whether the pre-release identifier s consists of digits only.
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// semver is a parsed MAJOR.MINOR.PATCH[-PRERELEASE] version; build metadata is ignored.
+type semver struct {
+	core [3]int
+	pre  string
+}
+
+// parseVersion parses v, which may start with "v" and omit the minor or patch number.
+func parseVersion(v string) (semver, bool) {
+	var parsed semver
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	v, _, _ = strings.Cut(v, "+")
+	v, parsed.pre, _ = strings.Cut(v, "-")
+	parts := strings.Split(v, ".")
+	if len(parts) > 3 {
+		return parsed, false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return parsed, false
+		}
+		parsed.core[i] = n
+	}
+	return parsed, true
+}
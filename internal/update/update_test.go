@@ -0,0 +1,79 @@
+package update
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewer(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"v0.2.0", "0.1.0", true},
+		{"v0.1.0", "v0.1.0", false},
+		{"v0.1.0", "v0.2.0", false},
+		{"v1.10.0", "v1.9.3", true},
+		{"v1.0.0", "v1.0.0-rc.1", true},
+		{"v1.0.0-rc.2", "v1.0.0-rc.1", true},
+		{"v1.0.0-rc.1", "v1.0.0", false},
+		{"v1.0.0-rc.10", "v1.0.0-rc.9", true},
+		{"v1.0.0-rc.9", "v1.0.0-rc.10", false},
+		{"v1.0.0-rc.1.1", "v1.0.0-rc.1", true},
+		{"v1.0.0-beta", "v1.0.0-alpha.2", true},
+		{"v1.0.0-alpha.beta", "v1.0.0-alpha.1", true},
+		{"v1.0.0-alpha.1", "v1.0.0-alpha.beta", false},
+		{"v1.1", "v1.0.9", true},
+		{"v1.0.0+build.5", "v1.0.0", false},
+		{"v1.0.0", "dev", false},
+		{"latest", "v1.0.0", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Newer(tt.a, tt.b), "Newer(%q, %q)", tt.a, tt.b)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/vnd.github+json", r.Header.Get("Accept"))
+		w.Write([]byte(`{"tag_name": "v0.3.0", "html_url": "https://github.com/karolswdev/ticketron/releases/tag/v0.3.0"}`))
+	}))
+	defer server.Close()
+
+	result, err := Check(context.Background(), server.Client(), server.URL, "v0.2.1")
+	require.NoError(t, err)
+	assert.Equal(t, &Result{
+		Current:         "v0.2.1",
+		Latest:          "v0.3.0",
+		URL:             "https://github.com/karolswdev/ticketron/releases/tag/v0.3.0",
+		UpdateAvailable: true,
+	}, result)
+
+	result, err = Check(context.Background(), server.Client(), server.URL, "dev")
+	require.NoError(t, err)
+	assert.False(t, result.UpdateAvailable)
+}
+
+func TestCheck_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	_, err := Check(context.Background(), server.Client(), server.URL+"/missing", "v0.1.0")
+	assert.ErrorIs(t, err, ErrCheckFailed)
+	assert.ErrorContains(t, err, "404")
+
+	_, err = Check(context.Background(), server.Client(), server.URL, "v0.1.0")
+	assert.ErrorIs(t, err, ErrCheckFailed)
+	assert.ErrorContains(t, err, "no tag")
+}
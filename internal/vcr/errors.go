@@ -0,0 +1,14 @@
+package vcr
+
+import "errors"
+
+// Sentinel errors for recording and replaying HTTP interactions.
+
+// ErrBothModes indicates both TICKETRON_RECORD and TICKETRON_REPLAY are set.
+var ErrBothModes = errors.New("TICKETRON_RECORD and TICKETRON_REPLAY cannot be used together")
+
+// ErrCassette indicates the cassette file could not be read, parsed or written.
+var ErrCassette = errors.New("cassette error")
+
+// ErrNoInteraction indicates a replayed request has no unused matching interaction in the cassette.
+var ErrNoInteraction = errors.New("no recorded interaction matches the request")
@@ -0,0 +1,223 @@
+// Package vcr records the HTTP interactions of the LLM and MCP clients to a YAML cassette
+// and replays them later without network access, for integration tests and offline demos.
+// It works at the transport level, so every client built on net/http is covered.
+//
+// Request headers are never recorded, as they carry the API key and credentials. Request
+// and response bodies are recorded as sent.
+package vcr
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Environment variables selecting the mode; each holds the cassette path.
+const (
+	EnvRecord = "TICKETRON_RECORD"
+	EnvReplay = "TICKETRON_REPLAY"
+)
+
+// Mode selects whether a Recorder records or replays.
+type Mode int
+
+const (
+	// ModeRecord forwards requests and appends each interaction to the cassette.
+	ModeRecord Mode = iota
+	// ModeReplay answers requests from the cassette without network access.
+	ModeReplay
+)
+
+// Request is the recorded part of an HTTP request.
+type Request struct {
+	Method string `yaml:"method"`
+	URL    string `yaml:"url"`
+	Body   string `yaml:"body,omitempty"`
+}
+
+// Response is the recorded part of an HTTP response.
+type Response struct {
+	Status      int    `yaml:"status"`
+	ContentType string `yaml:"content_type,omitempty"`
+	Body        string `yaml:"body,omitempty"`
+}
+
+// Interaction is one request with its response.
+type Interaction struct {
+	Request  Request  `yaml:"request"`
+	Response Response `yaml:"response"`
+}
+
+// Cassette is the file format: the interactions in the order they happened.
+type Cassette struct {
+	Interactions []Interaction `yaml:"interactions"`
+}
+
+// Recorder records or replays HTTP interactions. It is safe for concurrent use, so one
+// Recorder can be shared by all clients of a process.
+type Recorder struct {
+	mode Mode
+	path string
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool // Interactions already replayed
+}
+
+// New returns a Recorder for the cassette at path. In ModeReplay the cassette is loaded
+// now; in ModeRecord it is (re)written after every interaction.
+func New(mode Mode, path string) (*Recorder, error) {
+	r := &Recorder{mode: mode, path: path}
+	if mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrCassette, err)
+		}
+		if err := yaml.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("%w: failed to parse %s: %w", ErrCassette, path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+	return r, nil
+}
+
+// FromEnv returns the Recorder selected by TICKETRON_RECORD or TICKETRON_REPLAY, or nil if
+// neither is set.
+func FromEnv() (*Recorder, error) {
+	record, replay := os.Getenv(EnvRecord), os.Getenv(EnvReplay)
+	switch {
+	case record != "" && replay != "":
+		return nil, ErrBothModes
+	case record != "":
+		return New(ModeRecord, record)
+	case replay != "":
+		return New(ModeReplay, replay)
+	default:
+		return nil, nil
+	}
+}
+
+// Mode returns the mode of the Recorder.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Wrap returns a RoundTripper that records the interactions of next, or replays them
+// without calling next. A nil next uses http.DefaultTransport.
+func (r *Recorder) Wrap(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &transport{recorder: r, next: next}
+}
+
+// transport is the RoundTripper returned by Wrap.
+type transport struct {
+	recorder *Recorder
+	next     http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := captureRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if t.recorder.mode == ModeReplay {
+		resp, err := t.recorder.replay(recorded)
+		if err != nil {
+			return nil, err
+		}
+		return resp.toHTTP(req), nil
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	interaction := Interaction{
+		Request:  recorded,
+		Response: Response{Status: resp.StatusCode, ContentType: resp.Header.Get("Content-Type"), Body: string(body)},
+	}
+	if err := t.recorder.record(interaction); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// captureRequest reads the request body, leaving req readable for the real transport.
+func captureRequest(req *http.Request) (Request, error) {
+	recorded := Request{Method: req.Method, URL: req.URL.String()}
+	if req.Body == nil || req.Body == http.NoBody {
+		return recorded, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return Request{}, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	recorded.Body = string(body)
+	return recorded, nil
+}
+
+// record appends interaction and rewrites the cassette, so it is complete even if the
+// process exits without cleanup.
+func (r *Recorder) record(interaction Interaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	data, err := yaml.Marshal(r.cassette)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCassette, err)
+	}
+	if err := os.WriteFile(r.path, data, 0600); err != nil {
+		return fmt.Errorf("%w: %w", ErrCassette, err)
+	}
+	return nil
+}
+
+// replay returns the response of the first unused interaction matching req. Requests match
+// on method, URL and body, so repeated identical requests replay in recorded order.
+func (r *Recorder) replay(req Request) (Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, interaction := range r.cassette.Interactions {
+		if !r.used[i] && interaction.Request == req {
+			r.used[i] = true
+			return interaction.Response, nil
+		}
+	}
+	return Response{}, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, req.URL)
+}
+
+// toHTTP builds the http.Response for req.
+func (resp Response) toHTTP(req *http.Request) *http.Response {
+	header := http.Header{}
+	if resp.ContentType != "" {
+		header.Set("Content-Type", resp.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", resp.Status, http.StatusText(resp.Status)),
+		StatusCode:    resp.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(resp.Body)),
+		ContentLength: int64(len(resp.Body)),
+		Request:       req,
+	}
+}
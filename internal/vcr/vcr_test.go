@@ -0,0 +1,95 @@
+package vcr
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func doRequest(t *testing.T, client *http.Client, method, url, body string) (int, string, error) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer secret-key")
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(data), nil
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+		}
+		io.WriteString(w, `{"echo": "`+r.Method+string(body)+`"}`)
+	}))
+	cassette := filepath.Join(t.TempDir(), "cassette.yaml")
+
+	recorder, err := New(ModeRecord, cassette)
+	require.NoError(t, err)
+	client := &http.Client{Transport: recorder.Wrap(nil)}
+	status, body, err := doRequest(t, client, http.MethodPost, server.URL+"/issue", "create")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, `{"echo": "POSTcreate"}`, body)
+	_, _, err = doRequest(t, client, http.MethodGet, server.URL+"/issue/BE-1", "")
+	require.NoError(t, err)
+	server.Close()
+
+	data, err := os.ReadFile(cassette)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret-key", "request headers are not recorded")
+
+	replayer, err := New(ModeReplay, cassette)
+	require.NoError(t, err)
+	client = &http.Client{Transport: replayer.Wrap(nil)}
+	status, body, err = doRequest(t, client, http.MethodPost, server.URL+"/issue", "create")
+	require.NoError(t, err, "the server is closed, so the response must come from the cassette")
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, `{"echo": "POSTcreate"}`, body)
+
+	_, _, err = doRequest(t, client, http.MethodPost, server.URL+"/issue", "create")
+	assert.ErrorIs(t, err, ErrNoInteraction, "each interaction is replayed once")
+	_, _, err = doRequest(t, client, http.MethodPost, server.URL+"/issue", "other body")
+	assert.ErrorIs(t, err, ErrNoInteraction)
+	status, _, err = doRequest(t, client, http.MethodGet, server.URL+"/issue/BE-1", "")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestFromEnv(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "cassette.yaml")
+
+	t.Setenv(EnvRecord, "")
+	t.Setenv(EnvReplay, "")
+	recorder, err := FromEnv()
+	require.NoError(t, err)
+	assert.Nil(t, recorder)
+
+	t.Setenv(EnvRecord, cassette)
+	recorder, err = FromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, ModeRecord, recorder.Mode())
+
+	t.Setenv(EnvReplay, cassette)
+	_, err = FromEnv()
+	assert.ErrorIs(t, err, ErrBothModes)
+
+	t.Setenv(EnvRecord, "")
+	_, err = FromEnv()
+	assert.ErrorIs(t, err, ErrCassette, "a cassette to replay must exist")
+}
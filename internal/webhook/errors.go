@@ -0,0 +1,17 @@
+package webhook
+
+import "errors"
+
+// Sentinel errors for webhook mapping operations.
+
+// ErrConfigRead indicates an error occurred while reading the webhooks file.
+var ErrConfigRead = errors.New("failed to read webhooks file")
+
+// ErrConfigParse indicates an error occurred while parsing the webhooks file.
+var ErrConfigParse = errors.New("failed to parse webhooks file")
+
+// ErrInvalidMapping indicates a webhook mapping is incomplete or contains invalid templates.
+var ErrInvalidMapping = errors.New("invalid webhook mapping")
+
+// ErrRender indicates a mapping template failed to render against a payload.
+var ErrRender = errors.New("failed to render webhook template")
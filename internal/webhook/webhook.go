@@ -0,0 +1,218 @@
+// Package webhook converts inbound webhook payloads (e.g. from Alertmanager or Sentry)
+// into ticket creation inputs using user-defined mappings from webhooks.yaml.
+package webhook
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+)
+
+// Mapping describes how payloads received on /webhooks/{name} become a ticket.
+// In direct mode (UseLLM false) Project and Summary are required and the templates
+// are rendered straight into the issue fields. In LLM mode the Input template is rendered
+// and processed by the LLM like `tix create` input; Project and IssueType, if set, still
+// override what the LLM suggests.
+type Mapping struct {
+	Name        string `yaml:"name"`
+	Token       string `yaml:"token,omitempty"`       // Optional shared secret required in the X-Ticketron-Token header
+	Project     string `yaml:"project,omitempty"`     // JIRA project key
+	IssueType   string `yaml:"issue_type,omitempty"`  // JIRA issue type
+	Summary     string `yaml:"summary,omitempty"`     // Template for the summary (direct mode)
+	Description string `yaml:"description,omitempty"` // Template for the description (direct mode)
+	UseLLM      bool   `yaml:"use_llm,omitempty"`     // Process the rendered Input through the LLM
+	Input       string `yaml:"input,omitempty"`       // Template for the LLM input (LLM mode)
+}
+
+// Config holds all webhook mappings.
+type Config struct {
+	Webhooks    []Mapping    `yaml:"webhooks"`
+	QuickCreate *QuickCreate `yaml:"quick_create,omitempty"` // The /quick-create endpoint is disabled if nil
+}
+
+// QuickCreate configures the /quick-create endpoint of tix serve, which lets a bookmarklet
+// or browser extension create an issue from selected page text and the page URL. The
+// endpoint answers cross-origin requests from AllowedOrigins and requires Token.
+type QuickCreate struct {
+	Token          string   `yaml:"token"`                     // Required shared secret
+	AllowedOrigins []string `yaml:"allowed_origins,omitempty"` // Origins such as https://wiki.example.com; "*" for any, the default
+	Project        string   `yaml:"project,omitempty"`         // JIRA project key; the LLM's suggestion or default_project if empty
+	IssueType      string   `yaml:"issue_type,omitempty"`      // JIRA issue type
+	UseLLM         bool     `yaml:"use_llm,omitempty"`         // Let the LLM write the ticket from the selection
+}
+
+// Authorize reports whether token matches the configured token.
+func (q *QuickCreate) Authorize(token string) bool {
+	return q.Token != "" && subtle.ConstantTimeCompare([]byte(q.Token), []byte(token)) == 1
+}
+
+// AllowsOrigin reports whether pages of origin may call the endpoint.
+func (q *QuickCreate) AllowsOrigin(origin string) bool {
+	if len(q.AllowedOrigins) == 0 {
+		return true
+	}
+	for _, allowed := range q.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(strings.TrimRight(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// Rendered holds the result of applying a mapping's templates to a payload.
+type Rendered struct {
+	Summary     string
+	Description string
+	Input       string
+}
+
+// LoadConfig reads webhook mappings from path. A missing file yields an empty Config.
+// The loaded configuration is validated before being returned.
+func LoadConfig(path string) (*Config, error) {
+	cfg := &Config{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			log.Warn().Str("path", path).Msg("Webhooks file not found, no webhooks configured")
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("%w: %w", ErrConfigRead, err)
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrConfigParse, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	log.Debug().Str("path", path).Int("webhooks", len(cfg.Webhooks)).Msg("Loaded webhook mappings")
+	return cfg, nil
+}
+
+// Validate checks that mapping names are unique, that each mapping has the fields its
+// mode requires and that all templates parse, and that quick_create has a token.
+func (c *Config) Validate() error {
+	if c.QuickCreate != nil && c.QuickCreate.Token == "" {
+		return fmt.Errorf("%w: quick_create needs a 'token'", ErrInvalidMapping)
+	}
+	seen := make(map[string]bool)
+	for i, m := range c.Webhooks {
+		if m.Name == "" {
+			return fmt.Errorf("%w: webhook #%d has no name", ErrInvalidMapping, i+1)
+		}
+		if seen[m.Name] {
+			return fmt.Errorf("%w: duplicate webhook name %q", ErrInvalidMapping, m.Name)
+		}
+		seen[m.Name] = true
+
+		if m.UseLLM {
+			if m.Input == "" {
+				return fmt.Errorf("%w: webhook %q uses the LLM but has no 'input' template", ErrInvalidMapping, m.Name)
+			}
+		} else if m.Project == "" || m.Summary == "" {
+			return fmt.Errorf("%w: webhook %q needs 'project' and 'summary' (or use_llm with 'input')", ErrInvalidMapping, m.Name)
+		}
+
+		for field, text := range map[string]string{"summary": m.Summary, "description": m.Description, "input": m.Input} {
+			if _, err := parseTemplate(field, text); err != nil {
+				return fmt.Errorf("%w: webhook %q has an invalid %s template: %w", ErrInvalidMapping, m.Name, field, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Find returns the mapping with the given name.
+func (c *Config) Find(name string) (*Mapping, bool) {
+	for i := range c.Webhooks {
+		if c.Webhooks[i].Name == name {
+			return &c.Webhooks[i], true
+		}
+	}
+	return nil, false
+}
+
+// Authorize reports whether token matches the mapping's configured token.
+// Mappings without a token accept any request.
+func (m *Mapping) Authorize(token string) bool {
+	if m.Token == "" {
+		return true
+	}
+	return subtle.ConstantTimeCompare([]byte(m.Token), []byte(token)) == 1
+}
+
+// Render applies the mapping's templates to the decoded JSON payload.
+func (m *Mapping) Render(payload interface{}) (Rendered, error) {
+	var r Rendered
+	var err error
+	if r.Summary, err = execute("summary", m.Summary, payload); err != nil {
+		return r, err
+	}
+	if r.Description, err = execute("description", m.Description, payload); err != nil {
+		return r, err
+	}
+	if r.Input, err = execute("input", m.Input, payload); err != nil {
+		return r, err
+	}
+	r.Summary = strings.TrimSpace(r.Summary)
+	return r, nil
+}
+
+// templateFuncs are the helper functions available in mapping templates.
+var templateFuncs = template.FuncMap{
+	"default": func(def, v interface{}) interface{} {
+		if v == nil || v == "" {
+			return def
+		}
+		return v
+	},
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"truncate": func(n int, s string) string {
+		runes := []rune(s)
+		if len(runes) <= n {
+			return s
+		}
+		return string(runes[:n])
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// TemplateFuncs returns the helper functions available in mapping templates, for other
+// packages rendering payloads with the same templates.
+func TemplateFuncs() template.FuncMap {
+	funcs := make(template.FuncMap, len(templateFuncs))
+	for name, fn := range templateFuncs {
+		funcs[name] = fn
+	}
+	return funcs
+}
+
+func parseTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(templateFuncs).Option("missingkey=zero").Parse(text)
+}
+
+func execute(name, text string, payload interface{}) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+	tmpl, err := parseTemplate(name, text)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s: %w", ErrRender, name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, payload); err != nil {
+		return "", fmt.Errorf("%w: %s: %w", ErrRender, name, err)
+	}
+	// Missing map keys render as "<no value>"; treat them as empty.
+	return strings.ReplaceAll(buf.String(), "<no value>", ""), nil
+}
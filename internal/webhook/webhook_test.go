@@ -0,0 +1,126 @@
+package webhook
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const alertmanagerPayload = `{
+  "status": "firing",
+  "commonLabels": {"alertname": "HighErrorRate", "service": "checkout"},
+  "commonAnnotations": {"description": "5xx rate above 5%"}
+}`
+
+func decode(t *testing.T, raw string) interface{} {
+	t.Helper()
+	var payload interface{}
+	require.NoError(t, json.Unmarshal([]byte(raw), &payload))
+	return payload
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("Missing file", func(t *testing.T) {
+		cfg, err := LoadConfig(filepath.Join(dir, "missing.yaml"))
+		require.NoError(t, err)
+		assert.Empty(t, cfg.Webhooks)
+	})
+
+	t.Run("Valid file", func(t *testing.T) {
+		path := filepath.Join(dir, "webhooks.yaml")
+		content := `
+webhooks:
+  - name: alertmanager
+    project: OPS
+    issue_type: Bug
+    summary: "{{ .commonLabels.alertname }} on {{ .commonLabels.service }}"
+`
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+		cfg, err := LoadConfig(path)
+		require.NoError(t, err)
+		m, ok := cfg.Find("alertmanager")
+		require.True(t, ok)
+		assert.Equal(t, "OPS", m.Project)
+	})
+
+	t.Run("Invalid YAML", func(t *testing.T) {
+		path := filepath.Join(dir, "bad.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("webhooks: [unclosed"), 0600))
+		_, err := LoadConfig(path)
+		assert.ErrorIs(t, err, ErrConfigParse)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		ok   bool
+	}{
+		{"direct mode valid", Config{Webhooks: []Mapping{{Name: "a", Project: "P", Summary: "s"}}}, true},
+		{"llm mode valid", Config{Webhooks: []Mapping{{Name: "a", UseLLM: true, Input: "{{ .x }}"}}}, true},
+		{"missing name", Config{Webhooks: []Mapping{{Project: "P", Summary: "s"}}}, false},
+		{"duplicate name", Config{Webhooks: []Mapping{{Name: "a", Project: "P", Summary: "s"}, {Name: "a", Project: "P", Summary: "s"}}}, false},
+		{"direct mode missing project", Config{Webhooks: []Mapping{{Name: "a", Summary: "s"}}}, false},
+		{"llm mode missing input", Config{Webhooks: []Mapping{{Name: "a", UseLLM: true}}}, false},
+		{"bad template", Config{Webhooks: []Mapping{{Name: "a", Project: "P", Summary: "{{ .x "}}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.ok {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrInvalidMapping)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	m := Mapping{
+		Name:        "alertmanager",
+		Summary:     "[{{ upper .status }}] {{ .commonLabels.alertname }}",
+		Description: "{{ .commonAnnotations.description }}\nRunbook: {{ default \"n/a\" .commonAnnotations.runbook }}\nOwner: {{ .commonLabels.owner }}",
+		Input:       "Alert {{ truncate 4 .commonLabels.alertname }}",
+	}
+	r, err := m.Render(decode(t, alertmanagerPayload))
+	require.NoError(t, err)
+	assert.Equal(t, "[FIRING] HighErrorRate", r.Summary)
+	assert.Equal(t, "5xx rate above 5%\nRunbook: n/a\nOwner: ", r.Description)
+	assert.Equal(t, "Alert High", r.Input)
+}
+
+func TestAuthorize(t *testing.T) {
+	open := Mapping{Name: "a"}
+	assert.True(t, open.Authorize(""))
+
+	secured := Mapping{Name: "b", Token: "s3cret"}
+	assert.True(t, secured.Authorize("s3cret"))
+	assert.False(t, secured.Authorize("wrong"))
+	assert.False(t, secured.Authorize(""))
+}
+
+func TestQuickCreate(t *testing.T) {
+	cfg := &Config{QuickCreate: &QuickCreate{}}
+	assert.ErrorIs(t, cfg.Validate(), ErrInvalidMapping, "a token is required")
+
+	q := &QuickCreate{Token: "s3cret"}
+	require.NoError(t, (&Config{QuickCreate: q}).Validate())
+	assert.True(t, q.Authorize("s3cret"))
+	assert.False(t, q.Authorize(""))
+	assert.False(t, q.Authorize("wrong"))
+	assert.True(t, q.AllowsOrigin("https://any.example"), "any origin by default")
+
+	q.AllowedOrigins = []string{"https://wiki.example.com/"}
+	assert.True(t, q.AllowsOrigin("https://wiki.example.com"))
+	assert.False(t, q.AllowsOrigin("https://evil.example"))
+	q.AllowedOrigins = append(q.AllowedOrigins, "*")
+	assert.True(t, q.AllowsOrigin("https://evil.example"))
+}
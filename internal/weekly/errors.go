@@ -0,0 +1,8 @@
+package weekly
+
+import "errors"
+
+// Sentinel errors for activity reports.
+
+// ErrTemplate indicates the prompt template could not be parsed or executed.
+var ErrTemplate = errors.New("invalid weekly report template")
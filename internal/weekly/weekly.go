@@ -0,0 +1,108 @@
+// Package weekly builds activity reports: the issues created and closed in a period, the
+// prompt asking the LLM to summarize them, and a plain Markdown report.
+package weekly
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+	"github.com/karolswdev/ticketron/internal/sanitize"
+)
+
+// SystemPrompt is sent with the system role before the rendered template.
+const SystemPrompt = "You are a team lead. You write concise, factual activity reports in Markdown from a list of issues."
+
+// DefaultTemplate is the prompt template used unless it is overridden. It is executed
+// with a Data value.
+//
+//go:embed prompt.tmpl
+var DefaultTemplate string
+
+// Item is an issue as it is listed in a report.
+type Item struct {
+	Key      string `json:"key" yaml:"key"`
+	Summary  string `json:"summary" yaml:"summary"`
+	Type     string `json:"type" yaml:"type"`
+	Status   string `json:"status" yaml:"status"`
+	Assignee string `json:"assignee,omitempty" yaml:"assignee,omitempty"`
+}
+
+// Data is what the prompt template is executed with.
+type Data struct {
+	Scope   string    // Whose issues are covered, e.g. "you" or "the BE project"
+	Since   time.Time // Start of the period
+	Until   time.Time // End of the period
+	Created []Item    // Issues created in the period
+	Closed  []Item    // Issues resolved in the period
+}
+
+// Period returns the period as "2025-04-09 to 2025-04-16".
+func (d Data) Period() string {
+	return d.Since.Format(time.DateOnly) + " to " + d.Until.Format(time.DateOnly)
+}
+
+// Build converts the created and closed issues of a period into report data.
+func Build(scope string, since, until time.Time, created, closed []mcpclient.Issue) Data {
+	return Data{Scope: scope, Since: since, Until: until, Created: items(created), Closed: items(closed)}
+}
+
+func items(issues []mcpclient.Issue) []Item {
+	result := make([]Item, 0, len(issues))
+	for _, issue := range issues {
+		item := Item{
+			Key:     issue.Key,
+			Summary: sanitize.Line(issue.Fields.Summary),
+			Type:    sanitize.Line(issue.Fields.IssueType.Name),
+			Status:  sanitize.Line(issue.Fields.Status.Name),
+		}
+		if issue.Fields.Assignee != nil {
+			item.Assignee = sanitize.Line(issue.Fields.Assignee.DisplayName)
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+// RenderPrompt executes the prompt template text with data.
+func RenderPrompt(text string, data Data) (string, error) {
+	tmpl, err := template.New("weekly-report").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrTemplate, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrTemplate, err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// Markdown renders the report without the LLM: the closed and the created issues as lists.
+func Markdown(data Data) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Activity report: %s\n\n", data.Period())
+	fmt.Fprintf(&b, "%d issue(s) closed and %d created for %s.\n", len(data.Closed), len(data.Created), data.Scope)
+	section := func(title string, items []Item, withStatus bool) {
+		if len(items) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n", title)
+		for _, item := range items {
+			fmt.Fprintf(&b, "- %s (%s", item.Summary, item.Key)
+			if withStatus {
+				fmt.Fprintf(&b, ", %s", item.Status)
+			}
+			if item.Assignee != "" {
+				fmt.Fprintf(&b, ", %s", item.Assignee)
+			}
+			b.WriteString(")\n")
+		}
+	}
+	section("Done", data.Closed, false)
+	section("New", data.Created, true)
+	return strings.TrimRight(b.String(), "\n")
+}
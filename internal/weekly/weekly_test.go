@@ -0,0 +1,75 @@
+package weekly
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karolswdev/ticketron/internal/mcpclient"
+)
+
+func testData() Data {
+	issue := func(key, issueType, status, summary string, assignee *mcpclient.User) mcpclient.Issue {
+		return mcpclient.Issue{Key: key, Fields: mcpclient.IssueFields{
+			Summary:   summary,
+			IssueType: mcpclient.IssueType{Name: issueType},
+			Status:    mcpclient.Status{Name: status},
+			Assignee:  assignee,
+		}}
+	}
+	since := time.Date(2025, time.April, 9, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2025, time.April, 16, 14, 0, 0, 0, time.UTC)
+	return Build("you", since, until,
+		[]mcpclient.Issue{issue("BE-3", "Task", "To Do", "Bump Go", nil)},
+		[]mcpclient.Issue{issue("BE-1", "Bug", "Done", "Fix\tlogin", &mcpclient.User{DisplayName: "Ada"})},
+	)
+}
+
+func TestBuild(t *testing.T) {
+	data := testData()
+	assert.Equal(t, "2025-04-09 to 2025-04-16", data.Period())
+	assert.Equal(t, []Item{{Key: "BE-3", Summary: "Bump Go", Type: "Task", Status: "To Do"}}, data.Created)
+	assert.Equal(t, []Item{{Key: "BE-1", Summary: "Fix login", Type: "Bug", Status: "Done", Assignee: "Ada"}}, data.Closed)
+
+	empty := Build("you", data.Since, data.Until, nil, nil)
+	assert.NotNil(t, empty.Created, "empty lists are encoded as [] rather than null")
+}
+
+func TestRenderPrompt(t *testing.T) {
+	prompt, err := RenderPrompt(DefaultTemplate, testData())
+	require.NoError(t, err)
+	assert.Contains(t, prompt, "for you covering 2025-04-09 to 2025-04-16")
+	assert.Contains(t, prompt, "Closed issues (1):\n- BE-1 [Bug] Fix login (Ada)\n\nCreated issues (1):\n- BE-3 [Task, To Do] Bump Go")
+
+	data := testData()
+	data.Created = nil
+	prompt, err = RenderPrompt(DefaultTemplate, data)
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(prompt, "Created issues (0):\nnone"), prompt)
+
+	_, err = RenderPrompt("{{.Missing}}", data)
+	assert.ErrorIs(t, err, ErrTemplate)
+	_, err = RenderPrompt("{{if}}", data)
+	assert.ErrorIs(t, err, ErrTemplate)
+}
+
+func TestMarkdown(t *testing.T) {
+	assert.Equal(t, `# Activity report: 2025-04-09 to 2025-04-16
+
+1 issue(s) closed and 1 created for you.
+
+## Done
+
+- Fix login (BE-1, Ada)
+
+## New
+
+- Bump Go (BE-3, To Do)`, Markdown(testData()))
+
+	data := testData()
+	data.Closed = nil
+	assert.NotContains(t, Markdown(data), "## Done")
+}
@@ -18,15 +18,6 @@ import (
 	"github.com/karolswdev/ticketron/internal/config"
 )
 
-// mockLLMServer creates a mock HTTP server simulating the LLM API.
-// It takes a handler function to define the mock response.
-func mockLLMServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
-	t.Helper()
-	server := httptest.NewServer(handler)
-	t.Cleanup(server.Close)
-	return server
-}
-
 // mockMCPServer creates a mock HTTP server simulating the jira-mcp-server API.
 // It takes a handler function to define the mock response.
 func mockMCPServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
@@ -37,25 +28,23 @@ func mockMCPServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
 }
 
 // setupTestEnvironment creates a temporary directory for configuration files,
-// writes a basic config.yaml pointing to mock servers, and returns the path
-// to the temporary directory and a cleanup function.
-func setupTestEnvironment(t *testing.T, llmURL, mcpURL string) (string, func()) {
+// writes a basic config.yaml pointing to the mock MCP server and selecting the
+// deterministic mock LLM provider, and returns the path to the temporary directory
+// and a cleanup function.
+func setupTestEnvironment(t *testing.T, mcpURL string) (string, func()) {
 	t.Helper()
 	tempDir, err := os.MkdirTemp("", "ticketron-integration-test-*")
 	if err != nil {
 		t.Fatalf("Failed to create temp dir: %v", err)
 	}
 
-	// Create a basic config file pointing to the mock servers
+	// Create a basic config file pointing to the mock MCP server
 	// Ensure precise YAML indentation
 	configContent := fmt.Sprintf(`
 mcp_server_url: %s
 llm:
-  provider: "openai"
-  openai:
-    model_name: "test-model"
-    base_url: "%s"
-`, mcpURL, llmURL) // Provide both URLs, llmURL might be empty
+  provider: "mock"
+`, mcpURL)
 
 	configPath := filepath.Join(tempDir, config.DefaultConfigFileName)
 	err = os.WriteFile(configPath, []byte(configContent), 0600)
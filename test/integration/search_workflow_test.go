@@ -65,7 +65,7 @@ func commonSearchTestSetup(t *testing.T) (cleanup func()) {
 	})
 
 	// --- Setup Test Environment ---
-	_, cleanupEnv := setupTestEnvironment(t, "", mockMCP.URL) // No LLM needed
+	_, cleanupEnv := setupTestEnvironment(t, mockMCP.URL)
 
 	// --- Initial Config Init ---
 	// Run config init once for the setup